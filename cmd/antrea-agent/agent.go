@@ -17,6 +17,7 @@ package main
 import (
 	"fmt"
 	"net"
+	"path/filepath"
 	"time"
 
 	"k8s.io/client-go/informers"
@@ -25,20 +26,31 @@ import (
 	"github.com/vmware-tanzu/antrea/pkg/agent"
 	"github.com/vmware-tanzu/antrea/pkg/agent/apiserver"
 	"github.com/vmware-tanzu/antrea/pkg/agent/cniserver"
-	_ "github.com/vmware-tanzu/antrea/pkg/agent/cniserver/ipam"
+	"github.com/vmware-tanzu/antrea/pkg/agent/cniserver/ipam"
 	"github.com/vmware-tanzu/antrea/pkg/agent/config"
+	"github.com/vmware-tanzu/antrea/pkg/agent/controller/egress"
+	"github.com/vmware-tanzu/antrea/pkg/agent/controller/ipseccertificate"
 	"github.com/vmware-tanzu/antrea/pkg/agent/controller/networkpolicy"
+	"github.com/vmware-tanzu/antrea/pkg/agent/controller/nodeportlocal"
 	"github.com/vmware-tanzu/antrea/pkg/agent/controller/noderoute"
+	"github.com/vmware-tanzu/antrea/pkg/agent/controller/traceflow"
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter/connections"
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter/exporter"
 	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
 	"github.com/vmware-tanzu/antrea/pkg/agent/metrics"
 	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+	"github.com/vmware-tanzu/antrea/pkg/agent/proxy"
 	"github.com/vmware-tanzu/antrea/pkg/agent/querier"
 	"github.com/vmware-tanzu/antrea/pkg/agent/route"
 	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+	crdinformers "github.com/vmware-tanzu/antrea/pkg/client/informers/externalversions"
+	ipaminformers "github.com/vmware-tanzu/antrea/pkg/client/informers/externalversions/ipam/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/features"
 	"github.com/vmware-tanzu/antrea/pkg/k8s"
 	"github.com/vmware-tanzu/antrea/pkg/monitor"
 	ofconfig "github.com/vmware-tanzu/antrea/pkg/ovs/openflow"
 	"github.com/vmware-tanzu/antrea/pkg/ovs/ovsconfig"
+	"github.com/vmware-tanzu/antrea/pkg/ovs/ovsctl"
 	"github.com/vmware-tanzu/antrea/pkg/signals"
 	"github.com/vmware-tanzu/antrea/pkg/version"
 )
@@ -48,6 +60,10 @@ import (
 // https://github.com/kubernetes/kubernetes/blob/release-1.17/pkg/controller/apis/config/v1alpha1/defaults.go#L120
 const informerDefaultResync = 12 * time.Hour
 
+// ipsecCertDirName is the directory under the OVS run directory storing the IPSec credentials, which is
+// shared with the antrea-ipsec container.
+const ipsecCertDirName = "ipsec"
+
 // run starts Antrea agent with the given options and waits for termination signal.
 func run(o *Options) error {
 	klog.Infof("Starting Antrea agent (version %s)", version.GetFullVersion())
@@ -57,6 +73,7 @@ func run(o *Options) error {
 		return fmt.Errorf("error creating K8s clients: %v", err)
 	}
 	informerFactory := informers.NewSharedInformerFactory(k8sClient, informerDefaultResync)
+	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, informerDefaultResync)
 
 	// Create Antrea Clientset for the given config.
 	antreaClientProvider := agent.NewAntreaClientProvider(o.config.AntreaClientConnection, k8sClient)
@@ -80,9 +97,12 @@ func run(o *Options) error {
 	_, serviceCIDRNet, _ := net.ParseCIDR(o.config.ServiceCIDR)
 	_, encapMode := config.GetTrafficEncapModeFromStr(o.config.TrafficEncapMode)
 	networkConfig := &config.NetworkConfig{
-		TunnelType:        ovsconfig.TunnelType(o.config.TunnelType),
-		TrafficEncapMode:  encapMode,
-		EnableIPSecTunnel: o.config.EnableIPSecTunnel}
+		TunnelType:              ovsconfig.TunnelType(o.config.TunnelType),
+		TrafficEncapMode:        encapMode,
+		EnableIPSecTunnel:       o.config.EnableIPSecTunnel,
+		IPSecAuthenticationMode: config.IPSecAuthenticationMode(o.config.IPSecAuthenticationMode),
+		EnableWireGuard:         o.config.EnableWireGuard,
+		WireGuardPort:           o.config.WireGuardPort}
 
 	routeClient, err := route.NewClient(o.config.HostGateway, serviceCIDRNet, encapMode)
 
@@ -107,12 +127,24 @@ func run(o *Options) error {
 	}
 	nodeConfig := agentInitializer.GetNodeConfig()
 
+	// ipPoolInformer is only used when the AntreaIPAM feature is enabled, to route the IPs
+	// allocated from IPPools to the Pods of other Nodes.
+	var ipPoolInformer ipaminformers.IPPoolInformer
+	if features.DefaultFeatureGate.Enabled(features.AntreaIPAM) {
+		ipPoolInformer = crdInformerFactory.Ipam().V1alpha1().IPPools()
+		if err := ipam.InitializeAntreaIPAMDriver(k8sClient, crdClient, nodeConfig.Name); err != nil {
+			return fmt.Errorf("error initializing Antrea IPAM driver: %v", err)
+		}
+	}
+
 	nodeRouteController := noderoute.NewNodeRouteController(
 		k8sClient,
 		informerFactory,
+		ipPoolInformer,
 		ofClient,
 		ovsBridgeClient,
 		routeClient,
+		agentInitializer.GetWireGuardClient(),
 		ifaceStore,
 		networkConfig,
 		nodeConfig)
@@ -122,6 +154,76 @@ func run(o *Options) error {
 	// updated Pods.
 	podUpdates := make(chan v1beta1.PodReference, 100)
 	networkPolicyController := networkpolicy.NewNetworkPolicyController(antreaClientProvider, ofClient, ifaceStore, nodeConfig.Name, podUpdates)
+	var traceflowController *traceflow.Controller
+	if features.DefaultFeatureGate.Enabled(features.Traceflow) {
+		traceflowController = traceflow.NewTraceflowController(
+			k8sClient,
+			crdClient,
+			crdInformerFactory,
+			ofClient,
+			ifaceStore,
+			nodeConfig)
+	}
+	var proxier *proxy.Proxier
+	if features.DefaultFeatureGate.Enabled(features.AntreaProxy) {
+		proxier = proxy.NewProxier(informerFactory, ofClient)
+	}
+	var egressController *egress.Controller
+	if features.DefaultFeatureGate.Enabled(features.Egress) {
+		egressController = egress.NewEgressController(
+			crdClient,
+			crdInformerFactory.Security().V1alpha1().Egresses(),
+			informerFactory.Core().V1().Pods(),
+			informerFactory.Core().V1().Namespaces(),
+			informerFactory.Core().V1().Nodes(),
+			ofClient,
+			routeClient,
+			nodeConfig.Name)
+	}
+	var nplController *nodeportlocal.Controller
+	if features.DefaultFeatureGate.Enabled(features.NodePortLocal) {
+		// The port range has been validated with the options.
+		portRangeStart, portRangeEnd, _ := nodeportlocal.ParsePortRange(o.config.NPLPortRange)
+		nplController = nodeportlocal.NewNodePortLocalController(
+			k8sClient,
+			informerFactory.Core().V1().Pods(),
+			routeClient,
+			nodeConfig.Name,
+			nodeConfig.NodeIPAddr.IP,
+			portRangeStart,
+			portRangeEnd)
+	}
+	var ipsecCertController *ipseccertificate.Controller
+	if networkConfig.EnableIPSecTunnel && networkConfig.IPSecAuthenticationMode == config.IPSecAuthenticationModeCert {
+		ipsecCertController = ipseccertificate.NewIPSecCertificateController(
+			k8sClient,
+			ovsBridgeClient,
+			nodeConfig.Name,
+			filepath.Join(o.config.OVSRunDir, ipsecCertDirName))
+	}
+	ovsCtlClient := ovsctl.NewClient(o.config.OVSBridge)
+	connTrackDumper := connections.NewConnTrackDumper(o.config.OVSDatapathType, ovsCtlClient)
+	var flowExporter *exporter.FlowExporter
+	if features.DefaultFeatureGate.Enabled(features.FlowExporter) && o.config.FlowCollectorAddr != "" {
+		connStore, err := connections.NewConnectionStore(
+			connTrackDumper,
+			ifaceStore,
+			informerFactory.Core().V1().Pods(),
+			nodeConfig.Name)
+		if err != nil {
+			return fmt.Errorf("error creating connection store: %v", err)
+		}
+		// The address has been validated when validating the options.
+		collectorNetwork, collectorAddr, _ := parseFlowCollectorAddr(o.config.FlowCollectorAddr)
+		pollInterval, _ := time.ParseDuration(o.config.FlowPollInterval)
+		flowExporter = exporter.NewFlowExporter(
+			connStore,
+			collectorNetwork,
+			collectorAddr,
+			pollInterval,
+			o.config.FlowExportFrequency,
+			nodeConfig.Name)
+	}
 	isChaining := false
 	if networkConfig.TrafficEncapMode.IsNetworkPolicyOnly() {
 		isChaining = true
@@ -135,7 +237,12 @@ func run(o *Options) error {
 		podUpdates,
 		isChaining,
 		routeClient)
-	err = cniServer.Initialize(ovsBridgeClient, ofClient, ifaceStore, o.config.OVSDatapathType)
+	if features.DefaultFeatureGate.Enabled(features.SecondaryNetwork) {
+		cniServer.EnableSecondaryNetworks(crdClient, func(bridgeName string) ovsconfig.OVSBridgeClient {
+			return ovsconfig.NewOVSBridge(bridgeName, o.config.OVSDatapathType, ovsdbConnection)
+		})
+	}
+	err = cniServer.Initialize(ovsBridgeClient, ofClient, ovsCtlClient, ifaceStore, o.config.OVSDatapathType)
 	if err != nil {
 		return fmt.Errorf("error initializing CNI server: %v", err)
 	}
@@ -157,13 +264,43 @@ func run(o *Options) error {
 	go cniServer.Run(stopCh)
 
 	informerFactory.Start(stopCh)
+	crdInformerFactory.Start(stopCh)
 
 	go antreaClientProvider.Run(stopCh)
 
+	if ipsecCertController != nil {
+		go ipsecCertController.Run(stopCh)
+	}
+
 	go nodeRouteController.Run(stopCh)
 
 	go networkPolicyController.Run(stopCh)
 
+	if features.DefaultFeatureGate.Enabled(features.Traceflow) {
+		go traceflowController.Run(stopCh)
+	}
+
+	if features.DefaultFeatureGate.Enabled(features.AntreaProxy) {
+		go proxier.Run(stopCh)
+	}
+
+	if features.DefaultFeatureGate.Enabled(features.Egress) {
+		go egressController.Run(stopCh)
+	}
+
+	if features.DefaultFeatureGate.Enabled(features.NodePortLocal) {
+		go nplController.Run(stopCh)
+	}
+
+	if flowExporter != nil {
+		go flowExporter.Run(stopCh)
+	}
+
+	// The intercepted interfaces of the Pods are only audited in policy only mode.
+	var podInterfaceAuditor querier.PodInterfaceAuditor
+	if isChaining {
+		podInterfaceAuditor = cniServer
+	}
 	agentQuerier := querier.NewAgentQuerier(
 		nodeConfig,
 		ifaceStore,
@@ -171,6 +308,8 @@ func run(o *Options) error {
 		ofClient,
 		ovsBridgeClient,
 		networkPolicyController,
+		connTrackDumper,
+		podInterfaceAuditor,
 		o.config.APIPort)
 
 	if o.config.EnablePrometheusMetrics {
@@ -65,11 +65,26 @@ type AgentConfig struct {
 	// Default is 10.96.0.0/12
 	ServiceCIDR string `yaml:"serviceCIDR,omitempty"`
 	// Whether or not to enable IPSec (ESP) encryption for Pod traffic across Nodes. IPSec encryption
-	// is supported only for the GRE tunnel type. Antrea uses Preshared Key (PSK) for IKE
-	// authentication. When IPSec tunnel is enabled, the PSK value must be passed to Antrea Agent
-	// through an environment variable: ANTREA_IPSEC_PSK.
+	// is supported only for the GRE tunnel type. By default, Antrea uses Preshared Key (PSK) for IKE
+	// authentication. When IPSec tunnel is enabled with PSK authentication, the PSK value must be
+	// passed to Antrea Agent through an environment variable: ANTREA_IPSEC_PSK.
 	// Defaults to false.
 	EnableIPSecTunnel bool `yaml:"enableIPSecTunnel,omitempty"`
+	// The authentication mode of the IPSec tunnels, either "psk" or "cert". With "cert", each
+	// Node is authenticated with an X.509 certificate issued by the Antrea Controller, which is
+	// requested and rotated automatically by Antrea Agent. "cert" requires the IPSecCertAuth
+	// feature gate to be enabled.
+	// Defaults to "psk".
+	IPSecAuthenticationMode string `yaml:"ipsecAuthenticationMode,omitempty"`
+	// Whether or not to enable WireGuard encryption for Pod traffic across Nodes. With WireGuard,
+	// the traffic to the Pods of other Nodes is routed to a WireGuard device instead of being
+	// encapsulated by the OVS tunnel port. WireGuard is supported only in Encap mode and cannot be
+	// enabled together with IPSec. It requires the WireGuard kernel module on the Nodes.
+	// Defaults to false.
+	EnableWireGuard bool `yaml:"enableWireGuard,omitempty"`
+	// The UDP port used by the WireGuard device of each Node. It must be the same on all Nodes.
+	// Defaults to 51820.
+	WireGuardPort int `yaml:"wireGuardPort,omitempty"`
 	// Determines how traffic is encapsulated. It has the following options
 	// Encap(default): Inter-node Pod traffic is always encapsulated and Pod to outbound traffic is masqueraded.
 	// NoEncap: Inter-node Pod traffic is not encapsulated, but Pod to outbound traffic is masqueraded.
@@ -83,4 +98,25 @@ type AgentConfig struct {
 	// Enable metrics exposure via Prometheus. Initializes Prometheus metrics listener
 	// Defaults to false.
 	EnablePrometheusMetrics bool `yaml:"enablePrometheusMetrics,omitempty"`
+	// FeatureGates is a map of feature names to bools that enable or disable experimental features.
+	FeatureGates map[string]bool `yaml:"featureGates,omitempty"`
+	// Provide the flow collector address as string with format <IP>:<port>[:<proto>], where proto is
+	// tcp or udp. If no protocol is given, tcp is used. This config parameter is used only when the
+	// FlowExporter feature gate is enabled, and the flow exporter is disabled if it's empty.
+	FlowCollectorAddr string `yaml:"flowCollectorAddr,omitempty"`
+	// Provide the interval at which the connections are polled from conntrack, as a duration
+	// string, e.g. "5s". This config parameter is used only when the FlowExporter feature gate is
+	// enabled.
+	// Defaults to "5s".
+	FlowPollInterval string `yaml:"flowPollInterval,omitempty"`
+	// Provide the number of polls between two exports of the flow records, e.g. 12 means that the
+	// records are exported every 12 polls. This config parameter is used only when the
+	// FlowExporter feature gate is enabled.
+	// Defaults to 12.
+	FlowExportFrequency uint `yaml:"flowExportFrequency,omitempty"`
+	// Provide the port range used by NodePortLocal, with format <start>-<end>, both ends being
+	// included. The container ports of the Pods are exposed on Node ports allocated from this range.
+	// This config parameter is used only when the NodePortLocal feature gate is enabled.
+	// Defaults to "40000-41000".
+	NPLPortRange string `yaml:"nplPortRange,omitempty"`
 }
@@ -18,13 +18,19 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 	"gopkg.in/yaml.v2"
 
 	"github.com/vmware-tanzu/antrea/pkg/agent/config"
+	"github.com/vmware-tanzu/antrea/pkg/agent/controller/nodeportlocal"
 	"github.com/vmware-tanzu/antrea/pkg/apis"
 	"github.com/vmware-tanzu/antrea/pkg/cni"
+	"github.com/vmware-tanzu/antrea/pkg/features"
 	"github.com/vmware-tanzu/antrea/pkg/ovs/ovsconfig"
 )
 
@@ -41,6 +47,16 @@ const (
 	// IPsec ESP can add a maximum of 38 bytes to the packet including the ESP
 	// header and trailer.
 	ipsecESPOverhead = 38
+	// WireGuard adds a maximum of 80 bytes to the packet, for the outer IPv6 and
+	// UDP headers, the WireGuard header and the authentication tag.
+	wireGuardOverhead = 80
+
+	defaultWireGuardPort = 51820
+
+	defaultFlowPollInterval    = "5s"
+	defaultFlowExportFrequency = 12
+
+	defaultNPLPortRange = "40000-41000"
 )
 
 type Options struct {
@@ -101,9 +117,131 @@ func (o *Options) validate(args []string) error {
 	if encapMode.SupportsNoEncap() && o.config.EnableIPSecTunnel {
 		return fmt.Errorf("IPSec tunnel may only be enabled on %s mode", config.TrafficEncapModeEncap)
 	}
+	if err := features.DefaultMutableFeatureGate.SetFromMap(o.config.FeatureGates); err != nil {
+		return err
+	}
+	if err := o.validateFlowExporterConfig(); err != nil {
+		return fmt.Errorf("failed to validate flow exporter config: %v", err)
+	}
+	if features.DefaultFeatureGate.Enabled(features.Egress) {
+		if runtime.GOOS == "windows" {
+			return fmt.Errorf("the Egress feature is not supported on Windows")
+		}
+		if encapMode != config.TrafficEncapModeEncap {
+			return fmt.Errorf("the Egress feature is only supported in %s mode", config.TrafficEncapModeEncap)
+		}
+	}
+	if err := o.validateIPSecConfig(); err != nil {
+		return err
+	}
+	if err := o.validateWireGuardConfig(encapMode); err != nil {
+		return err
+	}
+	if features.DefaultFeatureGate.Enabled(features.AntreaIPAM) {
+		if runtime.GOOS == "windows" {
+			return fmt.Errorf("the AntreaIPAM feature is not supported on Windows")
+		}
+		if encapMode.IsNetworkPolicyOnly() {
+			return fmt.Errorf("the AntreaIPAM feature is not supported in %s mode", config.TrafficEncapModeNetworkPolicyOnly)
+		}
+	}
+	if features.DefaultFeatureGate.Enabled(features.SecondaryNetwork) {
+		if runtime.GOOS == "windows" {
+			return fmt.Errorf("the SecondaryNetwork feature is not supported on Windows")
+		}
+		if encapMode.IsNetworkPolicyOnly() {
+			return fmt.Errorf("the SecondaryNetwork feature is not supported in %s mode", config.TrafficEncapModeNetworkPolicyOnly)
+		}
+	}
+	if features.DefaultFeatureGate.Enabled(features.NodePortLocal) {
+		if runtime.GOOS == "windows" {
+			return fmt.Errorf("the NodePortLocal feature is not supported on Windows")
+		}
+		if _, _, err := nodeportlocal.ParsePortRange(o.config.NPLPortRange); err != nil {
+			return fmt.Errorf("invalid NodePortLocal port range: %v", err)
+		}
+	}
+	return nil
+}
+
+// validateWireGuardConfig validates the WireGuard configuration.
+func (o *Options) validateWireGuardConfig(encapMode config.TrafficEncapModeType) error {
+	if !o.config.EnableWireGuard {
+		return nil
+	}
+	if runtime.GOOS == "windows" {
+		return fmt.Errorf("WireGuard is not supported on Windows")
+	}
+	if o.config.EnableIPSecTunnel {
+		return fmt.Errorf("WireGuard and IPSec tunnel cannot be enabled at the same time")
+	}
+	if encapMode != config.TrafficEncapModeEncap {
+		return fmt.Errorf("WireGuard may only be enabled on %s mode", config.TrafficEncapModeEncap)
+	}
+	if o.config.WireGuardPort <= 0 || o.config.WireGuardPort > 65535 {
+		return fmt.Errorf("WireGuard port %d is invalid", o.config.WireGuardPort)
+	}
 	return nil
 }
 
+// validateIPSecConfig validates the IPSec authentication mode. It must be
+// called after the feature gates are set.
+func (o *Options) validateIPSecConfig() error {
+	switch config.IPSecAuthenticationMode(o.config.IPSecAuthenticationMode) {
+	case config.IPSecAuthenticationModePSK:
+	case config.IPSecAuthenticationModeCert:
+		if !features.DefaultFeatureGate.Enabled(features.IPSecCertAuth) {
+			return fmt.Errorf("IPSec authentication mode %s requires the %s feature gate", o.config.IPSecAuthenticationMode, features.IPSecCertAuth)
+		}
+	default:
+		return fmt.Errorf("IPSec authentication mode %s is unknown", o.config.IPSecAuthenticationMode)
+	}
+	return nil
+}
+
+// validateFlowExporterConfig validates the flow exporter parameters if the FlowExporter feature
+// is enabled and a collector is provided.
+func (o *Options) validateFlowExporterConfig() error {
+	if !features.DefaultFeatureGate.Enabled(features.FlowExporter) || o.config.FlowCollectorAddr == "" {
+		return nil
+	}
+	if _, _, err := parseFlowCollectorAddr(o.config.FlowCollectorAddr); err != nil {
+		return err
+	}
+	pollInterval, err := time.ParseDuration(o.config.FlowPollInterval)
+	if err != nil {
+		return fmt.Errorf("flowPollInterval %s is invalid: %v", o.config.FlowPollInterval, err)
+	}
+	if pollInterval < time.Second {
+		return fmt.Errorf("flowPollInterval %s must be at least 1s", o.config.FlowPollInterval)
+	}
+	return nil
+}
+
+// parseFlowCollectorAddr parses a flow collector address with format <IP>:<port>[:<proto>], and
+// returns the transport protocol and the address to connect to.
+func parseFlowCollectorAddr(addr string) (string, string, error) {
+	network := "tcp"
+	for _, proto := range []string{"tcp", "udp"} {
+		if strings.HasSuffix(addr, ":"+proto) {
+			network = proto
+			addr = strings.TrimSuffix(addr, ":"+proto)
+			break
+		}
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", "", fmt.Errorf("flowCollectorAddr %s is invalid: %v", addr, err)
+	}
+	if net.ParseIP(host) == nil {
+		return "", "", fmt.Errorf("flowCollectorAddr %s is invalid: %s is not an IP address", addr, host)
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return "", "", fmt.Errorf("flowCollectorAddr %s is invalid: port %s is invalid", addr, port)
+	}
+	return network, net.JoinHostPort(host, port), nil
+}
+
 func (o *Options) loadConfigFromFile(file string) (*AgentConfig, error) {
 	data, err := ioutil.ReadFile(file)
 	if err != nil {
@@ -143,14 +281,23 @@ func (o *Options) setDefaults() {
 	if o.config.ServiceCIDR == "" {
 		o.config.ServiceCIDR = defaultServiceCIDR
 	}
+	if o.config.IPSecAuthenticationMode == "" {
+		o.config.IPSecAuthenticationMode = string(config.IPSecAuthenticationModePSK)
+	}
 	if o.config.TrafficEncapMode == "" {
 		o.config.TrafficEncapMode = config.TrafficEncapModeEncap.String()
 	}
+	if o.config.WireGuardPort == 0 {
+		o.config.WireGuardPort = defaultWireGuardPort
+	}
 
 	if o.config.DefaultMTU == 0 {
 		ok, encapMode := config.GetTrafficEncapModeFromStr(o.config.TrafficEncapMode)
 		if ok && !encapMode.SupportsEncap() {
 			o.config.DefaultMTU = defaultMTU
+		} else if o.config.EnableWireGuard {
+			// The traffic across Nodes is not encapsulated by the tunnel port with WireGuard.
+			o.config.DefaultMTU = defaultMTU - wireGuardOverhead
 		} else if o.config.TunnelType == ovsconfig.VXLANTunnel {
 			o.config.DefaultMTU = defaultMTUVXLAN
 		} else if o.config.TunnelType == ovsconfig.GeneveTunnel {
@@ -169,4 +316,14 @@ func (o *Options) setDefaults() {
 	if o.config.APIPort == 0 {
 		o.config.APIPort = apis.AntreaAgentAPIPort
 	}
+
+	if o.config.FlowPollInterval == "" {
+		o.config.FlowPollInterval = defaultFlowPollInterval
+	}
+	if o.config.FlowExportFrequency == 0 {
+		o.config.FlowExportFrequency = defaultFlowExportFrequency
+	}
+	if o.config.NPLPortRange == "" {
+		o.config.NPLPortRange = defaultNPLPortRange
+	}
 }
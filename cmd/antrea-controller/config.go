@@ -35,4 +35,24 @@ type ControllerConfig struct {
 	//   tls.key: <TLS private key>
 	// Defaults to true.
 	SelfSignedCert bool `yaml:"selfSignedCert,omitempty"`
+	// FeatureGates is a map of feature names to bools that enable or disable experimental features.
+	FeatureGates map[string]bool `yaml:"featureGates,omitempty"`
+	// LeaderElection configures the leader election among antrea-controller replicas.
+	LeaderElection LeaderElectionConfig `yaml:"leaderElection,omitempty"`
+}
+
+type LeaderElectionConfig struct {
+	// Enable leader election among antrea-controller replicas. Only the leader serves the antrea Service while
+	// the other replicas stand by. It must be enabled when running more than one replica.
+	// Defaults to false.
+	Enable bool `yaml:"enable,omitempty"`
+	// The duration that non-leader replicas will wait before trying to acquire leadership.
+	// Defaults to 15s.
+	LeaseDuration string `yaml:"leaseDuration,omitempty"`
+	// The duration that the leader will retry refreshing leadership before giving up.
+	// Defaults to 10s.
+	RenewDeadline string `yaml:"renewDeadline,omitempty"`
+	// The duration the replicas should wait between tries of actions.
+	// Defaults to 2s.
+	RetryPeriod string `yaml:"retryPeriod,omitempty"`
 }
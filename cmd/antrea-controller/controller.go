@@ -34,13 +34,20 @@ import (
 	"github.com/vmware-tanzu/antrea/pkg/apiserver/certificate"
 	"github.com/vmware-tanzu/antrea/pkg/apiserver/openapi"
 	"github.com/vmware-tanzu/antrea/pkg/apiserver/storage"
+	crdinformers "github.com/vmware-tanzu/antrea/pkg/client/informers/externalversions"
+	"github.com/vmware-tanzu/antrea/pkg/controller/certificatesigningrequest"
+	"github.com/vmware-tanzu/antrea/pkg/controller/ipam"
+	"github.com/vmware-tanzu/antrea/pkg/controller/leaderelection"
 	"github.com/vmware-tanzu/antrea/pkg/controller/metrics"
 	"github.com/vmware-tanzu/antrea/pkg/controller/networkpolicy"
 	"github.com/vmware-tanzu/antrea/pkg/controller/networkpolicy/store"
 	"github.com/vmware-tanzu/antrea/pkg/controller/querier"
+	"github.com/vmware-tanzu/antrea/pkg/controller/traceflow"
+	"github.com/vmware-tanzu/antrea/pkg/features"
 	"github.com/vmware-tanzu/antrea/pkg/k8s"
 	"github.com/vmware-tanzu/antrea/pkg/monitor"
 	"github.com/vmware-tanzu/antrea/pkg/signals"
+	"github.com/vmware-tanzu/antrea/pkg/util/env"
 	"github.com/vmware-tanzu/antrea/pkg/version"
 )
 
@@ -64,6 +71,9 @@ func run(o *Options) error {
 	namespaceInformer := informerFactory.Core().V1().Namespaces()
 	networkPolicyInformer := informerFactory.Networking().V1().NetworkPolicies()
 	nodeInformer := informerFactory.Core().V1().Nodes()
+	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, informerDefaultResync)
+	cnpInformer := crdInformerFactory.Security().V1alpha1().ClusterNetworkPolicies()
+	traceflowInformer := crdInformerFactory.Ops().V1alpha1().Traceflows()
 
 	// Create Antrea object storage.
 	addressGroupStore := store.NewAddressGroupStore()
@@ -74,13 +84,50 @@ func run(o *Options) error {
 		podInformer,
 		namespaceInformer,
 		networkPolicyInformer,
+		cnpInformer,
 		addressGroupStore,
 		appliedToGroupStore,
 		networkPolicyStore)
 
+	statusAggregator := networkpolicy.NewStatusAggregator(networkPolicyStore)
+
+	var statsAggregator *networkpolicy.StatsAggregator
+	if features.DefaultFeatureGate.Enabled(features.NetworkPolicyStats) {
+		statsAggregator = networkpolicy.NewStatsAggregator(networkPolicyStore)
+	}
+
+	policyAnalyzer := networkpolicy.NewPolicyAnalyzer(podInformer.Lister(), addressGroupStore, appliedToGroupStore, networkPolicyStore)
+
 	controllerQuerier := querier.NewControllerQuerier(networkPolicyController, o.config.APIPort)
 
-	controllerMonitor := monitor.NewControllerMonitor(crdClient, nodeInformer, controllerQuerier)
+	// The components which must only run in the leader replica are started by the elector.
+	elector := leaderelection.NewElector(client, o.leaderElection, env.GetPodNamespace(), env.GetPodName())
+
+	controllerMonitor := monitor.NewControllerMonitor(crdClient, nodeInformer, controllerQuerier, elector)
+
+	var traceflowController *traceflow.Controller
+	if features.DefaultFeatureGate.Enabled(features.Traceflow) {
+		traceflowController = traceflow.NewTraceflowController(crdClient, traceflowInformer)
+	}
+
+	var ipsecCSRSigningController *certificatesigningrequest.IPSecCSRSigningController
+	if features.DefaultFeatureGate.Enabled(features.IPSecCertAuth) {
+		ipsecCSRSigningController = certificatesigningrequest.NewIPSecCSRSigningController(
+			client,
+			informerFactory.Certificates().V1beta1().CertificateSigningRequests(),
+			nodeInformer)
+	}
+
+	var antreaIPAMController *ipam.AntreaIPAMController
+	if features.DefaultFeatureGate.Enabled(features.AntreaIPAM) {
+		antreaIPAMController = ipam.NewAntreaIPAMController(
+			client,
+			crdClient,
+			namespaceInformer,
+			informerFactory.Apps().V1().StatefulSets(),
+			podInformer,
+			crdInformerFactory.Ipam().V1alpha1().IPPools())
+	}
 
 	apiServerConfig, err := createAPIServerConfig(o.config.ClientConnection.Kubeconfig,
 		client,
@@ -90,6 +137,9 @@ func run(o *Options) error {
 		addressGroupStore,
 		appliedToGroupStore,
 		networkPolicyStore,
+		statusAggregator,
+		statsAggregator,
+		policyAnalyzer,
 		controllerQuerier,
 		o.config.EnablePrometheusMetrics)
 	if err != nil {
@@ -106,13 +156,38 @@ func run(o *Options) error {
 	stopCh := signals.RegisterSignalHandlers()
 
 	informerFactory.Start(stopCh)
+	crdInformerFactory.Start(stopCh)
 
-	go controllerMonitor.Run(stopCh)
-
+	// All replicas compute NetworkPolicies so that a standby replica can take over with warm stores.
 	go networkPolicyController.Run(stopCh)
 
+	go statusAggregator.Run(stopCh)
+
+	if statsAggregator != nil {
+		go statsAggregator.Run(stopCh)
+	}
+
 	go apiServer.Run(stopCh)
 
+	// The components below must only run in the leader replica.
+	go elector.Run(stopCh, func(leaderStopCh <-chan struct{}) {
+		apiServer.RunCACertController(leaderStopCh)
+
+		go controllerMonitor.Run(leaderStopCh)
+
+		if traceflowController != nil {
+			go traceflowController.Run(leaderStopCh)
+		}
+
+		if ipsecCSRSigningController != nil {
+			go ipsecCSRSigningController.Run(leaderStopCh)
+		}
+
+		if antreaIPAMController != nil {
+			go antreaIPAMController.Run(leaderStopCh)
+		}
+	})
+
 	if o.config.EnablePrometheusMetrics {
 		metrics.InitializePrometheusMetrics()
 	}
@@ -130,6 +205,9 @@ func createAPIServerConfig(kubeconfig string,
 	addressGroupStore storage.Interface,
 	appliedToGroupStore storage.Interface,
 	networkPolicyStore storage.Interface,
+	statusAggregator *networkpolicy.StatusAggregator,
+	statsAggregator *networkpolicy.StatsAggregator,
+	policyAnalyzer *networkpolicy.PolicyAnalyzer,
 	controllerQuerier querier.ControllerQuerier,
 	enableMetrics bool) (*apiserver.Config, error) {
 	secureServing := genericoptions.NewSecureServingOptions().WithLoopback()
@@ -177,6 +255,9 @@ func createAPIServerConfig(kubeconfig string,
 		addressGroupStore,
 		appliedToGroupStore,
 		networkPolicyStore,
+		statusAggregator,
+		statsAggregator,
+		policyAnalyzer,
 		caCertController,
 		controllerQuerier), nil
 }
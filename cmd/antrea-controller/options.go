@@ -16,12 +16,17 @@ package main
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
+	"time"
 
 	"github.com/spf13/pflag"
 	"gopkg.in/yaml.v2"
+	k8sleaderelection "k8s.io/client-go/tools/leaderelection"
 
 	"github.com/vmware-tanzu/antrea/pkg/apis"
+	"github.com/vmware-tanzu/antrea/pkg/controller/leaderelection"
+	"github.com/vmware-tanzu/antrea/pkg/features"
 )
 
 type Options struct {
@@ -29,8 +34,16 @@ type Options struct {
 	configFile string
 	// The configuration object
 	config *ControllerConfig
+	// leaderElection is the leader election configuration parsed from config.
+	leaderElection leaderelection.Config
 }
 
+const (
+	defaultLeaseDuration = "15s"
+	defaultRenewDeadline = "10s"
+	defaultRetryPeriod   = "2s"
+)
+
 func newOptions() *Options {
 	return &Options{
 		config: new(ControllerConfig),
@@ -60,6 +73,43 @@ func (o *Options) validate(args []string) error {
 	if len(args) != 0 {
 		return errors.New("no positional arguments are supported")
 	}
+	if err := features.DefaultMutableFeatureGate.SetFromMap(o.config.FeatureGates); err != nil {
+		return err
+	}
+	if err := o.validateLeaderElection(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateLeaderElection parses and validates the leader election configuration.
+func (o *Options) validateLeaderElection() error {
+	config := o.config.LeaderElection
+	o.leaderElection.Enable = config.Enable
+	for _, d := range []struct {
+		name     string
+		value    string
+		duration *time.Duration
+	}{
+		{"leaseDuration", config.LeaseDuration, &o.leaderElection.LeaseDuration},
+		{"renewDeadline", config.RenewDeadline, &o.leaderElection.RenewDeadline},
+		{"retryPeriod", config.RetryPeriod, &o.leaderElection.RetryPeriod},
+	} {
+		duration, err := time.ParseDuration(d.value)
+		if err != nil {
+			return fmt.Errorf("leaderElection.%s %s is invalid: %v", d.name, d.value, err)
+		}
+		if duration <= 0 {
+			return fmt.Errorf("leaderElection.%s %s must be positive", d.name, d.value)
+		}
+		*d.duration = duration
+	}
+	if o.leaderElection.LeaseDuration <= o.leaderElection.RenewDeadline {
+		return fmt.Errorf("leaderElection.leaseDuration must be greater than leaderElection.renewDeadline")
+	}
+	if o.leaderElection.RenewDeadline <= time.Duration(k8sleaderelection.JitterFactor*float64(o.leaderElection.RetryPeriod)) {
+		return fmt.Errorf("leaderElection.renewDeadline must be greater than %.1f times leaderElection.retryPeriod", k8sleaderelection.JitterFactor)
+	}
 	return nil
 }
 
@@ -83,4 +133,13 @@ func (o *Options) setDefaults() {
 	if o.config.APIPort == 0 {
 		o.config.APIPort = apis.AntreaControllerAPIPort
 	}
+	if o.config.LeaderElection.LeaseDuration == "" {
+		o.config.LeaderElection.LeaseDuration = defaultLeaseDuration
+	}
+	if o.config.LeaderElection.RenewDeadline == "" {
+		o.config.LeaderElection.RenewDeadline = defaultRenewDeadline
+	}
+	if o.config.LeaderElection.RetryPeriod == "" {
+		o.config.LeaderElection.RetryPeriod = defaultRetryPeriod
+	}
 }
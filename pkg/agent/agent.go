@@ -36,6 +36,7 @@ import (
 	"github.com/vmware-tanzu/antrea/pkg/agent/route"
 	"github.com/vmware-tanzu/antrea/pkg/agent/types"
 	"github.com/vmware-tanzu/antrea/pkg/agent/util"
+	"github.com/vmware-tanzu/antrea/pkg/agent/wireguard"
 	"github.com/vmware-tanzu/antrea/pkg/ovs/ovsconfig"
 	"github.com/vmware-tanzu/antrea/pkg/util/env"
 )
@@ -62,6 +63,7 @@ type Initializer struct {
 	serviceCIDR     *net.IPNet // K8s Service ClusterIP CIDR
 	networkConfig   *config.NetworkConfig
 	nodeConfig      *config.NodeConfig
+	wireGuardClient wireguard.Interface
 }
 
 func NewInitializer(
@@ -94,6 +96,11 @@ func (i *Initializer) GetNodeConfig() *config.NodeConfig {
 	return i.nodeConfig
 }
 
+// GetWireGuardClient returns the WireGuard client. It's nil if WireGuard is not enabled.
+func (i *Initializer) GetWireGuardClient() wireguard.Interface {
+	return i.wireGuardClient
+}
+
 // setupOVSBridge sets up the OVS bridge and create host gateway interface and tunnel port
 func (i *Initializer) setupOVSBridge() error {
 	if err := i.ovsBridgeClient.Create(); err != nil {
@@ -183,6 +190,9 @@ func (i *Initializer) Initialize() error {
 	if err := i.readIPSecPSK(); err != nil {
 		return err
 	}
+	if err := i.initWireGuard(); err != nil {
+		return err
+	}
 	if err := i.prepareHostNetwork(); err != nil {
 		return err
 	}
@@ -265,7 +275,7 @@ func (i *Initializer) initOpenFlowPipeline() error {
 
 	// Setup flow entries for gateway interface, including classifier, skip spoof guard check,
 	// L3 forwarding and L2 forwarding
-	if err := i.ofClient.InstallGatewayFlows(gateway.IP, gateway.MAC, gatewayOFPort); err != nil {
+	if err := i.ofClient.InstallGatewayFlows(gateway.IPs, gateway.MAC, gatewayOFPort); err != nil {
 		klog.Errorf("Failed to setup openflow entries for gateway: %v", err)
 		return err
 	}
@@ -411,27 +421,34 @@ func (i *Initializer) configureGatewayInterface(gatewayIface *interfacestore.Int
 
 	if i.networkConfig.TrafficEncapMode.IsNetworkPolicyOnly() {
 		// In policy-only mode, Node IP is also assigned to local gateway for masquerade.
-		i.nodeConfig.GatewayConfig = &config.GatewayConfig{Name: i.hostGateway, MAC: gwMAC, IP: i.nodeConfig.NodeIPAddr.IP}
+		i.nodeConfig.GatewayConfig = &config.GatewayConfig{Name: i.hostGateway, MAC: gwMAC, IPv4: i.nodeConfig.NodeIPAddr.IP}
 		gatewayIface.MAC = i.nodeConfig.GatewayConfig.MAC
-		gatewayIface.IP = i.nodeConfig.NodeIPAddr.IP
+		gatewayIface.IPs = []net.IP{i.nodeConfig.NodeIPAddr.IP}
 		return nil
 	}
 
-	// Configure host gateway IP using the first address of node localSubnet.
-	localSubnet := i.nodeConfig.PodCIDR
-	subnetID := localSubnet.IP.Mask(localSubnet.Mask)
-	gwIP := &net.IPNet{IP: ip.NextIP(subnetID), Mask: localSubnet.Mask}
-	i.nodeConfig.GatewayConfig = &config.GatewayConfig{LinkIndex: gwLinkIdx, Name: i.hostGateway, IP: gwIP.IP, MAC: gwMAC}
-	gatewayIface.IP = gwIP.IP
+	// Configure host gateway IPs using the first address of each node localSubnet.
+	i.nodeConfig.GatewayConfig = &config.GatewayConfig{LinkIndex: gwLinkIdx, Name: i.hostGateway, MAC: gwMAC}
 	gatewayIface.MAC = gwMAC
-
-	// Check IP address configuration on existing interface first, return if the interface has the desired address.
-	// We perform this check unconditionally, even if the OVS port does not exist when this function is called
-	// (i.e. portExists is false). Indeed, it may be possible for the interface to exist even if the OVS bridge does
-	// not exist.
-	// Configure the IP address on the interface if it does not exist.
-	if err := util.ConfigureLinkAddress(gwLinkIdx, gwIP); err != nil {
-		return err
+	gatewayIface.IPs = nil
+	for _, localSubnet := range i.nodeConfig.PodCIDRs() {
+		subnetID := localSubnet.IP.Mask(localSubnet.Mask)
+		gwIP := &net.IPNet{IP: ip.NextIP(subnetID), Mask: localSubnet.Mask}
+		if gwIP.IP.To4() != nil {
+			i.nodeConfig.GatewayConfig.IPv4 = gwIP.IP
+		} else {
+			i.nodeConfig.GatewayConfig.IPv6 = gwIP.IP
+		}
+		gatewayIface.IPs = append(gatewayIface.IPs, gwIP.IP)
+
+		// Check IP address configuration on existing interface first, return if the interface has the desired address.
+		// We perform this check unconditionally, even if the OVS port does not exist when this function is called
+		// (i.e. portExists is false). Indeed, it may be possible for the interface to exist even if the OVS bridge does
+		// not exist.
+		// Configure the IP address on the interface if it does not exist.
+		if err := util.ConfigureLinkAddress(gwLinkIdx, gwIP); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -468,7 +485,7 @@ func (i *Initializer) setupDefaultTunnelInterface(tunnelPortName string) error {
 
 	// Create default tunnel port.
 	if i.networkConfig.TrafficEncapMode.SupportsEncap() {
-		tunnelPortUUID, err := i.ovsBridgeClient.CreateTunnelPortExt(tunnelPortName, i.networkConfig.TunnelType, config.DefaultTunOFPort, localIPStr, "", "", nil)
+		tunnelPortUUID, err := i.ovsBridgeClient.CreateTunnelPortExt(tunnelPortName, i.networkConfig.TunnelType, config.DefaultTunOFPort, localIPStr, "", "", "", nil)
 		if err != nil {
 			klog.Errorf("Failed to create tunnel port %s type %s on OVS bridge: %v", tunnelPortName, i.networkConfig.TunnelType, err)
 			return err
@@ -480,8 +497,8 @@ func (i *Initializer) setupDefaultTunnelInterface(tunnelPortName string) error {
 	return nil
 }
 
-// initNodeLocalConfig retrieves node's subnet CIDR from node.spec.PodCIDR, which is used for IPAM and setup
-// host gateway interface.
+// initNodeLocalConfig retrieves node's subnet CIDRs from node.spec.PodCIDRs (or node.spec.PodCIDR if the former is
+// not set), which are used for IPAM and setup host gateway interface.
 func (i *Initializer) initNodeLocalConfig() error {
 	nodeName, err := env.GetNodeName()
 	if err != nil {
@@ -507,32 +524,40 @@ func (i *Initializer) initNodeLocalConfig() error {
 		return nil
 	}
 
-	// Spec.PodCIDR can be empty due to misconfiguration
-	if node.Spec.PodCIDR == "" {
-		klog.Errorf("Spec.PodCIDR is empty for Node %s. Please make sure --allocate-node-cidrs is enabled "+
-			"for kube-controller-manager and --cluster-cidr specifies a sufficient CIDR range", nodeName)
-		return fmt.Errorf("CIDR string is empty for node %s", nodeName)
-	}
-	_, localSubnet, err := net.ParseCIDR(node.Spec.PodCIDR)
-	if err != nil {
-		klog.Errorf("Failed to parse subnet from CIDR string %s: %v", node.Spec.PodCIDR, err)
-		return err
-	}
-
 	i.nodeConfig = &config.NodeConfig{
 		Name:            nodeName,
 		OVSBridge:       i.ovsBridge,
-		PodCIDR:         localSubnet,
 		NodeIPAddr:      localAddr,
 		BridgeName:      i.ovsBridgeClient.GetBridgeName(),
 		UplinkNetConfig: new(config.AdapterNetConfig)}
+
+	podCIDRs := noderoute.GetPodCIDRs(node)
+	// Spec.PodCIDR can be empty due to misconfiguration
+	if len(podCIDRs) == 0 {
+		klog.Errorf("Spec.PodCIDR is empty for Node %s. Please make sure --allocate-node-cidrs is enabled "+
+			"for kube-controller-manager and --cluster-cidr specifies a sufficient CIDR range", nodeName)
+		return fmt.Errorf("CIDR string is empty for node %s", nodeName)
+	}
+	for _, podCIDR := range podCIDRs {
+		_, localSubnet, err := net.ParseCIDR(podCIDR)
+		if err != nil {
+			klog.Errorf("Failed to parse subnet from CIDR string %s: %v", podCIDR, err)
+			return err
+		}
+		if localSubnet.IP.To4() != nil {
+			i.nodeConfig.PodIPv4CIDR = localSubnet
+		} else {
+			i.nodeConfig.PodIPv6CIDR = localSubnet
+		}
+	}
 	return nil
 }
 
 // readIPSecPSK reads the IPSec PSK value from environment variable
-// ANTREA_IPSEC_PSK, when enableIPSecTunnel is set to true.
+// ANTREA_IPSEC_PSK, when enableIPSecTunnel is set to true and the IPSec
+// authentication mode is PSK.
 func (i *Initializer) readIPSecPSK() error {
-	if !i.networkConfig.EnableIPSecTunnel {
+	if !i.networkConfig.EnableIPSecTunnel || i.networkConfig.IPSecAuthenticationMode != config.IPSecAuthenticationModePSK {
 		return nil
 	}
 
@@ -546,6 +571,30 @@ func (i *Initializer) readIPSecPSK() error {
 	return nil
 }
 
+// initWireGuard creates and configures the WireGuard device, when enableWireGuard is set to true.
+// The traffic to the Pods of the other Nodes is routed to the device, which uses the same MTU as
+// the Pod network interfaces.
+func (i *Initializer) initWireGuard() error {
+	if !i.networkConfig.EnableWireGuard {
+		return nil
+	}
+	wireGuardConfig := &config.WireGuardConfig{
+		Name: wireguard.DefaultDeviceName,
+		Port: i.networkConfig.WireGuardPort,
+		MTU:  i.mtu,
+	}
+	wireGuardClient, err := wireguard.New(i.client, i.nodeConfig, wireGuardConfig)
+	if err != nil {
+		return err
+	}
+	if err := wireGuardClient.Init(); err != nil {
+		return err
+	}
+	i.nodeConfig.WireGuardConfig = wireGuardConfig
+	i.wireGuardClient = wireGuardClient
+	return nil
+}
+
 func getLastRoundNum(bridgeClient ovsconfig.OVSBridgeClient) (uint64, error) {
 	extIDs, ovsCfgErr := bridgeClient.GetExternalIDs()
 	if ovsCfgErr != nil {
@@ -31,7 +31,7 @@ import (
 // setupExternalConnectivity installs OpenFlow entries to SNAT Pod traffic using Node IP, and then Pod could communicate
 // to the external IP address.
 func (i *Initializer) setupExternalConnectivity() error {
-	subnetCIDR := i.nodeConfig.PodCIDR
+	subnetCIDR := i.nodeConfig.PodIPv4CIDR
 	nodeIP := i.nodeConfig.NodeIPAddr.IP
 	// Install OpenFlow entries on the OVS to enable Pod traffic to communicate to external IP addresses.
 	if err := i.ofClient.InstallExternalFlows(nodeIP, *subnetCIDR); err != nil {
@@ -73,7 +73,7 @@ func (i *Initializer) prepareHostNetwork() error {
 	}
 	i.nodeConfig.UplinkNetConfig.DNSServers = dnsServers
 	// Create HNS network.
-	return util.PrepareHNSNetwork(i.nodeConfig.PodCIDR, i.nodeConfig.NodeIPAddr, adapter)
+	return util.PrepareHNSNetwork(i.nodeConfig.PodIPv4CIDR, i.nodeConfig.NodeIPAddr, adapter)
 }
 
 // prepareOVSBridge adds local port and uplink to ovs bridge.
@@ -0,0 +1,160 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conntrack
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/apiserver/handlers"
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter"
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+	"github.com/vmware-tanzu/antrea/pkg/agent/querier"
+	"github.com/vmware-tanzu/antrea/pkg/antctl/transform/common"
+)
+
+var protocolNames = map[uint8]string{
+	1:   "icmp",
+	6:   "tcp",
+	17:  "udp",
+	58:  "icmpv6",
+	132: "sctp",
+}
+
+// Response is the response struct of conntrack command.
+type Response struct {
+	Protocol         string `json:"protocol,omitempty"`
+	Source           string `json:"source,omitempty"`
+	Destination      string `json:"destination,omitempty"`
+	ReplySource      string `json:"replySource,omitempty"`
+	ReplyDestination string `json:"replyDestination,omitempty"`
+	Packets          uint64 `json:"packets"`
+	Bytes            uint64 `json:"bytes"`
+	ReplyPackets     uint64 `json:"replyPackets"`
+	ReplyBytes       uint64 `json:"replyBytes"`
+}
+
+func formatAddress(ip net.IP, port uint16) string {
+	if port == 0 {
+		return ip.String()
+	}
+	return net.JoinHostPort(ip.String(), strconv.Itoa(int(port)))
+}
+
+func generateResponse(conn *flowexporter.Connection) Response {
+	protocol, ok := protocolNames[conn.TupleOrig.Protocol]
+	if !ok {
+		protocol = strconv.Itoa(int(conn.TupleOrig.Protocol))
+	}
+	return Response{
+		Protocol:         protocol,
+		Source:           formatAddress(conn.TupleOrig.SourceAddress, conn.TupleOrig.SourcePort),
+		Destination:      formatAddress(conn.TupleOrig.DestinationAddress, conn.TupleOrig.DestinationPort),
+		ReplySource:      formatAddress(conn.TupleReply.SourceAddress, conn.TupleReply.SourcePort),
+		ReplyDestination: formatAddress(conn.TupleReply.DestinationAddress, conn.TupleReply.DestinationPort),
+		Packets:          conn.OriginalPackets,
+		Bytes:            conn.OriginalBytes,
+		ReplyPackets:     conn.ReversePackets,
+		ReplyBytes:       conn.ReverseBytes,
+	}
+}
+
+// connectionHasIP returns whether the provided connection is from or to one of the provided IPs.
+// The destination of the connection is checked before and after DNAT.
+func connectionHasIP(conn *flowexporter.Connection, ips []net.IP) bool {
+	for _, ip := range ips {
+		if ip.Equal(conn.TupleOrig.SourceAddress) || ip.Equal(conn.TupleOrig.DestinationAddress) || ip.Equal(conn.TupleReply.SourceAddress) {
+			return true
+		}
+	}
+	return false
+}
+
+// getPodIPs parses the "pod" query parameter, in <Namespace>/<name> format, and returns the IPs
+// of the local Pod.
+func getPodIPs(aq querier.AgentQuerier, pod string) ([]net.IP, *handlers.HandlerError) {
+	parts := strings.Split(pod, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, handlers.NewHandlerError(errors.New("invalid Pod format, must be <Namespace>/<name>"), http.StatusBadRequest)
+	}
+	intf, ok := aq.GetInterfaceStore().GetContainerInterface(parts[1], parts[0])
+	if !ok {
+		return nil, handlers.NewHandlerError(fmt.Errorf("Pod %s not found on this Node", pod), http.StatusNotFound)
+	}
+	return intf.IPs, nil
+}
+
+// HandleFunc returns the function which can handle API requests to "/conntrack".
+func HandleFunc(aq querier.AgentQuerier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var podIPs []net.IP
+		if pod := r.URL.Query().Get("pod"); pod != "" {
+			var handlerErr *handlers.HandlerError
+			podIPs, handlerErr = getPodIPs(aq, pod)
+			if handlerErr != nil {
+				http.Error(w, handlerErr.Error(), handlerErr.HTTPStatusCode)
+				return
+			}
+		}
+
+		conns, err := aq.GetConnTrackDumper().DumpFlows(openflow.CtZone)
+		if err != nil {
+			klog.Errorf("Failed to dump conntrack zone %d: %v", openflow.CtZone, err)
+			http.Error(w, "failed to dump connections", http.StatusInternalServerError)
+			return
+		}
+		resps := []Response{}
+		for _, conn := range conns {
+			if podIPs != nil && !connectionHasIP(conn, podIPs) {
+				continue
+			}
+			resps = append(resps, generateResponse(conn))
+		}
+		err = json.NewEncoder(w).Encode(resps)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
+
+var _ common.TableOutput = new(Response)
+
+func (r Response) GetTableHeader() []string {
+	return []string{"PROTOCOL", "SOURCE", "DESTINATION", "REPLY-SOURCE", "PACKETS", "BYTES", "REPLY-PACKETS", "REPLY-BYTES"}
+}
+
+func (r Response) GetTableRow(maxColumnLength int) []string {
+	return []string{
+		r.Protocol,
+		r.Source,
+		r.Destination,
+		r.ReplySource,
+		strconv.FormatUint(r.Packets, 10),
+		strconv.FormatUint(r.Bytes, 10),
+		strconv.FormatUint(r.ReplyPackets, 10),
+		strconv.FormatUint(r.ReplyBytes, 10),
+	}
+}
+
+func (r Response) SortRows() bool {
+	return true
+}
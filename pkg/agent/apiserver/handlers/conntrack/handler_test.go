@@ -0,0 +1,166 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conntrack
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter"
+	connectionstest "github.com/vmware-tanzu/antrea/pkg/agent/flowexporter/connections/testing"
+	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
+	interfacestoretest "github.com/vmware-tanzu/antrea/pkg/agent/interfacestore/testing"
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+	aqtest "github.com/vmware-tanzu/antrea/pkg/agent/querier/testing"
+)
+
+var (
+	testConns = []*flowexporter.Connection{
+		{
+			TupleOrig:       flowexporter.Tuple{SourceAddress: net.ParseIP("10.10.0.2"), DestinationAddress: net.ParseIP("10.96.0.10"), Protocol: 6, SourcePort: 49712, DestinationPort: 80},
+			TupleReply:      flowexporter.Tuple{SourceAddress: net.ParseIP("10.10.1.2"), DestinationAddress: net.ParseIP("10.10.0.2"), Protocol: 6, SourcePort: 8080, DestinationPort: 49712},
+			OriginalPackets: 6,
+			OriginalBytes:   404,
+			ReversePackets:  4,
+			ReverseBytes:    460,
+		},
+		{
+			TupleOrig:  flowexporter.Tuple{SourceAddress: net.ParseIP("10.10.0.3"), DestinationAddress: net.ParseIP("10.10.1.3"), Protocol: 1},
+			TupleReply: flowexporter.Tuple{SourceAddress: net.ParseIP("10.10.1.3"), DestinationAddress: net.ParseIP("10.10.0.3"), Protocol: 1},
+		},
+	}
+	testResponses = []Response{
+		{
+			Protocol:         "tcp",
+			Source:           "10.10.0.2:49712",
+			Destination:      "10.96.0.10:80",
+			ReplySource:      "10.10.1.2:8080",
+			ReplyDestination: "10.10.0.2:49712",
+			Packets:          6,
+			Bytes:            404,
+			ReplyPackets:     4,
+			ReplyBytes:       460,
+		},
+		{
+			Protocol:         "icmp",
+			Source:           "10.10.0.3",
+			Destination:      "10.10.1.3",
+			ReplySource:      "10.10.1.3",
+			ReplyDestination: "10.10.0.3",
+		},
+	}
+)
+
+func runHTTPTest(t *testing.T, handler http.HandlerFunc, query string, expectedStatus int, expectedResponses []Response) {
+	req, err := http.NewRequest(http.MethodGet, query, nil)
+	assert.Nil(t, err)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, expectedStatus, recorder.Code, query)
+	if expectedStatus == http.StatusOK {
+		var received []Response
+		err = json.Unmarshal(recorder.Body.Bytes(), &received)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedResponses, received)
+	}
+}
+
+func TestBadRequests(t *testing.T) {
+	handler := HandleFunc(nil)
+	for _, query := range []string{"?pod=pod1", "?pod=ns1/", "?pod=/pod1", "?pod=ns1/pod1/foo"} {
+		runHTTPTest(t, handler, query, http.StatusBadRequest, nil)
+	}
+}
+
+func TestAllConnections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	dumper := connectionstest.NewMockConnTrackDumper(ctrl)
+	q := aqtest.NewMockAgentQuerier(ctrl)
+	q.EXPECT().GetConnTrackDumper().Return(dumper)
+	dumper.EXPECT().DumpFlows(uint16(openflow.CtZone)).Return(testConns, nil)
+
+	runHTTPTest(t, HandleFunc(q), "", http.StatusOK, testResponses)
+}
+
+func TestPodConnections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testcases := []struct {
+		test              string
+		query             string
+		ips               []string
+		found             bool
+		expectedStatus    int
+		expectedResponses []Response
+	}{
+		{
+			test:              "Pod source of the connection",
+			query:             "?pod=ns1/pod1",
+			ips:               []string{"10.10.0.2"},
+			found:             true,
+			expectedStatus:    http.StatusOK,
+			expectedResponses: testResponses[:1],
+		},
+		{
+			test:              "Pod destination of the connection",
+			query:             "?pod=ns1/pod1",
+			ips:               []string{"10.10.0.3"},
+			found:             true,
+			expectedStatus:    http.StatusOK,
+			expectedResponses: testResponses[1:],
+		},
+		{
+			test:              "Pod without connection",
+			query:             "?pod=ns1/pod1",
+			ips:               []string{"10.10.0.4"},
+			found:             true,
+			expectedStatus:    http.StatusOK,
+			expectedResponses: []Response{},
+		},
+		{
+			test:           "Non-existing Pod",
+			query:          "?pod=ns1/pod1",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.test, func(t *testing.T) {
+			i := interfacestoretest.NewMockInterfaceStore(ctrl)
+			q := aqtest.NewMockAgentQuerier(ctrl)
+			q.EXPECT().GetInterfaceStore().Return(i)
+			if tc.found {
+				var ips []net.IP
+				for _, ip := range tc.ips {
+					ips = append(ips, net.ParseIP(ip))
+				}
+				i.EXPECT().GetContainerInterface("pod1", "ns1").Return(&interfacestore.InterfaceConfig{IPs: ips}, true)
+				dumper := connectionstest.NewMockConnTrackDumper(ctrl)
+				q.EXPECT().GetConnTrackDumper().Return(dumper)
+				dumper.EXPECT().DumpFlows(uint16(openflow.CtZone)).Return(testConns, nil)
+			} else {
+				i.EXPECT().GetContainerInterface("pod1", "ns1").Return(nil, false)
+			}
+			runHTTPTest(t, HandleFunc(q), tc.query, tc.expectedStatus, tc.expectedResponses)
+		})
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
 	"github.com/vmware-tanzu/antrea/pkg/agent/querier"
 	"github.com/vmware-tanzu/antrea/pkg/ovs/ovsctl"
+	"github.com/vmware-tanzu/antrea/pkg/util/ip"
 )
 
 // Response is the response struct of ovsflows command.
@@ -83,13 +84,13 @@ func getPeerAddress(aq querier.AgentQuerier, peer *tracingPeer) (net.IP, *interf
 			err := handlers.NewHandlerError(fmt.Errorf("OVS port %s not found", peer.ovsPort), http.StatusNotFound)
 			return nil, nil, err
 		}
-		return intf.IP, intf, nil
+		return getInterfaceIP(intf), intf, nil
 	}
 
 	intf, ok := aq.GetInterfaceStore().GetContainerInterface(peer.name, peer.namespace)
 	if ok {
 		// Local Pod.
-		return intf.IP, intf, nil
+		return getInterfaceIP(intf), intf, nil
 	}
 
 	// Try getting the Pod from K8s API.
@@ -102,8 +103,23 @@ func getPeerAddress(aq querier.AgentQuerier, peer *tracingPeer) (net.IP, *interf
 		klog.Errorf("Failed to get Pod from Kubernetes API: %v", err)
 		return nil, nil, handlers.NewHandlerError(errors.New("Kubernetes API error"), http.StatusInternalServerError)
 	}
-	// Return IP only assuming it should be a remote Pod.
-	return net.ParseIP(pod.Status.PodIP).To4(), nil, nil
+	// Return IP only assuming it should be a remote Pod. The primary IP of a dual-stack Pod can be
+	// an IPv6 address, so look for the IPv4 address in all the Pod IPs.
+	podIPs := []net.IP{net.ParseIP(pod.Status.PodIP)}
+	for _, podIP := range pod.Status.PodIPs {
+		podIPs = append(podIPs, net.ParseIP(podIP.IP))
+	}
+	return getIPv4Addr(podIPs), nil, nil
+}
+
+// getInterfaceIP returns the IP address of the interface used for tracing.
+func getInterfaceIP(intf *interfacestore.InterfaceConfig) net.IP {
+	return getIPv4Addr(intf.IPs)
+}
+
+// getIPv4Addr returns the IPv4 address in the provided list, as tracing only supports IPv4 packets.
+func getIPv4Addr(ips []net.IP) net.IP {
+	return ip.GetIPv4Addr(ips).To4()
 }
 
 func prepareTracingRequest(aq querier.AgentQuerier, req *request) (*ovsctl.TracingRequest, *handlers.HandlerError) {
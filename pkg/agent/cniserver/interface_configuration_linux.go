@@ -47,10 +47,9 @@ func newInterfaceConfigurator(ovsDatapathType string) (*ifConfigurator, error) {
 // setupInterfaces creates a veth pair: containerIface is in the container
 // network namespace and hostIface is in the host network namespace.
 func (ic *ifConfigurator) setupInterfaces(
-	podName, podNamespace, ifname string,
+	hostVethName, ifname string,
 	netns ns.NetNS,
 	mtu int) (hostIface *current.Interface, containerIface *current.Interface, err error) {
-	hostVethName := util.GenerateContainerInterfaceName(podName, podNamespace)
 	hostIface = &current.Interface{}
 	containerIface = &current.Interface{}
 
@@ -156,7 +155,8 @@ func (ic *ifConfigurator) configureContainerLink(
 	}
 	defer netns.Close()
 	// Create veth pair and link up
-	hostIface, containerIface, err := ic.setupInterfaces(podName, podNameSpace, containerIFDev, netns, mtu)
+	hostVethName := util.GenerateContainerInterfaceName(podName, podNameSpace)
+	hostIface, containerIface, err := ic.setupInterfaces(hostVethName, containerIFDev, netns, mtu)
 	if err != nil {
 		return fmt.Errorf("failed to create veth devices for container %s: %v", containerID, err)
 	}
@@ -173,6 +173,37 @@ func (ic *ifConfigurator) configureContainerLink(
 	return nil
 }
 
+// configureSecondaryContainerLink creates the veth pair of a secondary interface of the container,
+// with hostIfaceName as the name of the host side, and configures the IPs of the result on the
+// container side if there are any.
+func (ic *ifConfigurator) configureSecondaryContainerLink(
+	hostIfaceName string,
+	containerID string,
+	containerNetNS string,
+	containerIFDev string,
+	mtu int,
+	result *current.Result,
+) error {
+	netns, err := ns.GetNS(containerNetNS)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %s: %v", containerNetNS, err)
+	}
+	defer netns.Close()
+	hostIface, containerIface, err := ic.setupInterfaces(hostIfaceName, containerIFDev, netns, mtu)
+	if err != nil {
+		return fmt.Errorf("failed to create veth devices for interface %s of container %s: %v", containerIFDev, containerID, err)
+	}
+	result.Interfaces = []*current.Interface{hostIface, containerIface}
+	if len(result.IPs) == 0 {
+		return nil
+	}
+	klog.V(2).Infof("Configuring IP address for interface %s of container %s", containerIFDev, containerID)
+	if err = configureContainerAddr(netns, containerIface, result); err != nil {
+		return fmt.Errorf("failed to configure IP address for interface %s of container %s: %v", containerIFDev, containerID, err)
+	}
+	return nil
+}
+
 func (ic *ifConfigurator) removeContainerLink(containerID, hostInterfaceName string) error {
 	klog.V(2).Infof("Deleting veth devices for container %s", containerID)
 	// Don't return an error if the device is already removed as CniDel can be called multiple times.
@@ -333,6 +364,32 @@ func (ic *ifConfigurator) getInterceptedInterfaces(
 	return containerIface, hostIface, nil
 }
 
+// checkInterceptedHostInterface checks that the host side of an intercepted container interface
+// is still present, and that it is attached to the OVS bridge rather than to the bridge of the
+// primary CNI.
+func (ic *ifConfigurator) checkInterceptedHostInterface(hostIfaceName string) error {
+	link, err := validateInterface(&current.Interface{Name: hostIfaceName}, false)
+	if err != nil {
+		return err
+	}
+	// With the OVS netdev datapath, ports are not enslaved to a kernel device.
+	if ic.ovsDatapathType == ovsconfig.OVSDatapathNetdev {
+		return nil
+	}
+	masterIndex := link.Attrs().MasterIndex
+	if masterIndex == 0 {
+		return fmt.Errorf("interface %s is not attached to the OVS bridge", hostIfaceName)
+	}
+	master, err := netlink.LinkByIndex(masterIndex)
+	if err != nil {
+		return fmt.Errorf("failed to find master of interface %s: %v", hostIfaceName, err)
+	}
+	if master.Type() != "openvswitch" {
+		return fmt.Errorf("interface %s is attached to %s device %s instead of the OVS bridge", hostIfaceName, master.Type(), master.Attrs().Name)
+	}
+	return nil
+}
+
 func validateInterface(intf *current.Interface, inNetns bool) (netlink.Link, error) {
 	if intf.Name == "" {
 		return nil, fmt.Errorf("interface name is missing")
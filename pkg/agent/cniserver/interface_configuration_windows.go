@@ -199,6 +199,18 @@ func (ic *ifConfigurator) advertiseContainerAddr(containerNetNS string, containe
 	return nil
 }
 
+// configureSecondaryContainerLink is not supported on Windows, as the secondary networks are not.
+func (ic *ifConfigurator) configureSecondaryContainerLink(
+	hostIfaceName string,
+	containerID string,
+	containerNetNS string,
+	containerIFDev string,
+	mtu int,
+	result *current.Result,
+) error {
+	return fmt.Errorf("secondary interfaces are not supported on Windows")
+}
+
 // removeContainerLink removes the HNSEndpoint attached on the Pod.
 func (ic *ifConfigurator) removeContainerLink(containerID, epName string) error {
 	ep, found := ic.getEndpoint(epName)
@@ -357,6 +369,11 @@ func (ic *ifConfigurator) getInterceptedInterfaces(
 	return nil, nil, errors.New("getInterceptedInterfaces is unsupported on Windows")
 }
 
+// checkInterceptedHostInterface is not supported on Windows.
+func (ic *ifConfigurator) checkInterceptedHostInterface(hostIfaceName string) error {
+	return errors.New("checkInterceptedHostInterface is unsupported on Windows")
+}
+
 // getOVSInterfaceType returns "internal". Windows uses internal OVS interface for container vNIC.
 func (ic *ifConfigurator) getOVSInterfaceType() int {
 	return internalOVSInterfaceType
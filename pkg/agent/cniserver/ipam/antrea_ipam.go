@@ -0,0 +1,189 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/containernetworking/cni/pkg/invoke"
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	"github.com/containernetworking/cni/pkg/types/current"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+
+	ipamv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/ipam/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned"
+	"github.com/vmware-tanzu/antrea/pkg/ipam/poolallocator"
+)
+
+const (
+	// AntreaIPAMType is the IPAM type of the Antrea IPAM driver. The CNI server uses it instead of the
+	// IPAM type of the network configuration when the AntreaIPAM feature is enabled.
+	AntreaIPAMType = "antrea"
+)
+
+// antreaIPAMArgs are the Kubernetes CNI_ARGS used by the Antrea IPAM driver.
+type antreaIPAMArgs struct {
+	cnitypes.CommonArgs
+	K8S_POD_NAME      cnitypes.UnmarshallableString
+	K8S_POD_NAMESPACE cnitypes.UnmarshallableString
+}
+
+// AntreaIPAM allocates the IPs of the Pods from the IPPool specified by the annotation of their
+// Namespace. The IPs of the Pods of the other Namespaces are allocated from the Pod CIDR of the Node
+// by the host-local plugin.
+type AntreaIPAM struct {
+	kubeClient clientset.Interface
+	crdClient  versioned.Interface
+	nodeName   string
+	// delegator is the IPAM driver used for the Pods whose Namespace has no IPPool.
+	delegator IPAMDriver
+}
+
+// InitializeAntreaIPAMDriver registers the Antrea IPAM driver.
+func InitializeAntreaIPAMDriver(kubeClient clientset.Interface, crdClient versioned.Interface, nodeName string) error {
+	return RegisterIPAMDriver(AntreaIPAMType, &AntreaIPAM{
+		kubeClient: kubeClient,
+		crdClient:  crdClient,
+		nodeName:   nodeName,
+		delegator:  &IPAMDelegator{pluginType: ipamHostLocal},
+	})
+}
+
+// getPodOwner parses the Kubernetes arguments of the request, and returns the allocator of the IPPool of
+// the Pod and the owner of its IP. A nil allocator is returned if the Namespace of the Pod has no IPPool.
+// It's only used to allocate IPs, as the annotation of the Namespace may change afterwards.
+func (d *AntreaIPAM) getPodOwner(args *invoke.Args) (*poolallocator.IPPoolAllocator, *ipamv1alpha1.IPAddressOwner, error) {
+	k8sArgs := &antreaIPAMArgs{}
+	if err := cnitypes.LoadArgs(args.PluginArgsStr, k8sArgs); err != nil {
+		return nil, nil, err
+	}
+	podName, podNamespace := string(k8sArgs.K8S_POD_NAME), string(k8sArgs.K8S_POD_NAMESPACE)
+	namespace, err := d.kubeClient.CoreV1().Namespaces().Get(podNamespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get Namespace %s: %v", podNamespace, err)
+	}
+	poolName, ok := namespace.Annotations[ipamv1alpha1.IPPoolAnnotationKey]
+	if !ok {
+		return nil, nil, nil
+	}
+	owner := &ipamv1alpha1.IPAddressOwner{Pod: &ipamv1alpha1.PodOwner{
+		Name:        podName,
+		Namespace:   podNamespace,
+		ContainerID: args.ContainerID,
+		NodeName:    d.nodeName,
+	}}
+	return poolallocator.NewIPPoolAllocator(poolName, d.crdClient), owner, nil
+}
+
+// getStatefulSetOwner returns the StatefulSet owner of the Pod, or nil if the Pod is not managed by a
+// StatefulSet. The index of a StatefulSet Pod is the ordinal suffix of its name.
+func (d *AntreaIPAM) getStatefulSetOwner(podName, podNamespace string) (*ipamv1alpha1.StatefulSetOwner, error) {
+	pod, err := d.kubeClient.CoreV1().Pods(podNamespace).Get(podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Pod %s/%s: %v", podNamespace, podName, err)
+	}
+	ownerRef := metav1.GetControllerOf(pod)
+	if ownerRef == nil || ownerRef.Kind != "StatefulSet" || !strings.HasPrefix(podName, ownerRef.Name+"-") {
+		return nil, nil
+	}
+	index, err := strconv.Atoi(strings.TrimPrefix(podName, ownerRef.Name+"-"))
+	if err != nil {
+		return nil, nil
+	}
+	return &ipamv1alpha1.StatefulSetOwner{Name: ownerRef.Name, Namespace: podNamespace, Index: index}, nil
+}
+
+// buildResult returns the result of an IP allocated from an IPPool, with a default route via the gateway
+// of the subnet of the IP.
+func buildResult(ip net.IP, subnetInfo *ipamv1alpha1.SubnetInfo) *current.Result {
+	gateway := net.ParseIP(subnetInfo.Gateway)
+	_, defaultRouteDst, _ := net.ParseCIDR("0.0.0.0/0")
+	return &current.Result{
+		IPs: []*current.IPConfig{{
+			Version: "4",
+			Address: net.IPNet{IP: ip, Mask: net.CIDRMask(int(subnetInfo.PrefixLength), 32)},
+			Gateway: gateway,
+		}},
+		Routes: []*cnitypes.Route{{Dst: *defaultRouteDst, GW: gateway}},
+	}
+}
+
+func (d *AntreaIPAM) Add(args *invoke.Args, networkConfig []byte) (*current.Result, error) {
+	allocator, owner, err := d.getPodOwner(args)
+	if err != nil {
+		return nil, err
+	}
+	if allocator == nil {
+		return d.delegator.Add(args, networkConfig)
+	}
+	if owner.StatefulSet, err = d.getStatefulSetOwner(owner.Pod.Name, owner.Pod.Namespace); err != nil {
+		return nil, err
+	}
+	ip, subnetInfo, err := allocator.AllocateIP(*owner)
+	if err != nil {
+		return nil, err
+	}
+	klog.Infof("Allocated IP %s to container %s of Pod %s/%s", ip, args.ContainerID, owner.Pod.Namespace, owner.Pod.Name)
+	return buildResult(ip, subnetInfo), nil
+}
+
+// Del releases the IP of the container. The IPPool is found from the container ID rather than from the
+// annotation of the Namespace, which may have been removed or changed since the IP was allocated, or whose
+// Namespace may have been deleted. Releasing the IP of a container without IP is not an error.
+func (d *AntreaIPAM) Del(args *invoke.Args, networkConfig []byte) error {
+	// The IP may have been allocated by host-local if the Namespace had no IPPool when the Pod was
+	// created, releasing it is a no-op otherwise.
+	if err := d.delegator.Del(args, networkConfig); err != nil {
+		return err
+	}
+	allocator, err := poolallocator.GetContainerIPPoolAllocator(d.crdClient, args.ContainerID, "")
+	if err != nil {
+		return fmt.Errorf("failed to get the IPPool of container %s: %v", args.ContainerID, err)
+	}
+	if allocator == nil {
+		return nil
+	}
+	// The IPPool may have been deleted since it was found.
+	if err := allocator.ReleaseContainerIP(args.ContainerID, ""); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// Check checks that an IP is allocated to the container, by the IPPool found from the container ID like
+// in Del, or by host-local otherwise.
+func (d *AntreaIPAM) Check(args *invoke.Args, networkConfig []byte) error {
+	allocator, err := poolallocator.GetContainerIPPoolAllocator(d.crdClient, args.ContainerID, "")
+	if err != nil {
+		return fmt.Errorf("failed to get the IPPool of container %s: %v", args.ContainerID, err)
+	}
+	if allocator == nil {
+		return d.delegator.Check(args, networkConfig)
+	}
+	ip, _, err := allocator.GetContainerIP(args.ContainerID, "")
+	if err != nil {
+		return err
+	}
+	if ip == nil {
+		return fmt.Errorf("no IP is allocated to container %s", args.ContainerID)
+	}
+	return nil
+}
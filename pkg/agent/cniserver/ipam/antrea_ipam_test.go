@@ -0,0 +1,72 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"testing"
+
+	"github.com/containernetworking/cni/pkg/invoke"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+
+	ipamtest "github.com/vmware-tanzu/antrea/pkg/agent/cniserver/ipam/testing"
+	ipamv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/ipam/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/fake"
+)
+
+func TestAntreaIPAMDelAndCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	delegator := ipamtest.NewMockIPAMDriver(ctrl)
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "ns1",
+		Annotations: map[string]string{ipamv1alpha1.IPPoolAnnotationKey: "pool1"},
+	}}
+	pool := &ipamv1alpha1.IPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool1"},
+		Spec: ipamv1alpha1.IPPoolSpec{IPRanges: []ipamv1alpha1.SubnetIPRange{{
+			IPRange:    ipamv1alpha1.IPRange{CIDR: "10.2.0.0/24"},
+			SubnetInfo: ipamv1alpha1.SubnetInfo{Gateway: "10.2.0.1", PrefixLength: 24},
+		}}},
+	}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns1"}}
+	kubeClient := fakeclientset.NewSimpleClientset(namespace, pod)
+	crdClient := fake.NewSimpleClientset(pool)
+	d := &AntreaIPAM{kubeClient: kubeClient, crdClient: crdClient, nodeName: "node1", delegator: delegator}
+	args := &invoke.Args{ContainerID: "c1", PluginArgsStr: "K8S_POD_NAME=pod1;K8S_POD_NAMESPACE=ns1"}
+
+	result, err := d.Add(args, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "10.2.0.2/24", result.IPs[0].Address.String())
+	require.NoError(t, d.Check(args, nil))
+
+	// The IP is released even though the Namespace has been deleted.
+	require.NoError(t, kubeClient.CoreV1().Namespaces().Delete("ns1", &metav1.DeleteOptions{}))
+	delegator.EXPECT().Del(args, nil).Times(2)
+	require.NoError(t, d.Del(args, nil))
+	pool, err = crdClient.IpamV1alpha1().IPPools().Get("pool1", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, pool.Status.IPAddresses)
+	// Releasing the IP again is not an error.
+	require.NoError(t, d.Del(args, nil))
+
+	// The IP is checked by the delegator once it's released.
+	delegator.EXPECT().Check(args, nil)
+	require.NoError(t, d.Check(args, nil))
+}
@@ -29,6 +29,19 @@ type IPAMConfig struct {
 	Type    string `json:"type,omitempty"`
 	Subnet  string `json:"subnet,omitempty"`
 	Gateway string `json:"gateway,omitempty"`
+	// Ranges holds additional address ranges to allocate IPs from, one IP is allocated from each
+	// RangeSet. It is used to allocate an IPv6 address in addition to the IPv4 one when the Node
+	// is dual-stack.
+	Ranges []RangeSet `json:"ranges,omitempty"`
+}
+
+// RangeSet is a set of address ranges of the same IP family.
+type RangeSet []Range
+
+// Range is an address range to allocate IPs from.
+type Range struct {
+	Subnet  string `json:"subnet"`
+	Gateway string `json:"gateway,omitempty"`
 }
 
 type IPAMDriver interface {
@@ -56,6 +69,9 @@ func argsFromEnv(cniArgs *cnipb.CniCmdArgs) *invoke.Args {
 		NetNS:       cniArgs.Netns,
 		IfName:      cniArgs.Ifname,
 		Path:        cniArgs.Path,
+		// The Kubernetes arguments of the Pod are passed to the IPAM plugin, like a CNI plugin would do
+		// when delegating to it.
+		PluginArgsStr: cniArgs.Args,
 	}
 }
 
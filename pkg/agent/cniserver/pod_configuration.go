@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"strings"
 
 	cnitypes "github.com/containernetworking/cni/pkg/types"
 	"github.com/containernetworking/cni/pkg/types/current"
@@ -30,6 +31,8 @@ import (
 	"github.com/vmware-tanzu/antrea/pkg/agent/route"
 	"github.com/vmware-tanzu/antrea/pkg/agent/util"
 	"github.com/vmware-tanzu/antrea/pkg/ovs/ovsconfig"
+	"github.com/vmware-tanzu/antrea/pkg/ovs/ovsctl"
+	"github.com/vmware-tanzu/antrea/pkg/util/ip"
 )
 
 type vethPair struct {
@@ -60,29 +63,38 @@ const (
 
 type interfaceConfigurator interface {
 	configureContainerLink(podName, podNameSpace, containerID, containerNetNS, containerIFDev string, mtu int, result *current.Result) error
+	configureSecondaryContainerLink(hostIfaceName, containerID, containerNetNS, containerIFDev string, mtu int, result *current.Result) error
 	advertiseContainerAddr(containerNetNS string, containerIfaceName string, result *current.Result) error
 	removeContainerLink(containerID, hostInterfaceName string) error
 	checkContainerInterface(containerNetns, containerID string, containerIface *current.Interface, containerIPs []*current.IPConfig, containerRoutes []*cnitypes.Route) (*vethPair, error)
 	validateContainerPeerInterface(interfaces []*current.Interface, containerVeth *vethPair) (*vethPair, error)
 	getOVSInterfaceType() int
 	getInterceptedInterfaces(sandbox, containerNS, containerIFDev string) (*current.Interface, *current.Interface, error)
+	checkInterceptedHostInterface(hostIfaceName string) error
 }
 
 type podConfigurator struct {
 	ovsBridgeClient ovsconfig.OVSBridgeClient
 	ofClient        openflow.Client
+	ovsCtlClient    ovsctl.OVSCtlClient
 	routeClient     route.Interface
 	ifaceStore      interfacestore.InterfaceStore
 	gatewayMAC      net.HardwareAddr
 	ifConfigurator  interfaceConfigurator
+	// podIPv4CIDR is the IPv4 Pod CIDR of the Node. It is only set when the AntreaIPAM feature is
+	// enabled, in which case the local Pods with an IPv4 address outside of it have IPs allocated
+	// from IPPools and must be routed individually.
+	podIPv4CIDR *net.IPNet
 }
 
 func newPodConfigurator(
 	ovsBridgeClient ovsconfig.OVSBridgeClient,
 	ofClient openflow.Client,
+	ovsCtlClient ovsctl.OVSCtlClient,
 	routeClient route.Interface,
 	ifaceStore interfacestore.InterfaceStore,
 	gatewayMAC net.HardwareAddr,
+	podIPv4CIDR *net.IPNet,
 	ovsDatapathType string,
 ) (*podConfigurator, error) {
 	ifConfigurator, err := newInterfaceConfigurator(ovsDatapathType)
@@ -92,13 +104,26 @@ func newPodConfigurator(
 	return &podConfigurator{
 		ovsBridgeClient: ovsBridgeClient,
 		ofClient:        ofClient,
+		ovsCtlClient:    ovsCtlClient,
 		routeClient:     routeClient,
 		ifaceStore:      ifaceStore,
 		gatewayMAC:      gatewayMAC,
 		ifConfigurator:  ifConfigurator,
+		podIPv4CIDR:     podIPv4CIDR,
 	}, nil
 }
 
+// getPoolPodIP returns the IPv4 address of the Pod if it is allocated from an IPPool, or nil.
+func (pc *podConfigurator) getPoolPodIP(ips []net.IP) net.IP {
+	if pc.podIPv4CIDR == nil {
+		return nil
+	}
+	if podIPv4 := ip.GetIPv4Addr(ips); podIPv4 != nil && !pc.podIPv4CIDR.Contains(podIPv4) {
+		return podIPv4
+	}
+	return nil
+}
+
 func findContainerIPConfig(ips []*current.IPConfig) (*current.IPConfig, error) {
 	for _, ipc := range ips {
 		if ipc.Version == "4" {
@@ -108,19 +133,45 @@ func findContainerIPConfig(ips []*current.IPConfig) (*current.IPConfig, error) {
 	return nil, fmt.Errorf("failed to find a valid IP address")
 }
 
-func parseContainerIP(ips []*current.IPConfig) (net.IP, error) {
-	ipc, err := findContainerIPConfig(ips)
-	if err == nil {
-		return ipc.Address.IP, nil
+// parseContainerIPs returns the IPv4 and IPv6 addresses allocated to the container.
+func parseContainerIPs(ips []*current.IPConfig) ([]net.IP, error) {
+	var containerIPs []net.IP
+	for _, ipc := range ips {
+		if ipc.Version == "4" || ipc.Version == "6" {
+			containerIPs = append(containerIPs, ipc.Address.IP)
+		}
 	}
-	return nil, fmt.Errorf("failed to find a valid IP address")
+	if len(containerIPs) == 0 {
+		return nil, fmt.Errorf("failed to find a valid IP address")
+	}
+	return containerIPs, nil
+}
+
+// formatContainerIPs returns the string saved in the OVS port external_ids for the container IPs.
+func formatContainerIPs(ips []net.IP) string {
+	ipStrs := make([]string, len(ips))
+	for i, ip := range ips {
+		ipStrs[i] = ip.String()
+	}
+	return strings.Join(ipStrs, ",")
+}
+
+// parseContainerIPsFromExternalIDs parses the container IPs saved in the OVS port external_ids.
+func parseContainerIPsFromExternalIDs(ipStr string) []net.IP {
+	var ips []net.IP
+	for _, s := range strings.Split(ipStr, ",") {
+		if ip := net.ParseIP(strings.TrimSpace(s)); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
 }
 
 func buildContainerConfig(
 	interfaceName, containerID, podName, podNamespace string,
 	containerIface *current.Interface,
 	ips []*current.IPConfig) *interfacestore.InterfaceConfig {
-	containerIP, err := parseContainerIP(ips)
+	containerIPs, err := parseContainerIPs(ips)
 	if err != nil {
 		klog.Errorf("Failed to find container %s IP", containerID)
 	}
@@ -132,7 +183,7 @@ func buildContainerConfig(
 		podName,
 		podNamespace,
 		containerMAC,
-		containerIP)
+		containerIPs)
 }
 
 // BuildOVSPortExternalIDs parses OVS port external_ids from InterfaceConfig.
@@ -141,7 +192,7 @@ func BuildOVSPortExternalIDs(containerConfig *interfacestore.InterfaceConfig) ma
 	externalIDs := make(map[string]interface{})
 	externalIDs[ovsExternalIDMAC] = containerConfig.MAC.String()
 	externalIDs[ovsExternalIDContainerID] = containerConfig.ContainerID
-	externalIDs[ovsExternalIDIP] = containerConfig.IP.String()
+	externalIDs[ovsExternalIDIP] = formatContainerIPs(containerConfig.IPs)
 	externalIDs[ovsExternalIDPodName] = containerConfig.PodName
 	externalIDs[ovsExternalIDPodNamespace] = containerConfig.PodNamespace
 	return externalIDs
@@ -162,7 +213,7 @@ func ParseOVSPortInterfaceConfig(portData *ovsconfig.OVSPortData, portConfig *in
 		klog.V(2).Infof("OVS port %s has no %s in external_ids", portData.Name, ovsExternalIDContainerID)
 		return nil
 	}
-	containerIP := net.ParseIP(portData.ExternalIDs[ovsExternalIDIP])
+	containerIPs := parseContainerIPsFromExternalIDs(portData.ExternalIDs[ovsExternalIDIP])
 	containerMAC, err := net.ParseMAC(portData.ExternalIDs[ovsExternalIDMAC])
 	if err != nil {
 		klog.Errorf("Failed to parse MAC address from OVS external config %s: %v",
@@ -177,7 +228,7 @@ func ParseOVSPortInterfaceConfig(portData *ovsconfig.OVSPortData, portConfig *in
 		podName,
 		podNamespace,
 		containerMAC,
-		containerIP)
+		containerIPs)
 	interfaceConfig.OVSPortConfig = portConfig
 	return interfaceConfig
 }
@@ -191,6 +242,7 @@ func (pc *podConfigurator) configureInterfaces(
 	mtu int,
 	result *current.Result,
 	createOVSPort bool,
+	bandwidth *BandwidthEntry,
 ) error {
 	err := pc.ifConfigurator.configureContainerLink(podName, podNameSpace, containerID, containerNetNS, containerIFDev, mtu, result)
 	if err != nil {
@@ -234,6 +286,10 @@ func (pc *podConfigurator) configureInterfaces(
 		}
 	}()
 
+	if err = pc.configureBandwidth(containerConfig, bandwidth); err != nil {
+		return fmt.Errorf("failed to configure bandwidth limits for container %s: %v", containerID, err)
+	}
+
 	// Note that the IP address should be advertised after Pod OpenFlow entries are installed, otherwise the packet might
 	// be dropped by OVS.
 	if err = pc.ifConfigurator.advertiseContainerAddr(containerNetNS, containerIface.Name, result); err != nil {
@@ -245,6 +301,64 @@ func (pc *podConfigurator) configureInterfaces(
 	return nil
 }
 
+// bitsToKbits converts a rate in bps or a burst size in bits to kbps or kb, rounding a non-zero
+// value up to 1, as 0 disables the limit.
+func bitsToKbits(bits int64) int64 {
+	if bits > 0 && bits < 1000 {
+		return 1
+	}
+	return bits / 1000
+}
+
+// configureBandwidth limits the bandwidth of the Pod on its OVS port. The traffic sent by the Pod
+// enters OVS through the port, so the egress limits are enforced with the ingress policing of the
+// OVS interface, while the traffic sent to the Pod is shaped with the QoS of the port. Nothing is
+// done if the runtime did not pass the bandwidth capability.
+func (pc *podConfigurator) configureBandwidth(containerConfig *interfacestore.InterfaceConfig, bandwidth *BandwidthEntry) error {
+	if bandwidth == nil {
+		return nil
+	}
+	if err := pc.ovsBridgeClient.SetInterfaceIngressPolicing(containerConfig.InterfaceName, bitsToKbits(bandwidth.EgressRate), bitsToKbits(bandwidth.EgressBurst)); err != nil {
+		return fmt.Errorf("failed to set the ingress policing of OVS interface %s: %v", containerConfig.InterfaceName, err)
+	}
+	if err := pc.ovsBridgeClient.SetPortQoS(containerConfig.PortUUID, bandwidth.IngressRate, bandwidth.IngressBurst); err != nil {
+		return fmt.Errorf("failed to set the QoS of OVS port %s: %v", containerConfig.InterfaceName, err)
+	}
+	klog.V(2).Infof("Configured bandwidth limits %+v for container %s", *bandwidth, containerConfig.ContainerID)
+	return nil
+}
+
+// checkBandwidth checks that the bandwidth limits configured on the OVS port of the Pod match the
+// ones passed by the runtime, no limit being expected if the runtime did not pass any.
+func (pc *podConfigurator) checkBandwidth(containerID, podName, podNamespace string, bandwidth *BandwidthEntry) error {
+	containerConfig, found := pc.ifaceStore.GetContainerInterface(podName, podNamespace)
+	if !found {
+		return fmt.Errorf("container %s interface not found from local cache", containerID)
+	}
+	if bandwidth == nil {
+		bandwidth = &BandwidthEntry{}
+	}
+	rate, burst, err := pc.ovsBridgeClient.GetInterfaceIngressPolicing(containerConfig.InterfaceName)
+	if err != nil {
+		return fmt.Errorf("failed to get the ingress policing of OVS interface %s: %v", containerConfig.InterfaceName, err)
+	}
+	expectedRate, expectedBurst := bitsToKbits(bandwidth.EgressRate), bitsToKbits(bandwidth.EgressBurst)
+	// The burst size is irrelevant if the traffic is not limited.
+	if rate != expectedRate || (rate != 0 && burst != expectedBurst) {
+		return fmt.Errorf("egress bandwidth limit of container %s is %d kbps with burst %d kb, expected %d kbps with burst %d kb",
+			containerID, rate, burst, expectedRate, expectedBurst)
+	}
+	maxRate, burst, err := pc.ovsBridgeClient.GetPortQoS(containerConfig.PortUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get the QoS of OVS port %s: %v", containerConfig.InterfaceName, err)
+	}
+	if maxRate != bandwidth.IngressRate || (maxRate != 0 && burst != bandwidth.IngressBurst) {
+		return fmt.Errorf("ingress bandwidth limit of container %s is %d bps with burst %d bits, expected %d bps with burst %d bits",
+			containerID, maxRate, burst, bandwidth.IngressRate, bandwidth.IngressBurst)
+	}
+	return nil
+}
+
 func (pc *podConfigurator) createOVSPort(ovsPortName string, ovsAttachInfo map[string]interface{}) (string, error) {
 	var portUUID string
 	var err error
@@ -292,7 +406,8 @@ func (pc *podConfigurator) removeInterfaces(podName, podNamespace, containerID s
 func (pc *podConfigurator) checkInterfaces(
 	containerID, containerNetNS, podName, podNamespace string,
 	containerIface *current.Interface,
-	prevResult *current.Result) error {
+	prevResult *current.Result,
+	bandwidth *BandwidthEntry) error {
 	if containerVeth, err := pc.ifConfigurator.checkContainerInterface(
 		containerNetNS,
 		containerID,
@@ -309,6 +424,9 @@ func (pc *podConfigurator) checkInterfaces(
 		prevResult.IPs,
 		prevResult.Interfaces); err != nil {
 		return err
+	} else if err := pc.checkBandwidth(containerID, podName, podNamespace, bandwidth); err != nil {
+		klog.Errorf("Failed to check bandwidth limits of container %s: %v", containerID, err)
+		return err
 	}
 	return nil
 }
@@ -348,14 +466,22 @@ func (pc *podConfigurator) validateOVSInterfaceConfig(
 		}
 
 		for _, ipc := range ips {
-			if ipc.Version == "4" {
-				if containerConfig.IP.Equal(ipc.Address.IP) {
-					return nil
+			if ipc.Version != "4" && ipc.Version != "6" {
+				continue
+			}
+			found := false
+			for _, ip := range containerConfig.IPs {
+				if ip.Equal(ipc.Address.IP) {
+					found = true
+					break
 				}
 			}
+			if !found {
+				return fmt.Errorf("interface IPs %s do not match container %s IP %s",
+					formatContainerIPs(containerConfig.IPs), containerID, ipc.Address.IP.String())
+			}
 		}
-		return fmt.Errorf("interface IP %s does not match container %s IP",
-			containerConfig.IP.String(), containerID)
+		return nil
 	} else {
 		return fmt.Errorf("container %s interface not found from local cache", containerID)
 	}
@@ -411,7 +537,7 @@ func (pc *podConfigurator) reconcile(pods []corev1.Pod) error {
 		klog.V(4).Infof("Syncing interface %s for Pod %s/%s", containerConfig.InterfaceName, pod.Namespace, pod.Name)
 		if err := pc.ofClient.InstallPodFlows(
 			containerConfig.InterfaceName,
-			containerConfig.IP,
+			containerConfig.IPs,
 			containerConfig.MAC,
 			pc.gatewayMAC,
 			uint32(containerConfig.OFPort),
@@ -419,6 +545,12 @@ func (pc *podConfigurator) reconcile(pods []corev1.Pod) error {
 			klog.Errorf("Error when re-installing flows for Pod %s/%s", pod.Namespace, pod.Name)
 			continue
 		}
+		if poolPodIP := pc.getPoolPodIP(containerConfig.IPs); poolPodIP != nil {
+			if err := pc.routeClient.AddPoolPodRoute(poolPodIP); err != nil {
+				klog.Errorf("Error when re-installing route for Pod %s/%s: %v", pod.Namespace, pod.Name, err)
+				continue
+			}
+		}
 		desiredInterfaces[util.GenerateContainerInterfaceKey(pod.Name, pod.Namespace)] = true
 	}
 
@@ -482,10 +614,17 @@ func (pc *podConfigurator) connectInterfaceToOVS(
 	}
 
 	klog.V(2).Infof("Setting up Openflow entries for container %s", containerID)
-	err = pc.ofClient.InstallPodFlows(ovsPortName, containerConfig.IP, containerConfig.MAC, pc.gatewayMAC, uint32(ofPort))
+	err = pc.ofClient.InstallPodFlows(ovsPortName, containerConfig.IPs, containerConfig.MAC, pc.gatewayMAC, uint32(ofPort))
 	if err != nil {
 		return nil, fmt.Errorf("failed to add Openflow entries for container %s: %v", containerID, err)
 	}
+	if poolPodIP := pc.getPoolPodIP(containerConfig.IPs); poolPodIP != nil {
+		klog.V(2).Infof("Setting up route for container %s", containerID)
+		if err = pc.routeClient.AddPoolPodRoute(poolPodIP); err != nil {
+			_ = pc.ofClient.UninstallPodFlows(ovsPortName)
+			return nil, fmt.Errorf("failed to add route for container %s: %v", containerID, err)
+		}
+	}
 	containerConfig.OVSPortConfig = &interfacestore.OVSPortConfig{PortUUID: portUUID, OFPort: ofPort}
 	// Add containerConfig into local cache
 	pc.ifaceStore.AddInterface(containerConfig)
@@ -503,6 +642,11 @@ func (pc *podConfigurator) disconnectInterfaceFromOVS(containerConfig *interface
 		// the OVS flows added for the new Pod can conflict with the stale
 		// flows of the deleted Pod.
 	}
+	if poolPodIP := pc.getPoolPodIP(containerConfig.IPs); poolPodIP != nil {
+		if err := pc.routeClient.DeletePoolPodRoute(poolPodIP); err != nil {
+			return fmt.Errorf("failed to delete route for container %s: %v", containerID, err)
+		}
+	}
 
 	klog.V(2).Infof("Deleting OVS port %s for container %s", containerConfig.PortUUID, containerID)
 	// TODO: handle error and introduce garbage collection for failure on deletion
@@ -547,12 +691,95 @@ func (pc *podConfigurator) disconnectInterceptedInterface(podName, podNamespace,
 		klog.V(2).Infof("Did not find the port for container %s in local cache", containerID)
 		return nil
 	}
-	if err := pc.routeClient.UnMigrateRoutesFromGw(&net.IPNet{
-		IP:   containerConfig.IP,
-		Mask: net.CIDRMask(32, 32),
-	}, ""); err != nil {
-		return fmt.Errorf("connectInterceptedInterface failed to migrate: %w", err)
+	for _, ip := range containerConfig.IPs {
+		mask := net.CIDRMask(32, 32)
+		if ip.To4() == nil {
+			mask = net.CIDRMask(128, 128)
+		}
+		if err := pc.routeClient.UnMigrateRoutesFromGw(&net.IPNet{IP: ip, Mask: mask}, ""); err != nil {
+			return fmt.Errorf("connectInterceptedInterface failed to migrate: %w", err)
+		}
 	}
 	return pc.disconnectInterfaceFromOVS(containerConfig)
 	// TODO recover pre-connect state? repatch vethpair to original bridge etc ?? to make first CNI happy??
 }
+
+// checkInterceptedInterface checks the intercepted interface of a container against the prevResult
+// of the CNI CHECK request, and validates that it is still connected to ovs br-int as done by
+// connectInterceptedInterface.
+func (pc *podConfigurator) checkInterceptedInterface(
+	podName string,
+	podNamespace string,
+	containerID string,
+	containerNetNS string,
+	containerIFDev string,
+	prevResult *current.Result,
+) error {
+	containerConfig, found := pc.ifaceStore.GetContainerInterface(podName, podNamespace)
+	if !found {
+		return fmt.Errorf("container %s interface not found from local cache", containerID)
+	}
+	sandbox, err := util.GetNSPath(containerNetNS)
+	if err != nil {
+		return err
+	}
+	containerIface, hostIface, err := pc.ifConfigurator.getInterceptedInterfaces(sandbox, containerNetNS, containerIFDev)
+	if err != nil {
+		return err
+	}
+	if hostIface.Name != containerConfig.InterfaceName {
+		return fmt.Errorf("peer interface %s of container %s does not match OVS port %s",
+			hostIface.Name, containerID, containerConfig.InterfaceName)
+	}
+	// The MAC address is optional in the interfaces reported by the primary CNI.
+	for _, intf := range prevResult.Interfaces {
+		if intf.Name == containerIFDev && intf.Sandbox != "" && intf.Mac != "" && intf.Mac != containerIface.Mac {
+			return fmt.Errorf("interface %s MAC %s does not match prevResult MAC %s",
+				containerIFDev, containerIface.Mac, intf.Mac)
+		}
+	}
+	if err := pc.validateOVSInterfaceConfig(containerID, podName, podNamespace, containerIface.Mac, prevResult.IPs); err != nil {
+		return err
+	}
+	flows, err := pc.ovsCtlClient.DumpFlowMatchSet()
+	if err != nil {
+		return fmt.Errorf("failed to dump Openflow entries: %v", err)
+	}
+	return pc.validateInterceptedInterface(containerConfig, flows)
+}
+
+// validateInterceptedInterface validates that the host interface, the OVS port and the Pod flows of
+// an intercepted container interface still match the configuration in the local cache. The Pod
+// flows are looked up in flows, so that the flows can be dumped once to validate many interfaces.
+func (pc *podConfigurator) validateInterceptedInterface(containerConfig *interfacestore.InterfaceConfig, flows ovsctl.FlowMatchSet) error {
+	containerID := containerConfig.ContainerID
+	if err := pc.ifConfigurator.checkInterceptedHostInterface(containerConfig.InterfaceName); err != nil {
+		return err
+	}
+
+	portData, err := pc.ovsBridgeClient.GetPortData(containerConfig.PortUUID, containerConfig.InterfaceName)
+	if err != nil {
+		return fmt.Errorf("failed to get OVS port %s of container %s: %v", containerConfig.InterfaceName, containerID, err)
+	}
+	if portData.OFPort != containerConfig.OFPort {
+		return fmt.Errorf("OVS port %s of container %s has of_port %d instead of %d",
+			containerConfig.InterfaceName, containerID, portData.OFPort, containerConfig.OFPort)
+	}
+	for key, value := range BuildOVSPortExternalIDs(containerConfig) {
+		if portData.ExternalIDs[key] != value.(string) {
+			return fmt.Errorf("OVS port %s of container %s has external_ids %s=%q instead of %q",
+				containerConfig.InterfaceName, containerID, key, portData.ExternalIDs[key], value)
+		}
+	}
+
+	flowKeys := pc.ofClient.GetPodFlowKeys(containerConfig.InterfaceName)
+	if len(flowKeys) == 0 {
+		return fmt.Errorf("no Openflow entries cached for container %s", containerID)
+	}
+	for _, flowKey := range flowKeys {
+		if !flows.Has(flowKey) {
+			return fmt.Errorf("missing Openflow entry %s for container %s", flowKey, containerID)
+		}
+	}
+	return nil
+}
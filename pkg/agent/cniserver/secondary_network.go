@@ -0,0 +1,380 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cniserver
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/containernetworking/cni/pkg/types/current"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
+	"github.com/vmware-tanzu/antrea/pkg/agent/util"
+	ipamv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/ipam/v1alpha1"
+	netattachv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/netattach/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned"
+	"github.com/vmware-tanzu/antrea/pkg/ipam/poolallocator"
+	"github.com/vmware-tanzu/antrea/pkg/ovs/ovsconfig"
+)
+
+const (
+	ovsExternalIDSecondaryNetwork = "secondary-network"
+	ovsExternalIDIFName           = "if-name"
+	ovsExternalIDVLANID           = "vlan-id"
+	ovsExternalIDIPPool           = "ip-pool"
+
+	defaultSecondaryMTU = 1500
+)
+
+// networkAttachment is a SecondaryNetwork a Pod is attached to, with the name of the interface in
+// the Pod.
+type networkAttachment struct {
+	networkName string
+	ifName      string
+}
+
+// parseNetworksAnnotation parses the NetworksAnnotationKey annotation of a Pod. The interfaces
+// without an explicit name are named "net<N>", N being the position of the network in the list
+// starting from 1.
+func parseNetworksAnnotation(annotation, primaryIFName string) ([]networkAttachment, error) {
+	var attachments []networkAttachment
+	ifNames := sets.NewString(primaryIFName)
+	for i, item := range strings.Split(annotation, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		attachment := networkAttachment{networkName: item, ifName: "net" + strconv.Itoa(i+1)}
+		if parts := strings.SplitN(item, "@", 2); len(parts) == 2 {
+			attachment.networkName, attachment.ifName = parts[0], parts[1]
+		}
+		if attachment.networkName == "" {
+			return nil, fmt.Errorf("invalid network %q: empty SecondaryNetwork name", item)
+		}
+		if attachment.ifName == "" || len(attachment.ifName) > 15 || strings.ContainsAny(attachment.ifName, "/ ") {
+			return nil, fmt.Errorf("invalid network %q: invalid interface name %q", item, attachment.ifName)
+		}
+		if ifNames.Has(attachment.ifName) {
+			return nil, fmt.Errorf("invalid network %q: duplicate interface name %q", item, attachment.ifName)
+		}
+		ifNames.Insert(attachment.ifName)
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
+
+// secondaryNetworkConfigurator configures the secondary interfaces of the Pods annotated with
+// SecondaryNetworks. A secondary interface is a veth pair whose host side is connected to the OVS
+// bridge of the SecondaryNetwork, as an access port of the VLAN of the SecondaryNetwork if it has
+// one. Unlike the Antrea bridge, these bridges are standalone L2 switches, without any flow
+// installed by Antrea, and the traffic of the secondary interfaces is not subject to
+// NetworkPolicies.
+type secondaryNetworkConfigurator struct {
+	kubeClient     clientset.Interface
+	crdClient      versioned.Interface
+	nodeName       string
+	ifaceStore     interfacestore.InterfaceStore
+	ifConfigurator interfaceConfigurator
+	// newBridgeClient returns an OVSBridgeClient for the OVS bridge with the provided name.
+	newBridgeClient func(bridgeName string) ovsconfig.OVSBridgeClient
+	// bridgeClients caches the OVSBridgeClients of the bridges which have been created.
+	bridgeClients map[string]ovsconfig.OVSBridgeClient
+	bridgeMutex   sync.Mutex
+}
+
+func newSecondaryNetworkConfigurator(
+	kubeClient clientset.Interface,
+	crdClient versioned.Interface,
+	nodeName string,
+	ifaceStore interfacestore.InterfaceStore,
+	ifConfigurator interfaceConfigurator,
+	newBridgeClient func(bridgeName string) ovsconfig.OVSBridgeClient,
+) *secondaryNetworkConfigurator {
+	return &secondaryNetworkConfigurator{
+		kubeClient:      kubeClient,
+		crdClient:       crdClient,
+		nodeName:        nodeName,
+		ifaceStore:      ifaceStore,
+		ifConfigurator:  ifConfigurator,
+		newBridgeClient: newBridgeClient,
+		bridgeClients:   map[string]ovsconfig.OVSBridgeClient{},
+	}
+}
+
+// getBridgeClient returns the OVSBridgeClient of the bridge, creating the bridge first if it is
+// not known yet.
+func (sc *secondaryNetworkConfigurator) getBridgeClient(bridgeName string) (ovsconfig.OVSBridgeClient, error) {
+	sc.bridgeMutex.Lock()
+	defer sc.bridgeMutex.Unlock()
+	if bridgeClient, ok := sc.bridgeClients[bridgeName]; ok {
+		return bridgeClient, nil
+	}
+	bridgeClient := sc.newBridgeClient(bridgeName)
+	if err := bridgeClient.Create(); err != nil {
+		return nil, fmt.Errorf("failed to create OVS bridge %s: %v", bridgeName, err)
+	}
+	sc.bridgeClients[bridgeName] = bridgeClient
+	return bridgeClient, nil
+}
+
+func getSecondaryNetworkBridge(network *netattachv1alpha1.SecondaryNetwork) string {
+	if network.Spec.Bridge != "" {
+		return network.Spec.Bridge
+	}
+	return netattachv1alpha1.DefaultBridge
+}
+
+func buildSecondaryOVSPortExternalIDs(interfaceConfig *interfacestore.InterfaceConfig) map[string]interface{} {
+	externalIDs := BuildOVSPortExternalIDs(interfaceConfig)
+	externalIDs[ovsExternalIDSecondaryNetwork] = interfaceConfig.NetworkName
+	externalIDs[ovsExternalIDIFName] = interfaceConfig.IFName
+	externalIDs[ovsExternalIDVLANID] = strconv.Itoa(int(interfaceConfig.VLANID))
+	externalIDs[ovsExternalIDIPPool] = interfaceConfig.IPPool
+	return externalIDs
+}
+
+// parseSecondaryOVSPortInterfaceConfig reads the Pod and SecondaryNetwork properties saved in the
+// external_ids of an OVS port of a secondary bridge. nil is returned if the OVS port is not
+// created for a secondary interface.
+func parseSecondaryOVSPortInterfaceConfig(portData *ovsconfig.OVSPortData, bridgeName string) *interfacestore.InterfaceConfig {
+	networkName, found := portData.ExternalIDs[ovsExternalIDSecondaryNetwork]
+	if !found {
+		return nil
+	}
+	interfaceConfig := ParseOVSPortInterfaceConfig(portData, &interfacestore.OVSPortConfig{PortUUID: portData.UUID, OFPort: portData.OFPort})
+	if interfaceConfig == nil {
+		return nil
+	}
+	vlanID, _ := strconv.ParseUint(portData.ExternalIDs[ovsExternalIDVLANID], 10, 16)
+	interfaceConfig.Type = interfacestore.SecondaryInterface
+	interfaceConfig.SecondaryInterfaceConfig = &interfacestore.SecondaryInterfaceConfig{
+		NetworkName: networkName,
+		IFName:      portData.ExternalIDs[ovsExternalIDIFName],
+		BridgeName:  bridgeName,
+		VLANID:      uint16(vlanID),
+		IPPool:      portData.ExternalIDs[ovsExternalIDIPPool],
+	}
+	return interfaceConfig
+}
+
+// restoreInterfaces adds the secondary interfaces connected to the bridges of the existing
+// SecondaryNetworks to the interface store. It must be called before any secondary interface is
+// configured.
+func (sc *secondaryNetworkConfigurator) restoreInterfaces() error {
+	networks, err := sc.crdClient.NetattachV1alpha1().SecondaryNetworks().List(metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list SecondaryNetworks: %v", err)
+	}
+	bridgeNames := sets.NewString()
+	for i := range networks.Items {
+		bridgeNames.Insert(getSecondaryNetworkBridge(&networks.Items[i]))
+	}
+	for _, bridgeName := range bridgeNames.List() {
+		ports, err := sc.newBridgeClient(bridgeName).GetPortList()
+		if err != nil {
+			return fmt.Errorf("failed to list the ports of OVS bridge %s: %v", bridgeName, err)
+		}
+		for i := range ports {
+			if interfaceConfig := parseSecondaryOVSPortInterfaceConfig(&ports[i], bridgeName); interfaceConfig != nil {
+				sc.ifaceStore.AddInterface(interfaceConfig)
+			}
+		}
+	}
+	return nil
+}
+
+// configureSecondaryInterfaces configures the secondary interfaces of the SecondaryNetworks the
+// Pod is annotated with. The secondary interfaces already configured are removed if it fails to
+// configure any of them.
+func (sc *secondaryNetworkConfigurator) configureSecondaryInterfaces(podName, podNamespace, containerID, containerNetNS, primaryIFName string) error {
+	pod, err := sc.kubeClient.CoreV1().Pods(podNamespace).Get(podName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get Pod %s/%s: %v", podNamespace, podName, err)
+	}
+	annotation, ok := pod.Annotations[netattachv1alpha1.NetworksAnnotationKey]
+	if !ok {
+		return nil
+	}
+	attachments, err := parseNetworksAnnotation(annotation, primaryIFName)
+	if err != nil {
+		return fmt.Errorf("invalid annotation %s of Pod %s/%s: %v", netattachv1alpha1.NetworksAnnotationKey, podNamespace, podName, err)
+	}
+	success := false
+	defer func() {
+		if !success {
+			_ = sc.removeSecondaryInterfaces(podName, podNamespace, containerID)
+		}
+	}()
+	for _, attachment := range attachments {
+		network, err := sc.crdClient.NetattachV1alpha1().SecondaryNetworks().Get(attachment.networkName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get SecondaryNetwork %s: %v", attachment.networkName, err)
+		}
+		if err := sc.configureSecondaryInterface(pod, containerID, containerNetNS, attachment.ifName, network); err != nil {
+			return err
+		}
+	}
+	success = true
+	return nil
+}
+
+// allocateSecondaryIP allocates an IP to the secondary interface from the IPPool of the
+// SecondaryNetwork, and returns the IPAM result of the interface.
+func (sc *secondaryNetworkConfigurator) allocateSecondaryIP(pod *corev1.Pod, containerID, ifName, ipPool string) (*current.Result, error) {
+	allocator := poolallocator.NewIPPoolAllocator(ipPool, sc.crdClient)
+	ip, subnetInfo, err := allocator.AllocateIP(ipamv1alpha1.IPAddressOwner{Pod: &ipamv1alpha1.PodOwner{
+		Name:        pod.Name,
+		Namespace:   pod.Namespace,
+		ContainerID: containerID,
+		NodeName:    sc.nodeName,
+		IFName:      ifName,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	containerIndex := 1
+	return &current.Result{IPs: []*current.IPConfig{{
+		Version:   "4",
+		Interface: &containerIndex,
+		Address:   net.IPNet{IP: ip, Mask: net.CIDRMask(int(subnetInfo.PrefixLength), 32)},
+		Gateway:   net.ParseIP(subnetInfo.Gateway),
+	}}}, nil
+}
+
+func (sc *secondaryNetworkConfigurator) configureSecondaryInterface(
+	pod *corev1.Pod,
+	containerID string,
+	containerNetNS string,
+	ifName string,
+	network *netattachv1alpha1.SecondaryNetwork,
+) error {
+	bridgeName := getSecondaryNetworkBridge(network)
+	bridgeClient, err := sc.getBridgeClient(bridgeName)
+	if err != nil {
+		return err
+	}
+	result := &current.Result{}
+	if network.Spec.IPPool != "" {
+		if result, err = sc.allocateSecondaryIP(pod, containerID, ifName, network.Spec.IPPool); err != nil {
+			return fmt.Errorf("failed to allocate IP for interface %s of container %s: %v", ifName, containerID, err)
+		}
+	}
+	mtu := int(network.Spec.MTU)
+	if mtu == 0 {
+		mtu = defaultSecondaryMTU
+	}
+	hostIfaceName := util.GenerateSecondaryInterfaceName(pod.Name, pod.Namespace, ifName)
+	// The interface is added to the interface store as soon as its veth pair is created, so that
+	// removeSecondaryInterfaces cleans it up if any later step fails.
+	interfaceConfig := interfacestore.NewSecondaryInterface(hostIfaceName, containerID, pod.Name, pod.Namespace, nil, nil,
+		&interfacestore.SecondaryInterfaceConfig{
+			NetworkName: network.Name,
+			IFName:      ifName,
+			BridgeName:  bridgeName,
+			VLANID:      network.Spec.VLAN,
+			IPPool:      network.Spec.IPPool,
+		})
+	sc.ifaceStore.AddInterface(interfaceConfig)
+	if err := sc.ifConfigurator.configureSecondaryContainerLink(hostIfaceName, containerID, containerNetNS, ifName, mtu, result); err != nil {
+		return err
+	}
+	containerIface := result.Interfaces[1]
+	interfaceConfig.MAC, _ = net.ParseMAC(containerIface.Mac)
+	for _, ipc := range result.IPs {
+		interfaceConfig.IPs = append(interfaceConfig.IPs, ipc.Address.IP)
+	}
+
+	klog.V(2).Infof("Adding OVS port %s to bridge %s for interface %s of container %s", hostIfaceName, bridgeName, ifName, containerID)
+	portUUID, err := bridgeClient.CreateAccessPort(hostIfaceName, hostIfaceName, network.Spec.VLAN, buildSecondaryOVSPortExternalIDs(interfaceConfig))
+	if err != nil {
+		return fmt.Errorf("failed to add OVS port for interface %s of container %s: %v", ifName, containerID, err)
+	}
+	interfaceConfig.OVSPortConfig = &interfacestore.OVSPortConfig{PortUUID: portUUID}
+
+	if len(result.IPs) > 0 {
+		if err := sc.ifConfigurator.advertiseContainerAddr(containerNetNS, ifName, result); err != nil {
+			klog.Errorf("Failed to advertise IP address for interface %s of container %s: %v", ifName, containerID, err)
+		}
+	}
+	klog.Infof("Configured interface %s of container %s on SecondaryNetwork %s", ifName, containerID, network.Name)
+	return nil
+}
+
+// removeSecondaryInterfaces removes all the secondary interfaces of the Pod, and releases their
+// IPs. It continues with the other interfaces if it fails to remove one, and returns the first
+// error.
+func (sc *secondaryNetworkConfigurator) removeSecondaryInterfaces(podName, podNamespace, containerID string) error {
+	var firstErr error
+	for _, interfaceConfig := range sc.ifaceStore.GetSecondaryInterfaces(podName, podNamespace) {
+		if err := sc.removeSecondaryInterface(interfaceConfig); err != nil {
+			klog.Errorf("Failed to remove interface %s of container %s: %v", interfaceConfig.IFName, containerID, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+func (sc *secondaryNetworkConfigurator) removeSecondaryInterface(interfaceConfig *interfacestore.InterfaceConfig) error {
+	containerID := interfaceConfig.ContainerID
+	if interfaceConfig.OVSPortConfig != nil {
+		bridgeClient, err := sc.getBridgeClient(interfaceConfig.BridgeName)
+		if err != nil {
+			return err
+		}
+		klog.V(2).Infof("Deleting OVS port %s from bridge %s for container %s", interfaceConfig.InterfaceName, interfaceConfig.BridgeName, containerID)
+		if err := bridgeClient.DeletePort(interfaceConfig.PortUUID); err != nil {
+			return fmt.Errorf("failed to delete OVS port %s: %v", interfaceConfig.InterfaceName, err)
+		}
+		interfaceConfig.OVSPortConfig = nil
+	}
+	if err := sc.ifConfigurator.removeContainerLink(containerID, interfaceConfig.InterfaceName); err != nil {
+		return err
+	}
+	if interfaceConfig.IPPool != "" {
+		allocator := poolallocator.NewIPPoolAllocator(interfaceConfig.IPPool, sc.crdClient)
+		if err := allocator.ReleaseContainerIP(containerID, interfaceConfig.IFName); err != nil {
+			return fmt.Errorf("failed to release IP of interface %s of container %s: %v", interfaceConfig.IFName, containerID, err)
+		}
+	}
+	sc.ifaceStore.DeleteInterface(interfaceConfig)
+	return nil
+}
+
+// reconcile removes the secondary interfaces of the Pods which no longer run on the Node.
+func (sc *secondaryNetworkConfigurator) reconcile(pods []corev1.Pod) {
+	desiredPods := sets.NewString()
+	for _, pod := range pods {
+		desiredPods.Insert(util.GenerateContainerInterfaceKey(pod.Name, pod.Namespace))
+	}
+	for _, interfaceConfig := range sc.ifaceStore.GetInterfacesByType(interfacestore.SecondaryInterface) {
+		if desiredPods.Has(util.GenerateContainerInterfaceKey(interfaceConfig.PodName, interfaceConfig.PodNamespace)) {
+			continue
+		}
+		klog.V(4).Infof("Deleting interface %s of Pod %s/%s", interfaceConfig.IFName, interfaceConfig.PodNamespace, interfaceConfig.PodName)
+		if err := sc.removeSecondaryInterface(interfaceConfig); err != nil {
+			klog.Errorf("Failed to delete interface %s of Pod %s/%s: %v", interfaceConfig.IFName, interfaceConfig.PodNamespace, interfaceConfig.PodName, err)
+		}
+	}
+}
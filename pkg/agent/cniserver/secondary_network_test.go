@@ -0,0 +1,271 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cniserver
+
+import (
+	"net"
+	"testing"
+
+	"github.com/containernetworking/cni/pkg/types/current"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
+	"github.com/vmware-tanzu/antrea/pkg/agent/util"
+	ipamv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/ipam/v1alpha1"
+	netattachv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/netattach/v1alpha1"
+	crdfake "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/fake"
+	"github.com/vmware-tanzu/antrea/pkg/ovs/ovsconfig"
+	ovsconfigtest "github.com/vmware-tanzu/antrea/pkg/ovs/ovsconfig/testing"
+)
+
+func TestParseNetworksAnnotation(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotation  string
+		expected    []networkAttachment
+		expectedErr bool
+	}{
+		{
+			name:       "default-names",
+			annotation: "net-a, net-b",
+			expected:   []networkAttachment{{"net-a", "net1"}, {"net-b", "net2"}},
+		},
+		{
+			name:       "explicit-names",
+			annotation: "net-a@data,net-b",
+			expected:   []networkAttachment{{"net-a", "data"}, {"net-b", "net2"}},
+		},
+		{
+			name:        "duplicate-names",
+			annotation:  "net-a@net2,net-b",
+			expectedErr: true,
+		},
+		{
+			name:        "primary-name",
+			annotation:  "net-a@eth0",
+			expectedErr: true,
+		},
+		{
+			name:        "long-name",
+			annotation:  "net-a@interface-name-1",
+			expectedErr: true,
+		},
+		{
+			name:        "empty-network",
+			annotation:  "@data",
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachments, err := parseNetworksAnnotation(tt.annotation, "eth0")
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, attachments)
+			}
+		})
+	}
+}
+
+// fakeSecondaryIfConfigurator records the secondary veth pairs instead of creating them.
+type fakeSecondaryIfConfigurator struct {
+	interfaceConfigurator
+	links map[string]*current.Result
+}
+
+func (ic *fakeSecondaryIfConfigurator) configureSecondaryContainerLink(hostIfaceName, containerID, containerNetNS, containerIFDev string, mtu int, result *current.Result) error {
+	result.Interfaces = []*current.Interface{
+		{Name: hostIfaceName, Mac: "aa:bb:cc:dd:ee:00"},
+		{Name: containerIFDev, Mac: "aa:bb:cc:dd:ee:01", Sandbox: containerNetNS},
+	}
+	ic.links[hostIfaceName] = result
+	return nil
+}
+
+func (ic *fakeSecondaryIfConfigurator) advertiseContainerAddr(containerNetNS string, containerIfaceName string, result *current.Result) error {
+	return nil
+}
+
+func (ic *fakeSecondaryIfConfigurator) removeContainerLink(containerID, hostInterfaceName string) error {
+	delete(ic.links, hostInterfaceName)
+	return nil
+}
+
+type secondaryNetworkTester struct {
+	configurator   *secondaryNetworkConfigurator
+	ifConfigurator *fakeSecondaryIfConfigurator
+	crdClient      *crdfake.Clientset
+	ifaceStore     interfacestore.InterfaceStore
+	bridgeClients  map[string]*ovsconfigtest.MockOVSBridgeClient
+}
+
+func newSecondaryNetworkTester(controller *gomock.Controller, networksAnnotation string, networks ...*netattachv1alpha1.SecondaryNetwork) *secondaryNetworkTester {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:        testPodName,
+		Namespace:   testPodNamespace,
+		Annotations: map[string]string{netattachv1alpha1.NetworksAnnotationKey: networksAnnotation},
+	}}
+	pool := &ipamv1alpha1.IPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool1"},
+		Spec: ipamv1alpha1.IPPoolSpec{IPRanges: []ipamv1alpha1.SubnetIPRange{{
+			IPRange:    ipamv1alpha1.IPRange{CIDR: "192.168.10.0/24"},
+			SubnetInfo: ipamv1alpha1.SubnetInfo{Gateway: "192.168.10.1", PrefixLength: 24},
+		}}},
+	}
+	crdClient := crdfake.NewSimpleClientset(pool)
+	for _, network := range networks {
+		crdClient.NetattachV1alpha1().SecondaryNetworks().Create(network)
+	}
+	tester := &secondaryNetworkTester{
+		ifConfigurator: &fakeSecondaryIfConfigurator{links: map[string]*current.Result{}},
+		crdClient:      crdClient,
+		ifaceStore:     interfacestore.NewInterfaceStore(),
+		bridgeClients: map[string]*ovsconfigtest.MockOVSBridgeClient{
+			netattachv1alpha1.DefaultBridge: ovsconfigtest.NewMockOVSBridgeClient(controller),
+			"br-data":                       ovsconfigtest.NewMockOVSBridgeClient(controller),
+		},
+	}
+	tester.configurator = newSecondaryNetworkConfigurator(k8sfake.NewSimpleClientset(pod), crdClient, "node1",
+		tester.ifaceStore, tester.ifConfigurator, func(bridgeName string) ovsconfig.OVSBridgeClient {
+			return tester.bridgeClients[bridgeName]
+		})
+	return tester
+}
+
+func (tester *secondaryNetworkTester) getIPAddresses(t *testing.T) []ipamv1alpha1.IPAddressState {
+	pool, err := tester.crdClient.IpamV1alpha1().IPPools().Get("pool1", metav1.GetOptions{})
+	require.NoError(t, err)
+	return pool.Status.IPAddresses
+}
+
+var (
+	vlanNetwork = &netattachv1alpha1.SecondaryNetwork{
+		ObjectMeta: metav1.ObjectMeta{Name: "vlan10"},
+		Spec:       netattachv1alpha1.SecondaryNetworkSpec{VLAN: 10, IPPool: "pool1"},
+	}
+	bridgeNetwork = &netattachv1alpha1.SecondaryNetwork{
+		ObjectMeta: metav1.ObjectMeta{Name: "data"},
+		Spec:       netattachv1alpha1.SecondaryNetworkSpec{Bridge: "br-data"},
+	}
+)
+
+func TestConfigureSecondaryInterfaces(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	tester := newSecondaryNetworkTester(controller, "vlan10,data@data0", vlanNetwork, bridgeNetwork)
+
+	vlanIfaceName := util.GenerateSecondaryInterfaceName(testPodName, testPodNamespace, "net1")
+	dataIfaceName := util.GenerateSecondaryInterfaceName(testPodName, testPodNamespace, "data0")
+	defaultBridge := tester.bridgeClients[netattachv1alpha1.DefaultBridge]
+	dataBridge := tester.bridgeClients["br-data"]
+	defaultBridge.EXPECT().Create().Return(nil)
+	dataBridge.EXPECT().Create().Return(nil)
+	defaultBridge.EXPECT().CreateAccessPort(vlanIfaceName, vlanIfaceName, uint16(10), gomock.Any()).DoAndReturn(
+		func(name, ifDev string, vlanID uint16, externalIDs map[string]interface{}) (string, ovsconfig.Error) {
+			assert.Equal(t, "vlan10", externalIDs[ovsExternalIDSecondaryNetwork])
+			assert.Equal(t, "net1", externalIDs[ovsExternalIDIFName])
+			assert.Equal(t, "192.168.10.2", externalIDs[ovsExternalIDIP])
+			return "port1", nil
+		})
+	dataBridge.EXPECT().CreateAccessPort(dataIfaceName, dataIfaceName, uint16(0), gomock.Any()).Return("port2", nil)
+
+	require.NoError(t, tester.configurator.configureSecondaryInterfaces(testPodName, testPodNamespace, testPodInfraContainerID, netns, "eth0"))
+	interfaces := tester.ifaceStore.GetSecondaryInterfaces(testPodName, testPodNamespace)
+	assert.Len(t, interfaces, 2)
+	vlanIface, ok := tester.ifaceStore.GetInterface(util.GenerateSecondaryInterfaceKey(testPodName, testPodNamespace, "net1"))
+	require.True(t, ok)
+	assert.Equal(t, "port1", vlanIface.PortUUID)
+	assert.Equal(t, netattachv1alpha1.DefaultBridge, vlanIface.BridgeName)
+	assert.Equal(t, []net.IP{net.ParseIP("192.168.10.2").To4()}, vlanIface.IPs)
+	vlanResult := tester.ifConfigurator.links[vlanIfaceName]
+	require.Len(t, vlanResult.IPs, 1)
+	assert.Equal(t, "192.168.10.2/24", vlanResult.IPs[0].Address.String())
+	assert.Empty(t, vlanResult.Routes, "The default route must not be changed by secondary interfaces")
+	assert.Empty(t, tester.ifConfigurator.links[dataIfaceName].IPs)
+	addresses := tester.getIPAddresses(t)
+	require.Len(t, addresses, 1)
+	assert.Equal(t, "net1", addresses[0].Owner.Pod.IFName)
+
+	// All the secondary interfaces are removed, and their IPs released.
+	defaultBridge.EXPECT().DeletePort("port1").Return(nil)
+	dataBridge.EXPECT().DeletePort("port2").Return(nil)
+	require.NoError(t, tester.configurator.removeSecondaryInterfaces(testPodName, testPodNamespace, testPodInfraContainerID))
+	assert.Empty(t, tester.ifaceStore.GetSecondaryInterfaces(testPodName, testPodNamespace))
+	assert.Empty(t, tester.ifConfigurator.links)
+	assert.Empty(t, tester.getIPAddresses(t))
+}
+
+func TestConfigureSecondaryInterfacesFailure(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	// The second SecondaryNetwork does not exist.
+	tester := newSecondaryNetworkTester(controller, "vlan10,data", vlanNetwork)
+
+	vlanIfaceName := util.GenerateSecondaryInterfaceName(testPodName, testPodNamespace, "net1")
+	defaultBridge := tester.bridgeClients[netattachv1alpha1.DefaultBridge]
+	defaultBridge.EXPECT().Create().Return(nil)
+	defaultBridge.EXPECT().CreateAccessPort(vlanIfaceName, vlanIfaceName, uint16(10), gomock.Any()).Return("port1", nil)
+	defaultBridge.EXPECT().DeletePort("port1").Return(nil)
+
+	assert.Error(t, tester.configurator.configureSecondaryInterfaces(testPodName, testPodNamespace, testPodInfraContainerID, netns, "eth0"))
+	assert.Empty(t, tester.ifaceStore.GetSecondaryInterfaces(testPodName, testPodNamespace))
+	assert.Empty(t, tester.ifConfigurator.links)
+	assert.Empty(t, tester.getIPAddresses(t))
+}
+
+func TestRestoreSecondaryInterfaces(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	tester := newSecondaryNetworkTester(controller, "", vlanNetwork, bridgeNetwork)
+
+	containerMAC, _ := net.ParseMAC("aa:bb:cc:dd:ee:01")
+	interfaceConfig := interfacestore.NewSecondaryInterface("vlan-iface", testPodInfraContainerID, testPodName, testPodNamespace,
+		containerMAC, []net.IP{net.ParseIP("192.168.10.2")}, &interfacestore.SecondaryInterfaceConfig{
+			NetworkName: "vlan10",
+			IFName:      "net1",
+			BridgeName:  netattachv1alpha1.DefaultBridge,
+			VLANID:      10,
+			IPPool:      "pool1",
+		})
+	externalIDs := make(map[string]string)
+	for k, v := range buildSecondaryOVSPortExternalIDs(interfaceConfig) {
+		externalIDs[k] = v.(string)
+	}
+	tester.bridgeClients[netattachv1alpha1.DefaultBridge].EXPECT().GetPortList().Return([]ovsconfig.OVSPortData{
+		{UUID: "port1", Name: "vlan-iface", ExternalIDs: externalIDs, OFPort: 1},
+		{UUID: "port0", Name: "uplink"},
+	}, nil)
+	tester.bridgeClients["br-data"].EXPECT().GetPortList().Return(nil, nil)
+
+	require.NoError(t, tester.configurator.restoreInterfaces())
+	interfaceConfig.OVSPortConfig = &interfacestore.OVSPortConfig{PortUUID: "port1", OFPort: 1}
+	restored, ok := tester.ifaceStore.GetInterface(util.GenerateSecondaryInterfaceKey(testPodName, testPodNamespace, "net1"))
+	require.True(t, ok)
+	assert.Equal(t, interfaceConfig, restored)
+	assert.Equal(t, 1, tester.ifaceStore.Len())
+
+	// The interfaces of the Pods which no longer run on the Node are removed.
+	tester.bridgeClients[netattachv1alpha1.DefaultBridge].EXPECT().Create().Return(nil)
+	tester.bridgeClients[netattachv1alpha1.DefaultBridge].EXPECT().DeletePort("port1").Return(nil)
+	tester.configurator.reconcile([]corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: testPodNamespace}}})
+	assert.Equal(t, 0, tester.ifaceStore.Len())
+}
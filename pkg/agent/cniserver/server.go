@@ -22,6 +22,7 @@ import (
 	"net"
 	"strings"
 	"sync"
+	"time"
 
 	cnitypes "github.com/containernetworking/cni/pkg/types"
 	"github.com/containernetworking/cni/pkg/types/current"
@@ -29,6 +30,7 @@ import (
 	"github.com/containernetworking/plugins/pkg/ip"
 	"google.golang.org/grpc"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog"
 
@@ -40,8 +42,11 @@ import (
 	"github.com/vmware-tanzu/antrea/pkg/agent/util"
 	cnipb "github.com/vmware-tanzu/antrea/pkg/apis/cni/v1beta1"
 	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+	"github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned"
 	"github.com/vmware-tanzu/antrea/pkg/cni"
+	"github.com/vmware-tanzu/antrea/pkg/features"
 	"github.com/vmware-tanzu/antrea/pkg/ovs/ovsconfig"
+	"github.com/vmware-tanzu/antrea/pkg/ovs/ovsctl"
 )
 
 // containerAccessArbitrator is used to ensure that concurrent goroutines cannot perfom operations
@@ -101,10 +106,21 @@ type CNIServer struct {
 	podUpdates  chan<- v1beta1.PodReference
 	isChaining  bool
 	routeClient route.Interface
+	// interfaceDrift stores the errors found by the last audit of the intercepted interfaces,
+	// indexed by container key.
+	interfaceDrift      map[string]string
+	interfaceDriftMutex sync.RWMutex
+	// secondaryNetworkConfigurator is only set when the SecondaryNetwork feature is enabled.
+	secondaryNetworkConfigurator *secondaryNetworkConfigurator
+	crdClient                    versioned.Interface
+	newBridgeClient              func(bridgeName string) ovsconfig.OVSBridgeClient
 }
 
 const (
 	supportedCNIVersions = "0.1.0,0.2.0,0.3.0,0.3.1,0.4.0"
+	// interfaceAuditInterval is the interval at which the intercepted interfaces are audited
+	// in policy only mode.
+	interfaceAuditInterval = 1 * time.Minute
 )
 
 var supportedCNIVersionSet map[string]bool
@@ -114,8 +130,18 @@ type RuntimeDNS struct {
 	Search      []string `json:"searches,omitempty"`
 }
 
+// BandwidthEntry is the bandwidth capability passed by the runtime. The rates are in bits per
+// second and the bursts in bits, and a rate of 0 means that the traffic is not limited.
+type BandwidthEntry struct {
+	IngressRate  int64 `json:"ingressRate"`
+	IngressBurst int64 `json:"ingressBurst"`
+	EgressRate   int64 `json:"egressRate"`
+	EgressBurst  int64 `json:"egressBurst"`
+}
+
 type RuntimeConfig struct {
-	DNS RuntimeDNS `json:"dns"`
+	DNS       RuntimeDNS      `json:"dns"`
+	Bandwidth *BandwidthEntry `json:"bandwidth,omitempty"`
 }
 
 type NetworkConfig struct {
@@ -142,8 +168,10 @@ type CNIConfig struct {
 //   * updates the IP configuration for each assigned IP address: this includes computing the
 //     gateway (if missing) based on the subnet and setting the interface pointer to the container
 //     interface
-//   * if there is no default route, add one using the provided default gateway
-func updateResultIfaceConfig(result *current.Result, defaultV4Gateway net.IP) {
+//   * if there is no default route, add one using the provided default gateway of each IP family
+//     for which an address is assigned
+func updateResultIfaceConfig(result *current.Result, defaultV4Gateway, defaultV6Gateway net.IP) {
+	hasIPv4, hasIPv6 := false, false
 	for _, ipc := range result.IPs {
 		// result.Interfaces[0] is host interface, and result.Interfaces[1] is container interface
 		ipc.Interface = current.Int(1)
@@ -152,26 +180,36 @@ func updateResultIfaceConfig(result *current.Result, defaultV4Gateway net.IP) {
 			netID := ipn.IP.Mask(ipn.Mask)
 			ipc.Gateway = ip.NextIP(netID)
 		}
+		if ipc.Address.IP.To4() != nil {
+			hasIPv4 = true
+		} else {
+			hasIPv6 = true
+		}
 	}
 
-	foundDefaultRoute := false
-	defaultRouteDst := "0.0.0.0/0"
-	if result.Routes != nil {
-		for _, rt := range result.Routes {
-			if rt.Dst.String() == defaultRouteDst {
-				foundDefaultRoute = true
-				break
-			}
-		}
-	} else {
+	if result.Routes == nil {
 		result.Routes = []*cnitypes.Route{}
 	}
-	if !foundDefaultRoute {
-		_, defaultRouteDstNet, _ := net.ParseCIDR(defaultRouteDst)
-		result.Routes = append(result.Routes, &cnitypes.Route{Dst: *defaultRouteDstNet, GW: defaultV4Gateway})
+	if hasIPv4 {
+		addDefaultRoute(result, "0.0.0.0/0", defaultV4Gateway)
+	}
+	if hasIPv6 && defaultV6Gateway != nil {
+		addDefaultRoute(result, "::/0", defaultV6Gateway)
 	}
 }
 
+// addDefaultRoute adds a default route with the provided destination to the result if it does not
+// have one.
+func addDefaultRoute(result *current.Result, defaultRouteDst string, gateway net.IP) {
+	for _, rt := range result.Routes {
+		if rt.Dst.String() == defaultRouteDst {
+			return
+		}
+	}
+	_, defaultRouteDstNet, _ := net.ParseCIDR(defaultRouteDst)
+	result.Routes = append(result.Routes, &cnitypes.Route{Dst: *defaultRouteDstNet, GW: gateway})
+}
+
 func (s *CNIServer) loadNetworkConfig(request *cnipb.CniCmdRequest) (*CNIConfig, error) {
 	cniConfig := &CNIConfig{}
 	cniConfig.CniCmdArgs = request.CniArgs
@@ -184,6 +222,11 @@ func (s *CNIServer) loadNetworkConfig(request *cnipb.CniCmdRequest) (*CNIConfig,
 	}
 	if !s.isChaining {
 		s.updateLocalIPAMSubnet(cniConfig)
+		// The Antrea IPAM driver allocates the IPs of the Pods from their IPPool, and delegates to the
+		// configured IPAM plugin for the Pods without IPPool.
+		if features.DefaultFeatureGate.Enabled(features.AntreaIPAM) {
+			cniConfig.IPAM.Type = ipam.AntreaIPAMType
+		}
 	}
 	if cniConfig.MTU == 0 {
 		cniConfig.MTU = s.defaultMTU
@@ -209,6 +252,12 @@ func (s *CNIServer) checkRequestMessage(request *cnipb.CniCmdRequest) (*CNIConfi
 		klog.Errorf(fmt.Sprintf("Unsupported CNI version [%s], supported CNI versions [%s]", cniVersion, supportedCNIVersions))
 		return cniConfig, s.incompatibleCniVersionResponse(cniVersion)
 	}
+	if bandwidth := cniConfig.RuntimeConfig.Bandwidth; bandwidth != nil {
+		if bandwidth.IngressRate < 0 || bandwidth.IngressBurst < 0 || bandwidth.EgressRate < 0 || bandwidth.EgressBurst < 0 {
+			klog.Errorf("Invalid bandwidth limits %+v", *bandwidth)
+			return cniConfig, s.unsupportedFieldResponse("runtimeConfig/bandwidth", *bandwidth)
+		}
+	}
 	if s.isChaining {
 		return cniConfig, nil
 	}
@@ -222,9 +271,23 @@ func (s *CNIServer) checkRequestMessage(request *cnipb.CniCmdRequest) (*CNIConfi
 	return cniConfig, nil
 }
 
+// updateLocalIPAMSubnet sets the Pod subnets of the Node in the IPAM configuration. The IPv4 subnet
+// is used as the default subnet if there is one, and the IPv6 subnet is added as an additional
+// range on a dual-stack Node, so that the IPAM plugin allocates one address of each IP family.
 func (s *CNIServer) updateLocalIPAMSubnet(cniConfig *CNIConfig) {
-	cniConfig.NetworkConfig.IPAM.Gateway = s.nodeConfig.GatewayConfig.IP.String()
-	cniConfig.NetworkConfig.IPAM.Subnet = s.nodeConfig.PodCIDR.String()
+	gatewayConfig := s.nodeConfig.GatewayConfig
+	ipamConfig := &cniConfig.NetworkConfig.IPAM
+	ipamConfig.Ranges = nil
+	if s.nodeConfig.PodIPv4CIDR != nil {
+		ipamConfig.Gateway = gatewayConfig.IPv4.String()
+		ipamConfig.Subnet = s.nodeConfig.PodIPv4CIDR.String()
+		if s.nodeConfig.PodIPv6CIDR != nil {
+			ipamConfig.Ranges = []ipam.RangeSet{{{Subnet: s.nodeConfig.PodIPv6CIDR.String(), Gateway: gatewayConfig.IPv6.String()}}}
+		}
+	} else if s.nodeConfig.PodIPv6CIDR != nil {
+		ipamConfig.Gateway = gatewayConfig.IPv6.String()
+		ipamConfig.Subnet = s.nodeConfig.PodIPv6CIDR.String()
+	}
 	cniConfig.NetworkConfiguration, _ = json.Marshal(cniConfig.NetworkConfig)
 }
 
@@ -321,7 +384,7 @@ func (s *CNIServer) parsePrevResultFromRequest(networkConfig *NetworkConfig) (*c
 	return prevResult, nil
 }
 
-func (s *CNIServer) validatePrevResult(cfgArgs *cnipb.CniCmdArgs, k8sCNIArgs *k8sArgs, prevResult *current.Result) (*cnipb.CniCmdResponse, error) {
+func (s *CNIServer) validatePrevResult(cfgArgs *cnipb.CniCmdArgs, k8sCNIArgs *k8sArgs, prevResult *current.Result, bandwidth *BandwidthEntry) (*cnipb.CniCmdResponse, error) {
 	containerID := cfgArgs.ContainerId
 	netNS := s.hostNetNsPath(cfgArgs.Netns)
 	podName := string(k8sCNIArgs.K8S_POD_NAME)
@@ -340,7 +403,8 @@ func (s *CNIServer) validatePrevResult(cfgArgs *cnipb.CniCmdArgs, k8sCNIArgs *k8
 		podName,
 		podNamespace,
 		containerIntf,
-		prevResult); err != nil {
+		prevResult,
+		bandwidth); err != nil {
 		return s.checkInterfaceFailureResponse(err), nil
 	}
 
@@ -406,7 +470,7 @@ func (s *CNIServer) CmdAdd(ctx context.Context, request *cnipb.CniCmdRequest) (*
 	result.IPs = ipamResult.IPs
 	result.Routes = ipamResult.Routes
 	// Ensure interface gateway setting and mapping relations between result.Interfaces and result.IPs
-	updateResultIfaceConfig(result, s.nodeConfig.GatewayConfig.IP)
+	updateResultIfaceConfig(result, s.nodeConfig.GatewayConfig.IPv4, s.nodeConfig.GatewayConfig.IPv6)
 	// Setup pod interfaces and connect to ovs bridge
 	podName := string(cniConfig.K8S_POD_NAME)
 	podNamespace := string(cniConfig.K8S_POD_NAMESPACE)
@@ -420,10 +484,23 @@ func (s *CNIServer) CmdAdd(ctx context.Context, request *cnipb.CniCmdRequest) (*
 		cniConfig.MTU,
 		result,
 		isInfraContainer,
+		cniConfig.RuntimeConfig.Bandwidth,
 	); err != nil {
 		klog.Errorf("Failed to configure interfaces for container %s: %v", cniConfig.ContainerId, err)
 		return s.configInterfaceFailureResponse(err), nil
 	}
+	if isInfraContainer && s.secondaryNetworkConfigurator != nil {
+		if err = s.secondaryNetworkConfigurator.configureSecondaryInterfaces(
+			podName,
+			podNamespace,
+			cniConfig.ContainerId,
+			netNS,
+			cniConfig.Ifname,
+		); err != nil {
+			klog.Errorf("Failed to configure secondary interfaces for container %s: %v", cniConfig.ContainerId, err)
+			return s.configInterfaceFailureResponse(err), nil
+		}
+	}
 
 	// Notify the Pod update event to required components.
 	s.podUpdates <- v1beta1.PodReference{Name: podName, Namespace: podNamespace}
@@ -461,6 +538,12 @@ func (s *CNIServer) CmdDel(_ context.Context, request *cnipb.CniCmdRequest) (
 	// Remove host interface and OVS configuration
 	podName := string(cniConfig.K8S_POD_NAME)
 	podNamespace := string(cniConfig.K8S_POD_NAMESPACE)
+	if s.secondaryNetworkConfigurator != nil {
+		if err := s.secondaryNetworkConfigurator.removeSecondaryInterfaces(podName, podNamespace, cniConfig.ContainerId); err != nil {
+			klog.Errorf("Failed to remove secondary interfaces for container %s: %v", cniConfig.ContainerId, err)
+			return s.configInterfaceFailureResponse(err), nil
+		}
+	}
 	if err := s.podConfigurator.removeInterfaces(podName, podNamespace, cniConfig.ContainerId); err != nil {
 		klog.Errorf("Failed to remove interfaces for container %s: %v", cniConfig.ContainerId, err)
 		return s.configInterfaceFailureResponse(err), nil
@@ -493,7 +576,7 @@ func (s *CNIServer) CmdCheck(_ context.Context, request *cnipb.CniCmdRequest) (
 	if valid, _ := version.GreaterThanOrEqualTo(cniVersion, "0.4.0"); valid {
 		if prevResult, response := s.parsePrevResultFromRequest(cniConfig.NetworkConfig); response != nil {
 			return response, nil
-		} else if response, err := s.validatePrevResult(cniConfig.CniCmdArgs, cniConfig.k8sArgs, prevResult); err != nil {
+		} else if response, err := s.validatePrevResult(cniConfig.CniCmdArgs, cniConfig.k8sArgs, prevResult, cniConfig.RuntimeConfig.Bandwidth); err != nil {
 			return response, err
 		}
 	}
@@ -530,17 +613,37 @@ func New(
 	}
 }
 
+// EnableSecondaryNetworks enables the secondary interfaces of the Pods annotated with
+// SecondaryNetworks. newBridgeClient returns an OVSBridgeClient for the OVS bridge of a
+// SecondaryNetwork. It must be called before Initialize.
+func (s *CNIServer) EnableSecondaryNetworks(crdClient versioned.Interface, newBridgeClient func(bridgeName string) ovsconfig.OVSBridgeClient) {
+	s.crdClient = crdClient
+	s.newBridgeClient = newBridgeClient
+}
+
 func (s *CNIServer) Initialize(
 	ovsBridgeClient ovsconfig.OVSBridgeClient,
 	ofClient openflow.Client,
+	ovsCtlClient ovsctl.OVSCtlClient,
 	ifaceStore interfacestore.InterfaceStore,
 	ovsDatapathType string,
 ) error {
 	var err error
-	s.podConfigurator, err = newPodConfigurator(ovsBridgeClient, ofClient, s.routeClient, ifaceStore, s.nodeConfig.GatewayConfig.MAC, ovsDatapathType)
+	var podIPv4CIDR *net.IPNet
+	if features.DefaultFeatureGate.Enabled(features.AntreaIPAM) {
+		podIPv4CIDR = s.nodeConfig.PodIPv4CIDR
+	}
+	s.podConfigurator, err = newPodConfigurator(ovsBridgeClient, ofClient, ovsCtlClient, s.routeClient, ifaceStore, s.nodeConfig.GatewayConfig.MAC, podIPv4CIDR, ovsDatapathType)
 	if err != nil {
 		return fmt.Errorf("error during initialize podConfigurator: %v", err)
 	}
+	if s.newBridgeClient != nil {
+		s.secondaryNetworkConfigurator = newSecondaryNetworkConfigurator(s.kubeClient, s.crdClient, s.nodeConfig.Name,
+			ifaceStore, s.podConfigurator.ifConfigurator, s.newBridgeClient)
+		if err := s.secondaryNetworkConfigurator.restoreInterfaces(); err != nil {
+			return fmt.Errorf("error during initialize secondaryNetworkConfigurator: %v", err)
+		}
+	}
 	if err := s.reconcile(); err != nil {
 		return fmt.Errorf("error during initial reconciliation for CNI server: %v", err)
 	}
@@ -564,6 +667,9 @@ func (s *CNIServer) Run(stopCh <-chan struct{}) {
 			klog.Errorf("Failed to serve connections: %v", err)
 		}
 	}()
+	if s.isChaining {
+		go wait.Until(s.auditInterceptedInterfaces, interfaceAuditInterval, stopCh)
+	}
 	<-stopCh
 }
 
@@ -603,12 +709,81 @@ func (s *CNIServer) interceptDel(cniConfig *CNIConfig) (*cnipb.CniCmdResponse, e
 		cniConfig.ContainerId)
 }
 
-func (s *CNIServer) interceptCheck(_ *CNIConfig) (*cnipb.CniCmdResponse, error) {
+// interceptCheck handles Check request in policy only mode. The intercepted interface is checked
+// against the prevResult of the request, which is only provided from CNI version 0.4.0, and its
+// host interface, OVS port and Pod flows are validated against the configuration done by
+// interceptAdd.
+func (s *CNIServer) interceptCheck(cniConfig *CNIConfig) (*cnipb.CniCmdResponse, error) {
 	klog.Infof("CNI Chaining: check")
-	// TODO, check for host interface setup later
+	if valid, _ := version.GreaterThanOrEqualTo(cniConfig.CNIVersion, "0.4.0"); !valid {
+		return &cnipb.CniCmdResponse{CniResult: make([]byte, 0, 0)}, nil
+	}
+	prevResult, response := s.parsePrevResultFromRequest(cniConfig.NetworkConfig)
+	if response != nil {
+		klog.Infof("Failed to parse prev result for container %s", cniConfig.ContainerId)
+		return response, nil
+	}
+	if err := s.podConfigurator.checkInterceptedInterface(
+		string(cniConfig.K8S_POD_NAME),
+		string(cniConfig.K8S_POD_NAMESPACE),
+		cniConfig.ContainerId,
+		s.hostNetNsPath(cniConfig.Netns),
+		cniConfig.Ifname,
+		prevResult); err != nil {
+		klog.Errorf("Failed to check intercepted interface of container %s: %v", cniConfig.ContainerId, err)
+		return s.checkInterfaceFailureResponse(err), nil
+	}
 	return &cnipb.CniCmdResponse{CniResult: make([]byte, 0, 0)}, nil
 }
 
+// auditInterceptedInterfaces validates the intercepted interfaces of all the local Pods in policy
+// only mode, and records the ones which drifted from the configuration done by interceptAdd, e.g.
+// because the host interface was re-attached to the bridge of the primary CNI or because the Pod
+// flows were removed. The drift is reported through the agent conditions. The flows are dumped once
+// per audit, and the containers are only locked to confirm the drift of an interface.
+func (s *CNIServer) auditInterceptedInterfaces() {
+	// The interfaces are listed before dumping the flows, as the flows of an interface are
+	// installed before it's added to the local cache.
+	containerConfigs := s.podConfigurator.ifaceStore.GetInterfacesByType(interfacestore.ContainerInterface)
+	flows, err := s.podConfigurator.ovsCtlClient.DumpFlowMatchSet()
+	if err != nil {
+		klog.Errorf("Failed to dump Openflow entries to audit the intercepted interfaces: %v", err)
+		return
+	}
+	interfaceDrift := make(map[string]string)
+	for _, containerConfig := range containerConfigs {
+		err := s.podConfigurator.validateInterceptedInterface(containerConfig, flows)
+		if err == nil {
+			continue
+		}
+		podName, podNamespace := containerConfig.PodName, containerConfig.PodNamespace
+		containerKey := fmt.Sprintf("%s/%s", podNamespace, podName)
+		// The interface may have been removed or replaced by a concurrent CNI request, in
+		// which case the error is not a drift.
+		s.containerAccess.lockContainer(containerKey)
+		if currentConfig, found := s.podConfigurator.ifaceStore.GetContainerInterface(podName, podNamespace); found && currentConfig == containerConfig {
+			klog.Warningf("Intercepted interface of Pod %s drifted from its configuration: %v", containerKey, err)
+			interfaceDrift[containerKey] = err.Error()
+		}
+		s.containerAccess.unlockContainer(containerKey)
+	}
+	s.interfaceDriftMutex.Lock()
+	defer s.interfaceDriftMutex.Unlock()
+	s.interfaceDrift = interfaceDrift
+}
+
+// GetPodInterfaceDrift returns the Pods whose intercepted interface drifted from its configuration
+// at the last audit, indexed by container key, with the error found for each of them.
+func (s *CNIServer) GetPodInterfaceDrift() map[string]string {
+	s.interfaceDriftMutex.RLock()
+	defer s.interfaceDriftMutex.RUnlock()
+	interfaceDrift := make(map[string]string, len(s.interfaceDrift))
+	for pod, drift := range s.interfaceDrift {
+		interfaceDrift[pod] = drift
+	}
+	return interfaceDrift
+}
+
 // reconcile performs startup reconciliation for the CNI server. The CNI server is in charge of
 // installing Pod flows, so as part of this reconciliation process we retrieve the Pod list from the
 // K8s apiserver and replay the necessary flows.
@@ -621,6 +796,9 @@ func (s *CNIServer) reconcile() error {
 		return fmt.Errorf("failed to list Pods running on Node %s: %v", s.nodeConfig.Name, err)
 	}
 
+	if s.secondaryNetworkConfigurator != nil {
+		s.secondaryNetworkConfigurator.reconcile(pods.Items)
+	}
 	return s.podConfigurator.reconcile(pods.Items)
 }
 
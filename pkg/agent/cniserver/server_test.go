@@ -39,6 +39,8 @@ import (
 	"github.com/vmware-tanzu/antrea/pkg/cni"
 	"github.com/vmware-tanzu/antrea/pkg/ovs/ovsconfig"
 	ovsconfigtest "github.com/vmware-tanzu/antrea/pkg/ovs/ovsconfig/testing"
+	"github.com/vmware-tanzu/antrea/pkg/ovs/ovsctl"
+	ovsctltest "github.com/vmware-tanzu/antrea/pkg/ovs/ovsctl/testing"
 )
 
 const (
@@ -79,11 +81,11 @@ func TestLoadNetConfig(t *testing.T) {
 	assert.Equal(networkCfg.Name, netCfg.Name)
 	assert.Equal(networkCfg.IPAM.Type, netCfg.IPAM.Type)
 	assert.Equal(
-		netCfg.IPAM.Subnet, testNodeConfig.PodCIDR.String(),
+		netCfg.IPAM.Subnet, testNodeConfig.PodIPv4CIDR.String(),
 		"Network configuration (PodCIDR) was not updated",
 	)
 	assert.Equal(
-		netCfg.IPAM.Gateway, testNodeConfig.GatewayConfig.IP.String(),
+		netCfg.IPAM.Gateway, testNodeConfig.GatewayConfig.IPv4.String(),
 		"Network configuration (Gateway IP) was not updated",
 	)
 }
@@ -220,6 +222,23 @@ func TestCheckRequestMessage(t *testing.T) {
 		_, response := cniServer.checkRequestMessage(&requestMsg)
 		checkErrorResponse(t, response, cnipb.ErrorCode_UNSUPPORTED_FIELD, "")
 	})
+
+	t.Run("Bandwidth limits", func(t *testing.T) {
+		networkCfg := generateNetworkConfiguration("testCfg", supportedCNIVersion)
+		networkCfg.RuntimeConfig.Bandwidth = &BandwidthEntry{IngressRate: 1000000, IngressBurst: 2147483647}
+		requestMsg, _ := newRequest(args, networkCfg, "", t)
+		cniConfig, response := cniServer.checkRequestMessage(&requestMsg)
+		require.Nil(t, response)
+		assert.Equal(t, networkCfg.RuntimeConfig.Bandwidth, cniConfig.RuntimeConfig.Bandwidth)
+	})
+
+	t.Run("Invalid bandwidth limits", func(t *testing.T) {
+		networkCfg := generateNetworkConfiguration("testCfg", supportedCNIVersion)
+		networkCfg.RuntimeConfig.Bandwidth = &BandwidthEntry{EgressRate: -1}
+		requestMsg, _ := newRequest(args, networkCfg, "", t)
+		_, response := cniServer.checkRequestMessage(&requestMsg)
+		checkErrorResponse(t, response, cnipb.ErrorCode_UNSUPPORTED_FIELD, "runtimeConfig/bandwidth")
+	})
 }
 
 func TestValidatePrevResult(t *testing.T) {
@@ -251,7 +270,7 @@ func TestValidatePrevResult(t *testing.T) {
 		cniConfig := baseCNIConfig()
 		cniConfig.Ifname = "invalid_iface" // invalid
 		prevResult.Interfaces = []*current.Interface{hostIface, containerIface}
-		response, _ := cniServer.validatePrevResult(cniConfig.CniCmdArgs, k8sPodArgs, prevResult)
+		response, _ := cniServer.validatePrevResult(cniConfig.CniCmdArgs, k8sPodArgs, prevResult, nil)
 		checkErrorResponse(
 			t, response, cnipb.ErrorCode_INVALID_NETWORK_CONFIG,
 			"prevResult does not match network configuration",
@@ -263,8 +282,8 @@ func TestValidatePrevResult(t *testing.T) {
 		cniConfig.Ifname = ifname
 		cniConfig.Netns = "invalid_netns"
 		prevResult.Interfaces = []*current.Interface{hostIface, containerIface}
-		cniServer.podConfigurator, _ = newPodConfigurator(nil, nil, nil, nil, nil, "")
-		response, _ := cniServer.validatePrevResult(cniConfig.CniCmdArgs, k8sPodArgs, prevResult)
+		cniServer.podConfigurator, _ = newPodConfigurator(nil, nil, nil, nil, nil, nil, nil, "")
+		response, _ := cniServer.validatePrevResult(cniConfig.CniCmdArgs, k8sPodArgs, prevResult, nil)
 		checkErrorResponse(t, response, cnipb.ErrorCode_CHECK_INTERFACE_FAILURE, "")
 	})
 }
@@ -314,13 +333,13 @@ func TestUpdateResultIfaceConfig(t *testing.T) {
 	// return a Result with 2 v4 addresses.
 	testIps := []string{"10.1.2.100/24, ,4", "192.168.1.100/24, 192.168.2.253, 4"}
 
-	require.Equal(gwIP, testNodeConfig.GatewayConfig.IP)
+	require.Equal(gwIP, testNodeConfig.GatewayConfig.IPv4)
 
 	t.Run("Gateways updated", func(t *testing.T) {
 		assert := assert.New(t)
 
 		result := ipamtest.GenerateIPAMResult(supportedCNIVersion, testIps, routes, dns)
-		updateResultIfaceConfig(result, gwIP)
+		updateResultIfaceConfig(result, gwIP, nil)
 
 		assert.Len(result.IPs, 2, "Failed to construct result")
 		for _, ipc := range result.IPs {
@@ -338,7 +357,7 @@ func TestUpdateResultIfaceConfig(t *testing.T) {
 	t.Run("Default route added", func(t *testing.T) {
 		emptyRoutes := []string{}
 		result := ipamtest.GenerateIPAMResult(supportedCNIVersion, testIps, emptyRoutes, dns)
-		updateResultIfaceConfig(result, gwIP)
+		updateResultIfaceConfig(result, gwIP, nil)
 		require.NotEmpty(t, result.Routes)
 		defaultRoute := func() *cnitypes.Route {
 			for _, route := range result.Routes {
@@ -350,6 +369,31 @@ func TestUpdateResultIfaceConfig(t *testing.T) {
 		}()
 		assert.NotNil(t, defaultRoute.GW)
 	})
+
+	t.Run("Dual-stack default routes added", func(t *testing.T) {
+		assert := assert.New(t)
+
+		dualStackIPs := []string{"10.1.2.100/24, ,4", "fd00:10:1:2::100/64, ,6"}
+		gwIPv6 := net.ParseIP("fd00:10:1:2::1")
+		result := ipamtest.GenerateIPAMResult(supportedCNIVersion, dualStackIPs, []string{}, dns)
+		updateResultIfaceConfig(result, gwIP, gwIPv6)
+
+		for _, ipc := range result.IPs {
+			switch ipc.Address.IP.String() {
+			case "10.1.2.100":
+				assert.Equal("10.1.2.1", ipc.Gateway.String())
+			case "fd00:10:1:2::100":
+				assert.Equal("fd00:10:1:2::1", ipc.Gateway.String())
+			default:
+				t.Errorf("Unexpected IP address in CNI result")
+			}
+		}
+		defaultRouteGWs := map[string]string{}
+		for _, route := range result.Routes {
+			defaultRouteGWs[route.Dst.String()] = route.GW.String()
+		}
+		assert.Equal(map[string]string{"0.0.0.0/0": gwIP.String(), "::/0": gwIPv6.String()}, defaultRouteGWs)
+	})
 }
 
 func TestValidateOVSInterface(t *testing.T) {
@@ -381,7 +425,7 @@ func TestRemoveInterface(t *testing.T) {
 	mockOFClient := openflowtest.NewMockClient(controller)
 	ifaceStore := interfacestore.NewInterfaceStore()
 	gwMAC, _ := net.ParseMAC("00:00:11:11:11:11")
-	podConfigurator, err := newPodConfigurator(mockOVSBridgeClient, mockOFClient, nil, ifaceStore, gwMAC, "system")
+	podConfigurator, err := newPodConfigurator(mockOVSBridgeClient, mockOFClient, nil, nil, ifaceStore, gwMAC, nil, "system")
 	require.Nil(t, err, "No error expected in podConfigurator constructor")
 
 	containerMAC, _ := net.ParseMAC("aa:bb:cc:dd:ee:ff")
@@ -412,7 +456,7 @@ func TestRemoveInterface(t *testing.T) {
 			podName,
 			testPodNamespace,
 			containerMAC,
-			containerIP)
+			[]net.IP{containerIP})
 		containerConfig.OVSPortConfig = &interfacestore.OVSPortConfig{fakePortUUID, 0}
 	}
 
@@ -455,11 +499,255 @@ func TestRemoveInterface(t *testing.T) {
 	})
 }
 
+func TestConfigureBandwidth(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	mockOVSBridgeClient := ovsconfigtest.NewMockOVSBridgeClient(controller)
+	podConfigurator := &podConfigurator{ovsBridgeClient: mockOVSBridgeClient}
+	hostIfaceName := util.GenerateContainerInterfaceName(testPodName, testPodNamespace)
+	containerConfig := interfacestore.NewContainerInterface(hostIfaceName, uuid.New().String(), testPodName, testPodNamespace, nil, nil)
+	containerConfig.OVSPortConfig = &interfacestore.OVSPortConfig{PortUUID: uuid.New().String()}
+
+	// Nothing is configured if the runtime did not pass the bandwidth capability.
+	require.NoError(t, podConfigurator.configureBandwidth(containerConfig, nil))
+
+	// The egress limits are converted to kbps and kb for the ingress policing.
+	bandwidth := &BandwidthEntry{IngressRate: 1000000, IngressBurst: 2147483647, EgressRate: 2000000, EgressBurst: 500}
+	mockOVSBridgeClient.EXPECT().SetInterfaceIngressPolicing(hostIfaceName, int64(2000), int64(1)).Return(nil)
+	mockOVSBridgeClient.EXPECT().SetPortQoS(containerConfig.PortUUID, int64(1000000), int64(2147483647)).Return(nil)
+	require.NoError(t, podConfigurator.configureBandwidth(containerConfig, bandwidth))
+
+	mockOVSBridgeClient.EXPECT().SetInterfaceIngressPolicing(hostIfaceName, int64(2000), int64(1)).Return(ovsconfig.NewTransactionError(fmt.Errorf("error while updating interface"), true))
+	assert.Error(t, podConfigurator.configureBandwidth(containerConfig, bandwidth))
+}
+
+func TestCheckBandwidth(t *testing.T) {
+	ifaceStore := interfacestore.NewInterfaceStore()
+	containerID := uuid.New().String()
+	hostIfaceName := util.GenerateContainerInterfaceName(testPodName, testPodNamespace)
+	portUUID := uuid.New().String()
+	containerConfig := interfacestore.NewContainerInterface(hostIfaceName, containerID, testPodName, testPodNamespace, nil, nil)
+	containerConfig.OVSPortConfig = &interfacestore.OVSPortConfig{PortUUID: portUUID}
+	ifaceStore.AddInterface(containerConfig)
+	bandwidth := &BandwidthEntry{IngressRate: 1000000, IngressBurst: 2000000, EgressRate: 2000000, EgressBurst: 3000000}
+
+	tests := []struct {
+		name          string
+		bandwidth     *BandwidthEntry
+		policingRate  int64
+		policingBurst int64
+		qosMaxRate    int64
+		qosBurst      int64
+		expectedErr   bool
+	}{
+		{"no-limit", nil, 0, 0, 0, 0, false},
+		{"no-limit-burst-ignored", nil, 0, 8000, 0, 0, false},
+		{"limits", bandwidth, 2000, 3000, 1000000, 2000000, false},
+		{"egress-rate-drifted", bandwidth, 1000, 3000, 1000000, 2000000, true},
+		{"egress-burst-drifted", bandwidth, 2000, 8000, 1000000, 2000000, true},
+		{"ingress-rate-drifted", bandwidth, 2000, 3000, 0, 0, true},
+		{"ingress-burst-drifted", bandwidth, 2000, 3000, 1000000, 0, true},
+		{"unexpected-limits", nil, 2000, 3000, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+			mockOVSBridgeClient := ovsconfigtest.NewMockOVSBridgeClient(controller)
+			podConfigurator := &podConfigurator{ovsBridgeClient: mockOVSBridgeClient, ifaceStore: ifaceStore}
+			mockOVSBridgeClient.EXPECT().GetInterfaceIngressPolicing(hostIfaceName).Return(tt.policingRate, tt.policingBurst, nil)
+			mockOVSBridgeClient.EXPECT().GetPortQoS(portUUID).Return(tt.qosMaxRate, tt.qosBurst, nil).MaxTimes(1)
+			err := podConfigurator.checkBandwidth(containerID, testPodName, testPodNamespace, tt.bandwidth)
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// fakeInterceptedIfConfigurator reports the host interfaces of intercepted containers as attached
+// to the OVS bridge, unless they are in detachedInterfaces.
+type fakeInterceptedIfConfigurator struct {
+	interfaceConfigurator
+	detachedInterfaces map[string]bool
+}
+
+func (ic *fakeInterceptedIfConfigurator) checkInterceptedHostInterface(hostIfaceName string) error {
+	if ic.detachedInterfaces[hostIfaceName] {
+		return fmt.Errorf("interface %s is not attached to the OVS bridge", hostIfaceName)
+	}
+	return nil
+}
+
+func newInterceptedContainerConfig(podName string) *interfacestore.InterfaceConfig {
+	containerMAC, _ := net.ParseMAC("aa:bb:cc:dd:ee:ff")
+	containerConfig := interfacestore.NewContainerInterface(
+		util.GenerateContainerInterfaceName(podName, testPodNamespace),
+		uuid.New().String(),
+		podName,
+		testPodNamespace,
+		containerMAC,
+		[]net.IP{net.ParseIP("10.1.2.100")})
+	containerConfig.OVSPortConfig = &interfacestore.OVSPortConfig{PortUUID: uuid.New().String(), OFPort: 3}
+	return containerConfig
+}
+
+func newInterceptedPortData(containerConfig *interfacestore.InterfaceConfig) *ovsconfig.OVSPortData {
+	externalIDs := make(map[string]string)
+	for k, v := range BuildOVSPortExternalIDs(containerConfig) {
+		externalIDs[k] = v.(string)
+	}
+	return &ovsconfig.OVSPortData{
+		UUID:        containerConfig.PortUUID,
+		Name:        containerConfig.InterfaceName,
+		IFName:      containerConfig.InterfaceName,
+		OFPort:      containerConfig.OFPort,
+		ExternalIDs: externalIDs,
+	}
+}
+
+func TestValidateInterceptedInterface(t *testing.T) {
+	flowKeys := []string{"table=0,in_port=3", "table=10,ip,in_port=3,dl_src=aa:bb:cc:dd:ee:ff,nw_src=10.1.2.100"}
+	tests := []struct {
+		name              string
+		detached          bool
+		updatePortData    func(portData *ovsconfig.OVSPortData)
+		portDataErr       ovsconfig.Error
+		flowKeys          []string
+		missingFlow       bool
+		expectedErrSubstr string
+	}{
+		{
+			name:     "valid",
+			flowKeys: flowKeys,
+		},
+		{
+			name:              "host-interface-detached",
+			detached:          true,
+			expectedErrSubstr: "not attached to the OVS bridge",
+		},
+		{
+			name:              "missing-port",
+			portDataErr:       ovsconfig.NewTransactionError(fmt.Errorf("port not found"), false),
+			expectedErrSubstr: "failed to get OVS port",
+		},
+		{
+			name:              "ofport-mismatch",
+			updatePortData:    func(portData *ovsconfig.OVSPortData) { portData.OFPort = 4 },
+			expectedErrSubstr: "has of_port 4 instead of 3",
+		},
+		{
+			name:              "external-ids-mismatch",
+			updatePortData:    func(portData *ovsconfig.OVSPortData) { portData.ExternalIDs[ovsExternalIDIP] = "10.1.2.101" },
+			expectedErrSubstr: ovsExternalIDIP,
+		},
+		{
+			name:              "no-flows",
+			expectedErrSubstr: "no Openflow entries",
+		},
+		{
+			name:              "missing-flow",
+			flowKeys:          flowKeys,
+			missingFlow:       true,
+			expectedErrSubstr: "missing Openflow entry " + flowKeys[1],
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+			mockOVSBridgeClient := ovsconfigtest.NewMockOVSBridgeClient(controller)
+			mockOFClient := openflowtest.NewMockClient(controller)
+			mockOVSCtlClient := ovsctltest.NewMockOVSCtlClient(controller)
+			containerConfig := newInterceptedContainerConfig(testPodName)
+			ifConfigurator := &fakeInterceptedIfConfigurator{detachedInterfaces: map[string]bool{}}
+			if tt.detached {
+				ifConfigurator.detachedInterfaces[containerConfig.InterfaceName] = true
+			}
+			podConfigurator := &podConfigurator{
+				ovsBridgeClient: mockOVSBridgeClient,
+				ofClient:        mockOFClient,
+				ovsCtlClient:    mockOVSCtlClient,
+				ifConfigurator:  ifConfigurator,
+			}
+
+			var flowMatches []string
+			if !tt.detached {
+				portData := newInterceptedPortData(containerConfig)
+				if tt.updatePortData != nil {
+					tt.updatePortData(portData)
+				}
+				if tt.portDataErr != nil {
+					portData = nil
+				}
+				mockOVSBridgeClient.EXPECT().GetPortData(containerConfig.PortUUID, containerConfig.InterfaceName).Return(portData, tt.portDataErr)
+				if tt.portDataErr == nil && tt.updatePortData == nil {
+					mockOFClient.EXPECT().GetPodFlowKeys(containerConfig.InterfaceName).Return(tt.flowKeys)
+					for i, flowKey := range tt.flowKeys {
+						if !tt.missingFlow || i < len(tt.flowKeys)-1 {
+							flowMatches = append(flowMatches, flowKey)
+						}
+					}
+				}
+			}
+
+			err := podConfigurator.validateInterceptedInterface(containerConfig, ovsctl.NewFlowMatchSet(flowMatches...))
+			if tt.expectedErrSubstr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErrSubstr)
+			}
+		})
+	}
+}
+
+func TestAuditInterceptedInterfaces(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	mockOVSBridgeClient := ovsconfigtest.NewMockOVSBridgeClient(controller)
+	mockOFClient := openflowtest.NewMockClient(controller)
+	mockOVSCtlClient := ovsctltest.NewMockOVSCtlClient(controller)
+	ifaceStore := interfacestore.NewInterfaceStore()
+	healthyConfig := newInterceptedContainerConfig("test-healthy")
+	driftedConfig := newInterceptedContainerConfig("test-drifted")
+	ifaceStore.AddInterface(healthyConfig)
+	ifaceStore.AddInterface(driftedConfig)
+	cniServer := newCNIServer(t)
+	cniServer.isChaining = true
+	cniServer.podConfigurator = &podConfigurator{
+		ovsBridgeClient: mockOVSBridgeClient,
+		ofClient:        mockOFClient,
+		ovsCtlClient:    mockOVSCtlClient,
+		ifaceStore:      ifaceStore,
+		ifConfigurator:  &fakeInterceptedIfConfigurator{detachedInterfaces: map[string]bool{driftedConfig.InterfaceName: true}},
+	}
+
+	flowKey := "table=0,in_port=3"
+	mockOVSBridgeClient.EXPECT().GetPortData(healthyConfig.PortUUID, healthyConfig.InterfaceName).Return(newInterceptedPortData(healthyConfig), nil).Times(2)
+	mockOFClient.EXPECT().GetPodFlowKeys(healthyConfig.InterfaceName).Return([]string{flowKey}).Times(2)
+	// The flows are dumped once per audit.
+	mockOVSCtlClient.EXPECT().DumpFlowMatchSet().Return(ovsctl.NewFlowMatchSet(flowKey), nil).Times(2)
+
+	assert.Empty(t, cniServer.GetPodInterfaceDrift())
+	cniServer.auditInterceptedInterfaces()
+	drift := cniServer.GetPodInterfaceDrift()
+	require.Len(t, drift, 1)
+	assert.Contains(t, drift[testPodNamespace+"/test-drifted"], "not attached to the OVS bridge")
+
+	// The drift is cleared once the interface is removed.
+	ifaceStore.DeleteInterface(driftedConfig)
+	cniServer.auditInterceptedInterfaces()
+	assert.Empty(t, cniServer.GetPodInterfaceDrift())
+}
+
 func TestBuildOVSPortExternalIDs(t *testing.T) {
 	containerID := uuid.New().String()
 	containerMAC, _ := net.ParseMAC("aa:bb:cc:dd:ee:ff")
 	containerIP := net.ParseIP("10.1.2.100")
-	containerConfig := interfacestore.NewContainerInterface("pod1-abcd", containerID, "test-1", "t1", containerMAC, containerIP)
+	containerConfig := interfacestore.NewContainerInterface("pod1-abcd", containerID, "test-1", "t1", containerMAC, []net.IP{containerIP})
 	externalIds := BuildOVSPortExternalIDs(containerConfig)
 	parsedIP, existed := externalIds[ovsExternalIDIP]
 	if !existed || parsedIP != "10.1.2.100" {
@@ -547,6 +835,6 @@ func init() {
 	gwIP = net.ParseIP("192.168.1.1")
 	_, nodePodCIDR, _ := net.ParseCIDR("192.168.1.0/24")
 	gwMAC, _ := net.ParseMAC("00:00:00:00:00:01")
-	gateway := &config.GatewayConfig{Name: "", IP: gwIP, MAC: gwMAC}
-	testNodeConfig = &config.NodeConfig{Name: nodeName, PodCIDR: nodePodCIDR, GatewayConfig: gateway}
+	gateway := &config.GatewayConfig{Name: "", IPv4: gwIP, MAC: gwMAC}
+	testNodeConfig = &config.NodeConfig{Name: nodeName, PodIPv4CIDR: nodePodCIDR, GatewayConfig: gateway}
 }
@@ -35,8 +35,11 @@ const (
 )
 
 type GatewayConfig struct {
-	IP  net.IP
-	MAC net.HardwareAddr
+	// IPv4 and IPv6 are the IPv4 and IPv6 addresses of the host gateway. Either of them can be nil if the
+	// corresponding IP family is not enabled on the Node.
+	IPv4 net.IP
+	IPv6 net.IP
+	MAC  net.HardwareAddr
 	// LinkIndex is the link index of host gateway.
 	LinkIndex int
 	// Name is the name of host gateway, e.g. gw0.
@@ -44,7 +47,19 @@ type GatewayConfig struct {
 }
 
 func (g *GatewayConfig) String() string {
-	return fmt.Sprintf("Name %s: IP %s, MAC %s", g.Name, g.IP, g.MAC)
+	return fmt.Sprintf("Name %s: IPv4 %s, IPv6 %s, MAC %s", g.Name, g.IPv4, g.IPv6, g.MAC)
+}
+
+// IPs returns all the IP addresses configured on the host gateway, the IPv4 address first.
+func (g *GatewayConfig) IPs() []net.IP {
+	var ips []net.IP
+	if g.IPv4 != nil {
+		ips = append(ips, g.IPv4)
+	}
+	if g.IPv6 != nil {
+		ips = append(ips, g.IPv6)
+	}
+	return ips
 }
 
 type AdapterNetConfig struct {
@@ -56,26 +71,74 @@ type AdapterNetConfig struct {
 	DNSServers string
 }
 
+type WireGuardConfig struct {
+	// Name is the name of the WireGuard device, e.g. antrea-wg0.
+	Name string
+	// LinkIndex is the link index of the WireGuard device.
+	LinkIndex int
+	// Port is the UDP port the WireGuard device listens on.
+	Port int
+	// MTU is the MTU of the WireGuard device.
+	MTU int
+}
+
+func (w *WireGuardConfig) String() string {
+	return fmt.Sprintf("Name %s: Port %d, MTU %d", w.Name, w.Port, w.MTU)
+}
+
 // Local Node configurations retrieved from K8s API or host networking state.
 type NodeConfig struct {
-	Name            string
-	OVSBridge       string
-	PodCIDR         *net.IPNet
+	Name      string
+	OVSBridge string
+	// PodIPv4CIDR and PodIPv6CIDR are the Pod subnets allocated to the Node for each IP family. Either of them
+	// can be nil if the corresponding IP family is not enabled in the cluster.
+	PodIPv4CIDR     *net.IPNet
+	PodIPv6CIDR     *net.IPNet
 	NodeIPAddr      *net.IPNet
 	GatewayConfig   *GatewayConfig
 	BridgeName      string
 	UplinkNetConfig *AdapterNetConfig
+	// WireGuardConfig is the configuration of the WireGuard device. It's nil if WireGuard is not enabled.
+	WireGuardConfig *WireGuardConfig
 }
 
 func (n *NodeConfig) String() string {
-	return fmt.Sprintf("NodeName: %s, OVSBridge: %s, PodCIDR: %s, NodeIP: %s, Gateway: %s",
-		n.Name, n.OVSBridge, n.PodCIDR, n.NodeIPAddr, n.GatewayConfig)
+	return fmt.Sprintf("NodeName: %s, OVSBridge: %s, PodIPv4CIDR: %s, PodIPv6CIDR: %s, NodeIP: %s, Gateway: %s",
+		n.Name, n.OVSBridge, n.PodIPv4CIDR, n.PodIPv6CIDR, n.NodeIPAddr, n.GatewayConfig)
+}
+
+// PodCIDRs returns all the Pod subnets allocated to the Node, the IPv4 subnet first.
+func (n *NodeConfig) PodCIDRs() []*net.IPNet {
+	var cidrs []*net.IPNet
+	if n.PodIPv4CIDR != nil {
+		cidrs = append(cidrs, n.PodIPv4CIDR)
+	}
+	if n.PodIPv6CIDR != nil {
+		cidrs = append(cidrs, n.PodIPv6CIDR)
+	}
+	return cidrs
 }
 
+// IPSecAuthenticationMode is the authentication method used by the IKE daemon
+// to establish the IPSec security associations between Nodes.
+type IPSecAuthenticationMode string
+
+const (
+	// IPSecAuthenticationModePSK authenticates the Nodes with a cluster-wide
+	// pre-shared key.
+	IPSecAuthenticationModePSK IPSecAuthenticationMode = "psk"
+	// IPSecAuthenticationModeCert authenticates the Nodes with X.509
+	// certificates issued by the Antrea Controller.
+	IPSecAuthenticationModeCert IPSecAuthenticationMode = "cert"
+)
+
 // User provided network configuration parameters.
 type NetworkConfig struct {
-	TrafficEncapMode  TrafficEncapModeType
-	TunnelType        ovsconfig.TunnelType
-	EnableIPSecTunnel bool
-	IPSecPSK          string
+	TrafficEncapMode        TrafficEncapModeType
+	TunnelType              ovsconfig.TunnelType
+	EnableIPSecTunnel       bool
+	IPSecAuthenticationMode IPSecAuthenticationMode
+	IPSecPSK                string
+	EnableWireGuard         bool
+	WireGuardPort           int
 }
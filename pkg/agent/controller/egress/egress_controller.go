@@ -0,0 +1,494 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package egress
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/controller/noderoute"
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+	"github.com/vmware-tanzu/antrea/pkg/agent/route"
+	securityv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned"
+	securityinformers "github.com/vmware-tanzu/antrea/pkg/client/informers/externalversions/security/v1alpha1"
+	securitylisters "github.com/vmware-tanzu/antrea/pkg/client/listers/security/v1alpha1"
+)
+
+const (
+	controllerName = "AntreaAgentEgressController"
+	// How long to wait before retrying the processing of the Egresses.
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = 300 * time.Second
+	// All the events are handled by syncing all the Egresses at once, the
+	// Pods being selected by the first Egress which selects them. A single
+	// key is therefore used in the workqueue.
+	egressesKey = "egresses"
+)
+
+// podSNATConfig is the SNAT configuration installed for a Pod.
+type podSNATConfig struct {
+	// egressNodeIP is the IP of the remote Node which hosts the egress IP
+	// of a local Pod. It's empty if the egress IP is hosted by this Node.
+	egressNodeIP string
+	// snatMark is the SNAT mark of the egress IP if it is hosted by this
+	// Node, 0 otherwise.
+	snatMark uint32
+}
+
+// Controller is responsible for realizing the Egresses on this Node: it hosts the egress IPs
+// which are assigned to this Node, and installs the flows which SNAT the traffic from the
+// local Pods selected by an Egress to its egress IP, either on this Node or by tunneling the
+// traffic to the Node which hosts the egress IP. The Node hosting an egress IP is the
+// preferred Node of the Egress if it is ready, otherwise all the Agents agree on one of the
+// ready Nodes, so that the egress IP is taken over by another Node if its Node fails.
+type Controller struct {
+	crdClient             versioned.Interface
+	ofClient              openflow.Client
+	routeClient           route.Interface
+	nodeName              string
+	egressLister          securitylisters.EgressLister
+	egressListerSynced    cache.InformerSynced
+	podLister             corelisters.PodLister
+	podListerSynced       cache.InformerSynced
+	namespaceLister       corelisters.NamespaceLister
+	namespaceListerSynced cache.InformerSynced
+	nodeLister            corelisters.NodeLister
+	nodeListerSynced      cache.InformerSynced
+	queue                 workqueue.RateLimitingInterface
+	// The fields below are only accessed by the single worker.
+	// hostedEgressIPs is a map from the egress IPs hosted by this Node to
+	// their SNAT marks.
+	hostedEgressIPs map[string]uint32
+	// podSNATConfigs is a map from the IPs of the Pods whose SNAT flows are
+	// installed to their SNAT configurations.
+	podSNATConfigs map[string]podSNATConfig
+	// egressIPsReconciled indicates whether the egress IPs hosted by this
+	// Node before the Agent restarted have been reconciled.
+	egressIPsReconciled bool
+}
+
+// NewEgressController instantiates a new Controller object which will process the Egress,
+// Pod, Namespace and Node events.
+func NewEgressController(
+	crdClient versioned.Interface,
+	egressInformer securityinformers.EgressInformer,
+	podInformer coreinformers.PodInformer,
+	namespaceInformer coreinformers.NamespaceInformer,
+	nodeInformer coreinformers.NodeInformer,
+	ofClient openflow.Client,
+	routeClient route.Interface,
+	nodeName string) *Controller {
+	c := &Controller{
+		crdClient:             crdClient,
+		ofClient:              ofClient,
+		routeClient:           routeClient,
+		nodeName:              nodeName,
+		egressLister:          egressInformer.Lister(),
+		egressListerSynced:    egressInformer.Informer().HasSynced,
+		podLister:             podInformer.Lister(),
+		podListerSynced:       podInformer.Informer().HasSynced,
+		namespaceLister:       namespaceInformer.Lister(),
+		namespaceListerSynced: namespaceInformer.Informer().HasSynced,
+		nodeLister:            nodeInformer.Lister(),
+		nodeListerSynced:      nodeInformer.Informer().HasSynced,
+		queue:                 workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "egress"),
+		hostedEgressIPs:       make(map[string]uint32),
+		podSNATConfigs:        make(map[string]podSNATConfig),
+	}
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueEgresses,
+		UpdateFunc: func(_, cur interface{}) { c.enqueueEgresses(cur) },
+		DeleteFunc: c.enqueueEgresses,
+	}
+	egressInformer.Informer().AddEventHandler(handler)
+	podInformer.Informer().AddEventHandler(handler)
+	namespaceInformer.Informer().AddEventHandler(handler)
+	nodeInformer.Informer().AddEventHandler(handler)
+	return c
+}
+
+func (c *Controller) enqueueEgresses(_ interface{}) {
+	c.queue.Add(egressesKey)
+}
+
+// Run will create a single worker (go routine) which will sync the Egresses whenever an
+// Egress, Pod, Namespace or Node event is received.
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+
+	klog.Infof("Starting %s", controllerName)
+	defer klog.Infof("Shutting down %s", controllerName)
+
+	if !cache.WaitForNamedCacheSync(controllerName, stopCh, c.egressListerSynced, c.podListerSynced, c.namespaceListerSynced, c.nodeListerSynced) {
+		return
+	}
+
+	go wait.Until(c.worker, time.Second, stopCh)
+	<-stopCh
+}
+
+func (c *Controller) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *Controller) processNextWorkItem() bool {
+	obj, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(obj)
+
+	if err := c.syncEgresses(); err == nil {
+		c.queue.Forget(obj)
+	} else {
+		c.queue.AddRateLimited(obj)
+		klog.Errorf("Error syncing Egresses, requeuing. Error: %v", err)
+	}
+	return true
+}
+
+// readyNodes returns the Nodes which can host egress IPs, i.e. the ready Linux Nodes.
+func (c *Controller) readyNodes() (map[string]*v1.Node, error) {
+	nodes, err := c.nodeLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	readyNodes := make(map[string]*v1.Node)
+	for _, node := range nodes {
+		if os, ok := node.Labels[v1.LabelOSStable]; ok && os != "linux" {
+			continue
+		}
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == v1.NodeReady && condition.Status == v1.ConditionTrue {
+				readyNodes[node.Name] = node
+				break
+			}
+		}
+	}
+	return readyNodes, nil
+}
+
+// selectEgressNode returns the Node which must host the egress IP of the provided Egress: its
+// preferred Node if it is ready, otherwise the ready Node which has the highest hash with the
+// Egress (rendezvous hashing), so that all the Agents select the same Node and that the
+// Egresses of a failed Node are spread across the other Nodes. It returns nil if no Node is
+// ready.
+func selectEgressNode(egress *securityv1alpha1.Egress, readyNodes map[string]*v1.Node) *v1.Node {
+	if node, ok := readyNodes[egress.Spec.NodeName]; ok {
+		return node
+	}
+	var selected *v1.Node
+	var selectedHash uint64
+	for name, node := range readyNodes {
+		h := fnv.New64a()
+		h.Write([]byte(egress.Name))
+		h.Write([]byte(name))
+		if hash := h.Sum64(); selected == nil || hash > selectedHash || (hash == selectedHash && name < selected.Name) {
+			selected, selectedHash = node, hash
+		}
+	}
+	return selected
+}
+
+// selectedPods returns the Pods selected by the AppliedTo field of the provided Egress. A
+// nil PodSelector selects all the Pods of the Namespaces selected by the NamespaceSelector,
+// and a nil NamespaceSelector selects the Pods from all the Namespaces. At least one of the
+// selectors must be set.
+func (c *Controller) selectedPods(egress *securityv1alpha1.Egress) ([]*v1.Pod, error) {
+	appliedTo := egress.Spec.AppliedTo
+	if appliedTo.PodSelector == nil && appliedTo.NamespaceSelector == nil {
+		return nil, nil
+	}
+	podSelector := labels.Everything()
+	if appliedTo.PodSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(appliedTo.PodSelector)
+		if err != nil {
+			return nil, err
+		}
+		podSelector = selector
+	}
+	if appliedTo.NamespaceSelector == nil {
+		return c.podLister.List(podSelector)
+	}
+	nsSelector, err := metav1.LabelSelectorAsSelector(appliedTo.NamespaceSelector)
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := c.namespaceLister.List(nsSelector)
+	if err != nil {
+		return nil, err
+	}
+	var pods []*v1.Pod
+	for _, ns := range namespaces {
+		nsPods, err := c.podLister.Pods(ns.Name).List(podSelector)
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, nsPods...)
+	}
+	return pods, nil
+}
+
+// getPodIPv4 returns the IPv4 address of a running Pod which is not in the host network, or
+// nil.
+func getPodIPv4(pod *v1.Pod) net.IP {
+	if pod.Spec.HostNetwork || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+		return nil
+	}
+	podIPs := pod.Status.PodIPs
+	if len(podIPs) == 0 && pod.Status.PodIP != "" {
+		podIPs = []v1.PodIP{{IP: pod.Status.PodIP}}
+	}
+	for _, podIP := range podIPs {
+		if ip := net.ParseIP(podIP.IP); ip != nil && ip.To4() != nil {
+			return ip.To4()
+		}
+	}
+	return nil
+}
+
+// syncEgresses computes the desired egress IPs hosted by this Node and the desired SNAT
+// configurations of the Pods from all the Egresses, and reconciles the installed ones with
+// them.
+func (c *Controller) syncEgresses() error {
+	startTime := time.Now()
+	defer func() {
+		klog.V(4).Infof("Finished syncing Egresses. (%v)", time.Since(startTime))
+	}()
+
+	egresses, err := c.egressLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	// The Egresses are processed in a deterministic order, so that all the
+	// Agents agree on the Egress of a Pod selected by several Egresses.
+	sort.Slice(egresses, func(i, j int) bool { return egresses[i].Name < egresses[j].Name })
+	readyNodes, err := c.readyNodes()
+	if err != nil {
+		return err
+	}
+
+	desiredEgressIPs := make(map[string]bool)
+	// desiredPods is a map from Pod IP to the egress IP and the IP of the
+	// Node hosting it, the latter being empty for this Node.
+	type podEgress struct {
+		egressIP     string
+		egressNodeIP string
+	}
+	desiredPods := make(map[string]podEgress)
+	var statusUpdates []*securityv1alpha1.Egress
+	for _, egress := range egresses {
+		egressIP := net.ParseIP(egress.Spec.EgressIP)
+		if egressIP == nil || egressIP.To4() == nil {
+			klog.Warningf("Ignoring Egress %s with invalid egress IP %s", egress.Name, egress.Spec.EgressIP)
+			continue
+		}
+		egressIPStr := egressIP.String()
+		if _, ok := desiredEgressIPs[egressIPStr]; ok {
+			klog.Warningf("Ignoring Egress %s as egress IP %s is used by another Egress", egress.Name, egressIPStr)
+			continue
+		}
+		egressNode := selectEgressNode(egress, readyNodes)
+		if egressNode == nil {
+			klog.Warningf("No Node is ready to host the egress IP of Egress %s", egress.Name)
+			continue
+		}
+		local := egressNode.Name == c.nodeName
+		desiredEgressIPs[egressIPStr] = local
+		var egressNodeIP string
+		if local {
+			if egress.Status.NodeName != c.nodeName {
+				statusUpdates = append(statusUpdates, egress)
+			}
+		} else {
+			nodeIP, err := noderoute.GetNodeAddr(egressNode)
+			if err != nil {
+				klog.Errorf("Failed to get the IP of Node %s hosting the egress IP of Egress %s: %v", egressNode.Name, egress.Name, err)
+				continue
+			}
+			egressNodeIP = nodeIP.String()
+		}
+		pods, err := c.selectedPods(egress)
+		if err != nil {
+			klog.Errorf("Failed to get the Pods selected by Egress %s: %v", egress.Name, err)
+			continue
+		}
+		for _, pod := range pods {
+			// The remote Pods are relevant only if their traffic is tunneled
+			// to this Node.
+			if pod.Spec.NodeName != c.nodeName && !local {
+				continue
+			}
+			podIP := getPodIPv4(pod)
+			if podIP == nil {
+				continue
+			}
+			if _, ok := desiredPods[podIP.String()]; ok {
+				continue
+			}
+			desiredPods[podIP.String()] = podEgress{egressIP: egressIPStr, egressNodeIP: egressNodeIP}
+		}
+	}
+
+	var errs []error
+	// Remove the SNAT flows of the Pods which are no longer selected first,
+	// so that the egress IPs which are no longer hosted are not used anymore.
+	for podIP := range c.podSNATConfigs {
+		if _, ok := desiredPods[podIP]; ok {
+			continue
+		}
+		if err := c.ofClient.UninstallPodSNATFlows(net.ParseIP(podIP)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to uninstall SNAT flows of Pod %s: %v", podIP, err))
+			continue
+		}
+		delete(c.podSNATConfigs, podIP)
+	}
+	// The egress IPs which were hosted by this Node before the Agent restarted
+	// and which are no longer assigned to it are removed once.
+	if !c.egressIPsReconciled {
+		var localEgressIPs []net.IP
+		for egressIP, local := range desiredEgressIPs {
+			if local {
+				localEgressIPs = append(localEgressIPs, net.ParseIP(egressIP))
+			}
+		}
+		if err := c.routeClient.ReconcileEgressIPs(localEgressIPs); err != nil {
+			errs = append(errs, fmt.Errorf("failed to reconcile egress IPs: %v", err))
+		} else {
+			c.egressIPsReconciled = true
+		}
+	}
+	for egressIP, local := range desiredEgressIPs {
+		if !local {
+			continue
+		}
+		if err := c.hostEgressIP(egressIP); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for podIP, podEgress := range desiredPods {
+		var config podSNATConfig
+		if podEgress.egressNodeIP != "" {
+			config.egressNodeIP = podEgress.egressNodeIP
+		} else if snatMark, ok := c.hostedEgressIPs[podEgress.egressIP]; ok {
+			config.snatMark = snatMark
+		} else {
+			// Hosting the egress IP failed.
+			continue
+		}
+		if installed, ok := c.podSNATConfigs[podIP]; ok && installed == config {
+			continue
+		}
+		if err := c.ofClient.InstallPodSNATFlows(net.ParseIP(podIP), net.ParseIP(config.egressNodeIP), config.snatMark); err != nil {
+			errs = append(errs, fmt.Errorf("failed to install SNAT flows of Pod %s: %v", podIP, err))
+			continue
+		}
+		c.podSNATConfigs[podIP] = config
+	}
+	for egressIP := range c.hostedEgressIPs {
+		if local := desiredEgressIPs[egressIP]; local {
+			continue
+		}
+		if err := c.unhostEgressIP(egressIP); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, egress := range statusUpdates {
+		if _, ok := c.hostedEgressIPs[net.ParseIP(egress.Spec.EgressIP).String()]; !ok {
+			continue
+		}
+		if err := c.updateEgressStatus(egress); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d error(s) when syncing Egresses, the first one: %v", len(errs), errs[0])
+	}
+	return nil
+}
+
+// allocateSNATMark returns the smallest SNAT mark which is not used by any hosted egress IP.
+func (c *Controller) allocateSNATMark() uint32 {
+	used := make(map[uint32]bool, len(c.hostedEgressIPs))
+	for _, mark := range c.hostedEgressIPs {
+		used[mark] = true
+	}
+	mark := uint32(1)
+	for used[mark] {
+		mark++
+	}
+	return mark
+}
+
+// hostEgressIP makes this Node host the provided egress IP and installs the flow which
+// SNATs the connections to it, if it is not hosted yet.
+func (c *Controller) hostEgressIP(egressIP string) error {
+	if _, ok := c.hostedEgressIPs[egressIP]; ok {
+		return nil
+	}
+	ip := net.ParseIP(egressIP)
+	snatMark := c.allocateSNATMark()
+	if err := c.ofClient.InstallSNATMarkFlows(ip, snatMark); err != nil {
+		return fmt.Errorf("failed to install SNAT flows of egress IP %s: %v", egressIP, err)
+	}
+	if err := c.routeClient.AddEgressIP(ip); err != nil {
+		if err := c.ofClient.UninstallSNATMarkFlows(snatMark); err != nil {
+			klog.Errorf("Failed to uninstall SNAT flows of egress IP %s: %v", egressIP, err)
+		}
+		return fmt.Errorf("failed to host egress IP %s: %v", egressIP, err)
+	}
+	klog.Infof("Hosting egress IP %s with SNAT mark %d", egressIP, snatMark)
+	c.hostedEgressIPs[egressIP] = snatMark
+	return nil
+}
+
+// unhostEgressIP removes the configuration added by hostEgressIP for the provided egress IP.
+func (c *Controller) unhostEgressIP(egressIP string) error {
+	ip := net.ParseIP(egressIP)
+	if err := c.routeClient.DeleteEgressIP(ip); err != nil {
+		return fmt.Errorf("failed to release egress IP %s: %v", egressIP, err)
+	}
+	if err := c.ofClient.UninstallSNATMarkFlows(c.hostedEgressIPs[egressIP]); err != nil {
+		return fmt.Errorf("failed to uninstall SNAT flows of egress IP %s: %v", egressIP, err)
+	}
+	klog.Infof("Released egress IP %s", egressIP)
+	delete(c.hostedEgressIPs, egressIP)
+	return nil
+}
+
+// updateEgressStatus reports that this Node hosts the egress IP of the provided Egress.
+func (c *Controller) updateEgressStatus(egress *securityv1alpha1.Egress) error {
+	toUpdate := egress.DeepCopy()
+	toUpdate.Status.NodeName = c.nodeName
+	if _, err := c.crdClient.SecurityV1alpha1().Egresses().UpdateStatus(toUpdate); err != nil {
+		return fmt.Errorf("failed to update the status of Egress %s: %v", egress.Name, err)
+	}
+	return nil
+}
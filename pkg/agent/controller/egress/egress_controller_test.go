@@ -0,0 +1,266 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package egress
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	openflowtest "github.com/vmware-tanzu/antrea/pkg/agent/openflow/testing"
+	routetest "github.com/vmware-tanzu/antrea/pkg/agent/route/testing"
+	securityv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/fake"
+	crdinformers "github.com/vmware-tanzu/antrea/pkg/client/informers/externalversions"
+)
+
+const localNodeName = "node1"
+
+type fakeController struct {
+	*Controller
+	crdClient          *fake.Clientset
+	crdInformerFactory crdinformers.SharedInformerFactory
+	informerFactory    informers.SharedInformerFactory
+	mockOFClient       *openflowtest.MockClient
+	mockRouteClient    *routetest.MockInterface
+}
+
+func newFakeController(t *testing.T) (*fakeController, *gomock.Controller) {
+	ctrl := gomock.NewController(t)
+	crdClient := fake.NewSimpleClientset()
+	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
+	informerFactory := informers.NewSharedInformerFactory(k8sfake.NewSimpleClientset(), 0)
+	mockOFClient := openflowtest.NewMockClient(ctrl)
+	mockRouteClient := routetest.NewMockInterface(ctrl)
+	c := NewEgressController(
+		crdClient,
+		crdInformerFactory.Security().V1alpha1().Egresses(),
+		informerFactory.Core().V1().Pods(),
+		informerFactory.Core().V1().Namespaces(),
+		informerFactory.Core().V1().Nodes(),
+		mockOFClient,
+		mockRouteClient,
+		localNodeName)
+	return &fakeController{
+		Controller:         c,
+		crdClient:          crdClient,
+		crdInformerFactory: crdInformerFactory,
+		informerFactory:    informerFactory,
+		mockOFClient:       mockOFClient,
+		mockRouteClient:    mockRouteClient,
+	}, ctrl
+}
+
+func (c *fakeController) addEgress(egress *securityv1alpha1.Egress) {
+	c.crdClient.SecurityV1alpha1().Egresses().Create(egress)
+	c.crdInformerFactory.Security().V1alpha1().Egresses().Informer().GetIndexer().Add(egress)
+}
+
+func (c *fakeController) deleteEgress(egress *securityv1alpha1.Egress) {
+	c.crdInformerFactory.Security().V1alpha1().Egresses().Informer().GetIndexer().Delete(egress)
+}
+
+func (c *fakeController) addObjects(objects ...interface{}) {
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *v1.Pod:
+			c.informerFactory.Core().V1().Pods().Informer().GetIndexer().Add(o)
+		case *v1.Namespace:
+			c.informerFactory.Core().V1().Namespaces().Informer().GetIndexer().Add(o)
+		case *v1.Node:
+			c.informerFactory.Core().V1().Nodes().Informer().GetIndexer().Add(o)
+		}
+	}
+}
+
+func newNode(name, ip string, ready bool) *v1.Node {
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{v1.LabelOSStable: "linux"}},
+		Status: v1.NodeStatus{
+			Addresses:  []v1.NodeAddress{{Type: v1.NodeInternalIP, Address: ip}},
+			Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: status}},
+		},
+	}
+}
+
+func newPod(namespace, name, nodeName, ip string, labels map[string]string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+		Spec:       v1.PodSpec{NodeName: nodeName},
+		Status:     v1.PodStatus{Phase: v1.PodRunning, PodIP: ip},
+	}
+}
+
+func newEgress(name, egressIP, nodeName string, podSelector, nsSelector *metav1.LabelSelector) *securityv1alpha1.Egress {
+	return &securityv1alpha1.Egress{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: securityv1alpha1.EgressSpec{
+			AppliedTo: securityv1alpha1.NetworkPolicyPeer{PodSelector: podSelector, NamespaceSelector: nsSelector},
+			EgressIP:  egressIP,
+			NodeName:  nodeName,
+		},
+	}
+}
+
+var (
+	appSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	webLabels   = map[string]string{"app": "web"}
+)
+
+func TestSyncEgressesLocalEgressIP(t *testing.T) {
+	c, ctrl := newFakeController(t)
+	defer ctrl.Finish()
+	hostNetworkPod := newPod("ns1", "host-network-web", localNodeName, "192.168.0.1", webLabels)
+	hostNetworkPod.Spec.HostNetwork = true
+	c.addObjects(
+		newNode(localNodeName, "192.168.0.1", true),
+		newNode("node2", "192.168.0.2", true),
+		newPod("ns1", "local-web", localNodeName, "10.10.0.2", webLabels),
+		newPod("ns1", "local-db", localNodeName, "10.10.0.3", nil),
+		newPod("ns1", "remote-web", "node2", "10.10.1.2", webLabels),
+		hostNetworkPod,
+	)
+	egress := newEgress("egress1", "192.168.0.100", localNodeName, appSelector, nil)
+	c.addEgress(egress)
+
+	c.mockRouteClient.EXPECT().ReconcileEgressIPs([]net.IP{net.ParseIP("192.168.0.100")})
+	c.mockOFClient.EXPECT().InstallSNATMarkFlows(net.ParseIP("192.168.0.100"), uint32(1))
+	c.mockRouteClient.EXPECT().AddEgressIP(net.ParseIP("192.168.0.100"))
+	c.mockOFClient.EXPECT().InstallPodSNATFlows(net.ParseIP("10.10.0.2"), nil, uint32(1))
+	c.mockOFClient.EXPECT().InstallPodSNATFlows(net.ParseIP("10.10.1.2"), nil, uint32(1))
+	require.NoError(t, c.syncEgresses())
+	assert.Equal(t, map[string]uint32{"192.168.0.100": 1}, c.hostedEgressIPs)
+	updated, err := c.crdClient.SecurityV1alpha1().Egresses().Get(egress.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, localNodeName, updated.Status.NodeName)
+
+	// Syncing again is a no-op.
+	require.NoError(t, c.syncEgresses())
+
+	// The flows and the egress IP are removed with the Egress.
+	c.deleteEgress(egress)
+	c.mockOFClient.EXPECT().UninstallPodSNATFlows(net.ParseIP("10.10.0.2"))
+	c.mockOFClient.EXPECT().UninstallPodSNATFlows(net.ParseIP("10.10.1.2"))
+	c.mockRouteClient.EXPECT().DeleteEgressIP(net.ParseIP("192.168.0.100"))
+	c.mockOFClient.EXPECT().UninstallSNATMarkFlows(uint32(1))
+	require.NoError(t, c.syncEgresses())
+	assert.Empty(t, c.hostedEgressIPs)
+	assert.Empty(t, c.podSNATConfigs)
+}
+
+func TestSyncEgressesRemoteEgressIP(t *testing.T) {
+	c, ctrl := newFakeController(t)
+	defer ctrl.Finish()
+	c.addObjects(
+		newNode(localNodeName, "192.168.0.1", true),
+		newNode("node2", "192.168.0.2", true),
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1", Labels: map[string]string{"egress": "true"}}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns2"}},
+		newPod("ns1", "local-pod", localNodeName, "10.10.0.2", nil),
+		newPod("ns2", "other-ns-pod", localNodeName, "10.10.0.3", nil),
+		newPod("ns1", "remote-pod", "node2", "10.10.1.2", nil),
+	)
+	c.addEgress(newEgress("egress1", "192.168.0.100", "node2", nil, &metav1.LabelSelector{MatchLabels: map[string]string{"egress": "true"}}))
+
+	// Only the local Pods are tunneled to the remote Node hosting the egress IP.
+	// Reconciling the egress IPs previously hosted by this Node fails, and is
+	// retried with the next sync.
+	c.mockRouteClient.EXPECT().ReconcileEgressIPs([]net.IP(nil)).Return(fmt.Errorf("error"))
+	c.mockOFClient.EXPECT().InstallPodSNATFlows(net.ParseIP("10.10.0.2"), net.ParseIP("192.168.0.2"), uint32(0))
+	require.Error(t, c.syncEgresses())
+	assert.Empty(t, c.hostedEgressIPs)
+
+	// The egress IP is taken over by this Node when node2 is not ready.
+	c.addObjects(newNode("node2", "192.168.0.2", false))
+	c.mockRouteClient.EXPECT().ReconcileEgressIPs([]net.IP{net.ParseIP("192.168.0.100")})
+	c.mockOFClient.EXPECT().InstallSNATMarkFlows(net.ParseIP("192.168.0.100"), uint32(1))
+	c.mockRouteClient.EXPECT().AddEgressIP(net.ParseIP("192.168.0.100"))
+	c.mockOFClient.EXPECT().InstallPodSNATFlows(net.ParseIP("10.10.0.2"), nil, uint32(1))
+	c.mockOFClient.EXPECT().InstallPodSNATFlows(net.ParseIP("10.10.1.2"), nil, uint32(1))
+	require.NoError(t, c.syncEgresses())
+	assert.Equal(t, map[string]uint32{"192.168.0.100": 1}, c.hostedEgressIPs)
+
+	// The egress IP is moved back to node2 when it is ready again.
+	c.addObjects(newNode("node2", "192.168.0.2", true))
+	c.mockOFClient.EXPECT().UninstallPodSNATFlows(net.ParseIP("10.10.1.2"))
+	c.mockOFClient.EXPECT().InstallPodSNATFlows(net.ParseIP("10.10.0.2"), net.ParseIP("192.168.0.2"), uint32(0))
+	c.mockRouteClient.EXPECT().DeleteEgressIP(net.ParseIP("192.168.0.100"))
+	c.mockOFClient.EXPECT().UninstallSNATMarkFlows(uint32(1))
+	require.NoError(t, c.syncEgresses())
+	assert.Empty(t, c.hostedEgressIPs)
+}
+
+func TestSyncEgressesConflicts(t *testing.T) {
+	c, ctrl := newFakeController(t)
+	defer ctrl.Finish()
+	c.addObjects(
+		newNode(localNodeName, "192.168.0.1", true),
+		newNode("node2", "192.168.0.2", true),
+		newPod("ns1", "local-web", localNodeName, "10.10.0.2", webLabels),
+	)
+	// The Pod is selected by the first Egress by name only.
+	c.addEgress(newEgress("egress-a", "192.168.0.100", "node2", appSelector, nil))
+	c.addEgress(newEgress("egress-b", "192.168.0.101", localNodeName, appSelector, nil))
+	// The egress IP is already used by egress-a.
+	c.addEgress(newEgress("egress-c", "192.168.0.100", localNodeName, appSelector, nil))
+	// The egress IP is invalid.
+	c.addEgress(newEgress("egress-d", "fd00::100", localNodeName, appSelector, nil))
+
+	c.mockRouteClient.EXPECT().ReconcileEgressIPs([]net.IP{net.ParseIP("192.168.0.101")})
+	c.mockOFClient.EXPECT().InstallSNATMarkFlows(net.ParseIP("192.168.0.101"), uint32(1))
+	c.mockRouteClient.EXPECT().AddEgressIP(net.ParseIP("192.168.0.101"))
+	c.mockOFClient.EXPECT().InstallPodSNATFlows(net.ParseIP("10.10.0.2"), net.ParseIP("192.168.0.2"), uint32(0))
+	require.NoError(t, c.syncEgresses())
+}
+
+func TestSelectEgressNode(t *testing.T) {
+	readyNodes := map[string]*v1.Node{
+		"node1": newNode("node1", "192.168.0.1", true),
+		"node2": newNode("node2", "192.168.0.2", true),
+		"node3": newNode("node3", "192.168.0.3", true),
+	}
+	egress := newEgress("egress1", "192.168.0.100", "node2", appSelector, nil)
+	assert.Equal(t, "node2", selectEgressNode(egress, readyNodes).Name)
+
+	// Without a ready preferred Node, the selection is stable and only
+	// changes if the selected Node is not ready anymore.
+	egress.Spec.NodeName = "node4"
+	selected := selectEgressNode(egress, readyNodes).Name
+	for name := range readyNodes {
+		if name == selected {
+			continue
+		}
+		remainingNodes := map[string]*v1.Node{}
+		for n, node := range readyNodes {
+			if n != name {
+				remainingNodes[n] = node
+			}
+		}
+		assert.Equal(t, selected, selectEgressNode(egress, remainingNodes).Name)
+	}
+	assert.Nil(t, selectEgressNode(egress, map[string]*v1.Node{}))
+}
@@ -0,0 +1,418 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipseccertificate
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	mathrand "math/rand"
+	"os"
+	"path/filepath"
+	"time"
+
+	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/server/dynamiccertificates"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/controller/certificatesigningrequest"
+	"github.com/vmware-tanzu/antrea/pkg/ovs/ovsconfig"
+)
+
+const (
+	controllerName = "AntreaAgentIPSecCertificateController"
+	// How long to wait before retrying the processing of the certificate.
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = 300 * time.Second
+	// The key can be anything as the queue only has a single item.
+	workerItemKey = "key"
+
+	// How long to wait for the CertificateSigningRequest to be signed.
+	csrWaitTimeout  = 5 * time.Minute
+	csrPollInterval = 2 * time.Second
+
+	// The keys of the Open_vSwitch other_config column from which the OVS
+	// IPSec monitor reads the credentials.
+	ovsConfigCertificateKey = "certificate"
+	ovsConfigPrivateKeyKey  = "private_key"
+	ovsConfigCACertKey      = "ca_cert"
+)
+
+// Controller is responsible for requesting the certificate used by the Node to
+// authenticate its IPSec tunnels, for configuring it and the CA certificate in
+// OVS, and for rotating the certificate before it expires.
+// Every set of credentials is written to new files, and OVS is only switched to
+// them once they are complete, so that the IPSec tunnels never read partial
+// credentials.
+type Controller struct {
+	kubeClient      clientset.Interface
+	ovsBridgeClient ovsconfig.OVSBridgeClient
+	nodeName        string
+	// certificateDir is the directory storing the credentials. It must be
+	// accessible to the OVS IPSec monitor with the same path.
+	certificateDir string
+	// caContentProvider provides the very latest content of the IPSec CA
+	// bundle.
+	caContentProvider dynamiccertificates.CAContentProvider
+	queue             workqueue.RateLimitingInterface
+	// createCSR creates the CertificateSigningRequest, it can be overridden
+	// in tests.
+	createCSR func(csr *certificatesv1beta1.CertificateSigningRequest) (*certificatesv1beta1.CertificateSigningRequest, error)
+
+	// The paths of the credentials currently configured in OVS.
+	certificatePath string
+	privateKeyPath  string
+	caCertPath      string
+	// rotationDeadline is the time after which the current certificate is
+	// rotated.
+	rotationDeadline time.Time
+}
+
+var _ dynamiccertificates.Listener = &Controller{}
+
+// NewIPSecCertificateController instantiates a new Controller which will
+// maintain the IPSec certificate of the Node.
+func NewIPSecCertificateController(
+	kubeClient clientset.Interface,
+	ovsBridgeClient ovsconfig.OVSBridgeClient,
+	nodeName string,
+	certificateDir string) *Controller {
+	// The key "ca.crt" may not exist at the beginning, no need to fail as the CA provider will watch the
+	// ConfigMap and notify the Controller of any update.
+	caContentProvider, _ := dynamiccertificates.NewDynamicCAFromConfigMapController(
+		"antrea-ipsec-ca",
+		certificatesigningrequest.IPSecCANamespace,
+		certificatesigningrequest.IPSecCAConfigMapName,
+		certificatesigningrequest.IPSecCAConfigMapKey,
+		kubeClient)
+	return newController(kubeClient, ovsBridgeClient, nodeName, certificateDir, caContentProvider)
+}
+
+func newController(
+	kubeClient clientset.Interface,
+	ovsBridgeClient ovsconfig.OVSBridgeClient,
+	nodeName string,
+	certificateDir string,
+	caContentProvider dynamiccertificates.CAContentProvider) *Controller {
+	c := &Controller{
+		kubeClient:        kubeClient,
+		ovsBridgeClient:   ovsBridgeClient,
+		nodeName:          nodeName,
+		certificateDir:    certificateDir,
+		caContentProvider: caContentProvider,
+		queue:             workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "ipsecCertificate"),
+	}
+	c.createCSR = c.createCSRWithSignerName
+	if notifier, ok := caContentProvider.(dynamiccertificates.Notifier); ok {
+		notifier.AddListener(c)
+	}
+	return c
+}
+
+// Enqueue implements dynamiccertificates.Listener. It will be called by
+// caContentProvider when the CA bundle is updated.
+func (c *Controller) Enqueue() {
+	c.queue.Add(workerItemKey)
+}
+
+// Run starts the Controller and blocks until stopCh is closed.
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+
+	klog.Infof("Starting %s", controllerName)
+	defer klog.Infof("Shutting down %s", controllerName)
+
+	// Reuse the credentials configured by a previous run if they are still valid.
+	if err := c.loadOVSConfig(); err != nil {
+		klog.Errorf("Failed to read the IPSec credentials from OVS: %v", err)
+	}
+
+	if controller, ok := c.caContentProvider.(dynamiccertificates.ControllerRunner); ok {
+		go controller.Run(1, stopCh)
+	}
+	c.queue.Add(workerItemKey)
+
+	go wait.Until(c.worker, time.Second, stopCh)
+	<-stopCh
+}
+
+// loadOVSConfig reads the paths of the credentials currently configured in
+// OVS.
+func (c *Controller) loadOVSConfig() error {
+	otherConfig, err := c.ovsBridgeClient.GetOVSOtherConfig()
+	if err != nil {
+		return err
+	}
+	c.certificatePath = otherConfig[ovsConfigCertificateKey]
+	c.privateKeyPath = otherConfig[ovsConfigPrivateKeyKey]
+	c.caCertPath = otherConfig[ovsConfigCACertKey]
+	return nil
+}
+
+func (c *Controller) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *Controller) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.syncCertificate(); err == nil {
+		c.queue.Forget(key)
+	} else {
+		klog.Errorf("Error syncing IPSec certificate, requeuing it: %v", err)
+		c.queue.AddRateLimited(key)
+	}
+	return true
+}
+
+// syncCertificate ensures that OVS is configured with the latest CA bundle and
+// with a certificate which is signed by it and not due for rotation, and
+// schedules the next rotation.
+func (c *Controller) syncCertificate() error {
+	caPEM := c.caContentProvider.CurrentCABundleContent()
+	if len(caPEM) == 0 {
+		// caContentProvider will call Enqueue when the CA bundle is available.
+		klog.Info("Waiting for the IPSec CA certificate to be published")
+		return nil
+	}
+	roots, err := cert.NewPoolFromBytes(caPEM)
+	if err != nil {
+		return fmt.Errorf("error parsing IPSec CA bundle: %v", err)
+	}
+
+	certPEM, keyPEM := readFile(c.certificatePath), readFile(c.privateKeyPath)
+	certificate, err := verifyCertificate(certPEM, keyPEM, roots, c.nodeName)
+	if err == nil && c.rotationDeadline.IsZero() {
+		// The certificate was configured by a previous run.
+		c.rotationDeadline = nextRotationDeadline(certificate)
+	}
+	rotate := err != nil || time.Now().After(c.rotationDeadline)
+	if !rotate && bytes.Equal(readFile(c.caCertPath), caPEM) {
+		// Nothing to do.
+		c.queue.AddAfter(workerItemKey, time.Until(c.rotationDeadline))
+		return nil
+	}
+	if rotate {
+		if err != nil {
+			klog.Infof("Requesting a new IPSec certificate as the current one is not valid: %v", err)
+		} else {
+			klog.Info("Requesting a new IPSec certificate as the current one is due for rotation")
+		}
+		if certPEM, keyPEM, err = c.requestCertificate(); err != nil {
+			return err
+		}
+		if certificate, err = verifyCertificate(certPEM, keyPEM, roots, c.nodeName); err != nil {
+			return fmt.Errorf("the issued IPSec certificate is not valid: %v", err)
+		}
+	}
+
+	if err := c.installCredentials(certPEM, keyPEM, caPEM); err != nil {
+		return err
+	}
+	if rotate {
+		c.rotationDeadline = nextRotationDeadline(certificate)
+	}
+	klog.Infof("Configured IPSec certificate valid until %v, it will be rotated after %v", certificate.NotAfter, c.rotationDeadline)
+	c.queue.AddAfter(workerItemKey, time.Until(c.rotationDeadline))
+	return nil
+}
+
+// requestCertificate generates a new private key, requests a certificate for it
+// with a CertificateSigningRequest, and waits for the certificate to be issued.
+// It returns the PEM encoded certificate and private key.
+func (c *Controller) requestCertificate() ([]byte, []byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating private key: %v", err)
+	}
+	keyPEM, err := keyutil.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error encoding private key: %v", err)
+	}
+	csrPEM, err := cert.MakeCSR(key, &pkix.Name{CommonName: c.nodeName}, nil, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating certificate request: %v", err)
+	}
+	csr := &certificatesv1beta1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("ipsec-%s-", c.nodeName),
+			Labels:       map[string]string{certificatesigningrequest.IPSecCSRLabelKey: "true"},
+		},
+		Spec: certificatesv1beta1.CertificateSigningRequestSpec{
+			Request: csrPEM,
+			Usages: []certificatesv1beta1.KeyUsage{
+				certificatesv1beta1.UsageDigitalSignature,
+				certificatesv1beta1.UsageKeyEncipherment,
+				certificatesv1beta1.UsageServerAuth,
+				certificatesv1beta1.UsageClientAuth,
+				certificatesv1beta1.UsageIPsecTunnel,
+			},
+		},
+	}
+	csr, err = c.createCSR(csr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating CertificateSigningRequest: %v", err)
+	}
+	klog.Infof("Created CertificateSigningRequest %s for the IPSec certificate", csr.Name)
+
+	var certPEM []byte
+	err = wait.PollImmediate(csrPollInterval, csrWaitTimeout, func() (bool, error) {
+		csr, err := c.kubeClient.CertificatesV1beta1().CertificateSigningRequests().Get(csr.Name, metav1.GetOptions{})
+		if err != nil {
+			klog.Errorf("Failed to get CertificateSigningRequest %s: %v", csr.Name, err)
+			return false, nil
+		}
+		for _, condition := range csr.Status.Conditions {
+			if condition.Type == certificatesv1beta1.CertificateDenied {
+				return false, fmt.Errorf("CertificateSigningRequest %s is denied: %s", csr.Name, condition.Message)
+			}
+		}
+		if len(csr.Status.Certificate) == 0 {
+			return false, nil
+		}
+		certPEM = csr.Status.Certificate
+		return true, nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error waiting for CertificateSigningRequest %s: %v", csr.Name, err)
+	}
+	return certPEM, keyPEM, nil
+}
+
+// createCSRWithSignerName creates the CertificateSigningRequest with the signer
+// name of the Antrea IPSec certificates. The field is not available in the
+// vendored API yet, so the request body is built manually.
+func (c *Controller) createCSRWithSignerName(csr *certificatesv1beta1.CertificateSigningRequest) (*certificatesv1beta1.CertificateSigningRequest, error) {
+	csr.TypeMeta = metav1.TypeMeta{APIVersion: certificatesv1beta1.SchemeGroupVersion.String(), Kind: "CertificateSigningRequest"}
+	data, err := json.Marshal(csr)
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	obj["spec"].(map[string]interface{})["signerName"] = certificatesigningrequest.IPSecCSRSignerName
+	if data, err = json.Marshal(obj); err != nil {
+		return nil, err
+	}
+	result := &certificatesv1beta1.CertificateSigningRequest{}
+	err = c.kubeClient.CertificatesV1beta1().RESTClient().Post().
+		Resource("certificatesigningrequests").
+		SetHeader("Content-Type", "application/json").
+		Body(data).
+		Do().
+		Into(result)
+	return result, err
+}
+
+// installCredentials writes the credentials to new files, configures OVS with
+// them, and removes the files of the previous credentials.
+func (c *Controller) installCredentials(certPEM, keyPEM, caPEM []byte) error {
+	suffix := time.Now().Format("20060102150405.000000000")
+	certificatePath := filepath.Join(c.certificateDir, fmt.Sprintf("%s-%s.crt", c.nodeName, suffix))
+	privateKeyPath := filepath.Join(c.certificateDir, fmt.Sprintf("%s-%s.key", c.nodeName, suffix))
+	caCertPath := filepath.Join(c.certificateDir, fmt.Sprintf("ca-%s.crt", suffix))
+	if err := keyutil.WriteKey(privateKeyPath, keyPEM); err != nil {
+		return fmt.Errorf("error writing IPSec private key: %v", err)
+	}
+	if err := cert.WriteCert(certificatePath, certPEM); err != nil {
+		return fmt.Errorf("error writing IPSec certificate: %v", err)
+	}
+	if err := cert.WriteCert(caCertPath, caPEM); err != nil {
+		return fmt.Errorf("error writing IPSec CA certificate: %v", err)
+	}
+	if err := c.ovsBridgeClient.UpdateOVSOtherConfig(map[string]interface{}{
+		ovsConfigCertificateKey: certificatePath,
+		ovsConfigPrivateKeyKey:  privateKeyPath,
+		ovsConfigCACertKey:      caCertPath,
+	}); err != nil {
+		return fmt.Errorf("error configuring IPSec credentials in OVS: %v", err)
+	}
+
+	for _, path := range []string{c.certificatePath, c.privateKeyPath, c.caCertPath} {
+		if path == "" || filepath.Dir(path) != filepath.Clean(c.certificateDir) {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			klog.Warningf("Failed to remove stale IPSec credential file %s: %v", path, err)
+		}
+	}
+	c.certificatePath = certificatePath
+	c.privateKeyPath = privateKeyPath
+	c.caCertPath = caCertPath
+	return nil
+}
+
+// verifyCertificate checks that the certificate matches the private key, is
+// issued to the Node, and is currently valid according to the CA bundle.
+func verifyCertificate(certPEM, keyPEM []byte, roots *x509.CertPool, nodeName string) (*x509.Certificate, error) {
+	if len(certPEM) == 0 || len(keyPEM) == 0 {
+		return nil, fmt.Errorf("certificate or private key is missing")
+	}
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	certificate, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, err
+	}
+	if certificate.Subject.CommonName != nodeName {
+		return nil, fmt.Errorf("certificate is issued to %s instead of %s", certificate.Subject.CommonName, nodeName)
+	}
+	if _, err := certificate.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}}); err != nil {
+		return nil, err
+	}
+	return certificate, nil
+}
+
+// nextRotationDeadline returns a random time between 70% and 90% of the
+// lifetime of the certificate, so that all the Nodes do not rotate their
+// certificates at the same time.
+func nextRotationDeadline(certificate *x509.Certificate) time.Time {
+	lifetime := certificate.NotAfter.Sub(certificate.NotBefore)
+	jittered := time.Duration(float64(lifetime) * (0.7 + 0.2*mathrand.Float64()))
+	return certificate.NotBefore.Add(jittered)
+}
+
+// readFile returns the content of the file, or nil if it cannot be read.
+func readFile(path string) []byte {
+	if path == "" {
+		return nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	return data
+}
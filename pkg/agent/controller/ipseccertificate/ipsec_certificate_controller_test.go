@@ -0,0 +1,248 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipseccertificate
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+	"k8s.io/apiserver/pkg/server/dynamiccertificates"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/util/cert"
+
+	ovsconfigtest "github.com/vmware-tanzu/antrea/pkg/ovs/ovsconfig/testing"
+)
+
+const testNodeName = "node1"
+
+type testCA struct {
+	cert   *x509.Certificate
+	key    *rsa.PrivateKey
+	pemCrt []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	caCert, err := cert.NewSelfSignedCACert(cert.Config{CommonName: "test-ca"}, key)
+	require.NoError(t, err)
+	return &testCA{
+		cert:   caCert,
+		key:    key,
+		pemCrt: pem.EncodeToMemory(&pem.Block{Type: cert.CertificateBlockType, Bytes: caCert.Raw}),
+	}
+}
+
+// sign issues a certificate valid for the provided duration for the PEM
+// encoded certificate request.
+func (ca *testCA) sign(t *testing.T, request []byte, validity time.Duration) []byte {
+	block, _ := pem.Decode(request)
+	x509CSR, err := x509.ParseCertificateRequest(block.Bytes)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: x509CSR.Subject.CommonName},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(validity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, x509CSR.PublicKey, ca.key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: cert.CertificateBlockType, Bytes: der})
+}
+
+func newTestController(t *testing.T, ca *testCA, ovsBridgeClient *ovsconfigtest.MockOVSBridgeClient, certificateDir string) (*Controller, *int) {
+	client := fake.NewSimpleClientset()
+	caContentProvider, err := dynamiccertificates.NewStaticCAContent("test-ca", ca.pemCrt)
+	require.NoError(t, err)
+	c := newController(client, ovsBridgeClient, testNodeName, certificateDir, caContentProvider)
+	csrCount := 0
+	// The fake clientset has no REST client, and no signer: the requests are
+	// signed immediately.
+	c.createCSR = func(csr *certificatesv1beta1.CertificateSigningRequest) (*certificatesv1beta1.CertificateSigningRequest, error) {
+		csrCount++
+		csr.Name = csr.GenerateName + string(rune('a'+csrCount))
+		csr.Status.Certificate = ca.sign(t, csr.Spec.Request, time.Hour)
+		return client.CertificatesV1beta1().CertificateSigningRequests().Create(csr)
+	}
+	return c, &csrCount
+}
+
+func expectUpdateOVSOtherConfig(ovsBridgeClient *ovsconfigtest.MockOVSBridgeClient, otherConfig *map[string]interface{}) {
+	ovsBridgeClient.EXPECT().UpdateOVSOtherConfig(gomock.Any()).DoAndReturn(func(configs map[string]interface{}) error {
+		*otherConfig = configs
+		return nil
+	})
+}
+
+func TestSyncCertificate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	ovsBridgeClient := ovsconfigtest.NewMockOVSBridgeClient(controller)
+	certificateDir, err := ioutil.TempDir("", "ipsec")
+	require.NoError(t, err)
+	defer os.RemoveAll(certificateDir)
+
+	ca := newTestCA(t)
+	c, csrCount := newTestController(t, ca, ovsBridgeClient, certificateDir)
+
+	// The first sync requests a certificate and configures it in OVS.
+	var otherConfig map[string]interface{}
+	expectUpdateOVSOtherConfig(ovsBridgeClient, &otherConfig)
+	require.NoError(t, c.syncCertificate())
+	assert.Equal(t, 1, *csrCount)
+	assert.Equal(t, c.certificatePath, otherConfig[ovsConfigCertificateKey])
+	assert.Equal(t, c.privateKeyPath, otherConfig[ovsConfigPrivateKeyKey])
+	assert.Equal(t, c.caCertPath, otherConfig[ovsConfigCACertKey])
+	assert.Equal(t, ca.pemCrt, readFile(c.caCertPath))
+	roots, _ := cert.NewPoolFromBytes(ca.pemCrt)
+	certificate, err := verifyCertificate(readFile(c.certificatePath), readFile(c.privateKeyPath), roots, testNodeName)
+	require.NoError(t, err)
+	lifetime := certificate.NotAfter.Sub(certificate.NotBefore)
+	assert.True(t, c.rotationDeadline.After(certificate.NotBefore.Add(lifetime*7/10-time.Second)))
+	assert.True(t, c.rotationDeadline.Before(certificate.NotBefore.Add(lifetime*9/10+time.Second)))
+
+	// Nothing changes while the certificate is not due for rotation.
+	require.NoError(t, c.syncCertificate())
+	assert.Equal(t, 1, *csrCount)
+
+	// A new certificate is requested and configured when the current one is due
+	// for rotation, and the files of the previous one are removed.
+	oldPaths := []string{c.certificatePath, c.privateKeyPath, c.caCertPath}
+	c.rotationDeadline = time.Now().Add(-time.Second)
+	expectUpdateOVSOtherConfig(ovsBridgeClient, &otherConfig)
+	require.NoError(t, c.syncCertificate())
+	assert.Equal(t, 2, *csrCount)
+	assert.Equal(t, c.certificatePath, otherConfig[ovsConfigCertificateKey])
+	assert.True(t, c.rotationDeadline.After(time.Now()))
+	for _, path := range oldPaths {
+		assert.NotContains(t, []string{c.certificatePath, c.privateKeyPath, c.caCertPath}, path)
+		_, err := os.Stat(path)
+		assert.True(t, os.IsNotExist(err), "File %s should be removed", path)
+	}
+	files, _ := filepath.Glob(filepath.Join(certificateDir, "*"))
+	assert.Len(t, files, 3)
+}
+
+func TestSyncCertificateWithExistingCredentials(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	ovsBridgeClient := ovsconfigtest.NewMockOVSBridgeClient(controller)
+	certificateDir, err := ioutil.TempDir("", "ipsec")
+	require.NoError(t, err)
+	defer os.RemoveAll(certificateDir)
+
+	ca := newTestCA(t)
+	c, _ := newTestController(t, ca, ovsBridgeClient, certificateDir)
+	var otherConfig map[string]interface{}
+	expectUpdateOVSOtherConfig(ovsBridgeClient, &otherConfig)
+	require.NoError(t, c.syncCertificate())
+
+	// The credentials configured by a previous run are reused.
+	ovsBridgeClient.EXPECT().GetOVSOtherConfig().Return(map[string]string{
+		ovsConfigCertificateKey: c.certificatePath,
+		ovsConfigPrivateKeyKey:  c.privateKeyPath,
+		ovsConfigCACertKey:      c.caCertPath,
+	}, nil)
+	restarted, csrCount := newTestController(t, ca, ovsBridgeClient, certificateDir)
+	require.NoError(t, restarted.loadOVSConfig())
+	require.NoError(t, restarted.syncCertificate())
+	assert.Equal(t, 0, *csrCount)
+	assert.False(t, restarted.rotationDeadline.IsZero())
+
+	// A new certificate is requested when the CA changes.
+	newCA := newTestCA(t)
+	c, csrCount = newTestController(t, newCA, ovsBridgeClient, certificateDir)
+	c.certificatePath, c.privateKeyPath, c.caCertPath = restarted.certificatePath, restarted.privateKeyPath, restarted.caCertPath
+	expectUpdateOVSOtherConfig(ovsBridgeClient, &otherConfig)
+	require.NoError(t, c.syncCertificate())
+	assert.Equal(t, 1, *csrCount)
+	assert.Equal(t, newCA.pemCrt, readFile(c.caCertPath))
+}
+
+func TestVerifyCertificate(t *testing.T) {
+	ca := newTestCA(t)
+	roots, _ := cert.NewPoolFromBytes(ca.pemCrt)
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(otherKey)})
+	newCertPEM := func(commonName string, validity time.Duration) []byte {
+		request, err := cert.MakeCSR(key, &pkix.Name{CommonName: commonName}, nil, nil)
+		require.NoError(t, err)
+		return ca.sign(t, request, validity)
+	}
+
+	tests := []struct {
+		name      string
+		certPEM   []byte
+		keyPEM    []byte
+		expectErr bool
+	}{
+		{
+			name:    "valid",
+			certPEM: newCertPEM(testNodeName, time.Hour),
+			keyPEM:  keyPEM,
+		},
+		{
+			name:      "missing",
+			keyPEM:    keyPEM,
+			expectErr: true,
+		},
+		{
+			name:      "wrong-key",
+			certPEM:   newCertPEM(testNodeName, time.Hour),
+			keyPEM:    otherKeyPEM,
+			expectErr: true,
+		},
+		{
+			name:      "wrong-node",
+			certPEM:   newCertPEM("node2", time.Hour),
+			keyPEM:    keyPEM,
+			expectErr: true,
+		},
+		{
+			name:      "expired",
+			certPEM:   newCertPEM(testNodeName, -time.Second),
+			keyPEM:    keyPEM,
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifyCertificate(tt.certPEM, tt.keyPEM, roots, testNodeName)
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/contiv/libOpenflow/protocol"
+	"github.com/contiv/ofnet/ofctrl"
+	"golang.org/x/time/rate"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+	binding "github.com/vmware-tanzu/antrea/pkg/ovs/openflow"
+)
+
+const (
+	// auditLogDir is the directory where the NetworkPolicy audit log file is written.
+	auditLogDir = "/var/log/antrea/networkpolicy"
+	// auditLogFile is the name of the NetworkPolicy audit log file.
+	auditLogFile = "np.log"
+	// The audit log file is rotated when its size reaches auditLogMaxSize megabytes, and at most
+	// auditLogMaxBackups rotated files are kept.
+	auditLogMaxSize    = 100
+	auditLogMaxBackups = 3
+	// At most auditLogRate lines are written per second, with a burst of auditLogBurst lines. The
+	// other PacketIn messages are counted but not logged.
+	auditLogRate  = 100
+	auditLogBurst = 500
+
+	verdictAllow  = "Allow"
+	verdictDrop   = "Drop"
+	verdictReject = "Reject"
+
+	directionIngress = "Ingress"
+	directionEgress  = "Egress"
+)
+
+// auditLogTables maps the tables which can send packets to the controller for logging to the
+// direction of the rules installed in them.
+var auditLogTables = map[binding.TableIDType]string{
+	openflow.CNPEgressRuleTable:  directionEgress,
+	openflow.EgressRuleTable:     directionEgress,
+	openflow.EgressDefaultTable:  directionEgress,
+	openflow.CNPIngressRuleTable: directionIngress,
+	openflow.IngressRuleTable:    directionIngress,
+	openflow.IngressDefaultTable: directionIngress,
+}
+
+// auditLogger writes a line to the NetworkPolicy audit log file for each PacketIn message sent
+// by the flows of the NetworkPolicy rules with logging enabled. The lines are rate-limited to
+// protect the agent and the Node's disk from connection floods.
+type auditLogger struct {
+	ofClient   openflow.Client
+	ifaceStore interfacestore.InterfaceStore
+	writer     io.Writer
+	limiter    *rate.Limiter
+	// suppressed is the number of lines which have not been written because of the rate limit
+	// since the last written line.
+	suppressed int
+}
+
+// auditLogEntry describes a connection logged in the NetworkPolicy audit log file.
+type auditLogEntry struct {
+	timestamp time.Time
+	verdict   string
+	direction string
+	table     string
+	policy    string
+	pod       string
+	protocol  string
+	srcIP     string
+	srcPort   string
+	dstIP     string
+	dstPort   string
+}
+
+func newAuditLogger(ofClient openflow.Client, ifaceStore interfacestore.InterfaceStore) *auditLogger {
+	// The log file is created by lumberjack on the first write, so that nothing is written to the
+	// Node if logging is not enabled for any rule.
+	writer := &lumberjack.Logger{
+		Filename:   filepath.Join(auditLogDir, auditLogFile),
+		MaxSize:    auditLogMaxSize,
+		MaxBackups: auditLogMaxBackups,
+		Compress:   true,
+	}
+	return &auditLogger{
+		ofClient:   ofClient,
+		ifaceStore: ifaceStore,
+		writer:     writer,
+		limiter:    rate.NewLimiter(auditLogRate, auditLogBurst),
+	}
+}
+
+// processPacketIn writes the audit log line of the provided PacketIn message, unless the rate
+// limit is exceeded.
+func (l *auditLogger) processPacketIn(pktIn *ofctrl.PacketIn) error {
+	if !l.limiter.Allow() {
+		l.suppressed++
+		return nil
+	}
+	entry, err := l.buildLogEntry(pktIn)
+	if err != nil {
+		return err
+	}
+	if l.suppressed > 0 {
+		if _, err := fmt.Fprintf(l.writer, "%s suppressed=%d\n", entry.timestamp.Format(time.RFC3339Nano), l.suppressed); err != nil {
+			return err
+		}
+		l.suppressed = 0
+	}
+	_, err = io.WriteString(l.writer, entry.String()+"\n")
+	return err
+}
+
+// buildLogEntry builds the audit log entry of the provided PacketIn message. The rule which the
+// packet matched is identified by the table which sent the packet to the controller, and the
+// conjunction ID stored in the register of this table, if any.
+func (l *auditLogger) buildLogEntry(pktIn *ofctrl.PacketIn) (*auditLogEntry, error) {
+	tableID := binding.TableIDType(pktIn.TableId)
+	direction, ok := auditLogTables[tableID]
+	if !ok {
+		return nil, fmt.Errorf("unexpected table %d", tableID)
+	}
+	if pktIn.Data.Ethertype != protocol.IPv4_MSG {
+		return nil, fmt.Errorf("unsupported ethertype 0x%x", pktIn.Data.Ethertype)
+	}
+	ipPacket, ok := pktIn.Data.Data.(*protocol.IPv4)
+	if !ok {
+		return nil, errors.New("invalid IPv4 packet")
+	}
+
+	entry := &auditLogEntry{
+		timestamp: time.Now(),
+		direction: direction,
+		table:     openflow.GetFlowTableName(tableID),
+		srcIP:     ipPacket.NWSrc.String(),
+		dstIP:     ipPacket.NWDst.String(),
+	}
+	switch ipPacket.Protocol {
+	case protocol.Type_ICMP:
+		entry.protocol = "ICMP"
+	case protocol.Type_TCP:
+		entry.protocol = "TCP"
+		if tcp, ok := ipPacket.Data.(*protocol.TCP); ok {
+			entry.srcPort, entry.dstPort = strconv.Itoa(int(tcp.PortSrc)), strconv.Itoa(int(tcp.PortDst))
+		}
+	case protocol.Type_UDP:
+		entry.protocol = "UDP"
+		if udp, ok := ipPacket.Data.(*protocol.UDP); ok {
+			entry.srcPort, entry.dstPort = strconv.Itoa(int(udp.PortSrc)), strconv.Itoa(int(udp.PortDst))
+		}
+	default:
+		entry.protocol = strconv.Itoa(int(ipPacket.Protocol))
+	}
+
+	// The Pod is the source of the egress traffic and the destination of the ingress traffic.
+	podIP := ipPacket.NWSrc
+	conjReg := openflow.EgressReg
+	if direction == directionIngress {
+		podIP = ipPacket.NWDst
+		conjReg = openflow.IngressReg
+	}
+	if iface := getContainerInterfaceByIP(l.ifaceStore, podIP); iface != nil {
+		entry.pod = iface.PodNamespace + "/" + iface.PodName
+	}
+
+	// The packets sent by the default drop tables are always dropped, while the packets sent by
+	// the rule tables are dropped only if the action of the matched rule is Drop or Reject.
+	entry.verdict = verdictAllow
+	if tableID == openflow.EgressDefaultTable || tableID == openflow.IngressDefaultTable {
+		entry.verdict = verdictDrop
+	}
+	if conjID := openflow.GetMatchRegField(&pktIn.Match, conjReg); conjID != 0 {
+		npName, npNamespace := l.ofClient.GetPolicyFromConjunction(conjID)
+		if npNamespace != "" {
+			entry.policy = npNamespace + "/" + npName
+		} else {
+			entry.policy = npName
+		}
+		if l.ofClient.IsDropConjunction(conjID) {
+			entry.verdict = verdictDrop
+		}
+	}
+	if getCustomReasons(&pktIn.Match)&openflow.CustomReasonReject != 0 {
+		entry.verdict = verdictReject
+	}
+	return entry, nil
+}
+
+// getContainerInterfaceByIP returns the interface of the local Pod with the provided IP, or nil
+// if there is no such Pod.
+func getContainerInterfaceByIP(ifaceStore interfacestore.InterfaceStore, ip net.IP) *interfacestore.InterfaceConfig {
+	for _, iface := range ifaceStore.GetInterfacesByType(interfacestore.ContainerInterface) {
+		for _, ifaceIP := range iface.IPs {
+			if ifaceIP.Equal(ip) {
+				return iface
+			}
+		}
+	}
+	return nil
+}
+
+// String returns the audit log line of the entry. Unknown Pods and NetworkPolicies are logged as
+// "-", and ports are omitted for the protocols without ports.
+func (e *auditLogEntry) String() string {
+	orDash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	address := func(ip, port string) string {
+		if port == "" {
+			return ip
+		}
+		return net.JoinHostPort(ip, port)
+	}
+	return fmt.Sprintf("%s verdict=%s direction=%s table=%s policy=%s pod=%s protocol=%s src=%s dst=%s",
+		e.timestamp.Format(time.RFC3339Nano), e.verdict, e.direction, e.table, orDash(e.policy), orDash(e.pod),
+		e.protocol, address(e.srcIP, e.srcPort), address(e.dstIP, e.dstPort))
+}
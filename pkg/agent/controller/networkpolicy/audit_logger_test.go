@@ -0,0 +1,202 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/contiv/libOpenflow/openflow13"
+	"github.com/contiv/libOpenflow/protocol"
+	"github.com/contiv/ofnet/ofctrl"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+	openflowtest "github.com/vmware-tanzu/antrea/pkg/agent/openflow/testing"
+	binding "github.com/vmware-tanzu/antrea/pkg/ovs/openflow"
+)
+
+func newLoggingPacketIn(tableID binding.TableIDType, regs map[int]uint32, srcIP, dstIP string, ipProto uint8, transport interface{}) *ofctrl.PacketIn {
+	ipPacket := &protocol.IPv4{
+		Protocol: ipProto,
+		NWSrc:    net.ParseIP(srcIP),
+		NWDst:    net.ParseIP(dstIP),
+	}
+	switch t := transport.(type) {
+	case *protocol.TCP:
+		ipPacket.Data = t
+	case *protocol.UDP:
+		ipPacket.Data = t
+	}
+	pktIn := &ofctrl.PacketIn{
+		TableId: uint8(tableID),
+		Match:   *openflow13.NewMatch(),
+		Data: protocol.Ethernet{
+			Ethertype: protocol.IPv4_MSG,
+			Data:      ipPacket,
+		},
+	}
+	for reg, value := range regs {
+		pktIn.Match.AddField(openflow13.MatchField{
+			Class: openflow13.OXM_CLASS_NXM_1,
+			Field: uint8(openflow13.NXM_NX_REG0 + reg),
+			Value: &openflow13.Uint32Message{Data: value},
+		})
+	}
+	return pktIn
+}
+
+func TestBuildLogEntry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ofClient := openflowtest.NewMockClient(ctrl)
+	ofClient.EXPECT().GetPolicyFromConjunction(uint32(10)).Return("np1", "ns1").AnyTimes()
+	ofClient.EXPECT().IsDropConjunction(uint32(10)).Return(false).AnyTimes()
+	ofClient.EXPECT().GetPolicyFromConjunction(uint32(20)).Return("cnp1", "").AnyTimes()
+	ofClient.EXPECT().IsDropConjunction(uint32(20)).Return(true).AnyTimes()
+
+	ifaceStore := interfacestore.NewInterfaceStore()
+	ifaceStore.AddInterface(interfacestore.NewContainerInterface("pod1-abcd", "c1", "pod1", "ns1", nil, []net.IP{net.ParseIP("10.10.0.1")}))
+	ifaceStore.AddInterface(interfacestore.NewContainerInterface("pod2-abcd", "c2", "pod2", "ns2", nil, []net.IP{net.ParseIP("10.10.0.2")}))
+	l := &auditLogger{ofClient: ofClient, ifaceStore: ifaceStore}
+
+	tests := []struct {
+		name          string
+		pktIn         *ofctrl.PacketIn
+		expectedEntry *auditLogEntry
+		expectedErr   bool
+	}{
+		{
+			name: "ingress allowed",
+			pktIn: newLoggingPacketIn(openflow.IngressRuleTable, map[int]uint32{openflow.IngressReg: 10},
+				"10.10.1.1", "10.10.0.1", protocol.Type_TCP, &protocol.TCP{PortSrc: 34567, PortDst: 80}),
+			expectedEntry: &auditLogEntry{
+				verdict:   verdictAllow,
+				direction: directionIngress,
+				table:     "IngressRule",
+				policy:    "ns1/np1",
+				pod:       "ns1/pod1",
+				protocol:  "TCP",
+				srcIP:     "10.10.1.1",
+				srcPort:   "34567",
+				dstIP:     "10.10.0.1",
+				dstPort:   "80",
+			},
+		},
+		{
+			name: "egress dropped by default rule",
+			pktIn: newLoggingPacketIn(openflow.EgressDefaultTable, nil,
+				"10.10.0.2", "10.10.1.1", protocol.Type_UDP, &protocol.UDP{PortSrc: 34567, PortDst: 53}),
+			expectedEntry: &auditLogEntry{
+				verdict:   verdictDrop,
+				direction: directionEgress,
+				table:     "EgressDefaultRule",
+				pod:       "ns2/pod2",
+				protocol:  "UDP",
+				srcIP:     "10.10.0.2",
+				srcPort:   "34567",
+				dstIP:     "10.10.1.1",
+				dstPort:   "53",
+			},
+		},
+		{
+			name: "egress dropped by ClusterNetworkPolicy rule",
+			pktIn: newLoggingPacketIn(openflow.CNPEgressRuleTable, map[int]uint32{openflow.EgressReg: 20, openflow.IngressReg: 10},
+				"10.10.0.1", "10.10.0.2", protocol.Type_ICMP, nil),
+			expectedEntry: &auditLogEntry{
+				verdict:   verdictDrop,
+				direction: directionEgress,
+				table:     "CNPEgressRule",
+				policy:    "cnp1",
+				pod:       "ns1/pod1",
+				protocol:  "ICMP",
+				srcIP:     "10.10.0.1",
+				dstIP:     "10.10.0.2",
+			},
+		},
+		{
+			name: "ingress rejected by ClusterNetworkPolicy rule",
+			pktIn: newLoggingPacketIn(openflow.CNPIngressRuleTable, map[int]uint32{
+				openflow.IngressReg: 20,
+				openflow.MarksReg:   (openflow.CustomReasonLogging | openflow.CustomReasonReject) << openflow.CustomReasonMarkRange[0],
+			}, "10.10.1.1", "10.10.0.2", protocol.Type_TCP, &protocol.TCP{PortSrc: 34567, PortDst: 80}),
+			expectedEntry: &auditLogEntry{
+				verdict:   verdictReject,
+				direction: directionIngress,
+				table:     "CNPIngressRule",
+				policy:    "cnp1",
+				pod:       "ns2/pod2",
+				protocol:  "TCP",
+				srcIP:     "10.10.1.1",
+				srcPort:   "34567",
+				dstIP:     "10.10.0.2",
+				dstPort:   "80",
+			},
+		},
+		{
+			name:        "unexpected table",
+			pktIn:       newLoggingPacketIn(openflow.L2ForwardingOutTable, nil, "10.10.0.1", "10.10.0.2", protocol.Type_ICMP, nil),
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := l.buildLogEntry(tt.pktIn)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			tt.expectedEntry.timestamp = entry.timestamp
+			assert.Equal(t, tt.expectedEntry, entry)
+		})
+	}
+}
+
+func TestProcessPacketInRateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ofClient := openflowtest.NewMockClient(ctrl)
+	buf := &bytes.Buffer{}
+	l := &auditLogger{
+		ofClient:   ofClient,
+		ifaceStore: interfacestore.NewInterfaceStore(),
+		writer:     buf,
+		limiter:    rate.NewLimiter(rate.Every(time.Hour), 2),
+	}
+	pktIn := newLoggingPacketIn(openflow.IngressDefaultTable, nil, "10.10.1.1", "10.10.0.1", protocol.Type_TCP, &protocol.TCP{PortSrc: 34567, PortDst: 80})
+	for i := 0; i < 3; i++ {
+		require.NoError(t, l.processPacketIn(pktIn))
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Equal(t, 2, len(lines))
+	assert.Contains(t, lines[0], "verdict=Drop direction=Ingress table=IngressDefaultRule policy=- pod=- protocol=TCP src=10.10.1.1:34567 dst=10.10.0.1:80")
+	assert.Equal(t, 1, l.suppressed)
+
+	// The number of suppressed lines is logged once the rate limit allows it.
+	l.limiter = rate.NewLimiter(rate.Inf, 0)
+	require.NoError(t, l.processPacketIn(pktIn))
+	lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Equal(t, 4, len(lines))
+	assert.Contains(t, lines[2], "suppressed=1")
+	assert.Equal(t, 0, l.suppressed)
+}
@@ -28,6 +28,7 @@ import (
 	"k8s.io/klog"
 
 	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
 )
 
 const (
@@ -47,6 +48,8 @@ const (
 type rule struct {
 	// ID is calculated from the hash value of all other fields.
 	ID string
+	// Name of this rule, unique within its parent Policy.
+	Name string
 	// Direction of this rule.
 	Direction v1beta1.Direction
 	// Source Address of this rule, can't coexist with To.
@@ -55,8 +58,21 @@ type rule struct {
 	To v1beta1.NetworkPolicyPeer
 	// Protocols and Ports of this rule.
 	Services []v1beta1.Service
+	// Action of this rule. nil for K8s NetworkPolicy.
+	Action *secv1alpha1.RuleAction
+	// Priority of this rule within its parent ClusterNetworkPolicy.
+	// It's not set for K8s NetworkPolicy.
+	Priority int32
+	// EnableLogging indicates whether the connections matching this rule, and
+	// the connections dropped because this rule isolates its Pods, are logged.
+	EnableLogging bool
 	// Targets of this rule.
 	AppliedToGroups []string
+	// The priority of the parent ClusterNetworkPolicy. nil for K8s NetworkPolicy.
+	PolicyPriority *float64
+	// The priority of the tier that the parent ClusterNetworkPolicy belongs to.
+	// nil for K8s NetworkPolicy.
+	TierPriority *v1beta1.TierPriority
 	// The parent Policy ID. Used to identify rules belong to a specified
 	// policy for deletion.
 	PolicyUID types.UID
@@ -75,6 +91,12 @@ func hashRule(r *rule) string {
 	return hashValue[:RuleIDLength]
 }
 
+// isAntreaNetworkPolicyRule returns true if the rule is part of a
+// ClusterNetworkPolicy.
+func (r *rule) isAntreaNetworkPolicyRule() bool {
+	return r.PolicyPriority != nil
+}
+
 // CompletedRule contains IPAddresses and Pods flattened from AddressGroups and AppliedToGroups.
 // It's the struct used by reconciler.
 type CompletedRule struct {
@@ -112,8 +134,9 @@ type ruleCache struct {
 	addressSetByGroup map[string]v1beta1.GroupMemberPodSet
 
 	policyMapLock sync.RWMutex
-	// policyMap is a map using NetworkPolicy UID as the key.
-	policyMap map[string]*types.NamespacedName
+	// policyMap is a map using NetworkPolicy UID as the key. It stores the
+	// metadata of the NetworkPolicies.
+	policyMap map[string]*metav1.ObjectMeta
 
 	// rules is a storage that supports listing rules using multiple indexing functions.
 	// rules is thread-safe.
@@ -157,6 +180,25 @@ func (c *ruleCache) getNetworkPolicy(npName, npNamespace string) *v1beta1.Networ
 	return c.buildNetworkPolicyFromRules(npUID)
 }
 
+// getNetworkPolicyMeta returns the metadata of the cached NetworkPolicy with
+// the provided UID. nil is returned if the NetworkPolicy is not found.
+func (c *ruleCache) getNetworkPolicyMeta(uid types.UID) *metav1.ObjectMeta {
+	c.policyMapLock.RLock()
+	defer c.policyMapLock.RUnlock()
+	return c.policyMap[string(uid)]
+}
+
+// getNetworkPolicyUIDs returns the UIDs of all the cached NetworkPolicies.
+func (c *ruleCache) getNetworkPolicyUIDs() []types.UID {
+	c.policyMapLock.RLock()
+	defer c.policyMapLock.RUnlock()
+	uids := make([]types.UID, 0, len(c.policyMap))
+	for uid := range c.policyMap {
+		uids = append(uids, types.UID(uid))
+	}
+	return uids
+}
+
 func (c *ruleCache) buildNetworkPolicyFromRules(uid string) *v1beta1.NetworkPolicy {
 	var np *v1beta1.NetworkPolicy
 	rules, _ := c.rules.ByIndex(policyIndex, uid)
@@ -175,23 +217,27 @@ func addRuleToNetworkPolicy(np *v1beta1.NetworkPolicy, rule *rule) *v1beta1.Netw
 				Name:      rule.PolicyName,
 				Namespace: rule.PolicyNamespace},
 			AppliedToGroups: rule.AppliedToGroups,
+			Priority:        rule.PolicyPriority,
+			TierPriority:    rule.TierPriority,
 		}
 	}
 	np.Rules = append(np.Rules, v1beta1.NetworkPolicyRule{
 		Direction: rule.Direction,
 		From:      rule.From,
 		To:        rule.To,
-		Services:  rule.Services})
+		Services:  rule.Services,
+		Action:    rule.Action,
+		Priority:  rule.Priority})
 	return np
 
 }
 
 func (c *ruleCache) getAppliedNetworkPolicies(pod, namespace string) []v1beta1.NetworkPolicy {
 	var groups []string
-	memberPod := &v1beta1.GroupMemberPod{Pod: &v1beta1.PodReference{pod, namespace}}
+	podRef := v1beta1.PodReference{pod, namespace}
 	c.podSetLock.RLock()
 	for group, podSet := range c.podSetByGroup {
-		if podSet.Has(memberPod) {
+		if podSetHasPod(podSet, podRef) {
 			groups = append(groups, group)
 		}
 	}
@@ -291,7 +337,7 @@ func newRuleCache(dirtyRuleHandler func(string), podUpdate <-chan v1beta1.PodRef
 	cache := &ruleCache{
 		podSetByGroup:     make(map[string]v1beta1.GroupMemberPodSet),
 		addressSetByGroup: make(map[string]v1beta1.GroupMemberPodSet),
-		policyMap:         make(map[string]*types.NamespacedName),
+		policyMap:         make(map[string]*metav1.ObjectMeta),
 		rules:             rules,
 		dirtyRuleHandler:  dirtyRuleHandler,
 		podUpdates:        podUpdate,
@@ -300,6 +346,18 @@ func newRuleCache(dirtyRuleHandler func(string), podUpdate <-chan v1beta1.PodRef
 	return cache
 }
 
+// podSetHasPod returns whether the provided GroupMemberPodSet contains a member
+// referring to the provided Pod. The set cannot be looked up directly as its
+// members are also identified by their named ports.
+func podSetHasPod(podSet v1beta1.GroupMemberPodSet, pod v1beta1.PodReference) bool {
+	for _, member := range podSet {
+		if member.Pod != nil && *member.Pod == pod {
+			return true
+		}
+	}
+	return false
+}
+
 // processPodUpdates is an infinite loop that takes Pod update events from the
 // channel, finds out AppliedToGroups that contains this Pod and trigger
 // reconciling of related rules.
@@ -312,11 +370,10 @@ func (c *ruleCache) processPodUpdates() {
 		select {
 		case pod := <-c.podUpdates:
 			func() {
-				memberPod := &v1beta1.GroupMemberPod{Pod: &pod}
 				c.podSetLock.RLock()
 				defer c.podSetLock.RUnlock()
 				for group, podSet := range c.podSetByGroup {
-					if podSet.Has(memberPod) {
+					if podSetHasPod(podSet, pod) {
 						c.onAppliedToGroupUpdate(group)
 					}
 				}
@@ -506,11 +563,17 @@ func (c *ruleCache) DeleteAppliedToGroup(group *v1beta1.AppliedToGroup) error {
 // toRule converts v1beta1.NetworkPolicyRule to *rule.
 func toRule(r *v1beta1.NetworkPolicyRule, policy *v1beta1.NetworkPolicy) *rule {
 	rule := &rule{
+		Name:            r.Name,
 		Direction:       r.Direction,
 		From:            r.From,
 		To:              r.To,
 		Services:        r.Services,
+		Action:          r.Action,
+		Priority:        r.Priority,
+		EnableLogging:   r.EnableLogging,
 		AppliedToGroups: policy.AppliedToGroups,
+		PolicyPriority:  policy.Priority,
+		TierPriority:    policy.TierPriority,
 		PolicyUID:       policy.UID,
 	}
 	rule.ID = hashRule(rule)
@@ -562,13 +625,21 @@ func (c *ruleCache) AddNetworkPolicy(policy *v1beta1.NetworkPolicy) error {
 }
 
 func (c *ruleCache) addNetworkPolicyLocked(policy *v1beta1.NetworkPolicy) error {
-	c.policyMap[string(policy.UID)] = &types.NamespacedName{policy.Namespace, policy.Name}
-	return c.UpdateNetworkPolicy(policy)
+	return c.updateNetworkPolicyLocked(policy)
 }
 
 // UpdateNetworkPolicy updates a cached *v1beta1.NetworkPolicy.
 // The added rules and removed rules will be regarded as dirty.
 func (c *ruleCache) UpdateNetworkPolicy(policy *v1beta1.NetworkPolicy) error {
+	c.policyMapLock.Lock()
+	defer c.policyMapLock.Unlock()
+
+	return c.updateNetworkPolicyLocked(policy)
+}
+
+func (c *ruleCache) updateNetworkPolicyLocked(policy *v1beta1.NetworkPolicy) error {
+	meta := policy.ObjectMeta
+	c.policyMap[string(policy.UID)] = &meta
 	existingRules, _ := c.rules.ByIndex(policyIndex, string(policy.UID))
 	ruleByID := map[string]interface{}{}
 	for _, r := range existingRules {
@@ -0,0 +1,540 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/contiv/libOpenflow/protocol"
+	"github.com/contiv/ofnet/ofctrl"
+	"golang.org/x/net/dns/dnsmessage"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+	binding "github.com/vmware-tanzu/antrea/pkg/ovs/openflow"
+)
+
+const (
+	dnsResponseChanSize = 100
+	// dnsResponseRate and dnsResponseBurst limit the rate at which the intercepted DNS responses
+	// are processed, so that a Pod flooding the agent with DNS responses can't starve the
+	// others. The responses exceeding the limit are forwarded to the Pods without processing.
+	dnsResponseRate  = 100
+	dnsResponseBurst = 200
+	// dnsResponseHoldTimeout is the maximum time a DNS response is held back while the rules
+	// selecting the names it resolves are updated. The response is forwarded to the Pod anyway
+	// afterwards, so that a failing rule doesn't break name resolution.
+	dnsResponseHoldTimeout = 2 * time.Second
+	// minDNSTTL is the minimum time an address learnt from a DNS response is kept, so that the
+	// Pod can still connect to it when the TTL of the record is 0 or very short.
+	minDNSTTL = 5 * time.Second
+	// dnsCacheGCInterval is the interval at which the expired addresses are removed from the
+	// rules.
+	dnsCacheGCInterval = 10 * time.Second
+)
+
+// fqdnSelector matches the DNS names selected by the FQDN of a NetworkPolicy peer.
+type fqdnSelector struct {
+	// name is the selected name, or the suffix of the selected names, including its leading
+	// dot, if wildcard is true.
+	name     string
+	wildcard bool
+}
+
+func newFQDNSelector(fqdn string) fqdnSelector {
+	name := normalizeDNSName(fqdn)
+	if strings.HasPrefix(name, "*.") {
+		return fqdnSelector{name: name[1:], wildcard: true}
+	}
+	return fqdnSelector{name: name}
+}
+
+// matches returns whether the provided normalized DNS name is selected.
+func (s fqdnSelector) matches(name string) bool {
+	if s.wildcard {
+		return strings.HasSuffix(name, s.name)
+	}
+	return name == s.name
+}
+
+// normalizeDNSName returns the lower-case form of a DNS name without the trailing dot, as DNS
+// names are case-insensitive.
+func normalizeDNSName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
+
+// fqdnController learns the addresses of the FQDNs used in egress rules by snooping the DNS
+// responses sent to the local Pods. The responses are sent to the agent by the flows installed
+// with InstallDNSInterceptFlows, which are installed only while there are rules with FQDNs. Each
+// response is held back until the rules selecting the names it resolves have been updated with
+// the new addresses, and forwarded to the Pod afterwards, so that the Pod can't connect to an
+// address before it's allowed. The addresses expire with the TTL of their records.
+type fqdnController struct {
+	ofClient openflow.Client
+	// enqueueRule marks a rule as dirty, so that the reconciler updates its addresses.
+	enqueueRule func(ruleID string)
+	// dnsResponseCh receives the intercepted DNS responses.
+	dnsResponseCh chan *ofctrl.PacketIn
+	// limiter limits the rate at which the intercepted DNS responses are processed.
+	limiter *rate.Limiter
+
+	mutex sync.Mutex
+	// dnsEntries maps the DNS names selected by the rules to the addresses they resolve to
+	// and the expiry times of these addresses.
+	dnsEntries map[string]map[string]time.Time
+	// ruleSelectors maps the ID of a rule with FQDNs to the selectors of its FQDNs.
+	ruleSelectors map[string][]fqdnSelector
+	// pendingWaiters maps the ID of a rule to the channels of the DNS responses which wait
+	// for the rule to be reconciled with the addresses they resolve.
+	pendingWaiters map[string][]chan struct{}
+	// syncingWaiters maps the ID of a rule being reconciled to the channels which must be
+	// closed once it's done, i.e. the pending ones when the reconciler got its addresses.
+	syncingWaiters map[string][]chan struct{}
+}
+
+func newFQDNController(ofClient openflow.Client, enqueueRule func(ruleID string)) *fqdnController {
+	return &fqdnController{
+		ofClient:       ofClient,
+		enqueueRule:    enqueueRule,
+		dnsResponseCh:  make(chan *ofctrl.PacketIn, dnsResponseChanSize),
+		limiter:        rate.NewLimiter(dnsResponseRate, dnsResponseBurst),
+		dnsEntries:     map[string]map[string]time.Time{},
+		ruleSelectors:  map[string][]fqdnSelector{},
+		pendingWaiters: map[string][]chan struct{}{},
+		syncingWaiters: map[string][]chan struct{}{},
+	}
+}
+
+// run processes the intercepted DNS responses and removes the expired addresses until stopCh is
+// closed.
+func (f *fqdnController) run(stopCh <-chan struct{}) {
+	go wait.Until(func() {
+		f.removeExpiredAddresses(time.Now())
+	}, dnsCacheGCInterval, stopCh)
+	for {
+		select {
+		case pktIn := <-f.dnsResponseCh:
+			f.processDNSResponse(pktIn)
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// handleDNSResponse queues an intercepted DNS response for processing. If the rate limit is
+// exceeded or the queue is full, the response is forwarded to the Pod directly, as dropping it
+// would only delay the resolution.
+func (f *fqdnController) handleDNSResponse(pktIn *ofctrl.PacketIn) {
+	if !f.limiter.Allow() {
+		klog.V(2).Info("DNS response rate limit exceeded, forwarding the response without processing it")
+		f.forwardDNSResponse(pktIn)
+		return
+	}
+	select {
+	case f.dnsResponseCh <- pktIn:
+	default:
+		klog.Warning("DNS response queue is full, forwarding the response without processing it")
+		f.forwardDNSResponse(pktIn)
+	}
+}
+
+// processDNSResponse learns the addresses resolved by a DNS response and forwards it to the Pod
+// once the rules using them have been updated, or dnsResponseHoldTimeout has passed.
+func (f *fqdnController) processDNSResponse(pktIn *ofctrl.PacketIn) {
+	var waiters []chan struct{}
+	messages, err := getDNSMessages(pktIn)
+	if err != nil {
+		klog.Errorf("Failed to process DNS response: %v", err)
+	}
+	for _, data := range messages {
+		messageWaiters, err := f.updateDNSEntries(data, time.Now())
+		if err != nil {
+			klog.Errorf("Failed to process DNS response: %v", err)
+			continue
+		}
+		waiters = append(waiters, messageWaiters...)
+	}
+	if len(waiters) == 0 {
+		f.forwardDNSResponse(pktIn)
+		return
+	}
+	go func() {
+		timer := time.NewTimer(dnsResponseHoldTimeout)
+		defer timer.Stop()
+		for _, ch := range waiters {
+			select {
+			case <-ch:
+			case <-timer.C:
+				klog.Warning("Timed out waiting for the rules to be updated with the addresses of a DNS response")
+				f.forwardDNSResponse(pktIn)
+				return
+			}
+		}
+		f.forwardDNSResponse(pktIn)
+	}()
+}
+
+// forwardDNSResponse outputs a DNS response to the port of the Pod, which was stored in
+// PortCacheReg by the pipeline before the packet was intercepted.
+func (f *fqdnController) forwardDNSResponse(pktIn *ofctrl.PacketIn) {
+	outPort := openflow.GetMatchRegField(&pktIn.Match, openflow.PortCacheReg)
+	if err := f.ofClient.ForwardPacket(pktIn, outPort); err != nil {
+		klog.Errorf("Failed to forward DNS response to port %d: %v", outPort, err)
+	}
+}
+
+// getDNSMessages returns the DNS messages carried by the UDP or TCP packet of a PacketIn message.
+// A TCP segment carries DNS messages prefixed with their lengths, and may carry none of them, e.g.
+// if it's only an acknowledgement, or several of them.
+func getDNSMessages(pktIn *ofctrl.PacketIn) ([][]byte, error) {
+	var ipProto uint8
+	var ipPayload []byte
+	switch pktIn.Data.Ethertype {
+	case protocol.IPv4_MSG:
+		ipPacket, ok := pktIn.Data.Data.(*protocol.IPv4)
+		if !ok {
+			return nil, errors.New("invalid IPv4 packet")
+		}
+		data, err := ipPacket.Data.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read IPv4 payload: %v", err)
+		}
+		// The payload is truncated to the length of the IPv4 header, so that the padding of the
+		// Ethernet frame is not considered as part of it.
+		if payloadLen := int(ipPacket.Length) - int(ipPacket.IHL)*4; payloadLen >= 0 && payloadLen < len(data) {
+			data = data[:payloadLen]
+		}
+		ipProto, ipPayload = ipPacket.Protocol, data
+	case protocol.IPv6_MSG:
+		data, err := pktIn.Data.Data.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read IPv6 packet: %v", err)
+		}
+		ipPacket, err := binding.ParseIPv6Packet(data)
+		if err != nil {
+			return nil, err
+		}
+		ipProto, ipPayload = ipPacket.NextHeader, ipPacket.Payload
+	default:
+		return nil, fmt.Errorf("unsupported ethertype 0x%x", pktIn.Data.Ethertype)
+	}
+	switch ipProto {
+	case protocol.Type_UDP:
+		if len(ipPayload) < 8 {
+			return nil, errors.New("invalid UDP packet")
+		}
+		return [][]byte{ipPayload[8:]}, nil
+	case protocol.Type_TCP:
+		if len(ipPayload) < 20 {
+			return nil, errors.New("invalid TCP segment")
+		}
+		headerLen := int(ipPayload[12]>>4) * 4
+		if headerLen < 20 || headerLen > len(ipPayload) {
+			return nil, fmt.Errorf("invalid TCP header length %d", headerLen)
+		}
+		return splitTCPDNSMessages(ipPayload[headerLen:])
+	default:
+		return nil, fmt.Errorf("unsupported IP protocol %d", ipProto)
+	}
+}
+
+// splitTCPDNSMessages returns the DNS messages of the payload of a TCP segment. The messages
+// spanning several segments are not reassembled: the segments carrying them are forwarded
+// without being processed.
+func splitTCPDNSMessages(data []byte) ([][]byte, error) {
+	var messages [][]byte
+	for len(data) > 0 {
+		if len(data) < 2 {
+			return nil, errors.New("incomplete DNS message length in TCP segment")
+		}
+		messageLen := int(binary.BigEndian.Uint16(data))
+		if len(data) < 2+messageLen {
+			return nil, fmt.Errorf("incomplete DNS message in TCP segment: %d bytes, expected %d", len(data)-2, messageLen)
+		}
+		messages = append(messages, data[2:2+messageLen])
+		data = data[2+messageLen:]
+	}
+	return messages, nil
+}
+
+// parseDNSResponse returns the addresses resolved by a DNS response and their TTLs, indexed by
+// the normalized names they are resolved for. The addresses of a canonical name are also
+// returned for all its aliases. The answers of a truncated response which could be parsed are
+// returned even if the response ends in the middle of an answer.
+func parseDNSResponse(data []byte) (map[string]map[string]time.Duration, error) {
+	var parser dnsmessage.Parser
+	header, err := parser.Start(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DNS message: %v", err)
+	}
+	if !header.Response || header.RCode != dnsmessage.RCodeSuccess {
+		return nil, nil
+	}
+	if err := parser.SkipAllQuestions(); err != nil {
+		return nil, fmt.Errorf("invalid DNS questions: %v", err)
+	}
+	addresses := map[string]map[string]time.Duration{}
+	// aliases maps a canonical name to the names which are aliases for it.
+	aliases := map[string][]string{}
+	if err := parseDNSAnswers(&parser, addresses, aliases); err != nil {
+		if !header.Truncated {
+			return nil, err
+		}
+		klog.V(2).Infof("Ignoring the last answer of a truncated DNS response: %v", err)
+	}
+	result := map[string]map[string]time.Duration{}
+	for name, ips := range addresses {
+		// Walk the aliases of the name, guarding against CNAME loops.
+		visited := sets.NewString()
+		names := []string{name}
+		for len(names) > 0 {
+			current := names[0]
+			names = names[1:]
+			if visited.Has(current) {
+				continue
+			}
+			visited.Insert(current)
+			names = append(names, aliases[current]...)
+			if result[current] == nil {
+				result[current] = map[string]time.Duration{}
+			}
+			for ip, ttl := range ips {
+				result[current][ip] = ttl
+			}
+		}
+	}
+	return result, nil
+}
+
+// parseDNSAnswers adds the addresses resolved by the answers of a DNS response to addresses, and
+// the aliases defined by its CNAME records to aliases.
+func parseDNSAnswers(parser *dnsmessage.Parser, addresses map[string]map[string]time.Duration, aliases map[string][]string) error {
+	for {
+		answer, err := parser.AnswerHeader()
+		if err == dnsmessage.ErrSectionDone {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid DNS answer: %v", err)
+		}
+		name := normalizeDNSName(answer.Name.String())
+		ttl := time.Duration(answer.TTL) * time.Second
+		var ip net.IP
+		switch answer.Type {
+		case dnsmessage.TypeA:
+			resource, err := parser.AResource()
+			if err != nil {
+				return fmt.Errorf("invalid A record: %v", err)
+			}
+			ip = net.IP(resource.A[:])
+		case dnsmessage.TypeAAAA:
+			resource, err := parser.AAAAResource()
+			if err != nil {
+				return fmt.Errorf("invalid AAAA record: %v", err)
+			}
+			ip = net.IP(resource.AAAA[:])
+		case dnsmessage.TypeCNAME:
+			resource, err := parser.CNAMEResource()
+			if err != nil {
+				return fmt.Errorf("invalid CNAME record: %v", err)
+			}
+			canonicalName := normalizeDNSName(resource.CNAME.String())
+			aliases[canonicalName] = append(aliases[canonicalName], name)
+			continue
+		default:
+			if err := parser.SkipAnswer(); err != nil {
+				return fmt.Errorf("invalid DNS answer: %v", err)
+			}
+			continue
+		}
+		if addresses[name] == nil {
+			addresses[name] = map[string]time.Duration{}
+		}
+		addresses[name][ip.String()] = ttl
+	}
+}
+
+// updateDNSEntries adds the addresses resolved by a DNS response for the selected names to
+// dnsEntries, or extends their expiry times. The rules using new addresses are marked as dirty,
+// and the returned channels are closed once they have been reconciled.
+func (f *fqdnController) updateDNSEntries(data []byte, now time.Time) ([]chan struct{}, error) {
+	resolved, err := parseDNSResponse(data)
+	if err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	dirtyRules := sets.NewString()
+	for name, ips := range resolved {
+		ruleIDs := f.getRulesForNameLocked(name)
+		if len(ruleIDs) == 0 {
+			continue
+		}
+		entry, exists := f.dnsEntries[name]
+		if !exists {
+			entry = map[string]time.Time{}
+			f.dnsEntries[name] = entry
+		}
+		for ip, ttl := range ips {
+			if ttl < minDNSTTL {
+				ttl = minDNSTTL
+			}
+			expiry := now.Add(ttl)
+			if oldExpiry, exists := entry[ip]; !exists {
+				dirtyRules.Insert(ruleIDs...)
+			} else if oldExpiry.After(expiry) {
+				continue
+			}
+			entry[ip] = expiry
+		}
+	}
+	waiters := make([]chan struct{}, 0, len(dirtyRules))
+	for ruleID := range dirtyRules {
+		ch := make(chan struct{})
+		f.pendingWaiters[ruleID] = append(f.pendingWaiters[ruleID], ch)
+		waiters = append(waiters, ch)
+	}
+	f.mutex.Unlock()
+
+	for ruleID := range dirtyRules {
+		f.enqueueRule(ruleID)
+	}
+	return waiters, nil
+}
+
+// removeExpiredAddresses removes the addresses which have expired from dnsEntries and marks the
+// rules using them as dirty.
+func (f *fqdnController) removeExpiredAddresses(now time.Time) {
+	f.mutex.Lock()
+	dirtyRules := sets.NewString()
+	for name, entry := range f.dnsEntries {
+		expired := false
+		for ip, expiry := range entry {
+			if !expiry.After(now) {
+				delete(entry, ip)
+				expired = true
+			}
+		}
+		if len(entry) == 0 {
+			delete(f.dnsEntries, name)
+		}
+		if expired {
+			dirtyRules.Insert(f.getRulesForNameLocked(name)...)
+		}
+	}
+	f.mutex.Unlock()
+
+	for ruleID := range dirtyRules {
+		f.enqueueRule(ruleID)
+	}
+}
+
+// getRulesForNameLocked returns the IDs of the rules selecting the provided normalized name.
+func (f *fqdnController) getRulesForNameLocked(name string) []string {
+	var ruleIDs []string
+	for ruleID, selectors := range f.ruleSelectors {
+		for _, selector := range selectors {
+			if selector.matches(name) {
+				ruleIDs = append(ruleIDs, ruleID)
+				break
+			}
+		}
+	}
+	return ruleIDs
+}
+
+// addRule registers the FQDNs of a rule, installing the flows intercepting the DNS responses if
+// it's the first rule with FQDNs, and returns the addresses they currently resolve to. It must be
+// called by the reconciler whenever it reconciles the rule, so that the DNS responses waiting for
+// the rule are released by ruleSynced afterwards.
+func (f *fqdnController) addRule(ruleID string, fqdns []string) (sets.String, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	selectors, exists := f.ruleSelectors[ruleID]
+	if !exists {
+		if len(f.ruleSelectors) == 0 {
+			if err := f.ofClient.InstallDNSInterceptFlows(); err != nil {
+				return nil, fmt.Errorf("error installing DNS intercept flows: %v", err)
+			}
+		}
+		for _, fqdn := range fqdns {
+			selectors = append(selectors, newFQDNSelector(fqdn))
+		}
+		f.ruleSelectors[ruleID] = selectors
+	}
+	f.syncingWaiters[ruleID] = append(f.syncingWaiters[ruleID], f.pendingWaiters[ruleID]...)
+	delete(f.pendingWaiters, ruleID)
+
+	ips := sets.NewString()
+	for name, entry := range f.dnsEntries {
+		for _, selector := range selectors {
+			if selector.matches(name) {
+				for ip := range entry {
+					ips.Insert(ip)
+				}
+				break
+			}
+		}
+	}
+	return ips, nil
+}
+
+// deleteRule unregisters the FQDNs of a rule, removing the flows intercepting the DNS responses
+// if it's the last rule with FQDNs. The DNS responses waiting for the rule are released.
+func (f *fqdnController) deleteRule(ruleID string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if _, exists := f.ruleSelectors[ruleID]; !exists {
+		return nil
+	}
+	if len(f.ruleSelectors) == 1 {
+		if err := f.ofClient.UninstallDNSInterceptFlows(); err != nil {
+			return fmt.Errorf("error uninstalling DNS intercept flows: %v", err)
+		}
+	}
+	delete(f.ruleSelectors, ruleID)
+	f.releaseWaitersLocked(f.pendingWaiters, ruleID)
+	f.releaseWaitersLocked(f.syncingWaiters, ruleID)
+	// Forget the names which are not selected by any rule anymore.
+	for name := range f.dnsEntries {
+		if len(f.getRulesForNameLocked(name)) == 0 {
+			delete(f.dnsEntries, name)
+		}
+	}
+	return nil
+}
+
+// ruleSynced releases the DNS responses waiting for a rule whose addresses have been got by the
+// reconciler. It must be called after each attempt to reconcile a rule, successful or not.
+func (f *fqdnController) ruleSynced(ruleID string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.releaseWaitersLocked(f.syncingWaiters, ruleID)
+}
+
+func (f *fqdnController) releaseWaitersLocked(waiters map[string][]chan struct{}, ruleID string) {
+	for _, ch := range waiters[ruleID] {
+		close(ch)
+	}
+	delete(waiters, ruleID)
+}
@@ -0,0 +1,414 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/contiv/libOpenflow/protocol"
+	"github.com/contiv/ofnet/ofctrl"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
+	openflowtest "github.com/vmware-tanzu/antrea/pkg/agent/openflow/testing"
+	"github.com/vmware-tanzu/antrea/pkg/agent/types"
+	"github.com/vmware-tanzu/antrea/pkg/agent/util"
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+)
+
+func TestFQDNSelectorMatches(t *testing.T) {
+	tests := []struct {
+		fqdn     string
+		name     string
+		expected bool
+	}{
+		{"www.example.com", "www.example.com", true},
+		{"WWW.Example.com.", "www.example.com", true},
+		{"www.example.com", "example.com", false},
+		{"www.example.com", "api.www.example.com", false},
+		{"*.example.com", "www.example.com", true},
+		{"*.example.com", "a.b.example.com", true},
+		{"*.example.com", "example.com", false},
+		{"*.example.com", "www.badexample.com", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, newFQDNSelector(tt.fqdn).matches(tt.name), "FQDN %s, name %s", tt.fqdn, tt.name)
+	}
+}
+
+// dnsRecord describes an answer of the DNS responses built by newDNSResponse. If cname is set,
+// it's a CNAME record, otherwise an A or AAAA record depending on the family of ip.
+type dnsRecord struct {
+	name  string
+	ttl   uint32
+	ip    string
+	cname string
+}
+
+func newDNSResponse(t *testing.T, question string, records ...dnsRecord) []byte {
+	builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{Response: true, RCode: dnsmessage.RCodeSuccess})
+	require.NoError(t, builder.StartQuestions())
+	require.NoError(t, builder.Question(dnsmessage.Question{
+		Name:  dnsmessage.MustNewName(question),
+		Type:  dnsmessage.TypeA,
+		Class: dnsmessage.ClassINET,
+	}))
+	require.NoError(t, builder.StartAnswers())
+	for _, record := range records {
+		header := dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(record.name), Class: dnsmessage.ClassINET, TTL: record.ttl}
+		if record.cname != "" {
+			require.NoError(t, builder.CNAMEResource(header, dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName(record.cname)}))
+			continue
+		}
+		ip := net.ParseIP(record.ip)
+		if ip.To4() != nil {
+			var a [4]byte
+			copy(a[:], ip.To4())
+			require.NoError(t, builder.AResource(header, dnsmessage.AResource{A: a}))
+		} else {
+			var aaaa [16]byte
+			copy(aaaa[:], ip)
+			require.NoError(t, builder.AAAAResource(header, dnsmessage.AAAAResource{AAAA: aaaa}))
+		}
+	}
+	data, err := builder.Finish()
+	require.NoError(t, err)
+	return data
+}
+
+func TestParseDNSResponse(t *testing.T) {
+	data := newDNSResponse(t, "www.example.com.",
+		dnsRecord{name: "WWW.example.com.", ttl: 300, cname: "cdn.example.net."},
+		dnsRecord{name: "cdn.example.net.", ttl: 30, ip: "1.1.1.1"},
+		dnsRecord{name: "cdn.example.net.", ttl: 60, ip: "fd00::1"},
+		dnsRecord{name: "other.example.org.", ttl: 10, ip: "2.2.2.2"},
+	)
+	resolved, err := parseDNSResponse(data)
+	require.NoError(t, err)
+	expected := map[string]map[string]time.Duration{
+		"www.example.com":   {"1.1.1.1": 30 * time.Second, "fd00::1": 60 * time.Second},
+		"cdn.example.net":   {"1.1.1.1": 30 * time.Second, "fd00::1": 60 * time.Second},
+		"other.example.org": {"2.2.2.2": 10 * time.Second},
+	}
+	assert.Equal(t, expected, resolved)
+
+	_, err = parseDNSResponse([]byte{0x1})
+	assert.Error(t, err)
+}
+
+func TestParseTruncatedDNSResponse(t *testing.T) {
+	data := newDNSResponse(t, "www.example.com.",
+		dnsRecord{name: "www.example.com.", ttl: 30, ip: "1.1.1.1"},
+		dnsRecord{name: "www.example.com.", ttl: 30, ip: "1.1.1.2"},
+	)
+	// Cut the response in the middle of its last answer.
+	data = data[:len(data)-2]
+	_, err := parseDNSResponse(data)
+	assert.Error(t, err)
+
+	// Set the TC bit of the header.
+	data[2] |= 0x2
+	resolved, err := parseDNSResponse(data)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]time.Duration{"www.example.com": {"1.1.1.1": 30 * time.Second}}, resolved)
+}
+
+// newDNSPacketIn returns a PacketIn message carrying the provided UDP or TCP payload sent from
+// port 53, parsed from the Ethernet frame like the PacketIn messages received from OVS.
+func newDNSPacketIn(t *testing.T, isIPv6 bool, ipProto uint8, payload []byte) *ofctrl.PacketIn {
+	var transport []byte
+	if ipProto == protocol.Type_UDP {
+		transport = make([]byte, 8)
+		binary.BigEndian.PutUint16(transport[4:6], uint16(8+len(payload)))
+	} else {
+		// The TCP header carries 4 bytes of options.
+		transport = make([]byte, 24)
+		transport[12] = 6 << 4
+	}
+	binary.BigEndian.PutUint16(transport[0:2], 53)
+	binary.BigEndian.PutUint16(transport[2:4], 10000)
+	transport = append(transport, payload...)
+
+	var ipPacket []byte
+	ethertype := uint16(protocol.IPv4_MSG)
+	if isIPv6 {
+		ethertype = protocol.IPv6_MSG
+		ipPacket = make([]byte, 40)
+		ipPacket[0] = 6 << 4
+		binary.BigEndian.PutUint16(ipPacket[4:6], uint16(len(transport)))
+		ipPacket[6] = ipProto
+		copy(ipPacket[8:24], net.ParseIP("fd00::53"))
+		copy(ipPacket[24:40], net.ParseIP("fd00::2"))
+	} else {
+		ipPacket = make([]byte, 20)
+		ipPacket[0] = 4<<4 | 5
+		binary.BigEndian.PutUint16(ipPacket[2:4], uint16(20+len(transport)))
+		ipPacket[9] = ipProto
+		copy(ipPacket[12:16], net.ParseIP("10.0.0.53").To4())
+		copy(ipPacket[16:20], net.ParseIP("10.0.0.2").To4())
+	}
+	frame := make([]byte, 14)
+	binary.BigEndian.PutUint16(frame[12:14], ethertype)
+	frame = append(frame, append(ipPacket, transport...)...)
+	// Pad the frame to the minimum Ethernet frame size.
+	for len(frame) < 60 {
+		frame = append(frame, 0)
+	}
+	pktIn := new(ofctrl.PacketIn)
+	require.NoError(t, pktIn.Data.UnmarshalBinary(frame))
+	return pktIn
+}
+
+// tcpDNSMessages prefixes each of the provided DNS messages with its length, like in the
+// payloads of TCP segments.
+func tcpDNSMessages(messages ...[]byte) []byte {
+	var data []byte
+	for _, message := range messages {
+		data = append(data, byte(len(message)>>8), byte(len(message)))
+		data = append(data, message...)
+	}
+	return data
+}
+
+func TestGetDNSMessages(t *testing.T) {
+	message1 := newDNSResponse(t, "www.example.com.", dnsRecord{name: "www.example.com.", ttl: 30, ip: "1.1.1.1"})
+	message2 := newDNSResponse(t, "www.example.com.", dnsRecord{name: "www.example.com.", ttl: 30, ip: "fd00::1"})
+	tests := []struct {
+		name             string
+		isIPv6           bool
+		ipProto          uint8
+		payload          []byte
+		expectedMessages [][]byte
+		expectedErr      bool
+	}{
+		{
+			name:             "IPv4 UDP",
+			ipProto:          protocol.Type_UDP,
+			payload:          message1,
+			expectedMessages: [][]byte{message1},
+		},
+		{
+			name:             "IPv6 UDP",
+			isIPv6:           true,
+			ipProto:          protocol.Type_UDP,
+			payload:          message2,
+			expectedMessages: [][]byte{message2},
+		},
+		{
+			name:             "IPv4 TCP",
+			ipProto:          protocol.Type_TCP,
+			payload:          tcpDNSMessages(message1, message2),
+			expectedMessages: [][]byte{message1, message2},
+		},
+		{
+			name:             "IPv6 TCP",
+			isIPv6:           true,
+			ipProto:          protocol.Type_TCP,
+			payload:          tcpDNSMessages(message2),
+			expectedMessages: [][]byte{message2},
+		},
+		{
+			name:    "TCP acknowledgement",
+			ipProto: protocol.Type_TCP,
+		},
+		{
+			name:        "TCP incomplete message",
+			isIPv6:      true,
+			ipProto:     protocol.Type_TCP,
+			payload:     tcpDNSMessages(message1)[:20],
+			expectedErr: true,
+		},
+		{
+			name:        "ICMP",
+			ipProto:     protocol.Type_ICMP,
+			payload:     make([]byte, 8),
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages, err := getDNSMessages(newDNSPacketIn(t, tt.isIPv6, tt.ipProto, tt.payload))
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedMessages, messages)
+		})
+	}
+}
+
+func TestHandleDNSResponseRateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ofClient := openflowtest.NewMockClient(ctrl)
+	f := newFQDNController(ofClient, func(string) {})
+	f.limiter = rate.NewLimiter(rate.Every(time.Hour), 2)
+	pktIn := newDNSPacketIn(t, false, protocol.Type_UDP, newDNSResponse(t, "www.example.com."))
+
+	f.handleDNSResponse(pktIn)
+	f.handleDNSResponse(pktIn)
+	// The responses exceeding the rate limit are forwarded without being queued.
+	ofClient.EXPECT().ForwardPacket(pktIn, uint32(0))
+	f.handleDNSResponse(pktIn)
+	assert.Len(t, f.dnsResponseCh, 2)
+}
+
+func TestFQDNControllerRules(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	mockOFClient := openflowtest.NewMockClient(controller)
+	enqueuedRules := sets.NewString()
+	f := newFQDNController(mockOFClient, func(ruleID string) {
+		enqueuedRules.Insert(ruleID)
+	})
+	now := time.Now()
+
+	// The DNS intercept flows are installed with the first rule.
+	mockOFClient.EXPECT().InstallDNSInterceptFlows()
+	ips, err := f.addRule("rule1", []string{"*.example.com"})
+	require.NoError(t, err)
+	assert.Empty(t, ips)
+	ips, err = f.addRule("rule2", []string{"api.example.org"})
+	require.NoError(t, err)
+	assert.Empty(t, ips)
+
+	// Names which are not selected by any rule are ignored.
+	waiters, err := f.updateDNSEntries(newDNSResponse(t, "www.example.org.",
+		dnsRecord{name: "www.example.org.", ttl: 30, ip: "3.3.3.3"}), now)
+	require.NoError(t, err)
+	assert.Empty(t, waiters)
+	assert.Empty(t, enqueuedRules)
+	assert.Empty(t, f.dnsEntries)
+
+	waiters, err = f.updateDNSEntries(newDNSResponse(t, "www.example.com.",
+		dnsRecord{name: "www.example.com.", ttl: 30, ip: "1.1.1.1"}), now)
+	require.NoError(t, err)
+	require.Len(t, waiters, 1)
+	assert.Equal(t, sets.NewString("rule1"), enqueuedRules)
+
+	// The response is released only once the rule has been reconciled with its addresses.
+	f.ruleSynced("rule1")
+	assertWaiting(t, waiters[0])
+	ips, err = f.addRule("rule1", []string{"*.example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, sets.NewString("1.1.1.1"), ips)
+	assertWaiting(t, waiters[0])
+	f.ruleSynced("rule1")
+	assertReleased(t, waiters[0])
+
+	// Known addresses don't make the rules dirty.
+	enqueuedRules = sets.NewString()
+	waiters, err = f.updateDNSEntries(newDNSResponse(t, "www.example.com.",
+		dnsRecord{name: "www.example.com.", ttl: 1, ip: "1.1.1.1"}), now.Add(10*time.Second))
+	require.NoError(t, err)
+	assert.Empty(t, waiters)
+	assert.Empty(t, enqueuedRules)
+
+	// The addresses are removed once their TTL has expired.
+	f.removeExpiredAddresses(now.Add(20 * time.Second))
+	assert.Empty(t, enqueuedRules)
+	f.removeExpiredAddresses(now.Add(40 * time.Second))
+	assert.Equal(t, sets.NewString("rule1"), enqueuedRules)
+	ips, err = f.addRule("rule1", []string{"*.example.com"})
+	require.NoError(t, err)
+	assert.Empty(t, ips)
+
+	// The responses waiting for a deleted rule are released, and the DNS intercept flows are
+	// removed with the last rule.
+	waiters, err = f.updateDNSEntries(newDNSResponse(t, "api.example.org.",
+		dnsRecord{name: "api.example.org.", ttl: 30, ip: "2.2.2.2"}), now)
+	require.NoError(t, err)
+	require.Len(t, waiters, 1)
+	require.NoError(t, f.deleteRule("rule2"))
+	assertReleased(t, waiters[0])
+	assert.Empty(t, f.dnsEntries)
+	mockOFClient.EXPECT().UninstallDNSInterceptFlows()
+	require.NoError(t, f.deleteRule("rule1"))
+	assert.Empty(t, f.ruleSelectors)
+}
+
+func assertWaiting(t *testing.T, ch chan struct{}) {
+	select {
+	case <-ch:
+		t.Error("DNS response should not be released")
+	default:
+	}
+}
+
+func assertReleased(t *testing.T, ch chan struct{}) {
+	select {
+	case <-ch:
+	default:
+		t.Error("DNS response should be released")
+	}
+}
+
+func TestReconcilerFQDNs(t *testing.T) {
+	ifaceStore := interfacestore.NewInterfaceStore()
+	ifaceStore.AddInterface(
+		&interfacestore.InterfaceConfig{
+			InterfaceName:            util.GenerateContainerInterfaceName("pod1", "ns1"),
+			IPs:                      []net.IP{net.ParseIP("2.2.2.2")},
+			ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod1", PodNamespace: "ns1"},
+			OVSPortConfig:            &interfacestore.OVSPortConfig{OFPort: 1}})
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	mockOFClient := openflowtest.NewMockClient(controller)
+	f := newFQDNController(mockOFClient, func(ruleID string) {})
+	r := newReconciler(mockOFClient, ifaceStore, f, nil)
+	now := time.Now()
+	rule := &CompletedRule{
+		rule: &rule{
+			ID:        "egress-rule",
+			Direction: v1beta1.DirectionOut,
+			To:        v1beta1.NetworkPolicyPeer{FQDNs: []string{"www.example.com"}},
+			Services:  services1,
+		},
+		Pods: appliedToGroup1,
+	}
+
+	mockOFClient.EXPECT().InstallDNSInterceptFlows()
+	mockOFClient.EXPECT().InstallPolicyRuleFlows(gomock.Any(), &types.PolicyRule{
+		Direction: v1beta1.DirectionOut,
+		From:      ipsToOFAddresses(sets.NewString("2.2.2.2")),
+		To:        []types.Address{},
+		Service:   services1,
+	}, gomock.Any(), gomock.Any())
+	require.NoError(t, r.Reconcile(rule))
+
+	_, err := f.updateDNSEntries(newDNSResponse(t, "www.example.com.",
+		dnsRecord{name: "www.example.com.", ttl: 30, ip: "1.1.1.1"}), now)
+	require.NoError(t, err)
+	ofID := getLastRealizedOFID(t, r, rule.ID)
+	mockOFClient.EXPECT().AddPolicyRuleAddress(ofID, types.DstAddress, ipsToOFAddresses(sets.NewString("1.1.1.1")))
+	require.NoError(t, r.Reconcile(rule))
+
+	f.removeExpiredAddresses(now.Add(time.Minute))
+	mockOFClient.EXPECT().DeletePolicyRuleAddress(ofID, types.DstAddress, ipsToOFAddresses(sets.NewString("1.1.1.1")))
+	require.NoError(t, r.Reconcile(rule))
+
+	mockOFClient.EXPECT().UninstallDNSInterceptFlows()
+	mockOFClient.EXPECT().UninstallPolicyRuleFlows(ofID)
+	require.NoError(t, r.Forget(rule.ID))
+}
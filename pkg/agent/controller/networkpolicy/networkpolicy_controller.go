@@ -19,6 +19,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/contiv/ofnet/ofctrl"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -31,6 +34,7 @@ import (
 	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
 	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
 	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+	"github.com/vmware-tanzu/antrea/pkg/features"
 )
 
 const (
@@ -68,6 +72,24 @@ type Controller struct {
 	// reconciler provides interfaces to reconcile the desired state of
 	// NetworkPolicy rules with the actual state of Openflow entries.
 	reconciler Reconciler
+	ofClient   openflow.Client
+	ifaceStore interfacestore.InterfaceStore
+	// packetInCh receives the PacketIn messages sent by the NetworkPolicy
+	// flows to log or reject connections.
+	packetInCh chan *ofctrl.PacketIn
+	// auditLogger logs the connections matching the NetworkPolicy rules with
+	// logging enabled.
+	auditLogger *auditLogger
+	// fqdnController learns the IPs of the FQDNs used in egress rules from
+	// the DNS responses to local Pods. It's nil if ClusterNetworkPolicy is
+	// disabled.
+	fqdnController *fqdnController
+	// statusController reports the realization statuses of the
+	// NetworkPolicies to antrea-controller.
+	statusController *statusController
+	// statsCollector reports the traffic statistics of the NetworkPolicies to
+	// antrea-controller. It's nil if NetworkPolicyStats is disabled.
+	statsCollector *statsCollector
 
 	networkPolicyWatcher  *watcher
 	appliedToGroupWatcher *watcher
@@ -83,9 +105,20 @@ func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 	c := &Controller{
 		antreaClientProvider: antreaClientGetter,
 		queue:                workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "networkpolicyrule"),
-		reconciler:           newReconciler(ofClient, ifaceStore),
+		ofClient:             ofClient,
+		ifaceStore:           ifaceStore,
+		packetInCh:           make(chan *ofctrl.PacketIn, packetInChanSize),
+		auditLogger:          newAuditLogger(ofClient, ifaceStore),
 	}
+	if features.DefaultFeatureGate.Enabled(features.ClusterNetworkPolicy) {
+		c.fqdnController = newFQDNController(ofClient, c.enqueueRule)
+	}
+	c.reconciler = newReconciler(ofClient, ifaceStore, c.fqdnController, c.enqueueRule)
 	c.ruleCache = newRuleCache(c.enqueueRule, podUpdates)
+	c.statusController = newStatusController(antreaClientGetter, nodeName, c.ruleCache)
+	if features.DefaultFeatureGate.Enabled(features.NetworkPolicyStats) {
+		c.statsCollector = newStatsCollector(antreaClientGetter, ofClient, c.reconciler, nodeName)
+	}
 
 	// Use nodeName to filter resources when watching resources.
 	options := metav1.ListOptions{
@@ -94,12 +127,12 @@ func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 
 	c.networkPolicyWatcher = &watcher{
 		objectType: "NetworkPolicy",
-		watchFunc: func() (watch.Interface, error) {
+		watchFunc: func(resourceVersion string) (watch.Interface, error) {
 			antreaClient, err := c.antreaClientProvider.GetAntreaClient()
 			if err != nil {
 				return nil, err
 			}
-			return antreaClient.NetworkingV1beta1().NetworkPolicies("").Watch(options)
+			return antreaClient.NetworkingV1beta1().NetworkPolicies("").Watch(withResourceVersion(options, resourceVersion))
 		},
 		AddFunc: func(obj runtime.Object) error {
 			policy, ok := obj.(*v1beta1.NetworkPolicy)
@@ -107,6 +140,7 @@ func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 				return fmt.Errorf("cannot convert to *v1beta1.NetworkPolicy: %v", obj)
 			}
 			c.ruleCache.AddNetworkPolicy(policy)
+			c.statusController.EnqueuePolicy(policy.UID)
 			klog.Infof("NetworkPolicy %s/%s applied to Pods on this Node", policy.Namespace, policy.Name)
 			return nil
 		},
@@ -116,6 +150,7 @@ func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 				return fmt.Errorf("cannot convert to *v1beta1.NetworkPolicy: %v", obj)
 			}
 			c.ruleCache.UpdateNetworkPolicy(policy)
+			c.statusController.EnqueuePolicy(policy.UID)
 			return nil
 		},
 		DeleteFunc: func(obj runtime.Object) error {
@@ -138,18 +173,21 @@ func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 				klog.Infof("NetworkPolicy %s/%s applied to Pods on this Node", policies[i].Namespace, policies[i].Name)
 			}
 			c.ruleCache.ReplaceNetworkPolicies(policies)
+			// The watch has been restarted, which may be caused by a
+			// restart of antrea-controller: report all the statuses again.
+			c.statusController.Resync()
 			return nil
 		},
 	}
 
 	c.appliedToGroupWatcher = &watcher{
 		objectType: "AppliedToGroup",
-		watchFunc: func() (watch.Interface, error) {
+		watchFunc: func(resourceVersion string) (watch.Interface, error) {
 			antreaClient, err := c.antreaClientProvider.GetAntreaClient()
 			if err != nil {
 				return nil, err
 			}
-			return antreaClient.NetworkingV1beta1().AppliedToGroups().Watch(options)
+			return antreaClient.NetworkingV1beta1().AppliedToGroups().Watch(withResourceVersion(options, resourceVersion))
 		},
 		AddFunc: func(obj runtime.Object) error {
 			group, ok := obj.(*v1beta1.AppliedToGroup)
@@ -191,12 +229,12 @@ func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 
 	c.addressGroupWatcher = &watcher{
 		objectType: "AddressGroup",
-		watchFunc: func() (watch.Interface, error) {
+		watchFunc: func(resourceVersion string) (watch.Interface, error) {
 			antreaClient, err := c.antreaClientProvider.GetAntreaClient()
 			if err != nil {
 				return nil, err
 			}
-			return antreaClient.NetworkingV1beta1().AddressGroups().Watch(options)
+			return antreaClient.NetworkingV1beta1().AddressGroups().Watch(withResourceVersion(options, resourceVersion))
 		},
 		AddFunc: func(obj runtime.Object) error {
 			group, ok := obj.(*v1beta1.AddressGroup)
@@ -292,6 +330,16 @@ func (c *Controller) Run(stopCh <-chan struct{}) error {
 	go wait.NonSlidingUntil(c.appliedToGroupWatcher.watch, 5*time.Second, stopCh)
 	go wait.NonSlidingUntil(c.addressGroupWatcher.watch, 5*time.Second, stopCh)
 	go wait.NonSlidingUntil(c.networkPolicyWatcher.watch, 5*time.Second, stopCh)
+	if c.packetInCh != nil {
+		go c.handlePacketIn(stopCh)
+	}
+	if c.fqdnController != nil {
+		go c.fqdnController.run(stopCh)
+	}
+	go c.statusController.Run(stopCh)
+	if c.statsCollector != nil {
+		go c.statsCollector.Run(stopCh)
+	}
 
 	for i := 0; i < defaultWorkers; i++ {
 		go wait.Until(c.worker, time.Second, stopCh)
@@ -331,6 +379,10 @@ func (c *Controller) syncRule(key string) error {
 	startTime := time.Now()
 	defer func() {
 		klog.V(4).Infof("Finished syncing rule %q. (%v)", key, time.Since(startTime))
+		// Release the DNS responses waiting for the addresses of the rule to be realized.
+		if c.fqdnController != nil {
+			c.fqdnController.ruleSynced(key)
+		}
 	}()
 
 	rule, exists, completed := c.ruleCache.GetCompletedRule(key)
@@ -339,6 +391,7 @@ func (c *Controller) syncRule(key string) error {
 		if err := c.reconciler.Forget(key); err != nil {
 			return err
 		}
+		c.statusController.DeleteRuleRealization(key)
 		return nil
 	}
 	// If the rule is not complete, we can simply skip it as it will be marked as dirty
@@ -347,10 +400,9 @@ func (c *Controller) syncRule(key string) error {
 		klog.V(2).Infof("Rule %v was not complete, skipping", key)
 		return nil
 	}
-	if err := c.reconciler.Reconcile(rule); err != nil {
-		return err
-	}
-	return nil
+	err := c.reconciler.Reconcile(rule)
+	c.statusController.SetRuleRealization(key, rule.PolicyUID, err)
+	return err
 }
 
 func (c *Controller) handleErr(err error, key interface{}) {
@@ -363,13 +415,23 @@ func (c *Controller) handleErr(err error, key interface{}) {
 	c.queue.AddRateLimited(key)
 }
 
+// withResourceVersion returns a copy of the provided ListOptions which resumes
+// the watch from the provided resourceVersion, if not empty, and asks for
+// Bookmark events to keep track of it.
+func withResourceVersion(options metav1.ListOptions, resourceVersion string) metav1.ListOptions {
+	options.ResourceVersion = resourceVersion
+	options.AllowWatchBookmarks = true
+	return options
+}
+
 // watcher is responsible for watching a given resource with the provided watchFunc
 // and calling the eventHandlers when receiving events.
 type watcher struct {
 	// objectType is the type of objects being watched, used for logging.
 	objectType string
-	// watchFunc is the function that starts the watch.
-	watchFunc func() (watch.Interface, error)
+	// watchFunc is the function that starts the watch. If resourceVersion is
+	// not empty, the watch resumes from it.
+	watchFunc func(resourceVersion string) (watch.Interface, error)
 	// AddFunc is the function that handles added event.
 	AddFunc func(obj runtime.Object) error
 	// UpdateFunc is the function that handles modified event.
@@ -378,6 +440,10 @@ type watcher struct {
 	DeleteFunc func(obj runtime.Object) error
 	// ReplaceFunc is the function that handles init events.
 	ReplaceFunc func(objs []runtime.Object) error
+	// resourceVersion is the resourceVersion up to which the events have been
+	// handled successfully. It's only accessed by the goroutine running watch.
+	// An empty value means the next watch must start with a full resync.
+	resourceVersion string
 	// connected represents whether the watch has connected to apiserver successfully.
 	connected bool
 	// lock protects connected.
@@ -396,15 +462,34 @@ func (w *watcher) setConnected(connected bool) {
 	w.connected = connected
 }
 
+// startWatch starts a watch which resumes from the last handled resourceVersion if
+// any. It falls back to a watch with a full resync if the resourceVersion is expired,
+// e.g. because antrea-controller was restarted. It returns whether the watch resumes.
+func (w *watcher) startWatch() (watch.Interface, bool, error) {
+	if w.resourceVersion != "" {
+		watcher, err := w.watchFunc(w.resourceVersion)
+		if err == nil {
+			return watcher, true, nil
+		}
+		if !errors.IsResourceExpired(err) && !errors.IsGone(err) {
+			return nil, false, err
+		}
+		klog.Infof("Cannot resume watch for %s from resourceVersion %s, starting a full resync: %v", w.objectType, w.resourceVersion, err)
+		w.resourceVersion = ""
+	}
+	watcher, err := w.watchFunc("")
+	return watcher, false, err
+}
+
 func (w *watcher) watch() {
 	klog.Infof("Starting watch for %s", w.objectType)
-	watcher, err := w.watchFunc()
+	watcher, resumed, err := w.startWatch()
 	if err != nil {
 		klog.Warningf("Failed to start watch for %s: %v", w.objectType, err)
 		return
 	}
 
-	klog.Infof("Started watch for %s", w.objectType)
+	klog.Infof("Started watch for %s (resumed: %t)", w.objectType, resumed)
 	w.setConnected(true)
 	eventCount := 0
 	defer func() {
@@ -415,8 +500,10 @@ func (w *watcher) watch() {
 
 	// First receive init events from the result channel and buffer them until
 	// a Bookmark event is received, indicating that all init events have been
-	// received.
-	var initObjects []runtime.Object
+	// received. If the watch resumes, the events are the ones missed since the
+	// last handled resourceVersion instead.
+	var initEvents []watch.Event
+	var bookmarkRV string
 loop:
 	for {
 		select {
@@ -426,21 +513,41 @@ loop:
 				return
 			}
 			switch event.Type {
-			case watch.Added:
-				klog.V(2).Infof("Added %s (%#v)", w.objectType, event.Object)
-				initObjects = append(initObjects, event.Object)
+			case watch.Added, watch.Modified, watch.Deleted:
+				klog.V(2).Infof("Received %s event for %s (%#v)", event.Type, w.objectType, event.Object)
+				initEvents = append(initEvents, event)
 			case watch.Bookmark:
+				bookmarkRV = getResourceVersion(event.Object)
 				break loop
 			}
 		}
 	}
-	klog.Infof("Received %d init events for %s", len(initObjects), w.objectType)
 
-	eventCount += len(initObjects)
-	if err := w.ReplaceFunc(initObjects); err != nil {
-		klog.Errorf("Failed to handle init events: %v", err)
-		return
+	eventCount += len(initEvents)
+	// The server doesn't set the resourceVersion of the Bookmark event if it
+	// doesn't support resuming a watch, in which case it sent init events.
+	if resumed && bookmarkRV != "" {
+		klog.Infof("Received %d missed events for %s", len(initEvents), w.objectType)
+		for _, event := range initEvents {
+			if err := w.handleEvent(event); err != nil {
+				klog.Errorf("Failed to handle missed events: %v", err)
+				w.resourceVersion = ""
+				return
+			}
+		}
+	} else {
+		klog.Infof("Received %d init events for %s", len(initEvents), w.objectType)
+		initObjects := make([]runtime.Object, 0, len(initEvents))
+		for _, event := range initEvents {
+			initObjects = append(initObjects, event.Object)
+		}
+		if err := w.ReplaceFunc(initObjects); err != nil {
+			klog.Errorf("Failed to handle init events: %v", err)
+			w.resourceVersion = ""
+			return
+		}
 	}
+	w.resourceVersion = bookmarkRV
 
 	for {
 		select {
@@ -448,30 +555,53 @@ loop:
 			if !ok {
 				return
 			}
-			switch event.Type {
-			case watch.Added:
-				if err := w.AddFunc(event.Object); err != nil {
-					klog.Errorf("Failed to handle added event: %v", err)
-					return
-				}
-				klog.V(2).Infof("Added %s (%#v)", w.objectType, event.Object)
-			case watch.Modified:
-				if err := w.UpdateFunc(event.Object); err != nil {
-					klog.Errorf("Failed to handle modified event: %v", err)
-					return
+			if event.Type == watch.Bookmark {
+				if rv := getResourceVersion(event.Object); rv != "" {
+					w.resourceVersion = rv
 				}
-				klog.V(2).Infof("Updated %s (%#v)", w.objectType, event.Object)
-			case watch.Deleted:
-				if err := w.DeleteFunc(event.Object); err != nil {
-					klog.Errorf("Failed to handle deleted event: %v", err)
-					return
-				}
-				klog.V(2).Infof("Removed %s (%#v)", w.objectType, event.Object)
-			default:
-				klog.Errorf("Unknown event: %v", event)
+				continue
+			}
+			if err := w.handleEvent(event); err != nil {
+				klog.Error(err)
+				// The event is lost, the next watch must start with a full resync.
+				w.resourceVersion = ""
 				return
 			}
 			eventCount++
 		}
 	}
 }
+
+// handleEvent calls the eventHandler corresponding to the type of the event.
+func (w *watcher) handleEvent(event watch.Event) error {
+	switch event.Type {
+	case watch.Added:
+		if err := w.AddFunc(event.Object); err != nil {
+			return fmt.Errorf("failed to handle added event: %v", err)
+		}
+		klog.V(2).Infof("Added %s (%#v)", w.objectType, event.Object)
+	case watch.Modified:
+		if err := w.UpdateFunc(event.Object); err != nil {
+			return fmt.Errorf("failed to handle modified event: %v", err)
+		}
+		klog.V(2).Infof("Updated %s (%#v)", w.objectType, event.Object)
+	case watch.Deleted:
+		if err := w.DeleteFunc(event.Object); err != nil {
+			return fmt.Errorf("failed to handle deleted event: %v", err)
+		}
+		klog.V(2).Infof("Removed %s (%#v)", w.objectType, event.Object)
+	default:
+		return fmt.Errorf("unknown event: %v", event)
+	}
+	return nil
+}
+
+// getResourceVersion returns the resourceVersion of the provided object, or an
+// empty string if it cannot be accessed.
+func getResourceVersion(obj runtime.Object) string {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return ""
+	}
+	return accessor.GetResourceVersion()
+}
@@ -20,7 +20,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/watch"
@@ -47,6 +49,8 @@ func newTestController() (*Controller, *fake.Clientset, *mockReconciler) {
 	controller := NewNetworkPolicyController(&antreaClientGetter{clientset}, nil, nil, "node1", ch)
 	reconciler := newMockReconciler()
 	controller.reconciler = reconciler
+	// There is no OpenFlow client to receive PacketIn messages from.
+	controller.packetInCh = nil
 	return controller, clientset, reconciler
 }
 
@@ -56,13 +60,17 @@ func newTestController() (*Controller, *fake.Clientset, *mockReconciler) {
 type mockReconciler struct {
 	sync.Mutex
 	lastRealized map[string]*CompletedRule
-	updated      chan string
-	deleted      chan string
+	// ruleFlowIDs is a map from Openflow rule ID to the rule, which is set by
+	// the tests directly.
+	ruleFlowIDs map[uint32]*CompletedRule
+	updated     chan string
+	deleted     chan string
 }
 
 func newMockReconciler() *mockReconciler {
 	return &mockReconciler{
 		lastRealized: map[string]*CompletedRule{},
+		ruleFlowIDs:  map[uint32]*CompletedRule{},
 		updated:      make(chan string, 10),
 		deleted:      make(chan string, 10),
 	}
@@ -84,6 +92,13 @@ func (r *mockReconciler) Forget(ruleID string) error {
 	return nil
 }
 
+func (r *mockReconciler) GetRuleByFlowID(ruleFlowID uint32) (*CompletedRule, bool) {
+	r.Lock()
+	defer r.Unlock()
+	rule, exists := r.ruleFlowIDs[ruleFlowID]
+	return rule, exists
+}
+
 func (r *mockReconciler) getLastRealized(ruleID string) (*CompletedRule, bool) {
 	r.Lock()
 	defer r.Unlock()
@@ -426,3 +441,76 @@ func TestAddNetworkPolicyWithMultipleRules(t *testing.T) {
 	assert.Equal(t, 2, controller.GetAddressGroupNum())
 	assert.Equal(t, 1, controller.GetAppliedToGroupNum())
 }
+
+func TestWatcherResume(t *testing.T) {
+	newBookmark := func(resourceVersion string) runtime.Object {
+		return &v1beta1.AddressGroup{ObjectMeta: v1.ObjectMeta{ResourceVersion: resourceVersion}}
+	}
+	group1 := newAddressGroup("addressGroup1", nil)
+	group2 := newAddressGroup("addressGroup2", nil)
+
+	var watchedRVs []string
+	var replaced, added, deleted []runtime.Object
+	var fakeWatcher *watch.FakeWatcher
+	w := &watcher{
+		objectType: "AddressGroup",
+		watchFunc: func(resourceVersion string) (watch.Interface, error) {
+			watchedRVs = append(watchedRVs, resourceVersion)
+			if resourceVersion == "expired" {
+				return nil, errors.NewResourceExpired("too old resource version")
+			}
+			return fakeWatcher, nil
+		},
+		AddFunc: func(obj runtime.Object) error {
+			added = append(added, obj)
+			return nil
+		},
+		UpdateFunc: func(obj runtime.Object) error {
+			return nil
+		},
+		DeleteFunc: func(obj runtime.Object) error {
+			deleted = append(deleted, obj)
+			return nil
+		},
+		ReplaceFunc: func(objs []runtime.Object) error {
+			replaced = objs
+			return nil
+		},
+	}
+
+	// The first watch receives init events and keeps track of the latest resourceVersion.
+	fakeWatcher = watch.NewFakeWithChanSize(10, false)
+	fakeWatcher.Add(group1)
+	fakeWatcher.Action(watch.Bookmark, newBookmark("10"))
+	fakeWatcher.Add(group2)
+	fakeWatcher.Action(watch.Bookmark, newBookmark("12"))
+	fakeWatcher.Stop()
+	w.watch()
+	assert.Equal(t, []string{""}, watchedRVs)
+	assert.Equal(t, []runtime.Object{group1}, replaced)
+	assert.Equal(t, []runtime.Object{group2}, added)
+	assert.Equal(t, "12", w.resourceVersion)
+
+	// The second watch resumes from it and receives the missed events only.
+	replaced, added = nil, nil
+	fakeWatcher = watch.NewFakeWithChanSize(10, false)
+	fakeWatcher.Delete(group1)
+	fakeWatcher.Action(watch.Bookmark, newBookmark("13"))
+	fakeWatcher.Stop()
+	w.watch()
+	assert.Equal(t, []string{"", "12"}, watchedRVs)
+	assert.Nil(t, replaced)
+	assert.Equal(t, []runtime.Object{group1}, deleted)
+	assert.Equal(t, "13", w.resourceVersion)
+
+	// The third watch falls back to a full resync as the resourceVersion has expired.
+	w.resourceVersion = "expired"
+	fakeWatcher = watch.NewFakeWithChanSize(10, false)
+	fakeWatcher.Add(group2)
+	fakeWatcher.Action(watch.Bookmark, newBookmark("20"))
+	fakeWatcher.Stop()
+	w.watch()
+	assert.Equal(t, []string{"", "12", "expired", ""}, watchedRVs)
+	assert.Equal(t, []runtime.Object{group2}, replaced)
+	assert.Equal(t, "20", w.resourceVersion)
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"github.com/contiv/libOpenflow/openflow13"
+	"github.com/contiv/ofnet/ofctrl"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+)
+
+const packetInChanSize = 100
+
+// handlePacketIn subscribes to the NetworkPolicy PacketIn messages and processes them until
+// stopCh is closed.
+func (c *Controller) handlePacketIn(stopCh <-chan struct{}) {
+	if err := c.ofClient.SubscribePacketIn(uint8(openflow.PacketInReasonNP), c.packetInCh); err != nil {
+		klog.Errorf("Failed to subscribe to NetworkPolicy PacketIn messages: %v", err)
+		return
+	}
+	for {
+		select {
+		case pktIn := <-c.packetInCh:
+			c.processPacketIn(pktIn)
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// processPacketIn logs and rejects the connection of the provided PacketIn message according to
+// the custom reasons set by the flow which sent the packet to the controller. The intercepted DNS
+// responses are passed to the fqdnController.
+func (c *Controller) processPacketIn(pktIn *ofctrl.PacketIn) {
+	customReasons := getCustomReasons(&pktIn.Match)
+	if customReasons&openflow.CustomReasonDNS != 0 {
+		if c.fqdnController != nil {
+			c.fqdnController.handleDNSResponse(pktIn)
+		}
+		return
+	}
+	if customReasons&openflow.CustomReasonLogging != 0 {
+		if err := c.auditLogger.processPacketIn(pktIn); err != nil {
+			klog.Errorf("Failed to log NetworkPolicy PacketIn message: %v", err)
+		}
+	}
+	if customReasons&openflow.CustomReasonReject != 0 {
+		if err := c.rejectRequest(pktIn); err != nil {
+			klog.Errorf("Failed to reject NetworkPolicy PacketIn message: %v", err)
+		}
+	}
+}
+
+// getCustomReasons returns the custom reasons stored in the marks register in the match of a
+// PacketIn message.
+func getCustomReasons(match *openflow13.Match) uint32 {
+	rng := openflow.CustomReasonMarkRange
+	marks := openflow.GetMatchRegField(match, openflow.MarksReg)
+	return (marks >> rng[0]) & (1<<(rng[1]-rng[0]+1) - 1)
+}
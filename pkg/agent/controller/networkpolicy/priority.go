@@ -0,0 +1,165 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+	"github.com/vmware-tanzu/antrea/pkg/agent/types"
+)
+
+const (
+	// maxPriorityStep is the maximum gap left between the Openflow priority
+	// of a newly added types.Priority and its only neighbor, so that there is
+	// still room for the priorities inserted later on the same side.
+	maxPriorityStep = uint16(128)
+)
+
+// priorityAssigner assigns Openflow priorities to ClusterNetworkPolicy rules
+// based on their types.Priority. A types.Priority with a higher precedence
+// always gets a higher Openflow priority. The Openflow priorities are within
+// the range (openflow.PriorityBottomCNP, openflow.PriorityTopCNP). It's
+// thread-safe.
+type priorityAssigner struct {
+	sync.Mutex
+	// priorityMap maps a types.Priority to its assigned Openflow priority.
+	priorityMap map[types.Priority]uint16
+	// refCounts maps a types.Priority to the number of rules using it.
+	refCounts map[types.Priority]int
+	// sortedPriorities keeps the types.Priority in priorityMap sorted in the
+	// ascending order of their Openflow priorities.
+	sortedPriorities []types.Priority
+	// bottom and top are the exclusive bounds of the Openflow priorities.
+	bottom uint16
+	top    uint16
+}
+
+// newPriorityAssigner returns a new *priorityAssigner.
+func newPriorityAssigner() *priorityAssigner {
+	return &priorityAssigner{
+		priorityMap: map[types.Priority]uint16{},
+		refCounts:   map[types.Priority]int{},
+		bottom:      openflow.PriorityBottomCNP,
+		top:         openflow.PriorityTopCNP,
+	}
+}
+
+// assignPriority returns the Openflow priority of the provided types.Priority,
+// allocating a new one if it's not used by any rule yet. If there's no room to
+// insert the new Openflow priority, the existing Openflow priorities are
+// re-balanced, and the ones that have been changed are returned so that the
+// rules using them can be reinstalled.
+func (pa *priorityAssigner) assignPriority(p types.Priority) (uint16, map[types.Priority]uint16, error) {
+	pa.Lock()
+	defer pa.Unlock()
+
+	if ofPriority, exists := pa.priorityMap[p]; exists {
+		pa.refCounts[p]++
+		return ofPriority, nil, nil
+	}
+	if len(pa.sortedPriorities) >= int(pa.top-pa.bottom-1) {
+		return 0, nil, fmt.Errorf("no Openflow priority available for %v", p)
+	}
+	// Find the index where the new priority should be inserted.
+	idx := sort.Search(len(pa.sortedPriorities), func(i int) bool {
+		return p.Less(pa.sortedPriorities[i])
+	})
+	lower, upper := pa.bottom, pa.top
+	if idx > 0 {
+		lower = pa.priorityMap[pa.sortedPriorities[idx-1]]
+	}
+	if idx < len(pa.sortedPriorities) {
+		upper = pa.priorityMap[pa.sortedPriorities[idx]]
+	}
+	pa.sortedPriorities = append(pa.sortedPriorities, types.Priority{})
+	copy(pa.sortedPriorities[idx+1:], pa.sortedPriorities[idx:])
+	pa.sortedPriorities[idx] = p
+	pa.refCounts[p] = 1
+
+	if upper-lower <= 1 {
+		// There's no room between the neighbors, re-balance all priorities.
+		updated := pa.rebalance()
+		ofPriority := updated[p]
+		delete(updated, p)
+		return ofPriority, updated, nil
+	}
+	var ofPriority uint16
+	gap := upper - lower
+	switch {
+	case idx > 0 && idx < len(pa.sortedPriorities)-1:
+		// Inserted between two existing priorities.
+		ofPriority = lower + gap/2
+	case idx > 0:
+		// Inserted after the highest existing priority.
+		ofPriority = lower + minStep(gap)
+	case idx < len(pa.sortedPriorities)-1:
+		// Inserted before the lowest existing priority.
+		ofPriority = upper - minStep(gap)
+	default:
+		// The first priority is placed in the middle of the range.
+		ofPriority = lower + gap/2
+	}
+	pa.priorityMap[p] = ofPriority
+	return ofPriority, nil, nil
+}
+
+// minStep returns the step to take from the only neighbor of a new priority.
+func minStep(gap uint16) uint16 {
+	if gap/2 < maxPriorityStep {
+		return gap / 2
+	}
+	return maxPriorityStep
+}
+
+// rebalance spreads the Openflow priorities of all types.Priority evenly
+// within the range, and returns the ones that have been changed.
+func (pa *priorityAssigner) rebalance() map[types.Priority]uint16 {
+	updated := map[types.Priority]uint16{}
+	step := (pa.top - pa.bottom) / uint16(len(pa.sortedPriorities)+1)
+	for i, p := range pa.sortedPriorities {
+		ofPriority := pa.bottom + step*uint16(i+1)
+		if oldOFPriority, exists := pa.priorityMap[p]; !exists || oldOFPriority != ofPriority {
+			pa.priorityMap[p] = ofPriority
+			updated[p] = ofPriority
+		}
+	}
+	return updated
+}
+
+// releasePriority releases the Openflow priority of the provided
+// types.Priority if it's no longer used by any rule.
+func (pa *priorityAssigner) releasePriority(p types.Priority) {
+	pa.Lock()
+	defer pa.Unlock()
+
+	if _, exists := pa.refCounts[p]; !exists {
+		return
+	}
+	pa.refCounts[p]--
+	if pa.refCounts[p] > 0 {
+		return
+	}
+	delete(pa.refCounts, p)
+	delete(pa.priorityMap, p)
+	for i := range pa.sortedPriorities {
+		if pa.sortedPriorities[i] == p {
+			pa.sortedPriorities = append(pa.sortedPriorities[:i], pa.sortedPriorities[i+1:]...)
+			break
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/types"
+)
+
+var (
+	p110 = types.Priority{TierPriority: 100, PolicyPriority: 1, RulePriority: 0}
+	p111 = types.Priority{TierPriority: 100, PolicyPriority: 1, RulePriority: 1}
+	p120 = types.Priority{TierPriority: 100, PolicyPriority: 2, RulePriority: 0}
+	p210 = types.Priority{TierPriority: 200, PolicyPriority: 1, RulePriority: 0}
+)
+
+func TestAssignPriorityOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		priorities []types.Priority
+	}{
+		{
+			"ascending-precedence",
+			[]types.Priority{p210, p120, p111, p110},
+		},
+		{
+			"descending-precedence",
+			[]types.Priority{p110, p111, p120, p210},
+		},
+		{
+			"mixed-precedence",
+			[]types.Priority{p120, p210, p110, p111},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pa := newPriorityAssigner()
+			for _, p := range tt.priorities {
+				_, updates, err := pa.assignPriority(p)
+				require.NoError(t, err)
+				assert.Empty(t, updates)
+			}
+			// A higher precedence must always get a higher Openflow priority.
+			assert.Greater(t, pa.priorityMap[p110], pa.priorityMap[p111])
+			assert.Greater(t, pa.priorityMap[p111], pa.priorityMap[p120])
+			assert.Greater(t, pa.priorityMap[p120], pa.priorityMap[p210])
+			assert.Equal(t, []types.Priority{p210, p120, p111, p110}, pa.sortedPriorities)
+		})
+	}
+}
+
+func TestAssignAndReleasePriority(t *testing.T) {
+	pa := newPriorityAssigner()
+	ofPriority1, _, err := pa.assignPriority(p110)
+	require.NoError(t, err)
+	ofPriority2, _, err := pa.assignPriority(p110)
+	require.NoError(t, err)
+	assert.Equal(t, ofPriority1, ofPriority2, "The same Priority should get the same Openflow priority")
+	assert.Equal(t, 2, pa.refCounts[p110])
+
+	pa.releasePriority(p110)
+	assert.Contains(t, pa.priorityMap, p110)
+	pa.releasePriority(p110)
+	assert.NotContains(t, pa.priorityMap, p110)
+	assert.NotContains(t, pa.refCounts, p110)
+	assert.Empty(t, pa.sortedPriorities)
+}
+
+func TestAssignPriorityRebalance(t *testing.T) {
+	pa := newPriorityAssigner()
+	pa.bottom, pa.top = 100, 110
+	for _, p := range []types.Priority{p110, p210, p111} {
+		_, updates, err := pa.assignPriority(p)
+		require.NoError(t, err)
+		assert.Empty(t, updates)
+	}
+	assert.Equal(t, map[types.Priority]uint16{p210: 103, p111: 104, p110: 105}, pa.priorityMap)
+
+	// There's no room left between p210 and p111.
+	ofPriority, updates, err := pa.assignPriority(p120)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(104), ofPriority)
+	assert.Equal(t, map[types.Priority]uint16{p210: 102, p111: 106, p110: 108}, updates)
+
+	pa.top = 105
+	_, _, err = pa.assignPriority(types.Priority{TierPriority: 300})
+	assert.Error(t, err)
+}
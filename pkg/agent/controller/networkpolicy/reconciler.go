@@ -30,6 +30,7 @@ import (
 	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
 	"github.com/vmware-tanzu/antrea/pkg/agent/types"
 	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+	"github.com/vmware-tanzu/antrea/pkg/features"
 	"github.com/vmware-tanzu/antrea/pkg/util/ip"
 )
 
@@ -51,6 +52,10 @@ type Reconciler interface {
 
 	// Forget cleanups the actual state of Openflow entries of the specified ruleID.
 	Forget(ruleID string) error
+
+	// GetRuleByFlowID returns the rule which the Openflow rule with the
+	// provided ID was installed for.
+	GetRuleByFlowID(ruleFlowID uint32) (*CompletedRule, bool)
 }
 
 // servicesHash is used to uniquely identify Services.
@@ -126,6 +131,16 @@ type lastRealized struct {
 	// It's same in all Openflow rules, because named port is only for
 	// destination Pods.
 	podIPs sets.String
+	// The IP set we have realized for the FQDNs of an egress rule. They are
+	// the "to" addresses of the Openflow rule of the original services.
+	fqdnIPs sets.String
+	// The Openflow priority assigned to the rule. It's only set for
+	// ClusterNetworkPolicy rules.
+	ofPriority *uint16
+	// reinstallNeeded indicates the Openflow rules failed to be reinstalled
+	// with the Openflow priority assigned to the rule, and must be reinstalled
+	// when the rule is reconciled again.
+	reinstallNeeded bool
 }
 
 func newLastRealized(rule *CompletedRule) *lastRealized {
@@ -148,21 +163,41 @@ type reconciler struct {
 	// ifaceStore provides container interface OFPort and IP information.
 	ifaceStore interfacestore.InterfaceStore
 
+	// fqdnController provides the IPs which the FQDNs of egress rules resolve
+	// to. It's nil if ClusterNetworkPolicy is disabled.
+	fqdnController *fqdnController
+
 	// lastRealizeds caches the last realized rules.
 	// It's a mapping from ruleID to *lastRealized.
 	lastRealizeds sync.Map
 
 	// idAllocator provides interfaces to allocate and release uint32 id.
 	idAllocator *idAllocator
+	// ofIDToRules caches the rules which the installed Openflow rules belong
+	// to. It's a mapping from Openflow rule ID to *CompletedRule.
+	ofIDToRules sync.Map
+
+	// cnpMutex serializes the processing of ClusterNetworkPolicy rules, as
+	// adding a rule may require reinstalling other rules whose Openflow
+	// priorities are re-balanced.
+	cnpMutex sync.Mutex
+	// priorityAssigner assigns Openflow priorities to ClusterNetworkPolicy rules.
+	priorityAssigner *priorityAssigner
+	// enqueueRule requests the rule with the provided ID to be reconciled
+	// again, e.g. when it failed to be reinstalled with a new Openflow priority.
+	enqueueRule func(ruleID string)
 }
 
 // newReconciler returns a new *reconciler.
-func newReconciler(ofClient openflow.Client, ifaceStore interfacestore.InterfaceStore) *reconciler {
+func newReconciler(ofClient openflow.Client, ifaceStore interfacestore.InterfaceStore, fqdnController *fqdnController, enqueueRule func(ruleID string)) *reconciler {
 	reconciler := &reconciler{
-		ofClient:      ofClient,
-		ifaceStore:    ifaceStore,
-		lastRealizeds: sync.Map{},
-		idAllocator:   newIDAllocator(),
+		ofClient:         ofClient,
+		ifaceStore:       ifaceStore,
+		fqdnController:   fqdnController,
+		enqueueRule:      enqueueRule,
+		lastRealizeds:    sync.Map{},
+		idAllocator:      newIDAllocator(),
+		priorityAssigner: newPriorityAssigner(),
 	}
 	return reconciler
 }
@@ -172,20 +207,122 @@ func newReconciler(ofClient openflow.Client, ifaceStore interfacestore.Interface
 func (r *reconciler) Reconcile(rule *CompletedRule) error {
 	klog.Infof("Reconciling rule %s of NetworkPolicy %s/%s", rule.ID, rule.PolicyNamespace, rule.PolicyName)
 
+	if rule.isAntreaNetworkPolicyRule() {
+		if !features.DefaultFeatureGate.Enabled(features.ClusterNetworkPolicy) {
+			klog.Warningf("Ignoring rule %s of ClusterNetworkPolicy %s as feature %s is disabled", rule.ID, rule.PolicyName, features.ClusterNetworkPolicy)
+			return nil
+		}
+		r.cnpMutex.Lock()
+		defer r.cnpMutex.Unlock()
+	}
+
 	value, exists := r.lastRealizeds.Load(rule.ID)
 	if !exists {
 		return r.add(rule)
 	}
-	return r.update(value.(*lastRealized), rule)
+	lastRealized := value.(*lastRealized)
+	if lastRealized.reinstallNeeded {
+		lastRealized.CompletedRule = rule
+		return r.reinstall(lastRealized)
+	}
+	return r.update(lastRealized, rule)
 }
 
 // add converts CompletedRule to PolicyRule(s) and invokes installOFRule to install them.
 func (r *reconciler) add(rule *CompletedRule) error {
 	klog.V(2).Infof("Adding new rule %v", rule)
 	lastRealized := newLastRealized(rule)
+	if rule.isAntreaNetworkPolicyRule() {
+		ofPriority, err := r.assignOFPriority(rule)
+		if err != nil {
+			return err
+		}
+		lastRealized.ofPriority = &ofPriority
+	}
 	// TODO: Handle the case that the following processing fails or partially succeeds.
 	r.lastRealizeds.Store(rule.ID, lastRealized)
+	return r.realize(lastRealized)
+}
 
+// assignOFPriority assigns an Openflow priority to the provided
+// ClusterNetworkPolicy rule. If the Openflow priorities of other rules are
+// changed because of this assignment, those rules are reinstalled with their
+// new Openflow priorities. If any of them fails to be reinstalled, the assigned
+// Openflow priority is released and the failed rules are enqueued to be
+// reinstalled again, as the re-balanced priorities remain valid without it.
+func (r *reconciler) assignOFPriority(rule *CompletedRule) (uint16, error) {
+	ofPriority, updates, err := r.priorityAssigner.assignPriority(*getRulePriority(rule))
+	if err != nil {
+		return 0, err
+	}
+	if len(updates) == 0 {
+		return ofPriority, nil
+	}
+	var reinstallErr error
+	r.lastRealizeds.Range(func(key, value interface{}) bool {
+		lastRealized := value.(*lastRealized)
+		if lastRealized.ofPriority == nil {
+			return true
+		}
+		newOFPriority, updated := updates[*getRulePriority(lastRealized.CompletedRule)]
+		if !updated {
+			return true
+		}
+		klog.V(2).Infof("Reinstalling rule %s with new Openflow priority %d", lastRealized.ID, newOFPriority)
+		lastRealized.ofPriority = &newOFPriority
+		if err := r.reinstall(lastRealized); err != nil {
+			klog.Errorf("Error reinstalling rule %s with new Openflow priority %d: %v", lastRealized.ID, newOFPriority, err)
+			reinstallErr = err
+			if r.enqueueRule != nil {
+				r.enqueueRule(lastRealized.ID)
+			}
+		}
+		return true
+	})
+	if reinstallErr != nil {
+		r.priorityAssigner.releasePriority(*getRulePriority(rule))
+		return 0, reinstallErr
+	}
+	return ofPriority, nil
+}
+
+// reinstall uninstalls the Openflow rules of the provided lastRealized and
+// installs them again, using the Openflow priority assigned to it. On failure,
+// lastRealized is marked to be reinstalled the next time it's reconciled.
+func (r *reconciler) reinstall(lastRealized *lastRealized) error {
+	lastRealized.reinstallNeeded = true
+	for svcHash, ofID := range lastRealized.ofIDs {
+		if err := r.uninstallOFRule(ofID); err != nil {
+			return err
+		}
+		delete(lastRealized.ofIDs, svcHash)
+	}
+	lastRealized.podOFPorts = map[servicesHash]sets.Int32{}
+	lastRealized.podIPs = nil
+	if err := r.realize(lastRealized); err != nil {
+		return err
+	}
+	lastRealized.reinstallNeeded = false
+	return nil
+}
+
+// getRulePriority returns the types.Priority of the provided
+// ClusterNetworkPolicy rule.
+func getRulePriority(rule *CompletedRule) *types.Priority {
+	p := &types.Priority{
+		PolicyPriority: *rule.PolicyPriority,
+		RulePriority:   rule.Priority,
+	}
+	if rule.TierPriority != nil {
+		p.TierPriority = uint32(*rule.TierPriority)
+	}
+	return p
+}
+
+// realize installs the Openflow rules of the provided lastRealized, using the
+// Openflow priority assigned to it if any.
+func (r *reconciler) realize(lastRealized *lastRealized) error {
+	rule := lastRealized.CompletedRule
 	ofRuleByServicesMap := map[servicesHash]*types.PolicyRule{}
 	if rule.Direction == v1beta1.DirectionIn {
 		// Addresses got from source Pod IPs.
@@ -241,12 +378,19 @@ func (r *reconciler) add(rule *CompletedRule) error {
 			to := ipBlocksToOFAddresses(rule.To.IPBlocks)
 			ofRule.To = append(ofRule.To, to...)
 		}
+		fqdnIPs, err := r.getFQDNIPs(rule)
+		if err != nil {
+			return err
+		}
+		lastRealized.fqdnIPs = fqdnIPs
+		ofRule.To = append(ofRule.To, ipsToOFAddresses(fqdnIPs)...)
 	}
 
 	for svcHash, ofRule := range ofRuleByServicesMap {
-		npName := lastRealized.CompletedRule.PolicyName
-		npNamespace := lastRealized.CompletedRule.PolicyNamespace
-		ofID, err := r.installOFRule(ofRule, npName, npNamespace)
+		ofRule.Action = rule.Action
+		ofRule.Priority = lastRealized.ofPriority
+		ofRule.EnableLogging = rule.EnableLogging
+		ofID, err := r.installOFRule(rule, ofRule)
 		if err != nil {
 			return err
 		}
@@ -283,12 +427,15 @@ func (r *reconciler) update(lastRealized *lastRealized, newRule *CompletedRule)
 			// Install a new Openflow rule if this group doesn't exist, otherwise do incremental update.
 			if !exists {
 				ofRule := &types.PolicyRule{
-					Direction: v1beta1.DirectionIn,
-					From:      append(from1, from2...),
-					To:        ofPortsToOFAddresses(newOFPorts),
-					Service:   filterUnresolvablePort(servicesMap[svcHash]),
+					Direction:     v1beta1.DirectionIn,
+					From:          append(from1, from2...),
+					To:            ofPortsToOFAddresses(newOFPorts),
+					Service:       filterUnresolvablePort(servicesMap[svcHash]),
+					Action:        newRule.Action,
+					Priority:      lastRealized.ofPriority,
+					EnableLogging: newRule.EnableLogging,
 				}
-				ofID, err := r.installOFRule(ofRule, newRule.PolicyName, newRule.PolicyNamespace)
+				ofID, err := r.installOFRule(newRule, ofRule)
 				if err != nil {
 					return err
 				}
@@ -310,25 +457,38 @@ func (r *reconciler) update(lastRealized *lastRealized, newRule *CompletedRule)
 		addedFrom := ipsToOFAddresses(newIPs.Difference(lastRealized.podIPs))
 		deletedFrom := ipsToOFAddresses(lastRealized.podIPs.Difference(newIPs))
 
+		newFQDNIPs, err := r.getFQDNIPs(newRule)
+		if err != nil {
+			return err
+		}
+
 		podsByServicesMap, servicesMap := groupPodsByServices(newRule.Services, newRule.ToAddresses)
 		// Same as the process in `add`, we must ensure the group for the original services is present
 		// in podsByServicesMap, so that this group won't be removed and its "From" will be updated.
-		svcHash := hashServices(newRule.Services)
-		if _, exists := podsByServicesMap[svcHash]; !exists {
-			podsByServicesMap[svcHash] = v1beta1.NewGroupMemberPodSet()
-			servicesMap[svcHash] = newRule.Services
+		origSvcHash := hashServices(newRule.Services)
+		if _, exists := podsByServicesMap[origSvcHash]; !exists {
+			podsByServicesMap[origSvcHash] = v1beta1.NewGroupMemberPodSet()
+			servicesMap[origSvcHash] = newRule.Services
 		}
 		prevPodsByServicesMap, _ := groupPodsByServices(lastRealized.Services, lastRealized.ToAddresses)
 		for svcHash, pods := range podsByServicesMap {
 			ofID, exists := lastRealized.ofIDs[svcHash]
 			if !exists {
+				to := podsToOFAddresses(pods)
+				// The IPs of the FQDNs share the Openflow rule of the original services, like IPBlocks.
+				if svcHash == origSvcHash {
+					to = append(to, ipsToOFAddresses(newFQDNIPs)...)
+				}
 				ofRule := &types.PolicyRule{
-					Direction: v1beta1.DirectionOut,
-					From:      from,
-					To:        podsToOFAddresses(pods),
-					Service:   filterUnresolvablePort(servicesMap[svcHash]),
+					Direction:     v1beta1.DirectionOut,
+					From:          from,
+					To:            to,
+					Service:       filterUnresolvablePort(servicesMap[svcHash]),
+					Action:        newRule.Action,
+					Priority:      lastRealized.ofPriority,
+					EnableLogging: newRule.EnableLogging,
 				}
-				ofID, err := r.installOFRule(ofRule, newRule.PolicyName, newRule.PolicyNamespace)
+				ofID, err := r.installOFRule(newRule, ofRule)
 				if err != nil {
 					return err
 				}
@@ -336,6 +496,10 @@ func (r *reconciler) update(lastRealized *lastRealized, newRule *CompletedRule)
 			} else {
 				addedTo := podsToOFAddresses(pods.Difference(prevPodsByServicesMap[svcHash]))
 				deletedTo := podsToOFAddresses(prevPodsByServicesMap[svcHash].Difference(pods))
+				if svcHash == origSvcHash {
+					addedTo = append(addedTo, ipsToOFAddresses(newFQDNIPs.Difference(lastRealized.fqdnIPs))...)
+					deletedTo = append(deletedTo, ipsToOFAddresses(lastRealized.fqdnIPs.Difference(newFQDNIPs))...)
+				}
 				if err := r.updateOFRule(ofID, addedFrom, addedTo, deletedFrom, deletedTo); err != nil {
 					return err
 				}
@@ -344,19 +508,30 @@ func (r *reconciler) update(lastRealized *lastRealized, newRule *CompletedRule)
 			}
 		}
 		lastRealized.podIPs = newIPs
+		lastRealized.fqdnIPs = newFQDNIPs
 	}
-	// Remove stale Openflow rules.
+	// Remove stale Openflow rules, e.g. the ones of a named port resolving
+	// result that no Pod has anymore.
 	for svcHash, ofID := range staleOFIDs {
 		if err := r.uninstallOFRule(ofID); err != nil {
 			return err
 		}
 		delete(lastRealized.ofIDs, svcHash)
+		delete(lastRealized.podOFPorts, svcHash)
 	}
 	lastRealized.CompletedRule = newRule
 	return nil
 }
 
-func (r *reconciler) installOFRule(ofRule *types.PolicyRule, npName, npNamespace string) (uint32, error) {
+// getFQDNIPs returns the IPs the FQDNs of an egress rule currently resolve to.
+func (r *reconciler) getFQDNIPs(rule *CompletedRule) (sets.String, error) {
+	if len(rule.To.FQDNs) == 0 || r.fqdnController == nil {
+		return nil, nil
+	}
+	return r.fqdnController.addRule(rule.ID, rule.To.FQDNs)
+}
+
+func (r *reconciler) installOFRule(rule *CompletedRule, ofRule *types.PolicyRule) (uint32, error) {
 	// Each pod group gets an Openflow ID.
 	ofID, err := r.idAllocator.allocate()
 	if err != nil {
@@ -364,10 +539,11 @@ func (r *reconciler) installOFRule(ofRule *types.PolicyRule, npName, npNamespace
 	}
 	klog.V(2).Infof("Installing ofRule %d (Direction: %v, From: %d, To: %d, Service: %d)",
 		ofID, ofRule.Direction, len(ofRule.From), len(ofRule.To), len(ofRule.Service))
-	if err := r.ofClient.InstallPolicyRuleFlows(ofID, ofRule, npName, npNamespace); err != nil {
+	if err := r.ofClient.InstallPolicyRuleFlows(ofID, ofRule, rule.PolicyName, rule.PolicyNamespace); err != nil {
 		r.idAllocator.release(ofID)
 		return 0, fmt.Errorf("error installing ofRule %v: %v", ofID, err)
 	}
+	r.ofIDToRules.Store(ofID, rule)
 	return ofID, nil
 }
 
@@ -403,6 +579,7 @@ func (r *reconciler) uninstallOFRule(ofID uint32) error {
 	if err := r.ofClient.UninstallPolicyRuleFlows(ofID); err != nil {
 		return fmt.Errorf("error uninstalling ofRule %v: %v", ofID, err)
 	}
+	r.ofIDToRules.Delete(ofID)
 	if err := r.idAllocator.release(ofID); err != nil {
 		// This should never happen. If it does, it is a programming error.
 		klog.Errorf("Error releasing Openflow ID for ofRule %v: %v", ofID, err)
@@ -415,6 +592,12 @@ func (r *reconciler) uninstallOFRule(ofID uint32) error {
 func (r *reconciler) Forget(ruleID string) error {
 	klog.Infof("Forgetting rule %v", ruleID)
 
+	if r.fqdnController != nil {
+		if err := r.fqdnController.deleteRule(ruleID); err != nil {
+			return err
+		}
+	}
+
 	value, exists := r.lastRealizeds.Load(ruleID)
 
 	if !exists {
@@ -423,17 +606,35 @@ func (r *reconciler) Forget(ruleID string) error {
 	}
 
 	lastRealized := value.(*lastRealized)
+	if lastRealized.ofPriority != nil {
+		r.cnpMutex.Lock()
+		defer r.cnpMutex.Unlock()
+	}
 	for svcHash, ofID := range lastRealized.ofIDs {
 		if err := r.uninstallOFRule(ofID); err != nil {
 			return err
 		}
 		delete(lastRealized.ofIDs, svcHash)
 	}
+	if lastRealized.ofPriority != nil {
+		r.priorityAssigner.releasePriority(*getRulePriority(lastRealized.CompletedRule))
+	}
 
 	r.lastRealizeds.Delete(ruleID)
 	return nil
 }
 
+// GetRuleByFlowID returns the rule which the Openflow rule with the provided ID
+// was installed for. Only the fields identifying the rule and its policy are
+// guaranteed to be up to date.
+func (r *reconciler) GetRuleByFlowID(ruleFlowID uint32) (*CompletedRule, bool) {
+	value, exists := r.ofIDToRules.Load(ruleFlowID)
+	if !exists {
+		return nil, false
+	}
+	return value.(*CompletedRule), true
+}
+
 func (r *reconciler) getPodOFPorts(pods v1beta1.GroupMemberPodSet) sets.Int32 {
 	ofPorts := sets.NewInt32()
 	for _, pod := range pods {
@@ -458,8 +659,10 @@ func (r *reconciler) getPodIPs(pods v1beta1.GroupMemberPodSet) sets.String {
 			klog.Infof("Can't find interface for Pod %s/%s, skipping", pod.Pod.Namespace, pod.Pod.Name)
 			continue
 		}
-		klog.V(2).Infof("Got IP %v for Pod %s/%s", iface.IP, pod.Pod.Namespace, pod.Pod.Name)
-		ips.Insert(iface.IP.String())
+		klog.V(2).Infof("Got IPs %v for Pod %s/%s", iface.IPs, pod.Pod.Namespace, pod.Pod.Name)
+		for _, podIP := range iface.IPs {
+			ips.Insert(podIP.String())
+		}
 	}
 	return ips
 }
@@ -498,7 +701,14 @@ func podsToOFAddresses(podSet v1beta1.GroupMemberPodSet) []types.Address {
 	// Must not return nil as it means not restricted by addresses in Openflow implementation.
 	addresses := make([]types.Address, 0, len(podSet))
 	for _, p := range podSet {
-		addresses = append(addresses, openflow.NewIPAddress(net.IP(p.IP)))
+		// IPs is not set by the controllers which don't support multiple Pod IPs.
+		if len(p.IPs) == 0 {
+			addresses = append(addresses, openflow.NewIPAddress(net.IP(p.IP)))
+			continue
+		}
+		for _, podIP := range p.IPs {
+			addresses = append(addresses, openflow.NewIPAddress(net.IP(podIP)))
+		}
 	}
 	return addresses
 }
@@ -513,7 +723,6 @@ func ipBlocksToOFAddresses(ipBlocks []v1beta1.IPBlock) []types.Address {
 		}
 		diffCIDRs, err := ip.DiffFromCIDRs(ip.IPNetToNetIPNet(&b.CIDR), exceptIPNet)
 		if err != nil {
-			// Currently only IPv4 addresses are supported
 			klog.Errorf("Error when determining diffCIDRs: %v", err)
 			continue
 		}
@@ -526,11 +735,7 @@ func ipBlocksToOFAddresses(ipBlocks []v1beta1.IPBlock) []types.Address {
 }
 
 func ipNetToOFAddress(in v1beta1.IPNet) *openflow.IPNetAddress {
-	ipNet := net.IPNet{
-		IP:   net.IP(in.IP),
-		Mask: net.CIDRMask(int(in.PrefixLength), 32),
-	}
-	return openflow.NewIPNetAddress(ipNet)
+	return openflow.NewIPNetAddress(*ip.IPNetToNetIPNet(&in))
 }
 
 func ipsToOFAddresses(ips sets.String) []types.Address {
@@ -15,12 +15,16 @@
 package networkpolicy
 
 import (
+	"fmt"
 	"net"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
 
 	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
 	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
@@ -28,6 +32,8 @@ import (
 	"github.com/vmware-tanzu/antrea/pkg/agent/types"
 	"github.com/vmware-tanzu/antrea/pkg/agent/util"
 	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/features"
 )
 
 var (
@@ -109,7 +115,7 @@ func TestReconcilerForget(t *testing.T) {
 					mockOFClient.EXPECT().UninstallPolicyRuleFlows(ofID)
 				}
 			}
-			r := newReconciler(mockOFClient, ifaceStore)
+			r := newReconciler(mockOFClient, ifaceStore, nil, nil)
 			for key, value := range tt.lastRealizeds {
 				r.lastRealizeds.Store(key, value)
 			}
@@ -124,13 +130,13 @@ func TestReconcilerReconcile(t *testing.T) {
 	ifaceStore := interfacestore.NewInterfaceStore()
 	ifaceStore.AddInterface(&interfacestore.InterfaceConfig{
 		InterfaceName:            util.GenerateContainerInterfaceName("pod1", "ns1"),
-		IP:                       net.ParseIP("2.2.2.2"),
+		IPs:                      []net.IP{net.ParseIP("2.2.2.2")},
 		ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod1", PodNamespace: "ns1"},
 		OVSPortConfig:            &interfacestore.OVSPortConfig{OFPort: 1},
 	})
 	ifaceStore.AddInterface(&interfacestore.InterfaceConfig{
 		InterfaceName:            util.GenerateContainerInterfaceName("pod3", "ns1"),
-		IP:                       net.ParseIP("3.3.3.3"),
+		IPs:                      []net.IP{net.ParseIP("3.3.3.3")},
 		ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod3", PodNamespace: "ns1"},
 		OVSPortConfig:            &interfacestore.OVSPortConfig{OFPort: 3},
 	})
@@ -431,7 +437,7 @@ func TestReconcilerReconcile(t *testing.T) {
 			for _, ofRule := range tt.expectedOFRules {
 				mockOFClient.EXPECT().InstallPolicyRuleFlows(gomock.Any(), gomock.Eq(ofRule), "", "")
 			}
-			r := newReconciler(mockOFClient, ifaceStore)
+			r := newReconciler(mockOFClient, ifaceStore, nil, nil)
 			if err := r.Reconcile(tt.args); (err != nil) != tt.wantErr {
 				t.Fatalf("Reconcile() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -439,18 +445,142 @@ func TestReconcilerReconcile(t *testing.T) {
 	}
 }
 
+func TestReconcilerReconcileClusterNetworkPolicy(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.ClusterNetworkPolicy, true)()
+
+	ifaceStore := interfacestore.NewInterfaceStore()
+	ifaceStore.AddInterface(&interfacestore.InterfaceConfig{
+		InterfaceName:            util.GenerateContainerInterfaceName("pod1", "ns1"),
+		IPs:                      []net.IP{net.ParseIP("2.2.2.2")},
+		ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod1", PodNamespace: "ns1"},
+		OVSPortConfig:            &interfacestore.OVSPortConfig{OFPort: 1},
+	})
+	actionDrop := secv1alpha1.RuleActionDrop
+	policyPriority1 := float64(1)
+	policyPriority2 := float64(2)
+	tierPriority := v1beta1.TierPriority(100)
+	rule1 := &CompletedRule{
+		rule:          &rule{ID: "cnp-rule-1", Direction: v1beta1.DirectionIn, Action: &actionDrop, PolicyPriority: &policyPriority1, TierPriority: &tierPriority},
+		FromAddresses: addressGroup1,
+		Pods:          appliedToGroup1,
+	}
+	rule2 := &CompletedRule{
+		rule:          &rule{ID: "cnp-rule-2", Direction: v1beta1.DirectionIn, Action: &actionDrop, PolicyPriority: &policyPriority2, TierPriority: &tierPriority},
+		FromAddresses: addressGroup1,
+		Pods:          appliedToGroup1,
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	mockOFClient := openflowtest.NewMockClient(controller)
+	ofPriorities := map[uint32]uint16{}
+	mockOFClient.EXPECT().InstallPolicyRuleFlows(gomock.Any(), gomock.Any(), "", "").DoAndReturn(
+		func(ruleID uint32, rule *types.PolicyRule, npName, npNamespace string) error {
+			if assert.NotNil(t, rule.Priority) {
+				ofPriorities[ruleID] = *rule.Priority
+			}
+			assert.Equal(t, &actionDrop, rule.Action)
+			return nil
+		}).Times(2)
+	r := newReconciler(mockOFClient, ifaceStore, nil, nil)
+	require.NoError(t, r.Reconcile(rule2))
+	require.NoError(t, r.Reconcile(rule1))
+	require.Len(t, ofPriorities, 2)
+	ofPriority1 := ofPriorities[getLastRealizedOFID(t, r, rule1.ID)]
+	ofPriority2 := ofPriorities[getLastRealizedOFID(t, r, rule2.ID)]
+	assert.Greater(t, ofPriority1, ofPriority2, "Rule of the policy with higher precedence should get higher Openflow priority")
+
+	mockOFClient.EXPECT().UninstallPolicyRuleFlows(gomock.Any()).Times(2)
+	require.NoError(t, r.Forget(rule1.ID))
+	require.NoError(t, r.Forget(rule2.ID))
+	assert.Empty(t, r.priorityAssigner.priorityMap)
+}
+
+func TestReconcilerReinstallOFPriorityFailure(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.ClusterNetworkPolicy, true)()
+
+	ifaceStore := interfacestore.NewInterfaceStore()
+	ifaceStore.AddInterface(&interfacestore.InterfaceConfig{
+		InterfaceName:            util.GenerateContainerInterfaceName("pod1", "ns1"),
+		IPs:                      []net.IP{net.ParseIP("2.2.2.2")},
+		ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod1", PodNamespace: "ns1"},
+		OVSPortConfig:            &interfacestore.OVSPortConfig{OFPort: 1},
+	})
+	actionDrop := secv1alpha1.RuleActionDrop
+	policyPriority1 := float64(1)
+	policyPriority2 := float64(2)
+	rule1 := &CompletedRule{
+		rule:          &rule{ID: "cnp-rule-1", Direction: v1beta1.DirectionIn, Action: &actionDrop, PolicyPriority: &policyPriority1},
+		FromAddresses: addressGroup1,
+		Pods:          appliedToGroup1,
+	}
+	rule2 := &CompletedRule{
+		rule:          &rule{ID: "cnp-rule-2", Direction: v1beta1.DirectionIn, Action: &actionDrop, PolicyPriority: &policyPriority2},
+		FromAddresses: addressGroup1,
+		Pods:          appliedToGroup1,
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	mockOFClient := openflowtest.NewMockClient(controller)
+	var enqueuedRules []string
+	r := newReconciler(mockOFClient, ifaceStore, nil, func(ruleID string) {
+		enqueuedRules = append(enqueuedRules, ruleID)
+	})
+	// Leave room for only one Openflow priority between the bounds, so that
+	// adding rule2 re-balances the priority of rule1.
+	r.priorityAssigner.bottom = 10
+	r.priorityAssigner.top = 13
+
+	mockOFClient.EXPECT().InstallPolicyRuleFlows(gomock.Any(), gomock.Any(), "", "").Return(nil)
+	require.NoError(t, r.Reconcile(rule1))
+	p2 := *getRulePriority(rule2)
+
+	// Reinstalling rule1 with its new Openflow priority fails.
+	mockOFClient.EXPECT().UninstallPolicyRuleFlows(gomock.Any()).Return(nil)
+	mockOFClient.EXPECT().InstallPolicyRuleFlows(gomock.Any(), gomock.Any(), "", "").Return(fmt.Errorf("error"))
+	require.Error(t, r.Reconcile(rule2))
+	_, exists := r.lastRealizeds.Load(rule2.ID)
+	assert.False(t, exists)
+	assert.NotContains(t, r.priorityAssigner.priorityMap, p2, "Openflow priority of the failed rule should be released")
+	assert.Equal(t, []string{rule1.ID}, enqueuedRules)
+
+	// rule1 is reinstalled with its new Openflow priority when reconciled again.
+	newOFPriority := r.priorityAssigner.priorityMap[*getRulePriority(rule1)]
+	mockOFClient.EXPECT().InstallPolicyRuleFlows(gomock.Any(), gomock.Any(), "", "").DoAndReturn(
+		func(ruleID uint32, rule *types.PolicyRule, npName, npNamespace string) error {
+			assert.Equal(t, newOFPriority, *rule.Priority)
+			return nil
+		})
+	require.NoError(t, r.Reconcile(rule1))
+	value, _ := r.lastRealizeds.Load(rule1.ID)
+	assert.False(t, value.(*lastRealized).reinstallNeeded)
+	assert.Len(t, value.(*lastRealized).ofIDs, 1)
+}
+
+func getLastRealizedOFID(t *testing.T, r *reconciler, ruleID string) uint32 {
+	value, exists := r.lastRealizeds.Load(ruleID)
+	require.True(t, exists)
+	lastRealized := value.(*lastRealized)
+	require.Len(t, lastRealized.ofIDs, 1)
+	for _, ofID := range lastRealized.ofIDs {
+		return ofID
+	}
+	return 0
+}
+
 func TestReconcilerUpdate(t *testing.T) {
 	ifaceStore := interfacestore.NewInterfaceStore()
 	ifaceStore.AddInterface(
 		&interfacestore.InterfaceConfig{
 			InterfaceName:            util.GenerateContainerInterfaceName("pod1", "ns1"),
-			IP:                       net.ParseIP("2.2.2.2"),
+			IPs:                      []net.IP{net.ParseIP("2.2.2.2")},
 			ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod1", PodNamespace: "ns1"},
 			OVSPortConfig:            &interfacestore.OVSPortConfig{OFPort: 1}})
 	ifaceStore.AddInterface(
 		&interfacestore.InterfaceConfig{
 			InterfaceName:            util.GenerateContainerInterfaceName("pod2", "ns1"),
-			IP:                       net.ParseIP("3.3.3.3"),
+			IPs:                      []net.IP{net.ParseIP("3.3.3.3")},
 			ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod2", PodNamespace: "ns1"},
 			OVSPortConfig:            &interfacestore.OVSPortConfig{OFPort: 2}})
 	tests := []struct {
@@ -572,7 +702,7 @@ func TestReconcilerUpdate(t *testing.T) {
 			if len(tt.expectedDeletedTo) > 0 {
 				mockOFClient.EXPECT().DeletePolicyRuleAddress(gomock.Any(), types.DstAddress, gomock.Eq(tt.expectedDeletedTo))
 			}
-			r := newReconciler(mockOFClient, ifaceStore)
+			r := newReconciler(mockOFClient, ifaceStore, nil, nil)
 			if err := r.Reconcile(tt.originalRule); (err != nil) != tt.wantErr {
 				t.Fatalf("Reconcile() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -582,3 +712,53 @@ func TestReconcilerUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcilerUpdateNamedPort(t *testing.T) {
+	ifaceStore := interfacestore.NewInterfaceStore()
+	for i, podName := range []string{"pod1", "pod3"} {
+		ifaceStore.AddInterface(
+			&interfacestore.InterfaceConfig{
+				InterfaceName:            util.GenerateContainerInterfaceName(podName, "ns1"),
+				IPs:                      []net.IP{net.ParseIP(fmt.Sprintf("2.2.2.%d", i+1))},
+				ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: podName, PodNamespace: "ns1"},
+				OVSPortConfig:            &interfacestore.OVSPortConfig{OFPort: int32(i + 1)}})
+	}
+	originalRule := &CompletedRule{
+		rule:          &rule{ID: "ingress-rule", Direction: v1beta1.DirectionIn, Services: []v1beta1.Service{serviceHTTP}},
+		FromAddresses: addressGroup1,
+		Pods:          appliedToGroupWithSameContainerPort,
+	}
+	// pod3 is recreated with the same name but resolves "http" to 443.
+	updatedRule := &CompletedRule{
+		rule:          originalRule.rule,
+		FromAddresses: addressGroup1,
+		Pods:          appliedToGroupWithDiffContainerPort,
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	mockOFClient := openflowtest.NewMockClient(controller)
+	mockOFClient.EXPECT().InstallPolicyRuleFlows(gomock.Any(), gomock.Eq(&types.PolicyRule{
+		Direction: v1beta1.DirectionIn,
+		From:      ipsToOFAddresses(sets.NewString("1.1.1.1")),
+		To:        ofPortsToOFAddresses(sets.NewInt32(1, 2)),
+		Service:   []v1beta1.Service{serviceTCP80},
+	}), "", "")
+	// The existing Openflow rule is updated to only apply to pod1.
+	mockOFClient.EXPECT().DeletePolicyRuleAddress(gomock.Any(), types.DstAddress, gomock.Eq(ofPortsToOFAddresses(sets.NewInt32(2))))
+	// A new Openflow rule is installed for pod3 with the new port.
+	mockOFClient.EXPECT().InstallPolicyRuleFlows(gomock.Any(), gomock.Eq(&types.PolicyRule{
+		Direction: v1beta1.DirectionIn,
+		From:      ipsToOFAddresses(sets.NewString("1.1.1.1")),
+		To:        ofPortsToOFAddresses(sets.NewInt32(2)),
+		Service:   []v1beta1.Service{serviceTCP443},
+	}), "", "")
+
+	r := newReconciler(mockOFClient, ifaceStore, nil, nil)
+	require.NoError(t, r.Reconcile(originalRule))
+	require.NoError(t, r.Reconcile(updatedRule))
+	value, _ := r.lastRealizeds.Load(originalRule.ID)
+	lastRealized := value.(*lastRealized)
+	assert.Len(t, lastRealized.ofIDs, 2)
+	assert.Len(t, lastRealized.podOFPorts, 2)
+}
@@ -0,0 +1,241 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/contiv/libOpenflow/protocol"
+	"github.com/contiv/ofnet/ofctrl"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
+	binding "github.com/vmware-tanzu/antrea/pkg/ovs/openflow"
+)
+
+const (
+	tcpFlagFIN = 0x01
+	tcpFlagSYN = 0x02
+	tcpFlagRST = 0x04
+	tcpFlagACK = 0x10
+
+	icmpDstUnreachableType  = 3
+	icmpPortUnreachableCode = 3
+	// icmpOriginalDataLen is the number of bytes of the original datagram's data included in an
+	// ICMP Destination Unreachable message, following its IP header.
+	icmpOriginalDataLen = 8
+
+	icmpv6DstUnreachableType  = 1
+	icmpv6PortUnreachableCode = 4
+	// icmpv6MaxOriginalLen is the maximum number of bytes of the original packet included in an
+	// ICMPv6 Destination Unreachable message, so that the message doesn't exceed the minimum IPv6
+	// MTU (1280 bytes) with its IPv6 (40 bytes) and ICMPv6 (8 bytes) headers, as specified in
+	// RFC 4443.
+	icmpv6MaxOriginalLen = 1280 - 40 - 8
+
+	rejectPacketTTL = 64
+)
+
+// rejectedPacket holds the fields of a rejected IPv4 or IPv6 packet needed to build its reply.
+type rejectedPacket struct {
+	srcIP   net.IP
+	dstIP   net.IP
+	ipProto uint8
+	isIPv6  bool
+	// payloadLen is the length of the IP payload, i.e. of the transport segment.
+	payloadLen int
+	// tcp is the TCP header of the packet, only set for TCP.
+	tcp *protocol.TCP
+	// original is the serialized packet, starting with its IP header, truncated to the length
+	// included in an ICMP or ICMPv6 Destination Unreachable message.
+	original []byte
+}
+
+// parseRejectedPacket parses the IPv4 or IPv6 packet of the provided PacketIn message.
+func parseRejectedPacket(pktIn *ofctrl.PacketIn) (*rejectedPacket, error) {
+	switch pktIn.Data.Ethertype {
+	case protocol.IPv4_MSG:
+		ipPacket, ok := pktIn.Data.Data.(*protocol.IPv4)
+		if !ok {
+			return nil, errors.New("invalid IPv4 packet")
+		}
+		packet := &rejectedPacket{
+			srcIP:      ipPacket.NWSrc,
+			dstIP:      ipPacket.NWDst,
+			ipProto:    ipPacket.Protocol,
+			payloadLen: int(ipPacket.Length) - int(ipPacket.IHL)*4,
+		}
+		switch ipPacket.Protocol {
+		case protocol.Type_TCP:
+			tcp, ok := ipPacket.Data.(*protocol.TCP)
+			if !ok {
+				return nil, errors.New("invalid TCP packet")
+			}
+			packet.tcp = tcp
+		case protocol.Type_UDP:
+			data, err := ipPacket.MarshalBinary()
+			if err != nil {
+				return nil, fmt.Errorf("failed to serialize IPv4 packet: %v", err)
+			}
+			if originalLen := int(ipPacket.IHL)*4 + icmpOriginalDataLen; len(data) > originalLen {
+				data = data[:originalLen]
+			}
+			packet.original = data
+		}
+		return packet, nil
+	case protocol.IPv6_MSG:
+		data, err := pktIn.Data.Data.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read IPv6 packet: %v", err)
+		}
+		ipPacket, err := binding.ParseIPv6Packet(data)
+		if err != nil {
+			return nil, err
+		}
+		packet := &rejectedPacket{
+			srcIP:      ipPacket.NWSrc,
+			dstIP:      ipPacket.NWDst,
+			ipProto:    ipPacket.NextHeader,
+			isIPv6:     true,
+			payloadLen: len(ipPacket.Payload),
+		}
+		switch ipPacket.NextHeader {
+		case protocol.Type_TCP:
+			tcp := new(protocol.TCP)
+			if err := tcp.UnmarshalBinary(ipPacket.Payload); err != nil {
+				return nil, fmt.Errorf("invalid TCP packet: %v", err)
+			}
+			packet.tcp = tcp
+		case protocol.Type_UDP:
+			originalLen := int(ipPacket.Len())
+			if originalLen > icmpv6MaxOriginalLen {
+				originalLen = icmpv6MaxOriginalLen
+			}
+			packet.original = data[:originalLen]
+		}
+		return packet, nil
+	default:
+		return nil, fmt.Errorf("unsupported ethertype 0x%x", pktIn.Data.Ethertype)
+	}
+}
+
+// rejectRequest sends a reply rejecting the connection of the provided PacketIn message, whose
+// packet has been dropped by a rule with the Reject action: a TCP RST for TCP connections, and
+// an ICMP (or ICMPv6 for IPv6) Port Unreachable message for UDP. The packets of the other
+// protocols are dropped silently. The reply is sent from the destination of the packet to its
+// source, so that the client considers it as sent by the server.
+func (c *Controller) rejectRequest(pktIn *ofctrl.PacketIn) error {
+	packet, err := parseRejectedPacket(pktIn)
+	if err != nil {
+		return err
+	}
+	reply := &binding.Packet{
+		SourceIP:      packet.dstIP,
+		DestinationIP: packet.srcIP,
+		TTL:           rejectPacketTTL,
+	}
+	switch packet.ipProto {
+	case protocol.Type_TCP:
+		// A RST must never be sent in response to a RST.
+		if packet.tcp.Code&tcpFlagRST != 0 {
+			return nil
+		}
+		reply.IPProto = protocol.Type_TCP
+		reply.SourcePort = packet.tcp.PortDst
+		reply.DestinationPort = packet.tcp.PortSrc
+		setTCPReset(reply, packet.tcp, packet.payloadLen-int(packet.tcp.HdrLen)*4)
+	case protocol.Type_UDP:
+		if packet.isIPv6 {
+			reply.IPProto = protocol.Type_IPv6ICMP
+			reply.ICMPType = icmpv6DstUnreachableType
+			reply.ICMPCode = icmpv6PortUnreachableCode
+		} else {
+			reply.IPProto = protocol.Type_ICMP
+			reply.ICMPType = icmpDstUnreachableType
+			reply.ICMPCode = icmpPortUnreachableCode
+		}
+		// The first 4 bytes of the data are unused in Destination Unreachable messages.
+		reply.ICMPData = append(make([]byte, 4), packet.original...)
+	default:
+		return nil
+	}
+
+	inPort, err := c.setRejectSource(reply)
+	if err != nil {
+		return err
+	}
+	return c.ofClient.SendRejectPacket(reply, inPort)
+}
+
+// setTCPReset sets the TCP fields of the RST rejecting the provided TCP segment as specified in
+// RFC 793: if the segment has the ACK flag, the RST takes its sequence number from the segment's
+// acknowledgment number. Otherwise, the RST has sequence number 0 and acknowledges the segment,
+// whose data length is segmentLen.
+func setTCPReset(reply *binding.Packet, tcp *protocol.TCP, segmentLen int) {
+	if tcp.Code&tcpFlagACK != 0 {
+		seqNum := tcp.AckNum
+		reply.TCPFlags = tcpFlagRST
+		reply.TCPSeqNum = &seqNum
+		return
+	}
+	if segmentLen < 0 {
+		segmentLen = 0
+	}
+	// The SYN and FIN flags occupy one sequence number each.
+	if tcp.Code&tcpFlagSYN != 0 {
+		segmentLen++
+	}
+	if tcp.Code&tcpFlagFIN != 0 {
+		segmentLen++
+	}
+	seqNum, ackNum := uint32(0), tcp.SeqNum+uint32(segmentLen)
+	reply.TCPFlags = tcpFlagRST | tcpFlagACK
+	reply.TCPSeqNum = &seqNum
+	reply.TCPAckNum = &ackNum
+}
+
+// setRejectSource sets the MAC addresses of the provided reply, and returns the OpenFlow port
+// from which it's injected into the OVS pipeline. The reply goes through the conntrack tables
+// like the other replies of the connection, which translates its source back to the Service IP
+// if the rejected packet was DNAT'd by AntreaProxy. If the server, i.e. the source of the reply,
+// is a local Pod, the rejected packet was dropped by an ingress rule of this Pod, and the reply
+// is injected from the port of the Pod, as if it was sent by the Pod. Otherwise, the rejected
+// packet was dropped by an egress rule of the client, which must be a local Pod, and the reply
+// is injected from the gateway port, as if it was received from the server through the gateway.
+func (c *Controller) setRejectSource(reply *binding.Packet) (uint32, error) {
+	client := getContainerInterfaceByIP(c.ifaceStore, reply.DestinationIP)
+	server := getContainerInterfaceByIP(c.ifaceStore, reply.SourceIP)
+	if client == nil && server == nil {
+		return 0, fmt.Errorf("neither %s nor %s is a local Pod", reply.DestinationIP, reply.SourceIP)
+	}
+	gateways := c.ifaceStore.GetInterfacesByType(interfacestore.GatewayInterface)
+	if len(gateways) == 0 {
+		return 0, errors.New("gateway interface not found")
+	}
+	gateway := gateways[0]
+	if server != nil {
+		reply.SourceMAC = server.MAC
+		if client != nil {
+			reply.DestinationMAC = client.MAC
+		} else {
+			reply.DestinationMAC = gateway.MAC
+		}
+		return uint32(server.OFPort), nil
+	}
+	reply.SourceMAC = gateway.MAC
+	reply.DestinationMAC = client.MAC
+	return uint32(gateway.OFPort), nil
+}
@@ -0,0 +1,242 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"net"
+	"testing"
+
+	"github.com/contiv/libOpenflow/protocol"
+	"github.com/contiv/libOpenflow/util"
+	"github.com/contiv/ofnet/ofctrl"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+	openflowtest "github.com/vmware-tanzu/antrea/pkg/agent/openflow/testing"
+	binding "github.com/vmware-tanzu/antrea/pkg/ovs/openflow"
+)
+
+var (
+	pod1MAC, _    = net.ParseMAC("aa:aa:aa:aa:aa:01")
+	pod2MAC, _    = net.ParseMAC("aa:aa:aa:aa:aa:02")
+	gatewayMAC, _ = net.ParseMAC("aa:aa:aa:aa:aa:ff")
+	remoteMAC, _  = net.ParseMAC("bb:bb:bb:bb:bb:bb")
+)
+
+func newRejectPacketIn(srcMAC, dstMAC net.HardwareAddr, srcIP, dstIP string, transport interface{}) *ofctrl.PacketIn {
+	pktIn := newLoggingPacketIn(openflow.CNPIngressRuleTable,
+		map[int]uint32{openflow.MarksReg: openflow.CustomReasonReject << openflow.CustomReasonMarkRange[0]},
+		srcIP, dstIP, 0, transport)
+	ipPacket := pktIn.Data.Data.(*protocol.IPv4)
+	ipPacket.Version, ipPacket.IHL, ipPacket.TTL = 4, 5, 64
+	switch t := transport.(type) {
+	case *protocol.TCP:
+		ipPacket.Protocol = protocol.Type_TCP
+		ipPacket.Length = 20 + t.Len()
+	case *protocol.UDP:
+		ipPacket.Protocol = protocol.Type_UDP
+		ipPacket.Length = 20 + t.Len()
+	default:
+		ipPacket.Protocol = protocol.Type_ICMP
+	}
+	pktIn.Data.HWSrc, pktIn.Data.HWDst = srcMAC, dstMAC
+	return pktIn
+}
+
+func newIPv6RejectPacketIn(srcMAC, dstMAC net.HardwareAddr, srcIP, dstIP string, transport util.Message) *ofctrl.PacketIn {
+	pktIn := newRejectPacketIn(srcMAC, dstMAC, "0.0.0.0", "0.0.0.0", nil)
+	ipPacket := &binding.IPv6Packet{
+		HopLimit: 64,
+		NWSrc:    net.ParseIP(srcIP),
+		NWDst:    net.ParseIP(dstIP),
+	}
+	switch transport.(type) {
+	case *protocol.TCP:
+		ipPacket.NextHeader = protocol.Type_TCP
+	case *protocol.UDP:
+		ipPacket.NextHeader = protocol.Type_UDP
+	}
+	ipPacket.Payload, _ = transport.MarshalBinary()
+	data, _ := ipPacket.MarshalBinary()
+	pktIn.Data.Ethertype = protocol.IPv6_MSG
+	pktIn.Data.Data = util.NewBuffer(data)
+	return pktIn
+}
+
+func TestRejectRequest(t *testing.T) {
+	ifaceStore := interfacestore.NewInterfaceStore()
+	pod1 := interfacestore.NewContainerInterface("pod1-abcd", "c1", "pod1", "ns1", pod1MAC, []net.IP{net.ParseIP("10.10.0.1"), net.ParseIP("fd00:10:10::1")})
+	pod1.OVSPortConfig = &interfacestore.OVSPortConfig{OFPort: 3}
+	pod2 := interfacestore.NewContainerInterface("pod2-abcd", "c2", "pod2", "ns2", pod2MAC, []net.IP{net.ParseIP("10.10.0.2")})
+	pod2.OVSPortConfig = &interfacestore.OVSPortConfig{OFPort: 4}
+	gateway := interfacestore.NewGatewayInterface("antrea-gw0")
+	gateway.MAC = gatewayMAC
+	gateway.OVSPortConfig = &interfacestore.OVSPortConfig{OFPort: 2}
+	ifaceStore.AddInterface(pod1)
+	ifaceStore.AddInterface(pod2)
+	ifaceStore.AddInterface(gateway)
+
+	uint32Ptr := func(v uint32) *uint32 { return &v }
+	tests := []struct {
+		name           string
+		pktIn          *ofctrl.PacketIn
+		expectedPacket *binding.Packet
+		expectedInPort uint32
+		expectedErr    bool
+	}{
+		{
+			name: "TCP SYN from local Pod",
+			pktIn: newRejectPacketIn(pod2MAC, pod1MAC, "10.10.0.2", "10.10.0.1",
+				&protocol.TCP{PortSrc: 34567, PortDst: 80, SeqNum: 1000, HdrLen: 5, Code: tcpFlagSYN}),
+			expectedPacket: &binding.Packet{
+				SourceMAC:       pod1MAC,
+				DestinationMAC:  pod2MAC,
+				SourceIP:        net.ParseIP("10.10.0.1"),
+				DestinationIP:   net.ParseIP("10.10.0.2"),
+				IPProto:         protocol.Type_TCP,
+				TTL:             rejectPacketTTL,
+				SourcePort:      80,
+				DestinationPort: 34567,
+				TCPFlags:        tcpFlagRST | tcpFlagACK,
+				TCPSeqNum:       uint32Ptr(0),
+				TCPAckNum:       uint32Ptr(1001),
+			},
+			// The reply is injected from the port of the server.
+			expectedInPort: 3,
+		},
+		{
+			name: "TCP ACK from remote Pod",
+			pktIn: newRejectPacketIn(remoteMAC, pod1MAC, "10.10.1.1", "10.10.0.1",
+				&protocol.TCP{PortSrc: 34567, PortDst: 80, SeqNum: 1000, AckNum: 2000, HdrLen: 5, Code: tcpFlagACK}),
+			expectedPacket: &binding.Packet{
+				SourceMAC:       pod1MAC,
+				DestinationMAC:  gatewayMAC,
+				SourceIP:        net.ParseIP("10.10.0.1"),
+				DestinationIP:   net.ParseIP("10.10.1.1"),
+				IPProto:         protocol.Type_TCP,
+				TTL:             rejectPacketTTL,
+				SourcePort:      80,
+				DestinationPort: 34567,
+				TCPFlags:        tcpFlagRST,
+				TCPSeqNum:       uint32Ptr(2000),
+			},
+			expectedInPort: 3,
+		},
+		{
+			name: "UDP from local Pod",
+			pktIn: newRejectPacketIn(pod1MAC, gatewayMAC, "10.10.0.1", "10.10.1.1",
+				&protocol.UDP{PortSrc: 34567, PortDst: 53, Length: 12, Data: []byte{1, 2, 3, 4}}),
+			expectedPacket: &binding.Packet{
+				SourceMAC:      gatewayMAC,
+				DestinationMAC: pod1MAC,
+				SourceIP:       net.ParseIP("10.10.1.1"),
+				DestinationIP:  net.ParseIP("10.10.0.1"),
+				IPProto:        protocol.Type_ICMP,
+				TTL:            rejectPacketTTL,
+				ICMPType:       icmpDstUnreachableType,
+				ICMPCode:       icmpPortUnreachableCode,
+			},
+			// The reply is injected from the gateway port, as the server is not a local Pod.
+			expectedInPort: 2,
+		},
+		{
+			name: "IPv6 TCP SYN from remote Pod",
+			pktIn: newIPv6RejectPacketIn(remoteMAC, pod1MAC, "fd00:10:10:1::1", "fd00:10:10::1",
+				&protocol.TCP{PortSrc: 34567, PortDst: 80, SeqNum: 1000, HdrLen: 5, Code: tcpFlagSYN}),
+			expectedPacket: &binding.Packet{
+				SourceMAC:       pod1MAC,
+				DestinationMAC:  gatewayMAC,
+				SourceIP:        net.ParseIP("fd00:10:10::1"),
+				DestinationIP:   net.ParseIP("fd00:10:10:1::1"),
+				IPProto:         protocol.Type_TCP,
+				TTL:             rejectPacketTTL,
+				SourcePort:      80,
+				DestinationPort: 34567,
+				TCPFlags:        tcpFlagRST | tcpFlagACK,
+				TCPSeqNum:       uint32Ptr(0),
+				TCPAckNum:       uint32Ptr(1001),
+			},
+			expectedInPort: 3,
+		},
+		{
+			name: "IPv6 UDP from local Pod",
+			pktIn: newIPv6RejectPacketIn(pod1MAC, gatewayMAC, "fd00:10:10::1", "fd00:10:10:1::1",
+				&protocol.UDP{PortSrc: 34567, PortDst: 53, Length: 12, Data: []byte{1, 2, 3, 4}}),
+			expectedPacket: &binding.Packet{
+				SourceMAC:      gatewayMAC,
+				DestinationMAC: pod1MAC,
+				SourceIP:       net.ParseIP("fd00:10:10:1::1"),
+				DestinationIP:  net.ParseIP("fd00:10:10::1"),
+				IPProto:        protocol.Type_IPv6ICMP,
+				TTL:            rejectPacketTTL,
+				ICMPType:       icmpv6DstUnreachableType,
+				ICMPCode:       icmpv6PortUnreachableCode,
+			},
+			expectedInPort: 2,
+		},
+		{
+			name: "TCP RST",
+			pktIn: newRejectPacketIn(pod2MAC, pod1MAC, "10.10.0.2", "10.10.0.1",
+				&protocol.TCP{PortSrc: 34567, PortDst: 80, HdrLen: 5, Code: tcpFlagRST}),
+		},
+		{
+			name:  "ICMP",
+			pktIn: newRejectPacketIn(pod2MAC, pod1MAC, "10.10.0.2", "10.10.0.1", nil),
+		},
+		{
+			name: "no local Pod",
+			pktIn: newRejectPacketIn(remoteMAC, gatewayMAC, "10.10.1.1", "10.10.2.1",
+				&protocol.TCP{PortSrc: 34567, PortDst: 80, HdrLen: 5, Code: tcpFlagSYN}),
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ofClient := openflowtest.NewMockClient(ctrl)
+			c := &Controller{ofClient: ofClient, ifaceStore: ifaceStore}
+			if tt.expectedPacket != nil {
+				ofClient.EXPECT().SendRejectPacket(gomock.Any(), tt.expectedInPort).DoAndReturn(
+					func(packet *binding.Packet, inPort uint32) error {
+						switch tt.expectedPacket.IPProto {
+						case protocol.Type_ICMP:
+							// The data includes the unused field, the IP header and the first 8 bytes of the UDP datagram.
+							require.Equal(t, 4+20+8, len(packet.ICMPData))
+							assert.Equal(t, []byte{0, 0, 0, 0, 0x45}, packet.ICMPData[:5])
+							packet.ICMPData = nil
+						case protocol.Type_IPv6ICMP:
+							// The data includes the unused field and the whole original packet, which is
+							// smaller than the minimum IPv6 MTU.
+							require.Equal(t, 4+40+12, len(packet.ICMPData))
+							assert.Equal(t, []byte{0, 0, 0, 0, 0x60}, packet.ICMPData[:5])
+							packet.ICMPData = nil
+						}
+						assert.Equal(t, tt.expectedPacket, packet)
+						return nil
+					})
+			}
+			err := c.rejectRequest(tt.pktIn)
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent"
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+	agenttypes "github.com/vmware-tanzu/antrea/pkg/agent/types"
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+)
+
+const (
+	// How often the traffic statistics of the NetworkPolicies are collected
+	// and reported to antrea-controller.
+	statsCollectInterval = 60 * time.Second
+)
+
+// collectedRuleMetric is the statistics of an Openflow rule collected last
+// time.
+type collectedRuleMetric struct {
+	// ruleID is the ID of the rule the Openflow rule was installed for. It's
+	// used to detect that an Openflow rule ID has been reused by another rule.
+	ruleID string
+	metric agenttypes.RuleMetric
+}
+
+// statsCollector periodically collects the traffic statistics of the
+// NetworkPolicy rules from the Openflow flows, and reports the statistics
+// accumulated since the last successful report to antrea-controller.
+type statsCollector struct {
+	nodeName             string
+	antreaClientProvider agent.AntreaClientProvider
+	ofClient             openflow.Client
+	reconciler           Reconciler
+	// lastRuleMetrics is a map from Openflow rule ID to the statistics
+	// reported last time. It's only accessed by the goroutine running Run.
+	lastRuleMetrics map[uint32]*collectedRuleMetric
+}
+
+func newStatsCollector(antreaClientProvider agent.AntreaClientProvider, ofClient openflow.Client, reconciler Reconciler, nodeName string) *statsCollector {
+	return &statsCollector{
+		nodeName:             nodeName,
+		antreaClientProvider: antreaClientProvider,
+		ofClient:             ofClient,
+		reconciler:           reconciler,
+		lastRuleMetrics:      map[uint32]*collectedRuleMetric{},
+	}
+}
+
+// Run collects and reports the statistics periodically until stopCh is closed.
+func (c *statsCollector) Run(stopCh <-chan struct{}) {
+	wait.Until(func() {
+		if err := c.collectAndReport(); err != nil {
+			klog.Errorf("Error reporting NetworkPolicy statistics: %v", err)
+		}
+	}, statsCollectInterval, stopCh)
+}
+
+// collect returns the statistics of the NetworkPolicies accumulated since the
+// last report, and the current statistics of the Openflow rules. The counters
+// of an Openflow rule are considered reset if its ID has been reused by another
+// rule, or if they decreased.
+func (c *statsCollector) collect() ([]v1beta1.NetworkPolicyStats, map[uint32]*collectedRuleMetric) {
+	ruleMetrics := c.ofClient.NetworkPolicyMetrics()
+	curRuleMetrics := make(map[uint32]*collectedRuleMetric, len(ruleMetrics))
+	statsByPolicy := map[types.UID]*v1beta1.NetworkPolicyStats{}
+	for ofID, metric := range ruleMetrics {
+		rule, exists := c.reconciler.GetRuleByFlowID(ofID)
+		if !exists {
+			// The Openflow rule has been uninstalled since the flows were dumped.
+			continue
+		}
+		curRuleMetrics[ofID] = &collectedRuleMetric{ruleID: rule.ID, metric: *metric}
+		delta := *metric
+		if last, exists := c.lastRuleMetrics[ofID]; exists && last.ruleID == rule.ID &&
+			metric.Packets >= last.metric.Packets && metric.Bytes >= last.metric.Bytes && metric.Sessions >= last.metric.Sessions {
+			delta = agenttypes.RuleMetric{
+				Bytes:    metric.Bytes - last.metric.Bytes,
+				Packets:  metric.Packets - last.metric.Packets,
+				Sessions: metric.Sessions - last.metric.Sessions,
+			}
+		}
+		if delta == (agenttypes.RuleMetric{}) {
+			continue
+		}
+		stats, exists := statsByPolicy[rule.PolicyUID]
+		if !exists {
+			stats = &v1beta1.NetworkPolicyStats{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      rule.PolicyName,
+					Namespace: rule.PolicyNamespace,
+					UID:       rule.PolicyUID,
+				},
+			}
+			statsByPolicy[rule.PolicyUID] = stats
+		}
+		addRuleMetric(&stats.TrafficStats, &delta)
+		// The isolation rules generated for the Policy don't have a name,
+		// their statistics are only counted in the Policy's.
+		if rule.Name != "" {
+			addRuleMetric(getRuleTrafficStats(stats, rule.Name), &delta)
+		}
+	}
+	policyStats := make([]v1beta1.NetworkPolicyStats, 0, len(statsByPolicy))
+	for _, stats := range statsByPolicy {
+		sort.Slice(stats.RuleTrafficStats, func(i, j int) bool {
+			return stats.RuleTrafficStats[i].Name < stats.RuleTrafficStats[j].Name
+		})
+		policyStats = append(policyStats, *stats)
+	}
+	return policyStats, curRuleMetrics
+}
+
+// getRuleTrafficStats returns the TrafficStats of the rule with the given name
+// in the NetworkPolicyStats, adding it if it doesn't exist.
+func getRuleTrafficStats(stats *v1beta1.NetworkPolicyStats, ruleName string) *v1beta1.TrafficStats {
+	for i := range stats.RuleTrafficStats {
+		if stats.RuleTrafficStats[i].Name == ruleName {
+			return &stats.RuleTrafficStats[i].TrafficStats
+		}
+	}
+	stats.RuleTrafficStats = append(stats.RuleTrafficStats, v1beta1.RuleTrafficStats{Name: ruleName})
+	return &stats.RuleTrafficStats[len(stats.RuleTrafficStats)-1].TrafficStats
+}
+
+func addRuleMetric(stats *v1beta1.TrafficStats, metric *agenttypes.RuleMetric) {
+	stats.Packets += int64(metric.Packets)
+	stats.Bytes += int64(metric.Bytes)
+	stats.Sessions += int64(metric.Sessions)
+}
+
+// collectAndReport reports the statistics accumulated since the last report.
+// If the report fails, the statistics are reported the next time.
+func (c *statsCollector) collectAndReport() error {
+	policyStats, curRuleMetrics := c.collect()
+	if len(policyStats) > 0 {
+		antreaClient, err := c.antreaClientProvider.GetAntreaClient()
+		if err != nil {
+			return err
+		}
+		summary := &v1beta1.NodeStatsSummary{
+			ObjectMeta:      metav1.ObjectMeta{Name: c.nodeName},
+			NetworkPolicies: policyStats,
+		}
+		if _, err := antreaClient.NetworkingV1beta1().NodeStatsSummaries().Create(summary); err != nil {
+			return err
+		}
+		klog.V(2).Infof("Reported statistics of %d NetworkPolicies", len(policyStats))
+	}
+	c.lastRuleMetrics = curRuleMetrics
+	return nil
+}
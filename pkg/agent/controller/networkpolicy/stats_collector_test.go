@@ -0,0 +1,130 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8stesting "k8s.io/client-go/testing"
+
+	openflowtest "github.com/vmware-tanzu/antrea/pkg/agent/openflow/testing"
+	agenttypes "github.com/vmware-tanzu/antrea/pkg/agent/types"
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+	"github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/fake"
+)
+
+func newCompletedRuleOfPolicy(ruleID, ruleName, policyName string) *CompletedRule {
+	return &CompletedRule{rule: &rule{
+		ID:              ruleID,
+		Name:            ruleName,
+		PolicyUID:       types.UID("uid-" + policyName),
+		PolicyName:      policyName,
+		PolicyNamespace: testNamespace,
+	}}
+}
+
+func newPolicyStats(policyName string, packets, bytes, sessions int64, ruleStats ...v1beta1.RuleTrafficStats) v1beta1.NetworkPolicyStats {
+	return v1beta1.NetworkPolicyStats{
+		ObjectMeta:       metav1.ObjectMeta{Name: policyName, Namespace: testNamespace, UID: types.UID("uid-" + policyName)},
+		TrafficStats:     v1beta1.TrafficStats{Packets: packets, Bytes: bytes, Sessions: sessions},
+		RuleTrafficStats: ruleStats,
+	}
+}
+
+func newRuleStats(ruleName string, packets, bytes, sessions int64) v1beta1.RuleTrafficStats {
+	return v1beta1.RuleTrafficStats{
+		Name:         ruleName,
+		TrafficStats: v1beta1.TrafficStats{Packets: packets, Bytes: bytes, Sessions: sessions},
+	}
+}
+
+func TestStatsCollectorReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ofClient := openflowtest.NewMockClient(ctrl)
+	clientset := &fake.Clientset{}
+	var reported []*v1beta1.NodeStatsSummary
+	reportErr := error(nil)
+	clientset.AddReactor("create", "nodestatssummaries", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if reportErr != nil {
+			return true, nil, reportErr
+		}
+		summary := action.(k8stesting.CreateAction).GetObject().(*v1beta1.NodeStatsSummary)
+		reported = append(reported, summary)
+		return true, summary, nil
+	})
+	reconciler := newMockReconciler()
+	reconciler.ruleFlowIDs[1] = newCompletedRuleOfPolicy("rule1", "ingress-0", "np1")
+	reconciler.ruleFlowIDs[2] = newCompletedRuleOfPolicy("rule2", "egress-0", "np1")
+	reconciler.ruleFlowIDs[3] = newCompletedRuleOfPolicy("rule3", "ingress-0", "np2")
+	c := newStatsCollector(&antreaClientGetter{clientset}, ofClient, reconciler, "node1")
+
+	// The statistics of the Openflow rules of a NetworkPolicy are summed,
+	// and reported per rule as well.
+	ofClient.EXPECT().NetworkPolicyMetrics().Return(map[uint32]*agenttypes.RuleMetric{
+		1: {Packets: 10, Bytes: 1000, Sessions: 1},
+		2: {Packets: 20, Bytes: 2000, Sessions: 2},
+		3: {Packets: 5, Bytes: 500, Sessions: 1},
+		// The Openflow rule has been uninstalled.
+		4: {Packets: 5, Bytes: 500, Sessions: 1},
+	})
+	require.NoError(t, c.collectAndReport())
+	require.Len(t, reported, 1)
+	assert.Equal(t, "node1", reported[0].Name)
+	assert.ElementsMatch(t, []v1beta1.NetworkPolicyStats{
+		newPolicyStats("np1", 30, 3000, 3, newRuleStats("egress-0", 20, 2000, 2), newRuleStats("ingress-0", 10, 1000, 1)),
+		newPolicyStats("np2", 5, 500, 1, newRuleStats("ingress-0", 5, 500, 1)),
+	}, reported[0].NetworkPolicies)
+
+	// The statistics which failed to be reported are reported the next time.
+	reportErr = assert.AnError
+	ofClient.EXPECT().NetworkPolicyMetrics().Return(map[uint32]*agenttypes.RuleMetric{
+		1: {Packets: 15, Bytes: 1500, Sessions: 2},
+		2: {Packets: 20, Bytes: 2000, Sessions: 2},
+		3: {Packets: 5, Bytes: 500, Sessions: 1},
+	})
+	assert.Error(t, c.collectAndReport())
+	reportErr = nil
+	// The Openflow rule ID 3 has been reused by the isolation rule of np2,
+	// which is only counted in the Policy's statistics, and the counters of
+	// the Openflow rule 2 have been reset.
+	reconciler.ruleFlowIDs[3] = newCompletedRuleOfPolicy("rule4", "", "np2")
+	ofClient.EXPECT().NetworkPolicyMetrics().Return(map[uint32]*agenttypes.RuleMetric{
+		1: {Packets: 16, Bytes: 1600, Sessions: 2},
+		2: {Packets: 2, Bytes: 200, Sessions: 1},
+		3: {Packets: 1, Bytes: 100, Sessions: 1},
+	})
+	require.NoError(t, c.collectAndReport())
+	require.Len(t, reported, 2)
+	assert.ElementsMatch(t, []v1beta1.NetworkPolicyStats{
+		newPolicyStats("np1", 8, 800, 2, newRuleStats("egress-0", 2, 200, 1), newRuleStats("ingress-0", 6, 600, 1)),
+		newPolicyStats("np2", 1, 100, 1),
+	}, reported[1].NetworkPolicies)
+
+	// Nothing is reported if the statistics haven't changed.
+	ofClient.EXPECT().NetworkPolicyMetrics().Return(map[uint32]*agenttypes.RuleMetric{
+		1: {Packets: 16, Bytes: 1600, Sessions: 2},
+		2: {Packets: 2, Bytes: 200, Sessions: 1},
+		3: {Packets: 1, Bytes: 100, Sessions: 1},
+	})
+	require.NoError(t, c.collectAndReport())
+	assert.Len(t, reported, 2)
+}
@@ -0,0 +1,211 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent"
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+)
+
+// ruleRealization is the result of the last reconciliation of a rule.
+type ruleRealization struct {
+	// policyUID is the UID of the NetworkPolicy the rule belongs to.
+	policyUID types.UID
+	// err is the error returned by the reconciler, nil if the rule was realized.
+	err error
+}
+
+// statusController reports the realization statuses of the NetworkPolicies on
+// this Node to antrea-controller. A NetworkPolicy's status is reported once all
+// its rules have been reconciled, successfully or not, and whenever it changes.
+type statusController struct {
+	nodeName             string
+	antreaClientProvider agent.AntreaClientProvider
+	ruleCache            *ruleCache
+	// queue maintains the UIDs of the NetworkPolicies whose statuses need to
+	// be reported.
+	queue workqueue.RateLimitingInterface
+
+	realizationsLock sync.Mutex
+	// realizations is a map from rule ID to the result of its last
+	// reconciliation.
+	realizations map[string]*ruleRealization
+	// reportedStatuses is a map from NetworkPolicy UID to the status last
+	// reported for it.
+	reportedStatuses map[types.UID]v1beta1.NetworkPolicyNodeStatus
+}
+
+func newStatusController(antreaClientProvider agent.AntreaClientProvider, nodeName string, ruleCache *ruleCache) *statusController {
+	return &statusController{
+		nodeName:             nodeName,
+		antreaClientProvider: antreaClientProvider,
+		ruleCache:            ruleCache,
+		queue:                workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "networkpolicystatus"),
+		realizations:         map[string]*ruleRealization{},
+		reportedStatuses:     map[types.UID]v1beta1.NetworkPolicyNodeStatus{},
+	}
+}
+
+// SetRuleRealization records the result of the reconciliation of a rule.
+func (c *statusController) SetRuleRealization(ruleID string, policyUID types.UID, err error) {
+	c.realizationsLock.Lock()
+	defer c.realizationsLock.Unlock()
+	c.realizations[ruleID] = &ruleRealization{policyUID: policyUID, err: err}
+	c.queue.Add(policyUID)
+}
+
+// DeleteRuleRealization forgets the result of the reconciliation of a rule
+// which has been removed.
+func (c *statusController) DeleteRuleRealization(ruleID string) {
+	c.realizationsLock.Lock()
+	defer c.realizationsLock.Unlock()
+	if realization, exists := c.realizations[ruleID]; exists {
+		delete(c.realizations, ruleID)
+		c.queue.Add(realization.policyUID)
+	}
+}
+
+// EnqueuePolicy triggers the report of the status of a NetworkPolicy.
+func (c *statusController) EnqueuePolicy(policyUID types.UID) {
+	c.queue.Add(policyUID)
+}
+
+// Resync triggers the report of the statuses of all the NetworkPolicies, even
+// if they haven't changed since they were last reported. It is used when the
+// connection to antrea-controller is restored, as antrea-controller may have
+// restarted and lost the statuses.
+func (c *statusController) Resync() {
+	c.realizationsLock.Lock()
+	c.reportedStatuses = map[types.UID]v1beta1.NetworkPolicyNodeStatus{}
+	c.realizationsLock.Unlock()
+	for _, uid := range c.ruleCache.getNetworkPolicyUIDs() {
+		c.queue.Add(uid)
+	}
+}
+
+// Run reports the statuses of the NetworkPolicies until stopCh is closed.
+func (c *statusController) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+	// A single worker keeps the statuses of a NetworkPolicy reported in order.
+	go wait.Until(c.worker, time.Second, stopCh)
+	<-stopCh
+}
+
+func (c *statusController) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *statusController) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.syncPolicyStatus(key.(types.UID)); err != nil {
+		klog.Errorf("Error reporting status of NetworkPolicy %s, retrying. Error: %v", key, err)
+		c.queue.AddRateLimited(key)
+	} else {
+		c.queue.Forget(key)
+	}
+	return true
+}
+
+// computeStatus returns the status of the NetworkPolicy on this Node. The
+// second return value is false if some rules of the NetworkPolicy haven't been
+// reconciled yet.
+func (c *statusController) computeStatus(policyUID types.UID, generation int64) (v1beta1.NetworkPolicyNodeStatus, bool) {
+	status := v1beta1.NetworkPolicyNodeStatus{
+		NodeName:   c.nodeName,
+		Generation: generation,
+	}
+	rules, _ := c.ruleCache.rules.ByIndex(policyIndex, string(policyUID))
+	ruleIDs := make([]string, 0, len(rules))
+	for _, r := range rules {
+		ruleIDs = append(ruleIDs, r.(*rule).ID)
+	}
+	// Sort the rules so that the reported error is stable.
+	sort.Strings(ruleIDs)
+
+	c.realizationsLock.Lock()
+	defer c.realizationsLock.Unlock()
+	for _, ruleID := range ruleIDs {
+		realization, exists := c.realizations[ruleID]
+		if !exists {
+			return status, false
+		}
+		if realization.err != nil {
+			status.FailedRules++
+			if status.LastError == "" {
+				status.LastError = realization.err.Error()
+			}
+		}
+	}
+	return status, true
+}
+
+func (c *statusController) syncPolicyStatus(policyUID types.UID) error {
+	policyMeta := c.ruleCache.getNetworkPolicyMeta(policyUID)
+	if policyMeta == nil {
+		c.realizationsLock.Lock()
+		delete(c.reportedStatuses, policyUID)
+		c.realizationsLock.Unlock()
+		return nil
+	}
+	status, completed := c.computeStatus(policyUID, policyMeta.Generation)
+	if !completed {
+		// The status will be reported when the remaining rules are reconciled.
+		return nil
+	}
+	c.realizationsLock.Lock()
+	reportedStatus, reported := c.reportedStatuses[policyUID]
+	c.realizationsLock.Unlock()
+	if reported && reportedStatus == status {
+		return nil
+	}
+
+	antreaClient, err := c.antreaClientProvider.GetAntreaClient()
+	if err != nil {
+		return err
+	}
+	policyStatus := &v1beta1.NetworkPolicyStatus{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      policyMeta.Name,
+			Namespace: policyMeta.Namespace,
+			UID:       policyMeta.UID,
+		},
+		Nodes: []v1beta1.NetworkPolicyNodeStatus{status},
+	}
+	if _, err := antreaClient.NetworkingV1beta1().NetworkPolicyStatuses().Create(policyStatus); err != nil {
+		return err
+	}
+	klog.V(2).Infof("Reported status of NetworkPolicy %s/%s: %+v", policyMeta.Namespace, policyMeta.Name, status)
+
+	c.realizationsLock.Lock()
+	c.reportedStatuses[policyUID] = status
+	c.realizationsLock.Unlock()
+	return nil
+}
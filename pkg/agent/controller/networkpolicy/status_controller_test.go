@@ -0,0 +1,85 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+	"github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/fake"
+)
+
+func TestStatusControllerReport(t *testing.T) {
+	clientset := &fake.Clientset{}
+	var reported []*v1beta1.NetworkPolicyStatus
+	clientset.AddReactor("create", "networkpolicystatuses", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		status := action.(k8stesting.CreateAction).GetObject().(*v1beta1.NetworkPolicyStatus)
+		reported = append(reported, status)
+		return true, status, nil
+	})
+	cache := newRuleCache(func(string) {}, make(chan v1beta1.PodReference))
+	c := newStatusController(&antreaClientGetter{clientset}, "node1", cache)
+
+	policy := newNetworkPolicy("policy1", []string{"addressGroup1"}, nil, []string{"appliedToGroup1"}, nil)
+	policy.Generation = 1
+	policy.Rules = append(policy.Rules, v1beta1.NetworkPolicyRule{
+		Direction: v1beta1.DirectionOut,
+		To:        v1beta1.NetworkPolicyPeer{AddressGroups: []string{"addressGroup2"}},
+	})
+	cache.AddNetworkPolicy(policy)
+	ruleIDs, _ := cache.rules.IndexKeys(policyIndex, "policy1")
+	require.Len(t, ruleIDs, 2)
+
+	// Nothing is reported until all the rules are reconciled.
+	c.SetRuleRealization(ruleIDs[0], policy.UID, nil)
+	require.NoError(t, c.syncPolicyStatus(policy.UID))
+	assert.Empty(t, reported)
+
+	c.SetRuleRealization(ruleIDs[1], policy.UID, fmt.Errorf("failed to install flows"))
+	require.NoError(t, c.syncPolicyStatus(policy.UID))
+	require.Len(t, reported, 1)
+	assert.Equal(t, "policy1", reported[0].Name)
+	assert.Equal(t, testNamespace, reported[0].Namespace)
+	assert.Equal(t, types.UID("policy1"), reported[0].UID)
+	assert.Equal(t, []v1beta1.NetworkPolicyNodeStatus{{NodeName: "node1", Generation: 1, FailedRules: 1, LastError: "failed to install flows"}}, reported[0].Nodes)
+
+	// An unchanged status is not reported again.
+	require.NoError(t, c.syncPolicyStatus(policy.UID))
+	assert.Len(t, reported, 1)
+
+	c.SetRuleRealization(ruleIDs[1], policy.UID, nil)
+	require.NoError(t, c.syncPolicyStatus(policy.UID))
+	require.Len(t, reported, 2)
+	assert.Equal(t, []v1beta1.NetworkPolicyNodeStatus{{NodeName: "node1", Generation: 1}}, reported[1].Nodes)
+
+	// A new generation with unchanged rules is reported.
+	policy.Generation = 2
+	cache.UpdateNetworkPolicy(policy)
+	require.NoError(t, c.syncPolicyStatus(policy.UID))
+	require.Len(t, reported, 3)
+	assert.Equal(t, []v1beta1.NetworkPolicyNodeStatus{{NodeName: "node1", Generation: 2}}, reported[2].Nodes)
+
+	// All the statuses are reported again after a resync.
+	c.Resync()
+	require.NoError(t, c.syncPolicyStatus(policy.UID))
+	assert.Len(t, reported, 4)
+}
@@ -0,0 +1,394 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeportlocal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/route"
+)
+
+const (
+	controllerName = "AntreaAgentNodePortLocalController"
+	// How long to wait before retrying the processing of a Pod.
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = 300 * time.Second
+	// Number of workers processing the Pods.
+	defaultWorkers = 4
+
+	// NPLEnabledAnnotationKey is the key of the annotation which must be set to "true" on a Pod for
+	// the ports of its containers to be exposed on ports of its Node.
+	NPLEnabledAnnotationKey = "nodeportlocal.antrea.tanzu.vmware.com/enabled"
+	// NPLAnnotationKey is the key of the annotation publishing the Node ports of a Pod, as a JSON
+	// list of NPLAnnotation.
+	NPLAnnotationKey = "nodeportlocal.antrea.tanzu.vmware.com"
+)
+
+// NPLAnnotation describes a container port of a Pod exposed on a port of its Node.
+type NPLAnnotation struct {
+	PodPort  int    `json:"podPort"`
+	NodeIP   string `json:"nodeIP"`
+	NodePort int    `json:"nodePort"`
+	Protocol string `json:"protocol"`
+}
+
+// portMapping is the forwarding installed for a container port of a Pod.
+type portMapping struct {
+	podIP    string
+	podPort  int
+	protocol string
+	nodePort int
+}
+
+// portKey identifies a container port of a Pod.
+func portKey(podPort int, protocol string) string {
+	return fmt.Sprintf("%d/%s", podPort, protocol)
+}
+
+// Controller is responsible for exposing the container ports of the local Pods annotated with
+// NPLEnabledAnnotationKey on ports of the Node: it allocates a Node port from the configured range
+// for each container port, forwards the traffic to the Node port to the Pod with the routeClient,
+// and publishes the Node ports in the NPLAnnotationKey annotation of the Pod, so that the external
+// load balancers can target the Pods directly.
+type Controller struct {
+	kubeClient      clientset.Interface
+	routeClient     route.Interface
+	nodeName        string
+	nodeIP          net.IP
+	podLister       corelisters.PodLister
+	podListerSynced cache.InformerSynced
+	queue           workqueue.RateLimitingInterface
+	// mutex protects portAllocator and podMappings, which are accessed by all the workers.
+	mutex         sync.Mutex
+	portAllocator *portAllocator
+	// podMappings maps the key of a Pod to its installed port mappings, indexed by portKey.
+	podMappings map[string]map[string]*portMapping
+	// preferredPorts maps the key of a Pod to the Node ports published in its annotation when the
+	// controller started, indexed by portKey, so that they are kept across restarts.
+	preferredPorts map[string]map[string]int
+}
+
+// NewNodePortLocalController instantiates a new Controller object which will process the events of
+// the Pods running on the provided Node.
+func NewNodePortLocalController(
+	kubeClient clientset.Interface,
+	podInformer coreinformers.PodInformer,
+	routeClient route.Interface,
+	nodeName string,
+	nodeIP net.IP,
+	portRangeStart, portRangeEnd int) *Controller {
+	c := &Controller{
+		kubeClient:      kubeClient,
+		routeClient:     routeClient,
+		nodeName:        nodeName,
+		nodeIP:          nodeIP,
+		podLister:       podInformer.Lister(),
+		podListerSynced: podInformer.Informer().HasSynced,
+		queue:           workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "nodeportlocal"),
+		portAllocator:   newPortAllocator(portRangeStart, portRangeEnd),
+		podMappings:     map[string]map[string]*portMapping{},
+		preferredPorts:  map[string]map[string]int{},
+	}
+	podInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: c.isLocalPod,
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.enqueuePod,
+			UpdateFunc: func(_, cur interface{}) { c.enqueuePod(cur) },
+			DeleteFunc: c.enqueuePod,
+		},
+	})
+	return c
+}
+
+func (c *Controller) isLocalPod(obj interface{}) bool {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	pod, ok := obj.(*v1.Pod)
+	return ok && pod.Spec.NodeName == c.nodeName
+}
+
+func (c *Controller) enqueuePod(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		klog.Errorf("Failed to get the key of Pod %v: %v", obj, err)
+		return
+	}
+	c.queue.Add(key)
+}
+
+// Run will create defaultWorkers workers (go routines) which will process the Pod events from the
+// workqueue. The Node ports published by the Pods are loaded before, so that they are reused.
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+
+	klog.Infof("Starting %s", controllerName)
+	defer klog.Infof("Shutting down %s", controllerName)
+
+	if !cache.WaitForNamedCacheSync(controllerName, stopCh, c.podListerSynced) {
+		return
+	}
+	if err := c.loadPreferredPorts(); err != nil {
+		klog.Errorf("Failed to load the Node ports published by the Pods: %v", err)
+	}
+
+	for i := 0; i < defaultWorkers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+	<-stopCh
+}
+
+// loadPreferredPorts loads the Node ports published in the annotations of the local Pods.
+func (c *Controller) loadPreferredPorts() error {
+	pods, err := c.podLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, pod := range pods {
+		if pod.Spec.NodeName != c.nodeName || pod.Annotations[NPLAnnotationKey] == "" {
+			continue
+		}
+		var annotations []NPLAnnotation
+		if err := json.Unmarshal([]byte(pod.Annotations[NPLAnnotationKey]), &annotations); err != nil {
+			klog.Warningf("Ignoring invalid %s annotation of Pod %s/%s: %v", NPLAnnotationKey, pod.Namespace, pod.Name, err)
+			continue
+		}
+		ports := map[string]int{}
+		for _, annotation := range annotations {
+			ports[portKey(annotation.PodPort, annotation.Protocol)] = annotation.NodePort
+		}
+		key, _ := cache.MetaNamespaceKeyFunc(pod)
+		c.preferredPorts[key] = ports
+	}
+	return nil
+}
+
+func (c *Controller) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *Controller) processNextWorkItem() bool {
+	obj, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(obj)
+
+	if err := c.syncPod(obj.(string)); err == nil {
+		c.queue.Forget(obj)
+	} else {
+		c.queue.AddRateLimited(obj)
+		klog.Errorf("Error syncing Pod %s, requeuing. Error: %v", obj, err)
+	}
+	return true
+}
+
+// getPodIPv4 returns the IPv4 address of a running Pod which is not in the host network, or nil.
+func getPodIPv4(pod *v1.Pod) net.IP {
+	if pod.Spec.HostNetwork || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+		return nil
+	}
+	podIPs := pod.Status.PodIPs
+	if len(podIPs) == 0 && pod.Status.PodIP != "" {
+		podIPs = []v1.PodIP{{IP: pod.Status.PodIP}}
+	}
+	for _, podIP := range podIPs {
+		if ip := net.ParseIP(podIP.IP); ip != nil && ip.To4() != nil {
+			return ip.To4()
+		}
+	}
+	return nil
+}
+
+// desiredPortMappings returns the container ports of a Pod which must be exposed on Node ports,
+// indexed by portKey, without their Node ports.
+func (c *Controller) desiredPortMappings(pod *v1.Pod) map[string]*portMapping {
+	mappings := map[string]*portMapping{}
+	if pod == nil || pod.Spec.NodeName != c.nodeName || pod.Annotations[NPLEnabledAnnotationKey] != "true" {
+		return mappings
+	}
+	podIP := getPodIPv4(pod)
+	if podIP == nil {
+		return mappings
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			protocol := port.Protocol
+			if protocol == "" {
+				protocol = v1.ProtocolTCP
+			}
+			if protocol != v1.ProtocolTCP && protocol != v1.ProtocolUDP {
+				klog.V(2).Infof("Ignoring port %d/%s of Pod %s/%s as only TCP and UDP are supported", port.ContainerPort, protocol, pod.Namespace, pod.Name)
+				continue
+			}
+			mappings[portKey(int(port.ContainerPort), string(protocol))] = &portMapping{
+				podIP:    podIP.String(),
+				podPort:  int(port.ContainerPort),
+				protocol: string(protocol),
+			}
+		}
+	}
+	return mappings
+}
+
+// syncPod reconciles the installed port mappings of a Pod with its container ports, and publishes
+// them in its annotation.
+func (c *Controller) syncPod(key string) error {
+	startTime := time.Now()
+	defer func() {
+		klog.V(4).Infof("Finished syncing Pod %s. (%v)", key, time.Since(startTime))
+	}()
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	pod, err := c.podLister.Pods(namespace).Get(name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		pod = nil
+	}
+	desired := c.desiredPortMappings(pod)
+	mappings, err := c.reconcilePortMappings(key, desired)
+	if err != nil {
+		return err
+	}
+	if pod == nil {
+		return nil
+	}
+	return c.updatePodAnnotation(pod, mappings)
+}
+
+// reconcilePortMappings removes the installed port mappings of a Pod which are not desired anymore
+// and installs the new ones, allocating their Node ports. It returns the installed port mappings.
+func (c *Controller) reconcilePortMappings(key string, desired map[string]*portMapping) ([]*portMapping, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	installed, ok := c.podMappings[key]
+	if !ok {
+		installed = map[string]*portMapping{}
+		c.podMappings[key] = installed
+	}
+	defer func() {
+		if len(installed) == 0 {
+			delete(c.podMappings, key)
+		}
+	}()
+
+	for pk, mapping := range installed {
+		if d, ok := desired[pk]; ok && d.podIP == mapping.podIP {
+			continue
+		}
+		if err := c.routeClient.DeleteNodePortLocal(mapping.nodePort, net.ParseIP(mapping.podIP), mapping.podPort, mapping.protocol); err != nil {
+			return nil, fmt.Errorf("failed to delete the forwarding from Node port %d to Pod %s port %s: %v", mapping.nodePort, key, pk, err)
+		}
+		c.portAllocator.release(mapping.nodePort)
+		delete(installed, pk)
+	}
+	for pk, mapping := range desired {
+		if _, ok := installed[pk]; ok {
+			continue
+		}
+		nodePort, err := c.portAllocator.allocate(c.preferredPorts[key][pk], mapping.protocol)
+		if err != nil {
+			return nil, fmt.Errorf("failed to allocate a Node port to Pod %s port %s: %v", key, pk, err)
+		}
+		if err := c.routeClient.AddNodePortLocal(nodePort, net.ParseIP(mapping.podIP), mapping.podPort, mapping.protocol); err != nil {
+			c.portAllocator.release(nodePort)
+			return nil, fmt.Errorf("failed to forward Node port %d to Pod %s port %s: %v", nodePort, key, pk, err)
+		}
+		mapping.nodePort = nodePort
+		installed[pk] = mapping
+	}
+	// The preferred ports are only used for the first allocation.
+	delete(c.preferredPorts, key)
+
+	mappings := make([]*portMapping, 0, len(installed))
+	for _, mapping := range installed {
+		mappings = append(mappings, mapping)
+	}
+	return mappings, nil
+}
+
+// updatePodAnnotation publishes the provided port mappings in the NPLAnnotationKey annotation of the
+// Pod, or removes the annotation if there is none.
+func (c *Controller) updatePodAnnotation(pod *v1.Pod, mappings []*portMapping) error {
+	var value interface{}
+	if len(mappings) > 0 {
+		sort.Slice(mappings, func(i, j int) bool {
+			if mappings[i].podPort != mappings[j].podPort {
+				return mappings[i].podPort < mappings[j].podPort
+			}
+			return mappings[i].protocol < mappings[j].protocol
+		})
+		annotations := make([]NPLAnnotation, 0, len(mappings))
+		for _, mapping := range mappings {
+			annotations = append(annotations, NPLAnnotation{
+				PodPort:  mapping.podPort,
+				NodeIP:   c.nodeIP.String(),
+				NodePort: mapping.nodePort,
+				Protocol: mapping.protocol,
+			})
+		}
+		data, err := json.Marshal(annotations)
+		if err != nil {
+			return err
+		}
+		if pod.Annotations[NPLAnnotationKey] == string(data) {
+			return nil
+		}
+		value = string(data)
+	} else if _, ok := pod.Annotations[NPLAnnotationKey]; !ok {
+		return nil
+	}
+	// A nil value removes the annotation.
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{NPLAnnotationKey: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := c.kubeClient.CoreV1().Pods(pod.Namespace).Patch(pod.Name, types.MergePatchType, patch); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to update the %s annotation of Pod %s/%s: %v", NPLAnnotationKey, pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
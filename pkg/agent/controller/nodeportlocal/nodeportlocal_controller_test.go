@@ -0,0 +1,262 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeportlocal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	routetest "github.com/vmware-tanzu/antrea/pkg/agent/route/testing"
+)
+
+const localNodeName = "node1"
+
+var localNodeIP = net.ParseIP("192.168.0.1")
+
+type fakeController struct {
+	*Controller
+	kubeClient      *k8sfake.Clientset
+	informerFactory informers.SharedInformerFactory
+	mockRouteClient *routetest.MockInterface
+	// reservedPorts is the set of the ports which cannot be reserved on the Node.
+	reservedPorts map[int]bool
+}
+
+func newFakeController(t *testing.T, portRangeStart, portRangeEnd int) (*fakeController, *gomock.Controller) {
+	ctrl := gomock.NewController(t)
+	kubeClient := k8sfake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(kubeClient, 0)
+	mockRouteClient := routetest.NewMockInterface(ctrl)
+	c := NewNodePortLocalController(
+		kubeClient,
+		informerFactory.Core().V1().Pods(),
+		mockRouteClient,
+		localNodeName,
+		localNodeIP,
+		portRangeStart,
+		portRangeEnd)
+	fc := &fakeController{
+		Controller:      c,
+		kubeClient:      kubeClient,
+		informerFactory: informerFactory,
+		mockRouteClient: mockRouteClient,
+		reservedPorts:   map[int]bool{},
+	}
+	c.portAllocator.reservePort = func(port int, protocol string) (io.Closer, error) {
+		if fc.reservedPorts[port] {
+			return nil, fmt.Errorf("port %d is in use", port)
+		}
+		return ioutil.NopCloser(nil), nil
+	}
+	return fc, ctrl
+}
+
+func (c *fakeController) addPod(pod *v1.Pod) {
+	c.kubeClient.CoreV1().Pods(pod.Namespace).Create(pod)
+	c.informerFactory.Core().V1().Pods().Informer().GetIndexer().Add(pod)
+}
+
+// updatePod updates the Pod in the informer, with the annotation patched by the controller.
+func (c *fakeController) updatePod(pod *v1.Pod) {
+	if cur, err := c.kubeClient.CoreV1().Pods(pod.Namespace).Get(pod.Name, metav1.GetOptions{}); err == nil {
+		if value, ok := cur.Annotations[NPLAnnotationKey]; ok {
+			pod.Annotations[NPLAnnotationKey] = value
+		}
+	}
+	c.informerFactory.Core().V1().Pods().Informer().GetIndexer().Update(pod)
+}
+
+func (c *fakeController) deletePod(pod *v1.Pod) {
+	c.kubeClient.CoreV1().Pods(pod.Namespace).Delete(pod.Name, &metav1.DeleteOptions{})
+	c.informerFactory.Core().V1().Pods().Informer().GetIndexer().Delete(pod)
+}
+
+// getAnnotations returns the Node ports published in the annotation of the Pod.
+func (c *fakeController) getAnnotations(t *testing.T, pod *v1.Pod) []NPLAnnotation {
+	pod, err := c.kubeClient.CoreV1().Pods(pod.Namespace).Get(pod.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	value, ok := pod.Annotations[NPLAnnotationKey]
+	if !ok {
+		return nil
+	}
+	var annotations []NPLAnnotation
+	require.NoError(t, json.Unmarshal([]byte(value), &annotations))
+	return annotations
+}
+
+func newPod(name, nodeName, ip string, enabled bool, ports ...v1.ContainerPort) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: name, Annotations: map[string]string{}},
+		Spec: v1.PodSpec{
+			NodeName:   nodeName,
+			Containers: []v1.Container{{Name: "c1", Ports: ports}},
+		},
+		Status: v1.PodStatus{Phase: v1.PodRunning, PodIP: ip},
+	}
+	if enabled {
+		pod.Annotations[NPLEnabledAnnotationKey] = "true"
+	}
+	return pod
+}
+
+func TestSyncPod(t *testing.T) {
+	c, ctrl := newFakeController(t, 40000, 40002)
+	defer ctrl.Finish()
+	podIP := net.ParseIP("10.10.0.2")
+
+	pod := newPod("pod1", localNodeName, podIP.String(), true,
+		v1.ContainerPort{ContainerPort: 8080},
+		v1.ContainerPort{ContainerPort: 53, Protocol: v1.ProtocolUDP},
+		v1.ContainerPort{ContainerPort: 9000, Protocol: v1.ProtocolSCTP})
+	c.addPod(pod)
+	c.reservedPorts[40000] = true
+	c.mockRouteClient.EXPECT().AddNodePortLocal(gomock.Any(), podIP, 8080, "TCP")
+	c.mockRouteClient.EXPECT().AddNodePortLocal(gomock.Any(), podIP, 53, "UDP")
+	require.NoError(t, c.syncPod("ns1/pod1"))
+	annotations := c.getAnnotations(t, pod)
+	require.Len(t, annotations, 2)
+	assert.Equal(t, 53, annotations[0].PodPort)
+	assert.Equal(t, "UDP", annotations[0].Protocol)
+	assert.Equal(t, 8080, annotations[1].PodPort)
+	assert.Equal(t, "TCP", annotations[1].Protocol)
+	assert.ElementsMatch(t, []int{40001, 40002}, []int{annotations[0].NodePort, annotations[1].NodePort})
+	assert.Equal(t, localNodeIP.String(), annotations[0].NodeIP)
+
+	// Removing a container port deletes its mapping and releases its Node port.
+	udpNodePort := annotations[0].NodePort
+	pod.Spec.Containers[0].Ports = pod.Spec.Containers[0].Ports[:1]
+	c.updatePod(pod)
+	c.mockRouteClient.EXPECT().DeleteNodePortLocal(udpNodePort, podIP, 53, "UDP")
+	require.NoError(t, c.syncPod("ns1/pod1"))
+	annotations = c.getAnnotations(t, pod)
+	require.Len(t, annotations, 1)
+	assert.Equal(t, 8080, annotations[0].PodPort)
+	assert.NotContains(t, c.portAllocator.allocated, udpNodePort)
+
+	// Disabling the feature for the Pod deletes all its mappings and removes the annotation.
+	tcpNodePort := annotations[0].NodePort
+	delete(pod.Annotations, NPLEnabledAnnotationKey)
+	c.updatePod(pod)
+	c.mockRouteClient.EXPECT().DeleteNodePortLocal(tcpNodePort, podIP, 8080, "TCP")
+	require.NoError(t, c.syncPod("ns1/pod1"))
+	assert.Nil(t, c.getAnnotations(t, pod))
+	assert.Empty(t, c.portAllocator.allocated)
+	assert.Empty(t, c.podMappings)
+}
+
+func TestSyncPodIgnored(t *testing.T) {
+	c, ctrl := newFakeController(t, 40000, 40002)
+	defer ctrl.Finish()
+
+	port := v1.ContainerPort{ContainerPort: 8080}
+	notEnabledPod := newPod("pod1", localNodeName, "10.10.0.2", false, port)
+	remotePod := newPod("pod2", "node2", "10.10.1.2", true, port)
+	noIPPod := newPod("pod3", localNodeName, "", true, port)
+	hostNetworkPod := newPod("pod4", localNodeName, "192.168.0.1", true, port)
+	hostNetworkPod.Spec.HostNetwork = true
+	ipv6Pod := newPod("pod5", localNodeName, "2001:db8::2", true, port)
+	for _, pod := range []*v1.Pod{notEnabledPod, remotePod, noIPPod, hostNetworkPod, ipv6Pod} {
+		c.addPod(pod)
+		require.NoError(t, c.syncPod("ns1/"+pod.Name))
+		assert.Nil(t, c.getAnnotations(t, pod), "Pod %s must not be annotated", pod.Name)
+	}
+	assert.Empty(t, c.podMappings)
+}
+
+func TestSyncDeletedPod(t *testing.T) {
+	c, ctrl := newFakeController(t, 40000, 40002)
+	defer ctrl.Finish()
+	podIP := net.ParseIP("10.10.0.2")
+
+	pod := newPod("pod1", localNodeName, podIP.String(), true, v1.ContainerPort{ContainerPort: 8080})
+	c.addPod(pod)
+	c.mockRouteClient.EXPECT().AddNodePortLocal(40000, podIP, 8080, "TCP")
+	require.NoError(t, c.syncPod("ns1/pod1"))
+
+	c.deletePod(pod)
+	c.mockRouteClient.EXPECT().DeleteNodePortLocal(40000, podIP, 8080, "TCP")
+	require.NoError(t, c.syncPod("ns1/pod1"))
+	assert.Empty(t, c.portAllocator.allocated)
+	assert.Empty(t, c.podMappings)
+}
+
+func TestSyncPodWithPreferredPorts(t *testing.T) {
+	c, ctrl := newFakeController(t, 40000, 40002)
+	defer ctrl.Finish()
+	podIP := net.ParseIP("10.10.0.2")
+
+	pod := newPod("pod1", localNodeName, podIP.String(), true, v1.ContainerPort{ContainerPort: 8080})
+	pod.Annotations[NPLAnnotationKey] = `[{"podPort":8080,"nodeIP":"192.168.0.1","nodePort":40002,"protocol":"TCP"}]`
+	c.addPod(pod)
+	require.NoError(t, c.loadPreferredPorts())
+	c.mockRouteClient.EXPECT().AddNodePortLocal(40002, podIP, 8080, "TCP")
+	require.NoError(t, c.syncPod("ns1/pod1"))
+	assert.Equal(t, []NPLAnnotation{{PodPort: 8080, NodeIP: "192.168.0.1", NodePort: 40002, Protocol: "TCP"}}, c.getAnnotations(t, pod))
+	assert.Empty(t, c.preferredPorts)
+}
+
+func TestSyncPodPortsExhausted(t *testing.T) {
+	c, ctrl := newFakeController(t, 40000, 40000)
+	defer ctrl.Finish()
+	podIP := net.ParseIP("10.10.0.2")
+
+	pod := newPod("pod1", localNodeName, podIP.String(), true,
+		v1.ContainerPort{ContainerPort: 8080},
+		v1.ContainerPort{ContainerPort: 8081})
+	c.addPod(pod)
+	c.mockRouteClient.EXPECT().AddNodePortLocal(40000, podIP, gomock.Any(), "TCP")
+	assert.Error(t, c.syncPod("ns1/pod1"))
+}
+
+func TestParsePortRange(t *testing.T) {
+	tests := []struct {
+		portRange     string
+		expectedStart int
+		expectedEnd   int
+		expectedErr   bool
+	}{
+		{"40000-41000", 40000, 41000, false},
+		{"40000-40000", 40000, 40000, false},
+		{"40000", 0, 0, true},
+		{"41000-40000", 0, 0, true},
+		{"0-100", 0, 0, true},
+		{"40000-70000", 0, 0, true},
+		{"a-b", 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.portRange, func(t *testing.T) {
+			start, end, err := ParsePortRange(tt.portRange)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStart, start)
+			assert.Equal(t, tt.expectedEnd, end)
+		})
+	}
+}
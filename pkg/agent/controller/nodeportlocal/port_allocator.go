@@ -0,0 +1,126 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeportlocal
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ParsePortRange parses a port range with format <start>-<end>, both ends being included.
+func ParsePortRange(portRange string) (int, int, error) {
+	parts := strings.Split(portRange, "-")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("port range %s is invalid: the format must be <start>-<end>", portRange)
+	}
+	start, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil || start == 0 {
+		return 0, 0, fmt.Errorf("port range %s is invalid: start port %s is invalid", portRange, parts[0])
+	}
+	end, err := strconv.ParseUint(parts[1], 10, 16)
+	if err != nil {
+		return 0, 0, fmt.Errorf("port range %s is invalid: end port %s is invalid", portRange, parts[1])
+	}
+	if end < start {
+		return 0, 0, fmt.Errorf("port range %s is invalid: end port must not be smaller than start port", portRange)
+	}
+	return int(start), int(end), nil
+}
+
+// reservePort binds a socket to the provided port and protocol on all the addresses of the Node, so
+// that no other process of the Node can use the port while it's allocated. The traffic to the port
+// is DNAT'd before it reaches the socket.
+func reservePort(port int, protocol string) (io.Closer, error) {
+	addr := fmt.Sprintf(":%d", port)
+	switch protocol {
+	case "TCP":
+		return net.Listen("tcp4", addr)
+	case "UDP":
+		return net.ListenPacket("udp4", addr)
+	}
+	return nil, fmt.Errorf("unsupported protocol %s", protocol)
+}
+
+// portAllocator allocates the Node ports from a range. A Node port is allocated only if it can be
+// reserved on the Node, and it's allocated to a single Pod port whatever the protocol. It's not
+// thread-safe.
+type portAllocator struct {
+	start, end int
+	// next is the first port checked by the next allocation which has no preferred port, so that
+	// the released ports are not reused immediately.
+	next int
+	// allocated maps the allocated ports to the sockets reserving them.
+	allocated map[int]io.Closer
+	// reservePort is the function reserving a port on the Node, which is overridden in tests.
+	reservePort func(port int, protocol string) (io.Closer, error)
+}
+
+func newPortAllocator(start, end int) *portAllocator {
+	return &portAllocator{
+		start:       start,
+		end:         end,
+		next:        start,
+		allocated:   map[int]io.Closer{},
+		reservePort: reservePort,
+	}
+}
+
+// tryAllocate allocates the provided port if it's in the range, not allocated yet and can be
+// reserved on the Node.
+func (a *portAllocator) tryAllocate(port int, protocol string) bool {
+	if port < a.start || port > a.end {
+		return false
+	}
+	if _, ok := a.allocated[port]; ok {
+		return false
+	}
+	closer, err := a.reservePort(port, protocol)
+	if err != nil {
+		return false
+	}
+	a.allocated[port] = closer
+	return true
+}
+
+// allocate allocates a port for the provided protocol. The preferred port is allocated if possible,
+// e.g. the port previously allocated to the same Pod port before the agent restarted.
+func (a *portAllocator) allocate(preferred int, protocol string) (int, error) {
+	if preferred != 0 && a.tryAllocate(preferred, protocol) {
+		return preferred, nil
+	}
+	size := a.end - a.start + 1
+	for i := 0; i < size; i++ {
+		port := a.start + (a.next-a.start+i)%size
+		if a.tryAllocate(port, protocol) {
+			a.next = port + 1
+			if a.next > a.end {
+				a.next = a.start
+			}
+			return port, nil
+		}
+	}
+	return 0, fmt.Errorf("no port available in range %d-%d", a.start, a.end)
+}
+
+// release releases an allocated port.
+func (a *portAllocator) release(port int) {
+	if closer, ok := a.allocated[port]; ok {
+		closer.Close()
+		delete(a.allocated, port)
+	}
+}
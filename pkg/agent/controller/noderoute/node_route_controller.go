@@ -37,6 +37,10 @@ import (
 	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
 	"github.com/vmware-tanzu/antrea/pkg/agent/route"
 	"github.com/vmware-tanzu/antrea/pkg/agent/util"
+	"github.com/vmware-tanzu/antrea/pkg/agent/wireguard"
+	ipamv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/ipam/v1alpha1"
+	ipaminformers "github.com/vmware-tanzu/antrea/pkg/client/informers/externalversions/ipam/v1alpha1"
+	ipamlisters "github.com/vmware-tanzu/antrea/pkg/client/listers/ipam/v1alpha1"
 	"github.com/vmware-tanzu/antrea/pkg/ovs/ovsconfig"
 )
 
@@ -59,27 +63,35 @@ type Controller struct {
 	ovsBridgeClient  ovsconfig.OVSBridgeClient
 	ofClient         openflow.Client
 	routeClient      route.Interface
+	wireGuardClient  wireguard.Interface
 	interfaceStore   interfacestore.InterfaceStore
 	networkConfig    *config.NetworkConfig
 	nodeConfig       *config.NodeConfig
 	nodeInformer     coreinformers.NodeInformer
 	nodeLister       corelisters.NodeLister
 	nodeListerSynced cache.InformerSynced
-	queue            workqueue.RateLimitingInterface
+	// ipPoolLister is nil if the AntreaIPAM feature is not enabled.
+	ipPoolLister       ipamlisters.IPPoolLister
+	ipPoolListerSynced cache.InformerSynced
+	queue              workqueue.RateLimitingInterface
 	// installedNodes records routes and flows installation states of Nodes.
-	// The key is the host name of the Node, the value is the podCIDR of the Node.
+	// The key is the host name of the Node, the value is the podCIDRs of the Node, including the /32
+	// CIDRs of the IPs allocated from IPPools to the Pods of the Node.
 	// A node will be in the map after its flows and routes are installed successfully.
 	installedNodes *sync.Map
 }
 
 // NewNodeRouteController instantiates a new Controller object which will process Node events
-// and ensure connectivity between different Nodes.
+// and ensure connectivity between different Nodes. wireGuardClient must be nil if WireGuard is
+// not enabled, and ipPoolInformer must be nil if the AntreaIPAM feature is not enabled.
 func NewNodeRouteController(
 	kubeClient clientset.Interface,
 	informerFactory informers.SharedInformerFactory,
+	ipPoolInformer ipaminformers.IPPoolInformer,
 	client openflow.Client,
 	ovsBridgeClient ovsconfig.OVSBridgeClient,
 	routeClient route.Interface,
+	wireGuardClient wireguard.Interface,
 	interfaceStore interfacestore.InterfaceStore,
 	networkConfig *config.NetworkConfig,
 	nodeConfig *config.NodeConfig) *Controller {
@@ -89,6 +101,7 @@ func NewNodeRouteController(
 		ovsBridgeClient:  ovsBridgeClient,
 		ofClient:         client,
 		routeClient:      routeClient,
+		wireGuardClient:  wireGuardClient,
 		interfaceStore:   interfaceStore,
 		networkConfig:    networkConfig,
 		nodeConfig:       nodeConfig,
@@ -111,9 +124,77 @@ func NewNodeRouteController(
 		},
 		nodeResyncPeriod,
 	)
+	if ipPoolInformer != nil {
+		controller.ipPoolLister = ipPoolInformer.Lister()
+		controller.ipPoolListerSynced = ipPoolInformer.Informer().HasSynced
+		ipPoolInformer.Informer().AddEventHandler(
+			cache.ResourceEventHandlerFuncs{
+				AddFunc: func(cur interface{}) {
+					controller.enqueueIPPoolNodes(cur)
+				},
+				UpdateFunc: func(old, cur interface{}) {
+					controller.enqueueIPPoolNodes(old)
+					controller.enqueueIPPoolNodes(cur)
+				},
+				DeleteFunc: func(old interface{}) {
+					controller.enqueueIPPoolNodes(old)
+				},
+			},
+		)
+	}
 	return controller
 }
 
+// enqueueIPPoolNodes adds the Nodes of the Pods which have IPs allocated from an IPPool to the
+// controller work queue, so that their routes and flows are updated.
+// obj could be an *ipamv1alpha1.IPPool, or a DeletionFinalStateUnknown item.
+func (c *Controller) enqueueIPPoolNodes(obj interface{}) {
+	pool, isPool := obj.(*ipamv1alpha1.IPPool)
+	if !isPool {
+		deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("Received unexpected object: %v", obj)
+			return
+		}
+		pool, ok = deletedState.Obj.(*ipamv1alpha1.IPPool)
+		if !ok {
+			klog.Errorf("DeletedFinalStateUnknown contains non-IPPool object: %v", deletedState.Obj)
+			return
+		}
+	}
+	for _, state := range pool.Status.IPAddresses {
+		if state.Owner.Pod != nil && state.Owner.Pod.NodeName != c.nodeConfig.Name {
+			c.queue.Add(state.Owner.Pod.NodeName)
+		}
+	}
+}
+
+// getPoolPodCIDRs returns the /32 CIDRs of the IPs allocated from IPPools to the primary interfaces
+// of the Pods of the Node. The IPs of the secondary interfaces are not routed by Antrea.
+func (c *Controller) getPoolPodCIDRs(nodeName string) []string {
+	if c.ipPoolLister == nil {
+		return nil
+	}
+	pools, err := c.ipPoolLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Error when listing IPPools: %v", err)
+		return nil
+	}
+	var poolPodCIDRs []string
+	for _, pool := range pools {
+		for _, state := range pool.Status.IPAddresses {
+			podOwner := state.Owner.Pod
+			if state.Phase != ipamv1alpha1.IPAddressPhaseAllocated || podOwner == nil || podOwner.NodeName != nodeName || podOwner.IFName != "" {
+				continue
+			}
+			if podIP := net.ParseIP(state.IPAddress); podIP != nil && podIP.To4() != nil {
+				poolPodCIDRs = append(poolPodCIDRs, (&net.IPNet{IP: podIP, Mask: net.CIDRMask(32, 32)}).String())
+			}
+		}
+	}
+	return poolPodCIDRs
+}
+
 // enqueueNode adds an object to the controller work queue
 // obj could be an *v1.Node, or a DeletionFinalStateUnknown item.
 func (c *Controller) enqueueNode(obj interface{}) {
@@ -152,10 +233,8 @@ func (c *Controller) removeStaleGatewayRoutes() error {
 	for _, node := range nodes {
 		// PodCIDR is allocated by K8s NodeIpamController asynchronously so it's possible we see a Node
 		// with no PodCIDR set when it just joins the cluster.
-		if node.Spec.PodCIDR == "" {
-			continue
-		}
-		desiredPodCIDRs = append(desiredPodCIDRs, node.Spec.PodCIDR)
+		desiredPodCIDRs = append(desiredPodCIDRs, GetPodCIDRs(node)...)
+		desiredPodCIDRs = append(desiredPodCIDRs, c.getPoolPodCIDRs(node.Name)...)
 	}
 
 	// routeClient will remove orphaned routes whose destinations are not in desiredPodCIDRs.
@@ -190,16 +269,11 @@ func (c *Controller) removeStaleTunnelPorts() error {
 				continue
 			}
 
-			peerNodeIP, err := GetNodeAddr(node)
-			if err != nil {
-				klog.Errorf("Failed to retrieve IP address of Node %s: %v", node.Name, err)
-				continue
-			}
-
 			ifaceID := util.GenerateNodeTunnelInterfaceKey(node.Name)
-			validConfiguration := interfaceConfig.PSK == c.networkConfig.IPSecPSK &&
-				interfaceConfig.RemoteIP.Equal(peerNodeIP) &&
-				interfaceConfig.TunnelInterfaceConfig.Type == c.networkConfig.TunnelType
+			// A port with a stale remote IP or stale credentials is kept, as its options
+			// are updated in place by createIPSecTunnelPort, which preserves its ofport
+			// and the flows using it. Only the tunnel type cannot be updated in place.
+			validConfiguration := interfaceConfig.TunnelInterfaceConfig.Type == c.networkConfig.TunnelType
 			if validConfiguration {
 				desiredInterfaces[ifaceID] = true
 			}
@@ -233,6 +307,25 @@ func (c *Controller) removeStaleTunnelPorts() error {
 	return nil
 }
 
+// removeStaleWireGuardPeers removes all the WireGuard peers which no longer correspond to a Node in
+// the cluster, or whose public key is no longer the one published by the Node.
+func (c *Controller) removeStaleWireGuardPeers() error {
+	nodes, err := c.nodeLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("error when listing Nodes: %v", err)
+	}
+	currentPeerPublicKeys := make(map[string]string)
+	for _, node := range nodes {
+		if node.Name == c.nodeConfig.Name {
+			continue
+		}
+		if publicKey, ok := node.Annotations[wireguard.NodeWireGuardPublicKeyAnnotationKey]; ok {
+			currentPeerPublicKeys[node.Name] = publicKey
+		}
+	}
+	return c.wireGuardClient.RemoveStalePeers(currentPeerPublicKeys)
+}
+
 func (c *Controller) reconcile() error {
 	klog.Infof("Reconciliation for %s", controllerName)
 	// reconciliation consists of removing stale routes and stale / invalid tunnel ports:
@@ -244,6 +337,11 @@ func (c *Controller) reconcile() error {
 	if err := c.removeStaleTunnelPorts(); err != nil {
 		return fmt.Errorf("error when removing stale tunnel ports: %v", err)
 	}
+	if c.wireGuardClient != nil {
+		if err := c.removeStaleWireGuardPeers(); err != nil {
+			return fmt.Errorf("error when removing stale WireGuard peers: %v", err)
+		}
+	}
 	return nil
 }
 
@@ -264,7 +362,11 @@ func (c *Controller) Run(stopCh <-chan struct{}) {
 	defer klog.Infof("Shutting down %s", controllerName)
 
 	klog.Infof("Waiting for caches to sync for %s", controllerName)
-	if !cache.WaitForCacheSync(stopCh, c.nodeListerSynced) {
+	cacheSyncs := []cache.InformerSynced{c.nodeListerSynced}
+	if c.ipPoolListerSynced != nil {
+		cacheSyncs = append(cacheSyncs, c.ipPoolListerSynced)
+	}
+	if !cache.WaitForCacheSync(stopCh, cacheSyncs...) {
 		klog.Errorf("Unable to sync caches for %s", controllerName)
 		return
 	}
@@ -353,14 +455,22 @@ func (c *Controller) syncNodeRoute(nodeName string) error {
 func (c *Controller) deleteNodeRoute(nodeName string) error {
 	klog.Infof("Deleting routes and flows to Node %s", nodeName)
 
-	podCIDR, installed := c.installedNodes.Load(nodeName)
+	if c.wireGuardClient != nil {
+		if err := c.wireGuardClient.DeletePeer(nodeName); err != nil {
+			return err
+		}
+	}
+
+	podCIDRs, installed := c.installedNodes.Load(nodeName)
 	if !installed {
 		// Route is not added for this Node.
 		return nil
 	}
 
-	if err := c.routeClient.DeleteRoutes(podCIDR.(*net.IPNet)); err != nil {
-		return fmt.Errorf("failed to delete the route to Node %s: %v", nodeName, err)
+	for _, podCIDR := range podCIDRs.([]*net.IPNet) {
+		if err := c.routeClient.DeleteRoutes(podCIDR); err != nil {
+			return fmt.Errorf("failed to delete the route to Node %s: %v", nodeName, err)
+		}
 	}
 
 	if err := c.ofClient.UninstallNodeFlows(nodeName); err != nil {
@@ -385,30 +495,71 @@ func (c *Controller) deleteNodeRoute(nodeName string) error {
 }
 
 func (c *Controller) addNodeRoute(nodeName string, node *v1.Node) error {
-	if _, installed := c.installedNodes.Load(nodeName); installed {
-		// Route is already added for this Node.
-		return nil
+	// The WireGuard peer is updated even if the routes and flows are installed, as the public key
+	// of the Node can be published or changed afterwards.
+	if c.wireGuardClient != nil {
+		if err := c.updateWireGuardPeer(node); err != nil {
+			return err
+		}
 	}
 
-	klog.Infof("Adding routes and flows to Node %s, podCIDR: %s, addresses: %v",
-		nodeName, node.Spec.PodCIDR, node.Status.Addresses)
-
-	if node.Spec.PodCIDR == "" {
+	podCIDRStrs := GetPodCIDRs(node)
+	if len(podCIDRStrs) == 0 {
 		klog.Errorf("PodCIDR is empty for Node %s", nodeName)
 		// Does not help to return an error and trigger controller retries.
 		return nil
 	}
-	peerPodCIDRAddr, peerPodCIDR, err := net.ParseCIDR(node.Spec.PodCIDR)
-	if err != nil {
-		klog.Errorf("Failed to parse PodCIDR %s for Node %s", node.Spec.PodCIDR, nodeName)
-		return nil
+	// peerConfigs maps each Pod CIDR of the Node to the gateway IP in the CIDR.
+	peerConfigs := make(map[*net.IPNet]net.IP, len(podCIDRStrs))
+	var peerPodCIDRs []*net.IPNet
+	var peerGatewayIPv4 net.IP
+	for _, podCIDR := range podCIDRStrs {
+		peerPodCIDRAddr, peerPodCIDR, err := net.ParseCIDR(podCIDR)
+		if err != nil {
+			klog.Errorf("Failed to parse PodCIDR %s for Node %s", podCIDR, nodeName)
+			return nil
+		}
+		peerConfigs[peerPodCIDR] = ip.NextIP(peerPodCIDRAddr)
+		peerPodCIDRs = append(peerPodCIDRs, peerPodCIDR)
+		if peerPodCIDRAddr.To4() != nil {
+			peerGatewayIPv4 = peerConfigs[peerPodCIDR]
+		}
+	}
+	// The IPs allocated from IPPools to the Pods of the Node are routed like its IPv4 Pod CIDR.
+	if peerGatewayIPv4 != nil {
+		for _, poolPodCIDR := range c.getPoolPodCIDRs(nodeName) {
+			_, peerPodCIDR, _ := net.ParseCIDR(poolPodCIDR)
+			peerConfigs[peerPodCIDR] = peerGatewayIPv4
+			peerPodCIDRs = append(peerPodCIDRs, peerPodCIDR)
+		}
 	}
+
+	if installedPodCIDRs, installed := c.installedNodes.Load(nodeName); installed {
+		staleCIDRs := diffPodCIDRs(installedPodCIDRs.([]*net.IPNet), peerPodCIDRs)
+		if len(staleCIDRs) == 0 && len(installedPodCIDRs.([]*net.IPNet)) == len(peerPodCIDRs) {
+			// Route is already added for this Node.
+			return nil
+		}
+		// The IPs allocated from IPPools to the Pods of the Node have changed: the flows are
+		// installed again and the routes to the stale IPs are deleted.
+		for _, podCIDR := range staleCIDRs {
+			if err := c.routeClient.DeleteRoutes(podCIDR); err != nil {
+				return fmt.Errorf("failed to delete the route to Node %s: %v", nodeName, err)
+			}
+		}
+		if err := c.ofClient.UninstallNodeFlows(nodeName); err != nil {
+			return fmt.Errorf("failed to uninstall flows to Node %s: %v", nodeName, err)
+		}
+		c.installedNodes.Delete(nodeName)
+	}
+
+	klog.Infof("Adding routes and flows to Node %s, podCIDRs: %v, addresses: %v",
+		nodeName, peerPodCIDRs, node.Status.Addresses)
 	peerNodeIP, err := GetNodeAddr(node)
 	if err != nil {
 		klog.Errorf("Failed to retrieve IP address of Node %s: %v", nodeName, err)
 		return nil
 	}
-	peerGatewayIP := ip.NextIP(peerPodCIDRAddr)
 
 	ipsecTunOFPort := int32(0)
 	if c.networkConfig.EnableIPSecTunnel {
@@ -420,33 +571,83 @@ func (c *Controller) addNodeRoute(nodeName string, node *v1.Node) error {
 		}
 	}
 
+	tunOFPort := uint32(config.DefaultTunOFPort)
+	if c.wireGuardClient != nil {
+		// The traffic to the Node is sent to the host gateway and routed to the WireGuard device,
+		// instead of being encapsulated by the tunnel port.
+		tunOFPort = 0
+	}
 	err = c.ofClient.InstallNodeFlows(
 		nodeName,
 		c.nodeConfig.GatewayConfig.MAC,
-		*peerPodCIDR,
-		peerGatewayIP,
+		peerConfigs,
 		peerNodeIP,
-		config.DefaultTunOFPort,
+		tunOFPort,
 		uint32(ipsecTunOFPort))
 	if err != nil {
 		return fmt.Errorf("failed to install flows to Node %s: %v", nodeName, err)
 	}
 
-	if err := c.routeClient.AddRoutes(peerPodCIDR, peerNodeIP, peerGatewayIP); err != nil {
-		return err
+	for _, peerPodCIDR := range peerPodCIDRs {
+		if err := c.routeClient.AddRoutes(peerPodCIDR, peerNodeIP, peerConfigs[peerPodCIDR]); err != nil {
+			return err
+		}
 	}
-	c.installedNodes.Store(nodeName, peerPodCIDR)
+	c.installedNodes.Store(nodeName, peerPodCIDRs)
 	return err
 }
 
+// updateWireGuardPeer configures the WireGuard peer of the Node with the public key published in its
+// annotation, so that the traffic to the Pod CIDRs of the Node is encrypted and sent to the Node IP.
+func (c *Controller) updateWireGuardPeer(node *v1.Node) error {
+	publicKey, ok := node.Annotations[wireguard.NodeWireGuardPublicKeyAnnotationKey]
+	if !ok {
+		// The Node will be updated when its agent publishes the public key.
+		klog.Infof("WireGuard public key of Node %s is not available yet", node.Name)
+		return nil
+	}
+	peerNodeIP, err := GetNodeAddr(node)
+	if err != nil {
+		// The error is logged by addNodeRoute.
+		return nil
+	}
+	var podCIDRs []*net.IPNet
+	for _, podCIDR := range append(GetPodCIDRs(node), c.getPoolPodCIDRs(node.Name)...) {
+		if _, peerPodCIDR, err := net.ParseCIDR(podCIDR); err == nil {
+			podCIDRs = append(podCIDRs, peerPodCIDR)
+		}
+	}
+	return c.wireGuardClient.UpdatePeer(node.Name, publicKey, peerNodeIP, podCIDRs)
+}
+
+// ipsecTunnelCredentials returns the PSK and the remote name to set for the
+// IPSec tunnel to the Node, according to the IPSec authentication mode. With
+// certificate based authentication, the remote Node must present a certificate
+// whose common name is its Node name.
+func (c *Controller) ipsecTunnelCredentials(nodeName string) (string, string) {
+	if c.networkConfig.IPSecAuthenticationMode == config.IPSecAuthenticationModeCert {
+		return "", nodeName
+	}
+	return c.networkConfig.IPSecPSK, ""
+}
+
 // createIPSecTunnelPort creates an IPSec tunnel port for the remote Node if the
-// tunnel does not exist, and returns the ofport number.
+// tunnel does not exist, and returns the ofport number. If the tunnel exists but
+// the remote IP or the credentials have changed, the tunnel interface options
+// are updated in place.
 func (c *Controller) createIPSecTunnelPort(nodeName string, nodeIP net.IP) (int32, error) {
+	psk, remoteName := c.ipsecTunnelCredentials(nodeName)
 	interfaceConfig, ok := c.interfaceStore.GetNodeTunnelInterface(nodeName)
 	if ok {
-		// TODO: check if Node IP, PSK, or tunnel type changes. This can
-		// happen if removeStaleTunnelPorts fails to remove a "stale"
-		// tunnel port for which the configuration has changed.
+		if !interfaceConfig.RemoteIP.Equal(nodeIP) || interfaceConfig.PSK != psk || interfaceConfig.RemoteName != remoteName {
+			if err := c.ovsBridgeClient.SetTunnelInterfaceOptions(interfaceConfig.InterfaceName, "", nodeIP.String(), psk, remoteName); err != nil {
+				return 0, fmt.Errorf("failed to update IPSec tunnel port for Node %s: %v", nodeName, err)
+			}
+			klog.Infof("Updated IPSec tunnel port %s for Node %s", interfaceConfig.InterfaceName, nodeName)
+			interfaceConfig.RemoteIP = nodeIP
+			interfaceConfig.PSK = psk
+			interfaceConfig.RemoteName = remoteName
+		}
 		if interfaceConfig.OFPort != 0 {
 			return interfaceConfig.OFPort, nil
 		}
@@ -459,7 +660,8 @@ func (c *Controller) createIPSecTunnelPort(nodeName string, nodeIP net.IP) (int3
 			0, // ofPortRequest - let OVS allocate OFPort number.
 			"",
 			nodeIP.String(),
-			c.networkConfig.IPSecPSK,
+			psk,
+			remoteName,
 			ovsExternalIDs)
 		if err != nil {
 			return 0, fmt.Errorf("failed to create IPSec tunnel port for Node %s", nodeName)
@@ -472,7 +674,8 @@ func (c *Controller) createIPSecTunnelPort(nodeName string, nodeIP net.IP) (int3
 			c.networkConfig.TunnelType,
 			nodeName,
 			nodeIP,
-			c.networkConfig.IPSecPSK)
+			psk,
+			remoteName)
 		interfaceConfig.OVSPortConfig = ovsPortConfig
 		c.interfaceStore.AddInterface(interfaceConfig)
 	}
@@ -489,8 +692,9 @@ func (c *Controller) createIPSecTunnelPort(nodeName string, nodeIP net.IP) (int3
 }
 
 // ParseTunnelInterfaceConfig initializes and returns an InterfaceConfig struct
-// for a tunnel interface. It reads tunnel type, remote IP, IPSec PSK from the
-// OVS interface options, and NodeName from the OVS port external_ids.
+// for a tunnel interface. It reads tunnel type, remote IP, IPSec PSK and IPSec
+// remote name from the OVS interface options, and NodeName from the OVS port
+// external_ids.
 // nil is returned, if the OVS port and interface configurations are not valid
 // for a tunnel interface.
 func ParseTunnelInterfaceConfig(
@@ -500,20 +704,21 @@ func ParseTunnelInterfaceConfig(
 		klog.V(2).Infof("OVS port %s has no options", portData.Name)
 		return nil
 	}
-	remoteIP, localIP, psk := ovsconfig.ParseTunnelInterfaceOptions(portData)
+	remoteIP, localIP, psk, remoteName := ovsconfig.ParseTunnelInterfaceOptions(portData)
 
 	var interfaceConfig *interfacestore.InterfaceConfig
 	var nodeName string
 	if portData.ExternalIDs != nil {
 		nodeName = portData.ExternalIDs[ovsExternalIDNodeName]
 	}
-	if psk != "" {
+	if psk != "" || remoteName != "" {
 		interfaceConfig = interfacestore.NewIPSecTunnelInterface(
 			portData.Name,
 			ovsconfig.TunnelType(portData.IFType),
 			nodeName,
 			remoteIP,
-			psk)
+			psk,
+			remoteName)
 	} else {
 		interfaceConfig = interfacestore.NewTunnelInterface(portData.Name, ovsconfig.TunnelType(portData.IFType), localIP)
 	}
@@ -542,3 +747,31 @@ func GetNodeAddr(node *v1.Node) (net.IP, error) {
 	}
 	return ipAddr, nil
 }
+
+// diffPodCIDRs returns the CIDRs of installed which are not in desired.
+func diffPodCIDRs(installed, desired []*net.IPNet) []*net.IPNet {
+	desiredSet := make(map[string]bool, len(desired))
+	for _, podCIDR := range desired {
+		desiredSet[podCIDR.String()] = true
+	}
+	var stale []*net.IPNet
+	for _, podCIDR := range installed {
+		if !desiredSet[podCIDR.String()] {
+			stale = append(stale, podCIDR)
+		}
+	}
+	return stale
+}
+
+// GetPodCIDRs returns the Pod CIDRs allocated to a Node, at most one for each IP family.
+// Spec.PodCIDRs is used if it is set, otherwise Spec.PodCIDR is used. An empty list is
+// returned if no Pod CIDR is allocated to the Node yet.
+func GetPodCIDRs(node *v1.Node) []string {
+	if len(node.Spec.PodCIDRs) > 0 {
+		return node.Spec.PodCIDRs
+	}
+	if node.Spec.PodCIDR != "" {
+		return []string{node.Spec.PodCIDR}
+	}
+	return nil
+}
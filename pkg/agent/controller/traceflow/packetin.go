@@ -0,0 +1,230 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package traceflow
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/contiv/libOpenflow/openflow13"
+	"github.com/contiv/libOpenflow/protocol"
+	"github.com/contiv/ofnet/ofctrl"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+	opsv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/ops/v1alpha1"
+	binding "github.com/vmware-tanzu/antrea/pkg/ovs/openflow"
+)
+
+const (
+	protocolICMP = 1
+	tcpFlagSYN   = 0x2
+)
+
+var (
+	egressDropTables = map[binding.TableIDType]string{
+		openflow.CNPEgressRuleTable: "ClusterNetworkPolicyEgressRule",
+		openflow.EgressRuleTable:    "EgressRule",
+		openflow.EgressDefaultTable: "EgressDefaultRule",
+	}
+	ingressDropTables = map[binding.TableIDType]string{
+		openflow.CNPIngressRuleTable: "ClusterNetworkPolicyIngressRule",
+		openflow.IngressRuleTable:    "IngressRule",
+		openflow.IngressDefaultTable: "IngressDefaultRule",
+	}
+)
+
+// handlePacketIn processes the PacketIn messages of the probe packets until stopCh is closed.
+func (c *Controller) handlePacketIn(stopCh <-chan struct{}) {
+	for {
+		select {
+		case pktIn := <-c.packetInCh:
+			if err := c.processPacketIn(pktIn); err != nil {
+				klog.Errorf("Failed to process Traceflow PacketIn message: %v", err)
+			}
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// processPacketIn parses the provided PacketIn message and adds the observations of this Node
+// to the status of the corresponding Traceflow.
+func (c *Controller) processPacketIn(pktIn *ofctrl.PacketIn) error {
+	tag, ipPacket, err := parseIPPacket(pktIn)
+	if err != nil {
+		return err
+	}
+	c.runningTraceflowsMutex.Lock()
+	rtf, ok := c.runningTraceflows[tag]
+	var name string
+	var sender bool
+	if ok {
+		name, sender = rtf.name, rtf.sender
+	}
+	c.runningTraceflowsMutex.Unlock()
+	if !ok {
+		klog.V(2).Infof("Ignoring PacketIn message with unknown dataplane tag %d", tag)
+		return nil
+	}
+
+	nodeResult := c.buildNodeResult(pktIn, ipPacket, sender)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		tf, err := c.traceflowClient.OpsV1alpha1().Traceflows().Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if tf.Status.Phase != opsv1alpha1.Running || tf.Status.DataplaneTag != tag {
+			return nil
+		}
+		toUpdate := tf.DeepCopy()
+		updated := false
+		for i := range toUpdate.Status.Results {
+			if toUpdate.Status.Results[i].Node == nodeResult.Node {
+				toUpdate.Status.Results[i] = *nodeResult
+				updated = true
+			}
+		}
+		if !updated {
+			toUpdate.Status.Results = append(toUpdate.Status.Results, *nodeResult)
+		}
+		_, err = c.traceflowClient.OpsV1alpha1().Traceflows().UpdateStatus(toUpdate)
+		return err
+	})
+}
+
+// parseIPPacket returns the dataplane tag and the IPv4 packet carried by the provided PacketIn
+// message.
+func parseIPPacket(pktIn *ofctrl.PacketIn) (uint8, *protocol.IPv4, error) {
+	if pktIn.Data.Ethertype != protocol.IPv4_MSG {
+		return 0, nil, fmt.Errorf("unsupported ethertype 0x%x", pktIn.Data.Ethertype)
+	}
+	ipPacket, ok := pktIn.Data.Data.(*protocol.IPv4)
+	if !ok {
+		return 0, nil, errors.New("invalid IPv4 packet")
+	}
+	return ipPacket.DSCP, ipPacket, nil
+}
+
+// getMatchTunnelDstField returns the tunnel destination IP in the match of a PacketIn message,
+// or an empty string if it's not set.
+func getMatchTunnelDstField(match *openflow13.Match) string {
+	for _, field := range match.Fields {
+		if field.Class == openflow13.OXM_CLASS_NXM_1 && field.Field == openflow13.NXM_NX_TUN_IPV4_DST {
+			if value, ok := field.Value.(*openflow13.TunnelIpv4DstField); ok {
+				return value.TunnelIpv4Dst.String()
+			}
+		}
+	}
+	return ""
+}
+
+// getNetworkPolicyName returns the name of the NetworkPolicy which the rule with the provided
+// conjunction ID belongs to, prefixed with its Namespace if any.
+func (c *Controller) getNetworkPolicyName(conjID uint32) string {
+	npName, npNamespace := c.ofClient.GetPolicyFromConjunction(conjID)
+	if npNamespace == "" {
+		return npName
+	}
+	return npNamespace + "/" + npName
+}
+
+// buildNodeResult builds the observations of this Node from the provided PacketIn message. The
+// packet is sent to the controller either when it's dropped by a NetworkPolicy table, or when
+// it's output by the L2ForwardingOut table.
+func (c *Controller) buildNodeResult(pktIn *ofctrl.PacketIn, ipPacket *protocol.IPv4, sender bool) *opsv1alpha1.NodeResult {
+	nodeResult := &opsv1alpha1.NodeResult{
+		Node:      c.nodeConfig.Name,
+		Timestamp: time.Now().Unix(),
+	}
+	var obs []opsv1alpha1.Observation
+	if sender {
+		nodeResult.Role = opsv1alpha1.RoleSender
+		obs = append(obs, opsv1alpha1.Observation{
+			Component: opsv1alpha1.SpoofGuard,
+			Action:    opsv1alpha1.Forwarded,
+		})
+	} else {
+		nodeResult.Role = opsv1alpha1.RoleReceiver
+		obs = append(obs, opsv1alpha1.Observation{
+			Component: opsv1alpha1.Forwarding,
+			Action:    opsv1alpha1.Received,
+		})
+	}
+
+	tableID := binding.TableIDType(pktIn.TableId)
+	egressConjID := openflow.GetMatchRegField(&pktIn.Match, openflow.EgressReg)
+	ingressConjID := openflow.GetMatchRegField(&pktIn.Match, openflow.IngressReg)
+	if info, ok := egressDropTables[tableID]; ok {
+		ob := opsv1alpha1.Observation{Component: opsv1alpha1.NetworkPolicy, ComponentInfo: info, Action: opsv1alpha1.Dropped}
+		if egressConjID != 0 {
+			ob.NetworkPolicy = c.getNetworkPolicyName(egressConjID)
+		}
+		nodeResult.Observations = append(obs, ob)
+		return nodeResult
+	}
+	if egressConjID != 0 {
+		obs = append(obs, opsv1alpha1.Observation{
+			Component:     opsv1alpha1.NetworkPolicy,
+			ComponentInfo: egressDropTables[openflow.EgressRuleTable],
+			Action:        opsv1alpha1.Forwarded,
+			NetworkPolicy: c.getNetworkPolicyName(egressConjID),
+		})
+	}
+	if info, ok := ingressDropTables[tableID]; ok {
+		ob := opsv1alpha1.Observation{Component: opsv1alpha1.NetworkPolicy, ComponentInfo: info, Action: opsv1alpha1.Dropped}
+		if ingressConjID != 0 {
+			ob.NetworkPolicy = c.getNetworkPolicyName(ingressConjID)
+		}
+		nodeResult.Observations = append(obs, ob)
+		return nodeResult
+	}
+	if ingressConjID != 0 {
+		obs = append(obs, opsv1alpha1.Observation{
+			Component:     opsv1alpha1.NetworkPolicy,
+			ComponentInfo: ingressDropTables[openflow.IngressRuleTable],
+			Action:        opsv1alpha1.Forwarded,
+			NetworkPolicy: c.getNetworkPolicyName(ingressConjID),
+		})
+	}
+
+	ob := opsv1alpha1.Observation{
+		Component:     opsv1alpha1.Forwarding,
+		ComponentInfo: "Output",
+		Action:        opsv1alpha1.Forwarded,
+		DstMAC:        pktIn.Data.HWDst.String(),
+		TTL:           int32(ipPacket.TTL),
+	}
+	if tunnelDst := getMatchTunnelDstField(&pktIn.Match); tunnelDst != "" {
+		ob.TunnelDstIP = tunnelDst
+	} else if iface := c.getContainerInterfaceByOFPort(openflow.GetMatchRegField(&pktIn.Match, openflow.PortCacheReg)); iface != nil {
+		ob.Action = opsv1alpha1.Delivered
+		ob.Pod = iface.PodNamespace + "/" + iface.PodName
+	}
+	nodeResult.Observations = append(obs, ob)
+	return nodeResult
+}
+
+func (c *Controller) getContainerInterfaceByOFPort(ofPort uint32) *interfacestore.InterfaceConfig {
+	for _, iface := range c.interfaceStore.GetInterfacesByType(interfacestore.ContainerInterface) {
+		if iface.OVSPortConfig != nil && uint32(iface.OFPort) == ofPort {
+			return iface
+		}
+	}
+	return nil
+}
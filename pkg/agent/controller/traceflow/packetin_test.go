@@ -0,0 +1,137 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package traceflow
+
+import (
+	"net"
+	"testing"
+
+	"github.com/contiv/libOpenflow/openflow13"
+	"github.com/contiv/libOpenflow/protocol"
+	"github.com/contiv/ofnet/ofctrl"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/config"
+	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+	openflowtest "github.com/vmware-tanzu/antrea/pkg/agent/openflow/testing"
+	opsv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/ops/v1alpha1"
+	binding "github.com/vmware-tanzu/antrea/pkg/ovs/openflow"
+)
+
+func newPacketIn(tag uint8, tableID binding.TableIDType, regs map[int]uint32, tunnelDst net.IP) *ofctrl.PacketIn {
+	dstMAC, _ := net.ParseMAC("aa:bb:cc:dd:ee:ff")
+	pktIn := &ofctrl.PacketIn{
+		TableId: uint8(tableID),
+		Match:   *openflow13.NewMatch(),
+		Data: protocol.Ethernet{
+			HWDst:     dstMAC,
+			Ethertype: protocol.IPv4_MSG,
+			Data:      &protocol.IPv4{DSCP: tag, TTL: 63},
+		},
+	}
+	for reg, value := range regs {
+		pktIn.Match.AddField(openflow13.MatchField{
+			Class: openflow13.OXM_CLASS_NXM_1,
+			Field: uint8(openflow13.NXM_NX_REG0 + reg),
+			Value: &openflow13.Uint32Message{Data: value},
+		})
+	}
+	if tunnelDst != nil {
+		pktIn.Match.AddField(*openflow13.NewTunnelIpv4DstField(tunnelDst, nil))
+	}
+	return pktIn
+}
+
+func TestBuildNodeResult(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ofClient := openflowtest.NewMockClient(ctrl)
+	ofClient.EXPECT().GetPolicyFromConjunction(uint32(10)).Return("np1", "ns1").AnyTimes()
+	ofClient.EXPECT().GetPolicyFromConjunction(uint32(20)).Return("cnp1", "").AnyTimes()
+
+	ifaceStore := interfacestore.NewInterfaceStore()
+	podIface := interfacestore.NewContainerInterface("pod2-abcd", "c1", "pod2", "ns2", nil, []net.IP{net.ParseIP("10.10.0.2")})
+	podIface.OVSPortConfig = &interfacestore.OVSPortConfig{OFPort: 5}
+	ifaceStore.AddInterface(podIface)
+	c := &Controller{
+		ofClient:       ofClient,
+		interfaceStore: ifaceStore,
+		nodeConfig:     &config.NodeConfig{Name: "node1"},
+	}
+
+	tests := []struct {
+		name                 string
+		pktIn                *ofctrl.PacketIn
+		sender               bool
+		expectedRole         opsv1alpha1.TraceflowNodeRole
+		expectedObservations []opsv1alpha1.Observation
+	}{
+		{
+			name:         "dropped by egress default rule",
+			pktIn:        newPacketIn(1, openflow.EgressDefaultTable, nil, nil),
+			sender:       true,
+			expectedRole: opsv1alpha1.RoleSender,
+			expectedObservations: []opsv1alpha1.Observation{
+				{Component: opsv1alpha1.SpoofGuard, Action: opsv1alpha1.Forwarded},
+				{Component: opsv1alpha1.NetworkPolicy, ComponentInfo: "EgressDefaultRule", Action: opsv1alpha1.Dropped},
+			},
+		},
+		{
+			name:         "dropped by ClusterNetworkPolicy ingress rule",
+			pktIn:        newPacketIn(1, openflow.CNPIngressRuleTable, map[int]uint32{openflow.IngressReg: 20}, nil),
+			sender:       false,
+			expectedRole: opsv1alpha1.RoleReceiver,
+			expectedObservations: []opsv1alpha1.Observation{
+				{Component: opsv1alpha1.Forwarding, Action: opsv1alpha1.Received},
+				{Component: opsv1alpha1.NetworkPolicy, ComponentInfo: "ClusterNetworkPolicyIngressRule", Action: opsv1alpha1.Dropped, NetworkPolicy: "cnp1"},
+			},
+		},
+		{
+			name:         "tunneled to remote Node",
+			pktIn:        newPacketIn(1, openflow.L2ForwardingOutTable, map[int]uint32{openflow.EgressReg: 10, openflow.PortCacheReg: 1}, net.ParseIP("192.168.1.2")),
+			sender:       true,
+			expectedRole: opsv1alpha1.RoleSender,
+			expectedObservations: []opsv1alpha1.Observation{
+				{Component: opsv1alpha1.SpoofGuard, Action: opsv1alpha1.Forwarded},
+				{Component: opsv1alpha1.NetworkPolicy, ComponentInfo: "EgressRule", Action: opsv1alpha1.Forwarded, NetworkPolicy: "ns1/np1"},
+				{Component: opsv1alpha1.Forwarding, ComponentInfo: "Output", Action: opsv1alpha1.Forwarded, DstMAC: "aa:bb:cc:dd:ee:ff", TTL: 63, TunnelDstIP: "192.168.1.2"},
+			},
+		},
+		{
+			name:         "delivered to local Pod",
+			pktIn:        newPacketIn(1, openflow.L2ForwardingOutTable, map[int]uint32{openflow.IngressReg: 10, openflow.PortCacheReg: 5}, nil),
+			sender:       false,
+			expectedRole: opsv1alpha1.RoleReceiver,
+			expectedObservations: []opsv1alpha1.Observation{
+				{Component: opsv1alpha1.Forwarding, Action: opsv1alpha1.Received},
+				{Component: opsv1alpha1.NetworkPolicy, ComponentInfo: "IngressRule", Action: opsv1alpha1.Forwarded, NetworkPolicy: "ns1/np1"},
+				{Component: opsv1alpha1.Forwarding, ComponentInfo: "Output", Action: opsv1alpha1.Delivered, DstMAC: "aa:bb:cc:dd:ee:ff", TTL: 63, Pod: "ns2/pod2"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag, ipPacket, err := parseIPPacket(tt.pktIn)
+			require.NoError(t, err)
+			assert.Equal(t, uint8(1), tag)
+			result := c.buildNodeResult(tt.pktIn, ipPacket, tt.sender)
+			assert.Equal(t, "node1", result.Node)
+			assert.Equal(t, tt.expectedRole, result.Role)
+			assert.Equal(t, tt.expectedObservations, result.Observations)
+		})
+	}
+}
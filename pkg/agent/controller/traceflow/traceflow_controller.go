@@ -0,0 +1,329 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package traceflow
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/contiv/ofnet/ofctrl"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/config"
+	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+	opsv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/ops/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned"
+	crdinformers "github.com/vmware-tanzu/antrea/pkg/client/informers/externalversions"
+	opslisters "github.com/vmware-tanzu/antrea/pkg/client/listers/ops/v1alpha1"
+	binding "github.com/vmware-tanzu/antrea/pkg/ovs/openflow"
+	"github.com/vmware-tanzu/antrea/pkg/util/ip"
+)
+
+const (
+	controllerName = "AntreaAgentTraceflowController"
+	// How long to wait before retrying the processing of a Traceflow.
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = 300 * time.Second
+	// Default number of workers processing a Traceflow.
+	defaultWorkers = 4
+	// Size of the buffer of the PacketIn channel.
+	packetInChanSize = 100
+
+	defaultTTL = 64
+)
+
+// runningTraceflow records the state of a Traceflow being traced on this Node.
+type runningTraceflow struct {
+	name string
+	// sender is true if the source Pod of the Traceflow runs on this Node.
+	sender bool
+	// injected is true if the probe packet has been sent.
+	injected bool
+}
+
+// Controller is responsible for installing the Traceflow flows on this Node, injecting the
+// probe packets from the local Pods, and reporting the observations made by the local OVS
+// pipeline.
+type Controller struct {
+	kubeClient            clientset.Interface
+	traceflowClient       versioned.Interface
+	traceflowLister       opslisters.TraceflowLister
+	traceflowListerSynced cache.InformerSynced
+	ofClient              openflow.Client
+	interfaceStore        interfacestore.InterfaceStore
+	nodeConfig            *config.NodeConfig
+	queue                 workqueue.RateLimitingInterface
+	packetInCh            chan *ofctrl.PacketIn
+	// runningTraceflows is a map from a dataplane tag to the Traceflow using it.
+	runningTraceflowsMutex sync.Mutex
+	runningTraceflows      map[uint8]*runningTraceflow
+}
+
+// NewTraceflowController instantiates a new Controller object which will process Traceflow
+// events and the PacketIn messages of the probe packets.
+func NewTraceflowController(
+	kubeClient clientset.Interface,
+	traceflowClient versioned.Interface,
+	crdInformerFactory crdinformers.SharedInformerFactory,
+	client openflow.Client,
+	interfaceStore interfacestore.InterfaceStore,
+	nodeConfig *config.NodeConfig) *Controller {
+	traceflowInformer := crdInformerFactory.Ops().V1alpha1().Traceflows()
+	c := &Controller{
+		kubeClient:            kubeClient,
+		traceflowClient:       traceflowClient,
+		traceflowLister:       traceflowInformer.Lister(),
+		traceflowListerSynced: traceflowInformer.Informer().HasSynced,
+		ofClient:              client,
+		interfaceStore:        interfaceStore,
+		nodeConfig:            nodeConfig,
+		queue:                 workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "traceflow"),
+		packetInCh:            make(chan *ofctrl.PacketIn, packetInChanSize),
+		runningTraceflows:     make(map[uint8]*runningTraceflow),
+	}
+	traceflowInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.addTraceflow,
+			UpdateFunc: c.updateTraceflow,
+			DeleteFunc: c.deleteTraceflow,
+		},
+	)
+	return c
+}
+
+// Run will create defaultWorkers workers (go routines) which will process the Traceflow events
+// from the workqueue, and a go routine which will process the PacketIn messages.
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+
+	klog.Infof("Starting %s", controllerName)
+	defer klog.Infof("Shutting down %s", controllerName)
+
+	if !cache.WaitForNamedCacheSync(controllerName, stopCh, c.traceflowListerSynced) {
+		return
+	}
+
+	if err := c.ofClient.SubscribePacketIn(uint8(openflow.PacketInReasonTF), c.packetInCh); err != nil {
+		klog.Errorf("Failed to subscribe to Traceflow PacketIn messages: %v", err)
+		return
+	}
+	go wait.Until(func() { c.handlePacketIn(stopCh) }, time.Second, stopCh)
+
+	for i := 0; i < defaultWorkers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+	<-stopCh
+}
+
+func (c *Controller) addTraceflow(obj interface{}) {
+	tf := obj.(*opsv1alpha1.Traceflow)
+	klog.V(2).Infof("Processing Traceflow %s ADD event", tf.Name)
+	c.queue.Add(tf.Name)
+}
+
+func (c *Controller) updateTraceflow(_, curObj interface{}) {
+	tf := curObj.(*opsv1alpha1.Traceflow)
+	klog.V(2).Infof("Processing Traceflow %s UPDATE event", tf.Name)
+	c.queue.Add(tf.Name)
+}
+
+func (c *Controller) deleteTraceflow(old interface{}) {
+	tf, ok := old.(*opsv1alpha1.Traceflow)
+	if !ok {
+		tombstone, ok := old.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("Error decoding object when deleting Traceflow, invalid type: %v", old)
+			return
+		}
+		tf, ok = tombstone.Obj.(*opsv1alpha1.Traceflow)
+		if !ok {
+			klog.Errorf("Error decoding object tombstone when deleting Traceflow, invalid type: %v", tombstone.Obj)
+			return
+		}
+	}
+	klog.V(2).Infof("Processing Traceflow %s DELETE event", tf.Name)
+	c.queue.Add(tf.Name)
+}
+
+func (c *Controller) worker() {
+	for c.processTraceflowItem() {
+	}
+}
+
+func (c *Controller) processTraceflowItem() bool {
+	obj, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	// We call Done here so the workqueue knows we have finished processing this item. We also
+	// must remember to call Forget if we do not want this work item being re-queued.
+	defer c.queue.Done(obj)
+
+	if key, ok := obj.(string); !ok {
+		c.queue.Forget(obj)
+		klog.Errorf("Expected string in work queue but got %#v", obj)
+		return true
+	} else if err := c.syncTraceflow(key); err == nil {
+		c.queue.Forget(key)
+	} else {
+		c.queue.AddRateLimited(key)
+		klog.Errorf("Error syncing Traceflow %s, requeuing. Error: %v", key, err)
+	}
+	return true
+}
+
+func (c *Controller) syncTraceflow(name string) error {
+	startTime := time.Now()
+	defer func() {
+		klog.V(4).Infof("Finished syncing Traceflow for %s. (%v)", name, time.Since(startTime))
+	}()
+
+	tf, err := c.traceflowLister.Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return c.cleanupTraceflow(name)
+		}
+		return err
+	}
+	if tf.Status.Phase != opsv1alpha1.Running || tf.Status.DataplaneTag == 0 {
+		return c.cleanupTraceflow(name)
+	}
+	return c.startTraceflow(tf)
+}
+
+// startTraceflow installs the flows for the dataplane tag of the provided Traceflow and
+// injects the probe packet if the source Pod runs on this Node.
+func (c *Controller) startTraceflow(tf *opsv1alpha1.Traceflow) error {
+	c.runningTraceflowsMutex.Lock()
+	defer c.runningTraceflowsMutex.Unlock()
+
+	tag := tf.Status.DataplaneTag
+	rtf, exists := c.runningTraceflows[tag]
+	if exists && rtf.name != tf.Name {
+		// The tag was used by another Traceflow which has not been cleaned up yet.
+		if err := c.ofClient.UninstallTraceflowFlows(tag); err != nil {
+			return err
+		}
+		delete(c.runningTraceflows, tag)
+		exists = false
+	}
+	if !exists {
+		if err := c.ofClient.InstallTraceflowFlows(tag); err != nil {
+			return err
+		}
+		_, sender := c.interfaceStore.GetContainerInterface(tf.Spec.Source.Pod, tf.Spec.Source.Namespace)
+		rtf = &runningTraceflow{name: tf.Name, sender: sender}
+		c.runningTraceflows[tag] = rtf
+	}
+	if !rtf.sender || rtf.injected {
+		return nil
+	}
+	if err := c.injectPacket(tf); err != nil {
+		return err
+	}
+	rtf.injected = true
+	return nil
+}
+
+// cleanupTraceflow removes the flows installed for the provided Traceflow.
+func (c *Controller) cleanupTraceflow(name string) error {
+	c.runningTraceflowsMutex.Lock()
+	defer c.runningTraceflowsMutex.Unlock()
+	for tag, rtf := range c.runningTraceflows {
+		if rtf.name != name {
+			continue
+		}
+		if err := c.ofClient.UninstallTraceflowFlows(tag); err != nil {
+			return err
+		}
+		delete(c.runningTraceflows, tag)
+	}
+	return nil
+}
+
+// injectPacket sends the probe packet of the provided Traceflow into the OVS pipeline from the
+// port of the source Pod.
+func (c *Controller) injectPacket(tf *opsv1alpha1.Traceflow) error {
+	srcIface, ok := c.interfaceStore.GetContainerInterface(tf.Spec.Source.Pod, tf.Spec.Source.Namespace)
+	if !ok {
+		return fmt.Errorf("source Pod %s/%s not found on this Node", tf.Spec.Source.Namespace, tf.Spec.Source.Pod)
+	}
+	// The probe packet is sent to the gateway like any packet sent by the Pod to another
+	// subnet, unless the destination is a Pod of the same Node.
+	dstMAC := c.nodeConfig.GatewayConfig.MAC
+	var dstIP net.IP
+	if tf.Spec.Destination.IP != "" {
+		dstIP = net.ParseIP(tf.Spec.Destination.IP)
+		if dstIP == nil || dstIP.To4() == nil {
+			return fmt.Errorf("invalid destination IP %s", tf.Spec.Destination.IP)
+		}
+	} else if dstIface, ok := c.interfaceStore.GetContainerInterface(tf.Spec.Destination.Pod, tf.Spec.Destination.Namespace); ok {
+		dstIP = ip.GetIPv4Addr(dstIface.IPs)
+		dstMAC = dstIface.MAC
+	} else {
+		dstPod, err := c.kubeClient.CoreV1().Pods(tf.Spec.Destination.Namespace).Get(tf.Spec.Destination.Pod, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get destination Pod %s/%s: %v", tf.Spec.Destination.Namespace, tf.Spec.Destination.Pod, err)
+		}
+		if dstIP = net.ParseIP(dstPod.Status.PodIP); dstIP == nil {
+			return fmt.Errorf("destination Pod %s/%s has no IP", tf.Spec.Destination.Namespace, tf.Spec.Destination.Pod)
+		}
+	}
+
+	packet := &binding.Packet{
+		SourceMAC:      srcIface.MAC,
+		DestinationMAC: dstMAC,
+		SourceIP:       ip.GetIPv4Addr(srcIface.IPs),
+		DestinationIP:  dstIP,
+		IPProto:        uint8(tf.Spec.Packet.IPHeader.Protocol),
+		IPFlags:        uint16(tf.Spec.Packet.IPHeader.Flags),
+		TTL:            uint8(tf.Spec.Packet.IPHeader.TTL),
+	}
+	if packet.IPProto == 0 {
+		packet.IPProto = protocolICMP
+	}
+	if packet.TTL == 0 {
+		packet.TTL = defaultTTL
+	}
+	header := tf.Spec.Packet.TransportHeader
+	switch {
+	case header.ICMP != nil:
+		packet.ICMPEchoID = uint16(header.ICMP.ID)
+		packet.ICMPEchoSeq = uint16(header.ICMP.Sequence)
+	case header.TCP != nil:
+		packet.SourcePort = uint16(header.TCP.SrcPort)
+		packet.DestinationPort = uint16(header.TCP.DstPort)
+		packet.TCPFlags = uint8(header.TCP.Flags)
+		if packet.TCPFlags == 0 {
+			// Use a SYN packet by default so that it's considered as a new connection.
+			packet.TCPFlags = tcpFlagSYN
+		}
+	case header.UDP != nil:
+		packet.SourcePort = uint16(header.UDP.SrcPort)
+		packet.DestinationPort = uint16(header.UDP.DstPort)
+	}
+	if srcIface.OVSPortConfig == nil {
+		return fmt.Errorf("source Pod %s/%s has no OVS port", tf.Spec.Source.Namespace, tf.Spec.Source.Pod)
+	}
+	return c.ofClient.SendTraceflowPacket(tf.Status.DataplaneTag, packet, uint32(srcIface.OFPort))
+}
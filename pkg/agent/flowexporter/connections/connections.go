@@ -0,0 +1,164 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connections
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter"
+	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+)
+
+const podIPIndex = "podIP"
+
+// ConnectionStore polls the connections of the Pods from the conntrack zone used by Antrea, and
+// adds the Kubernetes context to them. The local Pods are looked up in the interface store, and
+// the Pods running on other Nodes with the Pod informer.
+type ConnectionStore struct {
+	connTrackDumper ConnTrackDumper
+	ifaceStore      interfacestore.InterfaceStore
+	podIndexer      cache.Indexer
+	nodeName        string
+	mutex           sync.Mutex
+	connections     map[flowexporter.ConnectionKey]*flowexporter.Connection
+}
+
+// NewConnectionStore returns a new *ConnectionStore. It adds an index of the Pods by IP to the
+// provided Pod informer, so it must be called before the informer is started.
+func NewConnectionStore(connTrackDumper ConnTrackDumper, ifaceStore interfacestore.InterfaceStore, podInformer coreinformers.PodInformer, nodeName string) (*ConnectionStore, error) {
+	if err := podInformer.Informer().AddIndexers(cache.Indexers{podIPIndex: podIPIndexFunc}); err != nil {
+		return nil, err
+	}
+	return &ConnectionStore{
+		connTrackDumper: connTrackDumper,
+		ifaceStore:      ifaceStore,
+		podIndexer:      podInformer.Informer().GetIndexer(),
+		nodeName:        nodeName,
+		connections:     make(map[flowexporter.ConnectionKey]*flowexporter.Connection),
+	}, nil
+}
+
+// podIPIndexFunc indexes the Pods by their IPs. The Pods in the host network are not indexed,
+// as they share the IPs of their Nodes.
+func podIPIndexFunc(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil, fmt.Errorf("obj is not Pod: %+v", obj)
+	}
+	if pod.Spec.HostNetwork {
+		return nil, nil
+	}
+	var ips []string
+	for _, podIP := range pod.Status.PodIPs {
+		ips = append(ips, podIP.IP)
+	}
+	if len(ips) == 0 && pod.Status.PodIP != "" {
+		ips = append(ips, pod.Status.PodIP)
+	}
+	return ips, nil
+}
+
+// Poll dumps the connections of the conntrack zone and updates the store with them. New
+// connections are added with the Kubernetes context of their endpoints, the counters of the
+// existing ones are updated, and the connections which are no longer in the zone are marked as
+// inactive.
+func (cs *ConnectionStore) Poll() error {
+	conns, err := cs.connTrackDumper.DumpFlows(openflow.CtZone)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	polled := make(map[flowexporter.ConnectionKey]bool, len(conns))
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	for _, conn := range conns {
+		key := flowexporter.NewConnectionKey(conn)
+		polled[key] = true
+		if existing, ok := cs.connections[key]; ok {
+			existing.StopTime = now
+			existing.IsActive = true
+			existing.OriginalPackets, existing.OriginalBytes = conn.OriginalPackets, conn.OriginalBytes
+			existing.ReversePackets, existing.ReverseBytes = conn.ReversePackets, conn.ReverseBytes
+			continue
+		}
+		// The start time is only provided by the dumper when conntrack timestamps are enabled.
+		if conn.StartTime.IsZero() {
+			conn.StartTime = now
+		}
+		conn.StopTime = now
+		conn.SourcePodNamespace, conn.SourcePodName, conn.SourceNodeName = cs.lookupPod(conn.TupleOrig.SourceAddress)
+		conn.DestinationPodNamespace, conn.DestinationPodName, conn.DestinationNodeName = cs.lookupPod(conn.TupleReply.SourceAddress)
+		cs.connections[key] = conn
+	}
+	for key, conn := range cs.connections {
+		if !polled[key] {
+			conn.IsActive = false
+		}
+	}
+	klog.V(2).Infof("Polled %d connections from conntrack zone %d, %d connections in store", len(conns), openflow.CtZone, len(cs.connections))
+	return nil
+}
+
+// lookupPod returns the Namespace, name and Node of the Pod with the provided IP, or empty
+// strings if it's not a Pod IP.
+func (cs *ConnectionStore) lookupPod(ip net.IP) (string, string, string) {
+	for _, iface := range cs.ifaceStore.GetInterfacesByType(interfacestore.ContainerInterface) {
+		for _, ifaceIP := range iface.IPs {
+			if ifaceIP.Equal(ip) {
+				return iface.PodNamespace, iface.PodName, cs.nodeName
+			}
+		}
+	}
+	objs, err := cs.podIndexer.ByIndex(podIPIndex, ip.String())
+	if err != nil || len(objs) == 0 {
+		return "", "", ""
+	}
+	pod := objs[0].(*corev1.Pod)
+	return pod.Namespace, pod.Name, pod.Spec.NodeName
+}
+
+// ForAllConnectionsDo calls the provided function for each connection in the store, until it
+// returns an error.
+func (cs *ConnectionStore) ForAllConnectionsDo(fn func(key flowexporter.ConnectionKey, conn *flowexporter.Connection) error) error {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	for key, conn := range cs.connections {
+		if err := fn(key, conn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteInactiveConnections removes the connections which are no longer in the conntrack zone.
+// It must be called after their last records are exported.
+func (cs *ConnectionStore) DeleteInactiveConnections() {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	for key, conn := range cs.connections {
+		if !conn.IsActive {
+			delete(cs.connections, key)
+		}
+	}
+}
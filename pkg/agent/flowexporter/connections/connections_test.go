@@ -0,0 +1,130 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connections
+
+import (
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter"
+	connectionstest "github.com/vmware-tanzu/antrea/pkg/agent/flowexporter/connections/testing"
+	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+)
+
+func newTestConnection(proto uint8, srcIP, dstIP string, srcPort, dstPort uint16, packets, bytes, reversePackets, reverseBytes uint64) *flowexporter.Connection {
+	return &flowexporter.Connection{
+		Zone:            openflow.CtZone,
+		IsActive:        true,
+		TupleOrig:       flowexporter.Tuple{SourceAddress: net.ParseIP(srcIP), DestinationAddress: net.ParseIP(dstIP), Protocol: proto, SourcePort: srcPort, DestinationPort: dstPort},
+		TupleReply:      flowexporter.Tuple{SourceAddress: net.ParseIP(dstIP), DestinationAddress: net.ParseIP(srcIP), Protocol: proto, SourcePort: dstPort, DestinationPort: srcPort},
+		OriginalPackets: packets,
+		OriginalBytes:   bytes,
+		ReversePackets:  reversePackets,
+		ReverseBytes:    reverseBytes,
+	}
+}
+
+func newTestConnectionStore(t *testing.T, ctrl *gomock.Controller) (*ConnectionStore, *connectionstest.MockConnTrackDumper) {
+	remotePod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "pod2"},
+		Spec:       corev1.PodSpec{NodeName: "node2"},
+		Status:     corev1.PodStatus{PodIP: "10.10.1.2", PodIPs: []corev1.PodIP{{IP: "10.10.1.2"}}},
+	}
+	hostNetworkPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "pod3"},
+		Spec:       corev1.PodSpec{NodeName: "node2", HostNetwork: true},
+		Status:     corev1.PodStatus{PodIP: "8.8.8.8", PodIPs: []corev1.PodIP{{IP: "8.8.8.8"}}},
+	}
+	informerFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(remotePod, hostNetworkPod), 0)
+	podInformer := informerFactory.Core().V1().Pods()
+	ifaceStore := interfacestore.NewInterfaceStore()
+	ifaceStore.AddInterface(interfacestore.NewContainerInterface("pod1-abc", "abc", "pod1", "ns1", nil, []net.IP{net.ParseIP("10.10.0.2")}))
+	connTrackDumper := connectionstest.NewMockConnTrackDumper(ctrl)
+	store, err := NewConnectionStore(connTrackDumper, ifaceStore, podInformer, "node1")
+	require.NoError(t, err)
+
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	informerFactory.Start(stopCh)
+	informerFactory.WaitForCacheSync(stopCh)
+	return store, connTrackDumper
+}
+
+func getConnections(store *ConnectionStore) map[flowexporter.ConnectionKey]flowexporter.Connection {
+	connections := map[flowexporter.ConnectionKey]flowexporter.Connection{}
+	store.ForAllConnectionsDo(func(key flowexporter.ConnectionKey, conn *flowexporter.Connection) error {
+		connections[key] = *conn
+		return nil
+	})
+	return connections
+}
+
+func TestConnectionStorePoll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, connTrackDumper := newTestConnectionStore(t, ctrl)
+	key1 := flowexporter.ConnectionKey("6-10.10.0.2-49712-10.10.1.2-80")
+	key2 := flowexporter.ConnectionKey("17-10.10.0.2-5353-8.8.8.8-53")
+
+	conn1 := newTestConnection(6, "10.10.0.2", "10.10.1.2", 49712, 80, 6, 404, 4, 460)
+	// A connection from a local Pod to an external IP.
+	conn2 := newTestConnection(17, "10.10.0.2", "8.8.8.8", 5353, 53, 1, 60, 1, 120)
+	connTrackDumper.EXPECT().DumpFlows(uint16(openflow.CtZone)).Return([]*flowexporter.Connection{conn1, conn2}, nil)
+	require.NoError(t, store.Poll())
+	connections := getConnections(store)
+	require.Equal(t, 2, len(connections))
+	storedConn1 := connections[key1]
+	assert.True(t, storedConn1.IsActive)
+	assert.False(t, storedConn1.StartTime.IsZero())
+	assert.Equal(t, "ns1", storedConn1.SourcePodNamespace)
+	assert.Equal(t, "pod1", storedConn1.SourcePodName)
+	assert.Equal(t, "node1", storedConn1.SourceNodeName)
+	assert.Equal(t, "ns2", storedConn1.DestinationPodNamespace)
+	assert.Equal(t, "pod2", storedConn1.DestinationPodName)
+	assert.Equal(t, "node2", storedConn1.DestinationNodeName)
+	storedConn2 := connections[key2]
+	assert.Equal(t, "pod1", storedConn2.SourcePodName)
+	// Pods in the host network must not be looked up by IP.
+	assert.Equal(t, "", storedConn2.DestinationPodName)
+	assert.Equal(t, "", storedConn2.DestinationNodeName)
+
+	updatedConn1 := newTestConnection(6, "10.10.0.2", "10.10.1.2", 49712, 80, 10, 800, 8, 900)
+	connTrackDumper.EXPECT().DumpFlows(uint16(openflow.CtZone)).Return([]*flowexporter.Connection{updatedConn1}, nil)
+	require.NoError(t, store.Poll())
+	connections = getConnections(store)
+	require.Equal(t, 2, len(connections))
+	storedConn1Updated := connections[key1]
+	assert.True(t, storedConn1Updated.IsActive)
+	assert.Equal(t, storedConn1.StartTime, storedConn1Updated.StartTime)
+	assert.Equal(t, uint64(10), storedConn1Updated.OriginalPackets)
+	assert.Equal(t, uint64(800), storedConn1Updated.OriginalBytes)
+	assert.Equal(t, uint64(8), storedConn1Updated.ReversePackets)
+	assert.Equal(t, uint64(900), storedConn1Updated.ReverseBytes)
+	assert.False(t, connections[key2].IsActive)
+
+	store.DeleteInactiveConnections()
+	connections = getConnections(store)
+	assert.Equal(t, 1, len(connections))
+	assert.Contains(t, connections, key1)
+}
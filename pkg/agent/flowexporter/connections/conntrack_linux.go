@@ -0,0 +1,224 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package connections
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter"
+	"github.com/vmware-tanzu/antrea/pkg/ovs/ovsconfig"
+	"github.com/vmware-tanzu/antrea/pkg/ovs/ovsctl"
+)
+
+// The netlink constants which are not defined in the nl package.
+const (
+	nfnlSubsysCTNetlink = 1
+	ctaZone             = 18
+	ctaTimestamp        = 20
+	// nlaTypeMask removes the NLA_F_NESTED and NLA_F_NET_BYTEORDER flags from the attribute type.
+	nlaTypeMask = ^uint16(nl.NLA_F_NESTED | (1 << 14))
+)
+
+// connTrackSystem dumps the connections from the conntrack table of the kernel with netlink. It
+// must be used with the system datapath, as the connections are tracked by the kernel then.
+type connTrackSystem struct{}
+
+// NewConnTrackSystem returns a ConnTrackDumper which reads the conntrack table of the kernel.
+func NewConnTrackSystem() ConnTrackDumper {
+	return &connTrackSystem{}
+}
+
+// NewConnTrackDumper returns the ConnTrackDumper matching the provided OVS datapath type.
+func NewConnTrackDumper(ovsDatapathType string, ovsCtlClient ovsctl.OVSCtlClient) ConnTrackDumper {
+	if ovsDatapathType == ovsconfig.OVSDatapathNetdev {
+		return NewConnTrackOvsAppCtl(ovsCtlClient)
+	}
+	return NewConnTrackSystem()
+}
+
+func (ct *connTrackSystem) DumpFlows(zoneFilter uint16) ([]*flowexporter.Connection, error) {
+	req := nl.NewNetlinkRequest((nfnlSubsysCTNetlink<<8)|nl.IPCTNL_MSG_CT_GET, unix.NLM_F_DUMP)
+	// AF_UNSPEC dumps the connections of all the IP families.
+	req.AddData(&nl.Nfgenmsg{NfgenFamily: unix.AF_UNSPEC, Version: nl.NFNETLINK_V0})
+	msgs, err := req.Execute(unix.NETLINK_NETFILTER, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error when dumping conntrack table with netlink: %v", err)
+	}
+	var conns []*flowexporter.Connection
+	for _, msg := range msgs {
+		conn, err := parseNetlinkConntrackMsg(msg)
+		if err != nil {
+			klog.V(2).Infof("Skipping conntrack message: %v", err)
+			continue
+		}
+		// The zone can't be filtered by the kernel before Linux 5.8, so the connections of all
+		// the zones are dumped and filtered here.
+		if conn.Zone != zoneFilter {
+			continue
+		}
+		conns = append(conns, conn)
+	}
+	return conns, nil
+}
+
+// parseNetlinkConntrackMsg parses an IPCTNL_MSG_CT_NEW message, made of a nfgenmsg header followed
+// by the CTA_* attributes of the connection.
+func parseNetlinkConntrackMsg(msg []byte) (*flowexporter.Connection, error) {
+	if len(msg) < nl.SizeofNfgenmsg {
+		return nil, fmt.Errorf("message too short")
+	}
+	attrs, err := nl.ParseRouteAttr(msg[nl.SizeofNfgenmsg:])
+	if err != nil {
+		return nil, err
+	}
+	conn := &flowexporter.Connection{IsActive: true}
+	var hasOrig, hasReply bool
+	for _, attr := range attrs {
+		switch attr.Attr.Type & nlaTypeMask {
+		case nl.CTA_TUPLE_ORIG:
+			hasOrig = true
+			err = parseNetlinkTuple(attr.Value, &conn.TupleOrig)
+		case nl.CTA_TUPLE_REPLY:
+			hasReply = true
+			err = parseNetlinkTuple(attr.Value, &conn.TupleReply)
+		case nl.CTA_COUNTERS_ORIG:
+			conn.OriginalPackets, conn.OriginalBytes, err = parseNetlinkCounters(attr.Value)
+		case nl.CTA_COUNTERS_REPLY:
+			conn.ReversePackets, conn.ReverseBytes, err = parseNetlinkCounters(attr.Value)
+		case ctaZone:
+			if len(attr.Value) < 2 {
+				err = fmt.Errorf("invalid zone")
+				break
+			}
+			conn.Zone = binary.BigEndian.Uint16(attr.Value)
+		case ctaTimestamp:
+			// Only present when the conntrack timestamps are enabled with the
+			// nf_conntrack_timestamp sysctl.
+			err = parseNetlinkTimestamp(attr.Value, conn)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !hasOrig || !hasReply {
+		return nil, fmt.Errorf("missing tuple in conntrack message")
+	}
+	conn.TupleReply.Protocol = conn.TupleOrig.Protocol
+	return conn, nil
+}
+
+func parseNestedAttrs(b []byte) ([]syscall.NetlinkRouteAttr, error) {
+	attrs, err := nl.ParseRouteAttr(b)
+	if err != nil {
+		return nil, err
+	}
+	for i := range attrs {
+		attrs[i].Attr.Type &= nlaTypeMask
+	}
+	return attrs, nil
+}
+
+// parseNetlinkTuple parses the CTA_TUPLE_IP and CTA_TUPLE_PROTO attributes of a tuple.
+func parseNetlinkTuple(b []byte, tuple *flowexporter.Tuple) error {
+	attrs, err := parseNestedAttrs(b)
+	if err != nil {
+		return err
+	}
+	for _, attr := range attrs {
+		switch attr.Attr.Type {
+		case nl.CTA_TUPLE_IP:
+			ipAttrs, err := parseNestedAttrs(attr.Value)
+			if err != nil {
+				return err
+			}
+			for _, ipAttr := range ipAttrs {
+				switch ipAttr.Attr.Type {
+				case nl.CTA_IP_V4_SRC, nl.CTA_IP_V6_SRC:
+					tuple.SourceAddress = net.IP(ipAttr.Value)
+				case nl.CTA_IP_V4_DST, nl.CTA_IP_V6_DST:
+					tuple.DestinationAddress = net.IP(ipAttr.Value)
+				}
+			}
+		case nl.CTA_TUPLE_PROTO:
+			protoAttrs, err := parseNestedAttrs(attr.Value)
+			if err != nil {
+				return err
+			}
+			for _, protoAttr := range protoAttrs {
+				if len(protoAttr.Value) == 0 {
+					continue
+				}
+				switch protoAttr.Attr.Type {
+				case nl.CTA_PROTO_NUM:
+					tuple.Protocol = protoAttr.Value[0]
+				case nl.CTA_PROTO_SRC_PORT:
+					tuple.SourcePort = binary.BigEndian.Uint16(protoAttr.Value)
+				case nl.CTA_PROTO_DST_PORT:
+					tuple.DestinationPort = binary.BigEndian.Uint16(protoAttr.Value)
+				}
+			}
+		}
+	}
+	if tuple.SourceAddress == nil || tuple.DestinationAddress == nil {
+		return fmt.Errorf("missing address in tuple")
+	}
+	return nil
+}
+
+// parseNetlinkCounters returns the packet and byte counters of a CTA_COUNTERS_* attribute. The
+// counters are only present when the conntrack accounting is enabled with the nf_conntrack_acct
+// sysctl.
+func parseNetlinkCounters(b []byte) (uint64, uint64, error) {
+	attrs, err := parseNestedAttrs(b)
+	if err != nil {
+		return 0, 0, err
+	}
+	var packets, bytes uint64
+	for _, attr := range attrs {
+		if len(attr.Value) < 8 {
+			continue
+		}
+		switch attr.Attr.Type {
+		case nl.CTA_COUNTERS_PACKETS:
+			packets = binary.BigEndian.Uint64(attr.Value)
+		case nl.CTA_COUNTERS_BYTES:
+			bytes = binary.BigEndian.Uint64(attr.Value)
+		}
+	}
+	return packets, bytes, nil
+}
+
+func parseNetlinkTimestamp(b []byte, conn *flowexporter.Connection) error {
+	attrs, err := parseNestedAttrs(b)
+	if err != nil {
+		return err
+	}
+	for _, attr := range attrs {
+		if attr.Attr.Type == nl.CTA_TIMESTAMP_START && len(attr.Value) >= 8 {
+			conn.StartTime = time.Unix(0, int64(binary.BigEndian.Uint64(attr.Value)))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package connections
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter"
+)
+
+func be16(v uint16) []byte {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, v)
+	return b
+}
+
+func be64(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
+
+func newTupleAttr(attrType int, srcIP, dstIP net.IP, proto uint8, srcPort, dstPort uint16) *nl.RtAttr {
+	tuple := nl.NewRtAttr(attrType|nl.NLA_F_NESTED, nil)
+	ipAttr := tuple.AddRtAttr(nl.CTA_TUPLE_IP|nl.NLA_F_NESTED, nil)
+	if ip4 := srcIP.To4(); ip4 != nil {
+		ipAttr.AddRtAttr(nl.CTA_IP_V4_SRC, ip4)
+		ipAttr.AddRtAttr(nl.CTA_IP_V4_DST, dstIP.To4())
+	} else {
+		ipAttr.AddRtAttr(nl.CTA_IP_V6_SRC, srcIP.To16())
+		ipAttr.AddRtAttr(nl.CTA_IP_V6_DST, dstIP.To16())
+	}
+	protoAttr := tuple.AddRtAttr(nl.CTA_TUPLE_PROTO|nl.NLA_F_NESTED, nil)
+	protoAttr.AddRtAttr(nl.CTA_PROTO_NUM, []byte{proto})
+	protoAttr.AddRtAttr(nl.CTA_PROTO_SRC_PORT, be16(srcPort))
+	protoAttr.AddRtAttr(nl.CTA_PROTO_DST_PORT, be16(dstPort))
+	return tuple
+}
+
+func newCountersAttr(attrType int, packets, bytes uint64) *nl.RtAttr {
+	counters := nl.NewRtAttr(attrType|nl.NLA_F_NESTED, nil)
+	counters.AddRtAttr(nl.CTA_COUNTERS_PACKETS, be64(packets))
+	counters.AddRtAttr(nl.CTA_COUNTERS_BYTES, be64(bytes))
+	return counters
+}
+
+func serializeMsg(family uint8, attrs ...*nl.RtAttr) []byte {
+	msg := (&nl.Nfgenmsg{NfgenFamily: family, Version: nl.NFNETLINK_V0}).Serialize()
+	for _, attr := range attrs {
+		msg = append(msg, attr.Serialize()...)
+	}
+	return msg
+}
+
+func TestParseNetlinkConntrackMsg(t *testing.T) {
+	srcIP, dstIP := net.ParseIP("10.10.0.2"), net.ParseIP("10.10.1.2")
+	timestamp := nl.NewRtAttr(ctaTimestamp|nl.NLA_F_NESTED, nil)
+	timestamp.AddRtAttr(nl.CTA_TIMESTAMP_START, be64(1600000000000000000))
+	msg := serializeMsg(unix.AF_INET,
+		newTupleAttr(nl.CTA_TUPLE_ORIG, srcIP, dstIP, unix.IPPROTO_TCP, 49712, 80),
+		newTupleAttr(nl.CTA_TUPLE_REPLY, dstIP, srcIP, unix.IPPROTO_TCP, 80, 49712),
+		nl.NewRtAttr(nl.CTA_MARK, be64(0)[:4]),
+		newCountersAttr(nl.CTA_COUNTERS_ORIG, 6, 404),
+		newCountersAttr(nl.CTA_COUNTERS_REPLY, 4, 460),
+		nl.NewRtAttr(ctaZone, be16(65520)),
+		timestamp,
+	)
+	conn, err := parseNetlinkConntrackMsg(msg)
+	require.NoError(t, err)
+	expected := &flowexporter.Connection{
+		StartTime:       time.Unix(0, 1600000000000000000),
+		Zone:            65520,
+		IsActive:        true,
+		TupleOrig:       flowexporter.Tuple{SourceAddress: srcIP.To4(), DestinationAddress: dstIP.To4(), Protocol: 6, SourcePort: 49712, DestinationPort: 80},
+		TupleReply:      flowexporter.Tuple{SourceAddress: dstIP.To4(), DestinationAddress: srcIP.To4(), Protocol: 6, SourcePort: 80, DestinationPort: 49712},
+		OriginalPackets: 6,
+		OriginalBytes:   404,
+		ReversePackets:  4,
+		ReverseBytes:    460,
+	}
+	assert.Equal(t, expected, conn)
+}
+
+func TestParseNetlinkConntrackMsgIPv6(t *testing.T) {
+	srcIP, dstIP := net.ParseIP("fd00::2"), net.ParseIP("fd00::1:2")
+	msg := serializeMsg(unix.AF_INET6,
+		newTupleAttr(nl.CTA_TUPLE_ORIG, srcIP, dstIP, unix.IPPROTO_UDP, 5353, 53),
+		newTupleAttr(nl.CTA_TUPLE_REPLY, dstIP, srcIP, unix.IPPROTO_UDP, 53, 5353),
+	)
+	conn, err := parseNetlinkConntrackMsg(msg)
+	require.NoError(t, err)
+	assert.True(t, srcIP.Equal(conn.TupleOrig.SourceAddress))
+	assert.True(t, dstIP.Equal(conn.TupleReply.SourceAddress))
+	assert.Equal(t, uint8(17), conn.TupleOrig.Protocol)
+	// Connections without a zone attribute are in the default zone.
+	assert.Equal(t, uint16(0), conn.Zone)
+}
+
+func TestParseInvalidNetlinkConntrackMsg(t *testing.T) {
+	srcIP, dstIP := net.ParseIP("10.10.0.2"), net.ParseIP("10.10.1.2")
+	for name, msg := range map[string][]byte{
+		"too short":     {0x02},
+		"missing reply": serializeMsg(unix.AF_INET, newTupleAttr(nl.CTA_TUPLE_ORIG, srcIP, dstIP, unix.IPPROTO_TCP, 49712, 80)),
+		"missing address": serializeMsg(unix.AF_INET,
+			newTupleAttr(nl.CTA_TUPLE_ORIG, srcIP, dstIP, unix.IPPROTO_TCP, 49712, 80),
+			nl.NewRtAttr(nl.CTA_TUPLE_REPLY|nl.NLA_F_NESTED, nil)),
+	} {
+		_, err := parseNetlinkConntrackMsg(msg)
+		assert.Error(t, err, name)
+	}
+}
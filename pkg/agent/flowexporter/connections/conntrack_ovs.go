@@ -0,0 +1,186 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connections
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter"
+	"github.com/vmware-tanzu/antrea/pkg/ovs/ovsctl"
+)
+
+var protocols = map[string]uint8{
+	"icmp":   1,
+	"tcp":    6,
+	"udp":    17,
+	"icmpv6": 58,
+	"sctp":   132,
+}
+
+// connTrackOvsAppCtl dumps the connections with "ovs-appctl dpctl/dump-conntrack". It must be used
+// with the netdev datapath, as the connections are tracked by OVS in userspace then, and it can be
+// used with any datapath on the platforms on which the conntrack table can't be read from netlink.
+type connTrackOvsAppCtl struct {
+	ovsCtlClient ovsctl.OVSCtlClient
+}
+
+// NewConnTrackOvsAppCtl returns a ConnTrackDumper which dumps the connections with ovs-appctl.
+func NewConnTrackOvsAppCtl(ovsCtlClient ovsctl.OVSCtlClient) ConnTrackDumper {
+	return &connTrackOvsAppCtl{ovsCtlClient: ovsCtlClient}
+}
+
+func (ct *connTrackOvsAppCtl) DumpFlows(zoneFilter uint16) ([]*flowexporter.Connection, error) {
+	entries, err := ct.ovsCtlClient.DumpConntrack(zoneFilter)
+	if err != nil {
+		return nil, fmt.Errorf("error when dumping conntrack zone %d with ovs-appctl: %v", zoneFilter, err)
+	}
+	conns := make([]*flowexporter.Connection, 0, len(entries))
+	for _, entry := range entries {
+		conn, err := parseConntrackEntry(entry)
+		if err != nil {
+			klog.V(2).Infof("Skipping conntrack entry: %v", err)
+			continue
+		}
+		conns = append(conns, conn)
+	}
+	return conns, nil
+}
+
+// parseConntrackEntry parses a connection printed by "ovs-appctl dpctl/dump-conntrack -m -s",
+// e.g.:
+// tcp,orig=(src=10.10.0.2,dst=10.10.1.2,sport=49712,dport=80,packets=6,bytes=404),reply=(src=10.10.1.2,dst=10.10.0.2,sport=80,dport=49712,packets=4,bytes=460),id=3424516,zone=65520,status=SEEN_REPLY|ASSURED|CONFIRMED,timeout=86398,protoinfo=(state=ESTABLISHED)
+func parseConntrackEntry(entry string) (*flowexporter.Connection, error) {
+	fields := splitFields(entry)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty conntrack entry")
+	}
+	proto, ok := protocols[fields[0]]
+	if !ok {
+		p, err := strconv.ParseUint(fields[0], 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("unknown protocol %s in conntrack entry %s", fields[0], entry)
+		}
+		proto = uint8(p)
+	}
+	conn := &flowexporter.Connection{IsActive: true}
+	var hasOrig, hasReply bool
+	for _, field := range fields[1:] {
+		key, value := splitKeyValue(field)
+		var err error
+		switch key {
+		case "orig":
+			hasOrig = true
+			conn.OriginalPackets, conn.OriginalBytes, err = parseTuple(value, proto, &conn.TupleOrig)
+		case "reply":
+			hasReply = true
+			conn.ReversePackets, conn.ReverseBytes, err = parseTuple(value, proto, &conn.TupleReply)
+		case "zone":
+			var zone uint64
+			zone, err = strconv.ParseUint(value, 10, 16)
+			conn.Zone = uint16(zone)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid field %s in conntrack entry %s: %v", field, entry, err)
+		}
+	}
+	if !hasOrig || !hasReply {
+		return nil, fmt.Errorf("missing tuple in conntrack entry %s", entry)
+	}
+	return conn, nil
+}
+
+// parseTuple parses a tuple like "(src=10.10.0.2,dst=10.10.1.2,sport=49712,dport=80,packets=6,bytes=404)"
+// into the provided Tuple, and returns the packet and byte counters of the tuple.
+func parseTuple(value string, proto uint8, tuple *flowexporter.Tuple) (uint64, uint64, error) {
+	if !strings.HasPrefix(value, "(") || !strings.HasSuffix(value, ")") {
+		return 0, 0, fmt.Errorf("invalid tuple")
+	}
+	tuple.Protocol = proto
+	var packets, bytes uint64
+	for _, field := range splitFields(value[1 : len(value)-1]) {
+		key, v := splitKeyValue(field)
+		var err error
+		switch key {
+		case "src":
+			tuple.SourceAddress, err = parseIP(v)
+		case "dst":
+			tuple.DestinationAddress, err = parseIP(v)
+		case "sport":
+			tuple.SourcePort, err = parsePort(v)
+		case "dport":
+			tuple.DestinationPort, err = parsePort(v)
+		case "packets":
+			packets, err = strconv.ParseUint(v, 10, 64)
+		case "bytes":
+			bytes, err = strconv.ParseUint(v, 10, 64)
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	if tuple.SourceAddress == nil || tuple.DestinationAddress == nil {
+		return 0, 0, fmt.Errorf("missing address in tuple")
+	}
+	return packets, bytes, nil
+}
+
+func parseIP(s string) (net.IP, error) {
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP %s", s)
+	}
+	return ip, nil
+}
+
+func parsePort(s string) (uint16, error) {
+	port, err := strconv.ParseUint(s, 10, 16)
+	return uint16(port), err
+}
+
+// splitFields splits the provided string on the commas which are not enclosed in parentheses.
+func splitFields(s string) []string {
+	var fields []string
+	depth, start := 0, 0
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				fields = append(fields, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if start < len(s) {
+		fields = append(fields, s[start:])
+	}
+	return fields
+}
+
+func splitKeyValue(field string) (string, string) {
+	kv := strings.SplitN(field, "=", 2)
+	if len(kv) != 2 {
+		return kv[0], ""
+	}
+	return kv[0], kv[1]
+}
@@ -0,0 +1,115 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connections
+
+import (
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter"
+	ovsctltest "github.com/vmware-tanzu/antrea/pkg/ovs/ovsctl/testing"
+)
+
+func TestConnTrackOvsAppCtlDumpFlows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ovsCtlClient := ovsctltest.NewMockOVSCtlClient(ctrl)
+	connTrackDumper := NewConnTrackOvsAppCtl(ovsCtlClient)
+
+	entries := []string{
+		"tcp,orig=(src=10.10.0.2,dst=10.10.1.2,sport=49712,dport=80,packets=6,bytes=404),reply=(src=10.10.1.2,dst=10.10.0.2,sport=80,dport=49712,packets=4,bytes=460),zone=65520",
+		"invalid",
+		"udp,orig=(src=10.10.0.2,dst=8.8.8.8,sport=5353,dport=53),reply=(src=8.8.8.8,dst=10.10.0.2,sport=53,dport=5353),zone=65520",
+	}
+	ovsCtlClient.EXPECT().DumpConntrack(uint16(65520)).Return(entries, nil)
+	conns, err := connTrackDumper.DumpFlows(65520)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(conns))
+	assert.Equal(t, uint8(6), conns[0].TupleOrig.Protocol)
+	assert.Equal(t, uint8(17), conns[1].TupleOrig.Protocol)
+}
+
+func TestParseConntrackEntry(t *testing.T) {
+	tests := []struct {
+		name     string
+		entry    string
+		expected *flowexporter.Connection
+	}{
+		{
+			name:  "tcp",
+			entry: "tcp,orig=(src=10.10.0.2,dst=10.10.1.2,sport=49712,dport=80,packets=6,bytes=404),reply=(src=10.10.1.2,dst=10.10.0.2,sport=80,dport=49712,packets=4,bytes=460),id=3424516,zone=65520,status=SEEN_REPLY|ASSURED|CONFIRMED,timeout=86398,protoinfo=(state=ESTABLISHED)",
+			expected: &flowexporter.Connection{
+				Zone:            65520,
+				IsActive:        true,
+				TupleOrig:       flowexporter.Tuple{SourceAddress: net.ParseIP("10.10.0.2"), DestinationAddress: net.ParseIP("10.10.1.2"), Protocol: 6, SourcePort: 49712, DestinationPort: 80},
+				TupleReply:      flowexporter.Tuple{SourceAddress: net.ParseIP("10.10.1.2"), DestinationAddress: net.ParseIP("10.10.0.2"), Protocol: 6, SourcePort: 80, DestinationPort: 49712},
+				OriginalPackets: 6,
+				OriginalBytes:   404,
+				ReversePackets:  4,
+				ReverseBytes:    460,
+			},
+		},
+		{
+			name:  "icmp",
+			entry: "icmp,orig=(src=10.10.0.2,dst=10.10.1.2,id=1,type=8,code=0,packets=1,bytes=84),reply=(src=10.10.1.2,dst=10.10.0.2,id=1,type=0,code=0,packets=1,bytes=84),zone=65520",
+			expected: &flowexporter.Connection{
+				Zone:            65520,
+				IsActive:        true,
+				TupleOrig:       flowexporter.Tuple{SourceAddress: net.ParseIP("10.10.0.2"), DestinationAddress: net.ParseIP("10.10.1.2"), Protocol: 1},
+				TupleReply:      flowexporter.Tuple{SourceAddress: net.ParseIP("10.10.1.2"), DestinationAddress: net.ParseIP("10.10.0.2"), Protocol: 1},
+				OriginalPackets: 1,
+				OriginalBytes:   84,
+				ReversePackets:  1,
+				ReverseBytes:    84,
+			},
+		},
+		{
+			name:  "udp-ipv6",
+			entry: "udp,orig=(src=fd00::2,dst=fd00::1:2,sport=5353,dport=53),reply=(src=fd00::1:2,dst=fd00::2,sport=53,dport=5353),zone=65520",
+			expected: &flowexporter.Connection{
+				Zone:       65520,
+				IsActive:   true,
+				TupleOrig:  flowexporter.Tuple{SourceAddress: net.ParseIP("fd00::2"), DestinationAddress: net.ParseIP("fd00::1:2"), Protocol: 17, SourcePort: 5353, DestinationPort: 53},
+				TupleReply: flowexporter.Tuple{SourceAddress: net.ParseIP("fd00::1:2"), DestinationAddress: net.ParseIP("fd00::2"), Protocol: 17, SourcePort: 53, DestinationPort: 5353},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := parseConntrackEntry(tt.entry)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, conn)
+		})
+	}
+}
+
+func TestParseInvalidConntrackEntry(t *testing.T) {
+	for _, entry := range []string{
+		"",
+		"foo,orig=(src=10.10.0.2,dst=10.10.1.2),reply=(src=10.10.1.2,dst=10.10.0.2)",
+		"tcp,orig=(src=10.10.0.2,dst=10.10.1.2,sport=49712,dport=80)",
+		"tcp,orig=(src=10.10.0.2,sport=49712,dport=80),reply=(src=10.10.1.2,dst=10.10.0.2,sport=80,dport=49712)",
+		"tcp,orig=(src=10.10.0.300,dst=10.10.1.2,sport=49712,dport=80),reply=(src=10.10.1.2,dst=10.10.0.2,sport=80,dport=49712)",
+		"tcp,orig=(src=10.10.0.2,dst=10.10.1.2,sport=70000,dport=80),reply=(src=10.10.1.2,dst=10.10.0.2,sport=80,dport=49712)",
+		"tcp,orig=src=10.10.0.2,reply=(src=10.10.1.2,dst=10.10.0.2,sport=80,dport=49712)",
+	} {
+		_, err := parseConntrackEntry(entry)
+		assert.Error(t, err, "Parsing entry %q should fail", entry)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"hash/fnv"
+	"time"
+
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter"
+	"github.com/vmware-tanzu/antrea/pkg/ipfix"
+)
+
+// ConnectionStore provides the connections to export.
+type ConnectionStore interface {
+	Poll() error
+	ForAllConnectionsDo(fn func(key flowexporter.ConnectionKey, conn *flowexporter.Connection) error) error
+	DeleteInactiveConnections()
+}
+
+var (
+	// The Information Elements of the flow records, which are common to both IP families.
+	commonElements = []*ipfix.InfoElement{
+		ipfix.FlowStartSeconds,
+		ipfix.FlowEndSeconds,
+		ipfix.SourceTransportPort,
+		ipfix.DestinationTransportPort,
+		ipfix.ProtocolIdentifier,
+		ipfix.PacketTotalCount,
+		ipfix.OctetTotalCount,
+		ipfix.PacketDeltaCount,
+		ipfix.OctetDeltaCount,
+		ipfix.ReversePacketTotalCount,
+		ipfix.ReverseOctetTotalCount,
+		ipfix.ReversePacketDeltaCount,
+		ipfix.ReverseOctetDeltaCount,
+		ipfix.SourcePodNamespace,
+		ipfix.SourcePodName,
+		ipfix.SourceNodeName,
+		ipfix.DestinationPodNamespace,
+		ipfix.DestinationPodName,
+		ipfix.DestinationNodeName,
+	}
+	ipv4Elements = append([]*ipfix.InfoElement{ipfix.SourceIPv4Address, ipfix.DestinationIPv4Address}, commonElements...)
+	ipv6Elements = append([]*ipfix.InfoElement{ipfix.SourceIPv6Address, ipfix.DestinationIPv6Address}, commonElements...)
+)
+
+// FlowExporter periodically polls the connections of the Pods, and exports them as IPFIX flow
+// records to a collector.
+type FlowExporter struct {
+	store            ConnectionStore
+	collectorNetwork string
+	collectorAddr    string
+	pollInterval     time.Duration
+	// exportFrequency is the number of polls between two exports.
+	exportFrequency uint
+	obsDomainID     uint32
+	process         *ipfix.ExportingProcess
+	templateIDv4    uint16
+	templateIDv6    uint16
+}
+
+// NewFlowExporter returns a new *FlowExporter. collectorNetwork is the transport protocol used
+// to reach the collector, either "tcp" or "udp". The Observation Domain ID of the records is
+// derived from the Node name, so that the collector can tell the exporting Nodes apart.
+func NewFlowExporter(store ConnectionStore, collectorNetwork, collectorAddr string, pollInterval time.Duration, exportFrequency uint, nodeName string) *FlowExporter {
+	h := fnv.New32()
+	h.Write([]byte(nodeName))
+	return &FlowExporter{
+		store:            store,
+		collectorNetwork: collectorNetwork,
+		collectorAddr:    collectorAddr,
+		pollInterval:     pollInterval,
+		exportFrequency:  exportFrequency,
+		obsDomainID:      h.Sum32(),
+	}
+}
+
+// Run polls the connections every pollInterval, and exports them every exportFrequency polls,
+// until stopCh is closed.
+func (e *FlowExporter) Run(stopCh <-chan struct{}) {
+	klog.Infof("Starting flow exporter to %s collector %s", e.collectorNetwork, e.collectorAddr)
+	ticker := time.NewTicker(e.pollInterval)
+	defer ticker.Stop()
+	var polls uint
+	for {
+		select {
+		case <-ticker.C:
+			if err := e.store.Poll(); err != nil {
+				klog.Errorf("Failed to poll connections: %v", err)
+				continue
+			}
+			polls++
+			if polls%e.exportFrequency != 0 {
+				continue
+			}
+			if err := e.export(); err != nil {
+				klog.Errorf("Failed to export flow records: %v", err)
+				// Reconnect on the next export, the records will be exported then with
+				// the updated counters.
+				if e.process != nil {
+					e.process.Close()
+					e.process = nil
+				}
+			}
+		case <-stopCh:
+			if e.process != nil {
+				e.process.Close()
+			}
+			return
+		}
+	}
+}
+
+// export sends the records of all the connections in the store, and removes the inactive ones
+// once they are exported.
+func (e *FlowExporter) export() error {
+	if e.process == nil {
+		process, err := ipfix.NewExportingProcess(e.collectorNetwork, e.collectorAddr, e.obsDomainID)
+		if err != nil {
+			return err
+		}
+		e.process = process
+		e.templateIDv4 = process.AddTemplate(ipv4Elements)
+		e.templateIDv6 = process.AddTemplate(ipv6Elements)
+		if err := process.SendTemplates(); err != nil {
+			return err
+		}
+	} else if e.collectorNetwork == "udp" {
+		// The templates are resent over UDP in case the collector missed them or was restarted.
+		if err := e.process.SendTemplates(); err != nil {
+			return err
+		}
+	}
+
+	var records []ipfix.DataRecord
+	e.store.ForAllConnectionsDo(func(key flowexporter.ConnectionKey, conn *flowexporter.Connection) error {
+		records = append(records, e.buildRecord(conn))
+		return nil
+	})
+	if err := e.process.SendDataRecords(records); err != nil {
+		return err
+	}
+	// The delta counts of the next records are relative to the exported counters.
+	e.store.ForAllConnectionsDo(func(key flowexporter.ConnectionKey, conn *flowexporter.Connection) error {
+		conn.PrevPackets, conn.PrevBytes = conn.OriginalPackets, conn.OriginalBytes
+		conn.PrevReversePackets, conn.PrevReverseBytes = conn.ReversePackets, conn.ReverseBytes
+		return nil
+	})
+	e.store.DeleteInactiveConnections()
+	klog.V(2).Infof("Exported %d flow records", len(records))
+	return nil
+}
+
+func (e *FlowExporter) buildRecord(conn *flowexporter.Connection) ipfix.DataRecord {
+	templateID := e.templateIDv4
+	if conn.TupleOrig.SourceAddress.To4() == nil {
+		templateID = e.templateIDv6
+	}
+	return ipfix.DataRecord{
+		TemplateID: templateID,
+		Values: []interface{}{
+			conn.TupleOrig.SourceAddress,
+			conn.TupleOrig.DestinationAddress,
+			conn.StartTime,
+			conn.StopTime,
+			conn.TupleOrig.SourcePort,
+			conn.TupleOrig.DestinationPort,
+			conn.TupleOrig.Protocol,
+			conn.OriginalPackets,
+			conn.OriginalBytes,
+			conn.OriginalPackets - conn.PrevPackets,
+			conn.OriginalBytes - conn.PrevBytes,
+			conn.ReversePackets,
+			conn.ReverseBytes,
+			conn.ReversePackets - conn.PrevReversePackets,
+			conn.ReverseBytes - conn.PrevReverseBytes,
+			conn.SourcePodNamespace,
+			conn.SourcePodName,
+			conn.SourceNodeName,
+			conn.DestinationPodNamespace,
+			conn.DestinationPodName,
+			conn.DestinationNodeName,
+		},
+	}
+}
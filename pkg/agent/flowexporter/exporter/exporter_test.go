@@ -0,0 +1,55 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter"
+)
+
+func TestBuildRecord(t *testing.T) {
+	e := &FlowExporter{templateIDv4: 256, templateIDv6: 257}
+	conn := &flowexporter.Connection{
+		TupleOrig:          flowexporter.Tuple{SourceAddress: net.ParseIP("10.10.0.2"), DestinationAddress: net.ParseIP("10.10.1.2"), Protocol: 6, SourcePort: 49712, DestinationPort: 80},
+		OriginalPackets:    10,
+		OriginalBytes:      800,
+		ReversePackets:     8,
+		ReverseBytes:       900,
+		PrevPackets:        6,
+		PrevBytes:          404,
+		PrevReversePackets: 4,
+		PrevReverseBytes:   460,
+		SourcePodNamespace: "ns1",
+		SourcePodName:      "pod1",
+		SourceNodeName:     "node1",
+	}
+	record := e.buildRecord(conn)
+	assert.Equal(t, uint16(256), record.TemplateID)
+	assert.Equal(t, len(ipv4Elements), len(record.Values))
+	// packetTotalCount, octetTotalCount, packetDeltaCount, octetDeltaCount, and the same counters
+	// in the reverse direction.
+	assert.Equal(t, []interface{}{uint64(10), uint64(800), uint64(4), uint64(396), uint64(8), uint64(900), uint64(4), uint64(440)}, record.Values[7:15])
+	assert.Equal(t, []interface{}{"ns1", "pod1", "node1", "", "", ""}, record.Values[15:])
+
+	conn.TupleOrig.SourceAddress = net.ParseIP("fd00::2")
+	conn.TupleOrig.DestinationAddress = net.ParseIP("fd00::1:2")
+	record = e.buildRecord(conn)
+	assert.Equal(t, uint16(257), record.TemplateID)
+	assert.Equal(t, len(ipv6Elements), len(record.Values))
+}
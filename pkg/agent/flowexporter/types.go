@@ -0,0 +1,74 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flowexporter
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+// ConnectionKey uniquely identifies a connection in the conntrack zone. It is built from the
+// 5-tuple of the original direction.
+type ConnectionKey string
+
+// Tuple is the 5-tuple of one direction of a connection. For ICMP connections, the ports are 0.
+type Tuple struct {
+	SourceAddress      net.IP
+	DestinationAddress net.IP
+	Protocol           uint8
+	SourcePort         uint16
+	DestinationPort    uint16
+}
+
+// Connection is a connection polled from the conntrack zone, with the Kubernetes context of its
+// endpoints.
+type Connection struct {
+	// StartTime is the time when the connection was first polled, and StopTime is the last time
+	// it was polled.
+	StartTime time.Time
+	StopTime  time.Time
+	Zone      uint16
+	// IsActive is false once the connection is no longer in the conntrack zone. Inactive
+	// connections are removed after their last record is exported.
+	IsActive   bool
+	TupleOrig  Tuple
+	TupleReply Tuple
+	// Cumulative counters of the original and reply directions.
+	OriginalPackets uint64
+	OriginalBytes   uint64
+	ReversePackets  uint64
+	ReverseBytes    uint64
+	// Counters at the time of the last export, used to compute the delta counts.
+	PrevPackets        uint64
+	PrevBytes          uint64
+	PrevReversePackets uint64
+	PrevReverseBytes   uint64
+	// Kubernetes context of the source and destination. They are empty if the endpoint is not
+	// a Pod. The destination is identified with the source of the reply direction, so that the
+	// Endpoint of a Service is reported instead of the Service's ClusterIP.
+	SourcePodNamespace      string
+	SourcePodName           string
+	SourceNodeName          string
+	DestinationPodNamespace string
+	DestinationPodName      string
+	DestinationNodeName     string
+}
+
+// NewConnectionKey returns the key of the provided connection.
+func NewConnectionKey(conn *Connection) ConnectionKey {
+	t := conn.TupleOrig
+	return ConnectionKey(fmt.Sprintf("%d-%s-%d-%s-%d", t.Protocol, t.SourceAddress, t.SourcePort, t.DestinationAddress, t.DestinationPort))
+}
@@ -28,6 +28,8 @@ import (
 //     configurations.
 //  3) For tunnel port, the fields include: name and tunnel type; and for an IPSec tunnel,
 //     additionally: remoteIP, PSK and remote Node name.
+//  4) For secondary container interface, the fields include the ones of a container interface,
+//     and: SecondaryNetwork name, container interface name, OVS bridge, VLAN ID and IPPool.
 // OVS Port configurations include PortUUID and OFPort.
 // Container interface is added into cache after invocation of cniserver.CmdAdd, and removed
 // from cache after invocation of cniserver.CmdDel. For cniserver.CmdCheck, the server would
@@ -37,6 +39,8 @@ import (
 // An IPSec tunnel interface is added into the cache when IPSec encyption is enabled, and
 // NodeRouteController watches a new remote Node from K8s API, and is removed when the remote
 // Node is deleted.
+// Secondary container interfaces are added into cache after invocation of cniserver.CmdAdd for
+// Pods annotated with SecondaryNetworks, and removed with the container interface of the Pod.
 // Todo: add periodic task to sync local cache with container veth pair
 
 type interfaceCache struct {
@@ -56,6 +60,8 @@ func getInterfaceKey(interfaceConfig *InterfaceConfig) string {
 	var key string
 	if interfaceConfig.Type == ContainerInterface {
 		key = util.GenerateContainerInterfaceKey(interfaceConfig.PodName, interfaceConfig.PodNamespace)
+	} else if interfaceConfig.Type == SecondaryInterface {
+		key = util.GenerateSecondaryInterfaceKey(interfaceConfig.PodName, interfaceConfig.PodNamespace, interfaceConfig.IFName)
 	} else if interfaceConfig.Type == TunnelInterface && interfaceConfig.NodeName != "" {
 		// Tunnel interface for a Node.
 		key = util.GenerateNodeTunnelInterfaceKey(interfaceConfig.NodeName)
@@ -165,6 +171,19 @@ func (c *interfaceCache) GetContainerInterface(podName string, podNamespace stri
 	return iface, ok
 }
 
+// GetSecondaryInterfaces retrieves the InterfaceConfigs of the secondary interfaces of the Pod.
+func (c *interfaceCache) GetSecondaryInterfaces(podName string, podNamespace string) []*InterfaceConfig {
+	c.RLock()
+	defer c.RUnlock()
+	var interfaces []*InterfaceConfig
+	for _, v := range c.cache {
+		if v.Type == SecondaryInterface && v.PodName == podName && v.PodNamespace == podNamespace {
+			interfaces = append(interfaces, v)
+		}
+	}
+	return interfaces
+}
+
 // GetNodeTunnelInterface retrieves InterfaceConfig for the tunnel to the Node.
 func (c *interfaceCache) GetNodeTunnelInterface(nodeName string) (*InterfaceConfig, bool) {
 	key := util.GenerateNodeTunnelInterfaceKey(nodeName)
@@ -188,6 +188,20 @@ func (mr *MockInterfaceStoreMockRecorder) GetNodeTunnelInterface(arg0 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeTunnelInterface", reflect.TypeOf((*MockInterfaceStore)(nil).GetNodeTunnelInterface), arg0)
 }
 
+// GetSecondaryInterfaces mocks base method
+func (m *MockInterfaceStore) GetSecondaryInterfaces(arg0, arg1 string) []*interfacestore.InterfaceConfig {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecondaryInterfaces", arg0, arg1)
+	ret0, _ := ret[0].([]*interfacestore.InterfaceConfig)
+	return ret0
+}
+
+// GetSecondaryInterfaces indicates an expected call of GetSecondaryInterfaces
+func (mr *MockInterfaceStoreMockRecorder) GetSecondaryInterfaces(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecondaryInterfaces", reflect.TypeOf((*MockInterfaceStore)(nil).GetSecondaryInterfaces), arg0, arg1)
+}
+
 // Initialize mocks base method
 func (m *MockInterfaceStore) Initialize(arg0 []*interfacestore.InterfaceConfig) {
 	m.ctrl.T.Helper()
@@ -29,6 +29,8 @@ const (
 	TunnelInterface
 	// UplinkInterface is used to mark current interface is for uplink port
 	UplinkInterface
+	// SecondaryInterface is used to mark current interface is for a secondary network of a container
+	SecondaryInterface
 )
 
 type InterfaceType uint8
@@ -44,6 +46,22 @@ type ContainerInterfaceConfig struct {
 	PodNamespace string
 }
 
+// SecondaryInterfaceConfig is the configuration of a secondary interface of a container, which is
+// connected to the OVS bridge of a SecondaryNetwork instead of the Antrea bridge.
+type SecondaryInterfaceConfig struct {
+	// Name of the SecondaryNetwork.
+	NetworkName string
+	// Name of the interface in the container.
+	IFName string
+	// Name of the OVS bridge the interface is connected to.
+	BridgeName string
+	// VLAN ID of the OVS access port, 0 if the port is not tagged.
+	VLANID uint16
+	// Name of the IPPool the IPs of the interface are allocated from, empty if the interface has
+	// no IP.
+	IPPool string
+}
+
 type TunnelInterfaceConfig struct {
 	Type ovsconfig.TunnelType
 	// Name of the remote Node.
@@ -53,17 +71,22 @@ type TunnelInterfaceConfig struct {
 	// IP address of the remote Node.
 	RemoteIP net.IP
 	PSK      string
+	// Name expected in the certificate of the remote Node, when the IPSec tunnel uses certificate
+	// based authentication.
+	RemoteName string
 }
 
 type InterfaceConfig struct {
 	Type InterfaceType
 	// Unique name of the interface, also used for the OVS port name.
 	InterfaceName string
-	IP            net.IP
-	MAC           net.HardwareAddr
+	// IPs are the IP addresses of the interface, at most one for each IP family.
+	IPs []net.IP
+	MAC net.HardwareAddr
 	*OVSPortConfig
 	*ContainerInterfaceConfig
 	*TunnelInterfaceConfig
+	*SecondaryInterfaceConfig
 }
 
 // InterfaceStore is a service interface to create local interfaces for container, host gateway, and tunnel port.
@@ -75,6 +98,7 @@ type InterfaceStore interface {
 	GetInterface(interfaceKey string) (*InterfaceConfig, bool)
 	GetInterfaceByName(interfaceName string) (*InterfaceConfig, bool)
 	GetContainerInterface(podName string, podNamespace string) (*InterfaceConfig, bool)
+	GetSecondaryInterfaces(podName string, podNamespace string) []*InterfaceConfig
 	GetNodeTunnelInterface(nodeName string) (*InterfaceConfig, bool)
 	GetContainerInterfaceNum() int
 	GetInterfacesByType(interfaceType InterfaceType) []*InterfaceConfig
@@ -90,7 +114,7 @@ func NewContainerInterface(
 	podName string,
 	podNamespace string,
 	mac net.HardwareAddr,
-	ip net.IP) *InterfaceConfig {
+	ips []net.IP) *InterfaceConfig {
 	containerConfig := &ContainerInterfaceConfig{
 		ContainerID:  containerID,
 		PodName:      podName,
@@ -98,11 +122,26 @@ func NewContainerInterface(
 	return &InterfaceConfig{
 		InterfaceName:            interfaceName,
 		Type:                     ContainerInterface,
-		IP:                       ip,
+		IPs:                      ips,
 		MAC:                      mac,
 		ContainerInterfaceConfig: containerConfig}
 }
 
+// NewSecondaryInterface creates InterfaceConfig for a secondary interface of a Pod.
+func NewSecondaryInterface(
+	interfaceName string,
+	containerID string,
+	podName string,
+	podNamespace string,
+	mac net.HardwareAddr,
+	ips []net.IP,
+	secondaryConfig *SecondaryInterfaceConfig) *InterfaceConfig {
+	interfaceConfig := NewContainerInterface(interfaceName, containerID, podName, podNamespace, mac, ips)
+	interfaceConfig.Type = SecondaryInterface
+	interfaceConfig.SecondaryInterfaceConfig = secondaryConfig
+	return interfaceConfig
+}
+
 // NewGatewayInterface creates InterfaceConfig for the host gateway interface.
 func NewGatewayInterface(gatewayName string) *InterfaceConfig {
 	gatewayConfig := &InterfaceConfig{InterfaceName: gatewayName, Type: GatewayInterface}
@@ -117,9 +156,9 @@ func NewTunnelInterface(tunnelName string, tunnelType ovsconfig.TunnelType, loca
 }
 
 // NewIPSecTunnelInterface creates InterfaceConfig for the IPSec tunnel to the
-// Node.
-func NewIPSecTunnelInterface(interfaceName string, tunnelType ovsconfig.TunnelType, nodeName string, nodeIP net.IP, psk string) *InterfaceConfig {
-	tunnelConfig := &TunnelInterfaceConfig{Type: tunnelType, NodeName: nodeName, RemoteIP: nodeIP, PSK: psk}
+// Node. Either psk or remoteName is set, depending on the authentication mode.
+func NewIPSecTunnelInterface(interfaceName string, tunnelType ovsconfig.TunnelType, nodeName string, nodeIP net.IP, psk, remoteName string) *InterfaceConfig {
+	tunnelConfig := &TunnelInterfaceConfig{Type: tunnelType, NodeName: nodeName, RemoteIP: nodeIP, PSK: psk, RemoteName: remoteName}
 	return &InterfaceConfig{InterfaceName: interfaceName, Type: TunnelInterface, TunnelInterfaceConfig: tunnelConfig}
 }
 
@@ -17,13 +17,18 @@ package openflow
 import (
 	"fmt"
 	"net"
+	"strconv"
 
+	"github.com/contiv/libOpenflow/openflow13"
+	"github.com/contiv/libOpenflow/protocol"
+	"github.com/contiv/ofnet/ofctrl"
 	"k8s.io/klog"
 
 	"github.com/vmware-tanzu/antrea/pkg/agent/config"
 	"github.com/vmware-tanzu/antrea/pkg/agent/openflow/cookie"
 	"github.com/vmware-tanzu/antrea/pkg/agent/types"
 	binding "github.com/vmware-tanzu/antrea/pkg/ovs/openflow"
+	"github.com/vmware-tanzu/antrea/pkg/util/ip"
 )
 
 const maxRetryForOFSwitch = 5
@@ -38,7 +43,8 @@ type Client interface {
 	Initialize(roundInfo types.RoundInfo, config *config.NodeConfig, encapMode config.TrafficEncapModeType, gatewayOFPort uint32) (<-chan struct{}, error)
 
 	// InstallGatewayFlows sets up flows related to an OVS gateway port, the gateway must exist.
-	InstallGatewayFlows(gatewayAddr net.IP, gatewayMAC net.HardwareAddr, gatewayOFPort uint32) error
+	// gatewayAddrs includes at most one IP address of each IP family.
+	InstallGatewayFlows(gatewayAddrs []net.IP, gatewayMAC net.HardwareAddr, gatewayOFPort uint32) error
 
 	// InstallBridgeUplinkFlows installs Openflow flows between bridge local port and uplink port to support
 	// host networking. These flows are only needed on windows platform.
@@ -49,13 +55,45 @@ type Client interface {
 	// the Cluster Service CIDR as a parameter.
 	InstallClusterServiceCIDRFlows(serviceNet *net.IPNet, gatewayMAC net.HardwareAddr, gatewayOFPort uint32) error
 
+	// InstallServiceGroup installs a group which selects one of the provided Endpoints for the new connections to a
+	// Service. If the group already exists, its buckets are replaced with the provided Endpoints. Only IPv4
+	// Endpoints are supported. withSessionAffinity must be true if the Service has ClientIP session affinity, so
+	// that the selected Endpoints are learned by the flows installed by InstallServiceFlows.
+	InstallServiceGroup(groupID binding.GroupIDType, withSessionAffinity bool, endpoints []types.Endpoint) error
+
+	// UninstallServiceGroup removes the group installed by InstallServiceGroup with the provided groupID.
+	UninstallServiceGroup(groupID binding.GroupIDType) error
+
+	// InstallEndpointFlows installs the flows which DNAT the connections to the provided Endpoints once they are
+	// selected by a Service group, including the connections from an Endpoint to itself, which are sent back to
+	// it with a virtual source IP. Calls to InstallEndpointFlows are idempotent.
+	InstallEndpointFlows(protocol binding.Protocol, endpoints []types.Endpoint) error
+
+	// UninstallEndpointFlows removes the flows installed by InstallEndpointFlows for the provided Endpoint.
+	UninstallEndpointFlows(protocol binding.Protocol, endpoint types.Endpoint) error
+
+	// InstallServiceFlows installs the flow which sends the new connections to the Service with the provided
+	// ClusterIP, port and protocol to the group with groupID. If affinityTimeout is not 0, the Service has ClientIP
+	// session affinity: the Endpoint selected for a client is learned, and the following connections from the client
+	// are sent to the same Endpoint until no new connection has been made for affinityTimeout seconds. Session
+	// affinity is supported only for TCP and UDP. Calls to InstallServiceFlows are idempotent.
+	InstallServiceFlows(groupID binding.GroupIDType, svcIP net.IP, svcPort uint16, protocol binding.Protocol, affinityTimeout uint16) error
+
+	// UninstallServiceFlows removes the flows installed by InstallServiceFlows for the provided Service, together
+	// with the flows learned for the Service.
+	UninstallServiceFlows(svcIP net.IP, svcPort uint16, protocol binding.Protocol) error
+
 	// InstallDefaultTunnelFlows sets up the classification flow for the default (flow based) tunnel.
 	InstallDefaultTunnelFlows(tunnelOFPort uint32) error
 
 	// InstallNodeFlows should be invoked when a connection to a remote Node is going to be set
-	// up. The hostname is used to identify the added flows. When IPSec tunnel is enabled,
+	// up. The hostname is used to identify the added flows. peerConfigs maps each Pod CIDR of
+	// the remote Node (at most one for each IP family) to the gateway IP in the CIDR. When IPSec
+	// tunnel is enabled,
 	// ipsecTunOFPort must be set to the OFPort number of the IPSec tunnel port to the remote Node;
-	// otherwise ipsecTunOFPort must be set to 0.
+	// otherwise ipsecTunOFPort must be set to 0. When WireGuard is enabled, tunOFPort must be set
+	// to 0, and the traffic to the remote Node is sent to the host gateway, to be encrypted by the
+	// WireGuard device.
 	// InstallNodeFlows has all-or-nothing semantics(call succeeds if all the flows are installed
 	// successfully, otherwise no flows will be installed). Calls to InstallNodeFlows are idempotent.
 	// Concurrent calls to InstallNodeFlows and / or UninstallNodeFlows are supported as long as they
@@ -63,8 +101,8 @@ type Client interface {
 	InstallNodeFlows(
 		hostname string,
 		localGatewayMAC net.HardwareAddr,
-		peerPodCIDR net.IPNet,
-		peerGatewayIP, tunnelPeerIP net.IP,
+		peerConfigs map[*net.IPNet]net.IP,
+		tunnelPeerIP net.IP,
 		tunOFPort, ipsecTunOFPort uint32) error
 
 	// UninstallNodeFlows removes the connection to the remote Node specified with the
@@ -76,8 +114,9 @@ type Client interface {
 	// semantics(call succeeds if all the flows are installed successfully, otherwise no
 	// flows will be installed). Calls to InstallPodFlows are idempotent. Concurrent calls
 	// to InstallPodFlows and / or UninstallPodFlows are supported as long as they are all
-	// for different interfaceNames.
-	InstallPodFlows(interfaceName string, podInterfaceIP net.IP, podInterfaceMAC, gatewayMAC net.HardwareAddr, ofPort uint32) error
+	// for different interfaceNames. podInterfaceIPs includes at most one IP address of each IP
+	// family.
+	InstallPodFlows(interfaceName string, podInterfaceIPs []net.IP, podInterfaceMAC, gatewayMAC net.HardwareAddr, ofPort uint32) error
 
 	// UninstallPodFlows removes the connection to the local Pod specified with the
 	// interfaceName. UninstallPodFlows will do nothing if no connection to the Pod was established.
@@ -109,6 +148,24 @@ type Client interface {
 	// in the connection tracking context, and 3) SNAT the packets with Node IP.
 	InstallExternalFlows(nodeIP net.IP, localSubnet net.IPNet) error
 
+	// InstallSNATMarkFlows installs the flow which SNATs the new connections marked with snatMark to snatIP. It must be
+	// called for the egress IPs hosted by this Node only. Only IPv4 is supported. Calls to InstallSNATMarkFlows are
+	// idempotent.
+	InstallSNATMarkFlows(snatIP net.IP, snatMark uint32) error
+
+	// UninstallSNATMarkFlows removes the flow installed by InstallSNATMarkFlows for the provided snatMark.
+	UninstallSNATMarkFlows(snatMark uint32) error
+
+	// InstallPodSNATFlows installs the flow which makes the packets sent by the Pod with the provided IP to external
+	// destinations be SNATed to its egress IP. If snatMark is not 0, the egress IP is hosted by this Node and the
+	// packets are marked with snatMark, which must have been passed to InstallSNATMarkFlows; the Pod can then be a
+	// local Pod or a remote Pod. Otherwise the Pod must be a local Pod, and its packets are tunneled to the remote
+	// Node with the IP egressNodeIP. Calls to InstallPodSNATFlows are idempotent.
+	InstallPodSNATFlows(podIP net.IP, egressNodeIP net.IP, snatMark uint32) error
+
+	// UninstallPodSNATFlows removes the flow installed by InstallPodSNATFlows for the Pod with the provided IP.
+	UninstallPodSNATFlows(podIP net.IP) error
+
 	// Disconnect disconnects the connection between client and OFSwitch.
 	Disconnect() error
 
@@ -126,7 +183,7 @@ type Client interface {
 	// the new round number.
 	DeleteStaleFlows() error
 
-	// GetTunnelVirtualMAC() returns globalVirtualMAC used for tunnel traffic.
+	// GetTunnelVirtualMAC() returns GlobalVirtualMAC used for tunnel traffic.
 	GetTunnelVirtualMAC() net.HardwareAddr
 
 	// GetPodFlowKeys returns the keys (match strings) of the cached flows for a
@@ -138,6 +195,52 @@ type Client interface {
 	// entries can be added due to conjunctive match flows shared by multiple
 	// rules.
 	GetNetworkPolicyFlowKeys(npName, npNamespace string) []string
+
+	// GetPolicyFromConjunction returns the name and Namespace of the NetworkPolicy which the
+	// rule with the provided conjunction ID belongs to.
+	GetPolicyFromConjunction(ruleID uint32) (string, string)
+
+	// IsDropConjunction returns true if the packets matching the rule with the provided
+	// conjunction ID are dropped by the rule.
+	IsDropConjunction(ruleID uint32) bool
+
+	// NetworkPolicyMetrics returns the traffic statistics of the NetworkPolicy rules installed in
+	// the current round, as a map from the conjunction ID of a rule to its statistics.
+	NetworkPolicyMetrics() map[uint32]*types.RuleMetric
+
+	// InstallTraceflowFlows installs the flows which send the packets tagged with the provided
+	// dataplaneTag to the controller when they are output or dropped by NetworkPolicies.
+	InstallTraceflowFlows(dataplaneTag uint8) error
+
+	// UninstallTraceflowFlows removes the flows installed by InstallTraceflowFlows for the
+	// provided dataplaneTag.
+	UninstallTraceflowFlows(dataplaneTag uint8) error
+
+	// SendTraceflowPacket injects the provided packet tagged with dataplaneTag into the OVS
+	// pipeline as if it was received from inPort.
+	SendTraceflowPacket(dataplaneTag uint8, packet *binding.Packet, inPort uint32) error
+
+	// SendRejectPacket sends the provided packet, i.e. a TCP RST or an ICMP or ICMPv6 Destination
+	// Unreachable message, to reject a connection. The packet is injected into the OVS pipeline as
+	// if it was received from inPort, so that it goes through the conntrack tables like the other
+	// replies of the connection.
+	SendRejectPacket(packet *binding.Packet, inPort uint32) error
+
+	// InstallDNSInterceptFlows installs the flows which send the DNS responses to local Pods to
+	// the controller, so that the agent can learn the addresses of the FQDNs used in NetworkPolicy
+	// rules. The responses must then be forwarded to the Pods with ForwardPacket.
+	InstallDNSInterceptFlows() error
+
+	// UninstallDNSInterceptFlows removes the flows installed by InstallDNSInterceptFlows.
+	UninstallDNSInterceptFlows() error
+
+	// ForwardPacket outputs the Ethernet frame of the provided PacketIn message to outPort
+	// unchanged.
+	ForwardPacket(pktIn *ofctrl.PacketIn, outPort uint32) error
+
+	// SubscribePacketIn registers a consumer to listen to the PacketIn messages with the
+	// provided reason.
+	SubscribePacketIn(reason uint8, ch chan *ofctrl.PacketIn) error
 }
 
 // GetFlowTableStatus returns an array of flow table status.
@@ -196,19 +299,24 @@ func (c *client) deleteFlows(cache *flowCategoryCache, flowCacheKey string) erro
 
 func (c *client) InstallNodeFlows(hostname string,
 	localGatewayMAC net.HardwareAddr,
-	peerPodCIDR net.IPNet,
-	peerGatewayIP, tunnelPeerIP net.IP,
+	peerConfigs map[*net.IPNet]net.IP,
+	tunnelPeerIP net.IP,
 	tunOFPort, ipsecTunOFPort uint32) error {
 	c.replayMutex.RLock()
 	defer c.replayMutex.RUnlock()
 
-	flows := []binding.Flow{
-		c.arpResponderFlow(peerGatewayIP, cookie.Node),
-	}
-	if c.encapMode.NeedsEncapToPeer(tunnelPeerIP, c.nodeConfig.NodeIPAddr) {
-		flows = append(flows, c.l3FwdFlowToRemote(localGatewayMAC, peerPodCIDR, tunnelPeerIP, tunOFPort, cookie.Node))
-	} else {
-		flows = append(flows, c.l3FwdFlowToRemoteViaGW(localGatewayMAC, peerPodCIDR, cookie.Node))
+	var flows []binding.Flow
+	for peerPodCIDR, peerGatewayIP := range peerConfigs {
+		if peerGatewayIP.To4() != nil {
+			// ARP is only used by IPv4. The IPv6 neighbor of the peer gateway is resolved by the
+			// routing client with a permanent neighbor entry.
+			flows = append(flows, c.arpResponderFlow(peerGatewayIP, cookie.Node))
+		}
+		if tunOFPort != 0 && c.encapMode.NeedsEncapToPeer(tunnelPeerIP, c.nodeConfig.NodeIPAddr) {
+			flows = append(flows, c.l3FwdFlowToRemote(localGatewayMAC, *peerPodCIDR, tunnelPeerIP, tunOFPort, cookie.Node))
+		} else {
+			flows = append(flows, c.l3FwdFlowToRemoteViaGW(localGatewayMAC, *peerPodCIDR, cookie.Node))
+		}
 	}
 	if ipsecTunOFPort != 0 {
 		// When IPSec tunnel is enabled, packets received from the remote Node are
@@ -226,24 +334,31 @@ func (c *client) UninstallNodeFlows(hostname string) error {
 	return c.deleteFlows(c.nodeFlowCache, hostname)
 }
 
-func (c *client) InstallPodFlows(interfaceName string, podInterfaceIP net.IP, podInterfaceMAC, gatewayMAC net.HardwareAddr, ofPort uint32) error {
+func (c *client) InstallPodFlows(interfaceName string, podInterfaceIPs []net.IP, podInterfaceMAC, gatewayMAC net.HardwareAddr, ofPort uint32) error {
 	c.replayMutex.RLock()
 	defer c.replayMutex.RUnlock()
 	flows := []binding.Flow{
 		c.podClassifierFlow(ofPort, cookie.Pod),
-		c.podIPSpoofGuardFlow(podInterfaceIP, podInterfaceMAC, ofPort, cookie.Pod),
-		c.arpSpoofGuardFlow(podInterfaceIP, podInterfaceMAC, ofPort, cookie.Pod),
 		c.l2ForwardCalcFlow(podInterfaceMAC, ofPort, cookie.Pod),
 	}
+	flows = append(flows, c.podIPSpoofGuardFlows(podInterfaceIPs, podInterfaceMAC, ofPort, cookie.Pod)...)
+	if podInterfaceIPv4 := ip.GetIPv4Addr(podInterfaceIPs); podInterfaceIPv4 != nil {
+		flows = append(flows, c.arpSpoofGuardFlow(podInterfaceIPv4, podInterfaceMAC, ofPort, cookie.Pod))
+		// A Pod whose IP is allocated from an IPPool is not in the subnet of the host gateway, all its
+		// traffic is sent to the host gateway by answering its ARP requests with the gateway MAC.
+		if !c.encapMode.IsNetworkPolicyOnly() && c.nodeConfig.PodIPv4CIDR != nil && !c.nodeConfig.PodIPv4CIDR.Contains(podInterfaceIPv4) {
+			flows = append(flows, c.arpResponderPoolPodFlow(ofPort, gatewayMAC, cookie.Pod))
+		}
+	}
 
 	// NoEncap mode has no tunnel.
 	if c.encapMode.SupportsEncap() {
-		flows = append(flows, c.l3FlowsToPod(gatewayMAC, podInterfaceIP, podInterfaceMAC, cookie.Pod))
+		flows = append(flows, c.l3FlowsToPod(gatewayMAC, podInterfaceIPs, podInterfaceMAC, cookie.Pod)...)
 	}
 	if c.encapMode.IsNetworkPolicyOnly() {
 		// In policy-only mode, traffic to local Pod is routed based on destination IP.
 		flows = append(flows,
-			c.l3ToPodFlow(podInterfaceIP, podInterfaceMAC, cookie.Pod),
+			c.l3ToPodFlows(podInterfaceIPs, podInterfaceMAC, cookie.Pod)...,
 		)
 	}
 	return c.addFlows(c.podFlowCache, interfaceName, flows)
@@ -275,31 +390,113 @@ func (c *client) GetPodFlowKeys(interfaceName string) []string {
 }
 
 func (c *client) InstallClusterServiceCIDRFlows(serviceNet *net.IPNet, gatewayMAC net.HardwareAddr, gatewayOFPort uint32) error {
-	flow := c.serviceCIDRDNATFlow(serviceNet, gatewayMAC, gatewayOFPort, cookie.Service)
-	if err := c.ofEntryOperations.Add(flow); err != nil {
+	flows := []binding.Flow{c.serviceCIDRDNATFlow(serviceNet, gatewayMAC, gatewayOFPort, cookie.Service)}
+	if c.enableEgress && serviceNet.IP.To4() != nil {
+		flows = append(flows, c.snatServiceCIDRFlow(serviceNet, cookie.SNAT))
+	}
+	if err := c.ofEntryOperations.AddAll(flows); err != nil {
 		return err
 	}
-	c.clusterServiceCIDRFlows = []binding.Flow{flow}
+	c.clusterServiceCIDRFlows = flows
 	return nil
 }
 
-func (c *client) InstallGatewayFlows(gatewayAddr net.IP, gatewayMAC net.HardwareAddr, gatewayOFPort uint32) error {
+// generateServicePortFlowCacheKey generates the key of serviceFlowCache for the flows of a Service or an Endpoint
+// with the provided IP, port and protocol.
+func generateServicePortFlowCacheKey(ip net.IP, port uint16, protocol binding.Protocol) string {
+	return fmt.Sprintf("%s/%s", net.JoinHostPort(ip.String(), strconv.Itoa(int(port))), protocol)
+}
+
+func (c *client) InstallServiceGroup(groupID binding.GroupIDType, withSessionAffinity bool, endpoints []types.Endpoint) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	_, installed := c.groupCache.Load(groupID)
+	group := c.serviceEndpointGroup(groupID, withSessionAffinity, endpoints)
+	var err error
+	if installed {
+		err = group.Modify()
+	} else {
+		err = group.Add()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to install group %d for Service: %v", groupID, err)
+	}
+	c.groupCache.Store(groupID, group)
+	return nil
+}
+
+func (c *client) UninstallServiceGroup(groupID binding.GroupIDType) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	if !c.bridge.DeleteGroup(groupID) {
+		return fmt.Errorf("failed to delete group %d for Service", groupID)
+	}
+	c.groupCache.Delete(groupID)
+	return nil
+}
+
+func (c *client) InstallEndpointFlows(protocol binding.Protocol, endpoints []types.Endpoint) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	for _, endpoint := range endpoints {
+		cacheKey := generateServicePortFlowCacheKey(endpoint.IP, endpoint.Port, protocol)
+		flows := []binding.Flow{c.endpointDNATFlow(endpoint, protocol), c.endpointHairpinDNATFlow(endpoint, protocol)}
+		if err := c.addFlows(c.serviceFlowCache, cacheKey, flows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *client) UninstallEndpointFlows(protocol binding.Protocol, endpoint types.Endpoint) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	cacheKey := generateServicePortFlowCacheKey(endpoint.IP, endpoint.Port, protocol)
+	return c.deleteFlows(c.serviceFlowCache, cacheKey)
+}
+
+func (c *client) InstallServiceFlows(groupID binding.GroupIDType, svcIP net.IP, svcPort uint16, protocol binding.Protocol, affinityTimeout uint16) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	cacheKey := generateServicePortFlowCacheKey(svcIP, svcPort, protocol)
+	flows := []binding.Flow{c.serviceLBFlow(groupID, svcIP, svcPort, protocol, affinityTimeout)}
+	if affinityTimeout != 0 {
+		flows = append(flows,
+			c.sessionAffinityLookupFlow(svcIP, svcPort, protocol),
+			c.sessionAffinityLearnFlow(svcIP, svcPort, protocol, affinityTimeout))
+	}
+	return c.addFlows(c.serviceFlowCache, cacheKey, flows)
+}
+
+func (c *client) UninstallServiceFlows(svcIP net.IP, svcPort uint16, protocol binding.Protocol) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	cacheKey := generateServicePortFlowCacheKey(svcIP, svcPort, protocol)
+	return c.deleteFlows(c.serviceFlowCache, cacheKey)
+}
+
+func (c *client) InstallGatewayFlows(gatewayAddrs []net.IP, gatewayMAC net.HardwareAddr, gatewayOFPort uint32) error {
 	flows := []binding.Flow{
 		c.gatewayClassifierFlow(gatewayOFPort, cookie.Default),
-		c.gatewayIPSpoofGuardFlow(gatewayOFPort, cookie.Default),
-		c.gatewayARPSpoofGuardFlow(gatewayOFPort, gatewayAddr, gatewayMAC, cookie.Default),
-		c.ctRewriteDstMACFlow(gatewayMAC, cookie.Default),
 		c.l2ForwardCalcFlow(gatewayMAC, gatewayOFPort, cookie.Default),
-		c.localProbeFlow(gatewayAddr, cookie.Default),
+	}
+	flows = append(flows, c.gatewayIPSpoofGuardFlows(gatewayOFPort, cookie.Default)...)
+	if gatewayIPv4 := ip.GetIPv4Addr(gatewayAddrs); gatewayIPv4 != nil {
+		flows = append(flows, c.gatewayARPSpoofGuardFlow(gatewayOFPort, gatewayIPv4, gatewayMAC, cookie.Default))
+	}
+	flows = append(flows, c.ctRewriteDstMACFlows(gatewayMAC, cookie.Default)...)
+	flows = append(flows, c.localProbeFlows(gatewayAddrs, cookie.Default)...)
+	if c.enableEgress {
+		flows = append(flows, c.snatCommonFlows(c.nodeConfig.NodeIPAddr.IP, ip.GetIPv4Addr(gatewayAddrs), gatewayMAC, cookie.SNAT)...)
 	}
 
 	// In NoEncap , no traffic from tunnel port
 	if c.encapMode.SupportsEncap() {
-		flows = append(flows, c.l3ToGatewayFlow(gatewayAddr, gatewayMAC, cookie.Default))
+		flows = append(flows, c.l3ToGatewayFlows(gatewayAddrs, gatewayMAC, cookie.Default)...)
 	}
 
 	if c.encapMode.SupportsNoEncap() {
-		flows = append(flows, c.reEntranceBypassCTFlow(gatewayOFPort, gatewayOFPort, cookie.Default))
+		flows = append(flows, c.reEntranceBypassCTFlows(gatewayOFPort, gatewayOFPort, cookie.Default)...)
 	}
 
 	if err := c.ofEntryOperations.AddAll(flows); err != nil {
@@ -328,6 +525,127 @@ func (c *client) InstallBridgeUplinkFlows(uplinkPort uint32, bridgeLocalPort uin
 	return nil
 }
 
+func (c *client) InstallTraceflowFlows(dataplaneTag uint8) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	flows := []binding.Flow{c.traceflowOutputFlow(dataplaneTag)}
+	flows = append(flows, c.traceflowNetworkPolicyFlows(dataplaneTag)...)
+	return c.addFlows(c.tfFlowCache, strconv.Itoa(int(dataplaneTag)), flows)
+}
+
+func (c *client) UninstallTraceflowFlows(dataplaneTag uint8) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	return c.deleteFlows(c.tfFlowCache, strconv.Itoa(int(dataplaneTag)))
+}
+
+func (c *client) SendTraceflowPacket(dataplaneTag uint8, packet *binding.Packet, inPort uint32) error {
+	packetOutBuilder := c.bridge.BuildPacketOut().
+		SetSrcMAC(packet.SourceMAC).
+		SetDstMAC(packet.DestinationMAC).
+		SetSrcIP(packet.SourceIP).
+		SetDstIP(packet.DestinationIP).
+		SetTTL(packet.TTL).
+		SetIPFlags(packet.IPFlags).
+		SetIPDscp(dataplaneTag).
+		SetInport(inPort)
+	switch packet.IPProto {
+	case protocol.Type_ICMP:
+		packetOutBuilder = packetOutBuilder.SetIPProtocol(binding.ProtocolICMP).
+			SetICMPType(icmpEchoRequestType).
+			SetICMPCode(0).
+			SetICMPID(packet.ICMPEchoID).
+			SetICMPSequence(packet.ICMPEchoSeq)
+	case protocol.Type_TCP:
+		packetOutBuilder = packetOutBuilder.SetIPProtocol(binding.ProtocolTCP).
+			SetTCPSrcPort(packet.SourcePort).
+			SetTCPDstPort(packet.DestinationPort).
+			SetTCPFlags(packet.TCPFlags)
+	case protocol.Type_UDP:
+		packetOutBuilder = packetOutBuilder.SetIPProtocol(binding.ProtocolUDP).
+			SetUDPSrcPort(packet.SourcePort).
+			SetUDPDstPort(packet.DestinationPort)
+	default:
+		return fmt.Errorf("unsupported IP protocol %d", packet.IPProto)
+	}
+	return c.bridge.SendPacketOut(packetOutBuilder.Done())
+}
+
+func (c *client) SendRejectPacket(packet *binding.Packet, inPort uint32) error {
+	packetOutBuilder := c.bridge.BuildPacketOut().
+		SetSrcMAC(packet.SourceMAC).
+		SetDstMAC(packet.DestinationMAC).
+		SetSrcIP(packet.SourceIP).
+		SetDstIP(packet.DestinationIP).
+		SetTTL(packet.TTL).
+		SetInport(inPort)
+	switch packet.IPProto {
+	case protocol.Type_ICMP, protocol.Type_IPv6ICMP:
+		icmpProtocol := binding.ProtocolICMP
+		if packet.IPProto == protocol.Type_IPv6ICMP {
+			icmpProtocol = binding.ProtocolICMPv6
+		}
+		packetOutBuilder = packetOutBuilder.SetIPProtocol(icmpProtocol).
+			SetICMPType(packet.ICMPType).
+			SetICMPCode(packet.ICMPCode).
+			SetICMPData(packet.ICMPData)
+	case protocol.Type_TCP:
+		packetOutBuilder = packetOutBuilder.SetIPProtocol(binding.ProtocolTCP).
+			SetTCPSrcPort(packet.SourcePort).
+			SetTCPDstPort(packet.DestinationPort).
+			SetTCPFlags(packet.TCPFlags)
+		if packet.TCPSeqNum != nil {
+			packetOutBuilder = packetOutBuilder.SetTCPSeqNum(*packet.TCPSeqNum)
+		}
+		if packet.TCPAckNum != nil {
+			packetOutBuilder = packetOutBuilder.SetTCPAckNum(*packet.TCPAckNum)
+		}
+	default:
+		return fmt.Errorf("unsupported IP protocol %d", packet.IPProto)
+	}
+	return c.bridge.SendPacketOut(packetOutBuilder.Done())
+}
+
+// dnsFlowCacheKey is the key of the flows intercepting the DNS responses in dnsFlowCache.
+const dnsFlowCacheKey = "dns-intercept"
+
+func (c *client) InstallDNSInterceptFlows() error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	flows, err := c.dnsInterceptFlows(cookie.Policy)
+	if err != nil {
+		return err
+	}
+	return c.addFlows(c.dnsFlowCache, dnsFlowCacheKey, flows)
+}
+
+func (c *client) UninstallDNSInterceptFlows() error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	return c.deleteFlows(c.dnsFlowCache, dnsFlowCacheKey)
+}
+
+func (c *client) ForwardPacket(pktIn *ofctrl.PacketIn, outPort uint32) error {
+	return c.bridge.SendPacketOutFrame(&pktIn.Data, outPort)
+}
+
+func (c *client) SubscribePacketIn(reason uint8, ch chan *ofctrl.PacketIn) error {
+	return c.bridge.SubscribePacketIn(reason, ch)
+}
+
+// GetMatchRegField returns the value of the provided register in the match of a PacketIn
+// message, or 0 if the register is not set.
+func GetMatchRegField(match *openflow13.Match, reg int) uint32 {
+	for _, field := range match.Fields {
+		if field.Class == openflow13.OXM_CLASS_NXM_1 && int(field.Field) == openflow13.NXM_NX_REG0+reg {
+			if value, ok := field.Value.(*openflow13.Uint32Message); ok {
+				return value.Data
+			}
+		}
+	}
+	return 0
+}
+
 func (c *client) initialize() error {
 	if err := c.ofEntryOperations.AddAll(c.defaultFlows()); err != nil {
 		return fmt.Errorf("failed to install default flows: %v", err)
@@ -335,7 +653,7 @@ func (c *client) initialize() error {
 	if err := c.ofEntryOperations.Add(c.arpNormalFlow(cookie.Default)); err != nil {
 		return fmt.Errorf("failed to install arp normal flow: %v", err)
 	}
-	if err := c.ofEntryOperations.Add(c.l2ForwardOutputFlow(cookie.Default)); err != nil {
+	if err := c.ofEntryOperations.AddAll(c.l2ForwardOutputFlows(cookie.Default)); err != nil {
 		return fmt.Errorf("failed to install L2 forward output flows: %v", err)
 	}
 	if err := c.ofEntryOperations.AddAll(c.connectionTrackFlows(cookie.Default)); err != nil {
@@ -344,9 +662,19 @@ func (c *client) initialize() error {
 	if err := c.ofEntryOperations.AddAll(c.establishedConnectionFlows(cookie.Default)); err != nil {
 		return fmt.Errorf("failed to install flows to skip established connections: %v", err)
 	}
+	if c.enableProxy {
+		if err := c.ofEntryOperations.AddAll(c.serviceHairpinFlows(cookie.Default)); err != nil {
+			return fmt.Errorf("failed to install Service hairpin flows: %v", err)
+		}
+	}
+	if c.isIPv6Enabled() {
+		if err := c.ofEntryOperations.AddAll(c.ipv6Flows(cookie.Default)); err != nil {
+			return fmt.Errorf("failed to install flows for IPv6: %v", err)
+		}
+	}
 
 	if c.encapMode.SupportsNoEncap() {
-		if err := c.ofEntryOperations.Add(c.l2ForwardOutputReentInPortFlow(c.gatewayPort, cookie.Default)); err != nil {
+		if err := c.ofEntryOperations.AddAll(c.l2ForwardOutputReentInPortFlows(c.gatewayPort, cookie.Default)); err != nil {
 			return fmt.Errorf("failed to install L2 forward same in-port and out-port flow: %v", err)
 		}
 	}
@@ -362,6 +690,13 @@ func (c *client) Initialize(roundInfo types.RoundInfo, nodeConfig *config.NodeCo
 	c.nodeConfig = nodeConfig
 	c.encapMode = encapMode
 	c.gatewayPort = gatewayOFPort
+	// In policy-only mode, the Pod CIDRs are not allocated by Kubernetes, and only IPv4 is supported.
+	if nodeConfig.PodIPv4CIDR != nil || nodeConfig.PodIPv6CIDR == nil {
+		c.ipProtocols = append(c.ipProtocols, binding.ProtocolIP)
+	}
+	if nodeConfig.PodIPv6CIDR != nil {
+		c.ipProtocols = append(c.ipProtocols, binding.ProtocolIPv6)
+	}
 
 	// Initiate connections to target OFswitch, and create tables on the switch.
 	connCh := make(chan struct{})
@@ -396,6 +731,54 @@ func (c *client) InstallExternalFlows(nodeIP net.IP, localSubnet net.IPNet) erro
 	return nil
 }
 
+func (c *client) InstallSNATMarkFlows(snatIP net.IP, snatMark uint32) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+
+	flow := c.snatIPFlow(snatIP, snatMark, cookie.SNAT)
+	return c.addFlows(c.snatFlowCache, snatMarkFlowCacheKey(snatMark), []binding.Flow{flow})
+}
+
+func (c *client) UninstallSNATMarkFlows(snatMark uint32) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	return c.deleteFlows(c.snatFlowCache, snatMarkFlowCacheKey(snatMark))
+}
+
+func (c *client) InstallPodSNATFlows(podIP net.IP, egressNodeIP net.IP, snatMark uint32) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+
+	var flow binding.Flow
+	if snatMark != 0 {
+		flow = c.podSNATFlow(podIP, snatMark, c.nodeConfig.GatewayConfig.MAC, cookie.SNAT)
+	} else {
+		flow = c.podSNATFlowToRemote(podIP, egressNodeIP, c.nodeConfig.GatewayConfig.MAC, config.DefaultTunOFPort, cookie.SNAT)
+	}
+	cacheKey := podIP.String()
+	// The flow of a Pod always has the same match, and its actions are replaced if the egress IP of the Pod has
+	// changed.
+	if _, ok := c.snatFlowCache.Load(cacheKey); ok {
+		if err := c.ofEntryOperations.Modify(flow); err != nil {
+			return err
+		}
+		c.snatFlowCache.Store(cacheKey, flowCache{flow.MatchString(): flow})
+		return nil
+	}
+	return c.addFlows(c.snatFlowCache, cacheKey, []binding.Flow{flow})
+}
+
+func (c *client) UninstallPodSNATFlows(podIP net.IP) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	return c.deleteFlows(c.snatFlowCache, podIP.String())
+}
+
+// snatMarkFlowCacheKey returns the key of the flows installed for an egress IP in snatFlowCache.
+func snatMarkFlowCacheKey(snatMark uint32) string {
+	return fmt.Sprintf("snat-mark-%d", snatMark)
+}
+
 func (c *client) ReplayFlows() {
 	c.replayMutex.Lock()
 	defer c.replayMutex.Unlock()
@@ -422,6 +805,16 @@ func (c *client) ReplayFlows() {
 		addFixedFlows(c.hostNetworkingFlows)
 	}
 
+	// The groups must be installed before the flows which refer to them.
+	c.groupCache.Range(func(id, value interface{}) bool {
+		group := value.(binding.Group)
+		group.Reset()
+		if err := c.ofEntryOperations.AddOFEntries([]binding.OFEntry{group}); err != nil {
+			klog.Errorf("Error when replaying cached group %d: %v", id, err)
+		}
+		return true
+	})
+
 	installCachedFlows := func(key, value interface{}) bool {
 		fCache := value.(flowCache)
 		cachedFlows := make([]binding.Flow, 0)
@@ -439,6 +832,10 @@ func (c *client) ReplayFlows() {
 
 	c.nodeFlowCache.Range(installCachedFlows)
 	c.podFlowCache.Range(installCachedFlows)
+	c.tfFlowCache.Range(installCachedFlows)
+	c.dnsFlowCache.Range(installCachedFlows)
+	c.serviceFlowCache.Range(installCachedFlows)
+	c.snatFlowCache.Range(installCachedFlows)
 
 	c.replayPolicyFlows()
 }
@@ -457,14 +854,12 @@ func (c *client) DeleteStaleFlows() error {
 }
 
 func (c *client) setupPolicyOnlyFlows() error {
-	flows := []binding.Flow{
-		// Bypasses remaining l3forwarding flows if the MAC is set via ctRewriteDstMACFlow.
-		c.l3BypassMACRewriteFlow(c.nodeConfig.GatewayConfig.MAC, cookie.Default),
-		// Rewrites MAC to gw port if the packet received is unmatched by local Pod flows.
-		c.l3ToGWFlow(c.nodeConfig.GatewayConfig.MAC, cookie.Default),
-		// Replies any ARP request with the same global virtual MAC.
-		c.arpResponderStaticFlow(cookie.Default),
-	}
+	// Bypasses remaining l3forwarding flows if the MAC is set via ctRewriteDstMACFlows.
+	flows := c.l3BypassMACRewriteFlows(c.nodeConfig.GatewayConfig.MAC, cookie.Default)
+	// Rewrites MAC to gw port if the packet received is unmatched by local Pod flows.
+	flows = append(flows, c.l3ToGWFlows(c.nodeConfig.GatewayConfig.MAC, cookie.Default)...)
+	// Replies any ARP request with the same global virtual MAC.
+	flows = append(flows, c.arpResponderStaticFlow(cookie.Default))
 	if err := c.ofEntryOperations.AddAll(flows); err != nil {
 		return fmt.Errorf("failed to setup policy-only flows: %w", err)
 	}
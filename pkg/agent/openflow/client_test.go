@@ -26,10 +26,13 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
 
 	"github.com/vmware-tanzu/antrea/pkg/agent/config"
 	"github.com/vmware-tanzu/antrea/pkg/agent/openflow/cookie"
 	oftest "github.com/vmware-tanzu/antrea/pkg/agent/openflow/testing"
+	"github.com/vmware-tanzu/antrea/pkg/agent/types"
+	"github.com/vmware-tanzu/antrea/pkg/features"
 	ofconfig "github.com/vmware-tanzu/antrea/pkg/ovs/openflow"
 	"github.com/vmware-tanzu/antrea/pkg/ovs/ovsconfig"
 )
@@ -43,7 +46,10 @@ func installNodeFlows(ofClient Client, cacheKey string) (int, error) {
 	gwMAC, _ := net.ParseMAC("AA:BB:CC:DD:EE:FF")
 	gwIP, IPNet, _ := net.ParseCIDR("10.0.1.1/24")
 	peerNodeIP := net.ParseIP("192.168.1.1")
-	err := ofClient.InstallNodeFlows(hostName, gwMAC, *IPNet, gwIP, peerNodeIP, config.DefaultTunOFPort, 0)
+	peerConfig := map[*net.IPNet]net.IP{
+		IPNet: gwIP,
+	}
+	err := ofClient.InstallNodeFlows(hostName, gwMAC, peerConfig, peerNodeIP, config.DefaultTunOFPort, 0)
 	client := ofClient.(*client)
 	fCacheI, ok := client.nodeFlowCache.Load(hostName)
 	if ok {
@@ -59,7 +65,7 @@ func installPodFlows(ofClient Client, cacheKey string) (int, error) {
 	podMAC, _ := net.ParseMAC("AA:BB:CC:DD:EE:EE")
 	podIP := net.ParseIP("10.0.0.2")
 	ofPort := uint32(10)
-	err := ofClient.InstallPodFlows(containerID, podIP, podMAC, gwMAC, ofPort)
+	err := ofClient.InstallPodFlows(containerID, []net.IP{podIP}, podMAC, gwMAC, ofPort)
 	client := ofClient.(*client)
 	fCacheI, ok := client.podFlowCache.Load(containerID)
 	if ok {
@@ -69,7 +75,20 @@ func installPodFlows(ofClient Client, cacheKey string) (int, error) {
 	}
 }
 
-// TestIdempotentFlowInstallation checks that InstallNodeFlows and InstallPodFlows are idempotent.
+func installEndpointFlows(ofClient Client, cacheKey string) (int, error) {
+	endpoint := types.Endpoint{IP: net.ParseIP("10.0.0.2"), Port: 8080}
+	err := ofClient.InstallEndpointFlows(ofconfig.ProtocolTCP, []types.Endpoint{endpoint})
+	client := ofClient.(*client)
+	fCacheI, ok := client.serviceFlowCache.Load(generateServicePortFlowCacheKey(endpoint.IP, endpoint.Port, ofconfig.ProtocolTCP))
+	if ok {
+		return len(fCacheI.(flowCache)), err
+	} else {
+		return 0, err
+	}
+}
+
+// TestIdempotentFlowInstallation checks that InstallNodeFlows, InstallPodFlows and InstallEndpointFlows are
+// idempotent.
 func TestIdempotentFlowInstallation(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -79,6 +98,7 @@ func TestIdempotentFlowInstallation(t *testing.T) {
 	}{
 		{"NodeFlows", "host", 2, installNodeFlows},
 		{"PodFlows", "aaaa-bbbb-cccc-dddd", 5, installPodFlows},
+		{"EndpointFlows", "", 2, installEndpointFlows},
 	}
 
 	// Check the flows are installed only once even though InstallNodeFlows/InstallPodFlows is called multiple times.
@@ -149,6 +169,7 @@ func TestFlowInstallationFailed(t *testing.T) {
 	}{
 		{"NodeFlows", "host", 2, installNodeFlows},
 		{"PodFlows", "aaaa-bbbb-cccc-dddd", 5, installPodFlows},
+		{"EndpointFlows", "", 2, installEndpointFlows},
 	}
 
 	for _, tc := range testCases {
@@ -237,3 +258,81 @@ func TestConcurrentFlowInstallation(t *testing.T) {
 	}
 
 }
+
+// TestPodSNATFlowsUpdate checks that the SNAT flow of a Pod is replaced when its egress IP moves between Nodes.
+func TestPodSNATFlowsUpdate(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.Egress, true)()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := oftest.NewMockOFEntryOperations(ctrl)
+	ofClient := NewClient(bridgeName, bridgeMgmtAddr)
+	client := ofClient.(*client)
+	client.cookieAllocator = cookie.NewAllocator(0)
+	gwMAC, _ := net.ParseMAC("AA:BB:CC:DD:EE:FF")
+	client.nodeConfig = &config.NodeConfig{GatewayConfig: &config.GatewayConfig{MAC: gwMAC}}
+	client.ofEntryOperations = m
+
+	podIP := net.ParseIP("10.0.0.2")
+	snatIP := net.ParseIP("192.168.1.100")
+	m.EXPECT().AddAll(gomock.Any()).Return(nil).Times(2)
+	require.NoError(t, ofClient.InstallSNATMarkFlows(snatIP, 1))
+	require.NoError(t, ofClient.InstallPodSNATFlows(podIP, nil, 1))
+	fCacheI, ok := client.snatFlowCache.Load(podIP.String())
+	require.True(t, ok)
+	var localFlow ofconfig.Flow
+	for _, flow := range fCacheI.(flowCache) {
+		localFlow = flow
+	}
+
+	// The egress IP moves to a remote Node: the flow is modified in place.
+	m.EXPECT().Modify(gomock.Any()).Return(nil).Times(1)
+	require.NoError(t, ofClient.InstallPodSNATFlows(podIP, net.ParseIP("192.168.1.2"), 0))
+	fCacheI, ok = client.snatFlowCache.Load(podIP.String())
+	require.True(t, ok)
+	fCache := fCacheI.(flowCache)
+	require.Len(t, fCache, 1)
+	assert.Contains(t, fCache, localFlow.MatchString())
+
+	m.EXPECT().DeleteAll(gomock.Any()).Return(nil).Times(2)
+	require.NoError(t, ofClient.UninstallPodSNATFlows(podIP))
+	require.NoError(t, ofClient.UninstallSNATMarkFlows(1))
+	_, ok = client.snatFlowCache.Load(podIP.String())
+	assert.False(t, ok)
+}
+
+// TestServiceHairpinFlows checks that the connections from an Endpoint to itself are marked when they are DNAT'd, and
+// that the packets of the marked connections are sent back through their ingress port.
+func TestServiceHairpinFlows(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.AntreaProxy, true)()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := oftest.NewMockOFEntryOperations(ctrl)
+	ofClient := NewClient(bridgeName, bridgeMgmtAddr)
+	client := ofClient.(*client)
+	client.cookieAllocator = cookie.NewAllocator(0)
+	client.ofEntryOperations = m
+
+	endpoint := types.Endpoint{IP: net.ParseIP("10.0.0.2"), Port: 8080}
+	var endpointFlows []string
+	m.EXPECT().AddAll(gomock.Any()).DoAndReturn(func(flows []ofconfig.Flow) error {
+		for _, flow := range flows {
+			endpointFlows = append(endpointFlows, flow.MatchString())
+		}
+		return nil
+	})
+	require.NoError(t, ofClient.InstallEndpointFlows(ofconfig.ProtocolTCP, []types.Endpoint{endpoint}))
+	assert.ElementsMatch(t, []string{
+		"table=40,tcp,reg3=0xa000002",
+		"table=40,tcp,nw_src=10.0.0.2,reg3=0xa000002",
+	}, endpointFlows)
+
+	var hairpinFlows []string
+	for _, flow := range client.serviceHairpinFlows(cookie.Default) {
+		hairpinFlows = append(hairpinFlows, flow.MatchString())
+	}
+	assert.ElementsMatch(t, []string{
+		"table=30,ip,nw_dst=169.254.169.252",
+		"table=110,ip,ct_mark=128,ct_state=-rpl+trk",
+		"table=110,ip,ct_mark=128,ct_state=+rpl+trk",
+	}, hairpinFlows)
+}
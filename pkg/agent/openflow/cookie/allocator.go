@@ -22,8 +22,10 @@ const (
 	BitwidthRound           = 16
 	BitwidthCategory        = 8
 	BitwidthReserved        = 64 - BitwidthCategory - BitwidthRound
+	BitwidthObjectID        = 32
 	RoundMask        uint64 = 0xffff_0000_0000_0000
 	CategoryMask     uint64 = 0x0000_ff00_0000_0000
+	ObjectIDMask     uint64 = 0x0000_0000_ffff_ffff
 )
 
 // Category represents the flow entry category.
@@ -37,6 +39,8 @@ const (
 	Service
 	Policy
 	SNAT
+	Traceflow
+	PolicyMetric
 )
 
 func (c Category) String() string {
@@ -55,22 +59,30 @@ func (c Category) String() string {
 		return "Policy"
 	case SNAT:
 		return "SNAT"
+	case Traceflow:
+		return "Traceflow"
+	case PolicyMetric:
+		return "PolicyMetric"
 	default:
 		return "Invalid"
 	}
 }
 
 // ID defines segments a cookie ID contains. An ID is composed like:
-//  |------------------------- ID --------------------------|
-//  |- round 16bits -|- category 8bits -|- reserved 40bits -|
+//  |------------------------------------- ID --------------------------------------|
+//  |- round 16bits -|- category 8bits -|- unused 8bits -|-   object ID 32bits    -|
 // The round segment represents the round id.
 // The category segment represents the category of flow this ID belongs.
+// The object ID segment identifies the object the flow is installed for, e.g. the
+// conjunction ID of a NetworkPolicy rule. It is 0 if the flow is not installed for
+// a specific object.
 type ID uint64
 
-func newID(round uint64, cat Category) ID {
+func newID(round uint64, cat Category, objectID uint32) ID {
 	r := uint64(0)
 	r |= round << (64 - BitwidthRound)
 	r |= (uint64(cat) << BitwidthReserved) & CategoryMask
+	r |= uint64(objectID) & ObjectIDMask
 	return ID(r)
 }
 
@@ -95,8 +107,16 @@ func (i ID) Category() Category {
 	return Category((i.Raw() & CategoryMask) >> BitwidthReserved)
 }
 
+// ObjectID returns the object ID of the ID.
+func (i ID) ObjectID() uint32 {
+	return uint32(i.Raw() & ObjectIDMask)
+}
+
 // String returns the string representation of the ID.
 func (i ID) String() string {
+	if i.ObjectID() != 0 {
+		return fmt.Sprintf("<round:%d,category:%s,object:%d>", i.Round(), i.Category().String(), i.ObjectID())
+	}
 	return fmt.Sprintf("<round:%d,category:%s>", i.Round(), i.Category().String())
 }
 
@@ -104,6 +124,9 @@ func (i ID) String() string {
 type Allocator interface {
 	// Request cookie IDs of flow categories.
 	Request(cat Category) ID
+	// RequestWithObjectID requests cookie IDs of flow categories which
+	// identify the object the flows are installed for.
+	RequestWithObjectID(cat Category, objectID uint32) ID
 }
 
 type allocator struct {
@@ -112,7 +135,12 @@ type allocator struct {
 
 // Request returns a ID with the given category.
 func (a *allocator) Request(cat Category) ID {
-	return newID(a.round, cat)
+	return newID(a.round, cat, 0)
+}
+
+// RequestWithObjectID returns a ID with the given category and object ID.
+func (a *allocator) RequestWithObjectID(cat Category, objectID uint32) ID {
+	return newID(a.round, cat, objectID)
 }
 
 // NewAllocator creates a cookie ID allocator by using the given round number.
@@ -73,3 +73,15 @@ func TestConcurrentAllocate(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestRequestWithObjectID(t *testing.T) {
+	a := NewAllocator(3)
+	id := a.RequestWithObjectID(Policy, 0xffff_fff0)
+	assert.Equal(t, uint64(3), id.Round())
+	assert.Equal(t, Policy, id.Category())
+	assert.Equal(t, uint32(0xffff_fff0), id.ObjectID())
+	assert.Equal(t, "<round:3,category:Policy,object:4294967280>", id.String())
+
+	assert.Equal(t, a.Request(Policy).Raw(), id.Raw()&(RoundMask|CategoryMask))
+	assert.Equal(t, uint32(0), a.Request(Policy).ObjectID())
+}
@@ -20,8 +20,10 @@ import (
 
 	"k8s.io/klog"
 
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow/cookie"
 	"github.com/vmware-tanzu/antrea/pkg/agent/types"
 	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
 	binding "github.com/vmware-tanzu/antrea/pkg/ovs/openflow"
 )
 
@@ -35,6 +37,17 @@ const (
 	MatchTCPDstPort
 	MatchUDPDstPort
 	MatchSCTPDstPort
+	MatchDstIPv6
+	MatchSrcIPv6
+	MatchDstIPNetv6
+	MatchSrcIPNetv6
+	MatchDstOFPortv6
+	MatchSrcOFPortv6
+	MatchTCPv6DstPort
+	MatchUDPv6DstPort
+	MatchSCTPv6DstPort
+	MatchICMP
+	MatchICMPv6
 	Unsupported
 )
 
@@ -42,10 +55,17 @@ const (
 type IPAddress net.IP
 
 func (a *IPAddress) GetMatchKey(addrType types.AddressType) int {
+	isIPv6 := net.IP(*a).To4() == nil
 	switch addrType {
 	case types.SrcAddress:
+		if isIPv6 {
+			return MatchSrcIPv6
+		}
 		return MatchSrcIP
 	case types.DstAddress:
+		if isIPv6 {
+			return MatchDstIPv6
+		}
 		return MatchDstIP
 	default:
 		klog.Errorf("Unknown AddressType %d in IPAddress", addrType)
@@ -71,10 +91,17 @@ func NewIPAddress(addr net.IP) *IPAddress {
 type IPNetAddress net.IPNet
 
 func (a *IPNetAddress) GetMatchKey(addrType types.AddressType) int {
+	isIPv6 := a.IP.To4() == nil
 	switch addrType {
 	case types.SrcAddress:
+		if isIPv6 {
+			return MatchSrcIPNetv6
+		}
 		return MatchSrcIPNet
 	case types.DstAddress:
+		if isIPv6 {
+			return MatchDstIPNetv6
+		}
 		return MatchDstIPNet
 	default:
 		klog.Errorf("Unknown AddressType %d in IPNetAddress", addrType)
@@ -142,10 +169,13 @@ func newConjunctionNotFound(conjunctionID uint32) *ConjunctionNotFound {
 // IP address, ofport number of OVS interface, or Service port. When conjunctiveMatch is used to match IP
 // address or ofport number, matchProtocol is "ip". When conjunctiveMatch is used to match Service
 // port, matchProtocol is Service protocol. If Service protocol is not set, "tcp" is used by default.
+// priority is the Openflow priority of the conjunctive match flow, which is only set for ClusterNetworkPolicy rules,
+// as conjunctive match flows can be shared only by the rules with the same priority.
 type conjunctiveMatch struct {
 	tableID    binding.TableIDType
 	matchKey   int
 	matchValue interface{}
+	priority   *uint16
 }
 
 func (m *conjunctiveMatch) generateGlobalMapKey() string {
@@ -157,13 +187,21 @@ func (m *conjunctiveMatch) generateGlobalMapKey() string {
 		// keys for IP and IP/32. Use MatchDstIPNet/MatchSrcIPNet as match type to generate global cache key for both IP
 		// and IPNet. This is because OVS treats IP and IP/32 as the same condition, if Antrea has two different
 		// conjunctive match flow contexts, only one flow entry is installed on OVS, and the conjunctive actions in the
-		// first context wil be overwritten by those in the second one.
-		valueStr = fmt.Sprintf("%s/32", v.String())
+		// first context wil be overwritten by those in the second one. The same applies to IPv6 addresses with "/128".
+		if v.To4() != nil {
+			valueStr = fmt.Sprintf("%s/32", v.String())
+		} else {
+			valueStr = fmt.Sprintf("%s/128", v.String())
+		}
 		switch m.matchKey {
 		case MatchDstIP:
 			matchType = MatchDstIPNet
 		case MatchSrcIP:
 			matchType = MatchSrcIPNet
+		case MatchDstIPv6:
+			matchType = MatchDstIPNetv6
+		case MatchSrcIPv6:
+			matchType = MatchSrcIPNetv6
 		}
 	case net.IPNet:
 		valueStr = v.String()
@@ -171,6 +209,9 @@ func (m *conjunctiveMatch) generateGlobalMapKey() string {
 		// The default cases include the matchValue is a Service port or an ofport Number.
 		valueStr = fmt.Sprintf("%s", m.matchValue)
 	}
+	if m.priority != nil {
+		return fmt.Sprintf("table:%d,priority:%d,type:%d,value:%s", m.tableID, *m.priority, matchType, valueStr)
+	}
 	return fmt.Sprintf("table:%d,type:%d,value:%s", m.tableID, matchType, valueStr)
 }
 
@@ -231,6 +272,10 @@ type conjMatchFlowContext struct {
 	// empty, and uninstalled when both two are empty. When the dropFlow is uninstalled from the switch, the
 	// conjMatchFlowContext is removed from the cache.
 	dropFlow binding.Flow
+	// dropFlowEnableLogging is true if the dropFlow sends the packets to the controller for logging. It is set when
+	// any rule applied to the matching address has logging enabled, and it's not reset until the dropFlow is
+	// uninstalled.
+	dropFlowEnableLogging bool
 }
 
 // createOrUpdateConjunctiveMatchFlow creates or updates the conjunctive match flow with the latest actions. It returns
@@ -246,7 +291,7 @@ func (ctx *conjMatchFlowContext) createOrUpdateConjunctiveMatchFlow(actions []*c
 
 		// Create the conjunctive match flow entry. The actions here should not be empty for either add or update case.
 		// The expected operation for a new Openflow entry should be "insertion".
-		flow := ctx.client.conjunctiveMatchFlow(ctx.tableID, ctx.matchKey, ctx.matchValue, actions...)
+		flow := ctx.client.conjunctiveMatchFlow(ctx.tableID, ctx.matchKey, ctx.matchValue, ctx.priority, actions...)
 		return &flowChange{
 			flow:       flow,
 			changeType: insertion,
@@ -403,10 +448,14 @@ func (c *conjMatchFlowContextChange) updateContextStatus() {
 	// Update conjMatchFlowContext.dropFlow.
 	if c.dropFlow != nil {
 		switch c.dropFlow.changeType {
-		case insertion:
+		case insertion, modification:
 			c.context.dropFlow = c.dropFlow.flow
+			if c.clause.enableLogging {
+				c.context.dropFlowEnableLogging = true
+			}
 		case deletion:
 			c.context.dropFlow = nil
+			c.context.dropFlowEnableLogging = false
 		}
 	}
 
@@ -442,6 +491,18 @@ type policyRuleConjunction struct {
 	toClause      *clause
 	serviceClause *clause
 	actionFlows   []binding.Flow
+	// metricFlows are the flows which count the packets allowed by the rule. They are only installed if
+	// NetworkPolicyStats is enabled and the rule's action is Allow.
+	metricFlows []binding.Flow
+	// priority is the Openflow priority of the flows of a ClusterNetworkPolicy rule. It is nil for K8s
+	// NetworkPolicy rules.
+	priority *uint16
+	// actionDrop is true if the packets matching the rule are dropped by its action flows. It is only set for
+	// ClusterNetworkPolicy rules whose action is Drop or Reject.
+	actionDrop bool
+	// enableLogging is true if the packets matching the rule, and the packets dropped by the default drop flows of the
+	// rule, are sent to the controller for logging.
+	enableLogging bool
 	// NetworkPolicy name and Namespace information for debugging usage.
 	npName      string
 	npNamespace string
@@ -461,8 +522,13 @@ type clause struct {
 	// ruleTable is where to install conjunctive match flows.
 	ruleTable binding.Table
 	// dropTable is where to install Openflow entries to drop the packet sent to or from the AppliedToGroup but does not
-	// satisfy any conjunctive match conditions. It should be nil, if the clause is used for matching service port.
+	// satisfy any conjunctive match conditions. It should be nil, if the clause is used for matching service port, or
+	// the clause belongs to a ClusterNetworkPolicy rule.
 	dropTable binding.Table
+	// priority is the Openflow priority of the conjunctive match flows, which is only set for ClusterNetworkPolicy rules.
+	priority *uint16
+	// enableLogging is true if the default drop flows in dropTable must send the packets to the controller for logging.
+	enableLogging bool
 }
 
 func (c *clause) addConjunctiveMatchFlow(client *client, match *conjunctiveMatch) *conjMatchFlowContextChange {
@@ -489,10 +555,17 @@ func (c *clause) addConjunctiveMatchFlow(client *client, match *conjunctiveMatch
 		// Generate the default drop flow if dropTable is not nil and the default drop flow is not set yet.
 		if c.dropTable != nil && context.dropFlow == nil {
 			dropFlow = &flowChange{
-				flow:       context.client.defaultDropFlow(c.dropTable.GetID(), match.matchKey, match.matchValue),
+				flow:       context.client.defaultDropFlow(c.dropTable.GetID(), match.matchKey, match.matchValue, c.enableLogging),
 				changeType: insertion,
 			}
 		}
+	} else if c.dropTable != nil && context.dropFlow != nil && c.enableLogging && !context.dropFlowEnableLogging {
+		// Update the default drop flow to send the packets to the controller if it's installed without logging, while
+		// logging is enabled for the current rule.
+		dropFlow = &flowChange{
+			flow:       context.client.defaultDropFlow(c.dropTable.GetID(), match.matchKey, match.matchValue, true),
+			changeType: modification,
+		}
 	}
 
 	// Calculate the change on the conjMatchFlowContext.
@@ -523,39 +596,153 @@ func (c *clause) addConjunctiveMatchFlow(client *client, match *conjunctiveMatch
 	return ctxChanges
 }
 
-func (c *clause) generateAddressConjMatch(addr types.Address, addrType types.AddressType) *conjunctiveMatch {
+// generateAddressConjMatches generates the conjunctiveMatches for the provided address. An OFPortAddress is not
+// bound to an IP family, so an additional conjunctiveMatch is generated for IPv6 if IPv6 is enabled on the Node.
+func (c *clause) generateAddressConjMatches(client *client, addr types.Address, addrType types.AddressType) []*conjunctiveMatch {
 	matchKey := addr.GetMatchKey(addrType)
 	matchValue := addr.GetValue()
-	match := &conjunctiveMatch{
+	matches := []*conjunctiveMatch{{
 		tableID:    c.ruleTable.GetID(),
 		matchKey:   matchKey,
 		matchValue: matchValue,
-	}
-	return match
+		priority:   c.priority,
+	}}
+	if client.isIPv6Enabled() {
+		var ipv6MatchKey int
+		switch matchKey {
+		case MatchSrcOFPort:
+			ipv6MatchKey = MatchSrcOFPortv6
+		case MatchDstOFPort:
+			ipv6MatchKey = MatchDstOFPortv6
+		default:
+			return matches
+		}
+		matches = append(matches, &conjunctiveMatch{
+			tableID:    c.ruleTable.GetID(),
+			matchKey:   ipv6MatchKey,
+			matchValue: matchValue,
+			priority:   c.priority,
+		})
+	}
+	return matches
 }
 
-func getServiceMatchType(protocol *v1beta1.Protocol) int {
+func getServiceMatchType(protocol *v1beta1.Protocol, isIPv6 bool) int {
 	switch *protocol {
-	case v1beta1.ProtocolTCP:
-		return MatchTCPDstPort
+	case v1beta1.ProtocolICMP:
+		if isIPv6 {
+			return MatchICMPv6
+		}
+		return MatchICMP
 	case v1beta1.ProtocolUDP:
+		if isIPv6 {
+			return MatchUDPv6DstPort
+		}
 		return MatchUDPDstPort
 	case v1beta1.ProtocolSCTP:
+		if isIPv6 {
+			return MatchSCTPv6DstPort
+		}
 		return MatchSCTPDstPort
 	default:
+		if isIPv6 {
+			return MatchTCPv6DstPort
+		}
 		return MatchTCPDstPort
 	}
 }
 
-func (c *clause) generateServicePortConjMatch(port v1beta1.Service) *conjunctiveMatch {
-	matchKey := getServiceMatchType(port.Protocol)
-	matchValue := uint16(port.Port.IntVal)
-	match := &conjunctiveMatch{
-		tableID:    c.ruleTable.GetID(),
-		matchKey:   matchKey,
-		matchValue: matchValue,
+// portMask is a transport port matched with a bitmask. A port range is matched with a set of portMasks.
+type portMask struct {
+	port uint16
+	mask uint16
+}
+
+func (m portMask) String() string {
+	return fmt.Sprintf("0x%x/0x%x", m.port, m.mask)
+}
+
+// portRangeToBitMasks returns the minimal set of portMasks which together match exactly the ports from start to
+// end, both inclusive. Each portMask covers the largest aligned block of ports starting from the first port not
+// covered yet.
+func portRangeToBitMasks(start, end uint16) []portMask {
+	var masks []portMask
+	for port := uint32(start); port <= uint32(end); {
+		size := uint32(1)
+		for size < 1<<16 && port&(size<<1-1) == 0 && port+size<<1-1 <= uint32(end) {
+			size <<= 1
+		}
+		masks = append(masks, portMask{port: uint16(port), mask: uint16(^(size - 1))})
+		port += size
+	}
+	return masks
+}
+
+// icmpMatch is the ICMP type and code matched by an ICMP Service. A nil field matches all values.
+type icmpMatch struct {
+	icmpType *uint8
+	icmpCode *uint8
+}
+
+func (m icmpMatch) String() string {
+	str := "icmp"
+	if m.icmpType != nil {
+		str += fmt.Sprintf(",type=%d", *m.icmpType)
+	}
+	if m.icmpCode != nil {
+		str += fmt.Sprintf(",code=%d", *m.icmpCode)
+	}
+	return str
+}
+
+// getServiceMatchValues returns the values to match for the provided Service port: an icmpMatch for ICMP, the
+// portMasks covering the port range if EndPort is set, or the port number otherwise.
+func getServiceMatchValues(port v1beta1.Service) []interface{} {
+	if port.Protocol != nil && *port.Protocol == v1beta1.ProtocolICMP {
+		var m icmpMatch
+		if port.ICMPType != nil {
+			icmpType := uint8(*port.ICMPType)
+			m.icmpType = &icmpType
+		}
+		if port.ICMPCode != nil {
+			icmpCode := uint8(*port.ICMPCode)
+			m.icmpCode = &icmpCode
+		}
+		return []interface{}{m}
+	}
+	if port.EndPort == nil || *port.EndPort <= port.Port.IntVal {
+		return []interface{}{uint16(port.Port.IntVal)}
+	}
+	var values []interface{}
+	for _, m := range portRangeToBitMasks(uint16(port.Port.IntVal), uint16(*port.EndPort)) {
+		// Use the port number for a single port, so that it shares the conjunctive match flow with the Services
+		// matching the same port without a range.
+		if m.mask == 0xffff {
+			values = append(values, m.port)
+		} else {
+			values = append(values, m)
+		}
 	}
-	return match
+	return values
+}
+
+// generateServicePortConjMatches generates the conjunctiveMatches for the provided Service port, one for each match
+// value of the Service port and each IP protocol enabled on the Node.
+func (c *clause) generateServicePortConjMatches(client *client, port v1beta1.Service) []*conjunctiveMatch {
+	var matches []*conjunctiveMatch
+	matchValues := getServiceMatchValues(port)
+	for _, proto := range client.ipProtocols {
+		matchKey := getServiceMatchType(port.Protocol, proto == binding.ProtocolIPv6)
+		for _, matchValue := range matchValues {
+			matches = append(matches, &conjunctiveMatch{
+				tableID:    c.ruleTable.GetID(),
+				matchKey:   matchKey,
+				matchValue: matchValue,
+				priority:   c.priority,
+			})
+		}
+	}
+	return matches
 }
 
 // addAddrFlows translates the specified addresses to conjunctiveMatchFlows, and returns the corresponding changes on the
@@ -564,10 +751,11 @@ func (c *clause) addAddrFlows(client *client, addrType types.AddressType, addres
 	var conjMatchFlowContextChanges []*conjMatchFlowContextChange
 	// Calculate Openflow changes for the added addresses.
 	for _, addr := range addresses {
-		match := c.generateAddressConjMatch(addr, addrType)
-		ctxChange := c.addConjunctiveMatchFlow(client, match)
-		if ctxChange != nil {
-			conjMatchFlowContextChanges = append(conjMatchFlowContextChanges, ctxChange)
+		for _, match := range c.generateAddressConjMatches(client, addr, addrType) {
+			ctxChange := c.addConjunctiveMatchFlow(client, match)
+			if ctxChange != nil {
+				conjMatchFlowContextChanges = append(conjMatchFlowContextChanges, ctxChange)
+			}
 		}
 	}
 	return conjMatchFlowContextChanges
@@ -578,9 +766,10 @@ func (c *clause) addAddrFlows(client *client, addrType types.AddressType, addres
 func (c *clause) addServiceFlows(client *client, ports []v1beta1.Service) []*conjMatchFlowContextChange {
 	var conjMatchFlowContextChanges []*conjMatchFlowContextChange
 	for _, port := range ports {
-		match := c.generateServicePortConjMatch(port)
-		ctxChange := c.addConjunctiveMatchFlow(client, match)
-		conjMatchFlowContextChanges = append(conjMatchFlowContextChanges, ctxChange)
+		for _, match := range c.generateServicePortConjMatches(client, port) {
+			ctxChange := c.addConjunctiveMatchFlow(client, match)
+			conjMatchFlowContextChanges = append(conjMatchFlowContextChanges, ctxChange)
+		}
 	}
 	return conjMatchFlowContextChanges
 }
@@ -639,14 +828,15 @@ func (c *clause) deleteConjunctiveMatchFlow(flowContextKey string) *conjMatchFlo
 
 // deleteAddrFlows deletes conjunctiveMatchFlow relevant to the specified addresses from local cache,
 // and uninstalls Openflow entry.
-func (c *clause) deleteAddrFlows(addrType types.AddressType, addresses []types.Address) []*conjMatchFlowContextChange {
+func (c *clause) deleteAddrFlows(client *client, addrType types.AddressType, addresses []types.Address) []*conjMatchFlowContextChange {
 	var ctxChanges []*conjMatchFlowContextChange
 	for _, addr := range addresses {
-		match := c.generateAddressConjMatch(addr, addrType)
-		contextKey := match.generateGlobalMapKey()
-		ctxChange := c.deleteConjunctiveMatchFlow(contextKey)
-		if ctxChange != nil {
-			ctxChanges = append(ctxChanges, ctxChange)
+		for _, match := range c.generateAddressConjMatches(client, addr, addrType) {
+			contextKey := match.generateGlobalMapKey()
+			ctxChange := c.deleteConjunctiveMatchFlow(contextKey)
+			if ctxChange != nil {
+				ctxChanges = append(ctxChanges, ctxChange)
+			}
 		}
 	}
 	return ctxChanges
@@ -709,24 +899,38 @@ func (c *client) InstallPolicyRuleFlows(ruleID uint32, rule *types.PolicyRule, n
 	}
 
 	conj = &policyRuleConjunction{
-		id:          ruleID,
-		npName:      npName,
-		npNamespace: npNamespace}
+		id:            ruleID,
+		priority:      rule.Priority,
+		enableLogging: rule.EnableLogging,
+		npName:        npName,
+		npNamespace:   npNamespace}
 	nClause, ruleTable, dropTable := conj.calculateClauses(rule, c)
 
 	// Conjunction action flows are installed only if the number of clauses in the conjunction is > 1. It should be a rule
 	// to drop all packets.  If the number is 1, no conjunctive match flows or conjunction action flows are installed,
 	// but the default drop flow is installed.
 	if nClause > 1 {
-		// Install action flows.
-		var actionFlows = []binding.Flow{
-			c.conjunctionActionFlow(ruleID, ruleTable.GetID(), dropTable.GetNext()),
+		// Install action flows. The packets matching a ClusterNetworkPolicy rule with an Allow action skip the K8s
+		// NetworkPolicy tables, and the packets matching a rule with a Drop action are dropped. The packets matching
+		// a rule with a Reject action are dropped too, after being sent to the controller which rejects them.
+		var actionFlows []binding.Flow
+		if rule.IsAntreaNetworkPolicyRule() && rule.Action != nil && *rule.Action != secv1alpha1.RuleActionAllow {
+			reject := *rule.Action == secv1alpha1.RuleActionReject
+			actionFlows = c.conjunctionActionDropFlows(ruleID, ruleTable.GetID(), rule.Priority, rule.EnableLogging, reject)
+			conj.actionDrop = true
+		} else {
+			actionFlows = c.conjunctionActionFlows(ruleID, ruleTable.GetID(), dropTable.GetNext(), rule.Priority, rule.EnableLogging)
+		}
+		var metricFlows []binding.Flow
+		if c.enablePolicyStats && !conj.actionDrop {
+			metricFlows = c.policyMetricFlows(ruleID, dropTable)
 		}
-		if err := c.ofEntryOperations.AddAll(actionFlows); err != nil {
+		if err := c.ofEntryOperations.AddAll(append(actionFlows, metricFlows...)); err != nil {
 			return nil
 		}
 		// Add the action flows after the Openflow entries are installed on the OVS bridge successfully.
 		conj.actionFlows = actionFlows
+		conj.metricFlows = metricFlows
 	}
 	c.conjMatchFlowLock.Lock()
 	defer c.conjMatchFlowLock.Unlock()
@@ -784,9 +988,11 @@ func (c *client) sendConjunctiveMatchFlows(changes []*conjMatchFlowContextChange
 
 func (c *policyRuleConjunction) newClause(clauseID uint8, nClause uint8, ruleTable, dropTable binding.Table) *clause {
 	return &clause{
-		ruleTable: ruleTable,
-		dropTable: dropTable,
-		matches:   make(map[string]*conjMatchFlowContext, 0),
+		ruleTable:     ruleTable,
+		dropTable:     dropTable,
+		priority:      c.priority,
+		enableLogging: c.enableLogging,
+		matches:       make(map[string]*conjMatchFlowContext, 0),
 		action: &conjunctiveAction{
 			conjID:   c.id,
 			clauseID: clauseID,
@@ -802,11 +1008,19 @@ func (c *policyRuleConjunction) calculateClauses(rule *types.PolicyRule, clnt *c
 	var isEgressRule = false
 	switch rule.Direction {
 	case v1beta1.DirectionOut:
-		ruleTable = clnt.pipeline[egressRuleTable]
+		if rule.IsAntreaNetworkPolicyRule() {
+			ruleTable = clnt.pipeline[cnpEgressRuleTable]
+		} else {
+			ruleTable = clnt.pipeline[egressRuleTable]
+		}
 		dropTable = clnt.pipeline[egressDefaultTable]
 		isEgressRule = true
 	default:
-		ruleTable = clnt.pipeline[ingressRuleTable]
+		if rule.IsAntreaNetworkPolicyRule() {
+			ruleTable = clnt.pipeline[cnpIngressRuleTable]
+		} else {
+			ruleTable = clnt.pipeline[ingressRuleTable]
+		}
 		dropTable = clnt.pipeline[ingressDefaultTable]
 	}
 
@@ -825,9 +1039,11 @@ func (c *policyRuleConjunction) calculateClauses(rule *types.PolicyRule, clnt *c
 		serviceID = nClause
 	}
 
+	// No default drop flow is installed for ClusterNetworkPolicy rules, as the packets not matching any of them
+	// continue to be checked with the K8s NetworkPolicy rules.
 	var defaultTable binding.Table
 	if rule.From != nil {
-		if isEgressRule {
+		if isEgressRule && !rule.IsAntreaNetworkPolicyRule() {
 			defaultTable = dropTable
 		} else {
 			defaultTable = nil
@@ -835,7 +1051,7 @@ func (c *policyRuleConjunction) calculateClauses(rule *types.PolicyRule, clnt *c
 		c.fromClause = c.newClause(fromID, nClause, ruleTable, defaultTable)
 	}
 	if rule.To != nil {
-		if !isEgressRule {
+		if !isEgressRule && !rule.IsAntreaNetworkPolicyRule() {
 			defaultTable = dropTable
 		} else {
 			defaultTable = nil
@@ -888,6 +1104,9 @@ func (c *policyRuleConjunction) getAllFlowKeys() []string {
 	for _, flow := range c.actionFlows {
 		flowKeys = append(flowKeys, flow.MatchString())
 	}
+	for _, flow := range c.metricFlows {
+		flowKeys = append(flowKeys, flow.MatchString())
+	}
 
 	addClauseFlowKeys := func(clause *clause) {
 		if clause == nil {
@@ -930,8 +1149,8 @@ func (c *client) UninstallPolicyRuleFlows(ruleID uint32) error {
 		return nil
 	}
 
-	// Delete action flows from the OVS bridge.
-	if err := c.ofEntryOperations.DeleteAll(conj.actionFlows); err != nil {
+	// Delete action flows and metric flows from the OVS bridge.
+	if err := c.ofEntryOperations.DeleteAll(append(conj.actionFlows, conj.metricFlows...)); err != nil {
 		return err
 	}
 
@@ -956,6 +1175,10 @@ func (c *client) replayPolicyFlows() {
 			flow.Reset()
 			flows = append(flows, flow)
 		}
+		for _, flow := range conj.metricFlows {
+			flow.Reset()
+			flows = append(flows, flow)
+		}
 	}
 
 	c.policyCache.Range(func(key, value interface{}) bool {
@@ -1030,7 +1253,7 @@ func (c *client) DeletePolicyRuleAddress(ruleID uint32, addrType types.AddressTy
 	c.conjMatchFlowLock.Lock()
 	defer c.conjMatchFlowLock.Unlock()
 	// Remove policyRuleConjunction to actions of conjunctive match using specific address.
-	changes := clause.deleteAddrFlows(addrType, addresses)
+	changes := clause.deleteAddrFlows(c, addrType, addresses)
 	// Update the Openflow entries on the OVS bridge, and update local cache.
 	return c.applyConjunctiveMatchFlows(changes)
 }
@@ -1056,3 +1279,100 @@ func (c *client) GetNetworkPolicyFlowKeys(npName, npNamespace string) []string {
 	})
 	return flowKeys
 }
+
+// getRuleTableID returns the ID of the table where the flows of the policyRuleConjunction are installed.
+func (c *policyRuleConjunction) getRuleTableID() binding.TableIDType {
+	for _, cl := range []*clause{c.fromClause, c.toClause, c.serviceClause} {
+		if cl != nil {
+			return cl.ruleTable.GetID()
+		}
+	}
+	return binding.TableIDAll
+}
+
+// IsDropConjunction returns true if the packets matching the rule with the provided conjunction ID are dropped by its
+// action flows.
+func (c *client) IsDropConjunction(ruleID uint32) bool {
+	conj := c.getPolicyRuleConjunction(ruleID)
+	if conj == nil {
+		return false
+	}
+	return conj.actionDrop
+}
+
+// GetPolicyFromConjunction returns the name and Namespace of the NetworkPolicy which the rule with the provided
+// conjunction ID belongs to. Empty strings are returned if the rule is not found.
+func (c *client) GetPolicyFromConjunction(ruleID uint32) (string, string) {
+	conj := c.getPolicyRuleConjunction(ruleID)
+	if conj == nil {
+		return "", ""
+	}
+	return conj.npName, conj.npNamespace
+}
+
+// NetworkPolicyMetrics returns the traffic statistics of the NetworkPolicy rules, which are read from the counters of
+// the flows identified by the conjunction IDs of the rules in their cookies. The number of sessions of an allowed rule
+// is the number of packets matching its action flows, i.e. the first packets of the connections, while its packets and
+// bytes are counted by its metric flows. All the statistics of a dropped rule are counted by its action flows.
+func (c *client) NetworkPolicyMetrics() map[uint32]*types.RuleMetric {
+	result := map[uint32]*types.RuleMetric{}
+	cookieMask := cookie.RoundMask | cookie.CategoryMask
+	// getMetrics calls handleFlow for the flows of the provided category which identify a rule in the current round.
+	getMetrics := func(category cookie.Category, handleFlow func(ruleID uint32, state *binding.FlowStates, metric *types.RuleMetric)) {
+		flowStates, err := c.bridge.DumpFlows(c.cookieAllocator.Request(category).Raw(), cookieMask)
+		if err != nil {
+			klog.Errorf("Failed to dump the flows of category %s: %v", category, err)
+			return
+		}
+		for cookieID, state := range flowStates {
+			ruleID := cookie.ID(cookieID).ObjectID()
+			if ruleID == 0 {
+				continue
+			}
+			metric, exists := result[ruleID]
+			if !exists {
+				metric = &types.RuleMetric{}
+				result[ruleID] = metric
+			}
+			handleFlow(ruleID, state, metric)
+		}
+	}
+	getMetrics(cookie.Policy, func(ruleID uint32, state *binding.FlowStates, metric *types.RuleMetric) {
+		metric.Sessions += state.PacketCount
+		if c.IsDropConjunction(ruleID) {
+			metric.Packets += state.PacketCount
+			metric.Bytes += state.ByteCount
+		}
+	})
+	getMetrics(cookie.PolicyMetric, func(ruleID uint32, state *binding.FlowStates, metric *types.RuleMetric) {
+		metric.Packets += state.PacketCount
+		metric.Bytes += state.ByteCount
+	})
+	return result
+}
+
+// traceflowNetworkPolicyFlows generates the flows to send the packets tagged with the provided dataplaneTag to the
+// controller instead of dropping them with any NetworkPolicy drop flow.
+func (c *client) traceflowNetworkPolicyFlows(dataplaneTag uint8) []binding.Flow {
+	var flows []binding.Flow
+	c.conjMatchFlowLock.Lock()
+	for _, ctx := range c.globalConjMatchFlowCache {
+		if ctx.dropFlow != nil {
+			flows = append(flows, c.traceflowDropFlow(ctx.dropFlow, ctx.tableID, priorityNormal+2, 0, dataplaneTag))
+		}
+	}
+	c.conjMatchFlowLock.Unlock()
+	c.policyCache.Range(func(key, value interface{}) bool {
+		conj := value.(*policyRuleConjunction)
+		if !conj.actionDrop {
+			return true
+		}
+		for _, flow := range conj.actionFlows {
+			// The Openflow priority of the rule is increased by 1 so that the flow takes precedence over the
+			// original drop flow. It cannot overlap with other rules' flows as they match different conjunction IDs.
+			flows = append(flows, c.traceflowDropFlow(flow, conj.getRuleTableID(), *conj.priority+1, conj.id, dataplaneTag))
+		}
+		return true
+	})
+	return flows
+}
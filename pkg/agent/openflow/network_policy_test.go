@@ -70,7 +70,7 @@ func TestPolicyRuleConjunction(t *testing.T) {
 	var currentFlowCount = len(c.globalConjMatchFlowCache)
 
 	var deletedAddrs = parseAddresses([]string{"192.168.1.3", "103"})
-	flowChanges2 := clause1.deleteAddrFlows(types.SrcAddress, deletedAddrs)
+	flowChanges2 := clause1.deleteAddrFlows(c, types.SrcAddress, deletedAddrs)
 	err = c.applyConjunctiveMatchFlows(flowChanges2)
 	require.Nil(t, err, "Failed to invoke deleteAddrFlows")
 	checkFlowCount(t, currentFlowCount-len(deletedAddrs))
@@ -106,7 +106,7 @@ func TestPolicyRuleConjunction(t *testing.T) {
 	require.Nil(t, err, "Failed to invoke addAddrFlows")
 	checkConjMatchFlowActions(t, c, clause3, testAddr, types.SrcAddress, 2, 1)
 	checkFlowCount(t, currentFlowCount)
-	flowChanges5 := clause3.deleteAddrFlows(types.SrcAddress, addedAddrs3)
+	flowChanges5 := clause3.deleteAddrFlows(c, types.SrcAddress, addedAddrs3)
 	err = c.applyConjunctiveMatchFlows(flowChanges5)
 	require.Nil(t, err, "Failed to invoke deleteAddrFlows")
 	checkConjMatchFlowActions(t, c, clause3, testAddr, types.SrcAddress, 2, 0)
@@ -219,6 +219,62 @@ func TestInstallPolicyRuleFlows(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestInstallPolicyRuleFlowsWithLogging(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c = prepareClient(ctrl)
+	outDropTable.EXPECT().BuildFlow(gomock.Any()).Return(newMockDropFlowBuilder(ctrl)).AnyTimes()
+	outTable.EXPECT().BuildFlow(gomock.Any()).Return(newMockRuleFlowBuilder(ctrl)).AnyTimes()
+	ruleAction.EXPECT().Conjunction(gomock.Any(), gomock.Any(), gomock.Any()).Return(ruleFlowBuilder).AnyTimes()
+
+	ruleID1 := uint32(111)
+	rule1 := &types.PolicyRule{
+		Direction: v1beta1.DirectionOut,
+		From:      parseAddresses([]string{"192.168.1.30"}),
+		To:        parseAddresses([]string{"192.168.2.0/24"}),
+	}
+	err := c.InstallPolicyRuleFlows(ruleID1, rule1, "np1", "ns1")
+	require.Nil(t, err)
+	ctxKey := fmt.Sprintf("table:%d,type:%d,value:%s", egressRuleTable, MatchSrcIPNet, "192.168.1.30/32")
+	ctx, found := c.globalConjMatchFlowCache[ctxKey]
+	require.True(t, found)
+	assert.NotNil(t, ctx.dropFlow)
+	assert.False(t, ctx.dropFlowEnableLogging)
+
+	// The default drop flow of the shared address must be updated when a rule with logging enabled is added.
+	ruleID2 := uint32(112)
+	rule2 := &types.PolicyRule{
+		Direction:     v1beta1.DirectionOut,
+		From:          parseAddresses([]string{"192.168.1.30", "192.168.1.40"}),
+		To:            parseAddresses([]string{"192.168.3.0/24"}),
+		EnableLogging: true,
+	}
+	conj2 := &policyRuleConjunction{id: ruleID2, enableLogging: true}
+	conj2.calculateClauses(rule2, c)
+	ctxChanges := conj2.calculateChangesForRuleCreation(c, rule2)
+	_, dropFlows := getChangedFlows(ctxChanges)
+	assert.Equal(t, 1, getChangedFlowOPCount(dropFlows, insertion))
+	assert.Equal(t, 1, getChangedFlowOPCount(dropFlows, modification))
+
+	err = c.InstallPolicyRuleFlows(ruleID2, rule2, "np2", "ns1")
+	require.Nil(t, err)
+	assert.True(t, ctx.dropFlowEnableLogging)
+	conj := c.getPolicyRuleConjunction(ruleID2)
+	require.NotNil(t, conj)
+	assert.True(t, conj.enableLogging)
+	assert.False(t, c.IsDropConjunction(ruleID2))
+
+	// Logging is kept for the default drop flow until it's uninstalled.
+	err = c.UninstallPolicyRuleFlows(ruleID2)
+	require.Nil(t, err)
+	assert.True(t, ctx.dropFlowEnableLogging)
+	err = c.UninstallPolicyRuleFlows(ruleID1)
+	require.Nil(t, err)
+	assert.Nil(t, ctx.dropFlow)
+	assert.False(t, ctx.dropFlowEnableLogging)
+}
+
 func TestConjMatchFlowContextKeyConflict(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -324,11 +380,12 @@ func checkFlowCount(t *testing.T, expectCount int) {
 }
 
 func checkConjMatchFlowActions(t *testing.T, client *client, c *clause, address types.Address, addressType types.AddressType, actionCount int, anyDropRuleCount int) {
-	addrMatch := c.generateAddressConjMatch(address, addressType)
-	context, found := client.globalConjMatchFlowCache[addrMatch.generateGlobalMapKey()]
-	require.True(t, found, "Failed to add conjunctive match flow to global cache")
-	assert.Equal(t, actionCount, len(context.actions), fmt.Sprintf("Incorrect policyRuleConjunction action number, expect: %d, actual: %d", actionCount, len(context.actions)))
-	assert.Equal(t, anyDropRuleCount, len(context.denyAllRules), fmt.Sprintf("Incorrect policyRuleConjunction anyDropRule number, expect: %d, actual: %d", anyDropRuleCount, len(context.denyAllRules)))
+	for _, addrMatch := range c.generateAddressConjMatches(client, address, addressType) {
+		context, found := client.globalConjMatchFlowCache[addrMatch.generateGlobalMapKey()]
+		require.True(t, found, "Failed to add conjunctive match flow to global cache")
+		assert.Equal(t, actionCount, len(context.actions), fmt.Sprintf("Incorrect policyRuleConjunction action number, expect: %d, actual: %d", actionCount, len(context.actions)))
+		assert.Equal(t, anyDropRuleCount, len(context.denyAllRules), fmt.Sprintf("Incorrect policyRuleConjunction anyDropRule number, expect: %d, actual: %d", anyDropRuleCount, len(context.denyAllRules)))
+	}
 }
 
 func expectConjunctionsCount(conjs []*expectConjunctionTimes) {
@@ -349,6 +406,8 @@ func newMockDropFlowBuilder(ctrl *gomock.Controller) *mocks.MockFlowBuilder {
 	dropFlowBuilder.EXPECT().MatchRegRange(gomock.Any(), gomock.Any(), gomock.Any()).Return(dropFlowBuilder).AnyTimes()
 	action := mocks.NewMockAction(ctrl)
 	action.EXPECT().Drop().Return(dropFlowBuilder).AnyTimes()
+	action.EXPECT().SendToController(gomock.Any()).Return(dropFlowBuilder).AnyTimes()
+	action.EXPECT().LoadRegRange(gomock.Any(), gomock.Any(), gomock.Any()).Return(dropFlowBuilder).AnyTimes()
 	dropFlowBuilder.EXPECT().Action().Return(action).AnyTimes()
 	dropFlow = mocks.NewMockFlow(ctrl)
 	dropFlowBuilder.EXPECT().Done().Return(dropFlow).AnyTimes()
@@ -369,9 +428,14 @@ func newMockRuleFlowBuilder(ctrl *gomock.Controller) *mocks.MockFlowBuilder {
 	ruleFlowBuilder.EXPECT().MatchTCPDstPort(gomock.Any()).Return(ruleFlowBuilder).AnyTimes()
 	ruleFlowBuilder.EXPECT().MatchUDPDstPort(gomock.Any()).Return(ruleFlowBuilder).AnyTimes()
 	ruleFlowBuilder.EXPECT().MatchSCTPDstPort(gomock.Any()).Return(ruleFlowBuilder).AnyTimes()
+	ruleFlowBuilder.EXPECT().MatchDstPortMask(gomock.Any(), gomock.Any()).Return(ruleFlowBuilder).AnyTimes()
+	ruleFlowBuilder.EXPECT().MatchICMPType(gomock.Any()).Return(ruleFlowBuilder).AnyTimes()
+	ruleFlowBuilder.EXPECT().MatchICMPCode(gomock.Any()).Return(ruleFlowBuilder).AnyTimes()
 	ruleFlowBuilder.EXPECT().MatchConjID(gomock.Any()).Return(ruleFlowBuilder).AnyTimes()
 	ruleAction = mocks.NewMockAction(ctrl)
 	ruleAction.EXPECT().GotoTable(gomock.Any()).Return(ruleFlowBuilder).AnyTimes()
+	ruleAction.EXPECT().LoadRegRange(gomock.Any(), gomock.Any(), gomock.Any()).Return(ruleFlowBuilder).AnyTimes()
+	ruleAction.EXPECT().SendToController(gomock.Any()).Return(ruleFlowBuilder).AnyTimes()
 	ruleFlowBuilder.EXPECT().Action().Return(ruleAction).AnyTimes()
 	ruleFlow = mocks.NewMockFlow(ctrl)
 	ruleFlowBuilder.EXPECT().Done().Return(ruleFlow).AnyTimes()
@@ -420,6 +484,7 @@ func prepareClient(ctrl *gomock.Controller) *client {
 		policyCache:              sync.Map{},
 		globalConjMatchFlowCache: map[string]*conjMatchFlowContext{},
 		bridge:                   bridge,
+		ipProtocols:              []binding.Protocol{binding.ProtocolIP},
 	}
 	c.cookieAllocator = cookie.NewAllocator(0)
 	m := oftest.NewMockOFEntryOperations(ctrl)
@@ -428,3 +493,121 @@ func prepareClient(ctrl *gomock.Controller) *client {
 	c.ofEntryOperations = m
 	return c
 }
+
+func TestNetworkPolicyMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c = prepareClient(ctrl)
+	c.policyCache.Store(uint32(1), &policyRuleConjunction{id: 1})
+	c.policyCache.Store(uint32(2), &policyRuleConjunction{id: 2, actionDrop: true})
+	policyCookie := c.cookieAllocator.Request(cookie.Policy).Raw()
+	metricCookie := c.cookieAllocator.Request(cookie.PolicyMetric).Raw()
+	cookieMask := cookie.RoundMask | cookie.CategoryMask
+	bridge := c.bridge.(*mocks.MockBridge)
+	bridge.EXPECT().DumpFlows(policyCookie, cookieMask).Return(map[uint64]*binding.FlowStates{
+		// The flows not installed for a specific rule are ignored.
+		policyCookie: {PacketCount: 100, ByteCount: 10000},
+		c.cookieAllocator.RequestWithObjectID(cookie.Policy, 1).Raw(): {PacketCount: 2, ByteCount: 200},
+		c.cookieAllocator.RequestWithObjectID(cookie.Policy, 2).Raw(): {PacketCount: 5, ByteCount: 500},
+	}, nil)
+	bridge.EXPECT().DumpFlows(metricCookie, cookieMask).Return(map[uint64]*binding.FlowStates{
+		c.cookieAllocator.RequestWithObjectID(cookie.PolicyMetric, 1).Raw(): {PacketCount: 10, ByteCount: 1000},
+	}, nil)
+
+	assert.Equal(t, map[uint32]*types.RuleMetric{
+		// The sessions of an allowed rule are the packets matching its action flows.
+		1: {Packets: 10, Bytes: 1000, Sessions: 2},
+		// All the statistics of a dropped rule are counted by its action flows.
+		2: {Packets: 5, Bytes: 500, Sessions: 5},
+	}, c.NetworkPolicyMetrics())
+}
+
+func TestPortRangeToBitMasks(t *testing.T) {
+	tests := []struct {
+		start    uint16
+		end      uint16
+		expected []portMask
+	}{
+		{80, 80, []portMask{{80, 0xffff}}},
+		{8080, 8083, []portMask{{8080, 0xfffc}}},
+		{0, 65535, []portMask{{0, 0}}},
+		{1, 7, []portMask{{1, 0xffff}, {2, 0xfffe}, {4, 0xfffc}}},
+		{65534, 65535, []portMask{{65534, 0xfffe}}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, portRangeToBitMasks(tt.start, tt.end), "range %d-%d", tt.start, tt.end)
+	}
+
+	// The masks must cover exactly the ports in the range.
+	for _, r := range [][2]uint16{{1000, 1999}, {1, 65535}, {32767, 32769}} {
+		covered := map[uint32]bool{}
+		for _, m := range portRangeToBitMasks(r[0], r[1]) {
+			for port := uint32(0); port <= 65535; port++ {
+				if uint16(port)&m.mask == m.port {
+					assert.False(t, covered[port], "port %d matched twice", port)
+					covered[port] = true
+				}
+			}
+		}
+		assert.Equal(t, int(r[1])-int(r[0])+1, len(covered))
+		for port := range covered {
+			assert.True(t, port >= uint32(r[0]) && port <= uint32(r[1]), "port %d out of range %v", port, r)
+		}
+	}
+}
+
+func TestGenerateServicePortConjMatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	c = prepareClient(ctrl)
+	c.ipProtocols = []binding.Protocol{binding.ProtocolIP, binding.ProtocolIPv6}
+	cl := &clause{ruleTable: outTable}
+
+	tcpProtocol := v1beta1.ProtocolTCP
+	icmpProtocol := v1beta1.ProtocolICMP
+	port8080 := intstr.FromInt(8080)
+	endPort := int32(8084)
+	icmpType := int32(8)
+	tests := []struct {
+		name           string
+		service        v1beta1.Service
+		expectedKeys   []int
+		expectedValues []interface{}
+	}{
+		{
+			name:           "single port",
+			service:        v1beta1.Service{Protocol: &tcpProtocol, Port: &port8080},
+			expectedKeys:   []int{MatchTCPDstPort, MatchTCPv6DstPort},
+			expectedValues: []interface{}{uint16(8080), uint16(8080)},
+		},
+		{
+			name:         "port range",
+			service:      v1beta1.Service{Protocol: &tcpProtocol, Port: &port8080, EndPort: &endPort},
+			expectedKeys: []int{MatchTCPDstPort, MatchTCPDstPort, MatchTCPv6DstPort, MatchTCPv6DstPort},
+			expectedValues: []interface{}{
+				portMask{8080, 0xfffc}, uint16(8084),
+				portMask{8080, 0xfffc}, uint16(8084),
+			},
+		},
+		{
+			name:         "icmp type",
+			service:      v1beta1.Service{Protocol: &icmpProtocol, ICMPType: &icmpType},
+			expectedKeys: []int{MatchICMP, MatchICMPv6},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := cl.generateServicePortConjMatches(c, tt.service)
+			require.Equal(t, len(tt.expectedKeys), len(matches))
+			for i, m := range matches {
+				assert.Equal(t, tt.expectedKeys[i], m.matchKey)
+				if tt.expectedValues != nil {
+					assert.Equal(t, tt.expectedValues[i], m.matchValue)
+				}
+			}
+		})
+	}
+	icmpValue := cl.generateServicePortConjMatches(c, tests[2].service)[0].matchValue.(icmpMatch)
+	assert.Equal(t, "icmp,type=8", icmpValue.String())
+}
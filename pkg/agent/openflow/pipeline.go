@@ -15,6 +15,7 @@
 package openflow
 
 import (
+	"encoding/binary"
 	"fmt"
 	"net"
 	"strconv"
@@ -24,6 +25,7 @@ import (
 	"github.com/vmware-tanzu/antrea/pkg/agent/config"
 	"github.com/vmware-tanzu/antrea/pkg/agent/openflow/cookie"
 	"github.com/vmware-tanzu/antrea/pkg/agent/types"
+	"github.com/vmware-tanzu/antrea/pkg/features"
 	binding "github.com/vmware-tanzu/antrea/pkg/ovs/openflow"
 )
 
@@ -31,16 +33,23 @@ const (
 	// Flow table id index
 	classifierTable       binding.TableIDType = 0
 	spoofGuardTable       binding.TableIDType = 10
+	ipv6Table             binding.TableIDType = 21
 	arpResponderTable     binding.TableIDType = 20
 	conntrackTable        binding.TableIDType = 30
 	conntrackStateTable   binding.TableIDType = 31
 	dnatTable             binding.TableIDType = 40
+	sessionAffinityTable  binding.TableIDType = 41
+	cnpEgressRuleTable    binding.TableIDType = 45
 	egressRuleTable       binding.TableIDType = 50
 	egressDefaultTable    binding.TableIDType = 60
+	egressMetricTable     binding.TableIDType = 61
 	l3ForwardingTable     binding.TableIDType = 70
+	snatTable             binding.TableIDType = 71
 	l2ForwardingCalcTable binding.TableIDType = 80
+	cnpIngressRuleTable   binding.TableIDType = 85
 	ingressRuleTable      binding.TableIDType = 90
 	ingressDefaultTable   binding.TableIDType = 100
+	ingressMetricTable    binding.TableIDType = 101
 	conntrackCommitTable  binding.TableIDType = 105
 	l2ForwardingOutTable  binding.TableIDType = 110
 
@@ -50,6 +59,14 @@ const (
 	priorityLow    = uint16(190)
 	prioritySNAT   = uint16(180)
 	priorityMiss   = uint16(0)
+	// priorityTopCNP is used by the flows in ClusterNetworkPolicy tables
+	// which must be matched before any ClusterNetworkPolicy rule, e.g. the
+	// flows for established connections.
+	priorityTopCNP = uint16(64990)
+	// priorityDNSIntercept is used by the flows intercepting the DNS responses
+	// to local Pods, which must be matched before the flows skipping the
+	// ClusterNetworkPolicy rules for established connections.
+	priorityDNSIntercept = uint16(64991)
 
 	// Traffic marks
 	markTrafficFromTunnel  = 0
@@ -58,6 +75,14 @@ const (
 	markTrafficFromUplink  = 4
 )
 
+const (
+	// PriorityTopCNP and PriorityBottomCNP are the upper and lower bounds
+	// (exclusive) of the Openflow priorities that can be assigned to the
+	// ClusterNetworkPolicy rule flows.
+	PriorityTopCNP    = priorityTopCNP
+	PriorityBottomCNP = uint16(100)
+)
+
 var (
 	FlowTables = []struct {
 		Number binding.TableIDType
@@ -65,16 +90,21 @@ var (
 	}{
 		{classifierTable, "Classification"},
 		{spoofGuardTable, "SpoofGuard"},
+		{ipv6Table, "IPv6"},
 		{arpResponderTable, "ARPResponder"},
 		{conntrackTable, "ConntrackZone"},
 		{conntrackStateTable, "ContrackState"},
 		{dnatTable, "DNAT"},
+		{cnpEgressRuleTable, "CNPEgressRule"},
 		{egressRuleTable, "EgressRule"},
 		{egressDefaultTable, "EgressDefaultRule"},
+		{egressMetricTable, "EgressMetric"},
 		{l3ForwardingTable, "L3Forwarding"},
 		{l2ForwardingCalcTable, "L2Forwarding"},
+		{cnpIngressRuleTable, "CNPIngressRule"},
 		{ingressRuleTable, "IngressRule"},
 		{ingressDefaultTable, "IngressDefaultRule"},
+		{ingressMetricTable, "IngressMetric"},
 		{conntrackCommitTable, "ConntrackCommit"},
 		{l2ForwardingOutTable, "Output"},
 	}
@@ -120,17 +150,124 @@ func (rt regType) reg() string {
 const (
 	// marksReg stores traffic-source mark and pod-found mark.
 	// traffic-source resides in [0..15], pod-found resides in [16].
+	// The custom reasons of the NetworkPolicy PacketIn messages reside in
+	// [19..20].
 	marksReg     regType = 0
 	portCacheReg regType = 1
 	swapReg      regType = 2
+	// endpointIPReg and endpointPortReg store the IP address and port of the
+	// Endpoint selected by a Service group for a new connection to the
+	// Service. The port resides in [0..15] of endpointPortReg, the
+	// endpoint-selected mark resides in [16], and the affinity-checked mark
+	// resides in [17].
+	endpointIPReg   regType = 3
+	endpointPortReg regType = 4
+	// egressReg and ingressReg store the conjunction ID of the egress and
+	// ingress NetworkPolicy rule that a packet matched respectively. They are
+	// used by Traceflow to report the NetworkPolicy that allowed or dropped
+	// the packet. When NetworkPolicyStats is enabled, they are committed to
+	// egressLabelRange and ingressLabelRange of ct_label, and restored from
+	// ct_label for the packets of established connections, so that the
+	// metric tables can count the packets of the connections per rule.
+	egressReg  regType = 5
+	ingressReg regType = 6
+	// snatReg stores the SNAT mark of the egress IP which the packets of a new connection from a Pod selected by an
+	// Egress must be SNATed to. It's loaded in snatTable and matched in conntrackCommitTable.
+	snatReg regType = 7
 
 	ctZone = 0xfff0
 
 	portFoundMark    = 0x1
 	snatRequiredMark = 0x1
+	macRewriteMark   = 0x1
+	epSelectedMark   = 0x1
+	// affinityCheckedMark indicates that sessionAffinityTable has been looked
+	// up for a new connection to a Service with ClientIP session affinity.
+	affinityCheckedMark = 0x1
 
 	gatewayCTMark = 0x20
 	snatCTMark    = 0x40
+	// hairpinCTMark marks the connections from a Pod to a Service which are
+	// DNAT'd to the Pod itself. Their packets are sent back to the Pod
+	// through its ingress port, the request packets being SNAT'd to
+	// hairpinIP.
+	hairpinCTMark = 0x80
+
+	icmpEchoRequestType = 8
+
+	dnsPort = 53
+)
+
+var (
+	// egressLabelRange and ingressLabelRange are the ranges of ct_label which store the conjunction ID of the egress
+	// and ingress NetworkPolicy rule that allowed a connection respectively.
+	egressLabelRange  = binding.Range{0, 31}
+	ingressLabelRange = binding.Range{32, 63}
+)
+
+var (
+	// ipv6LinkLocalAddr and ipv6MulticastAddr are the IPv6 link-local and
+	// multicast address ranges. Packets sent from or to these addresses (e.g.
+	// Neighbor Discovery messages) are handled by the NORMAL action in
+	// ipv6Table.
+	_, ipv6LinkLocalAddr, _ = net.ParseCIDR("fe80::/10")
+	_, ipv6MulticastAddr, _ = net.ParseCIDR("ff00::/8")
+)
+
+var (
+	// The tables and registers below are exported for the consumers of the
+	// Traceflow PacketIn messages, which need to know where the packets were
+	// sent to the controller and which NetworkPolicy rules they matched.
+	CNPEgressRuleTable   = cnpEgressRuleTable
+	EgressRuleTable      = egressRuleTable
+	EgressDefaultTable   = egressDefaultTable
+	CNPIngressRuleTable  = cnpIngressRuleTable
+	IngressRuleTable     = ingressRuleTable
+	IngressDefaultTable  = ingressDefaultTable
+	L2ForwardingOutTable = l2ForwardingOutTable
+
+	EgressReg    = int(egressReg)
+	IngressReg   = int(ingressReg)
+	PortCacheReg = int(portCacheReg)
+	MarksReg     = int(marksReg)
+
+	CustomReasonMarkRange = customReasonMarkRange
+)
+
+// CtZone is the conntrack zone used for the connections of the Pods. It is
+// exported for the flow exporter, which polls the connections of this zone.
+const CtZone = ctZone
+
+// ofpPacketInReason is the reason of a PacketIn message.
+type ofpPacketInReason uint8
+
+const (
+	// PacketInReasonNP is the reason of the PacketIn messages sent for
+	// the connections which match NetworkPolicy rules with logging
+	// enabled. It's OFPR_NO_MATCH, which is never used otherwise as
+	// there is no table-miss flow sending packets to the controller.
+	PacketInReasonNP ofpPacketInReason = 0
+	// PacketInReasonTF is the reason of the PacketIn messages sent for
+	// Traceflow packets. It's OFPR_ACTION, i.e. the packets are sent to the
+	// controller by an explicit output action.
+	PacketInReasonTF ofpPacketInReason = 1
+)
+
+const (
+	// CustomReasonLogging and CustomReasonReject are the custom reasons of
+	// the NetworkPolicy PacketIn messages, stored in customReasonMarkRange
+	// of marksReg. A message can have both reasons, e.g. when the packet
+	// matches a rule with the Reject action and logging enabled.
+	// CustomReasonLogging indicates that the connection must be logged.
+	CustomReasonLogging = 0b01
+	// CustomReasonReject indicates that the connection must be rejected,
+	// i.e. that a TCP RST or an ICMP Destination Unreachable message must
+	// be sent back to the client.
+	CustomReasonReject = 0b10
+	// CustomReasonDNS indicates that the packet is a DNS response to a local
+	// Pod, which must be parsed to learn the addresses of the FQDNs used in
+	// NetworkPolicy rules and then forwarded to the Pod by the agent.
+	CustomReasonDNS = 0b100
 )
 
 var (
@@ -142,9 +279,35 @@ var (
 	// snatMarkRange takes the 17th bit of register marksReg to indicate if the packet needs to be SNATed with Node's IP
 	// or not. Its value is 0x1 if yes.
 	snatMarkRange = binding.Range{17, 17}
-
-	globalVirtualMAC, _ = net.ParseMAC("aa:bb:cc:dd:ee:ff")
+	// macRewriteMarkRange takes the 18th bit of register marksReg to indicate if the packet's MAC addresses need to be
+	// rewritten in l3ForwardingTable when it is forwarded to a local Pod. It is used only when AntreaProxy is enabled,
+	// in which case the packets from the tunnel and the packets DNAT'd to an Endpoint are marked.
+	macRewriteMarkRange = binding.Range{18, 18}
+	// customReasonMarkRange takes the 19th to 21st bits of register marksReg to indicate why a packet is sent to the
+	// controller by the NetworkPolicy flows, i.e. CustomReasonLogging, CustomReasonReject and CustomReasonDNS.
+	customReasonMarkRange = binding.Range{19, 21}
+	// endpointPortRegRange takes the 0th to 15th bits of register endpointPortReg to cache the port of the selected
+	// Endpoint.
+	endpointPortRegRange = binding.Range{0, 15}
+	// epSelectedMarkRange takes the 16th bit of register endpointPortReg to indicate if an Endpoint has been selected
+	// for the packet. Its value is 0x1 if yes.
+	epSelectedMarkRange = binding.Range{16, 16}
+	// endpointPortAndMarkRange covers both endpointPortRegRange and epSelectedMarkRange.
+	endpointPortAndMarkRange = binding.Range{0, 16}
+	// endpointIPRegRange takes a 32-bit range of register endpointIPReg to cache the IP of the selected Endpoint.
+	endpointIPRegRange = binding.Range{0, 31}
+	// affinityCheckedMarkRange takes the 17th bit of register endpointPortReg to indicate if the learned flows in
+	// sessionAffinityTable have been looked up for the packet. Its value is 0x1 if yes.
+	affinityCheckedMarkRange = binding.Range{17, 17}
+	// endpointMarksRange covers both epSelectedMarkRange and affinityCheckedMarkRange.
+	endpointMarksRange = binding.Range{16, 17}
+
+	GlobalVirtualMAC, _ = net.ParseMAC("aa:bb:cc:dd:ee:ff")
 	ReentranceMAC, _    = net.ParseMAC("de:ad:be:ef:de:ad")
+
+	// hairpinIP is the virtual IP which the packets of a Pod are SNAT'd to when they are load-balanced to the Pod
+	// itself by AntreaProxy, so that the Pod sends the reply packets back to OVS instead of to itself.
+	hairpinIP = net.ParseIP("169.254.169.252").To4()
 )
 
 type OFEntryOperations interface {
@@ -169,6 +332,15 @@ type client struct {
 	bridge                      binding.Bridge
 	pipeline                    map[binding.TableIDType]binding.Table
 	nodeFlowCache, podFlowCache *flowCategoryCache // cache for corresponding deletions
+	// serviceFlowCache caches the flows installed by AntreaProxy for Services and Endpoints. The flows of a Service
+	// are indexed by "<ClusterIP>:<port>/<protocol>", and the flows of an Endpoint by "<IP>:<port>/<protocol>".
+	serviceFlowCache *flowCategoryCache
+	// groupCache is a map from the group ID to the binding.Group installed for a Service.
+	groupCache sync.Map
+	// tfFlowCache caches the flows installed for Traceflow requests, indexed by the dataplane tag.
+	tfFlowCache *flowCategoryCache
+	// dnsFlowCache caches the flows intercepting the DNS responses to local Pods.
+	dnsFlowCache *flowCategoryCache
 	// "fixed" flows installed by the agent after initialization and which do not change during
 	// the lifetime of the client.
 	gatewayFlows, clusterServiceCIDRFlows, defaultTunnelFlows, hostNetworkingFlows []binding.Flow
@@ -187,10 +359,25 @@ type client struct {
 	nodeConfig  *config.NodeConfig
 	encapMode   config.TrafficEncapModeType
 	gatewayPort uint32 // OVSOFPort number
+	// ipProtocols are the IP protocols (IPv4 and / or IPv6) enabled on the Node, for which the IP flows
+	// which do not match any IP address are installed.
+	ipProtocols []binding.Protocol
+	// enableProxy indicates whether AntreaProxy is enabled, in which case the traffic to Services is load-balanced
+	// in OVS instead of being sent to the host gateway.
+	enableProxy bool
+	// enableEgress indicates whether the Egress feature is enabled, in which case the traffic from the Pods selected
+	// by an Egress to external destinations is SNATed to the egress IP in OVS.
+	enableEgress bool
+	// enablePolicyStats indicates whether the NetworkPolicyStats feature is enabled, in which case the packets allowed
+	// by the NetworkPolicy rules are counted per rule in egressMetricTable and ingressMetricTable.
+	enablePolicyStats bool
+	// snatFlowCache caches the flows installed for Egresses. The flows of an egress IP are indexed by its SNAT mark,
+	// and the flows of a Pod by its IP.
+	snatFlowCache *flowCategoryCache
 }
 
 func (c *client) GetTunnelVirtualMAC() net.HardwareAddr {
-	return globalVirtualMAC
+	return GlobalVirtualMAC
 }
 
 func (c *client) Add(flow binding.Flow) error {
@@ -244,10 +431,14 @@ func (c *client) defaultFlows() (flows []binding.Flow) {
 
 // tunnelClassifierFlow generates the flow to mark traffic comes from the tunnelOFPort.
 func (c *client) tunnelClassifierFlow(tunnelOFPort uint32, category cookie.Category) binding.Flow {
-	return c.pipeline[classifierTable].BuildFlow(priorityNormal).
+	flowBuilder := c.pipeline[classifierTable].BuildFlow(priorityNormal).
 		MatchInPort(tunnelOFPort).
-		Action().LoadRegRange(int(marksReg), markTrafficFromTunnel, binding.Range{0, 15}).
-		Action().GotoTable(conntrackTable).
+		Action().LoadRegRange(int(marksReg), markTrafficFromTunnel, binding.Range{0, 15})
+	if c.enableProxy {
+		// The packets from the tunnel always need the MAC rewrite if they are forwarded to a local Pod.
+		flowBuilder = flowBuilder.Action().LoadRegRange(int(marksReg), macRewriteMark, macRewriteMarkRange)
+	}
+	return flowBuilder.Action().GotoTable(conntrackTable).
 		Cookie(c.cookieAllocator.Request(category).Raw()).
 		Done()
 }
@@ -301,63 +492,101 @@ func (c *client) connectionTrackFlows(category cookie.Category) (flows []binding
 	connectionTrackTable := c.pipeline[conntrackTable]
 	connectionTrackStateTable := c.pipeline[conntrackStateTable]
 	connectionTrackCommitTable := c.pipeline[conntrackCommitTable]
-	flows = []binding.Flow{
-		connectionTrackTable.BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIP).
-			Action().CT(false, connectionTrackTable.GetNext(), ctZone).CTDone().
-			Cookie(c.cookieAllocator.Request(category).Raw()).
-			Done(),
-		connectionTrackStateTable.BuildFlow(priorityHigh).MatchProtocol(binding.ProtocolIP).
-			MatchRegRange(int(marksReg), markTrafficFromGateway, binding.Range{0, 15}).
-			MatchCTMark(gatewayCTMark).
-			MatchCTStateNew(false).MatchCTStateTrk(true).
-			Action().GotoTable(connectionTrackStateTable.GetNext()).
-			Cookie(c.cookieAllocator.Request(category).Raw()).
-			Done(),
-		connectionTrackStateTable.BuildFlow(priorityLow).MatchProtocol(binding.ProtocolIP).
-			MatchCTStateInv(true).MatchCTStateTrk(true).
-			Action().Drop().
-			Cookie(c.cookieAllocator.Request(category).Raw()).
-			Done(),
-		connectionTrackCommitTable.BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIP).
-			MatchRegRange(int(marksReg), markTrafficFromGateway, binding.Range{0, 15}).
-			MatchCTStateNew(true).MatchCTStateTrk(true).
-			Action().CT(true, connectionTrackCommitTable.GetNext(), ctZone).LoadToMark(gatewayCTMark).CTDone().
-			Cookie(c.cookieAllocator.Request(category).Raw()).
-			Done(),
-		connectionTrackCommitTable.BuildFlow(priorityLow).MatchProtocol(binding.ProtocolIP).
-			MatchCTStateNew(true).MatchCTStateTrk(true).
-			Action().CT(true, connectionTrackCommitTable.GetNext(), ctZone).CTDone().
-			Cookie(c.cookieAllocator.Request(category).Raw()).
-			Done(),
+	for _, proto := range c.ipProtocols {
+		ctAction := connectionTrackTable.BuildFlow(priorityNormal).MatchProtocol(proto).
+			Action().CT(false, connectionTrackTable.GetNext(), ctZone)
+		if c.enableProxy || c.enableEgress {
+			// Translate the packets of the connections DNAT'd by AntreaProxy or SNAT'd for an Egress, i.e. the
+			// destination of the request packets and the source of the reply packets, or the other way around.
+			ctAction = ctAction.NAT()
+		}
+		flows = append(flows,
+			ctAction.CTDone().
+				Cookie(c.cookieAllocator.Request(category).Raw()).
+				Done(),
+			connectionTrackStateTable.BuildFlow(priorityHigh).MatchProtocol(proto).
+				MatchRegRange(int(marksReg), markTrafficFromGateway, binding.Range{0, 15}).
+				MatchCTMark(gatewayCTMark).
+				MatchCTStateNew(false).MatchCTStateTrk(true).
+				Action().GotoTable(connectionTrackStateTable.GetNext()).
+				Cookie(c.cookieAllocator.Request(category).Raw()).
+				Done(),
+			connectionTrackStateTable.BuildFlow(priorityLow).MatchProtocol(proto).
+				MatchCTStateInv(true).MatchCTStateTrk(true).
+				Action().Drop().
+				Cookie(c.cookieAllocator.Request(category).Raw()).
+				Done(),
+			c.commitPolicyRulesToLabel(connectionTrackCommitTable.BuildFlow(priorityNormal).MatchProtocol(proto).
+				MatchRegRange(int(marksReg), markTrafficFromGateway, binding.Range{0, 15}).
+				MatchCTStateNew(true).MatchCTStateTrk(true).
+				Action().CT(true, connectionTrackCommitTable.GetNext(), ctZone).LoadToMark(gatewayCTMark)).
+				CTDone().
+				Cookie(c.cookieAllocator.Request(category).Raw()).
+				Done(),
+			c.commitPolicyRulesToLabel(connectionTrackCommitTable.BuildFlow(priorityLow).MatchProtocol(proto).
+				MatchCTStateNew(true).MatchCTStateTrk(true).
+				Action().CT(true, connectionTrackCommitTable.GetNext(), ctZone)).
+				CTDone().
+				Cookie(c.cookieAllocator.Request(category).Raw()).
+				Done(),
+		)
+		if proto == binding.ProtocolIPv6 {
+			// Some ICMPv6 messages (e.g. the Neighbor Discovery messages sent with a global source address) are
+			// considered invalid by conntrack. They must not be dropped, otherwise IPv6 address resolution fails.
+			// ICMPv6 types cannot be matched with the current Openflow library, so all invalid ICMPv6 packets
+			// are allowed.
+			flows = append(flows, connectionTrackStateTable.BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolICMPv6).
+				MatchCTStateInv(true).MatchCTStateTrk(true).
+				Action().GotoTable(connectionTrackStateTable.GetNext()).
+				Cookie(c.cookieAllocator.Request(category).Raw()).
+				Done())
+		}
 	}
 	return
 }
 
-// reEntranceBypassCTFlow generates flow that bypass CT for traffic re-entering host network space.
+// commitPolicyRulesToLabel adds the actions which store the conjunction IDs of the NetworkPolicy rules that allowed a
+// new connection in ct_label to the provided CT action, if NetworkPolicyStats is enabled. They are used to count the
+// packets of the connection once it is established.
+func (c *client) commitPolicyRulesToLabel(ctAction binding.CTAction) binding.CTAction {
+	if !c.enablePolicyStats {
+		return ctAction
+	}
+	return ctAction.MoveToLabel(egressReg.nxm(), &binding.Range{0, 31}, &egressLabelRange).
+		MoveToLabel(ingressReg.nxm(), &binding.Range{0, 31}, &ingressLabelRange)
+}
+
+// reEntranceBypassCTFlows generates flows that bypass CT for traffic re-entering host network space.
 // In host network space, we disable conntrack for re-entrance traffic so not to confuse conntrack
 // in host namespace, This however has inverse effect on conntrack in Antrea conntrack zone as well,
 // all subsequent re-entrance traffic becomes invalid.
-func (c *client) reEntranceBypassCTFlow(gwPort, reentPort uint32, category cookie.Category) binding.Flow {
+func (c *client) reEntranceBypassCTFlows(gwPort, reentPort uint32, category cookie.Category) (flows []binding.Flow) {
 	conntrackCommitTable := c.pipeline[conntrackCommitTable]
-	return conntrackCommitTable.BuildFlow(priorityHigh).MatchProtocol(binding.ProtocolIP).
-		MatchRegRange(int(marksReg), portFoundMark, ofPortMarkRange).
-		MatchInPort(gwPort).MatchReg(int(portCacheReg), reentPort).
-		Action().GotoTable(conntrackCommitTable.GetNext()).
-		Cookie(c.cookieAllocator.Request(category).Raw()).
-		Done()
+	for _, proto := range c.ipProtocols {
+		flows = append(flows, conntrackCommitTable.BuildFlow(priorityHigh).MatchProtocol(proto).
+			MatchRegRange(int(marksReg), portFoundMark, ofPortMarkRange).
+			MatchInPort(gwPort).MatchReg(int(portCacheReg), reentPort).
+			Action().GotoTable(conntrackCommitTable.GetNext()).
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done())
+	}
+	return flows
 }
 
-// ctRewriteDstMACFlow rewrites the destination MAC with local host gateway MAC if the packets has set ct_mark but not sent from the host gateway.
-func (c *client) ctRewriteDstMACFlow(gatewayMAC net.HardwareAddr, category cookie.Category) binding.Flow {
+// ctRewriteDstMACFlows rewrite the destination MAC with local host gateway MAC if the packets has set ct_mark but not sent from the host gateway.
+func (c *client) ctRewriteDstMACFlows(gatewayMAC net.HardwareAddr, category cookie.Category) (flows []binding.Flow) {
 	connectionTrackStateTable := c.pipeline[conntrackStateTable]
 	macData, _ := strconv.ParseUint(strings.Replace(gatewayMAC.String(), ":", "", -1), 16, 64)
-	return connectionTrackStateTable.BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIP).
-		MatchCTMark(gatewayCTMark).
-		MatchCTStateNew(false).MatchCTStateTrk(true).
-		Action().LoadRange(binding.NxmFieldDstMAC, macData, binding.Range{0, 47}).
-		Action().GotoTable(connectionTrackStateTable.GetNext()).
-		Cookie(c.cookieAllocator.Request(category).Raw()).
-		Done()
+	for _, proto := range c.ipProtocols {
+		flows = append(flows, connectionTrackStateTable.BuildFlow(priorityNormal).MatchProtocol(proto).
+			MatchCTMark(gatewayCTMark).
+			MatchCTStateNew(false).MatchCTStateTrk(true).
+			Action().LoadRange(binding.NxmFieldDstMAC, macData, binding.Range{0, 47}).
+			Action().GotoTable(connectionTrackStateTable.GetNext()).
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done())
+	}
+	return flows
 }
 
 // l2ForwardCalcFlow generates the flow that matches dst MAC and loads ofPort to reg.
@@ -372,89 +601,116 @@ func (c *client) l2ForwardCalcFlow(dstMAC net.HardwareAddr, ofPort uint32, categ
 		Done()
 }
 
-// l2ForwardOutputFlow generates the flow that outputs packets to OVS port after L2 forwarding calculation.
-func (c *client) l2ForwardOutputFlow(category cookie.Category) binding.Flow {
-	return c.pipeline[l2ForwardingOutTable].BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIP).
-		MatchRegRange(int(marksReg), portFoundMark, ofPortMarkRange).
-		Action().OutputRegRange(int(portCacheReg), ofPortRegRange).
-		Cookie(c.cookieAllocator.Request(category).Raw()).
-		Done()
+// l2ForwardOutputFlows generates the flows that output packets to OVS port after L2 forwarding calculation.
+func (c *client) l2ForwardOutputFlows(category cookie.Category) (flows []binding.Flow) {
+	for _, proto := range c.ipProtocols {
+		flows = append(flows, c.pipeline[l2ForwardingOutTable].BuildFlow(priorityNormal).MatchProtocol(proto).
+			MatchRegRange(int(marksReg), portFoundMark, ofPortMarkRange).
+			Action().OutputRegRange(int(portCacheReg), ofPortRegRange).
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done())
+	}
+	return flows
 }
 
-// l2ForwardOutputReentInPortFlow generates the flow that forward re-entrance peer Node traffic via gw0.
-// This flow supersedes default output flow because ovs by default auto-skips packets with output = input port.
-func (c *client) l2ForwardOutputReentInPortFlow(gwPort uint32, category cookie.Category) binding.Flow {
-	return c.pipeline[l2ForwardingOutTable].BuildFlow(priorityHigh).MatchProtocol(binding.ProtocolIP).
-		MatchRegRange(int(marksReg), portFoundMark, ofPortMarkRange).
-		MatchInPort(gwPort).MatchReg(int(portCacheReg), gwPort).
-		Action().SetSrcMAC(ReentranceMAC).
-		Action().OutputInPort().
-		Cookie(c.cookieAllocator.Request(category).Raw()).
-		Done()
+// l2ForwardOutputReentInPortFlows generates the flows that forward re-entrance peer Node traffic via gw0.
+// These flows supersede default output flows because ovs by default auto-skips packets with output = input port.
+func (c *client) l2ForwardOutputReentInPortFlows(gwPort uint32, category cookie.Category) (flows []binding.Flow) {
+	for _, proto := range c.ipProtocols {
+		flows = append(flows, c.pipeline[l2ForwardingOutTable].BuildFlow(priorityHigh).MatchProtocol(proto).
+			MatchRegRange(int(marksReg), portFoundMark, ofPortMarkRange).
+			MatchInPort(gwPort).MatchReg(int(portCacheReg), gwPort).
+			Action().SetSrcMAC(ReentranceMAC).
+			Action().OutputInPort().
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done())
+	}
+	return flows
 }
 
-// l3BypassMACRewriteFlow bypasses remaining l3forwarding flows if the MAC is set via ctRewriteDstMACFlow in
+// l3BypassMACRewriteFlows bypass remaining l3forwarding flows if the MAC is set via ctRewriteDstMACFlows in
 // conntrackState stage.
-func (c *client) l3BypassMACRewriteFlow(gatewayMAC net.HardwareAddr, category cookie.Category) binding.Flow {
+func (c *client) l3BypassMACRewriteFlows(gatewayMAC net.HardwareAddr, category cookie.Category) (flows []binding.Flow) {
 	l3FwdTable := c.pipeline[l3ForwardingTable]
-	return l3FwdTable.BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIP).
-		MatchCTMark(gatewayCTMark).
-		MatchDstMAC(gatewayMAC).
-		Action().GotoTable(l3FwdTable.GetNext()).
-		Cookie(c.cookieAllocator.Request(category).Raw()).
-		Done()
+	for _, proto := range c.ipProtocols {
+		flows = append(flows, l3FwdTable.BuildFlow(priorityNormal).MatchProtocol(proto).
+			MatchCTMark(gatewayCTMark).
+			MatchDstMAC(gatewayMAC).
+			Action().GotoTable(l3FwdTable.GetNext()).
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done())
+	}
+	return flows
 }
 
-// l3FlowsToPod generates the flow to rewrite MAC if the packet is received from tunnel port and destined for local Pods.
-func (c *client) l3FlowsToPod(localGatewayMAC net.HardwareAddr, podInterfaceIP net.IP, podInterfaceMAC net.HardwareAddr, category cookie.Category) binding.Flow {
+// l3FlowsToPod generates the flows to rewrite MAC if the packet is received from tunnel port and destined for local Pods.
+func (c *client) l3FlowsToPod(localGatewayMAC net.HardwareAddr, podInterfaceIPs []net.IP, podInterfaceMAC net.HardwareAddr, category cookie.Category) (flows []binding.Flow) {
 	l3FwdTable := c.pipeline[l3ForwardingTable]
-	// Rewrite src MAC to local gateway MAC, and rewrite dst MAC to pod MAC
-	return l3FwdTable.BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIP).
-		MatchDstMAC(globalVirtualMAC).
-		MatchDstIP(podInterfaceIP).
-		Action().SetSrcMAC(localGatewayMAC).
-		Action().SetDstMAC(podInterfaceMAC).
-		Action().DecTTL().
-		Action().GotoTable(l3FwdTable.GetNext()).
-		Cookie(c.cookieAllocator.Request(category).Raw()).
-		Done()
+	for _, ip := range podInterfaceIPs {
+		flowBuilder := l3FwdTable.BuildFlow(priorityNormal).MatchProtocol(getIPProtocol(ip))
+		if c.enableProxy {
+			// Besides the packets from the tunnel, the packets sent to the gateway MAC by local Pods and DNAT'd to
+			// this Pod by AntreaProxy need the MAC rewrite.
+			flowBuilder = flowBuilder.MatchRegRange(int(marksReg), macRewriteMark, macRewriteMarkRange)
+		} else {
+			flowBuilder = flowBuilder.MatchDstMAC(GlobalVirtualMAC)
+		}
+		// Rewrite src MAC to local gateway MAC, and rewrite dst MAC to pod MAC
+		flows = append(flows, flowBuilder.MatchDstIP(ip).
+			Action().SetSrcMAC(localGatewayMAC).
+			Action().SetDstMAC(podInterfaceMAC).
+			Action().DecTTL().
+			Action().GotoTable(l3FwdTable.GetNext()).
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done())
+	}
+	return flows
 }
 
-// l3ToPodFromGwFlow generates the flow to rewrite MAC if the packet IP matches an local IP.
-// This flow is used in policy only traffic mode.
-func (c *client) l3ToPodFlow(podInterfaceIP net.IP, podInterfaceMAC net.HardwareAddr, category cookie.Category) binding.Flow {
+// l3ToPodFlows generates the flows to rewrite MAC if the packet IP matches an local IP.
+// These flows are used in policy only traffic mode.
+func (c *client) l3ToPodFlows(podInterfaceIPs []net.IP, podInterfaceMAC net.HardwareAddr, category cookie.Category) (flows []binding.Flow) {
 	l3FwdTable := c.pipeline[l3ForwardingTable]
-	return l3FwdTable.BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIP).
-		MatchDstIP(podInterfaceIP).
-		Action().SetDstMAC(podInterfaceMAC).
-		Action().DecTTL().
-		Action().GotoTable(l3FwdTable.GetNext()).
-		Cookie(c.cookieAllocator.Request(category).Raw()).
-		Done()
+	for _, ip := range podInterfaceIPs {
+		flows = append(flows, l3FwdTable.BuildFlow(priorityNormal).MatchProtocol(getIPProtocol(ip)).
+			MatchDstIP(ip).
+			Action().SetDstMAC(podInterfaceMAC).
+			Action().DecTTL().
+			Action().GotoTable(l3FwdTable.GetNext()).
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done())
+	}
+	return flows
 }
 
-// l3ToGWFlow generates the flow to rewrite MAC to gw port if the packet received is unmatched by local Pod flows.
-// This flow is used in policy only traffic mode.
-func (c *client) l3ToGWFlow(gwMAC net.HardwareAddr, category cookie.Category) binding.Flow {
+// l3ToGWFlows generate the flows to rewrite MAC to gw port if the packet received is unmatched by local Pod flows.
+// These flows are used in policy only traffic mode.
+func (c *client) l3ToGWFlows(gwMAC net.HardwareAddr, category cookie.Category) (flows []binding.Flow) {
 	l3FwdTable := c.pipeline[l3ForwardingTable]
-	return l3FwdTable.BuildFlow(priorityLow).MatchProtocol(binding.ProtocolIP).
-		Action().SetDstMAC(gwMAC).
-		Action().DecTTL().
-		Action().GotoTable(l3FwdTable.GetNext()).
-		Cookie(c.cookieAllocator.Request(category).Raw()).
-		Done()
+	for _, proto := range c.ipProtocols {
+		flows = append(flows, l3FwdTable.BuildFlow(priorityLow).MatchProtocol(proto).
+			Action().SetDstMAC(gwMAC).
+			Action().DecTTL().
+			Action().GotoTable(l3FwdTable.GetNext()).
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done())
+	}
+	return flows
 }
 
-// l3ToGatewayFlow generates flow that rewrites MAC of the packet received from tunnel port and destined to local gateway.
-func (c *client) l3ToGatewayFlow(localGatewayIP net.IP, localGatewayMAC net.HardwareAddr, category cookie.Category) binding.Flow {
+// l3ToGatewayFlows generate flows that rewrite MAC of the packet received from tunnel port and destined to local gateway.
+func (c *client) l3ToGatewayFlows(localGatewayIPs []net.IP, localGatewayMAC net.HardwareAddr, category cookie.Category) (flows []binding.Flow) {
 	l3FwdTable := c.pipeline[l3ForwardingTable]
-	return l3FwdTable.BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIP).
-		MatchDstMAC(globalVirtualMAC).
-		MatchDstIP(localGatewayIP).
-		Action().SetDstMAC(localGatewayMAC).
-		Action().GotoTable(l3FwdTable.GetNext()).
-		Cookie(c.cookieAllocator.Request(category).Raw()).
-		Done()
+	for _, ip := range localGatewayIPs {
+		flows = append(flows, l3FwdTable.BuildFlow(priorityNormal).MatchProtocol(getIPProtocol(ip)).
+			MatchDstMAC(GlobalVirtualMAC).
+			MatchDstIP(ip).
+			Action().SetDstMAC(localGatewayMAC).
+			Action().GotoTable(l3FwdTable.GetNext()).
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done())
+	}
+	return flows
 }
 
 // l3FwdFlowToRemote generates the L3 forward flow on source node to support traffic to remote pods/gateway.
@@ -464,12 +720,12 @@ func (c *client) l3FwdFlowToRemote(
 	tunnelPeer net.IP,
 	tunOFPort uint32,
 	category cookie.Category) binding.Flow {
-	return c.pipeline[l3ForwardingTable].BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIP).
+	return c.pipeline[l3ForwardingTable].BuildFlow(priorityNormal).MatchProtocol(getIPProtocol(peerSubnet.IP)).
 		MatchDstIPNet(peerSubnet).
 		Action().DecTTL().
 		// Rewrite src MAC to local gateway MAC and rewrite dst MAC to virtual MAC.
 		Action().SetSrcMAC(localGatewayMAC).
-		Action().SetDstMAC(globalVirtualMAC).
+		Action().SetDstMAC(GlobalVirtualMAC).
 		// Load ofport of the tunnel interface.
 		Action().LoadRegRange(int(portCacheReg), tunOFPort, ofPortRegRange).
 		// Set MAC-known.
@@ -489,7 +745,7 @@ func (c *client) l3FwdFlowToRemoteViaGW(
 	peerSubnet net.IPNet,
 	category cookie.Category) binding.Flow {
 	l3FwdTable := c.pipeline[l3ForwardingTable]
-	return l3FwdTable.BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIP).
+	return l3FwdTable.BuildFlow(priorityNormal).MatchProtocol(getIPProtocol(peerSubnet.IP)).
 		MatchDstIPNet(peerSubnet).
 		Action().DecTTL().
 		Action().SetDstMAC(localGatewayMAC).
@@ -505,10 +761,10 @@ func (c *client) arpResponderFlow(peerGatewayIP net.IP, category cookie.Category
 		MatchARPOp(1).
 		MatchARPTpa(peerGatewayIP).
 		Action().Move(binding.NxmFieldSrcMAC, binding.NxmFieldDstMAC).
-		Action().SetSrcMAC(globalVirtualMAC).
+		Action().SetSrcMAC(GlobalVirtualMAC).
 		Action().LoadARPOperation(2).
 		Action().Move(binding.NxmFieldARPSha, binding.NxmFieldARPTha).
-		Action().SetARPSha(globalVirtualMAC).
+		Action().SetARPSha(GlobalVirtualMAC).
 		Action().Move(binding.NxmFieldARPSpa, binding.NxmFieldARPTpa).
 		Action().SetARPSpa(peerGatewayIP).
 		Action().OutputInPort().
@@ -522,10 +778,10 @@ func (c *client) arpResponderStaticFlow(category cookie.Category) binding.Flow {
 	return c.pipeline[arpResponderTable].BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolARP).
 		MatchARPOp(1).
 		Action().Move(binding.NxmFieldSrcMAC, binding.NxmFieldDstMAC).
-		Action().SetSrcMAC(globalVirtualMAC).
+		Action().SetSrcMAC(GlobalVirtualMAC).
 		Action().LoadARPOperation(2).
 		Action().Move(binding.NxmFieldARPSha, binding.NxmFieldARPTha).
-		Action().SetARPSha(globalVirtualMAC).
+		Action().SetARPSha(GlobalVirtualMAC).
 		Action().Move(binding.NxmFieldARPTpa, swapReg.nxm()).
 		Action().Move(binding.NxmFieldARPSpa, binding.NxmFieldARPTpa).
 		Action().Move(swapReg.nxm(), binding.NxmFieldARPSpa).
@@ -535,18 +791,67 @@ func (c *client) arpResponderStaticFlow(category cookie.Category) binding.Flow {
 
 }
 
-// podIPSpoofGuardFlow generates the flow to check IP traffic sent out from local pod. Traffic from host gateway interface
+// arpResponderPoolPodFlow generates the ARP reply with the local gateway MAC for any ARP request sent by the Pod
+// connected to ofPort, whose IP is allocated from an IPPool. Its priority is higher than the flows answering the ARP
+// requests for the peer gateways, as the Pod must send all its traffic to the local gateway.
+func (c *client) arpResponderPoolPodFlow(ofPort uint32, gatewayMAC net.HardwareAddr, category cookie.Category) binding.Flow {
+	return c.pipeline[arpResponderTable].BuildFlow(priorityHigh).MatchProtocol(binding.ProtocolARP).
+		MatchInPort(ofPort).
+		MatchARPOp(1).
+		Action().Move(binding.NxmFieldSrcMAC, binding.NxmFieldDstMAC).
+		Action().SetSrcMAC(gatewayMAC).
+		Action().LoadARPOperation(2).
+		Action().Move(binding.NxmFieldARPSha, binding.NxmFieldARPTha).
+		Action().SetARPSha(gatewayMAC).
+		Action().Move(binding.NxmFieldARPTpa, swapReg.nxm()).
+		Action().Move(binding.NxmFieldARPSpa, binding.NxmFieldARPTpa).
+		Action().Move(swapReg.nxm(), binding.NxmFieldARPSpa).
+		Action().OutputInPort().
+		Cookie(c.cookieAllocator.Request(category).Raw()).
+		Done()
+}
+
+// podIPSpoofGuardFlows generates the flows to check IP traffic sent out from local pod. Traffic from host gateway interface
 // will not be checked, since it might be pod to service traffic or host namespace traffic.
-func (c *client) podIPSpoofGuardFlow(ifIP net.IP, ifMAC net.HardwareAddr, ifOFPort uint32, category cookie.Category) binding.Flow {
+// IPv6 packets which pass the check are sent to ipv6Table. So are the IPv6 packets sent from the link-local address or
+// the unspecified address of the Pod, which are required by IPv6 Neighbor Discovery and Duplicate Address Detection.
+func (c *client) podIPSpoofGuardFlows(ifIPs []net.IP, ifMAC net.HardwareAddr, ifOFPort uint32, category cookie.Category) (flows []binding.Flow) {
 	ipPipeline := c.pipeline
 	ipSpoofGuardTable := ipPipeline[spoofGuardTable]
-	return ipSpoofGuardTable.BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIP).
-		MatchInPort(ifOFPort).
-		MatchSrcMAC(ifMAC).
-		MatchSrcIP(ifIP).
-		Action().GotoTable(ipSpoofGuardTable.GetNext()).
-		Cookie(c.cookieAllocator.Request(category).Raw()).
-		Done()
+	hasIPv6 := false
+	for _, ifIP := range ifIPs {
+		ipProtocol := getIPProtocol(ifIP)
+		nextTable := ipSpoofGuardTable.GetNext()
+		if ipProtocol == binding.ProtocolIPv6 {
+			nextTable = ipv6Table
+			hasIPv6 = true
+		}
+		flows = append(flows, ipSpoofGuardTable.BuildFlow(priorityNormal).MatchProtocol(ipProtocol).
+			MatchInPort(ifOFPort).
+			MatchSrcMAC(ifMAC).
+			MatchSrcIP(ifIP).
+			Action().GotoTable(nextTable).
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done())
+	}
+	if hasIPv6 {
+		flows = append(flows,
+			ipSpoofGuardTable.BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIPv6).
+				MatchInPort(ifOFPort).
+				MatchSrcMAC(ifMAC).
+				MatchSrcIPNet(*ipv6LinkLocalAddr).
+				Action().GotoTable(ipv6Table).
+				Cookie(c.cookieAllocator.Request(category).Raw()).
+				Done(),
+			ipSpoofGuardTable.BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIPv6).
+				MatchInPort(ifOFPort).
+				MatchSrcMAC(ifMAC).
+				MatchSrcIP(net.IPv6unspecified).
+				Action().GotoTable(ipv6Table).
+				Cookie(c.cookieAllocator.Request(category).Raw()).
+				Done())
+	}
+	return flows
 }
 
 // gatewayARPSpoofGuardFlow generates the flow to check ARP traffic sent out from the local gateway interface.
@@ -571,20 +876,59 @@ func (c *client) arpSpoofGuardFlow(ifIP net.IP, ifMAC net.HardwareAddr, ifOFPort
 		Done()
 }
 
-// gatewayIPSpoofGuardFlow generates the flow to skip spoof guard checking for traffic sent from gateway interface.
-func (c *client) gatewayIPSpoofGuardFlow(gatewayOFPort uint32, category cookie.Category) binding.Flow {
+// gatewayIPSpoofGuardFlows generates the flows to skip spoof guard checking for traffic sent from gateway interface.
+func (c *client) gatewayIPSpoofGuardFlows(gatewayOFPort uint32, category cookie.Category) (flows []binding.Flow) {
 	ipPipeline := c.pipeline
 	ipSpoofGuardTable := ipPipeline[spoofGuardTable]
-	return ipSpoofGuardTable.BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIP).
-		MatchInPort(gatewayOFPort).
-		Action().GotoTable(ipSpoofGuardTable.GetNext()).
-		Cookie(c.cookieAllocator.Request(category).Raw()).
-		Done()
+	for _, proto := range c.ipProtocols {
+		nextTable := ipSpoofGuardTable.GetNext()
+		if proto == binding.ProtocolIPv6 {
+			nextTable = ipv6Table
+		}
+		flows = append(flows, ipSpoofGuardTable.BuildFlow(priorityNormal).MatchProtocol(proto).
+			MatchInPort(gatewayOFPort).
+			Action().GotoTable(nextTable).
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done())
+	}
+	return flows
+}
+
+// ipv6Flows generates the flows to handle IPv6 Neighbor Discovery and multicast packets with the NORMAL action, since
+// they are not supposed to go through the rest of the pipeline.
+func (c *client) ipv6Flows(category cookie.Category) []binding.Flow {
+	table := c.pipeline[ipv6Table]
+	return []binding.Flow{
+		// Allow IPv6 packets sent from link-local addresses.
+		table.BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIPv6).
+			MatchSrcIPNet(*ipv6LinkLocalAddr).
+			Action().Normal().
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done(),
+		// Allow IPv6 multicast packets, e.g. Neighbor Solicitation messages.
+		table.BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIPv6).
+			MatchDstIPNet(*ipv6MulticastAddr).
+			Action().Normal().
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done(),
+		// Allow IPv6 packets sent to link-local addresses.
+		table.BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIPv6).
+			MatchDstIPNet(*ipv6LinkLocalAddr).
+			Action().Normal().
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done(),
+	}
 }
 
 // serviceCIDRDNATFlow generates flows to match dst IP in service CIDR and output to host gateway interface directly.
+// When AntreaProxy is enabled, the flow has a lower priority than the flows generated by serviceLBFlow, so that only
+// the traffic to the Services which are not load-balanced by AntreaProxy is sent to the host gateway.
 func (c *client) serviceCIDRDNATFlow(serviceCIDR *net.IPNet, gatewayMAC net.HardwareAddr, gatewayOFPort uint32, category cookie.Category) binding.Flow {
-	return c.pipeline[dnatTable].BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIP).
+	priority := priorityNormal
+	if c.enableProxy {
+		priority = priorityLow
+	}
+	return c.pipeline[dnatTable].BuildFlow(priority).MatchProtocol(getIPProtocol(serviceCIDR.IP)).
 		MatchDstIPNet(*serviceCIDR).
 		Action().SetDstMAC(gatewayMAC).
 		Action().LoadRegRange(int(portCacheReg), gatewayOFPort, ofPortRegRange).
@@ -594,6 +938,175 @@ func (c *client) serviceCIDRDNATFlow(serviceCIDR *net.IPNet, gatewayMAC net.Hard
 		Done()
 }
 
+// serviceLBFlow generates the flow which sends the new connections to the Service with the provided ClusterIP, port
+// and protocol to the group, which selects an Endpoint for the connection. If the Service has ClientIP session
+// affinity (affinityTimeout is not 0), the flow matches only the connections for which no Endpoint has been learned
+// in sessionAffinityTable.
+func (c *client) serviceLBFlow(groupID binding.GroupIDType, svcIP net.IP, svcPort uint16, protocol binding.Protocol, affinityTimeout uint16) binding.Flow {
+	flowBuilder := c.serviceFlowBuilder(dnatTable, priorityNormal, svcIP, svcPort, protocol).
+		MatchCTStateNew(true).MatchCTStateTrk(true)
+	if affinityTimeout != 0 {
+		flowBuilder = flowBuilder.MatchRegRange(int(endpointPortReg), affinityCheckedMark<<affinityCheckedMarkRange[0], endpointMarksRange)
+	}
+	return flowBuilder.Action().Group(groupID).
+		Cookie(c.cookieAllocator.Request(cookie.Service).Raw()).
+		Done()
+}
+
+// serviceFlowBuilder returns a FlowBuilder in the provided table which matches the packets sent to the Service with
+// the provided ClusterIP, port and protocol.
+func (c *client) serviceFlowBuilder(tableID binding.TableIDType, priority uint16, svcIP net.IP, svcPort uint16, protocol binding.Protocol) binding.FlowBuilder {
+	flowBuilder := c.pipeline[tableID].BuildFlow(priority).
+		MatchProtocol(protocol).
+		MatchDstIP(svcIP)
+	switch protocol {
+	case binding.ProtocolTCP:
+		flowBuilder = flowBuilder.MatchTCPDstPort(svcPort)
+	case binding.ProtocolUDP:
+		flowBuilder = flowBuilder.MatchUDPDstPort(svcPort)
+	case binding.ProtocolSCTP:
+		flowBuilder = flowBuilder.MatchSCTPDstPort(svcPort)
+	}
+	return flowBuilder
+}
+
+// sessionAffinityLookupFlow generates the flow which looks up the Endpoint learned in sessionAffinityTable for the
+// client of a new connection to a Service with ClientIP session affinity, and then resubmits the packet to dnatTable.
+// If an Endpoint is found, the packet is DNAT'd by the flow generated by endpointDNATFlow, otherwise it is sent to the
+// Service group by the flow generated by serviceLBFlow.
+func (c *client) sessionAffinityLookupFlow(svcIP net.IP, svcPort uint16, protocol binding.Protocol) binding.Flow {
+	return c.serviceFlowBuilder(dnatTable, priorityNormal, svcIP, svcPort, protocol).
+		MatchCTStateNew(true).MatchCTStateTrk(true).
+		MatchRegRange(int(endpointPortReg), 0, endpointMarksRange).
+		Action().ResubmitToTable(sessionAffinityTable).
+		Action().LoadRegRange(int(endpointPortReg), affinityCheckedMark, affinityCheckedMarkRange).
+		Action().ResubmitToTable(dnatTable).
+		Cookie(c.cookieAllocator.Request(cookie.Service).Raw()).
+		Done()
+}
+
+// sessionAffinityLearnFlow generates the flow which learns the Endpoint selected by the Service group for a new
+// connection to a Service with ClientIP session affinity, and then resubmits the packet to dnatTable. The learned
+// flow matches the following connections from the same client to the Service and loads the same Endpoint, until it
+// has been idle for affinityTimeout seconds. The learned flows are deleted together with this flow. Only TCP and UDP
+// are supported.
+func (c *client) sessionAffinityLearnFlow(svcIP net.IP, svcPort uint16, protocol binding.Protocol, affinityTimeout uint16) binding.Flow {
+	cookieID := c.cookieAllocator.Request(cookie.Service).Raw()
+	return c.serviceFlowBuilder(sessionAffinityTable, priorityLow, svcIP, svcPort, protocol).
+		MatchRegRange(int(endpointPortReg), epSelectedMark<<epSelectedMarkRange[0], epSelectedMarkRange).
+		Action().Learn(sessionAffinityTable, priorityNormal, affinityTimeout, 0, cookieID).
+		DeleteLearned().
+		MatchTransportDst(protocol).
+		MatchLearnedSrcIP().
+		MatchLearnedDstIP().
+		LoadRegToReg(int(endpointIPReg), int(endpointIPReg), endpointIPRegRange, endpointIPRegRange).
+		LoadRegToReg(int(endpointPortReg), int(endpointPortReg), endpointPortAndMarkRange, endpointPortAndMarkRange).
+		Done().
+		Action().ResubmitToTable(dnatTable).
+		Cookie(cookieID).
+		Done()
+}
+
+// endpointDNATFlow generates the flow which DNATs the packets to the provided Endpoint once the Endpoint is selected
+// by a Service group, and commits the connection. The packets are also marked so that their MAC addresses are
+// rewritten if they are forwarded to a local Pod.
+func (c *client) endpointDNATFlow(endpoint types.Endpoint, protocol binding.Protocol) binding.Flow {
+	dnatTable := c.pipeline[dnatTable]
+	ipVal := binary.BigEndian.Uint32(endpoint.IP.To4())
+	portVal := epSelectedMark<<epSelectedMarkRange[0] | uint32(endpoint.Port)
+	return dnatTable.BuildFlow(priorityHigh).
+		MatchProtocol(protocol).
+		MatchReg(int(endpointIPReg), ipVal).
+		MatchRegRange(int(endpointPortReg), portVal, endpointPortAndMarkRange).
+		Action().LoadRegRange(int(marksReg), macRewriteMark, macRewriteMarkRange).
+		Action().CT(true, dnatTable.GetNext(), ctZone).
+		DNAT(&binding.IPRange{StartIP: endpoint.IP, EndIP: endpoint.IP},
+			&binding.PortRange{StartPort: endpoint.Port, EndPort: endpoint.Port}).
+		CTDone().
+		Cookie(c.cookieAllocator.Request(cookie.Service).Raw()).
+		Done()
+}
+
+// endpointHairpinDNATFlow generates the flow which DNATs the packets to the provided Endpoint when they are sent by
+// the Endpoint itself, i.e. when the connection hairpins. It has a higher priority than the flow generated by
+// endpointDNATFlow, and marks the connection with hairpinCTMark, so that its packets are sent back to the Pod by the
+// flows generated by serviceHairpinFlows.
+func (c *client) endpointHairpinDNATFlow(endpoint types.Endpoint, protocol binding.Protocol) binding.Flow {
+	dnatTable := c.pipeline[dnatTable]
+	ipVal := binary.BigEndian.Uint32(endpoint.IP.To4())
+	portVal := epSelectedMark<<epSelectedMarkRange[0] | uint32(endpoint.Port)
+	return dnatTable.BuildFlow(priorityHigh+1).
+		MatchProtocol(protocol).
+		MatchSrcIP(endpoint.IP).
+		MatchReg(int(endpointIPReg), ipVal).
+		MatchRegRange(int(endpointPortReg), portVal, endpointPortAndMarkRange).
+		Action().LoadRegRange(int(marksReg), macRewriteMark, macRewriteMarkRange).
+		Action().CT(true, dnatTable.GetNext(), ctZone).
+		LoadToMark(hairpinCTMark).
+		DNAT(&binding.IPRange{StartIP: endpoint.IP, EndIP: endpoint.IP},
+			&binding.PortRange{StartPort: endpoint.Port, EndPort: endpoint.Port}).
+		CTDone().
+		Cookie(c.cookieAllocator.Request(cookie.Service).Raw()).
+		Done()
+}
+
+// serviceHairpinFlows generates the flows which send the packets of the hairpin connections, marked by the flow
+// generated by endpointHairpinDNATFlow, back to the Pod through its ingress port. OVS drops the packets output to
+// their ingress port otherwise. The request packets are SNAT'd to hairpinIP, so that the Pod sends the reply packets
+// to OVS. The destination of the reply packets is restored to the Pod IP before they are sent to conntrack, which
+// then translates their source back to the Service, and their MAC addresses are rewritten like the packets DNAT'd
+// to a local Pod.
+func (c *client) serviceHairpinFlows(category cookie.Category) []binding.Flow {
+	connectionTrackTable := c.pipeline[conntrackTable]
+	l2FwdOutTable := c.pipeline[l2ForwardingOutTable]
+	return []binding.Flow{
+		connectionTrackTable.BuildFlow(priorityHigh).MatchProtocol(binding.ProtocolIP).
+			MatchDstIP(hairpinIP).
+			Action().Move(binding.NxmFieldSrcIPv4, binding.NxmFieldDstIPv4).
+			Action().LoadRegRange(int(marksReg), macRewriteMark, macRewriteMarkRange).
+			Action().CT(false, connectionTrackTable.GetNext(), ctZone).NAT().CTDone().
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done(),
+		l2FwdOutTable.BuildFlow(priorityHigh).MatchProtocol(binding.ProtocolIP).
+			MatchRegRange(int(marksReg), portFoundMark, ofPortMarkRange).
+			MatchCTMark(hairpinCTMark).
+			MatchCTStateRpl(false).MatchCTStateTrk(true).
+			Action().SetSrcIP(hairpinIP).
+			Action().OutputInPort().
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done(),
+		l2FwdOutTable.BuildFlow(priorityHigh).MatchProtocol(binding.ProtocolIP).
+			MatchRegRange(int(marksReg), portFoundMark, ofPortMarkRange).
+			MatchCTMark(hairpinCTMark).
+			MatchCTStateRpl(true).MatchCTStateTrk(true).
+			Action().OutputInPort().
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done(),
+	}
+}
+
+// serviceEndpointGroup generates the group which selects one of the provided Endpoints with equal weights. The
+// selected Endpoint is loaded into endpointIPReg and endpointPortReg, and the packet is resubmitted to dnatTable, in
+// which it is DNAT'd by the flow generated by endpointDNATFlow. If withSessionAffinity is true, the packet is
+// resubmitted to sessionAffinityTable instead, so that the Endpoint is learned first.
+func (c *client) serviceEndpointGroup(groupID binding.GroupIDType, withSessionAffinity bool, endpoints []types.Endpoint) binding.Group {
+	resubmitTable := dnatTable
+	if withSessionAffinity {
+		resubmitTable = sessionAffinityTable
+	}
+	group := c.bridge.CreateGroup(groupID).ResetBuckets()
+	for _, endpoint := range endpoints {
+		ipVal := binary.BigEndian.Uint32(endpoint.IP.To4())
+		portVal := epSelectedMark<<epSelectedMarkRange[0] | uint32(endpoint.Port)
+		group = group.Bucket().Weight(100).
+			LoadReg(int(endpointIPReg), ipVal).
+			LoadRegRange(int(endpointPortReg), portVal, endpointPortAndMarkRange).
+			ResubmitToTable(resubmitTable).
+			Done()
+	}
+	return group
+}
+
 // arpNormalFlow generates the flow to response arp in normal way if no flow in arpResponderTable is matched.
 func (c *client) arpNormalFlow(category cookie.Category) binding.Flow {
 	return c.pipeline[arpResponderTable].BuildFlow(priorityLow).MatchProtocol(binding.ProtocolARP).
@@ -602,14 +1115,72 @@ func (c *client) arpNormalFlow(category cookie.Category) binding.Flow {
 		Done()
 }
 
-// conjunctionActionFlow generates the flow to jump to a specific table if policyRuleConjunction ID is matched. Priority of
-// conjunctionActionFlow is priorityLow.
-func (c *client) conjunctionActionFlow(conjunctionID uint32, tableID binding.TableIDType, nextTable binding.TableIDType) binding.Flow {
-	return c.pipeline[tableID].BuildFlow(priorityLow).MatchProtocol(binding.ProtocolIP).
-		MatchConjID(conjunctionID).
-		Action().GotoTable(nextTable).
-		Cookie(c.cookieAllocator.Request(cookie.Policy).Raw()).
-		Done()
+// conjunctionActionFlows generates the flows to jump to a specific table if policyRuleConjunction ID is matched.
+// Priority of conjunctionActionFlows is priorityLow for K8s NetworkPolicy rules, and the priority of the rule for
+// ClusterNetworkPolicy rules. If enableLogging is true, the packets are also sent to the controller with
+// CustomReasonLogging, so that the agent can log the connections. The flows are identified by the conjunction ID in
+// their cookie, so that the number of new connections allowed by the rule can be read from their packet counts.
+func (c *client) conjunctionActionFlows(conjunctionID uint32, tableID binding.TableIDType, nextTable binding.TableIDType, priority *uint16, enableLogging bool) (flows []binding.Flow) {
+	ofPriority := priorityLow
+	if priority != nil {
+		ofPriority = *priority
+	}
+	for _, proto := range c.ipProtocols {
+		fb := c.pipeline[tableID].BuildFlow(ofPriority).MatchProtocol(proto).
+			MatchConjID(conjunctionID).
+			Action().LoadRegRange(int(conjunctionReg(tableID)), conjunctionID, binding.Range{0, 31})
+		if enableLogging {
+			fb = fb.Action().LoadRegRange(int(marksReg), CustomReasonLogging, customReasonMarkRange).
+				Action().SendToController(uint8(PacketInReasonNP))
+		}
+		flows = append(flows, fb.Action().GotoTable(nextTable).
+			Cookie(c.cookieAllocator.RequestWithObjectID(cookie.Policy, conjunctionID).Raw()).
+			Done())
+	}
+	return flows
+}
+
+// conjunctionReg returns the register used to store the conjunction ID of the rule matched in the provided table.
+func conjunctionReg(tableID binding.TableIDType) regType {
+	if tableID == egressRuleTable || tableID == cnpEgressRuleTable {
+		return egressReg
+	}
+	return ingressReg
+}
+
+// conjunctionActionDropFlows generates the flows to drop packets if policyRuleConjunction ID is matched. They are used by
+// ClusterNetworkPolicy rules whose action is Drop or Reject, and their priority is the priority of the rule. If
+// enableLogging or reject is true, the conjunction ID is stored in the register of the table and the packets are sent
+// to the controller with the corresponding custom reasons instead of being dropped directly, so that the agent can log
+// the connections and send the replies rejecting them. As the controller action is the only action, the packets are
+// dropped by OVS afterwards. The flows are identified by the conjunction ID in their cookie, so that the packets
+// dropped by the rule can be counted.
+func (c *client) conjunctionActionDropFlows(conjunctionID uint32, tableID binding.TableIDType, priority *uint16, enableLogging, reject bool) (flows []binding.Flow) {
+	ofPriority := priorityLow
+	if priority != nil {
+		ofPriority = *priority
+	}
+	var customReasons uint32
+	if enableLogging {
+		customReasons |= CustomReasonLogging
+	}
+	if reject {
+		customReasons |= CustomReasonReject
+	}
+	for _, proto := range c.ipProtocols {
+		fb := c.pipeline[tableID].BuildFlow(ofPriority).MatchProtocol(proto).
+			MatchConjID(conjunctionID)
+		if customReasons != 0 {
+			fb = fb.Action().LoadRegRange(int(conjunctionReg(tableID)), conjunctionID, binding.Range{0, 31}).
+				Action().LoadRegRange(int(marksReg), customReasons, customReasonMarkRange).
+				Action().SendToController(uint8(PacketInReasonNP))
+		} else {
+			fb = fb.Action().Drop()
+		}
+		flows = append(flows, fb.Cookie(c.cookieAllocator.RequestWithObjectID(cookie.Policy, conjunctionID).Raw()).
+			Done())
+	}
+	return flows
 }
 
 func (c *client) Disconnect() error {
@@ -626,21 +1197,70 @@ func (c *client) establishedConnectionFlows(category cookie.Category) (flows []b
 	// matching the NetworkPolicy rules. Packets in the established connections need not to be checked with the
 	// egressRuleTable or the egressDropTable.
 	egressDropTable := c.pipeline[egressDefaultTable]
-	egressEstFlow := c.pipeline[egressRuleTable].BuildFlow(priorityHigh).MatchProtocol(binding.ProtocolIP).
-		MatchCTStateNew(false).MatchCTStateEst(true).
-		Action().GotoTable(egressDropTable.GetNext()).
-		Cookie(c.cookieAllocator.Request(category).Raw()).
-		Done()
 	// ingressDropTable checks the destination address of packets, and drops packets sent to the AppliedToGroup but not
 	// matching the NetworkPolicy rules. Packets in the established connections need not to be checked with the
 	// ingressRuleTable or ingressDropTable.
 	ingressDropTable := c.pipeline[ingressDefaultTable]
-	ingressEstFlow := c.pipeline[ingressRuleTable].BuildFlow(priorityHigh).MatchProtocol(binding.ProtocolIP).
-		MatchCTStateNew(false).MatchCTStateEst(true).
-		Action().GotoTable(ingressDropTable.GetNext()).
-		Cookie(c.cookieAllocator.Request(category).Raw()).
-		Done()
-	return []binding.Flow{egressEstFlow, ingressEstFlow}
+	cnpEgressTable, cnpEgressOK := c.pipeline[cnpEgressRuleTable]
+	cnpIngressTable, cnpIngressOK := c.pipeline[cnpIngressRuleTable]
+	for _, proto := range c.ipProtocols {
+		egressEstFlow := c.restorePolicyRuleFromLabel(c.pipeline[egressRuleTable].BuildFlow(priorityHigh).MatchProtocol(proto).
+			MatchCTStateNew(false).MatchCTStateEst(true), egressReg, egressLabelRange).
+			Action().GotoTable(egressDropTable.GetNext()).
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done()
+		ingressEstFlow := c.restorePolicyRuleFromLabel(c.pipeline[ingressRuleTable].BuildFlow(priorityHigh).MatchProtocol(proto).
+			MatchCTStateNew(false).MatchCTStateEst(true), ingressReg, ingressLabelRange).
+			Action().GotoTable(ingressDropTable.GetNext()).
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done()
+		flows = append(flows, egressEstFlow, ingressEstFlow)
+		// Packets in the established connections need not to be checked with the ClusterNetworkPolicy rules either.
+		if cnpEgressOK {
+			flows = append(flows, c.restorePolicyRuleFromLabel(cnpEgressTable.BuildFlow(priorityTopCNP).MatchProtocol(proto).
+				MatchCTStateNew(false).MatchCTStateEst(true), egressReg, egressLabelRange).
+				Action().GotoTable(egressDropTable.GetNext()).
+				Cookie(c.cookieAllocator.Request(category).Raw()).
+				Done())
+		}
+		if cnpIngressOK {
+			flows = append(flows, c.restorePolicyRuleFromLabel(cnpIngressTable.BuildFlow(priorityTopCNP).MatchProtocol(proto).
+				MatchCTStateNew(false).MatchCTStateEst(true), ingressReg, ingressLabelRange).
+				Action().GotoTable(ingressDropTable.GetNext()).
+				Cookie(c.cookieAllocator.Request(category).Raw()).
+				Done())
+		}
+	}
+	return flows
+}
+
+// restorePolicyRuleFromLabel adds the action which loads the conjunction ID of the NetworkPolicy rule that allowed an
+// established connection from the provided range of ct_label to the provided register, if NetworkPolicyStats is enabled.
+func (c *client) restorePolicyRuleFromLabel(fb binding.FlowBuilder, reg regType, labelRange binding.Range) binding.FlowBuilder {
+	if !c.enablePolicyStats {
+		return fb
+	}
+	return fb.Action().MoveRange(binding.NxmFieldCtLabel, reg.nxm(), labelRange, binding.Range{0, 31})
+}
+
+// policyMetricFlows generates the flows which count the packets allowed by the NetworkPolicy rule with the provided
+// conjunction ID in the metric table following the default table of the rule. The packets of new connections match
+// the register loaded by the conjunction action flows, and the packets of established connections match the register
+// restored from ct_label.
+func (c *client) policyMetricFlows(conjunctionID uint32, dropTable binding.Table) (flows []binding.Flow) {
+	metricTable := c.pipeline[dropTable.GetNext()]
+	reg := ingressReg
+	if dropTable.GetID() == egressDefaultTable {
+		reg = egressReg
+	}
+	for _, proto := range c.ipProtocols {
+		flows = append(flows, metricTable.BuildFlow(priorityNormal).MatchProtocol(proto).
+			MatchReg(int(reg), conjunctionID).
+			Action().GotoTable(metricTable.GetNext()).
+			Cookie(c.cookieAllocator.RequestWithObjectID(cookie.PolicyMetric, conjunctionID).Raw()).
+			Done())
+	}
+	return flows
 }
 
 func (c *client) addFlowMatch(fb binding.FlowBuilder, matchType int, matchValue interface{}) binding.FlowBuilder {
@@ -659,11 +1279,59 @@ func (c *client) addFlowMatch(fb binding.FlowBuilder, matchType int, matchValue
 	case MatchSrcOFPort:
 		fb = fb.MatchProtocol(binding.ProtocolIP).MatchInPort(uint32(matchValue.(int32)))
 	case MatchTCPDstPort:
-		fb = fb.MatchProtocol(binding.ProtocolTCP).MatchTCPDstPort(matchValue.(uint16))
+		fb = fb.MatchProtocol(binding.ProtocolTCP)
+		fb = addDstPortMatch(fb, matchValue, fb.MatchTCPDstPort)
 	case MatchUDPDstPort:
-		fb = fb.MatchProtocol(binding.ProtocolUDP).MatchUDPDstPort(matchValue.(uint16))
+		fb = fb.MatchProtocol(binding.ProtocolUDP)
+		fb = addDstPortMatch(fb, matchValue, fb.MatchUDPDstPort)
 	case MatchSCTPDstPort:
-		fb = fb.MatchProtocol(binding.ProtocolSCTP).MatchSCTPDstPort(matchValue.(uint16))
+		fb = fb.MatchProtocol(binding.ProtocolSCTP)
+		fb = addDstPortMatch(fb, matchValue, fb.MatchSCTPDstPort)
+	case MatchDstIPv6:
+		fb = fb.MatchProtocol(binding.ProtocolIPv6).MatchDstIP(matchValue.(net.IP))
+	case MatchDstIPNetv6:
+		fb = fb.MatchProtocol(binding.ProtocolIPv6).MatchDstIPNet(matchValue.(net.IPNet))
+	case MatchSrcIPv6:
+		fb = fb.MatchProtocol(binding.ProtocolIPv6).MatchSrcIP(matchValue.(net.IP))
+	case MatchSrcIPNetv6:
+		fb = fb.MatchProtocol(binding.ProtocolIPv6).MatchSrcIPNet(matchValue.(net.IPNet))
+	case MatchDstOFPortv6:
+		fb = fb.MatchProtocol(binding.ProtocolIPv6).MatchReg(int(portCacheReg), uint32(matchValue.(int32)))
+	case MatchSrcOFPortv6:
+		fb = fb.MatchProtocol(binding.ProtocolIPv6).MatchInPort(uint32(matchValue.(int32)))
+	case MatchTCPv6DstPort:
+		fb = fb.MatchProtocol(binding.ProtocolTCPv6)
+		fb = addDstPortMatch(fb, matchValue, fb.MatchTCPDstPort)
+	case MatchUDPv6DstPort:
+		fb = fb.MatchProtocol(binding.ProtocolUDPv6)
+		fb = addDstPortMatch(fb, matchValue, fb.MatchUDPDstPort)
+	case MatchSCTPv6DstPort:
+		fb = fb.MatchProtocol(binding.ProtocolSCTPv6)
+		fb = addDstPortMatch(fb, matchValue, fb.MatchSCTPDstPort)
+	case MatchICMP:
+		fb = addICMPMatch(fb.MatchProtocol(binding.ProtocolICMP), matchValue.(icmpMatch))
+	case MatchICMPv6:
+		fb = addICMPMatch(fb.MatchProtocol(binding.ProtocolICMPv6), matchValue.(icmpMatch))
+	}
+	return fb
+}
+
+// addDstPortMatch adds the match on the transport destination port, which is either a port number matched with
+// matchPort, or a portMask generated from a port range.
+func addDstPortMatch(fb binding.FlowBuilder, matchValue interface{}, matchPort func(port uint16) binding.FlowBuilder) binding.FlowBuilder {
+	if m, ok := matchValue.(portMask); ok {
+		return fb.MatchDstPortMask(m.port, m.mask)
+	}
+	return matchPort(matchValue.(uint16))
+}
+
+// addICMPMatch adds the matches on the ICMP type and code which are set in the provided icmpMatch.
+func addICMPMatch(fb binding.FlowBuilder, m icmpMatch) binding.FlowBuilder {
+	if m.icmpType != nil {
+		fb = fb.MatchICMPType(*m.icmpType)
+	}
+	if m.icmpCode != nil {
+		fb = fb.MatchICMPCode(*m.icmpCode)
 	}
 	return fb
 }
@@ -678,9 +1346,15 @@ func (c *client) conjunctionExceptionFlow(conjunctionID uint32, tableID binding.
 		Done()
 }
 
-// conjunctiveMatchFlow generates the flow to set conjunctive actions if the match condition is matched.
-func (c *client) conjunctiveMatchFlow(tableID binding.TableIDType, matchKey int, matchValue interface{}, actions ...*conjunctiveAction) binding.Flow {
-	fb := c.pipeline[tableID].BuildFlow(priorityNormal)
+// conjunctiveMatchFlow generates the flow to set conjunctive actions if the match condition is matched. Priority of
+// conjunctiveMatchFlow is priorityNormal for K8s NetworkPolicy rules, and the priority of the rule for
+// ClusterNetworkPolicy rules.
+func (c *client) conjunctiveMatchFlow(tableID binding.TableIDType, matchKey int, matchValue interface{}, priority *uint16, actions ...*conjunctiveAction) binding.Flow {
+	ofPriority := priorityNormal
+	if priority != nil {
+		ofPriority = *priority
+	}
+	fb := c.pipeline[tableID].BuildFlow(ofPriority)
 	fb = c.addFlowMatch(fb, matchKey, matchValue)
 	for _, act := range actions {
 		fb.Action().Conjunction(act.conjID, act.clauseID, act.nClause)
@@ -688,28 +1362,112 @@ func (c *client) conjunctiveMatchFlow(tableID binding.TableIDType, matchKey int,
 	return fb.Cookie(c.cookieAllocator.Request(cookie.Policy).Raw()).Done()
 }
 
-// defaultDropFlow generates the flow to drop packets if the match condition is matched.
-func (c *client) defaultDropFlow(tableID binding.TableIDType, matchKey int, matchValue interface{}) binding.Flow {
+// defaultDropFlow generates the flow to drop packets if the match condition is matched. If enableLogging is true, the
+// packets are sent to the controller with CustomReasonLogging instead, which logs and drops them.
+func (c *client) defaultDropFlow(tableID binding.TableIDType, matchKey int, matchValue interface{}, enableLogging bool) binding.Flow {
 	fb := c.pipeline[tableID].BuildFlow(priorityNormal)
-	return c.addFlowMatch(fb, matchKey, matchValue).
-		Action().Drop().
-		Cookie(c.cookieAllocator.Request(cookie.Default).Raw()).
+	fb = c.addFlowMatch(fb, matchKey, matchValue)
+	if enableLogging {
+		fb = fb.Action().LoadRegRange(int(marksReg), CustomReasonLogging, customReasonMarkRange).
+			Action().SendToController(uint8(PacketInReasonNP))
+	} else {
+		fb = fb.Action().Drop()
+	}
+	return fb.Cookie(c.cookieAllocator.Request(cookie.Default).Raw()).
 		Done()
 }
 
-// localProbeFlow generates the flow to forward packets to conntrackCommitTable. The packets are sent from Node to probe the liveness/readiness of local Pods.
-func (c *client) localProbeFlow(localGatewayIP net.IP, category cookie.Category) binding.Flow {
-	return c.pipeline[ingressRuleTable].BuildFlow(priorityHigh).
+// dnsInterceptFlows generates the flows which send the DNS responses to local Pods to the controller with
+// CustomReasonDNS instead of forwarding them, so that the agent can learn the addresses of the FQDNs used in
+// NetworkPolicy rules before the Pods receive the responses. The agent forwards the responses to the Pods itself once
+// the rules are updated. The responses sent over UDP and TCP are intercepted for each IP protocol enabled on the Node.
+func (c *client) dnsInterceptFlows(category cookie.Category) ([]binding.Flow, error) {
+	cnpIngressTable, ok := c.pipeline[cnpIngressRuleTable]
+	if !ok {
+		return nil, fmt.Errorf("table %d is not initialized", cnpIngressRuleTable)
+	}
+	var flows []binding.Flow
+	for _, proto := range c.ipProtocols {
+		udpProtocol, tcpProtocol := binding.ProtocolUDP, binding.ProtocolTCP
+		if proto == binding.ProtocolIPv6 {
+			udpProtocol, tcpProtocol = binding.ProtocolUDPv6, binding.ProtocolTCPv6
+		}
+		flows = append(flows,
+			cnpIngressTable.BuildFlow(priorityDNSIntercept).
+				MatchProtocol(udpProtocol).
+				MatchUDPSrcPort(dnsPort).
+				MatchCTStateTrk(true).MatchCTStateRpl(true).
+				MatchRegRange(int(marksReg), portFoundMark, ofPortMarkRange).
+				Action().LoadRegRange(int(marksReg), CustomReasonDNS, customReasonMarkRange).
+				Action().SendToController(uint8(PacketInReasonNP)).
+				Cookie(c.cookieAllocator.Request(category).Raw()).
+				Done(),
+			cnpIngressTable.BuildFlow(priorityDNSIntercept).
+				MatchProtocol(tcpProtocol).
+				MatchTCPSrcPort(dnsPort).
+				MatchCTStateTrk(true).MatchCTStateRpl(true).
+				MatchRegRange(int(marksReg), portFoundMark, ofPortMarkRange).
+				Action().LoadRegRange(int(marksReg), CustomReasonDNS, customReasonMarkRange).
+				Action().SendToController(uint8(PacketInReasonNP)).
+				Cookie(c.cookieAllocator.Request(category).Raw()).
+				Done())
+	}
+	return flows, nil
+}
+
+// localProbeFlows generates the flows to forward packets to conntrackCommitTable. The packets are sent from Node to probe the liveness/readiness of local Pods.
+func (c *client) localProbeFlows(localGatewayIPs []net.IP, category cookie.Category) (flows []binding.Flow) {
+	cnpIngressTable, cnpIngressOK := c.pipeline[cnpIngressRuleTable]
+	for _, ip := range localGatewayIPs {
+		ipProtocol := getIPProtocol(ip)
+		flows = append(flows, c.pipeline[ingressRuleTable].BuildFlow(priorityHigh).
+			MatchProtocol(ipProtocol).
+			MatchSrcIP(ip).
+			Action().GotoTable(conntrackCommitTable).
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done())
+		// The probe packets must not be dropped by ClusterNetworkPolicy rules either.
+		if cnpIngressOK {
+			flows = append(flows, cnpIngressTable.BuildFlow(priorityTopCNP).
+				MatchProtocol(ipProtocol).
+				MatchSrcIP(ip).
+				Action().GotoTable(conntrackCommitTable).
+				Cookie(c.cookieAllocator.Request(category).Raw()).
+				Done())
+		}
+	}
+	return flows
+}
+
+// traceflowOutputFlow generates the flow to both output the packets tagged with the provided dataplaneTag and send
+// them to the controller, so that the agent can report where the packets are sent to.
+func (c *client) traceflowOutputFlow(dataplaneTag uint8) binding.Flow {
+	return c.pipeline[l2ForwardingOutTable].BuildFlow(priorityNormal+2).
 		MatchProtocol(binding.ProtocolIP).
-		MatchSrcIP(localGatewayIP).
-		Action().GotoTable(conntrackCommitTable).
-		Cookie(c.cookieAllocator.Request(category).Raw()).
+		MatchIPDscp(dataplaneTag).
+		MatchRegRange(int(marksReg), portFoundMark, ofPortMarkRange).
+		Action().OutputRegRange(int(portCacheReg), ofPortRegRange).
+		Action().SendToController(uint8(PacketInReasonTF)).
+		Cookie(c.cookieAllocator.Request(cookie.Traceflow).Raw()).
+		Done()
+}
+
+// traceflowDropFlow generates the flow to send the packets tagged with the provided dataplaneTag to the controller
+// instead of dropping them with the provided drop flow. If conjunctionID is not 0, it is stored in the register
+// of the table, so that the agent can report the rule which dropped the packets.
+func (c *client) traceflowDropFlow(dropFlow binding.Flow, tableID binding.TableIDType, priority uint16, conjunctionID uint32, dataplaneTag uint8) binding.Flow {
+	fb := dropFlow.CopyToBuilder(priority).MatchIPDscp(dataplaneTag)
+	if conjunctionID != 0 {
+		fb = fb.Action().LoadRegRange(int(conjunctionReg(tableID)), conjunctionID, binding.Range{0, 31})
+	}
+	return fb.Action().SendToController(uint8(PacketInReasonTF)).
+		Cookie(c.cookieAllocator.Request(cookie.Traceflow).Raw()).
 		Done()
 }
 
 func (c *client) bridgeAndUplinkFlows(uplinkOfport uint32, bridgeLocalPort uint32, nodeIP net.IP, localSubnet net.IPNet, category cookie.Category) []binding.Flow {
 	snatIPRange := &binding.IPRange{nodeIP, nodeIP}
-	vMACInt, _ := strconv.ParseUint(strings.Replace(globalVirtualMAC.String(), ":", "", -1), 16, 64)
+	vMACInt, _ := strconv.ParseUint(strings.Replace(GlobalVirtualMAC.String(), ":", "", -1), 16, 64)
 	flows := []binding.Flow{
 		// Resubmit the packet from the uplink interface to conntrackTable.
 		c.pipeline[classifierTable].BuildFlow(priorityNormal).
@@ -725,7 +1483,7 @@ func (c *client) bridgeAndUplinkFlows(uplinkOfport uint32, bridgeLocalPort uint3
 			MatchProtocol(binding.ProtocolIP).
 			MatchInPort(bridgeLocalPort).
 			MatchDstIPNet(localSubnet).
-			Action().SetDstMAC(globalVirtualMAC).
+			Action().SetDstMAC(GlobalVirtualMAC).
 			Action().ResubmitToTable(conntrackTable).
 			Done(),
 		// Enforce IP packet into the conntrack zone with SNAT. If the connection is SNATed, the reply packet should use
@@ -822,32 +1580,205 @@ func (c *client) l3ToExternalFlows(nodeIP net.IP, localSubnet net.IPNet, outputP
 	return flows
 }
 
+// snatCommonFlows generates the flows which send the packets from local Pods and remote Pods to external destinations
+// to snatTable, where the packets from the Pods selected by an Egress are SNATed or tunneled to the Node hosting the
+// egress IP. The packets to the local Node and gateway are never SNATed. Only IPv4 is supported.
+func (c *client) snatCommonFlows(nodeIP net.IP, localGatewayIP net.IP, localGatewayMAC net.HardwareAddr, category cookie.Category) []binding.Flow {
+	l3FwdTable := c.pipeline[l3ForwardingTable]
+	snatTable := c.pipeline[snatTable]
+	flows := []binding.Flow{
+		// The packets from local Pods to external destinations are sent to the local gateway and are not matched
+		// by any other flow in l3ForwardingTable.
+		l3FwdTable.BuildFlow(prioritySNAT).MatchProtocol(binding.ProtocolIP).
+			MatchRegRange(int(marksReg), markTrafficFromLocal, binding.Range{0, 15}).
+			MatchDstMAC(localGatewayMAC).
+			Action().GotoTable(snatTable.GetID()).
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done(),
+		// The packets from remote Pods tunneled to this Node because it hosts their egress IP.
+		l3FwdTable.BuildFlow(prioritySNAT).MatchProtocol(binding.ProtocolIP).
+			MatchRegRange(int(marksReg), markTrafficFromTunnel, binding.Range{0, 15}).
+			MatchDstMAC(GlobalVirtualMAC).
+			Action().GotoTable(snatTable.GetID()).
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done(),
+		snatTable.BuildFlow(priorityHigh).MatchProtocol(binding.ProtocolIP).
+			MatchDstIP(nodeIP).
+			Action().GotoTable(snatTable.GetNext()).
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done(),
+		// The source of the packets of the established SNAT'd connections has already been translated in
+		// conntrackTable, so that they don't match the flows of the Pods anymore. The packets from remote Pods
+		// must still be sent to the local gateway.
+		snatTable.BuildFlow(priorityHigh).MatchProtocol(binding.ProtocolIP).
+			MatchCTMark(snatCTMark).
+			MatchCTStateNew(false).MatchCTStateTrk(true).
+			Action().SetDstMAC(localGatewayMAC).
+			Action().GotoTable(snatTable.GetNext()).
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done(),
+	}
+	if localGatewayIP != nil {
+		flows = append(flows, snatTable.BuildFlow(priorityHigh).MatchProtocol(binding.ProtocolIP).
+			MatchDstIP(localGatewayIP).
+			Action().GotoTable(snatTable.GetNext()).
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done())
+	}
+	if c.enableProxy {
+		// The reply packets of the SNAT'd connections are sent back to OVS by the host with the global virtual MAC,
+		// and need the MAC rewrite if they are forwarded to a local Pod.
+		connectionTrackStateTable := c.pipeline[conntrackStateTable]
+		flows = append(flows, connectionTrackStateTable.BuildFlow(priorityHigh).MatchProtocol(binding.ProtocolIP).
+			MatchRegRange(int(marksReg), markTrafficFromGateway, binding.Range{0, 15}).
+			MatchCTMark(snatCTMark).
+			MatchCTStateNew(false).MatchCTStateTrk(true).
+			Action().LoadRegRange(int(marksReg), macRewriteMark, macRewriteMarkRange).
+			Action().GotoTable(connectionTrackStateTable.GetNext()).
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done())
+	}
+	return flows
+}
+
+// snatServiceCIDRFlow generates the flow which prevents the packets sent to the Service CIDR from being SNATed.
+func (c *client) snatServiceCIDRFlow(serviceNet *net.IPNet, category cookie.Category) binding.Flow {
+	snatTable := c.pipeline[snatTable]
+	return snatTable.BuildFlow(priorityHigh).MatchProtocol(binding.ProtocolIP).
+		MatchDstIPNet(*serviceNet).
+		Action().GotoTable(snatTable.GetNext()).
+		Cookie(c.cookieAllocator.Request(category).Raw()).
+		Done()
+}
+
+// snatIPFlow generates the flow which commits the new connections marked with snatMark and SNATs them to snatIP.
+func (c *client) snatIPFlow(snatIP net.IP, snatMark uint32, category cookie.Category) binding.Flow {
+	ctCommitTable := c.pipeline[conntrackCommitTable]
+	ctAction := ctCommitTable.BuildFlow(priorityHigh).MatchProtocol(binding.ProtocolIP).
+		MatchCTStateNew(true).MatchCTStateTrk(true).
+		MatchReg(int(snatReg), snatMark).
+		Action().CT(true, ctCommitTable.GetNext(), ctZone).
+		SNAT(&binding.IPRange{StartIP: snatIP, EndIP: snatIP}, nil).
+		LoadToMark(snatCTMark)
+	return c.commitPolicyRulesToLabel(ctAction).CTDone().
+		Cookie(c.cookieAllocator.Request(category).Raw()).
+		Done()
+}
+
+// podSNATFlow generates the flow which marks the packets from a Pod with snatMark, so that they are SNATed to the
+// egress IP hosted by this Node. The Pod can be a local Pod or a remote Pod, whose packets are received from the
+// tunnel, and the packets are sent to the local gateway in both cases.
+func (c *client) podSNATFlow(podIP net.IP, snatMark uint32, localGatewayMAC net.HardwareAddr, category cookie.Category) binding.Flow {
+	snatTable := c.pipeline[snatTable]
+	return snatTable.BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIP).
+		MatchSrcIP(podIP).
+		Action().SetDstMAC(localGatewayMAC).
+		Action().LoadRegRange(int(snatReg), snatMark, binding.Range{0, 31}).
+		Action().GotoTable(snatTable.GetNext()).
+		Cookie(c.cookieAllocator.Request(category).Raw()).
+		Done()
+}
+
+// podSNATFlowToRemote generates the flow which tunnels the packets from a local Pod to the remote Node hosting its
+// egress IP.
+func (c *client) podSNATFlowToRemote(podIP net.IP, tunnelPeer net.IP, localGatewayMAC net.HardwareAddr, tunOFPort uint32, category cookie.Category) binding.Flow {
+	return c.pipeline[snatTable].BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIP).
+		MatchSrcIP(podIP).
+		Action().DecTTL().
+		Action().SetSrcMAC(localGatewayMAC).
+		Action().SetDstMAC(GlobalVirtualMAC).
+		Action().LoadRegRange(int(portCacheReg), tunOFPort, ofPortRegRange).
+		Action().LoadRegRange(int(marksReg), portFoundMark, ofPortMarkRange).
+		Action().SetTunnelDst(tunnelPeer).
+		Action().GotoTable(conntrackCommitTable).
+		Cookie(c.cookieAllocator.Request(category).Raw()).
+		Done()
+}
+
+// isIPv6Enabled returns true if IPv6 is enabled on the Node.
+func (c *client) isIPv6Enabled() bool {
+	for _, proto := range c.ipProtocols {
+		if proto == binding.ProtocolIPv6 {
+			return true
+		}
+	}
+	return false
+}
+
+// getIPProtocol returns the IP protocol (IPv4 or IPv6) of the provided IP address.
+func getIPProtocol(ip net.IP) binding.Protocol {
+	if ip.To4() != nil {
+		return binding.ProtocolIP
+	}
+	return binding.ProtocolIPv6
+}
+
 // NewClient is the constructor of the Client interface.
 func NewClient(bridgeName, mgmtAddr string) Client {
 	bridge := binding.NewOFBridge(bridgeName, mgmtAddr)
+	// The ClusterNetworkPolicy tables are inserted before the K8s NetworkPolicy
+	// tables only if the feature is enabled.
+	egressEntryTable, ingressEntryTable := egressRuleTable, ingressRuleTable
+	cnpEnabled := features.DefaultFeatureGate.Enabled(features.ClusterNetworkPolicy)
+	if cnpEnabled {
+		egressEntryTable, ingressEntryTable = cnpEgressRuleTable, cnpIngressRuleTable
+	}
+	// The metric tables are inserted after the default tables only if the feature is enabled, so that only the
+	// packets allowed by the NetworkPolicy rules are counted.
+	egressDefaultNext, ingressDefaultNext := l3ForwardingTable, conntrackCommitTable
+	policyStatsEnabled := features.DefaultFeatureGate.Enabled(features.NetworkPolicyStats)
+	if policyStatsEnabled {
+		egressDefaultNext, ingressDefaultNext = egressMetricTable, ingressMetricTable
+	}
 	c := &client{
 		bridge: bridge,
 		pipeline: map[binding.TableIDType]binding.Table{
 			classifierTable:       bridge.CreateTable(classifierTable, spoofGuardTable, binding.TableMissActionDrop),
 			spoofGuardTable:       bridge.CreateTable(spoofGuardTable, conntrackTable, binding.TableMissActionDrop),
+			ipv6Table:             bridge.CreateTable(ipv6Table, conntrackTable, binding.TableMissActionNext),
 			conntrackTable:        bridge.CreateTable(conntrackTable, conntrackStateTable, binding.TableMissActionNone),
 			conntrackStateTable:   bridge.CreateTable(conntrackStateTable, dnatTable, binding.TableMissActionNext),
-			dnatTable:             bridge.CreateTable(dnatTable, egressRuleTable, binding.TableMissActionNext),
+			dnatTable:             bridge.CreateTable(dnatTable, egressEntryTable, binding.TableMissActionNext),
 			egressRuleTable:       bridge.CreateTable(egressRuleTable, egressDefaultTable, binding.TableMissActionNext),
-			egressDefaultTable:    bridge.CreateTable(egressDefaultTable, l3ForwardingTable, binding.TableMissActionNext),
+			egressDefaultTable:    bridge.CreateTable(egressDefaultTable, egressDefaultNext, binding.TableMissActionNext),
 			l3ForwardingTable:     bridge.CreateTable(l3ForwardingTable, l2ForwardingCalcTable, binding.TableMissActionNext),
-			l2ForwardingCalcTable: bridge.CreateTable(l2ForwardingCalcTable, ingressRuleTable, binding.TableMissActionNext),
+			l2ForwardingCalcTable: bridge.CreateTable(l2ForwardingCalcTable, ingressEntryTable, binding.TableMissActionNext),
 			arpResponderTable:     bridge.CreateTable(arpResponderTable, binding.LastTableID, binding.TableMissActionDrop),
 			ingressRuleTable:      bridge.CreateTable(ingressRuleTable, ingressDefaultTable, binding.TableMissActionNext),
-			ingressDefaultTable:   bridge.CreateTable(ingressDefaultTable, conntrackCommitTable, binding.TableMissActionNext),
+			ingressDefaultTable:   bridge.CreateTable(ingressDefaultTable, ingressDefaultNext, binding.TableMissActionNext),
 			conntrackCommitTable:  bridge.CreateTable(conntrackCommitTable, l2ForwardingOutTable, binding.TableMissActionNext),
 			l2ForwardingOutTable:  bridge.CreateTable(l2ForwardingOutTable, binding.LastTableID, binding.TableMissActionDrop),
 		},
 		nodeFlowCache:            newFlowCategoryCache(),
 		podFlowCache:             newFlowCategoryCache(),
+		tfFlowCache:              newFlowCategoryCache(),
+		dnsFlowCache:             newFlowCategoryCache(),
+		serviceFlowCache:         newFlowCategoryCache(),
+		snatFlowCache:            newFlowCategoryCache(),
+		enableProxy:              features.DefaultFeatureGate.Enabled(features.AntreaProxy),
+		enableEgress:             features.DefaultFeatureGate.Enabled(features.Egress),
+		enablePolicyStats:        policyStatsEnabled,
 		policyCache:              sync.Map{},
 		globalConjMatchFlowCache: map[string]*conjMatchFlowContext{},
 	}
+	if cnpEnabled {
+		c.pipeline[cnpEgressRuleTable] = bridge.CreateTable(cnpEgressRuleTable, egressRuleTable, binding.TableMissActionNext)
+		c.pipeline[cnpIngressRuleTable] = bridge.CreateTable(cnpIngressRuleTable, ingressRuleTable, binding.TableMissActionNext)
+	}
+	if c.enableProxy {
+		// sessionAffinityTable is only reached by resubmitting the packets to it, and the packets which match no
+		// flow in it continue to be processed by the resubmitting flow or group.
+		c.pipeline[sessionAffinityTable] = bridge.CreateTable(sessionAffinityTable, binding.LastTableID, binding.TableMissActionNone)
+	}
+	if policyStatsEnabled {
+		c.pipeline[egressMetricTable] = bridge.CreateTable(egressMetricTable, l3ForwardingTable, binding.TableMissActionNext)
+		c.pipeline[ingressMetricTable] = bridge.CreateTable(ingressMetricTable, conntrackCommitTable, binding.TableMissActionNext)
+	}
+	if c.enableEgress {
+		// snatTable is only reached by the packets sent to external destinations, which are sent to it by
+		// l3ForwardingTable.
+		c.pipeline[snatTable] = bridge.CreateTable(snatTable, l2ForwardingCalcTable, binding.TableMissActionNext)
+	}
 	c.ofEntryOperations = c
 	return c
 }
@@ -0,0 +1,442 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+	"github.com/vmware-tanzu/antrea/pkg/agent/types"
+	binding "github.com/vmware-tanzu/antrea/pkg/ovs/openflow"
+)
+
+const (
+	controllerName = "AntreaAgentProxy"
+	// Interval of reprocessing every Service.
+	resyncPeriod = 60 * time.Second
+	// How long to wait before retrying the processing of a Service change.
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = 300 * time.Second
+	// The Endpoints are shared by the Services (e.g. two Services selecting the same Pods), and the Endpoint flows
+	// are reference-counted by the Proxier. A single worker processes the Service changes so that the references
+	// are updated consistently.
+	defaultWorkers = 1
+)
+
+// servicePortInfo is the state of a Service port installed by the Proxier.
+type servicePortInfo struct {
+	clusterIP net.IP
+	port      uint16
+	protocol  binding.Protocol
+	groupID   binding.GroupIDType
+	// affinityTimeout is the timeout in seconds of the ClientIP session affinity of the Service, or 0 if the Service
+	// has no session affinity.
+	affinityTimeout uint16
+	// endpoints is a map from the Endpoint string ("<IP>:<port>") to the Endpoint selected by the group.
+	endpoints map[string]types.Endpoint
+}
+
+// Proxier watches the Services and Endpoints, and programs the OVS flows and groups which load-balance the
+// connections from the local Pods to the ClusterIPs of the Services among their Endpoints, without the help of
+// kube-proxy. Only IPv4 Services of protocol TCP, UDP and SCTP are supported. The traffic to the other Services is
+// still sent to the host gateway. ClientIP session affinity is supported for the TCP and UDP Service ports.
+type Proxier struct {
+	ofClient              openflow.Client
+	serviceLister         corelisters.ServiceLister
+	serviceListerSynced   cache.InformerSynced
+	endpointsLister       corelisters.EndpointsLister
+	endpointsListerSynced cache.InformerSynced
+	queue                 workqueue.RateLimitingInterface
+	// installedServices is a map from the Service key ("<Namespace>/<name>") to the installed ports of the Service,
+	// indexed by the port name and protocol. It is accessed only by the worker.
+	installedServices map[string]map[string]*servicePortInfo
+	// endpointReferences counts the installed Service ports which select an Endpoint, indexed by the Endpoint flow
+	// key. It is accessed only by the worker.
+	endpointReferences map[string]int
+	groupIDAllocator   *groupIDAllocator
+}
+
+// NewProxier instantiates a new Proxier which processes the Service and Endpoints events.
+func NewProxier(informerFactory informers.SharedInformerFactory, ofClient openflow.Client) *Proxier {
+	serviceInformer := informerFactory.Core().V1().Services()
+	endpointsInformer := informerFactory.Core().V1().Endpoints()
+	p := &Proxier{
+		ofClient:              ofClient,
+		serviceLister:         serviceInformer.Lister(),
+		serviceListerSynced:   serviceInformer.Informer().HasSynced,
+		endpointsLister:       endpointsInformer.Lister(),
+		endpointsListerSynced: endpointsInformer.Informer().HasSynced,
+		queue:                 workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "proxy"),
+		installedServices:     map[string]map[string]*servicePortInfo{},
+		endpointReferences:    map[string]int{},
+		groupIDAllocator:      newGroupIDAllocator(),
+	}
+	// The Endpoints object of a Service has the same Namespace and name as the Service, so the events of both are
+	// processed with the same key.
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(cur interface{}) {
+			p.enqueue(cur)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			p.enqueue(cur)
+		},
+		DeleteFunc: func(old interface{}) {
+			p.enqueue(old)
+		},
+	}
+	serviceInformer.Informer().AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
+	endpointsInformer.Informer().AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
+	return p
+}
+
+// enqueue adds the key of a Service or an Endpoints object to the work queue. obj could be a *v1.Service, a
+// *v1.Endpoints, or a DeletedFinalStateUnknown item.
+func (p *Proxier) enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		klog.Errorf("Failed to get key of object %v: %v", obj, err)
+		return
+	}
+	p.queue.Add(key)
+}
+
+// Run will create defaultWorkers workers (go routines) which will process the Service events from the work queue.
+func (p *Proxier) Run(stopCh <-chan struct{}) {
+	defer p.queue.ShutDown()
+
+	klog.Infof("Starting %s", controllerName)
+	defer klog.Infof("Shutting down %s", controllerName)
+
+	klog.Infof("Waiting for caches to sync for %s", controllerName)
+	if !cache.WaitForCacheSync(stopCh, p.serviceListerSynced, p.endpointsListerSynced) {
+		klog.Errorf("Unable to sync caches for %s", controllerName)
+		return
+	}
+	klog.Infof("Caches are synced for %s", controllerName)
+
+	for i := 0; i < defaultWorkers; i++ {
+		go wait.Until(p.worker, time.Second, stopCh)
+	}
+	<-stopCh
+}
+
+// worker is a long-running function that will continually call the processNextWorkItem function in order to read
+// and process a message on the work queue.
+func (p *Proxier) worker() {
+	for p.processNextWorkItem() {
+	}
+}
+
+func (p *Proxier) processNextWorkItem() bool {
+	obj, quit := p.queue.Get()
+	if quit {
+		return false
+	}
+	defer p.queue.Done(obj)
+
+	// We expect strings (Service keys) to come off the work queue.
+	if key, ok := obj.(string); !ok {
+		p.queue.Forget(obj)
+		klog.Errorf("Expected string in work queue but got %#v", obj)
+		return true
+	} else if err := p.syncService(key); err == nil {
+		p.queue.Forget(key)
+	} else {
+		// Put the item back on the work queue to handle any transient errors.
+		p.queue.AddRateLimited(key)
+		klog.Errorf("Error syncing Service %s, requeuing. Error: %v", key, err)
+	}
+	return true
+}
+
+// syncService makes the installed flows and groups of the Service match its current ClusterIP, ports and Endpoints.
+func (p *Proxier) syncService(key string) error {
+	startTime := time.Now()
+	defer func() {
+		klog.V(4).Infof("Finished syncing Service %s. (%v)", key, time.Since(startTime))
+	}()
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	desired := map[string]*servicePortInfo{}
+	svc, err := p.serviceLister.Services(namespace).Get(name)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if svc != nil {
+		endpoints, err := p.endpointsLister.Endpoints(namespace).Get(name)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		desired = buildServicePorts(svc, endpoints)
+	}
+
+	installed := p.installedServices[key]
+	for portKey, info := range installed {
+		if desiredInfo, ok := desired[portKey]; !ok || !desiredInfo.clusterIP.Equal(info.clusterIP) || desiredInfo.port != info.port || desiredInfo.affinityTimeout != info.affinityTimeout {
+			if err := p.uninstallServicePort(info); err != nil {
+				return err
+			}
+			delete(installed, portKey)
+		}
+	}
+	for portKey, info := range desired {
+		installedInfo := installed[portKey]
+		if err := p.installServicePort(info, installedInfo); err != nil {
+			return err
+		}
+		if installed == nil {
+			installed = map[string]*servicePortInfo{}
+			p.installedServices[key] = installed
+		}
+		installed[portKey] = info
+	}
+	if len(installed) == 0 {
+		delete(p.installedServices, key)
+	}
+	return nil
+}
+
+// installServicePort installs or updates the group and flows of a Service port. installedInfo is the state of the
+// Service port which has been installed with the same ClusterIP, port and session affinity, or nil if there is none.
+func (p *Proxier) installServicePort(info, installedInfo *servicePortInfo) error {
+	var addedEndpoints, removedEndpoints []types.Endpoint
+	for epKey, endpoint := range info.endpoints {
+		if installedInfo == nil {
+			addedEndpoints = append(addedEndpoints, endpoint)
+		} else if _, ok := installedInfo.endpoints[epKey]; !ok {
+			addedEndpoints = append(addedEndpoints, endpoint)
+		}
+	}
+	if installedInfo != nil {
+		for epKey, endpoint := range installedInfo.endpoints {
+			if _, ok := info.endpoints[epKey]; !ok {
+				removedEndpoints = append(removedEndpoints, endpoint)
+			}
+		}
+		if len(addedEndpoints) == 0 && len(removedEndpoints) == 0 {
+			info.groupID = installedInfo.groupID
+			return nil
+		}
+	}
+
+	// The Endpoint flows must be installed before the group selects the Endpoints.
+	if len(addedEndpoints) > 0 {
+		if err := p.ofClient.InstallEndpointFlows(info.protocol, addedEndpoints); err != nil {
+			return fmt.Errorf("failed to install flows for Endpoints: %v", err)
+		}
+		for _, endpoint := range addedEndpoints {
+			p.endpointReferences[endpointKey(info.protocol, endpoint)]++
+		}
+	}
+
+	if installedInfo != nil {
+		info.groupID = installedInfo.groupID
+	} else {
+		info.groupID = p.groupIDAllocator.allocate()
+	}
+	endpoints := make([]types.Endpoint, 0, len(info.endpoints))
+	for _, endpoint := range info.endpoints {
+		endpoints = append(endpoints, endpoint)
+	}
+	if err := p.ofClient.InstallServiceGroup(info.groupID, info.affinityTimeout != 0, endpoints); err != nil {
+		if installedInfo == nil {
+			p.groupIDAllocator.release(info.groupID)
+		}
+		p.releaseEndpoints(info.protocol, addedEndpoints)
+		return err
+	}
+	if installedInfo == nil {
+		if err := p.ofClient.InstallServiceFlows(info.groupID, info.clusterIP, info.port, info.protocol, info.affinityTimeout); err != nil {
+			if err := p.ofClient.UninstallServiceGroup(info.groupID); err != nil {
+				klog.Errorf("Failed to uninstall group %d after failing to install Service flows: %v", info.groupID, err)
+			}
+			p.groupIDAllocator.release(info.groupID)
+			p.releaseEndpoints(info.protocol, addedEndpoints)
+			return fmt.Errorf("failed to install flows for Service %s: %v", info.clusterIP, err)
+		}
+	} else if info.affinityTimeout != 0 && len(removedEndpoints) > 0 {
+		// The learned flows may select the removed Endpoints, and they are deleted only when the Service flows are
+		// deleted.
+		if err := p.ofClient.UninstallServiceFlows(info.clusterIP, info.port, info.protocol); err != nil {
+			p.releaseEndpoints(info.protocol, addedEndpoints)
+			return fmt.Errorf("failed to uninstall flows for Service %s: %v", info.clusterIP, err)
+		}
+		if err := p.ofClient.InstallServiceFlows(info.groupID, info.clusterIP, info.port, info.protocol, info.affinityTimeout); err != nil {
+			p.releaseEndpoints(info.protocol, addedEndpoints)
+			return fmt.Errorf("failed to install flows for Service %s: %v", info.clusterIP, err)
+		}
+	}
+	return p.releaseEndpoints(info.protocol, removedEndpoints)
+}
+
+// uninstallServicePort removes the flows and group of a Service port, and releases its Endpoints.
+func (p *Proxier) uninstallServicePort(info *servicePortInfo) error {
+	if err := p.ofClient.UninstallServiceFlows(info.clusterIP, info.port, info.protocol); err != nil {
+		return fmt.Errorf("failed to uninstall flows for Service %s: %v", info.clusterIP, err)
+	}
+	if err := p.ofClient.UninstallServiceGroup(info.groupID); err != nil {
+		return err
+	}
+	p.groupIDAllocator.release(info.groupID)
+	endpoints := make([]types.Endpoint, 0, len(info.endpoints))
+	for _, endpoint := range info.endpoints {
+		endpoints = append(endpoints, endpoint)
+	}
+	return p.releaseEndpoints(info.protocol, endpoints)
+}
+
+// releaseEndpoints decrements the references of the provided Endpoints, and uninstalls the flows of the Endpoints
+// which are no longer selected by any Service port.
+func (p *Proxier) releaseEndpoints(protocol binding.Protocol, endpoints []types.Endpoint) error {
+	for _, endpoint := range endpoints {
+		key := endpointKey(protocol, endpoint)
+		if p.endpointReferences[key] > 1 {
+			p.endpointReferences[key]--
+			continue
+		}
+		if err := p.ofClient.UninstallEndpointFlows(protocol, endpoint); err != nil {
+			return fmt.Errorf("failed to uninstall flows for Endpoint %s: %v", endpoint, err)
+		}
+		delete(p.endpointReferences, key)
+	}
+	return nil
+}
+
+func endpointKey(protocol binding.Protocol, endpoint types.Endpoint) string {
+	return fmt.Sprintf("%s/%s", endpoint, protocol)
+}
+
+// buildServicePorts returns the Service ports which can be load-balanced by the Proxier, indexed by the port name and
+// protocol. A Service port is included only if it has at least one ready Endpoint, otherwise the traffic to it is
+// sent to the host gateway.
+func buildServicePorts(svc *corev1.Service, endpoints *corev1.Endpoints) map[string]*servicePortInfo {
+	servicePorts := map[string]*servicePortInfo{}
+	if endpoints == nil || svc.Spec.Type == corev1.ServiceTypeExternalName {
+		return servicePorts
+	}
+	clusterIP := net.ParseIP(svc.Spec.ClusterIP).To4()
+	if clusterIP == nil {
+		// Headless Services and IPv6 Services are not handled.
+		return servicePorts
+	}
+	affinityTimeout := getAffinityTimeout(svc)
+	for _, svcPort := range svc.Spec.Ports {
+		protocol := getOFProtocol(svcPort.Protocol)
+		if protocol == "" {
+			continue
+		}
+		info := &servicePortInfo{
+			clusterIP: clusterIP,
+			port:      uint16(svcPort.Port),
+			protocol:  protocol,
+			endpoints: map[string]types.Endpoint{},
+		}
+		if protocol != binding.ProtocolSCTP {
+			info.affinityTimeout = affinityTimeout
+		}
+		for _, subset := range endpoints.Subsets {
+			for _, epPort := range subset.Ports {
+				if epPort.Name != svcPort.Name || epPort.Protocol != svcPort.Protocol {
+					continue
+				}
+				for _, addr := range subset.Addresses {
+					epIP := net.ParseIP(addr.IP).To4()
+					if epIP == nil {
+						continue
+					}
+					endpoint := types.Endpoint{IP: epIP, Port: uint16(epPort.Port)}
+					info.endpoints[endpoint.String()] = endpoint
+				}
+			}
+		}
+		if len(info.endpoints) > 0 {
+			servicePorts[fmt.Sprintf("%s/%s", svcPort.Name, svcPort.Protocol)] = info
+		}
+	}
+	return servicePorts
+}
+
+// getAffinityTimeout returns the timeout in seconds of the ClientIP session affinity of a Service, or 0 if the
+// Service has no session affinity. The timeout is capped at the maximum idle timeout of an OpenFlow flow.
+func getAffinityTimeout(svc *corev1.Service) uint16 {
+	if svc.Spec.SessionAffinity != corev1.ServiceAffinityClientIP {
+		return 0
+	}
+	timeout := int32(corev1.DefaultClientIPServiceAffinitySeconds)
+	if config := svc.Spec.SessionAffinityConfig; config != nil && config.ClientIP != nil && config.ClientIP.TimeoutSeconds != nil {
+		timeout = *config.ClientIP.TimeoutSeconds
+	}
+	if timeout > math.MaxUint16 {
+		return math.MaxUint16
+	} else if timeout <= 0 {
+		return 0
+	}
+	return uint16(timeout)
+}
+
+// getOFProtocol returns the Openflow protocol of a Service protocol, or an empty string if the protocol is not
+// supported.
+func getOFProtocol(protocol corev1.Protocol) binding.Protocol {
+	switch protocol {
+	case corev1.ProtocolTCP:
+		return binding.ProtocolTCP
+	case corev1.ProtocolUDP:
+		return binding.ProtocolUDP
+	case corev1.ProtocolSCTP:
+		return binding.ProtocolSCTP
+	}
+	return ""
+}
+
+// groupIDAllocator allocates the IDs of the groups installed for the Service ports. The released IDs are reused.
+type groupIDAllocator struct {
+	nextID      binding.GroupIDType
+	releasedIDs []binding.GroupIDType
+}
+
+func newGroupIDAllocator() *groupIDAllocator {
+	// Group ID 0 is not used.
+	return &groupIDAllocator{nextID: 1}
+}
+
+func (a *groupIDAllocator) allocate() binding.GroupIDType {
+	if n := len(a.releasedIDs); n > 0 {
+		id := a.releasedIDs[n-1]
+		a.releasedIDs = a.releasedIDs[:n-1]
+		return id
+	}
+	id := a.nextID
+	a.nextID++
+	return id
+}
+
+func (a *groupIDAllocator) release(id binding.GroupIDType) {
+	a.releasedIDs = append(a.releasedIDs, id)
+}
@@ -0,0 +1,339 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	openflowtest "github.com/vmware-tanzu/antrea/pkg/agent/openflow/testing"
+	"github.com/vmware-tanzu/antrea/pkg/agent/types"
+	binding "github.com/vmware-tanzu/antrea/pkg/ovs/openflow"
+)
+
+type fakeProxier struct {
+	*Proxier
+	informerFactory informers.SharedInformerFactory
+}
+
+func newFakeProxier(ofClient *openflowtest.MockClient) *fakeProxier {
+	informerFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+	return &fakeProxier{
+		Proxier:         NewProxier(informerFactory, ofClient),
+		informerFactory: informerFactory,
+	}
+}
+
+func (p *fakeProxier) setService(svc *corev1.Service, endpoints *corev1.Endpoints) {
+	p.informerFactory.Core().V1().Services().Informer().GetIndexer().Add(svc)
+	p.informerFactory.Core().V1().Endpoints().Informer().GetIndexer().Add(endpoints)
+}
+
+func (p *fakeProxier) deleteService(svc *corev1.Service, endpoints *corev1.Endpoints) {
+	p.informerFactory.Core().V1().Services().Informer().GetIndexer().Delete(svc)
+	p.informerFactory.Core().V1().Endpoints().Informer().GetIndexer().Delete(endpoints)
+}
+
+func newService(name, clusterIP string, port int32, protocol corev1.Protocol) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: name},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: clusterIP,
+			Type:      corev1.ServiceTypeClusterIP,
+			Ports:     []corev1.ServicePort{{Name: "http", Port: port, Protocol: protocol}},
+		},
+	}
+}
+
+func newEndpoints(name string, port int32, protocol corev1.Protocol, ips ...string) *corev1.Endpoints {
+	var addresses []corev1.EndpointAddress
+	for _, ip := range ips {
+		addresses = append(addresses, corev1.EndpointAddress{IP: ip})
+	}
+	return &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: name},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: addresses,
+			Ports:     []corev1.EndpointPort{{Name: "http", Port: port, Protocol: protocol}},
+		}},
+	}
+}
+
+func newEndpoint(ip string, port uint16) types.Endpoint {
+	return types.Endpoint{IP: net.ParseIP(ip).To4(), Port: port}
+}
+
+func TestSyncServiceLifecycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ofClient := openflowtest.NewMockClient(ctrl)
+	p := newFakeProxier(ofClient)
+
+	svcIP := net.ParseIP("10.96.0.10")
+	svc := newService("svc1", svcIP.String(), 80, corev1.ProtocolTCP)
+	ep1, ep2, ep3 := newEndpoint("10.10.0.2", 8080), newEndpoint("10.10.1.2", 8080), newEndpoint("10.10.2.2", 8080)
+
+	// Add the Service with two Endpoints.
+	endpoints := newEndpoints("svc1", 8080, corev1.ProtocolTCP, "10.10.0.2", "10.10.1.2")
+	p.setService(svc, endpoints)
+	var groupEndpoints []types.Endpoint
+	ofClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, gomock.Any()).Do(
+		func(_ binding.Protocol, eps []types.Endpoint) {
+			assert.ElementsMatch(t, []types.Endpoint{ep1, ep2}, eps)
+		})
+	ofClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, gomock.Any()).Do(
+		func(_ binding.GroupIDType, _ bool, eps []types.Endpoint) {
+			groupEndpoints = eps
+		})
+	ofClient.EXPECT().InstallServiceFlows(binding.GroupIDType(1), svcIP.To4(), uint16(80), binding.ProtocolTCP, uint16(0))
+	require.NoError(t, p.syncService("ns1/svc1"))
+	assert.ElementsMatch(t, []types.Endpoint{ep1, ep2}, groupEndpoints)
+
+	// Syncing the Service again is a no-op.
+	require.NoError(t, p.syncService("ns1/svc1"))
+
+	// Replace an Endpoint of the Service.
+	endpoints = newEndpoints("svc1", 8080, corev1.ProtocolTCP, "10.10.0.2", "10.10.2.2")
+	p.setService(svc, endpoints)
+	ofClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, []types.Endpoint{ep3})
+	ofClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, gomock.Any()).Do(
+		func(_ binding.GroupIDType, _ bool, eps []types.Endpoint) {
+			groupEndpoints = eps
+		})
+	ofClient.EXPECT().UninstallEndpointFlows(binding.ProtocolTCP, ep2)
+	require.NoError(t, p.syncService("ns1/svc1"))
+	assert.ElementsMatch(t, []types.Endpoint{ep1, ep3}, groupEndpoints)
+
+	// Delete the Service.
+	p.deleteService(svc, endpoints)
+	ofClient.EXPECT().UninstallServiceFlows(svcIP.To4(), uint16(80), binding.ProtocolTCP)
+	ofClient.EXPECT().UninstallServiceGroup(binding.GroupIDType(1))
+	ofClient.EXPECT().UninstallEndpointFlows(binding.ProtocolTCP, ep1)
+	ofClient.EXPECT().UninstallEndpointFlows(binding.ProtocolTCP, ep3)
+	require.NoError(t, p.syncService("ns1/svc1"))
+	assert.Empty(t, p.installedServices)
+	assert.Empty(t, p.endpointReferences)
+}
+
+func TestSyncServiceSharedEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ofClient := openflowtest.NewMockClient(ctrl)
+	p := newFakeProxier(ofClient)
+
+	svc1IP, svc2IP := net.ParseIP("10.96.0.10"), net.ParseIP("10.96.0.11")
+	svc1, svc2 := newService("svc1", svc1IP.String(), 80, corev1.ProtocolUDP), newService("svc2", svc2IP.String(), 53, corev1.ProtocolUDP)
+	endpoints1, endpoints2 := newEndpoints("svc1", 5353, corev1.ProtocolUDP, "10.10.0.2"), newEndpoints("svc2", 5353, corev1.ProtocolUDP, "10.10.0.2")
+	ep := newEndpoint("10.10.0.2", 5353)
+	p.setService(svc1, endpoints1)
+	p.setService(svc2, endpoints2)
+
+	ofClient.EXPECT().InstallEndpointFlows(binding.ProtocolUDP, []types.Endpoint{ep}).Times(2)
+	ofClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, []types.Endpoint{ep})
+	ofClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), false, []types.Endpoint{ep})
+	ofClient.EXPECT().InstallServiceFlows(binding.GroupIDType(1), svc1IP.To4(), uint16(80), binding.ProtocolUDP, uint16(0))
+	ofClient.EXPECT().InstallServiceFlows(binding.GroupIDType(2), svc2IP.To4(), uint16(53), binding.ProtocolUDP, uint16(0))
+	require.NoError(t, p.syncService("ns1/svc1"))
+	require.NoError(t, p.syncService("ns1/svc2"))
+
+	// The Endpoint flows are kept until no Service selects the Endpoint.
+	p.deleteService(svc1, endpoints1)
+	ofClient.EXPECT().UninstallServiceFlows(svc1IP.To4(), uint16(80), binding.ProtocolUDP)
+	ofClient.EXPECT().UninstallServiceGroup(binding.GroupIDType(1))
+	require.NoError(t, p.syncService("ns1/svc1"))
+
+	p.deleteService(svc2, endpoints2)
+	ofClient.EXPECT().UninstallServiceFlows(svc2IP.To4(), uint16(53), binding.ProtocolUDP)
+	ofClient.EXPECT().UninstallServiceGroup(binding.GroupIDType(2))
+	ofClient.EXPECT().UninstallEndpointFlows(binding.ProtocolUDP, ep)
+	require.NoError(t, p.syncService("ns1/svc2"))
+}
+
+func TestSyncServiceSessionAffinity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ofClient := openflowtest.NewMockClient(ctrl)
+	p := newFakeProxier(ofClient)
+
+	svcIP := net.ParseIP("10.96.0.10").To4()
+	svc := newService("svc1", svcIP.String(), 80, corev1.ProtocolTCP)
+	svc.Spec.SessionAffinity = corev1.ServiceAffinityClientIP
+	timeout := int32(600)
+	svc.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: &timeout}}
+	ep1, ep2 := newEndpoint("10.10.0.2", 8080), newEndpoint("10.10.1.2", 8080)
+
+	endpoints := newEndpoints("svc1", 8080, corev1.ProtocolTCP, "10.10.0.2", "10.10.1.2")
+	p.setService(svc, endpoints)
+	ofClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, gomock.Any())
+	ofClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), true, gomock.Any())
+	ofClient.EXPECT().InstallServiceFlows(binding.GroupIDType(1), svcIP, uint16(80), binding.ProtocolTCP, uint16(600))
+	require.NoError(t, p.syncService("ns1/svc1"))
+
+	// The Service flows are reinstalled to delete the flows learned for the removed Endpoint.
+	endpoints = newEndpoints("svc1", 8080, corev1.ProtocolTCP, "10.10.0.2")
+	p.setService(svc, endpoints)
+	gomock.InOrder(
+		ofClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), true, []types.Endpoint{ep1}),
+		ofClient.EXPECT().UninstallServiceFlows(svcIP, uint16(80), binding.ProtocolTCP),
+		ofClient.EXPECT().InstallServiceFlows(binding.GroupIDType(1), svcIP, uint16(80), binding.ProtocolTCP, uint16(600)),
+		ofClient.EXPECT().UninstallEndpointFlows(binding.ProtocolTCP, ep2),
+	)
+	require.NoError(t, p.syncService("ns1/svc1"))
+
+	// Disabling session affinity reinstalls the Service port.
+	svc = newService("svc1", svcIP.String(), 80, corev1.ProtocolTCP)
+	p.setService(svc, endpoints)
+	gomock.InOrder(
+		ofClient.EXPECT().UninstallServiceFlows(svcIP, uint16(80), binding.ProtocolTCP),
+		ofClient.EXPECT().UninstallServiceGroup(binding.GroupIDType(1)),
+		ofClient.EXPECT().UninstallEndpointFlows(binding.ProtocolTCP, ep1),
+		ofClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, []types.Endpoint{ep1}),
+		ofClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, []types.Endpoint{ep1}),
+		ofClient.EXPECT().InstallServiceFlows(binding.GroupIDType(1), svcIP, uint16(80), binding.ProtocolTCP, uint16(0)),
+	)
+	require.NoError(t, p.syncService("ns1/svc1"))
+}
+
+func TestSyncServiceSessionAffinityFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ofClient := openflowtest.NewMockClient(ctrl)
+	p := newFakeProxier(ofClient)
+
+	svcIP := net.ParseIP("10.96.0.10").To4()
+	svc := newService("svc1", svcIP.String(), 80, corev1.ProtocolTCP)
+	svc.Spec.SessionAffinity = corev1.ServiceAffinityClientIP
+	ep2, ep3 := newEndpoint("10.10.1.2", 8080), newEndpoint("10.10.2.2", 8080)
+
+	endpoints := newEndpoints("svc1", 8080, corev1.ProtocolTCP, "10.10.0.2", "10.10.1.2")
+	p.setService(svc, endpoints)
+	ofClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, gomock.Any())
+	ofClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), true, gomock.Any())
+	ofClient.EXPECT().InstallServiceFlows(binding.GroupIDType(1), svcIP, uint16(80), binding.ProtocolTCP, gomock.Any())
+	require.NoError(t, p.syncService("ns1/svc1"))
+
+	// The added Endpoint is released when the Service flows cannot be reinstalled.
+	endpoints = newEndpoints("svc1", 8080, corev1.ProtocolTCP, "10.10.0.2", "10.10.2.2")
+	p.setService(svc, endpoints)
+	gomock.InOrder(
+		ofClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, []types.Endpoint{ep3}),
+		ofClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), true, gomock.Any()),
+		ofClient.EXPECT().UninstallServiceFlows(svcIP, uint16(80), binding.ProtocolTCP),
+		ofClient.EXPECT().InstallServiceFlows(binding.GroupIDType(1), svcIP, uint16(80), binding.ProtocolTCP, gomock.Any()).Return(errors.New("error")),
+		ofClient.EXPECT().UninstallEndpointFlows(binding.ProtocolTCP, ep3),
+	)
+	require.Error(t, p.syncService("ns1/svc1"))
+	assert.Len(t, p.endpointReferences, 2)
+
+	// The Endpoint references are not leaked by the retry.
+	gomock.InOrder(
+		ofClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, []types.Endpoint{ep3}),
+		ofClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), true, gomock.Any()),
+		ofClient.EXPECT().UninstallServiceFlows(svcIP, uint16(80), binding.ProtocolTCP),
+		ofClient.EXPECT().InstallServiceFlows(binding.GroupIDType(1), svcIP, uint16(80), binding.ProtocolTCP, gomock.Any()),
+		ofClient.EXPECT().UninstallEndpointFlows(binding.ProtocolTCP, ep2),
+	)
+	require.NoError(t, p.syncService("ns1/svc1"))
+	assert.Len(t, p.endpointReferences, 2)
+	for _, references := range p.endpointReferences {
+		assert.Equal(t, 1, references)
+	}
+}
+
+func TestGetAffinityTimeout(t *testing.T) {
+	newTimeout := func(timeout int32) *int32 {
+		return &timeout
+	}
+	tests := []struct {
+		name            string
+		affinity        corev1.ServiceAffinity
+		timeoutSeconds  *int32
+		expectedTimeout uint16
+	}{
+		{"no session affinity", corev1.ServiceAffinityNone, nil, 0},
+		{"default timeout", corev1.ServiceAffinityClientIP, nil, uint16(corev1.DefaultClientIPServiceAffinitySeconds)},
+		{"custom timeout", corev1.ServiceAffinityClientIP, newTimeout(60), 60},
+		{"timeout exceeding the maximum idle timeout", corev1.ServiceAffinityClientIP, newTimeout(86400), 65535},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newService("svc1", "10.96.0.10", 80, corev1.ProtocolTCP)
+			svc.Spec.SessionAffinity = tt.affinity
+			if tt.timeoutSeconds != nil {
+				svc.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: tt.timeoutSeconds}}
+			}
+			assert.Equal(t, tt.expectedTimeout, getAffinityTimeout(svc))
+		})
+	}
+}
+
+func TestBuildServicePorts(t *testing.T) {
+	tests := []struct {
+		name          string
+		svc           *corev1.Service
+		endpoints     *corev1.Endpoints
+		expectedPorts []string
+	}{
+		{
+			name:          "ClusterIP Service",
+			svc:           newService("svc1", "10.96.0.10", 80, corev1.ProtocolTCP),
+			endpoints:     newEndpoints("svc1", 8080, corev1.ProtocolTCP, "10.10.0.2"),
+			expectedPorts: []string{"http/TCP"},
+		},
+		{
+			name:          "headless Service",
+			svc:           newService("svc1", corev1.ClusterIPNone, 80, corev1.ProtocolTCP),
+			endpoints:     newEndpoints("svc1", 8080, corev1.ProtocolTCP, "10.10.0.2"),
+			expectedPorts: nil,
+		},
+		{
+			name:          "IPv6 Service",
+			svc:           newService("svc1", "fd00:10:96::10", 80, corev1.ProtocolTCP),
+			endpoints:     newEndpoints("svc1", 8080, corev1.ProtocolTCP, "fd00:10:10::2"),
+			expectedPorts: nil,
+		},
+		{
+			name:          "Service without Endpoints",
+			svc:           newService("svc1", "10.96.0.10", 80, corev1.ProtocolTCP),
+			endpoints:     newEndpoints("svc1", 8080, corev1.ProtocolTCP),
+			expectedPorts: nil,
+		},
+		{
+			name:          "Endpoints of another protocol",
+			svc:           newService("svc1", "10.96.0.10", 80, corev1.ProtocolTCP),
+			endpoints:     newEndpoints("svc1", 8080, corev1.ProtocolUDP, "10.10.0.2"),
+			expectedPorts: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			servicePorts := buildServicePorts(tt.svc, tt.endpoints)
+			var ports []string
+			for portKey := range servicePorts {
+				ports = append(ports, portKey)
+			}
+			assert.ElementsMatch(t, tt.expectedPorts, ports)
+		})
+	}
+}
@@ -15,7 +15,10 @@
 package querier
 
 import (
+	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -23,6 +26,7 @@ import (
 	"k8s.io/klog"
 
 	"github.com/vmware-tanzu/antrea/pkg/agent/config"
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter/connections"
 	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
 	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
 	"github.com/vmware-tanzu/antrea/pkg/apis/clusterinformation/v1beta1"
@@ -41,8 +45,19 @@ type AgentQuerier interface {
 	GetOpenflowClient() openflow.Client
 	GetOVSCtlClient() ovsctl.OVSCtlClient
 	GetNetworkPolicyInfoQuerier() querier.AgentNetworkPolicyInfoQuerier
+	GetConnTrackDumper() connections.ConnTrackDumper
 }
 
+// PodInterfaceAuditor reports the Pods whose network interface drifted from the configuration done
+// by the agent, indexed by "<Namespace>/<Name>", with the error found for each of them.
+type PodInterfaceAuditor interface {
+	GetPodInterfaceDrift() map[string]string
+}
+
+// maxReportedDriftedPods is the maximum number of Pods listed in the message of the
+// PodInterfacesHealthy condition.
+const maxReportedDriftedPods = 10
+
 type agentQuerier struct {
 	nodeConfig               *config.NodeConfig
 	interfaceStore           interfacestore.InterfaceStore
@@ -50,6 +65,8 @@ type agentQuerier struct {
 	ofClient                 openflow.Client
 	ovsBridgeClient          ovsconfig.OVSBridgeClient
 	networkPolicyInfoQuerier querier.AgentNetworkPolicyInfoQuerier
+	connTrackDumper          connections.ConnTrackDumper
+	podInterfaceAuditor      PodInterfaceAuditor
 	apiPort                  int
 }
 
@@ -60,6 +77,8 @@ func NewAgentQuerier(
 	ofClient openflow.Client,
 	ovsBridgeClient ovsconfig.OVSBridgeClient,
 	networkPolicyInfoQuerier querier.AgentNetworkPolicyInfoQuerier,
+	connTrackDumper connections.ConnTrackDumper,
+	podInterfaceAuditor PodInterfaceAuditor,
 	apiPort int,
 ) *agentQuerier {
 	return &agentQuerier{
@@ -69,6 +88,8 @@ func NewAgentQuerier(
 		ofClient:                 ofClient,
 		ovsBridgeClient:          ovsBridgeClient,
 		networkPolicyInfoQuerier: networkPolicyInfoQuerier,
+		connTrackDumper:          connTrackDumper,
+		podInterfaceAuditor:      podInterfaceAuditor,
 		apiPort:                  apiPort}
 }
 
@@ -102,6 +123,11 @@ func (aq agentQuerier) GetNetworkPolicyInfoQuerier() querier.AgentNetworkPolicyI
 	return aq.networkPolicyInfoQuerier
 }
 
+// GetConnTrackDumper returns ConnTrackDumper.
+func (aq agentQuerier) GetConnTrackDumper() connections.ConnTrackDumper {
+	return aq.connTrackDumper
+}
+
 // getOVSVersion gets current OVS version.
 func (aq agentQuerier) getOVSVersion() string {
 	v, err := aq.ovsBridgeClient.GetOVSVersion()
@@ -137,7 +163,7 @@ func (aq agentQuerier) getAgentConditions(ovsConnected bool) []v1beta1.AgentCond
 	if !aq.ofClient.IsConnected() {
 		openflowConnectionStatus = v1.ConditionFalse
 	}
-	return []v1beta1.AgentCondition{
+	conditions := []v1beta1.AgentCondition{
 		{
 			Type:              v1beta1.AgentHealthy,
 			Status:            v1.ConditionTrue,
@@ -159,6 +185,41 @@ func (aq agentQuerier) getAgentConditions(ovsConnected bool) []v1beta1.AgentCond
 			LastHeartbeatTime: lastHeartbeatTime,
 		},
 	}
+	if aq.podInterfaceAuditor != nil {
+		conditions = append(conditions, aq.getPodInterfacesCondition(lastHeartbeatTime))
+	}
+	return conditions
+}
+
+// getPodInterfacesCondition gets the PodInterfacesHealthy condition from the last audit of the Pod
+// interfaces.
+func (aq agentQuerier) getPodInterfacesCondition(lastHeartbeatTime metav1.Time) v1beta1.AgentCondition {
+	condition := v1beta1.AgentCondition{
+		Type:              v1beta1.PodInterfacesHealthy,
+		Status:            v1.ConditionTrue,
+		LastHeartbeatTime: lastHeartbeatTime,
+	}
+	interfaceDrift := aq.podInterfaceAuditor.GetPodInterfaceDrift()
+	if len(interfaceDrift) == 0 {
+		return condition
+	}
+	pods := make([]string, 0, len(interfaceDrift))
+	for pod := range interfaceDrift {
+		pods = append(pods, pod)
+	}
+	sort.Strings(pods)
+	details := make([]string, 0, maxReportedDriftedPods)
+	for _, pod := range pods {
+		if len(details) == maxReportedDriftedPods {
+			details = append(details, fmt.Sprintf("and %d more", len(pods)-maxReportedDriftedPods))
+			break
+		}
+		details = append(details, fmt.Sprintf("%s: %s", pod, interfaceDrift[pod]))
+	}
+	condition.Status = v1.ConditionFalse
+	condition.Reason = "InterfaceDrift"
+	condition.Message = fmt.Sprintf("Interfaces of %d Pods drifted from their configuration: %s", len(pods), strings.Join(details, "; "))
+	return condition
 }
 
 // getNetworkPolicyControllerInfo gets current network policy controller info
@@ -193,7 +254,10 @@ func (aq agentQuerier) GetAgentInfo(agentInfo *v1beta1.AntreaAgentInfo, partial
 		agentInfo.Version = querier.GetVersion()
 		agentInfo.PodRef = querier.GetSelfPod()
 		agentInfo.NodeRef = querier.GetSelfNode(true, aq.nodeConfig.Name)
-		agentInfo.NodeSubnet = []string{aq.nodeConfig.PodCIDR.String()}
+		agentInfo.NodeSubnet = nil
+		for _, podCIDR := range aq.nodeConfig.PodCIDRs() {
+			agentInfo.NodeSubnet = append(agentInfo.NodeSubnet, podCIDR.String())
+		}
 		agentInfo.OVSInfo.BridgeName = aq.nodeConfig.OVSBridge
 		agentInfo.APIPort = aq.apiPort
 	}
@@ -22,6 +22,7 @@ package testing
 import (
 	gomock "github.com/golang/mock/gomock"
 	config "github.com/vmware-tanzu/antrea/pkg/agent/config"
+	connections "github.com/vmware-tanzu/antrea/pkg/agent/flowexporter/connections"
 	interfacestore "github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
 	openflow "github.com/vmware-tanzu/antrea/pkg/agent/openflow"
 	v1beta1 "github.com/vmware-tanzu/antrea/pkg/apis/clusterinformation/v1beta1"
@@ -66,6 +67,20 @@ func (mr *MockAgentQuerierMockRecorder) GetAgentInfo(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAgentInfo", reflect.TypeOf((*MockAgentQuerier)(nil).GetAgentInfo), arg0, arg1)
 }
 
+// GetConnTrackDumper mocks base method
+func (m *MockAgentQuerier) GetConnTrackDumper() connections.ConnTrackDumper {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConnTrackDumper")
+	ret0, _ := ret[0].(connections.ConnTrackDumper)
+	return ret0
+}
+
+// GetConnTrackDumper indicates an expected call of GetConnTrackDumper
+func (mr *MockAgentQuerierMockRecorder) GetConnTrackDumper() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnTrackDumper", reflect.TypeOf((*MockAgentQuerier)(nil).GetConnTrackDumper))
+}
+
 // GetInterfaceStore mocks base method
 func (m *MockAgentQuerier) GetInterfaceStore() interfacestore.InterfaceStore {
 	m.ctrl.T.Helper()
@@ -43,4 +43,34 @@ type Interface interface {
 	// UnMigrateRoutesFromGw should move routes back from local gateway to original device linkName
 	// if linkName is nil, it should remove the routes.
 	UnMigrateRoutesFromGw(route *net.IPNet, linkName string) error
+
+	// AddEgressIP should make the Node host the provided egress IP, so that the traffic to it is sent to the OVS
+	// pipeline, where the connections SNAT'd to the IP are translated back to the Pods. It should override the
+	// configuration if it already exists, without error.
+	AddEgressIP(egressIP net.IP) error
+
+	// DeleteEgressIP should remove the configuration added by AddEgressIP for the provided egress IP.
+	// It should do nothing if the configuration doesn't exist, without error.
+	DeleteEgressIP(egressIP net.IP) error
+
+	// ReconcileEgressIPs should remove the configuration added by AddEgressIP for the egress IPs which are not in
+	// the provided list.
+	ReconcileEgressIPs(egressIPs []net.IP) error
+
+	// AddNodePortLocal should forward the traffic to the provided port and protocol of the Node's IPs to
+	// podIP:podPort. It should do nothing if the forwarding already exists, without error.
+	AddNodePortLocal(nodePort int, podIP net.IP, podPort int, protocol string) error
+
+	// DeleteNodePortLocal should remove the forwarding added by AddNodePortLocal with the same arguments.
+	// It should do nothing if the forwarding doesn't exist, without error.
+	DeleteNodePortLocal(nodePort int, podIP net.IP, podPort int, protocol string) error
+
+	// AddPoolPodRoute should route the traffic to a local Pod whose IP is allocated from an IPPool, instead
+	// of the Pod CIDR of the Node, to the host gateway, and masquerade the traffic from the Pod to external
+	// destinations. It should override the configuration if it already exists, without error.
+	AddPoolPodRoute(podIP net.IP) error
+
+	// DeletePoolPodRoute should remove the configuration added by AddPoolPodRoute for the provided Pod IP.
+	// It should do nothing if the configuration doesn't exist, without error.
+	DeletePoolPodRoute(podIP net.IP) error
 }
@@ -20,9 +20,11 @@ import (
 	"net"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 	"sync"
 
+	"github.com/j-keck/arping"
 	"github.com/vishvananda/netlink"
 	"golang.org/x/sys/unix"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -33,6 +35,7 @@ import (
 	"github.com/vmware-tanzu/antrea/pkg/agent/util"
 	"github.com/vmware-tanzu/antrea/pkg/agent/util/ipset"
 	"github.com/vmware-tanzu/antrea/pkg/agent/util/iptables"
+	"github.com/vmware-tanzu/antrea/pkg/features"
 	"github.com/vmware-tanzu/antrea/pkg/util/env"
 )
 
@@ -53,14 +56,22 @@ const (
 	svcTblVirtualDefaultGWMAC = "12:34:56:78:9a:bc"
 
 	// Antrea managed ipset.
-	// antreaPodIPSet contains all Pod CIDRs of this cluster.
+	// antreaPodIPSet contains all IPv4 Pod CIDRs of this cluster.
 	antreaPodIPSet = "ANTREA-POD-IP"
+	// antreaPodIP6Set contains all IPv6 Pod CIDRs of this cluster.
+	antreaPodIP6Set = "ANTREA-POD-IP6"
+	// antreaPoolPodIPSet contains the IPs of the local Pods which are allocated from IPPools. It's only
+	// created when the AntreaIPAM feature is enabled.
+	antreaPoolPodIPSet = "ANTREA-POOL-POD-IP"
 
 	// Antrea managed iptables chains.
 	antreaForwardChain     = "ANTREA-FORWARD"
 	antreaPostRoutingChain = "ANTREA-POSTROUTING"
 	antreaMangleChain      = "ANTREA-MANGLE"
 	antreaRawChain         = "ANTREA-RAW"
+	// antreaNodePortLocalChain contains the DNAT rules of the NodePortLocal feature. It's only created when the
+	// feature is enabled.
+	antreaNodePortLocalChain = "ANTREA-NODE-PORT-LOCAL"
 )
 
 var (
@@ -79,10 +90,20 @@ type Client struct {
 	hostGateway string
 	serviceCIDR *net.IPNet
 	ipt         *iptables.Client
+	// ip6t is used to configure ip6tables. It's nil if IPv6 is not enabled on the Node.
+	ip6t *iptables.Client
 	// serviceRtTable contains Antrea service route table information.
 	serviceRtTable *serviceRtTableConfig
 	// nodeRoutes caches ip routes to remote Pods. It's a map of podCIDR to routes.
 	nodeRoutes sync.Map
+	// nodeNeighbors caches the IPv6 neighbors of the remote gateways. It's a map of podCIDR to neighbor.
+	nodeNeighbors sync.Map
+	// egressRoutes caches the routes to the egress IPs hosted by this Node. It's a map of egress IP /32 CIDR to
+	// route. These routes are on gw0 and must not be removed by Reconcile.
+	egressRoutes sync.Map
+	// poolPodRoutes caches the routes to the local Pods whose IPs are allocated from IPPools. It's a map of
+	// Pod IP /32 CIDR to routes. These routes are on gw0 and must not be removed by Reconcile.
+	poolPodRoutes sync.Map
 }
 
 type serviceRtTableConfig struct {
@@ -124,6 +145,13 @@ func NewClient(hostGateway string, serviceCIDR *net.IPNet, encapMode config.Traf
 // It is idempotent and can be safely called on every startup.
 func (c *Client) Initialize(nodeConfig *config.NodeConfig) error {
 	c.nodeConfig = nodeConfig
+	if nodeConfig.PodIPv6CIDR != nil {
+		ip6t, err := iptables.NewIPv6()
+		if err != nil {
+			return fmt.Errorf("error creating IP6Tables instance: %v", err)
+		}
+		c.ip6t = ip6t
+	}
 
 	// Sets up the ipset that will be used in iptables.
 	if err := c.initIPSet(); err != nil {
@@ -163,16 +191,33 @@ func (c *Client) initIPSet() error {
 	if c.encapMode.IsNetworkPolicyOnly() {
 		return nil
 	}
-	if err := ipset.CreateIPSet(antreaPodIPSet, ipset.HashNet); err != nil {
-		return err
+	for _, podCIDR := range c.nodeConfig.PodCIDRs() {
+		ipSetName, isIPv6 := getIPSetName(podCIDR)
+		if err := ipset.CreateIPSet(ipSetName, ipset.HashNet, isIPv6); err != nil {
+			return err
+		}
+		// Ensure its own PodCIDR is in it.
+		if err := ipset.AddEntry(ipSetName, podCIDR.String()); err != nil {
+			return err
+		}
 	}
-	// Ensure its own PodCIDR is in it.
-	if err := ipset.AddEntry(antreaPodIPSet, c.nodeConfig.PodCIDR.String()); err != nil {
-		return err
+	if c.nodeConfig.PodIPv4CIDR != nil && features.DefaultFeatureGate.Enabled(features.AntreaIPAM) {
+		if err := ipset.CreateIPSet(antreaPoolPodIPSet, ipset.HashNet, false); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// getIPSetName returns the name of the ipset which the provided Pod CIDR should be added to, and whether
+// it is an IPv6 CIDR.
+func getIPSetName(podCIDR *net.IPNet) (string, bool) {
+	if podCIDR.IP.To4() == nil {
+		return antreaPodIP6Set, true
+	}
+	return antreaPodIPSet, false
+}
+
 // writeEKSMangleRule writes an additional iptables mangle rule to the
 // iptablesData buffer, which is required to ensure that the reverse path for
 // NodePort Service traffic is correct on EKS.
@@ -202,30 +247,65 @@ func (c *Client) initIPTables() error {
 	// Create the antrea managed chains and link them to built-in chains.
 	// We cannot use iptables-restore for these jump rules because there
 	// are non antrea managed rules in built-in chains.
-	jumpRules := []struct{ table, srcChain, dstChain, comment string }{
+	type jumpRule struct{ table, srcChain, dstChain, comment string }
+	jumpRules := []jumpRule{
 		{iptables.FilterTable, iptables.ForwardChain, antreaForwardChain, "Antrea: jump to Antrea forwarding rules"},
 		{iptables.NATTable, iptables.PostRoutingChain, antreaPostRoutingChain, "Antrea: jump to Antrea postrouting rules"},
 		{iptables.MangleTable, iptables.PreRoutingChain, antreaMangleChain, "Antrea: jump to Antrea mangle rules"},
 		{iptables.RawTable, iptables.PreRoutingChain, antreaRawChain, "Antrea: jump to Antrea raw rules"},
 	}
-	for _, rule := range jumpRules {
-		if err := c.ipt.EnsureChain(rule.table, rule.dstChain); err != nil {
-			return err
+	ipts := []*iptables.Client{c.ipt}
+	if c.ip6t != nil {
+		ipts = append(ipts, c.ip6t)
+	}
+	for _, ipt := range ipts {
+		rules := jumpRules
+		// NodePortLocal only supports IPv4. Both the traffic received by the Node and the traffic sent from the
+		// Node to its own IPs must be forwarded.
+		if ipt == c.ipt && features.DefaultFeatureGate.Enabled(features.NodePortLocal) {
+			rules = append(rules,
+				jumpRule{iptables.NATTable, iptables.PreRoutingChain, antreaNodePortLocalChain, "Antrea: jump to Antrea NodePortLocal rules"},
+				jumpRule{iptables.NATTable, iptables.OutputChain, antreaNodePortLocalChain, "Antrea: jump to Antrea NodePortLocal rules"})
 		}
-		ruleSpec := []string{"-j", rule.dstChain, "-m", "comment", "--comment", rule.comment}
-		if err := c.ipt.EnsureRule(rule.table, rule.srcChain, ruleSpec); err != nil {
-			return err
+		for _, rule := range rules {
+			if err := ipt.EnsureChain(rule.table, rule.dstChain); err != nil {
+				return err
+			}
+			ruleSpec := []string{"-j", rule.dstChain, "-m", "comment", "--comment", rule.comment}
+			if err := ipt.EnsureRule(rule.table, rule.srcChain, ruleSpec); err != nil {
+				return err
+			}
 		}
 	}
 
 	// Create required rules in the antrea chains.
 	// Use iptables-restore as it flushes the involved chains and creates the desired rules
 	// with a single call, instead of string matching to clean up stale rules.
+	iptablesData := c.restoreIPTablesData(c.nodeConfig.PodIPv4CIDR, antreaPodIPSet, false)
+	// Setting --noflush to keep the previous contents (i.e. non antrea managed chains) of the tables.
+	if err := c.ipt.Restore(iptablesData.Bytes(), false); err != nil {
+		return err
+	}
+	if c.ip6t != nil {
+		ip6tablesData := c.restoreIPTablesData(c.nodeConfig.PodIPv6CIDR, antreaPodIP6Set, true)
+		if err := c.ip6t.Restore(ip6tablesData.Bytes(), false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreIPTablesData returns the rules of the antrea chains for one IP family, in the format of
+// iptables-restore. podCIDR is the local Pod CIDR of the IP family and can be nil, podIPSet is the
+// ipset containing all Pod CIDRs of the IP family.
+func (c *Client) restoreIPTablesData(podCIDR *net.IPNet, podIPSet string, isIPv6 bool) *bytes.Buffer {
+	// The Service CIDR only has one IP family, the rules matching it are not needed for the other one.
+	matchServiceCIDR := c.serviceCIDR != nil && (c.serviceCIDR.IP.To4() == nil) == isIPv6
 	iptablesData := bytes.NewBuffer(nil)
 	// Write head lines anyway so the undesired rules can be deleted when noEncap -> encap.
 	writeLine(iptablesData, "*mangle")
 	writeLine(iptablesData, iptables.MakeChainLine(antreaMangleChain))
-	if c.encapMode.SupportsNoEncap() {
+	if c.encapMode.SupportsNoEncap() && matchServiceCIDR {
 		writeLine(iptablesData, []string{
 			"-A", antreaMangleChain,
 			"-m", "comment", "--comment", `"Antrea: mark pod to service packets"`,
@@ -266,13 +346,35 @@ func (c *Client) initIPTables() error {
 	// Antrea should not get involved.
 	writeLine(iptablesData, "*nat")
 	writeLine(iptablesData, iptables.MakeChainLine(antreaPostRoutingChain))
-	if !c.encapMode.IsNetworkPolicyOnly() {
-		writeLine(iptablesData, []string{
+	// The NodePortLocal rules are flushed on startup, the NodePortLocal controller adds them again from the
+	// annotations of the Pods.
+	if !isIPv6 && features.DefaultFeatureGate.Enabled(features.NodePortLocal) {
+		writeLine(iptablesData, iptables.MakeChainLine(antreaNodePortLocalChain))
+	}
+	if !c.encapMode.IsNetworkPolicyOnly() && podCIDR != nil {
+		// The IPs allocated from IPPools to the local Pods are neither in the Pod CIDR of the Node nor in
+		// podIPSet.
+		poolPodEnabled := !isIPv6 && features.DefaultFeatureGate.Enabled(features.AntreaIPAM)
+		excludePoolPodDst := []string{}
+		if poolPodEnabled {
+			excludePoolPodDst = []string{"-m", "set", "!", "--match-set", antreaPoolPodIPSet, "dst"}
+		}
+		rule := []string{
 			"-A", antreaPostRoutingChain,
 			"-m", "comment", "--comment", `"Antrea: masquerade pod to external packets"`,
-			"-s", c.nodeConfig.PodCIDR.String(), "-m", "set", "!", "--match-set", antreaPodIPSet, "dst",
-			"-j", iptables.MasqueradeTarget,
-		}...)
+			"-s", podCIDR.String(), "-m", "set", "!", "--match-set", podIPSet, "dst",
+		}
+		rule = append(rule, excludePoolPodDst...)
+		writeLine(iptablesData, append(rule, "-j", iptables.MasqueradeTarget)...)
+		if poolPodEnabled {
+			rule = []string{
+				"-A", antreaPostRoutingChain,
+				"-m", "comment", "--comment", `"Antrea: masquerade IPPool pod to external packets"`,
+				"-m", "set", "--match-set", antreaPoolPodIPSet, "src", "-m", "set", "!", "--match-set", podIPSet, "dst",
+			}
+			rule = append(rule, excludePoolPodDst...)
+			writeLine(iptablesData, append(rule, "-j", iptables.MasqueradeTarget)...)
+		}
 	}
 	writeLine(iptablesData, "COMMIT")
 
@@ -287,12 +389,7 @@ func (c *Client) initIPTables() error {
 		}...)
 	}
 	writeLine(iptablesData, "COMMIT")
-
-	// Setting --noflush to keep the previous contents (i.e. non antrea managed chains) of the tables.
-	if err := c.ipt.Restore(iptablesData.Bytes(), false); err != nil {
-		return err
-	}
-	return nil
+	return iptablesData
 }
 
 func (c *Client) initIPRoutes() error {
@@ -318,19 +415,27 @@ func (c *Client) Reconcile(podCIDRs []string) error {
 
 	desiredPodCIDRs := sets.NewString(podCIDRs...)
 
-	// Remove orphaned podCIDRs from antreaPodIPSet.
-	entries, err := ipset.ListEntries(antreaPodIPSet)
-	if err != nil {
-		return err
+	// Remove orphaned podCIDRs from antreaPodIPSet and antreaPodIP6Set.
+	// The ipset of an IP family is only created by initIPSet when the Node has a Pod CIDR of that family.
+	var ipSets []string
+	for _, podCIDR := range c.nodeConfig.PodCIDRs() {
+		ipSetName, _ := getIPSetName(podCIDR)
+		ipSets = append(ipSets, ipSetName)
 	}
-	for _, entry := range entries {
-		if desiredPodCIDRs.Has(entry) {
-			continue
-		}
-		klog.V(4).Infof("Deleting orphaned ip %s from ipset", entry)
-		if err := ipset.DelEntry(antreaPodIPSet, entry); err != nil {
+	for _, ipSetName := range ipSets {
+		entries, err := ipset.ListEntries(ipSetName)
+		if err != nil {
 			return err
 		}
+		for _, entry := range entries {
+			if desiredPodCIDRs.Has(entry) {
+				continue
+			}
+			klog.V(4).Infof("Deleting orphaned ip %s from ipset %s", entry, ipSetName)
+			if err := ipset.DelEntry(ipSetName, entry); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Remove orphaned routes from host network.
@@ -342,6 +447,17 @@ func (c *Client) Reconcile(podCIDRs []string) error {
 		if desiredPodCIDRs.Has(podCIDR) {
 			continue
 		}
+		if _, ok := c.egressRoutes.Load(podCIDR); ok {
+			continue
+		}
+		if _, ok := c.poolPodRoutes.Load(podCIDR); ok {
+			continue
+		}
+		// The link-local and multicast routes on the host gateway are added by the kernel for IPv6, they
+		// must be kept.
+		if _, ipNet, err := net.ParseCIDR(podCIDR); err == nil && (ipNet.IP.IsLinkLocalUnicast() || ipNet.IP.IsMulticast()) {
+			continue
+		}
 		for _, route := range actualRoutes {
 			klog.V(4).Infof("Deleting orphaned route %v", route)
 			if err := netlink.RouteDel(route); err != nil && err != unix.ESRCH {
@@ -355,11 +471,18 @@ func (c *Client) Reconcile(podCIDRs []string) error {
 // AddRoutes adds routes to a new podCIDR. It overrides the routes if they already exist.
 func (c *Client) AddRoutes(podCIDR *net.IPNet, nodeIP, nodeGwIP net.IP) error {
 	podCIDRStr := podCIDR.String()
+	ipSetName, _ := getIPSetName(podCIDR)
 	// Add this podCIDR to antreaPodIPSet so that packets to them won't be masqueraded when they leave the host.
-	if err := ipset.AddEntry(antreaPodIPSet, podCIDRStr); err != nil {
+	if err := ipset.AddEntry(ipSetName, podCIDRStr); err != nil {
 		return err
 	}
 
+	if c.nodeConfig.WireGuardConfig != nil {
+		// With WireGuard, the traffic to the Node is routed to the WireGuard device, which
+		// encrypts it and sends it to the peer configured for the Pod CIDR.
+		return c.addWireGuardRoute(podCIDR, nodeIP)
+	}
+
 	// Install routes to this Node.
 	routes := []*netlink.Route{
 		{
@@ -405,14 +528,47 @@ func (c *Client) AddRoutes(podCIDR *net.IPNet, nodeIP, nodeGwIP net.IP) error {
 		}
 	}
 	c.nodeRoutes.Store(podCIDRStr, routes)
+
+	// IPv6 doesn't use ARP, and the Neighbor Solicitation messages for the remote gateway cannot be answered
+	// by the OVS pipeline. Add a permanent neighbor entry so that no Neighbor Discovery is ever required on
+	// gw0 for the remote gateway.
+	if podCIDR.IP.To4() == nil {
+		neigh := &netlink.Neigh{
+			LinkIndex:    c.nodeConfig.GatewayConfig.LinkIndex,
+			Family:       netlink.FAMILY_V6,
+			State:        netlink.NUD_PERMANENT,
+			IP:           nodeGwIP,
+			HardwareAddr: openflow.GlobalVirtualMAC,
+		}
+		if err := netlink.NeighSet(neigh); err != nil {
+			return fmt.Errorf("failed to add neighbor %v to gw %s: %v", neigh, c.nodeConfig.GatewayConfig.Name, err)
+		}
+		c.nodeNeighbors.Store(podCIDRStr, neigh)
+	}
+	return nil
+}
+
+// addWireGuardRoute adds the route to a podCIDR through the WireGuard device.
+func (c *Client) addWireGuardRoute(podCIDR *net.IPNet, nodeIP net.IP) error {
+	route := &netlink.Route{
+		Dst:       podCIDR,
+		LinkIndex: c.nodeConfig.WireGuardConfig.LinkIndex,
+		Scope:     netlink.SCOPE_LINK,
+		Table:     c.serviceRtTable.Idx,
+	}
+	if err := netlink.RouteReplace(route); err != nil {
+		return fmt.Errorf("failed to install route to peer %s with netlink: %v", nodeIP, err)
+	}
+	c.nodeRoutes.Store(podCIDR.String(), []*netlink.Route{route})
 	return nil
 }
 
 // DeleteRoutes deletes routes to a PodCIDR. It does nothing if the routes doesn't exist.
 func (c *Client) DeleteRoutes(podCIDR *net.IPNet) error {
 	podCIDRStr := podCIDR.String()
+	ipSetName, _ := getIPSetName(podCIDR)
 	// Delete this podCIDR from antreaPodIPSet as the CIDR is no longer for Pods.
-	if err := ipset.DelEntry(antreaPodIPSet, podCIDRStr); err != nil {
+	if err := ipset.DelEntry(ipSetName, podCIDRStr); err != nil {
 		return err
 	}
 
@@ -427,6 +583,12 @@ func (c *Client) DeleteRoutes(podCIDR *net.IPNet) error {
 		}
 	}
 	c.nodeRoutes.Delete(podCIDRStr)
+	if neigh, exists := c.nodeNeighbors.Load(podCIDRStr); exists {
+		if err := netlink.NeighDel(neigh.(*netlink.Neigh)); err != nil && err != unix.ENOENT {
+			return err
+		}
+		c.nodeNeighbors.Delete(podCIDRStr)
+	}
 	return nil
 }
 
@@ -436,11 +598,23 @@ func (c *Client) listIPRoutes() (map[string][]*netlink.Route, error) {
 	filter := &netlink.Route{
 		Table:     c.serviceRtTable.Idx,
 		LinkIndex: c.nodeConfig.GatewayConfig.LinkIndex}
-	routes, err := netlink.RouteListFiltered(netlink.FAMILY_V4, filter, netlink.RT_FILTER_TABLE|netlink.RT_FILTER_OIF)
+	routes, err := netlink.RouteListFiltered(c.routeFamily(), filter, netlink.RT_FILTER_TABLE|netlink.RT_FILTER_OIF)
 	if err != nil {
 		return nil, err
 	}
 
+	if c.nodeConfig.WireGuardConfig != nil {
+		// get all routes on the WireGuard device from service table.
+		wireGuardFilter := &netlink.Route{
+			Table:     c.serviceRtTable.Idx,
+			LinkIndex: c.nodeConfig.WireGuardConfig.LinkIndex}
+		wireGuardRoutes, err := netlink.RouteListFiltered(c.routeFamily(), wireGuardFilter, netlink.RT_FILTER_TABLE|netlink.RT_FILTER_OIF)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, wireGuardRoutes...)
+	}
+
 	rtMap := make(map[string][]*netlink.Route)
 	for _, rt := range routes {
 		// rt is reference to actual data, as it changes,
@@ -452,7 +626,7 @@ func (c *Client) listIPRoutes() (map[string][]*netlink.Route, error) {
 	if !c.serviceRtTable.IsMainTable() {
 		// get all routes on gw0 from main table.
 		filter.Table = 0
-		routes, err := netlink.RouteListFiltered(netlink.FAMILY_V4, filter, netlink.RT_FILTER_OIF)
+		routes, err := netlink.RouteListFiltered(c.routeFamily(), filter, netlink.RT_FILTER_OIF)
 		if err != nil {
 			return nil, err
 		}
@@ -464,7 +638,7 @@ func (c *Client) listIPRoutes() (map[string][]*netlink.Route, error) {
 		}
 
 		// now get all routes gw0 on other interfaces from main table.
-		routes, err = netlink.RouteListFiltered(netlink.FAMILY_V4, nil, 0)
+		routes, err = netlink.RouteListFiltered(c.routeFamily(), nil, 0)
 		if err != nil {
 			return nil, err
 		}
@@ -483,6 +657,15 @@ func (c *Client) listIPRoutes() (map[string][]*netlink.Route, error) {
 	return rtMap, nil
 }
 
+// routeFamily returns the netlink family of the routes managed by the client, which covers IPv6 only if
+// IPv6 is enabled on the Node.
+func (c *Client) routeFamily() int {
+	if c.nodeConfig.PodIPv6CIDR != nil {
+		return netlink.FAMILY_ALL
+	}
+	return netlink.FAMILY_V4
+}
+
 func (c *Client) addServiceRouting() error {
 	f, err := os.OpenFile(routeTableConfigPath, os.O_RDWR|os.O_APPEND, 0)
 	if err != nil {
@@ -506,15 +689,17 @@ func (c *Client) addServiceRouting() error {
 
 	gwConfig := c.nodeConfig.GatewayConfig
 	if !c.encapMode.IsNetworkPolicyOnly() {
-		// Add local podCIDR if applicable to service rt table.
-		route := &netlink.Route{
-			LinkIndex: gwConfig.LinkIndex,
-			Scope:     netlink.SCOPE_LINK,
-			Dst:       c.nodeConfig.PodCIDR,
-			Table:     c.serviceRtTable.Idx,
-		}
-		if err := netlink.RouteReplace(route); err != nil {
-			return fmt.Errorf("failed to add link route to service table: %v", err)
+		// Add local podCIDRs if applicable to service rt table.
+		for _, podCIDR := range c.nodeConfig.PodCIDRs() {
+			route := &netlink.Route{
+				LinkIndex: gwConfig.LinkIndex,
+				Scope:     netlink.SCOPE_LINK,
+				Dst:       podCIDR,
+				Table:     c.serviceRtTable.Idx,
+			}
+			if err := netlink.RouteReplace(route); err != nil {
+				return fmt.Errorf("failed to add link route to service table: %v", err)
+			}
 		}
 	}
 
@@ -734,3 +919,194 @@ func (c *Client) UnMigrateRoutesFromGw(route *net.IPNet, linkName string) error
 	}
 	return nil
 }
+
+// egressIPConfig returns the route, the neighbor and the proxy neighbor which make the Node host the provided egress IP.
+func (c *Client) egressIPConfig(egressIP net.IP) (*netlink.Route, *netlink.Neigh, *netlink.Neigh, error) {
+	_, uplink, err := util.GetIPNetDeviceFromIP(c.nodeConfig.NodeIPAddr.IP)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get the uplink interface of Node IP %s: %v", c.nodeConfig.NodeIPAddr.IP, err)
+	}
+	route := &netlink.Route{
+		Dst:       &net.IPNet{IP: egressIP, Mask: net.CIDRMask(32, 32)},
+		LinkIndex: c.nodeConfig.GatewayConfig.LinkIndex,
+		Scope:     netlink.SCOPE_LINK,
+	}
+	// The reply packets to the egress IP are sent to OVS with the global virtual MAC, like the packets tunneled
+	// from remote Nodes, so that no ARP is ever required on gw0 for the egress IP.
+	neigh := &netlink.Neigh{
+		LinkIndex:    c.nodeConfig.GatewayConfig.LinkIndex,
+		Family:       netlink.FAMILY_V4,
+		State:        netlink.NUD_PERMANENT,
+		IP:           egressIP,
+		HardwareAddr: openflow.GlobalVirtualMAC,
+	}
+	// The Node answers the ARP requests for the egress IP received on the uplink interface, as the egress IP is
+	// routed to another interface.
+	proxyNeigh := &netlink.Neigh{
+		LinkIndex: uplink.Attrs().Index,
+		Family:    netlink.FAMILY_V4,
+		Flags:     netlink.NTF_PROXY,
+		IP:        egressIP,
+	}
+	return route, neigh, proxyNeigh, nil
+}
+
+// AddEgressIP makes the Node host the provided egress IP: the Node answers the ARP requests for the IP on its uplink
+// interface, and routes the traffic to the IP to the OVS pipeline through the host gateway. The IP is not assigned to
+// any interface. A gratuitous ARP is sent so that the neighbors of the Node learn the new location of the IP.
+func (c *Client) AddEgressIP(egressIP net.IP) error {
+	route, neigh, proxyNeigh, err := c.egressIPConfig(egressIP)
+	if err != nil {
+		return err
+	}
+	if err := netlink.NeighSet(neigh); err != nil {
+		return fmt.Errorf("failed to add neighbor %v to gw %s: %v", neigh, c.nodeConfig.GatewayConfig.Name, err)
+	}
+	if err := netlink.RouteReplace(route); err != nil {
+		return fmt.Errorf("failed to install route to egress IP %s: %v", egressIP, err)
+	}
+	c.egressRoutes.Store(route.Dst.String(), route)
+	if err := netlink.NeighSet(proxyNeigh); err != nil {
+		return fmt.Errorf("failed to add proxy neighbor for egress IP %s: %v", egressIP, err)
+	}
+	uplink, err := net.InterfaceByIndex(proxyNeigh.LinkIndex)
+	if err != nil {
+		return fmt.Errorf("failed to get the uplink interface: %v", err)
+	}
+	if err := arping.GratuitousArpOverIface(egressIP, *uplink); err != nil {
+		// The neighbors will eventually resolve the IP again, the ARP requests being answered by this Node.
+		klog.Warningf("Failed to send gratuitous ARP for egress IP %s on interface %s: %v", egressIP, uplink.Name, err)
+	}
+	return nil
+}
+
+// nodePortLocalRuleSpec returns the iptables rule which DNATs the traffic to the provided port and protocol of the
+// Node's IPs to podIP:podPort.
+func nodePortLocalRuleSpec(nodePort int, podIP net.IP, podPort int, protocol string) []string {
+	return []string{
+		"-p", strings.ToLower(protocol),
+		"-m", "addrtype", "--dst-type", "LOCAL",
+		"--dport", strconv.Itoa(nodePort),
+		"-m", "comment", "--comment", "Antrea: NodePortLocal",
+		"-j", iptables.DNATTarget, "--to-destination", net.JoinHostPort(podIP.String(), strconv.Itoa(podPort)),
+	}
+}
+
+// AddNodePortLocal adds the iptables rule which forwards the traffic to the provided port and protocol of the Node's
+// IPs to podIP:podPort. It does nothing if the rule already exists.
+func (c *Client) AddNodePortLocal(nodePort int, podIP net.IP, podPort int, protocol string) error {
+	return c.ipt.EnsureRule(iptables.NATTable, antreaNodePortLocalChain, nodePortLocalRuleSpec(nodePort, podIP, podPort, protocol))
+}
+
+// DeleteNodePortLocal removes the iptables rule added by AddNodePortLocal with the same arguments. It does nothing if
+// the rule doesn't exist.
+func (c *Client) DeleteNodePortLocal(nodePort int, podIP net.IP, podPort int, protocol string) error {
+	return c.ipt.DeleteRule(iptables.NATTable, antreaNodePortLocalChain, nodePortLocalRuleSpec(nodePort, podIP, podPort, protocol))
+}
+
+// DeleteEgressIP removes the configuration added by AddEgressIP for the provided egress IP. It does nothing if the
+// configuration doesn't exist.
+func (c *Client) DeleteEgressIP(egressIP net.IP) error {
+	route, neigh, proxyNeigh, err := c.egressIPConfig(egressIP)
+	if err != nil {
+		return err
+	}
+	if err := netlink.NeighDel(proxyNeigh); err != nil && err != unix.ENOENT {
+		return fmt.Errorf("failed to delete proxy neighbor for egress IP %s: %v", egressIP, err)
+	}
+	if err := netlink.RouteDel(route); err != nil && err != unix.ESRCH {
+		return fmt.Errorf("failed to delete route to egress IP %s: %v", egressIP, err)
+	}
+	c.egressRoutes.Delete(route.Dst.String())
+	if err := netlink.NeighDel(neigh); err != nil && err != unix.ENOENT {
+		return fmt.Errorf("failed to delete neighbor %v from gw %s: %v", neigh, c.nodeConfig.GatewayConfig.Name, err)
+	}
+	return nil
+}
+
+// ReconcileEgressIPs removes the configuration added by AddEgressIP for the egress IPs which are not in the provided
+// list, e.g. the ones left by a previous run of the Agent. The egress IPs are identified by their permanent neighbors
+// on gw0: AddEgressIP adds them first and DeleteEgressIP removes them last, so that the route and the proxy neighbor
+// of an egress IP never exist without them.
+func (c *Client) ReconcileEgressIPs(egressIPs []net.IP) error {
+	desiredEgressIPs := sets.NewString()
+	for _, ip := range egressIPs {
+		desiredEgressIPs.Insert(ip.String())
+	}
+	neighs, err := netlink.NeighList(c.nodeConfig.GatewayConfig.LinkIndex, netlink.FAMILY_V4)
+	if err != nil {
+		return fmt.Errorf("failed to list neighbors of gw %s: %v", c.nodeConfig.GatewayConfig.Name, err)
+	}
+	for _, neigh := range neighs {
+		if neigh.State != netlink.NUD_PERMANENT || neigh.HardwareAddr.String() != openflow.GlobalVirtualMAC.String() {
+			continue
+		}
+		if desiredEgressIPs.Has(neigh.IP.String()) {
+			continue
+		}
+		klog.V(4).Infof("Deleting orphaned egress IP %s", neigh.IP)
+		if err := c.DeleteEgressIP(neigh.IP); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// poolPodRoutesForIP returns the routes to a local Pod whose IP is allocated from an IPPool. The route is added to
+// the service route table too if it is not the main table, like the routes to the Pod CIDR of the Node.
+func (c *Client) poolPodRoutesForIP(podIP net.IP) []*netlink.Route {
+	podIPNet := &net.IPNet{IP: podIP, Mask: net.CIDRMask(32, 32)}
+	routes := []*netlink.Route{{
+		Dst:       podIPNet,
+		LinkIndex: c.nodeConfig.GatewayConfig.LinkIndex,
+		Scope:     netlink.SCOPE_LINK,
+	}}
+	if !c.serviceRtTable.IsMainTable() {
+		routes = append(routes, &netlink.Route{
+			Dst:       podIPNet,
+			LinkIndex: c.nodeConfig.GatewayConfig.LinkIndex,
+			Scope:     netlink.SCOPE_LINK,
+			Table:     c.serviceRtTable.Idx,
+		})
+	}
+	return routes
+}
+
+// AddPoolPodRoute routes the traffic to a local Pod whose IP is allocated from an IPPool to the host gateway, and
+// adds the IP to the ipset of the IPPool Pods so that the traffic from the Pod to external destinations is
+// masqueraded. It overrides the configuration if it already exists.
+func (c *Client) AddPoolPodRoute(podIP net.IP) error {
+	if podIP.To4() == nil {
+		return fmt.Errorf("IPPool Pod IP %s is not an IPv4 address", podIP)
+	}
+	routes := c.poolPodRoutesForIP(podIP)
+	for _, route := range routes {
+		if err := netlink.RouteReplace(route); err != nil {
+			return fmt.Errorf("failed to install route to IPPool Pod IP %s: %v", podIP, err)
+		}
+	}
+	c.poolPodRoutes.Store(routes[0].Dst.String(), routes)
+	if err := ipset.AddEntry(antreaPoolPodIPSet, podIP.String()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeletePoolPodRoute removes the configuration added by AddPoolPodRoute for the provided Pod IP. It does nothing if
+// the configuration doesn't exist.
+func (c *Client) DeletePoolPodRoute(podIP net.IP) error {
+	if podIP.To4() == nil {
+		return nil
+	}
+	if err := ipset.DelEntry(antreaPoolPodIPSet, podIP.String()); err != nil {
+		return err
+	}
+	routes := c.poolPodRoutesForIP(podIP)
+	for _, route := range routes {
+		if err := netlink.RouteDel(route); err != nil && err != unix.ESRCH {
+			return fmt.Errorf("failed to delete route to IPPool Pod IP %s: %v", podIP, err)
+		}
+	}
+	c.poolPodRoutes.Delete(routes[0].Dst.String())
+	return nil
+}
@@ -150,6 +150,31 @@ func (c *Client) UnMigrateRoutesFromGw(route *net.IPNet, linkName string) error
 	return errors.New("UnMigrateRoutesFromGw is unsupported on Windows")
 }
 
+// AddEgressIP is not supported on Windows.
+func (c *Client) AddEgressIP(egressIP net.IP) error {
+	return errors.New("AddEgressIP is unsupported on Windows")
+}
+
+// DeleteEgressIP is not supported on Windows.
+func (c *Client) DeleteEgressIP(egressIP net.IP) error {
+	return errors.New("DeleteEgressIP is unsupported on Windows")
+}
+
+// ReconcileEgressIPs is not supported on Windows.
+func (c *Client) ReconcileEgressIPs(egressIPs []net.IP) error {
+	return errors.New("ReconcileEgressIPs is unsupported on Windows")
+}
+
+// AddNodePortLocal is not supported on Windows.
+func (c *Client) AddNodePortLocal(nodePort int, podIP net.IP, podPort int, protocol string) error {
+	return errors.New("AddNodePortLocal is unsupported on Windows")
+}
+
+// DeleteNodePortLocal is not supported on Windows.
+func (c *Client) DeleteNodePortLocal(nodePort int, podIP net.IP, podPort int, protocol string) error {
+	return errors.New("DeleteNodePortLocal is unsupported on Windows")
+}
+
 func (c *Client) listRoutes() (map[string]*netroute.Route, error) {
 	routes, err := c.nr.GetNetRoutesAll()
 	if err != nil {
@@ -189,13 +214,23 @@ func (c *Client) listRoutes() (map[string]*netroute.Route, error) {
 
 // initFwRules adds Windows Firewall rules to accept the traffic that is sent to or from local Pods.
 func (c *Client) initFwRules() error {
-	err := c.fwClient.AddRuleAllowIP(inboundFirewallRuleName, winfirewall.FWRuleIn, c.nodeConfig.PodCIDR)
+	err := c.fwClient.AddRuleAllowIP(inboundFirewallRuleName, winfirewall.FWRuleIn, c.nodeConfig.PodIPv4CIDR)
 	if err != nil {
 		return err
 	}
-	err = c.fwClient.AddRuleAllowIP(outboundFirewallRuleName, winfirewall.FWRuleOut, c.nodeConfig.PodCIDR)
+	err = c.fwClient.AddRuleAllowIP(outboundFirewallRuleName, winfirewall.FWRuleOut, c.nodeConfig.PodIPv4CIDR)
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// AddPoolPodRoute is not supported on Windows.
+func (c *Client) AddPoolPodRoute(podIP net.IP) error {
+	return errors.New("AddPoolPodRoute is unsupported on Windows")
+}
+
+// DeletePoolPodRoute is not supported on Windows.
+func (c *Client) DeletePoolPodRoute(podIP net.IP) error {
+	return errors.New("DeletePoolPodRoute is unsupported on Windows")
+}
@@ -49,6 +49,48 @@ func (m *MockInterface) EXPECT() *MockInterfaceMockRecorder {
 	return m.recorder
 }
 
+// AddEgressIP mocks base method
+func (m *MockInterface) AddEgressIP(arg0 net.IP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEgressIP", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddEgressIP indicates an expected call of AddEgressIP
+func (mr *MockInterfaceMockRecorder) AddEgressIP(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEgressIP", reflect.TypeOf((*MockInterface)(nil).AddEgressIP), arg0)
+}
+
+// AddNodePortLocal mocks base method
+func (m *MockInterface) AddNodePortLocal(arg0 int, arg1 net.IP, arg2 int, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddNodePortLocal", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddNodePortLocal indicates an expected call of AddNodePortLocal
+func (mr *MockInterfaceMockRecorder) AddNodePortLocal(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNodePortLocal", reflect.TypeOf((*MockInterface)(nil).AddNodePortLocal), arg0, arg1, arg2, arg3)
+}
+
+// AddPoolPodRoute mocks base method
+func (m *MockInterface) AddPoolPodRoute(arg0 net.IP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPoolPodRoute", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPoolPodRoute indicates an expected call of AddPoolPodRoute
+func (mr *MockInterfaceMockRecorder) AddPoolPodRoute(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPoolPodRoute", reflect.TypeOf((*MockInterface)(nil).AddPoolPodRoute), arg0)
+}
+
 // AddRoutes mocks base method
 func (m *MockInterface) AddRoutes(arg0 *net.IPNet, arg1, arg2 net.IP) error {
 	m.ctrl.T.Helper()
@@ -63,6 +105,48 @@ func (mr *MockInterfaceMockRecorder) AddRoutes(arg0, arg1, arg2 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRoutes", reflect.TypeOf((*MockInterface)(nil).AddRoutes), arg0, arg1, arg2)
 }
 
+// DeleteEgressIP mocks base method
+func (m *MockInterface) DeleteEgressIP(arg0 net.IP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEgressIP", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEgressIP indicates an expected call of DeleteEgressIP
+func (mr *MockInterfaceMockRecorder) DeleteEgressIP(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEgressIP", reflect.TypeOf((*MockInterface)(nil).DeleteEgressIP), arg0)
+}
+
+// DeleteNodePortLocal mocks base method
+func (m *MockInterface) DeleteNodePortLocal(arg0 int, arg1 net.IP, arg2 int, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNodePortLocal", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNodePortLocal indicates an expected call of DeleteNodePortLocal
+func (mr *MockInterfaceMockRecorder) DeleteNodePortLocal(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNodePortLocal", reflect.TypeOf((*MockInterface)(nil).DeleteNodePortLocal), arg0, arg1, arg2, arg3)
+}
+
+// DeletePoolPodRoute mocks base method
+func (m *MockInterface) DeletePoolPodRoute(arg0 net.IP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePoolPodRoute", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePoolPodRoute indicates an expected call of DeletePoolPodRoute
+func (mr *MockInterfaceMockRecorder) DeletePoolPodRoute(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePoolPodRoute", reflect.TypeOf((*MockInterface)(nil).DeletePoolPodRoute), arg0)
+}
+
 // DeleteRoutes mocks base method
 func (m *MockInterface) DeleteRoutes(arg0 *net.IPNet) error {
 	m.ctrl.T.Helper()
@@ -119,6 +203,20 @@ func (mr *MockInterfaceMockRecorder) Reconcile(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockInterface)(nil).Reconcile), arg0)
 }
 
+// ReconcileEgressIPs mocks base method
+func (m *MockInterface) ReconcileEgressIPs(arg0 []net.IP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileEgressIPs", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileEgressIPs indicates an expected call of ReconcileEgressIPs
+func (mr *MockInterfaceMockRecorder) ReconcileEgressIPs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileEgressIPs", reflect.TypeOf((*MockInterface)(nil).ReconcileEgressIPs), arg0)
+}
+
 // UnMigrateRoutesFromGw mocks base method
 func (m *MockInterface) UnMigrateRoutesFromGw(arg0 *net.IPNet, arg1 string) error {
 	m.ctrl.T.Helper()
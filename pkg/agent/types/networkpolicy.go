@@ -16,6 +16,7 @@ package types
 
 import (
 	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
 )
 
 type AddressCategory uint8
@@ -45,4 +46,53 @@ type PolicyRule struct {
 	From      []Address
 	To        []Address
 	Service   []v1beta1.Service
+	// Action is the action of the rule. It's only set for ClusterNetworkPolicy rules.
+	Action *secv1alpha1.RuleAction
+	// Priority is the Openflow priority of the rule. It's only set for ClusterNetworkPolicy rules.
+	Priority *uint16
+	// EnableLogging indicates whether the packets matching the rule, and the packets dropped because the rule
+	// isolates its Pods, are sent to the agent to be logged.
+	EnableLogging bool
+}
+
+// IsAntreaNetworkPolicyRule returns true if the rule is created for a
+// ClusterNetworkPolicy, which is the case when its Priority is set.
+func (r *PolicyRule) IsAntreaNetworkPolicyRule() bool {
+	return r.Priority != nil
+}
+
+// Priority is a struct that is composed of the tier priority, the policy
+// priority and the rule priority of a ClusterNetworkPolicy rule. A lower value
+// of each field means a higher precedence, and the fields are compared in the
+// declared order.
+type Priority struct {
+	TierPriority   uint32
+	PolicyPriority float64
+	RulePriority   int32
+}
+
+// Less returns true if p has a lower precedence than p2, i.e. its Openflow
+// priority should be lower.
+func (p *Priority) Less(p2 Priority) bool {
+	if p.TierPriority != p2.TierPriority {
+		return p.TierPriority > p2.TierPriority
+	}
+	if p.PolicyPriority != p2.PolicyPriority {
+		return p.PolicyPriority > p2.PolicyPriority
+	}
+	return p.RulePriority > p2.RulePriority
+}
+
+// RuleMetric contains the traffic statistics of a NetworkPolicy rule. Sessions
+// is the number of connections allowed by the rule, or the number of packets
+// dropped by the rule if its action is Drop.
+type RuleMetric struct {
+	Bytes, Packets, Sessions uint64
+}
+
+// Merge adds the statistics of m1 to m.
+func (m *RuleMetric) Merge(m1 *RuleMetric) {
+	m.Bytes += m1.Bytes
+	m.Packets += m1.Packets
+	m.Sessions += m1.Sessions
 }
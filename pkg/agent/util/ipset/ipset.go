@@ -32,9 +32,14 @@ const (
 // memberPattern is used to match the members part of ipset list result.
 var memberPattern = regexp.MustCompile("(?m)^(.*\n)*Members:\n")
 
-// CreateIPSet creates a new set, it will ignore error when the set already exists.
-func CreateIPSet(name string, setType SetType) error {
-	cmd := exec.Command("ipset", "create", name, string(setType), "-exist")
+// CreateIPSet creates a new set, it will ignore error when the set already exists. The set stores IPv6
+// entries if isIPv6 is true, otherwise IPv4 entries.
+func CreateIPSet(name string, setType SetType, isIPv6 bool) error {
+	args := []string{"create", name, string(setType)}
+	if isIPv6 {
+		args = append(args, "family", "inet6")
+	}
+	cmd := exec.Command("ipset", append(args, "-exist")...)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("error creating ipset %s: %v", name, err)
 	}
@@ -36,10 +36,12 @@ const (
 	MasqueradeTarget = "MASQUERADE"
 	MarkTarget       = "MARK"
 	ConnTrackTarget  = "CT"
+	DNATTarget       = "DNAT"
 
 	PreRoutingChain  = "PREROUTING"
 	ForwardChain     = "FORWARD"
 	PostRoutingChain = "POSTROUTING"
+	OutputChain      = "OUTPUT"
 
 	waitSeconds              = 10
 	waitIntervalMicroSeconds = 200000
@@ -53,14 +55,28 @@ type Client struct {
 	ipt *iptables.IPTables
 	// restoreWaitSupported indicates whether iptables-restore supports --wait flag.
 	restoreWaitSupported bool
+	// restoreCmd and saveCmd are the commands used to restore and dump the rules, which differ between
+	// iptables and ip6tables.
+	restoreCmd string
+	saveCmd    string
 }
 
+// New returns a Client which manages the IPv4 rules with iptables.
 func New() (*Client, error) {
 	ipt, err := iptables.New()
 	if err != nil {
 		return nil, fmt.Errorf("error creating IPTables instance: %v", err)
 	}
-	return &Client{ipt: ipt, restoreWaitSupported: isRestoreWaitSupported(ipt)}, nil
+	return &Client{ipt: ipt, restoreWaitSupported: isRestoreWaitSupported(ipt), restoreCmd: "iptables-restore", saveCmd: "iptables-save"}, nil
+}
+
+// NewIPv6 returns a Client which manages the IPv6 rules with ip6tables.
+func NewIPv6() (*Client, error) {
+	ipt, err := iptables.NewWithProtocol(iptables.ProtocolIPv6)
+	if err != nil {
+		return nil, fmt.Errorf("error creating IP6Tables instance: %v", err)
+	}
+	return &Client{ipt: ipt, restoreWaitSupported: isRestoreWaitSupported(ipt), restoreCmd: "ip6tables-restore", saveCmd: "ip6tables-save"}, nil
 }
 
 func isRestoreWaitSupported(ipt *iptables.IPTables) bool {
@@ -101,7 +117,23 @@ func (c *Client) EnsureRule(table string, chain string, ruleSpec []string) error
 	return nil
 }
 
-// Restore calls iptable-restore to restore iptables with the provided content.
+// DeleteRule checks if target rule exists, deletes it if so.
+func (c *Client) DeleteRule(table string, chain string, ruleSpec []string) error {
+	exist, err := c.ipt.Exists(table, chain, ruleSpec...)
+	if err != nil {
+		return fmt.Errorf("error checking if rule %v exists in table %s chain %s: %v", ruleSpec, table, chain, err)
+	}
+	if !exist {
+		return nil
+	}
+	if err := c.ipt.Delete(table, chain, ruleSpec...); err != nil {
+		return fmt.Errorf("error deleting rule %v from table %s chain %s: %v", ruleSpec, table, chain, err)
+	}
+	klog.V(2).Infof("Deleted rule %v from table %s chain %s", ruleSpec, table, chain)
+	return nil
+}
+
+// Restore calls iptables-restore (or ip6tables-restore) to restore iptables with the provided content.
 // If flush is true, all previous contents of the respective tables will be flushed.
 // Otherwise only involved chains will be flushed.
 func (c *Client) Restore(data []byte, flush bool) error {
@@ -109,7 +141,7 @@ func (c *Client) Restore(data []byte, flush bool) error {
 	if !flush {
 		args = append(args, "--noflush")
 	}
-	cmd := exec.Command("iptables-restore", args...)
+	cmd := exec.Command(c.restoreCmd, args...)
 	cmd.Stdin = bytes.NewBuffer(data)
 	// We acquire xtables lock for iptables-restore to prevent it from conflicting
 	// with iptables/iptables-restore which might being called by kube-proxy.
@@ -129,14 +161,14 @@ func (c *Client) Restore(data []byte, flush bool) error {
 		defer unlockFunc()
 	}
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("error executing iptables-restore: %v", err)
+		return fmt.Errorf("error executing %s: %v", c.restoreCmd, err)
 	}
 	return nil
 }
 
-// Save calls iptables-saves to dump chains and tables in iptables.
+// Save calls iptables-save (or ip6tables-save) to dump chains and tables in iptables.
 func (c *Client) Save() ([]byte, error) {
-	return exec.Command("iptables-save", "-c").CombinedOutput()
+	return exec.Command(c.saveCmd, "-c").CombinedOutput()
 }
 
 func contains(chains []string, targetChain string) bool {
@@ -49,6 +49,12 @@ func GenerateContainerInterfaceKey(podName, podNamespace string) string {
 	return fmt.Sprintf("pod/%s/%s", podNamespace, podName)
 }
 
+// GenerateSecondaryInterfaceKey generates a unique string for a secondary
+// interface of a Pod as: pod/<Pod-Namespace-name>/<Pod-name>/<interface-name>.
+func GenerateSecondaryInterfaceKey(podName, podNamespace, ifName string) string {
+	return fmt.Sprintf("pod/%s/%s/%s", podNamespace, podName, ifName)
+}
+
 // GenerateNodeTunnelInterfaceKey generates a unique string for a Node's
 // tunnel interface as: node/<Node-name>.
 func GenerateNodeTunnelInterfaceKey(nodeName string) string {
@@ -64,6 +70,13 @@ func GenerateContainerInterfaceName(podName string, podNamespace string) string
 	return generateInterfaceName(GenerateContainerInterfaceKey(podNamespace, podName), podName, true)
 }
 
+// GenerateSecondaryInterfaceName generates a unique name for the host
+// interface of a secondary interface of a Pod, using the Pod's Namespace and
+// name and the name of the interface in the Pod.
+func GenerateSecondaryInterfaceName(podName, podNamespace, ifName string) string {
+	return generateInterfaceName(GenerateSecondaryInterfaceKey(podName, podNamespace, ifName), podName, true)
+}
+
 // GenerateNodeTunnelInterfaceName generates a unique interface name for the
 // tunnel to the Node, using the Node's name.
 func GenerateNodeTunnelInterfaceName(nodeName string) string {
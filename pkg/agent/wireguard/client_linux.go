@@ -0,0 +1,215 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package wireguard
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	"k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/config"
+)
+
+const linkType = "wireguard"
+
+type client struct {
+	k8sClient       clientset.Interface
+	nodeName        string
+	wireGuardConfig *config.WireGuardConfig
+	netlinkClient   *netlinkClient
+	// peersMutex protects peers, as the peers of different Nodes can be updated concurrently.
+	peersMutex sync.Mutex
+	// peers caches the configured peers, keyed by Node name.
+	peers map[string]*peer
+}
+
+var _ Interface = &client{}
+
+// New returns a WireGuard client managing the WireGuard device described by wireGuardConfig. The
+// link index of wireGuardConfig is set by Init.
+func New(k8sClient clientset.Interface, nodeConfig *config.NodeConfig, wireGuardConfig *config.WireGuardConfig) (Interface, error) {
+	netlinkClient, err := newNetlinkClient()
+	if err != nil {
+		return nil, err
+	}
+	return &client{
+		k8sClient:       k8sClient,
+		nodeName:        nodeConfig.Name,
+		wireGuardConfig: wireGuardConfig,
+		netlinkClient:   netlinkClient,
+		peers:           map[string]*peer{},
+	}, nil
+}
+
+func (c *client) Init() error {
+	name := c.wireGuardConfig.Name
+	link := &netlink.GenericLink{
+		LinkAttrs: netlink.LinkAttrs{Name: name, MTU: c.wireGuardConfig.MTU},
+		LinkType:  linkType,
+	}
+	if err := netlink.LinkAdd(link); err != nil && err != unix.EEXIST {
+		return fmt.Errorf("failed to create WireGuard device %s: %v", name, err)
+	}
+	existingLink, err := netlink.LinkByName(name)
+	if err != nil {
+		return fmt.Errorf("failed to get WireGuard device %s: %v", name, err)
+	}
+	if existingLink.Type() != linkType {
+		return fmt.Errorf("link %s exists but is not a WireGuard device", name)
+	}
+	// The MTU of an existing device may have been configured by a previous run with a different
+	// configuration.
+	if existingLink.Attrs().MTU != c.wireGuardConfig.MTU {
+		if err := netlink.LinkSetMTU(existingLink, c.wireGuardConfig.MTU); err != nil {
+			return fmt.Errorf("failed to set the MTU of WireGuard device %s: %v", name, err)
+		}
+	}
+
+	existingDevice, err := c.netlinkClient.getDevice(name)
+	if err != nil {
+		return err
+	}
+	// Reuse the private key of an existing device, so that the peers don't need to reconfigure
+	// this Node when the agent restarts.
+	privateKey := existingDevice.privateKey
+	if privateKey.IsZero() {
+		if privateKey, err = GeneratePrivateKey(); err != nil {
+			return err
+		}
+	}
+	if err := c.netlinkClient.configureDevice(name, &device{privateKey: privateKey, listenPort: c.wireGuardConfig.Port}); err != nil {
+		return err
+	}
+	if err := netlink.LinkSetUp(existingLink); err != nil {
+		return fmt.Errorf("failed to set WireGuard device %s up: %v", name, err)
+	}
+	c.wireGuardConfig.LinkIndex = existingLink.Attrs().Index
+
+	publicKey := privateKey.PublicKey().String()
+	patch, _ := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{NodeWireGuardPublicKeyAnnotationKey: publicKey},
+		},
+	})
+	if _, err := c.k8sClient.CoreV1().Nodes().Patch(c.nodeName, types.MergePatchType, patch); err != nil {
+		return fmt.Errorf("failed to update the %s annotation of Node %s: %v", NodeWireGuardPublicKeyAnnotationKey, c.nodeName, err)
+	}
+	klog.Infof("Initialized WireGuard device %s with public key %s", name, publicKey)
+	return nil
+}
+
+func (c *client) UpdatePeer(nodeName, publicKeyString string, peerNodeIP net.IP, podCIDRs []*net.IPNet) error {
+	publicKey, err := ParseKey(publicKeyString)
+	if err != nil {
+		return err
+	}
+	desiredPeer := &peer{
+		publicKey: publicKey,
+		endpoint:  &net.UDPAddr{IP: peerNodeIP, Port: c.wireGuardConfig.Port},
+	}
+	for _, podCIDR := range podCIDRs {
+		desiredPeer.allowedIPs = append(desiredPeer.allowedIPs, *podCIDR)
+	}
+
+	c.peersMutex.Lock()
+	defer c.peersMutex.Unlock()
+	existingPeer, exists := c.peers[nodeName]
+	if exists && peerEqual(existingPeer, desiredPeer) {
+		return nil
+	}
+	peers := []peer{*desiredPeer}
+	// The public key of the Node has changed, the peer with the previous key must be removed.
+	if exists && existingPeer.publicKey != publicKey {
+		peers = append(peers, peer{publicKey: existingPeer.publicKey, remove: true})
+	}
+	if err := c.netlinkClient.configureDevice(c.wireGuardConfig.Name, &device{peers: peers}); err != nil {
+		return fmt.Errorf("failed to configure the WireGuard peer for Node %s: %v", nodeName, err)
+	}
+	c.peers[nodeName] = desiredPeer
+	return nil
+}
+
+func (c *client) RemoveStalePeers(currentPeerPublicKeys map[string]string) error {
+	desiredPublicKeys := make(map[Key]bool, len(currentPeerPublicKeys))
+	for _, publicKeyString := range currentPeerPublicKeys {
+		publicKey, err := ParseKey(publicKeyString)
+		if err != nil {
+			klog.Errorf("Ignoring invalid WireGuard public key: %v", err)
+			continue
+		}
+		desiredPublicKeys[publicKey] = true
+	}
+
+	c.peersMutex.Lock()
+	defer c.peersMutex.Unlock()
+	existingDevice, err := c.netlinkClient.getDevice(c.wireGuardConfig.Name)
+	if err != nil {
+		return err
+	}
+	var stalePeers []peer
+	for _, p := range existingDevice.peers {
+		if !desiredPublicKeys[p.publicKey] {
+			klog.V(2).Infof("Removing stale WireGuard peer %s", p.publicKey)
+			stalePeers = append(stalePeers, peer{publicKey: p.publicKey, remove: true})
+		}
+	}
+	if len(stalePeers) > 0 {
+		if err := c.netlinkClient.configureDevice(c.wireGuardConfig.Name, &device{peers: stalePeers}); err != nil {
+			return fmt.Errorf("failed to remove stale WireGuard peers: %v", err)
+		}
+	}
+	for nodeName := range c.peers {
+		if _, ok := currentPeerPublicKeys[nodeName]; !ok {
+			delete(c.peers, nodeName)
+		}
+	}
+	return nil
+}
+
+func (c *client) DeletePeer(nodeName string) error {
+	c.peersMutex.Lock()
+	defer c.peersMutex.Unlock()
+	existingPeer, exists := c.peers[nodeName]
+	if !exists {
+		return nil
+	}
+	d := &device{peers: []peer{{publicKey: existingPeer.publicKey, remove: true}}}
+	if err := c.netlinkClient.configureDevice(c.wireGuardConfig.Name, d); err != nil {
+		return fmt.Errorf("failed to delete the WireGuard peer for Node %s: %v", nodeName, err)
+	}
+	delete(c.peers, nodeName)
+	return nil
+}
+
+func peerEqual(p1, p2 *peer) bool {
+	if p1.publicKey != p2.publicKey || p1.endpoint.String() != p2.endpoint.String() || len(p1.allowedIPs) != len(p2.allowedIPs) {
+		return false
+	}
+	for i := range p1.allowedIPs {
+		if p1.allowedIPs[i].String() != p2.allowedIPs[i].String() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard
+
+import (
+	"net"
+)
+
+const (
+	// DefaultDeviceName is the name of the WireGuard device created by Antrea Agent.
+	DefaultDeviceName = "antrea-wg0"
+	// NodeWireGuardPublicKeyAnnotationKey is the annotation of the Node storing the public key of
+	// the Node's WireGuard device, which is used by the other Nodes to configure it as a peer.
+	NodeWireGuardPublicKeyAnnotationKey = "node.antrea.tanzu.vmware.com/wireguard-public-key"
+)
+
+// Interface is the interface for managing the WireGuard device of the Node and its peers.
+type Interface interface {
+	// Init creates the WireGuard device if it doesn't exist and configures it. The private key of
+	// an existing device is reused, so that the peers don't need to be reconfigured when the
+	// agent restarts. The public key is published to the Node's annotation.
+	Init() error
+	// UpdatePeer adds or updates the peer for the provided Node: the traffic to the Pod CIDRs of
+	// the Node is encrypted with the public key and sent to the Node IP. It replaces the existing
+	// peer of the Node if the public key has changed.
+	UpdatePeer(nodeName, publicKeyString string, peerNodeIP net.IP, podCIDRs []*net.IPNet) error
+	// RemoveStalePeers removes the peers whose public keys are not in the provided map of Node
+	// names to public keys.
+	RemoveStalePeers(currentPeerPublicKeys map[string]string) error
+	// DeletePeer deletes the peer for the provided Node. It does nothing if the peer doesn't exist.
+	DeletePeer(nodeName string) error
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"golang.org/x/crypto/curve25519"
+)
+
+// KeyLen is the length of a WireGuard key.
+const KeyLen = 32
+
+// Key is a Curve25519 private or public key of a WireGuard device.
+type Key [KeyLen]byte
+
+// GeneratePrivateKey generates a new private key, clamped as specified by Curve25519.
+func GeneratePrivateKey() (Key, error) {
+	var key Key
+	if _, err := rand.Read(key[:]); err != nil {
+		return Key{}, fmt.Errorf("error generating private key: %v", err)
+	}
+	key[0] &= 248
+	key[31] &= 127
+	key[31] |= 64
+	return key, nil
+}
+
+// ParseKey parses a base64 encoded key.
+func ParseKey(s string) (Key, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return Key{}, fmt.Errorf("invalid key %q: %v", s, err)
+	}
+	if len(b) != KeyLen {
+		return Key{}, fmt.Errorf("invalid key %q: length is %d, expected %d", s, len(b), KeyLen)
+	}
+	var key Key
+	copy(key[:], b)
+	return key, nil
+}
+
+// PublicKey returns the public key corresponding to the private key.
+func (k Key) PublicKey() Key {
+	var pub Key
+	priv := [KeyLen]byte(k)
+	curve25519.ScalarBaseMult((*[KeyLen]byte)(&pub), &priv)
+	return pub
+}
+
+// IsZero returns whether the key is unset.
+func (k Key) IsZero() bool {
+	return k == Key{}
+}
+
+// String returns the base64 encoding of the key, as used by the wg tool and the Node annotation.
+func (k Key) String() string {
+	return base64.StdEncoding.EncodeToString(k[:])
+}
@@ -0,0 +1,52 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicKey(t *testing.T) {
+	// The test vector of Alice in RFC 7748, section 6.1.
+	privateKeyBytes, _ := hex.DecodeString("77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a")
+	publicKeyBytes, _ := hex.DecodeString("8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a")
+	privateKey, err := ParseKey(base64.StdEncoding.EncodeToString(privateKeyBytes))
+	require.NoError(t, err)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(publicKeyBytes), privateKey.PublicKey().String())
+}
+
+func TestGeneratePrivateKey(t *testing.T) {
+	key, err := GeneratePrivateKey()
+	require.NoError(t, err)
+	assert.False(t, key.IsZero())
+	assert.Zero(t, key[0]&7)
+	assert.Equal(t, byte(64), key[31]&192)
+
+	parsedKey, err := ParseKey(key.String())
+	require.NoError(t, err)
+	assert.Equal(t, key, parsedKey)
+}
+
+func TestParseInvalidKey(t *testing.T) {
+	for _, s := range []string{"", "invalid", base64.StdEncoding.EncodeToString(make([]byte, KeyLen-1))} {
+		_, err := ParseKey(s)
+		assert.Error(t, err, "Key %q should be invalid", s)
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package wireguard
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+)
+
+// The WireGuard generic netlink API, as defined in include/uapi/linux/wireguard.h.
+const (
+	genlFamilyName = "wireguard"
+	genlVersion    = 1
+
+	cmdGetDevice = 0
+	cmdSetDevice = 1
+
+	deviceAttrIfname     = 2
+	deviceAttrPrivateKey = 3
+	deviceAttrPublicKey  = 4
+	deviceAttrListenPort = 6
+	deviceAttrPeers      = 8
+
+	peerAttrPublicKey  = 1
+	peerAttrFlags      = 3
+	peerAttrEndpoint   = 4
+	peerAttrAllowedIPs = 9
+
+	peerFlagRemoveMe          = 1 << 0
+	peerFlagReplaceAllowedIPs = 1 << 1
+
+	allowedIPAttrFamily   = 1
+	allowedIPAttrIPAddr   = 2
+	allowedIPAttrCIDRMask = 3
+
+	// nlaTypeMask masks out the NLA_F_NESTED and NLA_F_NET_BYTEORDER flags of the attribute type.
+	nlaTypeMask = ^uint16(nl.NLA_F_NESTED | 1<<14)
+)
+
+// device is the configuration of a WireGuard device. When configuring a device, the zero values of
+// privateKey and listenPort leave the corresponding settings unchanged.
+type device struct {
+	privateKey Key
+	publicKey  Key
+	listenPort int
+	peers      []peer
+}
+
+// peer is the configuration of a peer of a WireGuard device. When configuring a device, the peer is
+// removed if remove is true, otherwise its endpoint is set if not nil, and its allowed IPs replace
+// the existing ones.
+type peer struct {
+	publicKey  Key
+	remove     bool
+	endpoint   *net.UDPAddr
+	allowedIPs []net.IPNet
+}
+
+// netlinkClient configures WireGuard devices with the generic netlink API of the kernel module.
+type netlinkClient struct {
+	familyID uint16
+}
+
+func newNetlinkClient() (*netlinkClient, error) {
+	family, err := netlink.GenlFamilyGet(genlFamilyName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the %s generic netlink family, is the WireGuard kernel module loaded? %v", genlFamilyName, err)
+	}
+	return &netlinkClient{familyID: family.ID}, nil
+}
+
+// getDevice returns the configuration of the WireGuard device with the provided name.
+func (c *netlinkClient) getDevice(name string) (*device, error) {
+	req := nl.NewNetlinkRequest(int(c.familyID), unix.NLM_F_DUMP)
+	req.AddData(&nl.Genlmsg{Command: cmdGetDevice, Version: genlVersion})
+	req.AddData(nl.NewRtAttr(deviceAttrIfname, nl.ZeroTerminated(name)))
+	msgs, err := req.Execute(unix.NETLINK_GENERIC, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error getting WireGuard device %s: %v", name, err)
+	}
+	return parseDevice(msgs)
+}
+
+// configureDevice applies the provided configuration to the WireGuard device with the provided name.
+func (c *netlinkClient) configureDevice(name string, d *device) error {
+	req := nl.NewNetlinkRequest(int(c.familyID), unix.NLM_F_ACK)
+	req.AddData(&nl.Genlmsg{Command: cmdSetDevice, Version: genlVersion})
+	for _, attr := range encodeDevice(name, d) {
+		req.AddData(attr)
+	}
+	if _, err := req.Execute(unix.NETLINK_GENERIC, 0); err != nil {
+		return fmt.Errorf("error configuring WireGuard device %s: %v", name, err)
+	}
+	return nil
+}
+
+// encodeDevice returns the netlink attributes of a WG_CMD_SET_DEVICE request.
+func encodeDevice(name string, d *device) []*nl.RtAttr {
+	attrs := []*nl.RtAttr{nl.NewRtAttr(deviceAttrIfname, nl.ZeroTerminated(name))}
+	if !d.privateKey.IsZero() {
+		attrs = append(attrs, nl.NewRtAttr(deviceAttrPrivateKey, d.privateKey[:]))
+	}
+	if d.listenPort != 0 {
+		attrs = append(attrs, nl.NewRtAttr(deviceAttrListenPort, nl.Uint16Attr(uint16(d.listenPort))))
+	}
+	if len(d.peers) == 0 {
+		return attrs
+	}
+	peersAttr := nl.NewRtAttr(deviceAttrPeers|nl.NLA_F_NESTED, nil)
+	for i := range d.peers {
+		p := &d.peers[i]
+		peerAttr := peersAttr.AddRtAttr(i|nl.NLA_F_NESTED, nil)
+		peerAttr.AddRtAttr(peerAttrPublicKey, p.publicKey[:])
+		if p.remove {
+			peerAttr.AddRtAttr(peerAttrFlags, nl.Uint32Attr(peerFlagRemoveMe))
+			continue
+		}
+		peerAttr.AddRtAttr(peerAttrFlags, nl.Uint32Attr(peerFlagReplaceAllowedIPs))
+		if p.endpoint != nil {
+			peerAttr.AddRtAttr(peerAttrEndpoint, encodeSockaddr(p.endpoint))
+		}
+		allowedIPsAttr := peerAttr.AddRtAttr(peerAttrAllowedIPs|nl.NLA_F_NESTED, nil)
+		for j, ipNet := range p.allowedIPs {
+			family, ip := uint16(unix.AF_INET6), ipNet.IP.To16()
+			if ipv4 := ipNet.IP.To4(); ipv4 != nil {
+				family, ip = unix.AF_INET, ipv4
+			}
+			ones, _ := ipNet.Mask.Size()
+			allowedIPAttr := allowedIPsAttr.AddRtAttr(j|nl.NLA_F_NESTED, nil)
+			allowedIPAttr.AddRtAttr(allowedIPAttrFamily, nl.Uint16Attr(family))
+			allowedIPAttr.AddRtAttr(allowedIPAttrIPAddr, ip)
+			allowedIPAttr.AddRtAttr(allowedIPAttrCIDRMask, nl.Uint8Attr(uint8(ones)))
+		}
+	}
+	return append(attrs, peersAttr)
+}
+
+// encodeSockaddr encodes the UDP address as a struct sockaddr_in or sockaddr_in6.
+func encodeSockaddr(addr *net.UDPAddr) []byte {
+	if ipv4 := addr.IP.To4(); ipv4 != nil {
+		b := make([]byte, unix.SizeofSockaddrInet4)
+		nl.NativeEndian().PutUint16(b[0:2], unix.AF_INET)
+		binary.BigEndian.PutUint16(b[2:4], uint16(addr.Port))
+		copy(b[4:8], ipv4)
+		return b
+	}
+	b := make([]byte, unix.SizeofSockaddrInet6)
+	nl.NativeEndian().PutUint16(b[0:2], unix.AF_INET6)
+	binary.BigEndian.PutUint16(b[2:4], uint16(addr.Port))
+	copy(b[8:24], addr.IP.To16())
+	return b
+}
+
+// decodeSockaddr decodes a struct sockaddr_in or sockaddr_in6.
+func decodeSockaddr(b []byte) (*net.UDPAddr, error) {
+	if len(b) < 4 {
+		return nil, fmt.Errorf("invalid sockaddr length %d", len(b))
+	}
+	port := int(binary.BigEndian.Uint16(b[2:4]))
+	switch nl.NativeEndian().Uint16(b[0:2]) {
+	case unix.AF_INET:
+		if len(b) < unix.SizeofSockaddrInet4 {
+			return nil, fmt.Errorf("invalid sockaddr_in length %d", len(b))
+		}
+		return &net.UDPAddr{IP: net.IP(append([]byte{}, b[4:8]...)), Port: port}, nil
+	case unix.AF_INET6:
+		if len(b) < unix.SizeofSockaddrInet6 {
+			return nil, fmt.Errorf("invalid sockaddr_in6 length %d", len(b))
+		}
+		return &net.UDPAddr{IP: net.IP(append([]byte{}, b[8:24]...)), Port: port}, nil
+	}
+	return nil, fmt.Errorf("unsupported sockaddr family %d", nl.NativeEndian().Uint16(b[0:2]))
+}
+
+// parseDevice parses the messages of a WG_CMD_GET_DEVICE response. The peers of a device may be
+// split across multiple messages.
+func parseDevice(msgs [][]byte) (*device, error) {
+	d := &device{}
+	for _, msg := range msgs {
+		if len(msg) < nl.SizeofGenlmsg {
+			return nil, fmt.Errorf("invalid generic netlink message length %d", len(msg))
+		}
+		attrs, err := nl.ParseRouteAttr(msg[nl.SizeofGenlmsg:])
+		if err != nil {
+			return nil, err
+		}
+		for _, attr := range attrs {
+			switch attr.Attr.Type & nlaTypeMask {
+			case deviceAttrPrivateKey:
+				copy(d.privateKey[:], attr.Value)
+			case deviceAttrPublicKey:
+				copy(d.publicKey[:], attr.Value)
+			case deviceAttrListenPort:
+				d.listenPort = int(nl.NativeEndian().Uint16(attr.Value))
+			case deviceAttrPeers:
+				if err := parsePeers(d, attr.Value); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return d, nil
+}
+
+func parsePeers(d *device, b []byte) error {
+	peerAttrs, err := nl.ParseRouteAttr(b)
+	if err != nil {
+		return err
+	}
+	for _, peerAttr := range peerAttrs {
+		attrs, err := nl.ParseRouteAttr(peerAttr.Value)
+		if err != nil {
+			return err
+		}
+		var p peer
+		for _, attr := range attrs {
+			switch attr.Attr.Type & nlaTypeMask {
+			case peerAttrPublicKey:
+				copy(p.publicKey[:], attr.Value)
+			case peerAttrEndpoint:
+				if p.endpoint, err = decodeSockaddr(attr.Value); err != nil {
+					return err
+				}
+			case peerAttrAllowedIPs:
+				if p.allowedIPs, err = parseAllowedIPs(attr.Value); err != nil {
+					return err
+				}
+			}
+		}
+		// A peer with many allowed IPs may be continued in the next message, in which case
+		// its public key is repeated.
+		if n := len(d.peers); n > 0 && d.peers[n-1].publicKey == p.publicKey {
+			d.peers[n-1].allowedIPs = append(d.peers[n-1].allowedIPs, p.allowedIPs...)
+			continue
+		}
+		d.peers = append(d.peers, p)
+	}
+	return nil
+}
+
+func parseAllowedIPs(b []byte) ([]net.IPNet, error) {
+	allowedIPAttrs, err := nl.ParseRouteAttr(b)
+	if err != nil {
+		return nil, err
+	}
+	var allowedIPs []net.IPNet
+	for _, allowedIPAttr := range allowedIPAttrs {
+		attrs, err := nl.ParseRouteAttr(allowedIPAttr.Value)
+		if err != nil {
+			return nil, err
+		}
+		var family uint16
+		var ip net.IP
+		var ones int
+		for _, attr := range attrs {
+			switch attr.Attr.Type & nlaTypeMask {
+			case allowedIPAttrFamily:
+				family = nl.NativeEndian().Uint16(attr.Value)
+			case allowedIPAttrIPAddr:
+				ip = net.IP(append([]byte{}, attr.Value...))
+			case allowedIPAttrCIDRMask:
+				ones = int(attr.Value[0])
+			}
+		}
+		bits := 8 * net.IPv6len
+		if family == unix.AF_INET {
+			bits = 8 * net.IPv4len
+		}
+		allowedIPs = append(allowedIPs, net.IPNet{IP: ip, Mask: net.CIDRMask(ones, bits)})
+	}
+	return allowedIPs, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package wireguard
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink/nl"
+)
+
+func mustParseCIDR(s string) net.IPNet {
+	_, ipNet, _ := net.ParseCIDR(s)
+	return *ipNet
+}
+
+// serializeDevice returns the payload of a generic netlink message carrying the attributes of the
+// device, as received in a WG_CMD_GET_DEVICE response.
+func serializeDevice(d *device) []byte {
+	msg := (&nl.Genlmsg{Command: cmdGetDevice, Version: genlVersion}).Serialize()
+	for _, attr := range encodeDevice(DefaultDeviceName, d) {
+		msg = append(msg, attr.Serialize()...)
+	}
+	return msg
+}
+
+func TestEncodeAndParseDevice(t *testing.T) {
+	privateKey, err := GeneratePrivateKey()
+	require.NoError(t, err)
+	peer1Key, _ := GeneratePrivateKey()
+	peer2Key, _ := GeneratePrivateKey()
+	d := &device{
+		privateKey: privateKey,
+		listenPort: 51820,
+		peers: []peer{
+			{
+				publicKey:  peer1Key.PublicKey(),
+				endpoint:   &net.UDPAddr{IP: net.ParseIP("192.168.1.2").To4(), Port: 51820},
+				allowedIPs: []net.IPNet{mustParseCIDR("10.10.1.0/24"), mustParseCIDR("fd00:10:10:1::/64")},
+			},
+			{
+				publicKey:  peer2Key.PublicKey(),
+				endpoint:   &net.UDPAddr{IP: net.ParseIP("fd00::3"), Port: 51821},
+				allowedIPs: []net.IPNet{mustParseCIDR("10.10.2.0/24")},
+			},
+		},
+	}
+
+	parsedDevice, err := parseDevice([][]byte{serializeDevice(d)})
+	require.NoError(t, err)
+	assert.Equal(t, d, parsedDevice)
+}
+
+func TestParseDeviceAcrossMessages(t *testing.T) {
+	peerKey, _ := GeneratePrivateKey()
+	// The allowed IPs of a peer are continued in a second message, which repeats the public key.
+	msg1 := serializeDevice(&device{
+		listenPort: 51820,
+		peers:      []peer{{publicKey: peerKey.PublicKey(), allowedIPs: []net.IPNet{mustParseCIDR("10.10.1.0/24")}}},
+	})
+	msg2 := serializeDevice(&device{
+		peers: []peer{{publicKey: peerKey.PublicKey(), allowedIPs: []net.IPNet{mustParseCIDR("fd00:10:10:1::/64")}}},
+	})
+
+	parsedDevice, err := parseDevice([][]byte{msg1, msg2})
+	require.NoError(t, err)
+	assert.Equal(t, 51820, parsedDevice.listenPort)
+	require.Len(t, parsedDevice.peers, 1)
+	assert.Equal(t, []net.IPNet{mustParseCIDR("10.10.1.0/24"), mustParseCIDR("fd00:10:10:1::/64")}, parsedDevice.peers[0].allowedIPs)
+}
+
+func TestEncodeRemovedPeer(t *testing.T) {
+	peerKey, _ := GeneratePrivateKey()
+	attrs := encodeDevice(DefaultDeviceName, &device{peers: []peer{{publicKey: peerKey.PublicKey(), remove: true}}})
+	require.Len(t, attrs, 2)
+	peerAttrs, err := nl.ParseRouteAttr(attrs[1].Serialize()[4:])
+	require.NoError(t, err)
+	require.Len(t, peerAttrs, 1)
+	peerAttrValues, err := nl.ParseRouteAttr(peerAttrs[0].Value)
+	require.NoError(t, err)
+	values := map[uint16][]byte{}
+	for _, attr := range peerAttrValues {
+		values[attr.Attr.Type] = attr.Value
+	}
+	assert.Equal(t, nl.Uint32Attr(peerFlagRemoveMe), values[peerAttrFlags])
+	assert.NotContains(t, values, uint16(peerAttrAllowedIPs|nl.NLA_F_NESTED))
+}
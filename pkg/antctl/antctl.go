@@ -16,9 +16,16 @@ package antctl
 
 import (
 	"fmt"
+	"net"
 	"reflect"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/vmware-tanzu/antrea/pkg/agent/apiserver/handlers/agentinfo"
+	"github.com/vmware-tanzu/antrea/pkg/agent/apiserver/handlers/conntrack"
 	"github.com/vmware-tanzu/antrea/pkg/agent/apiserver/handlers/ovsflows"
 	"github.com/vmware-tanzu/antrea/pkg/agent/apiserver/handlers/ovstracing"
 	"github.com/vmware-tanzu/antrea/pkg/agent/apiserver/handlers/podinterface"
@@ -28,6 +35,7 @@ import (
 	"github.com/vmware-tanzu/antrea/pkg/antctl/transform/appliedtogroup"
 	"github.com/vmware-tanzu/antrea/pkg/antctl/transform/controllerinfo"
 	"github.com/vmware-tanzu/antrea/pkg/antctl/transform/networkpolicy"
+	"github.com/vmware-tanzu/antrea/pkg/antctl/transform/networkpolicystats"
 	"github.com/vmware-tanzu/antrea/pkg/antctl/transform/version"
 	networkingv1beta1 "github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
 	systemv1beta1 "github.com/vmware-tanzu/antrea/pkg/apis/system/v1beta1"
@@ -160,6 +168,81 @@ var CommandList = &commandList{
 			},
 			transformedResponse: reflect.TypeOf(addressgroup.Response{}),
 		},
+		{
+			use:     "networkpolicystats",
+			aliases: []string{"netpolstats"},
+			short:   "Print NetworkPolicy traffic statistics",
+			long:    "Print the traffic statistics of NetworkPolicies aggregated from all Nodes. It requires the NetworkPolicyStats feature to be enabled.",
+			example: `  Get the traffic statistics of a specific NetworkPolicy
+  $ antctl get networkpolicystats np1 -n ns1
+  Get the traffic statistics of the NetworkPolicies in a Namespace
+  $ antctl get networkpolicystats -n ns1
+  Get the traffic statistics of all NetworkPolicies
+  $ antctl get networkpolicystats`,
+			commandGroup: get,
+			controllerEndpoint: &endpoint{
+				resourceEndpoint: &resourceEndpoint{
+					groupVersionResource: &networkingv1beta1.NetworkPolicyStatsVersionResource,
+					namespaced:           true,
+				},
+				addonTransform: networkpolicystats.Transform,
+			},
+			transformedResponse: reflect.TypeOf(networkpolicystats.Response{}),
+		},
+		{
+			use:   "endpoint",
+			short: "Print the NetworkPolicies affecting a Pod",
+			long:  "Print the NetworkPolicies applied to a Pod and the rules of NetworkPolicies which select the Pod as a peer. 'namespace' defaults to 'default'.",
+			example: `  Get the NetworkPolicies affecting a specific Pod
+  $ antctl query endpoint pod1 -n ns1`,
+			commandGroup: query,
+			controllerEndpoint: &endpoint{
+				resourceEndpoint: &resourceEndpoint{
+					groupVersionResource: &networkingv1beta1.EndpointQueryVersionResource,
+					namespaced:           true,
+					defaultNamespace:     metav1.NamespaceDefault,
+				},
+			},
+			transformedResponse: reflect.TypeOf(networkingv1beta1.EndpointQuery{}),
+		},
+		{
+			use:   "connectivity",
+			short: "Evaluate whether traffic is allowed by NetworkPolicies",
+			long:  "Evaluate whether the traffic from a source to a destination is allowed by the egress NetworkPolicies of the source and the ingress NetworkPolicies of the destination, and print the rules deciding it.",
+			example: `  Evaluate whether Pod ns1/pod1 can reach port 80 of Pod ns2/pod2 over TCP
+  $ antctl query connectivity -S ns1/pod1 -D ns2/pod2 --port 80
+  Evaluate whether Pod ns1/pod1 can reach port 53 of IP 10.96.0.10 over UDP
+  $ antctl query connectivity -S ns1/pod1 -D 10.96.0.10 --protocol UDP --port 53`,
+			commandGroup: query,
+			controllerEndpoint: &endpoint{
+				resourceEndpoint: &resourceEndpoint{
+					groupVersionResource: &networkingv1beta1.ConnectivityQueryVersionResource,
+					params: []flagInfo{
+						{
+							name:      "source",
+							shorthand: "S",
+							usage:     "Source of the traffic, a Pod in the form of [namespace/]name or an IP address",
+						},
+						{
+							name:      "destination",
+							shorthand: "D",
+							usage:     "Destination of the traffic, a Pod in the form of [namespace/]name or an IP address",
+						},
+						{
+							name:         "protocol",
+							defaultValue: string(networkingv1beta1.ProtocolTCP),
+							usage:        "Protocol of the traffic: TCP|UDP|SCTP",
+						},
+						{
+							name:  "port",
+							usage: "Destination port of the traffic. If not set, only the rules matching all ports are considered",
+						},
+					},
+					requestBody: newConnectivityQuery,
+				},
+			},
+			transformedResponse: reflect.TypeOf(networkingv1beta1.ConnectivityQuery{}),
+		},
 		{
 			use:     "controllerinfo",
 			aliases: []string{"controllerinfos", "ci"},
@@ -268,6 +351,30 @@ var CommandList = &commandList{
 			commandGroup:        get,
 			transformedResponse: reflect.TypeOf(ovsflows.Response{}),
 		},
+		{
+			use:   "conntrack",
+			short: "Dump the connections tracked by conntrack",
+			long:  "Dump the connections of the conntrack zone used by Antrea, or only the connections from or to the specified local Pod.",
+			example: `  Dump all the connections tracked by Antrea
+  $ antctl get conntrack
+  Dump the connections of a local Pod
+  $ antctl get conntrack -p ns1/pod1`,
+			agentEndpoint: &endpoint{
+				nonResourceEndpoint: &nonResourceEndpoint{
+					path: "/conntrack",
+					params: []flagInfo{
+						{
+							name:      "pod",
+							usage:     "Local Pod (specified by <Namespace>/<name>) whose connections are dumped.",
+							shorthand: "p",
+						},
+					},
+					outputType: multiple,
+				},
+			},
+			commandGroup:        get,
+			transformedResponse: reflect.TypeOf(conntrack.Response{}),
+		},
 		{
 			use:   "trace-packet",
 			short: "OVS packet tracing",
@@ -328,6 +435,42 @@ var CommandList = &commandList{
 	codec: scheme.Codecs,
 }
 
+// newConnectivityQuery builds a ConnectivityQuery from the arguments of the
+// "query connectivity" command.
+func newConnectivityQuery(args map[string]string) (k8sruntime.Object, error) {
+	if args["source"] == "" || args["destination"] == "" {
+		return nil, fmt.Errorf("both source and destination must be provided")
+	}
+	query := &networkingv1beta1.ConnectivityQuery{
+		Spec: networkingv1beta1.ConnectivityQuerySpec{
+			Source:      newConnectivityQueryPeer(args["source"]),
+			Destination: newConnectivityQueryPeer(args["destination"]),
+			Protocol:    networkingv1beta1.Protocol(strings.ToUpper(args["protocol"])),
+		},
+	}
+	if portStr, ok := args["port"]; ok {
+		port, err := strconv.ParseUint(portStr, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %s", portStr)
+		}
+		query.Spec.Port = int32(port)
+	}
+	return query, nil
+}
+
+// newConnectivityQueryPeer parses a peer of the "query connectivity" command,
+// which is either an IP address or a Pod in the form of [namespace/]name.
+func newConnectivityQueryPeer(peer string) networkingv1beta1.ConnectivityQueryPeer {
+	if net.ParseIP(peer) != nil {
+		return networkingv1beta1.ConnectivityQueryPeer{IP: peer}
+	}
+	namespace, name := metav1.NamespaceDefault, peer
+	if parts := strings.SplitN(peer, "/", 2); len(parts) == 2 {
+		namespace, name = parts[0], parts[1]
+	}
+	return networkingv1beta1.ConnectivityQueryPeer{Pod: &networkingv1beta1.PodReference{Namespace: namespace, Name: name}}
+}
+
 func generateFlowTableHelpMsg() string {
 	msg := ""
 	for _, t := range openflow.FlowTables {
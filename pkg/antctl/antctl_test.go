@@ -18,6 +18,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	networkingv1beta1 "github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
 )
 
 // TestCommandListValidation ensures the command list is valid.
@@ -25,3 +28,53 @@ func TestCommandListValidation(t *testing.T) {
 	errs := CommandList.validate()
 	assert.Len(t, errs, 0)
 }
+
+func TestNewConnectivityQuery(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          map[string]string
+		expectedSpec  networkingv1beta1.ConnectivityQuerySpec
+		expectedError bool
+	}{
+		{
+			name: "Pod to Pod",
+			args: map[string]string{"source": "ns1/pod1", "destination": "pod2", "protocol": "tcp", "port": "80"},
+			expectedSpec: networkingv1beta1.ConnectivityQuerySpec{
+				Source:      networkingv1beta1.ConnectivityQueryPeer{Pod: &networkingv1beta1.PodReference{Namespace: "ns1", Name: "pod1"}},
+				Destination: networkingv1beta1.ConnectivityQueryPeer{Pod: &networkingv1beta1.PodReference{Namespace: "default", Name: "pod2"}},
+				Protocol:    networkingv1beta1.ProtocolTCP,
+				Port:        80,
+			},
+		},
+		{
+			name: "Pod to IP",
+			args: map[string]string{"source": "ns1/pod1", "destination": "10.96.0.10", "protocol": "UDP"},
+			expectedSpec: networkingv1beta1.ConnectivityQuerySpec{
+				Source:      networkingv1beta1.ConnectivityQueryPeer{Pod: &networkingv1beta1.PodReference{Namespace: "ns1", Name: "pod1"}},
+				Destination: networkingv1beta1.ConnectivityQueryPeer{IP: "10.96.0.10"},
+				Protocol:    networkingv1beta1.ProtocolUDP,
+			},
+		},
+		{
+			name:          "missing destination",
+			args:          map[string]string{"source": "ns1/pod1"},
+			expectedError: true,
+		},
+		{
+			name:          "invalid port",
+			args:          map[string]string{"source": "ns1/pod1", "destination": "pod2", "port": "http"},
+			expectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := newConnectivityQuery(tt.args)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSpec, obj.(*networkingv1beta1.ConnectivityQuery).Spec)
+		})
+	}
+}
@@ -143,6 +143,26 @@ func (c *client) resourceRequest(e *resourceEndpoint, opt *requestOption) (io.Re
 	// If timeout is zero, there will be no timeout.
 	restClient.Client.Timeout = opt.timeout
 
+	if e.requestBody != nil {
+		body, err := e.requestBody(opt.args)
+		if err != nil {
+			return nil, err
+		}
+		result := restClient.Post().
+			NamespaceIfScoped(opt.args["namespace"], e.namespaced).
+			Resource(e.groupVersionResource.Resource).
+			Body(body).
+			Do()
+		if result.Error() != nil {
+			return nil, generateMessage(opt, result)
+		}
+		raw, err := result.Raw()
+		if err != nil {
+			return nil, err
+		}
+		return bytes.NewReader(raw), nil
+	}
+
 	resGetter := restClient.Get().
 		NamespaceIfScoped(opt.args["namespace"], e.namespaced).
 		Resource(e.groupVersionResource.Resource)
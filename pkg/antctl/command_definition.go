@@ -28,6 +28,7 @@ import (
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog"
 
@@ -72,6 +73,7 @@ const (
 const (
 	flat commandGroup = iota
 	get
+	query
 )
 
 var groupCommands = map[commandGroup]*cobra.Command{
@@ -80,6 +82,11 @@ var groupCommands = map[commandGroup]*cobra.Command{
 		Short: "Get the status or resource of a topic",
 		Long:  "Get the status or resource of a topic",
 	},
+	query: {
+		Use:   "query",
+		Short: "Execute a user-provided query",
+		Long:  "Execute a user-provided query",
+	},
 }
 
 type endpointResponder interface {
@@ -91,10 +98,20 @@ type resourceEndpoint struct {
 	groupVersionResource *schema.GroupVersionResource
 	resourceName         string
 	namespaced           bool
+	// defaultNamespace is the default value of the namespace flag. If not
+	// set, the resources of all Namespaces are retrieved.
+	defaultNamespace string
+	// params are the flags of the command besides name and namespace. They
+	// are passed as parameters of get requests.
+	params []flagInfo
+	// requestBody, if set, makes the command create the resource with the
+	// object it returns from the command arguments, instead of getting it.
+	// It is used for the resources which answer a query when created.
+	requestBody func(args map[string]string) (k8sruntime.Object, error)
 }
 
 func (e *resourceEndpoint) OutputType() OutputType {
-	if len(e.resourceName) != 0 {
+	if len(e.resourceName) != 0 || e.requestBody != nil {
 		return single
 	}
 	return defaultType
@@ -102,7 +119,7 @@ func (e *resourceEndpoint) OutputType() OutputType {
 
 func (e *resourceEndpoint) flags() []flagInfo {
 	var flags []flagInfo
-	if len(e.resourceName) == 0 {
+	if len(e.resourceName) == 0 && e.requestBody == nil {
 		flags = append(flags, flagInfo{
 			name:         "name",
 			defaultValue: "",
@@ -111,14 +128,18 @@ func (e *resourceEndpoint) flags() []flagInfo {
 		})
 	}
 	if e.namespaced {
+		namespace := metav1.NamespaceAll
+		if len(e.defaultNamespace) != 0 {
+			namespace = e.defaultNamespace
+		}
 		flags = append(flags, flagInfo{
 			name:         "namespace",
 			shorthand:    "n",
-			defaultValue: metav1.NamespaceAll,
+			defaultValue: namespace,
 			usage:        "Filter the resource by namespace",
 		})
 	}
-	return flags
+	return append(flags, e.params...)
 }
 
 type nonResourceEndpoint struct {
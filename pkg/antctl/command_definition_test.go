@@ -158,6 +158,10 @@ foo2
 							Services:  nil,
 						},
 					},
+					Status: &networkingv1beta1.NetworkPolicyRealizationStatus{
+						CurrentNodesRealized: 1,
+						DesiredNodesRealized: 2,
+					},
 				},
 				{
 					Rules: []rule.Response{
@@ -175,9 +179,9 @@ foo2
 					NameSpace:       "Namespace2",
 				},
 			},
-			expected: `NAMESPACE  NAME       APPLIED-TO                                       RULES
-Namespace1 GroupName2 32ef631b-6817-5a18-86eb-93f4abf0467c + 1 more... 1    
-Namespace2 GroupName1 32ef631b-6817-5a18-86eb-93f4abf0467c             2    
+			expected: `NAMESPACE  NAME       APPLIED-TO                                       RULES REALIZED
+Namespace1 GroupName2 32ef631b-6817-5a18-86eb-93f4abf0467c + 1 more... 1     1/2     
+Namespace2 GroupName1 32ef631b-6817-5a18-86eb-93f4abf0467c             2     <NONE>  
 `,
 		},
 		{
@@ -241,7 +245,7 @@ GroupName <NONE>
 					PodName:       "nginx-6db489d4b7-vgv7v",
 					PodNamespace:  "default",
 					InterfaceName: "Interface",
-					IP:            "127.0.0.1",
+					IPs:           []string{"127.0.0.1"},
 					MAC:           "07-16-76-00-02-86",
 					PortUUID:      "portuuid0",
 					OFPort:        80,
@@ -251,7 +255,7 @@ GroupName <NONE>
 					PodName:       "nginx-32b489d4b7-vgv7v",
 					PodNamespace:  "default",
 					InterfaceName: "Interface2",
-					IP:            "127.0.0.2",
+					IPs:           []string{"127.0.0.2"},
 					MAC:           "07-16-76-00-02-87",
 					PortUUID:      "portuuid1",
 					OFPort:        35572,
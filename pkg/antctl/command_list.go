@@ -103,11 +103,15 @@ func (cl *commandList) validate() []error {
 }
 
 // GetDebugCommands returns all commands supported by Controller or Agent that
-// are used for debugging purpose.
+// are used for debugging purpose. The commands of the query group are excluded
+// as they cannot run without user-provided arguments.
 func (cl *commandList) GetDebugCommands(mode string) [][]string {
 	var allCommands [][]string
 	for i := range cl.definitions {
 		def := cl.definitions[i]
+		if def.commandGroup == query {
+			continue
+		}
 		if mode == runtime.ModeAgent && def.agentEndpoint != nil ||
 			mode == runtime.ModeController && def.controllerEndpoint != nil {
 			var currentCommand []string
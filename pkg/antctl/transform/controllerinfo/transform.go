@@ -36,6 +36,7 @@ type Response struct {
 	NetworkPolicyControllerInfo clusterinfo.NetworkPolicyControllerInfo `json:"networkPolicyControllerInfo,omitempty"` // Antrea Controller NetworkPolicy information
 	ConnectedAgentNum           int32                                   `json:"connectedAgentNum,omitempty"`           // Number of agents which are connected to this controller
 	ControllerConditions        []clusterinfo.ControllerCondition       `json:"controllerConditions,omitempty"`        // Controller condition contains types like ControllerHealthy
+	Leader                      string                                  `json:"leader,omitempty"`                      // The name of the Pod of the leader Antrea Controller replica
 }
 
 func Transform(reader io.Reader, _ bool) (interface{}, error) {
@@ -57,6 +58,7 @@ func Transform(reader io.Reader, _ bool) (interface{}, error) {
 		NetworkPolicyControllerInfo: controllerInfo.NetworkPolicyControllerInfo,
 		ConnectedAgentNum:           controllerInfo.ConnectedAgentNum,
 		ControllerConditions:        controllerInfo.ControllerConditions,
+		Leader:                      controllerInfo.Leader,
 	}
 	return resp, nil
 }
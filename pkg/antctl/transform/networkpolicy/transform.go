@@ -15,6 +15,7 @@
 package networkpolicy
 
 import (
+	"fmt"
 	"io"
 	"reflect"
 	"strconv"
@@ -30,6 +31,8 @@ type Response struct {
 	Name            string          `json:"name" yaml:"name"`
 	Rules           []rule.Response `json:"rules" yaml:"rules"`
 	AppliedToGroups []string        `json:"appliedToGroups" yaml:"appliedToGroups"`
+	// Status is only set in the responses of antrea-controller.
+	Status *networkingv1beta1.NetworkPolicyRealizationStatus `json:"status,omitempty" yaml:"status,omitempty"`
 }
 
 func objectTransform(o interface{}) (interface{}, error) {
@@ -43,6 +46,7 @@ func objectTransform(o interface{}) (interface{}, error) {
 		Name:            policy.Name,
 		Rules:           rules.([]rule.Response),
 		AppliedToGroups: policy.AppliedToGroups,
+		Status:          policy.Status,
 	}, nil
 }
 
@@ -68,11 +72,16 @@ func Transform(reader io.Reader, single bool) (interface{}, error) {
 var _ common.TableOutput = new(Response)
 
 func (r Response) GetTableHeader() []string {
-	return []string{"NAMESPACE", "NAME", "APPLIED-TO", "RULES"}
+	return []string{"NAMESPACE", "NAME", "APPLIED-TO", "RULES", "REALIZED"}
 }
 
 func (r Response) GetTableRow(maxColumnLength int) []string {
-	return []string{r.NameSpace, r.Name, common.GenerateTableElementWithSummary(r.AppliedToGroups, maxColumnLength), strconv.Itoa(len(r.Rules))}
+	// The realization status is unknown to antrea-agent.
+	realized := ""
+	if r.Status != nil {
+		realized = fmt.Sprintf("%d/%d", r.Status.CurrentNodesRealized, r.Status.DesiredNodesRealized)
+	}
+	return []string{r.NameSpace, r.Name, common.GenerateTableElementWithSummary(r.AppliedToGroups, maxColumnLength), strconv.Itoa(len(r.Rules)), realized}
 }
 
 func (r Response) SortRows() bool {
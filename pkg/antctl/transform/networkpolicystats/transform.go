@@ -0,0 +1,75 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicystats
+
+import (
+	"io"
+	"reflect"
+	"strconv"
+
+	"github.com/vmware-tanzu/antrea/pkg/antctl/transform"
+	"github.com/vmware-tanzu/antrea/pkg/antctl/transform/common"
+	networkingv1beta1 "github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+)
+
+type Response struct {
+	NameSpace        string                               `json:"namespace" yaml:"namespace"`
+	Name             string                               `json:"name" yaml:"name"`
+	TrafficStats     networkingv1beta1.TrafficStats       `json:"trafficStats" yaml:"trafficStats"`
+	RuleTrafficStats []networkingv1beta1.RuleTrafficStats `json:"ruleTrafficStats,omitempty" yaml:"ruleTrafficStats,omitempty"`
+}
+
+func objectTransform(o interface{}) (interface{}, error) {
+	stats := o.(*networkingv1beta1.NetworkPolicyStats)
+	return Response{
+		NameSpace:        stats.Namespace,
+		Name:             stats.Name,
+		TrafficStats:     stats.TrafficStats,
+		RuleTrafficStats: stats.RuleTrafficStats,
+	}, nil
+}
+
+func listTransform(l interface{}) (interface{}, error) {
+	statsList := l.(*networkingv1beta1.NetworkPolicyStatsList)
+	result := []Response{}
+	for _, item := range statsList.Items {
+		o, _ := objectTransform(&item)
+		result = append(result, o.(Response))
+	}
+	return result, nil
+}
+
+func Transform(reader io.Reader, single bool) (interface{}, error) {
+	return transform.GenericFactory(
+		reflect.TypeOf(networkingv1beta1.NetworkPolicyStats{}),
+		reflect.TypeOf(networkingv1beta1.NetworkPolicyStatsList{}),
+		objectTransform,
+		listTransform,
+	)(reader, single)
+}
+
+var _ common.TableOutput = new(Response)
+
+func (r Response) GetTableHeader() []string {
+	return []string{"NAMESPACE", "NAME", "SESSIONS", "PACKETS", "BYTES"}
+}
+
+func (r Response) GetTableRow(maxColumnLength int) []string {
+	return []string{r.NameSpace, r.Name, strconv.FormatInt(r.TrafficStats.Sessions, 10), strconv.FormatInt(r.TrafficStats.Packets, 10), strconv.FormatInt(r.TrafficStats.Bytes, 10)}
+}
+
+func (r Response) SortRows() bool {
+	return true
+}
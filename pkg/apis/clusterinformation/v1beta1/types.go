@@ -50,6 +50,7 @@ const (
 	ControllerConnectionUp AgentConditionType = "ControllerConnectionUp" // Status True/False is used to mark the connection status between Agent and Controller.
 	OVSDBConnectionUp      AgentConditionType = "OVSDBConnectionUp"      // Status True/False is used to mark OVSDB connection status.
 	OpenflowConnectionUp   AgentConditionType = "OpenflowConnectionUp"   // Status True/False is used to mark Openflow connection status.
+	PodInterfacesHealthy   AgentConditionType = "PodInterfacesHealthy"   // Status True/False is used to mark whether the Pod interfaces match their configuration, only reported in networkPolicyOnly mode.
 )
 
 type AgentCondition struct {
@@ -76,13 +77,14 @@ type AntreaControllerInfo struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Version                     string                      `json:"version,omitempty"`                     // Antrea binary version
-	PodRef                      corev1.ObjectReference      `json:"podRef,omitempty"`                      // The Pod that Antrea Controller is running in
+	PodRef                      corev1.ObjectReference      `json:"podRef,omitempty"`                      // The Pod of the leader Antrea Controller replica
 	NodeRef                     corev1.ObjectReference      `json:"nodeRef,omitempty"`                     // The Node that Antrea Controller is running in
 	ServiceRef                  corev1.ObjectReference      `json:"serviceRef, omitempty"`                 // Antrea Controller Service
 	NetworkPolicyControllerInfo NetworkPolicyControllerInfo `json:"networkPolicyControllerInfo,omitempty"` // Antrea Controller NetworkPolicy information
 	ConnectedAgentNum           int32                       `json:"connectedAgentNum,omitempty"`           // Number of agents which are connected to this controller
 	ControllerConditions        []ControllerCondition       `json:"controllerConditions,omitempty"`        // Controller condition contains types like ControllerHealthy
 	APIPort                     int                         `json:"apiPort,omitempty"`                     // The port of antrea controller API Server
+	Leader                      string                      `json:"leader,omitempty"`                      // The name of the Pod of the leader Antrea Controller replica
 }
 
 type NetworkPolicyControllerInfo struct {
@@ -0,0 +1,122 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// IPPoolAnnotationKey is the annotation of a Namespace which specifies
+	// the name of the IPPool from which the IPs of its Pods are allocated.
+	IPPoolAnnotationKey = "ipam.antrea.tanzu.vmware.com/ip-pools"
+)
+
+type IPAddressPhase string
+
+const (
+	// IPAddressPhaseAllocated means the IP address is allocated to a Pod.
+	IPAddressPhaseAllocated IPAddressPhase = "Allocated"
+	// IPAddressPhaseReserved means the IP address is reserved for a
+	// StatefulSet Pod which is not running.
+	IPAddressPhaseReserved IPAddressPhase = "Reserved"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IPPool defines one or more IP ranges from which the IPs of Pods are
+// allocated, and records the allocated IPs in its status.
+type IPPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IPPoolSpec   `json:"spec,omitempty"`
+	Status IPPoolStatus `json:"status,omitempty"`
+}
+
+// IPPoolSpec describes the IP ranges of an IPPool.
+type IPPoolSpec struct {
+	IPRanges []SubnetIPRange `json:"ipRanges"`
+}
+
+// IPRange is either a CIDR, or a range of IPs defined by its first and last
+// IPs.
+type IPRange struct {
+	CIDR  string `json:"cidr,omitempty"`
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+}
+
+// SubnetInfo describes the subnet the IPs of an IPRange belong to.
+type SubnetInfo struct {
+	Gateway      string `json:"gateway"`
+	PrefixLength int32  `json:"prefixLength"`
+}
+
+// SubnetIPRange is an IPRange with the information of its subnet.
+type SubnetIPRange struct {
+	IPRange    `json:",inline"`
+	SubnetInfo `json:",inline"`
+}
+
+// IPPoolStatus records the IPs allocated from an IPPool.
+type IPPoolStatus struct {
+	IPAddresses []IPAddressState `json:"ipAddresses,omitempty"`
+}
+
+// IPAddressState is the state of an IP allocated or reserved from an IPPool.
+type IPAddressState struct {
+	IPAddress string         `json:"ipAddress"`
+	Phase     IPAddressPhase `json:"phase"`
+	Owner     IPAddressOwner `json:"owner"`
+}
+
+// IPAddressOwner is the owner of an IP. An IP reserved for a StatefulSet Pod
+// has a StatefulSet owner, and also a Pod owner when it is allocated.
+type IPAddressOwner struct {
+	Pod         *PodOwner         `json:"pod,omitempty"`
+	StatefulSet *StatefulSetOwner `json:"statefulSet,omitempty"`
+}
+
+// PodOwner identifies the Pod an IP is allocated to.
+type PodOwner struct {
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+	ContainerID string `json:"containerID"`
+	// NodeName is the Node the Pod runs on, used to route the IP to it.
+	NodeName string `json:"nodeName"`
+	// IFName is the name of the secondary interface of the Pod the IP is
+	// allocated to. It is empty for the primary interface of the Pod.
+	IFName string `json:"ifName,omitempty"`
+}
+
+// StatefulSetOwner identifies the StatefulSet Pod an IP is reserved for.
+type StatefulSetOwner struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Index     int    `json:"index"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IPPoolList is a list of IPPool objects.
+type IPPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []IPPool `json:"items"`
+}
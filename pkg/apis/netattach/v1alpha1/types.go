@@ -0,0 +1,73 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// NetworksAnnotationKey is the annotation of a Pod which specifies the
+	// SecondaryNetworks the Pod is attached to, as a comma-separated list of
+	// SecondaryNetwork names, each optionally followed by "@" and the name
+	// of the interface in the Pod, e.g. "net1,net2@vlan10".
+	NetworksAnnotationKey = "netattach.antrea.tanzu.vmware.com/networks"
+
+	// DefaultBridge is the OVS bridge of the SecondaryNetworks which do not
+	// specify one.
+	DefaultBridge = "br-secondary"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SecondaryNetwork defines a network which Pods can be attached to with
+// additional interfaces, besides their primary interface.
+type SecondaryNetwork struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SecondaryNetworkSpec `json:"spec,omitempty"`
+}
+
+// SecondaryNetworkSpec describes how the interfaces attached to a
+// SecondaryNetwork are connected and configured.
+type SecondaryNetworkSpec struct {
+	// Bridge is the name of the OVS bridge the interfaces are connected
+	// to. The bridge is created on the Nodes if it does not exist, and
+	// forwards the traffic as a standalone L2 switch. Defaults to
+	// DefaultBridge.
+	Bridge string `json:"bridge,omitempty"`
+	// VLAN is the VLAN ID of the network. If set, the interfaces are
+	// connected to access ports of the VLAN on the bridge, so that several
+	// SecondaryNetworks can share a bridge.
+	VLAN uint16 `json:"vlan,omitempty"`
+	// MTU is the MTU of the interfaces. Defaults to 1500.
+	MTU int32 `json:"mtu,omitempty"`
+	// IPPool is the name of the IPPool from which the IPs of the interfaces
+	// are allocated. No IP is configured on the interfaces if it is empty.
+	IPPool string `json:"ipPool,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SecondaryNetworkList is a list of SecondaryNetwork objects.
+type SecondaryNetworkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []SecondaryNetwork `json:"items"`
+}
@@ -53,6 +53,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&AddressGroupList{},
 		&NetworkPolicy{},
 		&NetworkPolicyList{},
+		&NetworkPolicyStatus{},
+		&NodeStatsSummary{},
+		&NetworkPolicyStats{},
+		&NetworkPolicyStatsList{},
+		&EndpointQuery{},
+		&ConnectivityQuery{},
 	)
 	return nil
 }
@@ -16,7 +16,10 @@ package networking
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -54,6 +57,9 @@ type GroupMemberPod struct {
 	IP IPAddress
 	// Ports maintain the list of named port associated with this Pod member.
 	Ports []NamedPort
+	// IPs maintains the IPAddresses of the Pod, at most one for each IP family.
+	// IP is the first one of them and is kept for backward compatibility.
+	IPs []IPAddress
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -117,8 +123,36 @@ type NetworkPolicy struct {
 	Rules []NetworkPolicyRule
 	// AppliedToGroups is a list of names of AppliedToGroups to which this policy applies.
 	AppliedToGroups []string
+	// Priority represents the relative priority of this NetworkPolicy as compared to
+	// other NetworkPolicies in the same tier. Priority will be unset (nil) for K8s
+	// NetworkPolicy.
+	Priority *float64
+	// TierPriority represents the priority of the tier associated with this NetworkPolicy.
+	// TierPriority will be unset (nil) for K8s NetworkPolicy.
+	TierPriority *TierPriority
+	// Status is the realization status of this NetworkPolicy, aggregated from
+	// the statuses reported by the Nodes it spans. It is only set in the
+	// responses of get and list requests.
+	Status *NetworkPolicyRealizationStatus
+}
+
+// NetworkPolicyRealizationStatus is the realization status of a NetworkPolicy
+// across the Nodes it spans.
+type NetworkPolicyRealizationStatus struct {
+	// CurrentNodesRealized is the number of Nodes that have realized the
+	// current generation of this NetworkPolicy.
+	CurrentNodesRealized int32
+	// DesiredNodesRealized is the number of Nodes this NetworkPolicy spans.
+	DesiredNodesRealized int32
+	// FailedNodes is a list of statuses of the Nodes that failed to realize
+	// some rules of the current generation of this NetworkPolicy.
+	FailedNodes []NetworkPolicyNodeStatus
 }
 
+// TierPriority specifies the relative ordering among tiers. A lower value
+// means a higher precedence.
+type TierPriority uint32
+
 // Direction defines traffic direction of NetworkPolicyRule.
 type Direction string
 
@@ -139,6 +173,20 @@ type NetworkPolicyRule struct {
 	To NetworkPolicyPeer
 	// Services is a list of services which should be matched.
 	Services []Service
+	// Priority defines the priority of the rule as compared to other rules in the
+	// NetworkPolicy. A lower value means a higher precedence.
+	Priority int32
+	// Action specifies the action to be applied on the rule, i.e. Allow, Drop or
+	// Reject. An empty action "nil" defaults to Allow, which would be the case for
+	// rules created for K8s NetworkPolicy.
+	Action *secv1alpha1.RuleAction
+	// EnableLogging indicates whether the connections matching the rule, and
+	// the connections dropped because the rule isolates its Pods, must be logged.
+	EnableLogging bool
+	// Name is the name of the rule, unique within the NetworkPolicy. It's
+	// generated from the direction of the rule and its position in the spec,
+	// e.g. "ingress-0".
+	Name string
 }
 
 // Protocol defines network protocols supported for things like container ports.
@@ -151,17 +199,31 @@ const (
 	ProtocolUDP Protocol = "UDP"
 	// ProtocolSCTP is the SCTP protocol.
 	ProtocolSCTP Protocol = "SCTP"
+	// ProtocolICMP is the ICMP protocol, or ICMPv6 for IPv6 traffic.
+	ProtocolICMP Protocol = "ICMP"
 )
 
 // Service describes a port to allow traffic on.
 type Service struct {
-	// The protocol (TCP, UDP, or SCTP) which traffic must match. If not specified, this
+	// The protocol (TCP, UDP, SCTP, or ICMP) which traffic must match. If not specified, this
 	// field defaults to TCP.
 	// +optional
 	Protocol *Protocol
 	// The port name or number on the given protocol. If not specified, this matches all port numbers.
 	// +optional
 	Port *intstr.IntOrString
+	// EndPort, if set, makes the Service match the range of port numbers from Port to
+	// EndPort, both inclusive. It can only be set when Port is a number.
+	// +optional
+	EndPort *int32
+	// ICMPType is the ICMP type which traffic must match, only used with the ICMP
+	// protocol. If not specified, this matches all ICMP types.
+	// +optional
+	ICMPType *int32
+	// ICMPCode is the ICMP code which traffic must match, only used with the ICMP
+	// protocol. If not specified, this matches all ICMP codes.
+	// +optional
+	ICMPCode *int32
 }
 
 // NetworkPolicyPeer describes a peer of NetworkPolicyRules.
@@ -171,6 +233,10 @@ type NetworkPolicyPeer struct {
 	AddressGroups []string
 	// A list of IPBlock.
 	IPBlocks []IPBlock
+	// A list of FQDNs, which are resolved by the agent by snooping the DNS
+	// responses sent to the Pods the rule applies to. Only valid for egress
+	// rules. A leading "*." matches any subdomain.
+	FQDNs []string
 }
 
 // IPBlock describes a particular CIDR (Ex. "192.168.1.1/24"). The except entry describes CIDRs that should
@@ -191,3 +257,174 @@ type NetworkPolicyList struct {
 	metav1.ListMeta
 	Items []NetworkPolicy
 }
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// NetworkPolicyStatus is the status of a NetworkPolicy reported by antrea-agents.
+// Its name, namespace and UID are the ones of the NetworkPolicy.
+type NetworkPolicyStatus struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+	// Nodes is a list of statuses of the NetworkPolicy on the reporting Nodes.
+	Nodes []NetworkPolicyNodeStatus
+}
+
+// NetworkPolicyNodeStatus is the status of a NetworkPolicy on a Node.
+type NetworkPolicyNodeStatus struct {
+	// NodeName is the name of the Node that produced this status.
+	NodeName string
+	// Generation is the generation of the NetworkPolicy processed by the Node.
+	Generation int64
+	// FailedRules is the number of rules of the NetworkPolicy that the Node
+	// failed to realize.
+	FailedRules int32
+	// LastError is the error encountered when realizing a failed rule.
+	LastError string
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// NodeStatsSummary contains the traffic statistics collected by an antrea-agent
+// since its last report. Its name is the name of the Node.
+type NodeStatsSummary struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+	// NetworkPolicies is a list of the traffic statistics of the
+	// NetworkPolicies collected on the Node.
+	NetworkPolicies []NetworkPolicyStats
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// NetworkPolicyStats is the traffic statistics of a NetworkPolicy. Its name,
+// namespace and UID are the ones of the NetworkPolicy.
+type NetworkPolicyStats struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+	// TrafficStats is the traffic statistics of the NetworkPolicy.
+	TrafficStats TrafficStats
+	// RuleTrafficStats is a list of the traffic statistics of the rules of
+	// the NetworkPolicy.
+	RuleTrafficStats []RuleTrafficStats
+}
+
+// RuleTrafficStats contains the traffic statistics of a NetworkPolicy rule.
+type RuleTrafficStats struct {
+	// Name is the name of the rule.
+	Name string
+	// TrafficStats is the traffic statistics of the rule.
+	TrafficStats TrafficStats
+}
+
+// TrafficStats contains the traffic statistics of an object.
+type TrafficStats struct {
+	// Packets is the number of packets.
+	Packets int64
+	// Bytes is the number of bytes.
+	Bytes int64
+	// Sessions is the number of sessions.
+	Sessions int64
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// NetworkPolicyStatsList is a list of NetworkPolicyStats.
+type NetworkPolicyStatsList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+	Items []NetworkPolicyStats
+}
+
+// NetworkPolicyReference is a reference to a NetworkPolicy.
+type NetworkPolicyReference struct {
+	// Namespace of the NetworkPolicy. It is empty for ClusterNetworkPolicies.
+	Namespace string
+	// Name of the NetworkPolicy.
+	Name string
+	// UID of the NetworkPolicy.
+	UID types.UID
+}
+
+// NetworkPolicyRuleReference is a reference to a rule of a NetworkPolicy.
+type NetworkPolicyRuleReference struct {
+	// Policy is the NetworkPolicy the rule belongs to.
+	Policy NetworkPolicyReference
+	// Direction is the direction of the rule.
+	Direction Direction
+	// Index is the index of the rule in the Rules of the NetworkPolicy.
+	Index int32
+	// Action is the action of the rule. It is nil for the rules of K8s
+	// NetworkPolicies, which are always Allow.
+	Action *secv1alpha1.RuleAction
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// EndpointQuery describes the NetworkPolicies affecting a Pod. Its name and
+// namespace are the ones of the Pod.
+type EndpointQuery struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+	// AppliedPolicies is a list of the NetworkPolicies applied to the Pod.
+	AppliedPolicies []NetworkPolicyReference
+	// PeerRules is a list of the rules of any NetworkPolicy which select the
+	// Pod as a peer, i.e. the ingress rules whose sources include the Pod and
+	// the egress rules whose destinations include the Pod.
+	PeerRules []NetworkPolicyRuleReference
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// ConnectivityQuery evaluates whether the traffic from a source to a
+// destination is allowed by the NetworkPolicies.
+type ConnectivityQuery struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+	// Spec describes the traffic to evaluate.
+	Spec ConnectivityQuerySpec
+	// Status is the result of the evaluation.
+	Status ConnectivityQueryStatus
+}
+
+// ConnectivityQuerySpec describes the traffic to evaluate.
+type ConnectivityQuerySpec struct {
+	// Source is the source of the traffic.
+	Source ConnectivityQueryPeer
+	// Destination is the destination of the traffic.
+	Destination ConnectivityQueryPeer
+	// Protocol is the protocol of the traffic. It defaults to TCP.
+	Protocol Protocol
+	// Port is the destination port of the traffic. 0 means any port, in
+	// which case only the rules matching all ports are considered.
+	Port int32
+}
+
+// ConnectivityQueryPeer is a peer of a ConnectivityQuery. Exactly one of Pod
+// and IP must be set.
+type ConnectivityQueryPeer struct {
+	// Pod is a reference to a Pod.
+	Pod *PodReference
+	// IP is an IP address which doesn't belong to a Pod.
+	IP string
+}
+
+// ConnectivityQueryStatus is the result of a ConnectivityQuery.
+type ConnectivityQueryStatus struct {
+	// Allowed indicates whether the traffic is allowed in both directions
+	// it is subject to.
+	Allowed bool
+	// Egress is the verdict of the egress policies of the source. It is nil
+	// if the source isn't a Pod.
+	Egress *ConnectivityQueryVerdict
+	// Ingress is the verdict of the ingress policies of the destination. It
+	// is nil if the destination isn't a Pod.
+	Ingress *ConnectivityQueryVerdict
+}
+
+// ConnectivityQueryVerdict is the verdict of the NetworkPolicies applied to a
+// Pod for a direction.
+type ConnectivityQueryVerdict struct {
+	// Allowed indicates whether the traffic is allowed in this direction.
+	Allowed bool
+	// Rule is the rule that decided the verdict. It is nil if no rule
+	// matched the traffic.
+	Rule *NetworkPolicyRuleReference
+	// Isolated indicates whether the Pod is isolated in this direction by
+	// K8s NetworkPolicies, in which case the traffic matching no rule is
+	// dropped.
+	Isolated bool
+}
@@ -17,17 +17,20 @@
 package v1beta1
 
 import (
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 
 	io "io"
 
 	proto "github.com/gogo/protobuf/proto"
+	github_com_vmware_tanzu_antrea_pkg_apis_security_v1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
 
 	math "math"
 	math_bits "math/bits"
 	reflect "reflect"
 	strings "strings"
 
+	k8s_io_apimachinery_pkg_types "k8s.io/apimachinery/pkg/types"
 	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -210,10 +213,178 @@ func (m *AppliedToGroupPatch) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_AppliedToGroupPatch proto.InternalMessageInfo
 
+func (m *ConnectivityQuery) Reset()      { *m = ConnectivityQuery{} }
+func (*ConnectivityQuery) ProtoMessage() {}
+func (*ConnectivityQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da8f95e0f1c69434, []int{6}
+}
+func (m *ConnectivityQuery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConnectivityQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *ConnectivityQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectivityQuery.Merge(m, src)
+}
+func (m *ConnectivityQuery) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConnectivityQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectivityQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectivityQuery proto.InternalMessageInfo
+
+func (m *ConnectivityQueryPeer) Reset()      { *m = ConnectivityQueryPeer{} }
+func (*ConnectivityQueryPeer) ProtoMessage() {}
+func (*ConnectivityQueryPeer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da8f95e0f1c69434, []int{7}
+}
+func (m *ConnectivityQueryPeer) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConnectivityQueryPeer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *ConnectivityQueryPeer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectivityQueryPeer.Merge(m, src)
+}
+func (m *ConnectivityQueryPeer) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConnectivityQueryPeer) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectivityQueryPeer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectivityQueryPeer proto.InternalMessageInfo
+
+func (m *ConnectivityQuerySpec) Reset()      { *m = ConnectivityQuerySpec{} }
+func (*ConnectivityQuerySpec) ProtoMessage() {}
+func (*ConnectivityQuerySpec) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da8f95e0f1c69434, []int{8}
+}
+func (m *ConnectivityQuerySpec) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConnectivityQuerySpec) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *ConnectivityQuerySpec) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectivityQuerySpec.Merge(m, src)
+}
+func (m *ConnectivityQuerySpec) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConnectivityQuerySpec) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectivityQuerySpec.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectivityQuerySpec proto.InternalMessageInfo
+
+func (m *ConnectivityQueryStatus) Reset()      { *m = ConnectivityQueryStatus{} }
+func (*ConnectivityQueryStatus) ProtoMessage() {}
+func (*ConnectivityQueryStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da8f95e0f1c69434, []int{9}
+}
+func (m *ConnectivityQueryStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConnectivityQueryStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *ConnectivityQueryStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectivityQueryStatus.Merge(m, src)
+}
+func (m *ConnectivityQueryStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConnectivityQueryStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectivityQueryStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectivityQueryStatus proto.InternalMessageInfo
+
+func (m *ConnectivityQueryVerdict) Reset()      { *m = ConnectivityQueryVerdict{} }
+func (*ConnectivityQueryVerdict) ProtoMessage() {}
+func (*ConnectivityQueryVerdict) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da8f95e0f1c69434, []int{10}
+}
+func (m *ConnectivityQueryVerdict) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConnectivityQueryVerdict) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *ConnectivityQueryVerdict) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectivityQueryVerdict.Merge(m, src)
+}
+func (m *ConnectivityQueryVerdict) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConnectivityQueryVerdict) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectivityQueryVerdict.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectivityQueryVerdict proto.InternalMessageInfo
+
+func (m *EndpointQuery) Reset()      { *m = EndpointQuery{} }
+func (*EndpointQuery) ProtoMessage() {}
+func (*EndpointQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da8f95e0f1c69434, []int{11}
+}
+func (m *EndpointQuery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EndpointQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *EndpointQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndpointQuery.Merge(m, src)
+}
+func (m *EndpointQuery) XXX_Size() int {
+	return m.Size()
+}
+func (m *EndpointQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndpointQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndpointQuery proto.InternalMessageInfo
+
 func (m *GroupMemberPod) Reset()      { *m = GroupMemberPod{} }
 func (*GroupMemberPod) ProtoMessage() {}
 func (*GroupMemberPod) Descriptor() ([]byte, []int) {
-	return fileDescriptor_da8f95e0f1c69434, []int{6}
+	return fileDescriptor_da8f95e0f1c69434, []int{12}
 }
 func (m *GroupMemberPod) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -241,7 +412,7 @@ var xxx_messageInfo_GroupMemberPod proto.InternalMessageInfo
 func (m *IPBlock) Reset()      { *m = IPBlock{} }
 func (*IPBlock) ProtoMessage() {}
 func (*IPBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_da8f95e0f1c69434, []int{7}
+	return fileDescriptor_da8f95e0f1c69434, []int{13}
 }
 func (m *IPBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -269,7 +440,7 @@ var xxx_messageInfo_IPBlock proto.InternalMessageInfo
 func (m *IPNet) Reset()      { *m = IPNet{} }
 func (*IPNet) ProtoMessage() {}
 func (*IPNet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_da8f95e0f1c69434, []int{8}
+	return fileDescriptor_da8f95e0f1c69434, []int{14}
 }
 func (m *IPNet) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -297,7 +468,7 @@ var xxx_messageInfo_IPNet proto.InternalMessageInfo
 func (m *NamedPort) Reset()      { *m = NamedPort{} }
 func (*NamedPort) ProtoMessage() {}
 func (*NamedPort) Descriptor() ([]byte, []int) {
-	return fileDescriptor_da8f95e0f1c69434, []int{9}
+	return fileDescriptor_da8f95e0f1c69434, []int{15}
 }
 func (m *NamedPort) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -325,7 +496,7 @@ var xxx_messageInfo_NamedPort proto.InternalMessageInfo
 func (m *NetworkPolicy) Reset()      { *m = NetworkPolicy{} }
 func (*NetworkPolicy) ProtoMessage() {}
 func (*NetworkPolicy) Descriptor() ([]byte, []int) {
-	return fileDescriptor_da8f95e0f1c69434, []int{10}
+	return fileDescriptor_da8f95e0f1c69434, []int{16}
 }
 func (m *NetworkPolicy) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -353,7 +524,7 @@ var xxx_messageInfo_NetworkPolicy proto.InternalMessageInfo
 func (m *NetworkPolicyList) Reset()      { *m = NetworkPolicyList{} }
 func (*NetworkPolicyList) ProtoMessage() {}
 func (*NetworkPolicyList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_da8f95e0f1c69434, []int{11}
+	return fileDescriptor_da8f95e0f1c69434, []int{17}
 }
 func (m *NetworkPolicyList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -378,10 +549,38 @@ func (m *NetworkPolicyList) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_NetworkPolicyList proto.InternalMessageInfo
 
+func (m *NetworkPolicyNodeStatus) Reset()      { *m = NetworkPolicyNodeStatus{} }
+func (*NetworkPolicyNodeStatus) ProtoMessage() {}
+func (*NetworkPolicyNodeStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da8f95e0f1c69434, []int{18}
+}
+func (m *NetworkPolicyNodeStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NetworkPolicyNodeStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *NetworkPolicyNodeStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkPolicyNodeStatus.Merge(m, src)
+}
+func (m *NetworkPolicyNodeStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *NetworkPolicyNodeStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkPolicyNodeStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkPolicyNodeStatus proto.InternalMessageInfo
+
 func (m *NetworkPolicyPeer) Reset()      { *m = NetworkPolicyPeer{} }
 func (*NetworkPolicyPeer) ProtoMessage() {}
 func (*NetworkPolicyPeer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_da8f95e0f1c69434, []int{12}
+	return fileDescriptor_da8f95e0f1c69434, []int{19}
 }
 func (m *NetworkPolicyPeer) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -406,10 +605,66 @@ func (m *NetworkPolicyPeer) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_NetworkPolicyPeer proto.InternalMessageInfo
 
+func (m *NetworkPolicyRealizationStatus) Reset()      { *m = NetworkPolicyRealizationStatus{} }
+func (*NetworkPolicyRealizationStatus) ProtoMessage() {}
+func (*NetworkPolicyRealizationStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da8f95e0f1c69434, []int{20}
+}
+func (m *NetworkPolicyRealizationStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NetworkPolicyRealizationStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *NetworkPolicyRealizationStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkPolicyRealizationStatus.Merge(m, src)
+}
+func (m *NetworkPolicyRealizationStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *NetworkPolicyRealizationStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkPolicyRealizationStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkPolicyRealizationStatus proto.InternalMessageInfo
+
+func (m *NetworkPolicyReference) Reset()      { *m = NetworkPolicyReference{} }
+func (*NetworkPolicyReference) ProtoMessage() {}
+func (*NetworkPolicyReference) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da8f95e0f1c69434, []int{21}
+}
+func (m *NetworkPolicyReference) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NetworkPolicyReference) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *NetworkPolicyReference) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkPolicyReference.Merge(m, src)
+}
+func (m *NetworkPolicyReference) XXX_Size() int {
+	return m.Size()
+}
+func (m *NetworkPolicyReference) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkPolicyReference.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkPolicyReference proto.InternalMessageInfo
+
 func (m *NetworkPolicyRule) Reset()      { *m = NetworkPolicyRule{} }
 func (*NetworkPolicyRule) ProtoMessage() {}
 func (*NetworkPolicyRule) Descriptor() ([]byte, []int) {
-	return fileDescriptor_da8f95e0f1c69434, []int{13}
+	return fileDescriptor_da8f95e0f1c69434, []int{22}
 }
 func (m *NetworkPolicyRule) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -434,15 +689,15 @@ func (m *NetworkPolicyRule) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_NetworkPolicyRule proto.InternalMessageInfo
 
-func (m *PodReference) Reset()      { *m = PodReference{} }
-func (*PodReference) ProtoMessage() {}
-func (*PodReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_da8f95e0f1c69434, []int{14}
+func (m *NetworkPolicyRuleReference) Reset()      { *m = NetworkPolicyRuleReference{} }
+func (*NetworkPolicyRuleReference) ProtoMessage() {}
+func (*NetworkPolicyRuleReference) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da8f95e0f1c69434, []int{23}
 }
-func (m *PodReference) XXX_Unmarshal(b []byte) error {
+func (m *NetworkPolicyRuleReference) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PodReference) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *NetworkPolicyRuleReference) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	b = b[:cap(b)]
 	n, err := m.MarshalToSizedBuffer(b)
 	if err != nil {
@@ -450,27 +705,27 @@ func (m *PodReference) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 	}
 	return b[:n], nil
 }
-func (m *PodReference) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PodReference.Merge(m, src)
+func (m *NetworkPolicyRuleReference) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkPolicyRuleReference.Merge(m, src)
 }
-func (m *PodReference) XXX_Size() int {
+func (m *NetworkPolicyRuleReference) XXX_Size() int {
 	return m.Size()
 }
-func (m *PodReference) XXX_DiscardUnknown() {
-	xxx_messageInfo_PodReference.DiscardUnknown(m)
+func (m *NetworkPolicyRuleReference) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkPolicyRuleReference.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PodReference proto.InternalMessageInfo
+var xxx_messageInfo_NetworkPolicyRuleReference proto.InternalMessageInfo
 
-func (m *Service) Reset()      { *m = Service{} }
-func (*Service) ProtoMessage() {}
-func (*Service) Descriptor() ([]byte, []int) {
-	return fileDescriptor_da8f95e0f1c69434, []int{15}
+func (m *NetworkPolicyStats) Reset()      { *m = NetworkPolicyStats{} }
+func (*NetworkPolicyStats) ProtoMessage() {}
+func (*NetworkPolicyStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da8f95e0f1c69434, []int{24}
 }
-func (m *Service) XXX_Unmarshal(b []byte) error {
+func (m *NetworkPolicyStats) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Service) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *NetworkPolicyStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	b = b[:cap(b)]
 	n, err := m.MarshalToSizedBuffer(b)
 	if err != nil {
@@ -478,132 +733,411 @@ func (m *Service) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	}
 	return b[:n], nil
 }
-func (m *Service) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Service.Merge(m, src)
+func (m *NetworkPolicyStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkPolicyStats.Merge(m, src)
 }
-func (m *Service) XXX_Size() int {
+func (m *NetworkPolicyStats) XXX_Size() int {
 	return m.Size()
 }
-func (m *Service) XXX_DiscardUnknown() {
-	xxx_messageInfo_Service.DiscardUnknown(m)
+func (m *NetworkPolicyStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkPolicyStats.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Service proto.InternalMessageInfo
+var xxx_messageInfo_NetworkPolicyStats proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterType((*AddressGroup)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.AddressGroup")
-	proto.RegisterType((*AddressGroupList)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.AddressGroupList")
-	proto.RegisterType((*AddressGroupPatch)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.AddressGroupPatch")
-	proto.RegisterType((*AppliedToGroup)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.AppliedToGroup")
-	proto.RegisterType((*AppliedToGroupList)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.AppliedToGroupList")
-	proto.RegisterType((*AppliedToGroupPatch)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.AppliedToGroupPatch")
-	proto.RegisterType((*GroupMemberPod)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.GroupMemberPod")
-	proto.RegisterType((*IPBlock)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.IPBlock")
-	proto.RegisterType((*IPNet)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.IPNet")
-	proto.RegisterType((*NamedPort)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.NamedPort")
-	proto.RegisterType((*NetworkPolicy)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.NetworkPolicy")
-	proto.RegisterType((*NetworkPolicyList)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.NetworkPolicyList")
-	proto.RegisterType((*NetworkPolicyPeer)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.NetworkPolicyPeer")
-	proto.RegisterType((*NetworkPolicyRule)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.NetworkPolicyRule")
-	proto.RegisterType((*PodReference)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.PodReference")
-	proto.RegisterType((*Service)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.Service")
+func (m *NetworkPolicyStatsList) Reset()      { *m = NetworkPolicyStatsList{} }
+func (*NetworkPolicyStatsList) ProtoMessage() {}
+func (*NetworkPolicyStatsList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da8f95e0f1c69434, []int{25}
 }
-
-func init() {
-	proto.RegisterFile("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1/generated.proto", fileDescriptor_da8f95e0f1c69434)
+func (m *NetworkPolicyStatsList) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-var fileDescriptor_da8f95e0f1c69434 = []byte{
-	// 1076 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x57, 0x4f, 0x6f, 0xdc, 0x44,
-	0x14, 0xcf, 0x78, 0xb3, 0x4d, 0x76, 0xb2, 0x69, 0x9b, 0x09, 0x87, 0x55, 0x84, 0xbc, 0x91, 0xb9,
-	0xe4, 0x12, 0x9b, 0x94, 0x0a, 0x22, 0x04, 0x87, 0xb8, 0x2d, 0xb0, 0x55, 0x93, 0x5a, 0xd3, 0x9e,
-	0x10, 0x12, 0x4c, 0xec, 0xc9, 0x66, 0x9a, 0xb5, 0xc7, 0x8c, 0x67, 0xb7, 0x2d, 0x5c, 0xe0, 0x82,
-	0xc4, 0x09, 0x3e, 0x02, 0x07, 0xbe, 0x07, 0xd7, 0x1c, 0x7b, 0x2c, 0x97, 0xa5, 0xd9, 0x7e, 0x07,
-	0x84, 0x72, 0x42, 0x1e, 0x8f, 0xd7, 0x76, 0xa2, 0x25, 0x88, 0x5d, 0xf6, 0x80, 0x38, 0x25, 0x33,
-	0xf3, 0xde, 0xef, 0xf7, 0xfe, 0xcc, 0xfb, 0x8d, 0x17, 0xde, 0xef, 0x32, 0x79, 0xdc, 0x3f, 0xb4,
-	0x7d, 0x1e, 0x3a, 0x83, 0xf0, 0x29, 0x11, 0x74, 0x5b, 0x92, 0xe8, 0xab, 0xbe, 0x43, 0x22, 0x29,
-	0x28, 0x71, 0xe2, 0x93, 0xae, 0x43, 0x62, 0x96, 0x38, 0x11, 0x95, 0x4f, 0xb9, 0x38, 0x61, 0x51,
-	0xd7, 0x19, 0xec, 0x1c, 0x52, 0x49, 0x76, 0x9c, 0x2e, 0x8d, 0xa8, 0x20, 0x92, 0x06, 0x76, 0x2c,
-	0xb8, 0xe4, 0xe8, 0xfd, 0x02, 0xcb, 0xce, 0xb0, 0x3e, 0x57, 0x58, 0x76, 0x86, 0x65, 0xc7, 0x27,
-	0x5d, 0x3b, 0xc5, 0xb2, 0x0b, 0x2c, 0x5b, 0x63, 0x6d, 0x6c, 0x97, 0xe2, 0xe8, 0xf2, 0x2e, 0x77,
-	0x14, 0xe4, 0x61, 0xff, 0x48, 0xad, 0xd4, 0x42, 0xfd, 0x97, 0x51, 0x6d, 0xdc, 0x3e, 0xd9, 0x4d,
-	0x6c, 0xc6, 0xd3, 0xd0, 0x42, 0xe2, 0x1f, 0xb3, 0x88, 0x8a, 0xe7, 0x45, 0xac, 0x21, 0x95, 0xc4,
-	0x19, 0x5c, 0x0a, 0x70, 0xc3, 0x99, 0xe4, 0x25, 0xfa, 0x91, 0x64, 0x21, 0xbd, 0xe4, 0xf0, 0xee,
-	0x55, 0x0e, 0x89, 0x7f, 0x4c, 0x43, 0x72, 0xc9, 0xef, 0x9d, 0x49, 0x7e, 0x7d, 0xc9, 0x7a, 0x0e,
-	0x8b, 0x64, 0x22, 0xc5, 0x45, 0x27, 0x6b, 0x08, 0x60, 0x73, 0x2f, 0x08, 0x04, 0x4d, 0x92, 0x8f,
-	0x05, 0xef, 0xc7, 0xe8, 0x0b, 0xb8, 0x9c, 0x66, 0x12, 0x10, 0x49, 0x5a, 0x60, 0x13, 0x6c, 0xad,
-	0xdc, 0x7a, 0xdb, 0xce, 0x80, 0xed, 0x32, 0x70, 0x51, 0xd7, 0xd4, 0xda, 0x1e, 0xec, 0xd8, 0x0f,
-	0x0f, 0x9f, 0x50, 0x5f, 0xee, 0x53, 0x49, 0x5c, 0x74, 0x3a, 0x6c, 0x2f, 0x8c, 0x86, 0x6d, 0x58,
-	0xec, 0xe1, 0x31, 0x2a, 0xea, 0xc1, 0xc5, 0x98, 0x07, 0x49, 0xcb, 0xd8, 0xac, 0x6d, 0xad, 0xdc,
-	0xba, 0x6f, 0xff, 0xf3, 0x06, 0xda, 0x2a, 0xe4, 0x7d, 0x1a, 0x1e, 0x52, 0xe1, 0xf1, 0xc0, 0x6d,
-	0x6a, 0xde, 0x45, 0x8f, 0x07, 0x09, 0x56, 0x2c, 0xd6, 0x6f, 0x00, 0xde, 0x2c, 0x27, 0xf8, 0x80,
-	0x25, 0x12, 0x7d, 0x76, 0x29, 0x49, 0xfb, 0xef, 0x25, 0x99, 0x7a, 0xab, 0x14, 0x6f, 0x6a, 0xaa,
-	0xe5, 0x7c, 0xa7, 0x94, 0x60, 0x08, 0xeb, 0x4c, 0xd2, 0x30, 0xcf, 0xf0, 0x93, 0x69, 0x32, 0x2c,
-	0x87, 0xee, 0xae, 0x6a, 0xd2, 0x7a, 0x27, 0x85, 0xc7, 0x19, 0x8b, 0xf5, 0xbb, 0x01, 0xd7, 0xca,
-	0x66, 0x1e, 0x91, 0xfe, 0xf1, 0x1c, 0xfa, 0xf8, 0x35, 0x6c, 0x90, 0x20, 0xa0, 0x81, 0xf7, 0xef,
-	0x34, 0x73, 0x4d, 0x93, 0x37, 0xf6, 0x72, 0x12, 0x5c, 0xf0, 0xa1, 0x6f, 0x01, 0x5c, 0x11, 0x34,
-	0xe4, 0x03, 0xcd, 0x5f, 0x9b, 0x39, 0xff, 0xba, 0xe6, 0x5f, 0xc1, 0x05, 0x0d, 0x2e, 0x73, 0x5a,
-	0xaf, 0x00, 0xbc, 0xbe, 0x17, 0xc7, 0x3d, 0x46, 0x83, 0xc7, 0xfc, 0xbf, 0x39, 0x3d, 0xaf, 0x01,
-	0x44, 0xd5, 0x14, 0xe7, 0x30, 0x3f, 0xbc, 0x3a, 0x3f, 0x53, 0xe5, 0x58, 0x0d, 0x7e, 0xc2, 0x04,
-	0xfd, 0x61, 0xc0, 0xf5, 0xaa, 0xe1, 0xff, 0x33, 0x34, 0xa7, 0x19, 0xfa, 0xce, 0x80, 0xd7, 0xab,
-	0x4e, 0xc8, 0x87, 0xb5, 0x98, 0x07, 0xba, 0xe0, 0x53, 0x89, 0xa7, 0xc7, 0x03, 0x4c, 0x8f, 0xa8,
-	0xa0, 0x91, 0x4f, 0xdd, 0xa5, 0xd1, 0xb0, 0x5d, 0x4b, 0x77, 0x52, 0x74, 0xf4, 0x16, 0x34, 0x58,
-	0xdc, 0x32, 0x36, 0xc1, 0x56, 0xd3, 0x5d, 0x1f, 0x0d, 0xdb, 0x46, 0xc7, 0x3b, 0x1f, 0xb6, 0x1b,
-	0x1d, 0x4f, 0x2b, 0x29, 0x36, 0x58, 0x8c, 0x9e, 0xc0, 0x7a, 0xcc, 0x85, 0xcc, 0x2b, 0x73, 0x6f,
-	0x9a, 0x58, 0x0e, 0x48, 0x98, 0xa6, 0x2c, 0x64, 0x71, 0x07, 0xd3, 0x55, 0x82, 0x33, 0x0a, 0xeb,
-	0x57, 0x00, 0x97, 0x3a, 0x9e, 0xdb, 0xe3, 0xfe, 0x09, 0xf2, 0xe1, 0xa2, 0xcf, 0x02, 0xa1, 0x4b,
-	0xb0, 0x37, 0x0d, 0x6d, 0xc7, 0x3b, 0xa0, 0xb2, 0x18, 0xed, 0x3b, 0x9d, 0xbb, 0x18, 0x2b, 0x70,
-	0xc4, 0xe0, 0x35, 0xfa, 0xcc, 0xa7, 0xb1, 0xd4, 0xf7, 0x6e, 0x06, 0x34, 0xd7, 0x35, 0xcd, 0xb5,
-	0x7b, 0x0a, 0x18, 0x6b, 0x02, 0xeb, 0x08, 0xd6, 0x95, 0x81, 0xae, 0x3a, 0xf8, 0xeb, 0xaa, 0xef,
-	0xc2, 0x66, 0x2c, 0xe8, 0x11, 0x7b, 0xf6, 0x80, 0x46, 0x5d, 0x79, 0xac, 0x9a, 0x54, 0x77, 0xdf,
-	0xd0, 0xd8, 0x4d, 0xaf, 0x74, 0x86, 0x2b, 0x96, 0xd6, 0xf7, 0x00, 0x36, 0xc6, 0x75, 0x46, 0x9b,
-	0xa9, 0x52, 0x0a, 0xa9, 0xe8, 0xea, 0x65, 0x75, 0x13, 0x12, 0xab, 0x93, 0xd4, 0x22, 0x22, 0x21,
-	0x55, 0x0c, 0x8d, 0xc2, 0x22, 0x85, 0xc0, 0xea, 0x04, 0xed, 0xc2, 0x65, 0xf5, 0x9d, 0xe4, 0xf3,
-	0x5e, 0xab, 0xa6, 0xac, 0xde, 0xcc, 0x85, 0xcb, 0xd3, 0xfb, 0xe7, 0xa5, 0xff, 0xf1, 0xd8, 0xda,
-	0xfa, 0xd9, 0x80, 0xab, 0x07, 0x59, 0xa1, 0x3c, 0xde, 0x63, 0xfe, 0xf3, 0x39, 0xa8, 0x89, 0x80,
-	0x75, 0xd1, 0xef, 0xd1, 0x5c, 0x49, 0xf6, 0xa7, 0xba, 0xaf, 0xe5, 0xd8, 0x71, 0xbf, 0x47, 0x8b,
-	0x7b, 0x9b, 0xae, 0x12, 0x9c, 0x51, 0xa1, 0x0f, 0xe1, 0x0d, 0x52, 0x91, 0xce, 0x6c, 0x5a, 0x1a,
-	0xaa, 0xbf, 0x37, 0xaa, 0xaa, 0x9a, 0xe0, 0x8b, 0xb6, 0xd6, 0x19, 0x80, 0x6b, 0x15, 0xaa, 0x39,
-	0xbc, 0x2f, 0x51, 0xf5, 0x7d, 0xe9, 0xcc, 0xac, 0x4c, 0x13, 0x9e, 0x97, 0x5f, 0x2e, 0xe6, 0xe8,
-	0x51, 0x2a, 0xd0, 0x7b, 0x70, 0x95, 0x94, 0xbe, 0xda, 0x92, 0x16, 0x50, 0x65, 0x5b, 0x1b, 0x0d,
-	0xdb, 0xab, 0xe5, 0xcf, 0xb9, 0x04, 0x57, 0xed, 0xd0, 0x97, 0x70, 0x99, 0xc5, 0x4a, 0x28, 0xf2,
-	0x0c, 0xee, 0x4c, 0x37, 0xba, 0x0a, 0xab, 0xa8, 0x98, 0xde, 0x48, 0xf0, 0x98, 0xc6, 0xfa, 0xa9,
-	0x76, 0x21, 0x83, 0xf4, 0x0a, 0xa0, 0x0f, 0x60, 0x23, 0x60, 0x82, 0xfa, 0x92, 0xf1, 0x48, 0xb5,
-	0xa9, 0xe1, 0x9a, 0xf9, 0x83, 0x73, 0x37, 0x3f, 0x38, 0x2f, 0x2f, 0x70, 0xe1, 0x80, 0x38, 0x5c,
-	0x3c, 0x12, 0x3c, 0x54, 0xc3, 0x37, 0xcb, 0xbb, 0x9a, 0x16, 0xb7, 0x98, 0xe5, 0x8f, 0x04, 0x0f,
-	0xb1, 0x22, 0x42, 0x0c, 0x1a, 0x92, 0xab, 0x29, 0x9e, 0x39, 0x1d, 0xd4, 0x74, 0xc6, 0x63, 0x8e,
-	0x0d, 0xc9, 0xd3, 0x16, 0x25, 0x54, 0x0c, 0x98, 0x4f, 0x93, 0xd6, 0xe2, 0xf4, 0x2d, 0x7a, 0x94,
-	0x61, 0x15, 0x2d, 0xd2, 0x1b, 0x09, 0x1e, 0xd3, 0x58, 0x04, 0x36, 0xcb, 0xcf, 0xdd, 0x58, 0xdb,
-	0xc0, 0x44, 0x6d, 0x73, 0x60, 0x23, 0xfd, 0x9b, 0xc4, 0xc4, 0xcf, 0x25, 0x70, 0xfc, 0xbd, 0x70,
-	0x90, 0x1f, 0xe0, 0xc2, 0xc6, 0xfa, 0x01, 0xc0, 0x25, 0xcd, 0x8c, 0x6e, 0x97, 0x84, 0x31, 0xa3,
-	0x68, 0x5d, 0x2d, 0x8a, 0xe8, 0x40, 0x4b, 0xb2, 0x71, 0x85, 0xfc, 0xa5, 0xbf, 0x58, 0xed, 0xec,
-	0x17, 0xab, 0xdd, 0x89, 0xe4, 0x43, 0xf1, 0x48, 0x0a, 0x16, 0x75, 0xdd, 0xe5, 0xaa, 0x80, 0xbb,
-	0xdb, 0xa7, 0x67, 0xe6, 0xc2, 0x8b, 0x33, 0x73, 0xe1, 0xe5, 0x99, 0xb9, 0xf0, 0xcd, 0xc8, 0x04,
-	0xa7, 0x23, 0x13, 0xbc, 0x18, 0x99, 0xe0, 0xe5, 0xc8, 0x04, 0xaf, 0x46, 0x26, 0xf8, 0xf1, 0xb5,
-	0xb9, 0xf0, 0xe9, 0x92, 0xae, 0xe3, 0x9f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x5a, 0x41, 0xed, 0x9c,
-	0x78, 0x10, 0x00, 0x00,
+func (m *NetworkPolicyStatsList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *NetworkPolicyStatsList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkPolicyStatsList.Merge(m, src)
+}
+func (m *NetworkPolicyStatsList) XXX_Size() int {
+	return m.Size()
+}
+func (m *NetworkPolicyStatsList) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkPolicyStatsList.DiscardUnknown(m)
 }
 
-func (m *AddressGroup) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_NetworkPolicyStatsList proto.InternalMessageInfo
+
+func (m *NetworkPolicyStatus) Reset()      { *m = NetworkPolicyStatus{} }
+func (*NetworkPolicyStatus) ProtoMessage() {}
+func (*NetworkPolicyStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da8f95e0f1c69434, []int{26}
+}
+func (m *NetworkPolicyStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NetworkPolicyStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return b[:n], nil
+}
+func (m *NetworkPolicyStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkPolicyStatus.Merge(m, src)
+}
+func (m *NetworkPolicyStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *NetworkPolicyStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkPolicyStatus.DiscardUnknown(m)
 }
 
-func (m *AddressGroup) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_NetworkPolicyStatus proto.InternalMessageInfo
+
+func (m *NodeStatsSummary) Reset()      { *m = NodeStatsSummary{} }
+func (*NodeStatsSummary) ProtoMessage() {}
+func (*NodeStatsSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da8f95e0f1c69434, []int{27}
+}
+func (m *NodeStatsSummary) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NodeStatsSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *NodeStatsSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeStatsSummary.Merge(m, src)
+}
+func (m *NodeStatsSummary) XXX_Size() int {
+	return m.Size()
+}
+func (m *NodeStatsSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeStatsSummary.DiscardUnknown(m)
 }
 
-func (m *AddressGroup) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
+var xxx_messageInfo_NodeStatsSummary proto.InternalMessageInfo
+
+func (m *PodReference) Reset()      { *m = PodReference{} }
+func (*PodReference) ProtoMessage() {}
+func (*PodReference) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da8f95e0f1c69434, []int{28}
+}
+func (m *PodReference) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PodReference) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *PodReference) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PodReference.Merge(m, src)
+}
+func (m *PodReference) XXX_Size() int {
+	return m.Size()
+}
+func (m *PodReference) XXX_DiscardUnknown() {
+	xxx_messageInfo_PodReference.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PodReference proto.InternalMessageInfo
+
+func (m *RuleTrafficStats) Reset()      { *m = RuleTrafficStats{} }
+func (*RuleTrafficStats) ProtoMessage() {}
+func (*RuleTrafficStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da8f95e0f1c69434, []int{29}
+}
+func (m *RuleTrafficStats) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RuleTrafficStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *RuleTrafficStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RuleTrafficStats.Merge(m, src)
+}
+func (m *RuleTrafficStats) XXX_Size() int {
+	return m.Size()
+}
+func (m *RuleTrafficStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_RuleTrafficStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RuleTrafficStats proto.InternalMessageInfo
+
+func (m *Service) Reset()      { *m = Service{} }
+func (*Service) ProtoMessage() {}
+func (*Service) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da8f95e0f1c69434, []int{30}
+}
+func (m *Service) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Service) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *Service) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Service.Merge(m, src)
+}
+func (m *Service) XXX_Size() int {
+	return m.Size()
+}
+func (m *Service) XXX_DiscardUnknown() {
+	xxx_messageInfo_Service.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Service proto.InternalMessageInfo
+
+func (m *TrafficStats) Reset()      { *m = TrafficStats{} }
+func (*TrafficStats) ProtoMessage() {}
+func (*TrafficStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da8f95e0f1c69434, []int{31}
+}
+func (m *TrafficStats) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TrafficStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *TrafficStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TrafficStats.Merge(m, src)
+}
+func (m *TrafficStats) XXX_Size() int {
+	return m.Size()
+}
+func (m *TrafficStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_TrafficStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TrafficStats proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*AddressGroup)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.AddressGroup")
+	proto.RegisterType((*AddressGroupList)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.AddressGroupList")
+	proto.RegisterType((*AddressGroupPatch)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.AddressGroupPatch")
+	proto.RegisterType((*AppliedToGroup)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.AppliedToGroup")
+	proto.RegisterType((*AppliedToGroupList)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.AppliedToGroupList")
+	proto.RegisterType((*AppliedToGroupPatch)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.AppliedToGroupPatch")
+	proto.RegisterType((*ConnectivityQuery)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.ConnectivityQuery")
+	proto.RegisterType((*ConnectivityQueryPeer)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.ConnectivityQueryPeer")
+	proto.RegisterType((*ConnectivityQuerySpec)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.ConnectivityQuerySpec")
+	proto.RegisterType((*ConnectivityQueryStatus)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.ConnectivityQueryStatus")
+	proto.RegisterType((*ConnectivityQueryVerdict)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.ConnectivityQueryVerdict")
+	proto.RegisterType((*EndpointQuery)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.EndpointQuery")
+	proto.RegisterType((*GroupMemberPod)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.GroupMemberPod")
+	proto.RegisterType((*IPBlock)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.IPBlock")
+	proto.RegisterType((*IPNet)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.IPNet")
+	proto.RegisterType((*NamedPort)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.NamedPort")
+	proto.RegisterType((*NetworkPolicy)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.NetworkPolicy")
+	proto.RegisterType((*NetworkPolicyList)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.NetworkPolicyList")
+	proto.RegisterType((*NetworkPolicyNodeStatus)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.NetworkPolicyNodeStatus")
+	proto.RegisterType((*NetworkPolicyPeer)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.NetworkPolicyPeer")
+	proto.RegisterType((*NetworkPolicyRealizationStatus)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.NetworkPolicyRealizationStatus")
+	proto.RegisterType((*NetworkPolicyReference)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.NetworkPolicyReference")
+	proto.RegisterType((*NetworkPolicyRule)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.NetworkPolicyRule")
+	proto.RegisterType((*NetworkPolicyRuleReference)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.NetworkPolicyRuleReference")
+	proto.RegisterType((*NetworkPolicyStats)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.NetworkPolicyStats")
+	proto.RegisterType((*NetworkPolicyStatsList)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.NetworkPolicyStatsList")
+	proto.RegisterType((*NetworkPolicyStatus)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.NetworkPolicyStatus")
+	proto.RegisterType((*NodeStatsSummary)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.NodeStatsSummary")
+	proto.RegisterType((*PodReference)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.PodReference")
+	proto.RegisterType((*RuleTrafficStats)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.RuleTrafficStats")
+	proto.RegisterType((*Service)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.Service")
+	proto.RegisterType((*TrafficStats)(nil), "github.com.vmware_tanzu.antrea.pkg.apis.networking.v1beta1.TrafficStats")
+}
+
+func init() {
+	proto.RegisterFile("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1/generated.proto", fileDescriptor_da8f95e0f1c69434)
+}
+
+var fileDescriptor_da8f95e0f1c69434 = []byte{
+	// 2146 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0xcd, 0x6f, 0x1c, 0x49,
+	0x15, 0x4f, 0xf7, 0xcc, 0xd8, 0x33, 0xcf, 0xe3, 0x4d, 0x52, 0xc9, 0x92, 0x51, 0x84, 0xc6, 0xa6,
+	0x57, 0x20, 0x23, 0x6d, 0x7a, 0x36, 0x61, 0x81, 0x88, 0x8f, 0x83, 0xdb, 0x76, 0x96, 0x59, 0x25,
+	0xde, 0x49, 0xd9, 0xbb, 0x87, 0x15, 0x12, 0xb4, 0xbb, 0xcb, 0xe3, 0x8a, 0x67, 0xba, 0x7b, 0xab,
+	0x6b, 0x9c, 0x38, 0x91, 0x10, 0x70, 0x61, 0x59, 0x71, 0x58, 0xbe, 0x24, 0xf8, 0x27, 0xe0, 0x8c,
+	0xf8, 0x07, 0x72, 0x5c, 0x6e, 0xcb, 0xc5, 0x24, 0xce, 0x01, 0x21, 0x4e, 0x5c, 0x10, 0xe4, 0x84,
+	0xea, 0xa3, 0xbf, 0xc6, 0x9e, 0x8d, 0x77, 0xe7, 0x43, 0x08, 0x71, 0x73, 0xbf, 0xf7, 0xea, 0xfd,
+	0x5e, 0xbd, 0x57, 0xef, 0xa3, 0xca, 0x03, 0x6f, 0x76, 0x29, 0xdf, 0x1b, 0xec, 0xd8, 0x5e, 0xd8,
+	0x6f, 0x1d, 0xf4, 0xef, 0xbb, 0x8c, 0x5c, 0xe3, 0x6e, 0xf0, 0x70, 0xd0, 0x72, 0x03, 0xce, 0x88,
+	0xdb, 0x8a, 0xf6, 0xbb, 0x2d, 0x37, 0xa2, 0x71, 0x2b, 0x20, 0xfc, 0x7e, 0xc8, 0xf6, 0x69, 0xd0,
+	0x6d, 0x1d, 0x5c, 0xdf, 0x21, 0xdc, 0xbd, 0xde, 0xea, 0x92, 0x80, 0x30, 0x97, 0x13, 0xdf, 0x8e,
+	0x58, 0xc8, 0x43, 0xf4, 0x8d, 0x4c, 0x97, 0xad, 0x74, 0x7d, 0x4f, 0xea, 0xb2, 0x95, 0x2e, 0x3b,
+	0xda, 0xef, 0xda, 0x42, 0x97, 0x9d, 0xe9, 0xb2, 0xb5, 0xae, 0xab, 0xd7, 0x72, 0x76, 0x74, 0xc3,
+	0x6e, 0xd8, 0x92, 0x2a, 0x77, 0x06, 0xbb, 0xf2, 0x4b, 0x7e, 0xc8, 0xbf, 0x14, 0xd4, 0xd5, 0xd7,
+	0xf7, 0x6f, 0xc6, 0x36, 0x0d, 0x85, 0x69, 0x7d, 0xd7, 0xdb, 0xa3, 0x01, 0x61, 0x87, 0x99, 0xad,
+	0x7d, 0xc2, 0xdd, 0xd6, 0xc1, 0x09, 0x03, 0xaf, 0xb6, 0x46, 0xad, 0x62, 0x83, 0x80, 0xd3, 0x3e,
+	0x39, 0xb1, 0xe0, 0x6b, 0x2f, 0x5a, 0x10, 0x7b, 0x7b, 0xa4, 0xef, 0x9e, 0x58, 0xf7, 0x95, 0x51,
+	0xeb, 0x06, 0x9c, 0xf6, 0x5a, 0x34, 0xe0, 0x31, 0x67, 0xc3, 0x8b, 0xac, 0x23, 0x03, 0xea, 0xab,
+	0xbe, 0xcf, 0x48, 0x1c, 0xbf, 0xc1, 0xc2, 0x41, 0x84, 0xbe, 0x0f, 0x55, 0xb1, 0x13, 0xdf, 0xe5,
+	0x6e, 0xc3, 0x58, 0x36, 0x56, 0x16, 0x6e, 0xbc, 0x66, 0x2b, 0xc5, 0x76, 0x5e, 0x71, 0xe6, 0x57,
+	0x21, 0x6d, 0x1f, 0x5c, 0xb7, 0xdf, 0xda, 0xb9, 0x47, 0x3c, 0x7e, 0x87, 0x70, 0xd7, 0x41, 0x8f,
+	0x8f, 0x96, 0xce, 0x1d, 0x1f, 0x2d, 0x41, 0x46, 0xc3, 0xa9, 0x56, 0xd4, 0x83, 0x72, 0x14, 0xfa,
+	0x71, 0xc3, 0x5c, 0x2e, 0xad, 0x2c, 0xdc, 0x78, 0xd3, 0xfe, 0xec, 0x01, 0xb4, 0xa5, 0xc9, 0x77,
+	0x48, 0x7f, 0x87, 0xb0, 0x4e, 0xe8, 0x3b, 0x75, 0x8d, 0x5b, 0xee, 0x84, 0x7e, 0x8c, 0x25, 0x8a,
+	0xf5, 0x17, 0x03, 0x2e, 0xe4, 0x37, 0x78, 0x9b, 0xc6, 0x1c, 0x7d, 0xf7, 0xc4, 0x26, 0xed, 0xb3,
+	0x6d, 0x52, 0xac, 0x96, 0x5b, 0xbc, 0xa0, 0xa1, 0xaa, 0x09, 0x25, 0xb7, 0xc1, 0x3e, 0x54, 0x28,
+	0x27, 0xfd, 0x64, 0x87, 0xdf, 0x19, 0x67, 0x87, 0x79, 0xd3, 0x9d, 0x45, 0x0d, 0x5a, 0x69, 0x0b,
+	0xf5, 0x58, 0xa1, 0x58, 0xff, 0x34, 0xe1, 0x62, 0x5e, 0xac, 0xe3, 0x72, 0x6f, 0x6f, 0x06, 0x71,
+	0x7c, 0x04, 0x35, 0xd7, 0xf7, 0x89, 0xdf, 0x99, 0x4e, 0x30, 0x2f, 0x6a, 0xf0, 0xda, 0x6a, 0x02,
+	0x82, 0x33, 0x3c, 0xf4, 0x23, 0x03, 0x16, 0x18, 0xe9, 0x87, 0x07, 0x1a, 0xbf, 0x34, 0x71, 0xfc,
+	0x4b, 0x1a, 0x7f, 0x01, 0x67, 0x30, 0x38, 0x8f, 0x69, 0x3d, 0x31, 0xe0, 0xa5, 0xd5, 0x28, 0xea,
+	0x51, 0xe2, 0x6f, 0x87, 0xff, 0x9b, 0xd9, 0xf3, 0xcc, 0x00, 0x54, 0xdc, 0xe2, 0x0c, 0xf2, 0x27,
+	0x2c, 0xe6, 0xcf, 0x58, 0x7b, 0x2c, 0x1a, 0x3f, 0x22, 0x83, 0xfe, 0x65, 0xc2, 0xa5, 0xa2, 0xe0,
+	0xff, 0x73, 0x68, 0x46, 0x39, 0xf4, 0x57, 0x13, 0x2e, 0xae, 0x85, 0x41, 0x40, 0x3c, 0x4e, 0x0f,
+	0x28, 0x3f, 0xbc, 0x3b, 0x20, 0xec, 0x70, 0x06, 0x8e, 0x8f, 0xa1, 0x1c, 0x47, 0xc4, 0x6b, 0x98,
+	0x52, 0xfb, 0xdd, 0x71, 0xf6, 0x7c, 0xc2, 0xfc, 0xad, 0x88, 0x78, 0x59, 0x36, 0x89, 0x2f, 0x2c,
+	0xc1, 0xd0, 0x23, 0x98, 0x8b, 0xb9, 0xcb, 0x07, 0xc2, 0xd5, 0x02, 0x76, 0x6b, 0xb2, 0xb0, 0x52,
+	0xb5, 0xf3, 0x92, 0x06, 0x9e, 0x53, 0xdf, 0x58, 0x43, 0x5a, 0xbf, 0x31, 0xe0, 0xe5, 0x13, 0x6b,
+	0x3a, 0x84, 0x30, 0xe4, 0x41, 0x29, 0x0a, 0x7d, 0xed, 0xe8, 0xb1, 0xba, 0x55, 0x27, 0xf4, 0x31,
+	0xd9, 0x25, 0x8c, 0x04, 0x1e, 0x71, 0xe6, 0x8f, 0x8f, 0x96, 0x4a, 0x82, 0x22, 0xb4, 0xa3, 0xab,
+	0x60, 0xd2, 0x48, 0xba, 0xbb, 0xe6, 0x80, 0x36, 0xd1, 0x6c, 0x77, 0xb0, 0x49, 0x23, 0xeb, 0xdf,
+	0xe6, 0x29, 0xa6, 0x09, 0xbf, 0xa1, 0x43, 0x98, 0x8b, 0xc3, 0x01, 0xf3, 0x48, 0xc3, 0x98, 0x42,
+	0xa0, 0xc4, 0xee, 0x73, 0xfe, 0x92, 0x40, 0x58, 0x03, 0xa2, 0xf7, 0x0d, 0x58, 0xf0, 0x49, 0xcc,
+	0x69, 0xe0, 0x72, 0x1a, 0x06, 0x0d, 0x73, 0x5a, 0x06, 0xa4, 0x49, 0xb2, 0x9e, 0xa1, 0xe1, 0x3c,
+	0x34, 0xba, 0x09, 0x55, 0x39, 0xad, 0x79, 0x61, 0x4f, 0x9e, 0x9c, 0x9a, 0xf3, 0xf9, 0xa4, 0x7c,
+	0x76, 0x34, 0xfd, 0x79, 0xee, 0x6f, 0x9c, 0x4a, 0xa3, 0x65, 0xd1, 0x2d, 0x18, 0x6f, 0x94, 0x97,
+	0x8d, 0x95, 0x4a, 0xbe, 0xc2, 0x33, 0x8e, 0x25, 0xc7, 0xfa, 0xa3, 0x09, 0x57, 0x46, 0x1c, 0x25,
+	0xf4, 0x65, 0x98, 0x77, 0x7b, 0xbd, 0xf0, 0x3e, 0x51, 0x87, 0xa3, 0xea, 0x9c, 0xd7, 0x0a, 0xe6,
+	0x57, 0x15, 0x19, 0x27, 0x7c, 0xf4, 0x00, 0xe6, 0x48, 0x57, 0x8c, 0x20, 0xda, 0x4f, 0xdb, 0x13,
+	0xf5, 0xd3, 0x3b, 0x84, 0xf9, 0xd4, 0xe3, 0x0e, 0x88, 0x38, 0x6d, 0x48, 0x1c, 0xac, 0xf1, 0xd0,
+	0x23, 0x98, 0xa7, 0x81, 0x82, 0x2e, 0x4d, 0x11, 0x7a, 0x41, 0x6c, 0xbb, 0xad, 0x80, 0x70, 0x82,
+	0x68, 0xfd, 0xcd, 0x80, 0xc6, 0xa8, 0x25, 0x9f, 0xc6, 0x7d, 0x1c, 0xca, 0x6c, 0xd0, 0x23, 0xda,
+	0x79, 0xef, 0x8c, 0xb3, 0x83, 0x4d, 0x45, 0xea, 0x84, 0x3d, 0xea, 0x1d, 0xe2, 0x41, 0x8f, 0x64,
+	0x19, 0x59, 0x15, 0xb1, 0x97, 0x24, 0x89, 0x86, 0x5e, 0x85, 0x2a, 0x8d, 0xc3, 0x9e, 0xb8, 0x0e,
+	0x48, 0xdf, 0x55, 0xb3, 0xb6, 0xdc, 0xd6, 0x74, 0x9c, 0x4a, 0x58, 0xbf, 0x2c, 0xc1, 0xe2, 0x46,
+	0xe0, 0x47, 0x21, 0x0d, 0xf8, 0xac, 0xca, 0xf4, 0xaf, 0x0d, 0x38, 0xef, 0xaa, 0xce, 0x2c, 0x37,
+	0x44, 0x49, 0xd2, 0x26, 0xf1, 0xe4, 0x7c, 0x94, 0xfa, 0xe7, 0x8a, 0xb6, 0xe5, 0xfc, 0x6a, 0x11,
+	0x12, 0x0f, 0xdb, 0x80, 0x7e, 0x62, 0x40, 0x2d, 0x22, 0x84, 0x09, 0x67, 0x26, 0x8d, 0x73, 0x5a,
+	0x51, 0x4b, 0x9b, 0x78, 0x27, 0x01, 0xc4, 0x19, 0xb6, 0xf5, 0x3b, 0x13, 0x5e, 0x2a, 0x76, 0xdd,
+	0xd9, 0xd4, 0xf3, 0x57, 0xd2, 0x7a, 0x5e, 0x77, 0x2e, 0xa9, 0x5a, 0xfe, 0xfc, 0x68, 0xa9, 0xd6,
+	0xee, 0xe8, 0xab, 0x88, 0x28, 0xec, 0xe8, 0x1e, 0x54, 0x44, 0x91, 0x49, 0x3c, 0xb4, 0x31, 0x96,
+	0x87, 0xdc, 0xbe, 0x08, 0x00, 0xe3, 0xd9, 0x10, 0x27, 0xbe, 0x62, 0xac, 0x20, 0xd0, 0x97, 0xa0,
+	0x44, 0xa3, 0xb8, 0x51, 0x5e, 0x2e, 0xad, 0xd4, 0x9d, 0xcb, 0xc2, 0xd6, 0x76, 0x27, 0x2e, 0x9a,
+	0x24, 0x04, 0xac, 0x3f, 0x1b, 0x30, 0xdf, 0xee, 0x38, 0xbd, 0xd0, 0xdb, 0x47, 0x1e, 0x94, 0x3d,
+	0xea, 0x33, 0xed, 0xaa, 0xd5, 0x71, 0xcc, 0x6b, 0x77, 0x36, 0x09, 0xcf, 0x2a, 0xec, 0x5a, 0x7b,
+	0x1d, 0x63, 0xa9, 0x1c, 0x51, 0x98, 0x23, 0x0f, 0x3c, 0x12, 0x71, 0x7d, 0x72, 0x27, 0x00, 0x93,
+	0xf6, 0xac, 0x0d, 0xa9, 0x18, 0x6b, 0x00, 0x6b, 0x17, 0x2a, 0x52, 0x40, 0x47, 0xc7, 0xf8, 0xe4,
+	0xe8, 0xdc, 0x84, 0x7a, 0xc4, 0xc8, 0x2e, 0x7d, 0x70, 0x9b, 0x04, 0x5d, 0xbe, 0x27, 0x83, 0x59,
+	0x71, 0x2e, 0x6b, 0xdd, 0xf5, 0x4e, 0x8e, 0x87, 0x0b, 0x92, 0xd6, 0x4f, 0x0d, 0xa8, 0xa5, 0xf1,
+	0x48, 0x9b, 0x8c, 0x31, 0xaa, 0xc9, 0x08, 0x89, 0xc0, 0xed, 0x13, 0xdd, 0xfe, 0x53, 0x09, 0xa1,
+	0x02, 0x4b, 0xce, 0x67, 0x6f, 0x71, 0xd6, 0xaf, 0xca, 0xb0, 0x58, 0xc8, 0x9e, 0x19, 0x94, 0x25,
+	0x06, 0x15, 0x26, 0x33, 0x5f, 0x45, 0xf4, 0xce, 0x44, 0x33, 0x3f, 0x3b, 0xdf, 0x2a, 0xd9, 0x15,
+	0x14, 0xfa, 0x76, 0x5a, 0x09, 0xf5, 0x1d, 0x45, 0x65, 0x55, 0xcd, 0xb9, 0x94, 0xab, 0x58, 0x09,
+	0x0b, 0x0f, 0xcb, 0xa2, 0x15, 0xe1, 0x60, 0x1a, 0x32, 0xca, 0x0f, 0xe5, 0x34, 0x60, 0x38, 0x75,
+	0xe5, 0x5c, 0x45, 0xc3, 0x29, 0x17, 0xad, 0x43, 0x9d, 0x53, 0xc2, 0x12, 0x4e, 0xa3, 0xb2, 0x6c,
+	0xac, 0x2c, 0x3a, 0xcb, 0xe2, 0x48, 0x6c, 0xe7, 0xe8, 0xcf, 0x87, 0xbe, 0x71, 0x61, 0x15, 0xfa,
+	0x41, 0x3a, 0xeb, 0xce, 0xc9, 0x10, 0xbc, 0x3b, 0xc1, 0x7a, 0xed, 0xf6, 0xe8, 0x43, 0x39, 0x19,
+	0xe9, 0x91, 0x17, 0x4e, 0x19, 0x77, 0x9f, 0x1a, 0x70, 0xb1, 0xb0, 0x6c, 0x06, 0x17, 0xd7, 0xa0,
+	0x78, 0x71, 0x6d, 0x4f, 0x6c, 0xcb, 0x23, 0xee, 0xad, 0xcf, 0x0c, 0xb8, 0x52, 0x90, 0xdb, 0x0c,
+	0x7d, 0xa2, 0x67, 0xb7, 0x57, 0xa1, 0x1a, 0x84, 0x3e, 0x11, 0x29, 0x26, 0x77, 0x5a, 0xcb, 0x2c,
+	0xdf, 0xd4, 0x74, 0x9c, 0x4a, 0xa0, 0x1b, 0x00, 0xfa, 0x65, 0x30, 0x19, 0x75, 0x4b, 0x59, 0x0a,
+	0xbc, 0x91, 0x72, 0x70, 0x4e, 0x0a, 0x7d, 0x15, 0x16, 0x76, 0x5d, 0xda, 0x23, 0x7e, 0xd2, 0x04,
+	0x45, 0xf6, 0xa7, 0xc3, 0xec, 0xad, 0x8c, 0x85, 0xf3, 0x72, 0xa8, 0x05, 0xb5, 0x9e, 0x1b, 0xf3,
+	0x0d, 0xc6, 0x42, 0x26, 0x4f, 0x62, 0x2d, 0xeb, 0x70, 0xb7, 0x13, 0x06, 0xce, 0x64, 0xac, 0x27,
+	0xc3, 0x91, 0x94, 0x97, 0x96, 0xaf, 0xc3, 0xa2, 0x9b, 0x7b, 0xf4, 0x8a, 0x1b, 0x86, 0x4c, 0x86,
+	0x8b, 0xc7, 0x47, 0x4b, 0x8b, 0xf9, 0xd7, 0xb0, 0x18, 0x17, 0xe5, 0xd0, 0x7b, 0x50, 0xa5, 0x91,
+	0x2c, 0xff, 0x49, 0x9c, 0xd6, 0xc6, 0x2b, 0xc8, 0x52, 0x57, 0x6e, 0x72, 0x52, 0x84, 0x18, 0xa7,
+	0x30, 0x68, 0x09, 0x2a, 0xbb, 0xef, 0xf9, 0x41, 0x92, 0xb0, 0x35, 0x11, 0xc8, 0x5b, 0x77, 0xd7,
+	0x37, 0x63, 0xac, 0xe8, 0xd6, 0x9f, 0x4c, 0x68, 0x7e, 0xf2, 0x19, 0x47, 0x1d, 0xb8, 0xec, 0x0d,
+	0x18, 0x23, 0x01, 0x17, 0xe1, 0x8b, 0x95, 0x80, 0x9e, 0x2c, 0x2b, 0x69, 0xb1, 0xbc, 0xbc, 0x76,
+	0x8a, 0x0c, 0x3e, 0x75, 0xa5, 0xd0, 0xe8, 0x93, 0x98, 0x32, 0xe2, 0x17, 0x35, 0x9a, 0x45, 0x8d,
+	0xeb, 0xa7, 0xc8, 0xe0, 0x53, 0x57, 0xa2, 0x0f, 0x8c, 0xe4, 0x48, 0x48, 0xba, 0xee, 0xfa, 0x5b,
+	0x13, 0x4b, 0x83, 0xec, 0x78, 0x0f, 0x9f, 0x33, 0x65, 0x47, 0x1e, 0xdc, 0xfa, 0xbd, 0x01, 0x9f,
+	0x3b, 0x7d, 0xce, 0x13, 0x47, 0x50, 0x34, 0x9d, 0x38, 0x72, 0xbd, 0x24, 0x39, 0xd2, 0x23, 0xb8,
+	0x99, 0x30, 0x70, 0x26, 0x73, 0x86, 0xfe, 0xe5, 0x40, 0x69, 0x40, 0x7d, 0xdd, 0xba, 0x5e, 0xd3,
+	0x02, 0xa5, 0xb7, 0xdb, 0xeb, 0xcf, 0x8f, 0x96, 0xbe, 0x30, 0xea, 0x61, 0x9e, 0x1f, 0x46, 0x24,
+	0xb6, 0xdf, 0x6e, 0xaf, 0x63, 0xb1, 0xd8, 0xfa, 0x6d, 0x65, 0xe8, 0xa0, 0x8b, 0x84, 0x41, 0xdf,
+	0x82, 0x9a, 0x4f, 0x19, 0xf1, 0x64, 0x66, 0x2a, 0x63, 0x9b, 0x89, 0xb1, 0xeb, 0x09, 0xe3, 0x79,
+	0xfe, 0x03, 0x67, 0x0b, 0x50, 0x08, 0xe5, 0x5d, 0x16, 0xf6, 0xf5, 0xc5, 0x62, 0x72, 0x8d, 0x4a,
+	0xde, 0x5c, 0x53, 0x47, 0xdc, 0x62, 0x61, 0x1f, 0x4b, 0x20, 0x44, 0xc1, 0xe4, 0x61, 0xa3, 0x34,
+	0x0d, 0xb8, 0xf4, 0xd9, 0x60, 0x3b, 0xc4, 0x26, 0x0f, 0x45, 0x26, 0xc7, 0x84, 0x1d, 0x50, 0x8f,
+	0xa8, 0xb1, 0x6f, 0xcc, 0x4c, 0xde, 0x52, 0xba, 0xb2, 0x4c, 0xd6, 0x84, 0x18, 0xa7, 0x30, 0xa2,
+	0xaa, 0x46, 0xf9, 0xbe, 0x58, 0xc9, 0xa4, 0x4f, 0xe9, 0xa4, 0xf7, 0x60, 0xce, 0x55, 0x71, 0x9b,
+	0x93, 0x71, 0xc3, 0xa2, 0x4f, 0xad, 0x26, 0x01, 0x5b, 0x3f, 0xeb, 0x7f, 0xc1, 0x62, 0xe2, 0x0d,
+	0x84, 0xbe, 0xd6, 0xc1, 0x75, 0xb7, 0x17, 0xed, 0xb9, 0xd7, 0x6d, 0x71, 0x30, 0x94, 0x1e, 0xac,
+	0x11, 0xd0, 0x37, 0x61, 0x91, 0x04, 0xee, 0x4e, 0x8f, 0xdc, 0x0e, 0xbb, 0x5d, 0x1a, 0x74, 0x1b,
+	0xf3, 0xf2, 0x42, 0xf7, 0xb2, 0x36, 0x6f, 0x71, 0x23, 0xcf, 0xc4, 0x45, 0xd9, 0xf4, 0x7c, 0x57,
+	0x47, 0x9d, 0x6f, 0xf1, 0x4e, 0x77, 0x75, 0xf4, 0x1d, 0x05, 0x3d, 0x84, 0xb9, 0x48, 0x92, 0x75,
+	0x57, 0x9d, 0xc6, 0xed, 0x2c, 0x1d, 0x7a, 0x35, 0x43, 0x23, 0x16, 0x13, 0xc4, 0xfc, 0xb4, 0x09,
+	0xf2, 0x0a, 0x54, 0x68, 0xe0, 0x93, 0x07, 0xba, 0x7f, 0x65, 0x8d, 0x56, 0x10, 0xb1, 0xe2, 0xe5,
+	0x02, 0x59, 0x9e, 0x76, 0x20, 0xad, 0x0f, 0x4b, 0x80, 0x0a, 0x1e, 0x10, 0x15, 0x2f, 0x9e, 0xc1,
+	0x50, 0xfb, 0x63, 0x03, 0xea, 0x9c, 0xb9, 0xbb, 0xbb, 0xd4, 0x93, 0x90, 0x0d, 0x73, 0xfc, 0x0b,
+	0xe4, 0x76, 0x4e, 0x5f, 0x76, 0xb3, 0xc8, 0x53, 0x71, 0x01, 0x13, 0xfd, 0xc2, 0x80, 0x0b, 0x62,
+	0xde, 0xcd, 0x8b, 0xe8, 0x3e, 0x72, 0x7b, 0x1c, 0x43, 0xf0, 0x90, 0x4e, 0xa7, 0xa1, 0x8d, 0xb9,
+	0x30, 0xcc, 0xc1, 0x27, 0xf0, 0xad, 0xbf, 0x0f, 0xb7, 0x12, 0x49, 0x9e, 0xc1, 0x40, 0x19, 0x17,
+	0x07, 0xca, 0xcd, 0x89, 0x65, 0x95, 0xf2, 0xc1, 0xe9, 0x53, 0xe5, 0x3f, 0x0c, 0xb8, 0x74, 0x42,
+	0x78, 0x30, 0x8b, 0x13, 0xf8, 0x00, 0x2a, 0x81, 0x1c, 0x1c, 0xcc, 0xe9, 0x0d, 0x0e, 0xe9, 0x9e,
+	0xd5, 0xc8, 0xa0, 0x00, 0xad, 0xf7, 0x4d, 0xb8, 0x90, 0x08, 0xc5, 0x5b, 0x83, 0x7e, 0xdf, 0x9d,
+	0xc9, 0xf3, 0xd6, 0xcf, 0x0d, 0x38, 0x1f, 0xe4, 0x0c, 0xa5, 0x64, 0x5a, 0xa1, 0x4e, 0x9f, 0xb6,
+	0x36, 0x8b, 0x70, 0x78, 0x18, 0xdf, 0x72, 0xa1, 0x9e, 0x7f, 0xf6, 0x49, 0x7b, 0x83, 0x31, 0x72,
+	0xf6, 0x29, 0x8c, 0x53, 0xe6, 0x8b, 0xc7, 0x29, 0xeb, 0x0f, 0x06, 0x9c, 0x48, 0xbb, 0x33, 0xe0,
+	0xfc, 0x37, 0x14, 0x28, 0xeb, 0x03, 0x13, 0xe6, 0xf5, 0x60, 0x80, 0x5e, 0xcf, 0x3d, 0x5a, 0x28,
+	0xb3, 0x1b, 0x67, 0x78, 0x93, 0xdf, 0xd4, 0xcf, 0x25, 0xe6, 0x0b, 0x8e, 0x94, 0xf8, 0xd9, 0x86,
+	0xad, 0x7e, 0xb6, 0x61, 0xb7, 0x03, 0xfe, 0x16, 0xdb, 0xe2, 0x8c, 0x06, 0x5d, 0xa7, 0x3a, 0xf4,
+	0xb8, 0xf2, 0x45, 0x98, 0x27, 0x81, 0x7c, 0x89, 0xd1, 0x3d, 0x4c, 0x3e, 0x55, 0x6f, 0x28, 0x12,
+	0x4e, 0x78, 0xe2, 0x01, 0x80, 0x7a, 0xfd, 0x68, 0xfb, 0x30, 0x22, 0xc9, 0xbf, 0x03, 0xe4, 0x75,
+	0x65, 0xed, 0x4e, 0x47, 0xd0, 0x70, 0xca, 0x4d, 0x24, 0xd7, 0x42, 0x9f, 0x34, 0x2a, 0x45, 0x49,
+	0x41, 0xc3, 0x29, 0xd7, 0xfa, 0x99, 0x01, 0x05, 0x5f, 0x89, 0x27, 0xef, 0xc8, 0xf5, 0xf6, 0x09,
+	0x8f, 0xa5, 0x43, 0x4a, 0xd9, 0x93, 0x77, 0x47, 0x91, 0x71, 0xc2, 0x17, 0x8d, 0x77, 0xe7, 0x90,
+	0x93, 0x58, 0xdf, 0x36, 0xd3, 0xbc, 0x74, 0x04, 0x11, 0x2b, 0x9e, 0x98, 0xb7, 0x62, 0x12, 0xc7,
+	0x34, 0x0c, 0xd4, 0x05, 0xb3, 0x94, 0x9f, 0xce, 0x14, 0x1d, 0xa7, 0x12, 0xce, 0xb5, 0xc7, 0x4f,
+	0x9b, 0xe7, 0x3e, 0x7a, 0xda, 0x3c, 0xf7, 0xf1, 0xd3, 0xe6, 0xb9, 0x1f, 0x1e, 0x37, 0x8d, 0xc7,
+	0xc7, 0x4d, 0xe3, 0xa3, 0xe3, 0xa6, 0xf1, 0xf1, 0x71, 0xd3, 0x78, 0x72, 0xdc, 0x34, 0x3e, 0x7c,
+	0xd6, 0x3c, 0xf7, 0xee, 0xbc, 0x8e, 0xfd, 0x7f, 0x06, 0x00, 0xa3, 0xa2, 0xab, 0xf7, 0x87, 0x24,
+	0x00, 0x00,
+}
+
+func (m *AddressGroup) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddressGroup) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddressGroup) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
 	_ = l
 	if len(m.Pods) > 0 {
 		for iNdEx := len(m.Pods) - 1; iNdEx >= 0; iNdEx-- {
@@ -895,7 +1429,7 @@ func (m *AppliedToGroupPatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *GroupMemberPod) Marshal() (dAtA []byte, err error) {
+func (m *ConnectivityQuery) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -905,37 +1439,74 @@ func (m *GroupMemberPod) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GroupMemberPod) MarshalTo(dAtA []byte) (int, error) {
+func (m *ConnectivityQuery) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GroupMemberPod) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ConnectivityQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Ports) > 0 {
-		for iNdEx := len(m.Ports) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Ports[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintGenerated(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
+	{
+		size, err := m.Status.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
 		}
+		i -= size
+		i = encodeVarintGenerated(dAtA, i, uint64(size))
 	}
-	if m.IP != nil {
-		i -= len(m.IP)
-		copy(dAtA[i:], m.IP)
-		i = encodeVarintGenerated(dAtA, i, uint64(len(m.IP)))
-		i--
-		dAtA[i] = 0x12
+	i--
+	dAtA[i] = 0x1a
+	{
+		size, err := m.Spec.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenerated(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	{
+		size, err := m.ObjectMeta.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenerated(dAtA, i, uint64(size))
 	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *ConnectivityQueryPeer) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnectivityQueryPeer) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConnectivityQueryPeer) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	i -= len(m.IP)
+	copy(dAtA[i:], m.IP)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.IP)))
+	i--
+	dAtA[i] = 0x12
 	if m.Pod != nil {
 		{
 			size, err := m.Pod.MarshalToSizedBuffer(dAtA[:i])
@@ -951,7 +1522,7 @@ func (m *GroupMemberPod) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *IPBlock) Marshal() (dAtA []byte, err error) {
+func (m *ConnectivityQuerySpec) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -961,32 +1532,36 @@ func (m *IPBlock) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *IPBlock) MarshalTo(dAtA []byte) (int, error) {
+func (m *ConnectivityQuerySpec) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *IPBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ConnectivityQuerySpec) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Except) > 0 {
-		for iNdEx := len(m.Except) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Except[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintGenerated(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
+	i = encodeVarintGenerated(dAtA, i, uint64(m.Port))
+	i--
+	dAtA[i] = 0x20
+	i -= len(m.Protocol)
+	copy(dAtA[i:], m.Protocol)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Protocol)))
+	i--
+	dAtA[i] = 0x1a
+	{
+		size, err := m.Destination.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
 		}
+		i -= size
+		i = encodeVarintGenerated(dAtA, i, uint64(size))
 	}
+	i--
+	dAtA[i] = 0x12
 	{
-		size, err := m.CIDR.MarshalToSizedBuffer(dAtA[:i])
+		size, err := m.Source.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
 			return 0, err
 		}
@@ -998,7 +1573,7 @@ func (m *IPBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *IPNet) Marshal() (dAtA []byte, err error) {
+func (m *ConnectivityQueryStatus) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1008,66 +1583,103 @@ func (m *IPNet) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *IPNet) MarshalTo(dAtA []byte) (int, error) {
+func (m *ConnectivityQueryStatus) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *IPNet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ConnectivityQueryStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	i = encodeVarintGenerated(dAtA, i, uint64(m.PrefixLength))
-	i--
-	dAtA[i] = 0x10
-	if m.IP != nil {
-		i -= len(m.IP)
-		copy(dAtA[i:], m.IP)
-		i = encodeVarintGenerated(dAtA, i, uint64(len(m.IP)))
+	if m.Ingress != nil {
+		{
+			size, err := m.Ingress.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGenerated(dAtA, i, uint64(size))
+		}
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x1a
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *NamedPort) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+	if m.Egress != nil {
+		{
+			size, err := m.Egress.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGenerated(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	i--
+	if m.Allowed {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
+	}
+	i--
+	dAtA[i] = 0x8
+	return len(dAtA) - i, nil
+}
+
+func (m *ConnectivityQueryVerdict) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
 		return nil, err
 	}
 	return dAtA[:n], nil
 }
 
-func (m *NamedPort) MarshalTo(dAtA []byte) (int, error) {
+func (m *ConnectivityQueryVerdict) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *NamedPort) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ConnectivityQueryVerdict) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	i -= len(m.Protocol)
-	copy(dAtA[i:], m.Protocol)
-	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Protocol)))
 	i--
-	dAtA[i] = 0x1a
-	i -= len(m.Name)
-	copy(dAtA[i:], m.Name)
-	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Name)))
+	if m.Isolated {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
+	}
 	i--
-	dAtA[i] = 0x12
-	i = encodeVarintGenerated(dAtA, i, uint64(m.Port))
+	dAtA[i] = 0x18
+	if m.Rule != nil {
+		{
+			size, err := m.Rule.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGenerated(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	i--
+	if m.Allowed {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
+	}
 	i--
 	dAtA[i] = 0x8
 	return len(dAtA) - i, nil
 }
 
-func (m *NetworkPolicy) Marshal() (dAtA []byte, err error) {
+func (m *EndpointQuery) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1077,29 +1689,34 @@ func (m *NetworkPolicy) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *NetworkPolicy) MarshalTo(dAtA []byte) (int, error) {
+func (m *EndpointQuery) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *NetworkPolicy) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *EndpointQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.AppliedToGroups) > 0 {
-		for iNdEx := len(m.AppliedToGroups) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.AppliedToGroups[iNdEx])
-			copy(dAtA[i:], m.AppliedToGroups[iNdEx])
-			i = encodeVarintGenerated(dAtA, i, uint64(len(m.AppliedToGroups[iNdEx])))
+	if len(m.PeerRules) > 0 {
+		for iNdEx := len(m.PeerRules) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.PeerRules[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenerated(dAtA, i, uint64(size))
+			}
 			i--
 			dAtA[i] = 0x1a
 		}
 	}
-	if len(m.Rules) > 0 {
-		for iNdEx := len(m.Rules) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.AppliedPolicies) > 0 {
+		for iNdEx := len(m.AppliedPolicies) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Rules[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.AppliedPolicies[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -1123,7 +1740,7 @@ func (m *NetworkPolicy) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *NetworkPolicyList) Marshal() (dAtA []byte, err error) {
+func (m *GroupMemberPod) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1133,20 +1750,29 @@ func (m *NetworkPolicyList) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *NetworkPolicyList) MarshalTo(dAtA []byte) (int, error) {
+func (m *GroupMemberPod) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *NetworkPolicyList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *GroupMemberPod) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Items) > 0 {
-		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.IPs) > 0 {
+		for iNdEx := len(m.IPs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.IPs[iNdEx])
+			copy(dAtA[i:], m.IPs[iNdEx])
+			i = encodeVarintGenerated(dAtA, i, uint64(len(m.IPs[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Ports) > 0 {
+		for iNdEx := len(m.Ports) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.Ports[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -1154,23 +1780,32 @@ func (m *NetworkPolicyList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 				i = encodeVarintGenerated(dAtA, i, uint64(size))
 			}
 			i--
-			dAtA[i] = 0x12
+			dAtA[i] = 0x1a
 		}
 	}
-	{
-		size, err := m.ListMeta.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
+	if m.IP != nil {
+		i -= len(m.IP)
+		copy(dAtA[i:], m.IP)
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.IP)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Pod != nil {
+		{
+			size, err := m.Pod.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGenerated(dAtA, i, uint64(size))
 		}
-		i -= size
-		i = encodeVarintGenerated(dAtA, i, uint64(size))
+		i--
+		dAtA[i] = 0xa
 	}
-	i--
-	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
 }
 
-func (m *NetworkPolicyPeer) Marshal() (dAtA []byte, err error) {
+func (m *IPBlock) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1180,20 +1815,20 @@ func (m *NetworkPolicyPeer) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *NetworkPolicyPeer) MarshalTo(dAtA []byte) (int, error) {
+func (m *IPBlock) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *NetworkPolicyPeer) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *IPBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.IPBlocks) > 0 {
-		for iNdEx := len(m.IPBlocks) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.Except) > 0 {
+		for iNdEx := len(m.Except) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.IPBlocks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.Except[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -1204,19 +1839,20 @@ func (m *NetworkPolicyPeer) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			dAtA[i] = 0x12
 		}
 	}
-	if len(m.AddressGroups) > 0 {
-		for iNdEx := len(m.AddressGroups) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.AddressGroups[iNdEx])
-			copy(dAtA[i:], m.AddressGroups[iNdEx])
-			i = encodeVarintGenerated(dAtA, i, uint64(len(m.AddressGroups[iNdEx])))
-			i--
-			dAtA[i] = 0xa
+	{
+		size, err := m.CIDR.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
 		}
+		i -= size
+		i = encodeVarintGenerated(dAtA, i, uint64(size))
 	}
+	i--
+	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
 }
 
-func (m *NetworkPolicyRule) Marshal() (dAtA []byte, err error) {
+func (m *IPNet) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1226,59 +1862,30 @@ func (m *NetworkPolicyRule) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *NetworkPolicyRule) MarshalTo(dAtA []byte) (int, error) {
+func (m *IPNet) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *NetworkPolicyRule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *IPNet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Services) > 0 {
-		for iNdEx := len(m.Services) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Services[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintGenerated(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x22
-		}
-	}
-	{
-		size, err := m.To.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintGenerated(dAtA, i, uint64(size))
-	}
+	i = encodeVarintGenerated(dAtA, i, uint64(m.PrefixLength))
 	i--
-	dAtA[i] = 0x1a
-	{
-		size, err := m.From.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintGenerated(dAtA, i, uint64(size))
+	dAtA[i] = 0x10
+	if m.IP != nil {
+		i -= len(m.IP)
+		copy(dAtA[i:], m.IP)
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.IP)))
+		i--
+		dAtA[i] = 0xa
 	}
-	i--
-	dAtA[i] = 0x12
-	i -= len(m.Direction)
-	copy(dAtA[i:], m.Direction)
-	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Direction)))
-	i--
-	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
 }
 
-func (m *PodReference) Marshal() (dAtA []byte, err error) {
+func (m *NamedPort) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1288,30 +1895,33 @@ func (m *PodReference) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PodReference) MarshalTo(dAtA []byte) (int, error) {
+func (m *NamedPort) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *PodReference) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *NamedPort) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	i -= len(m.Namespace)
-	copy(dAtA[i:], m.Namespace)
-	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Namespace)))
+	i -= len(m.Protocol)
+	copy(dAtA[i:], m.Protocol)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Protocol)))
 	i--
-	dAtA[i] = 0x12
+	dAtA[i] = 0x1a
 	i -= len(m.Name)
 	copy(dAtA[i:], m.Name)
 	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Name)))
 	i--
-	dAtA[i] = 0xa
+	dAtA[i] = 0x12
+	i = encodeVarintGenerated(dAtA, i, uint64(m.Port))
+	i--
+	dAtA[i] = 0x8
 	return len(dAtA) - i, nil
 }
 
-func (m *Service) Marshal() (dAtA []byte, err error) {
+func (m *NetworkPolicy) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1321,19 +1931,19 @@ func (m *Service) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Service) MarshalTo(dAtA []byte) (int, error) {
+func (m *NetworkPolicy) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Service) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *NetworkPolicy) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Port != nil {
+	if m.Status != nil {
 		{
-			size, err := m.Port.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Status.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -1341,591 +1951,4429 @@ func (m *Service) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintGenerated(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x32
 	}
-	if m.Protocol != nil {
-		i -= len(*m.Protocol)
-		copy(dAtA[i:], *m.Protocol)
-		i = encodeVarintGenerated(dAtA, i, uint64(len(*m.Protocol)))
+	if m.TierPriority != nil {
+		i = encodeVarintGenerated(dAtA, i, uint64(*m.TierPriority))
 		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
-}
-
-func encodeVarintGenerated(dAtA []byte, offset int, v uint64) int {
-	offset -= sovGenerated(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+		dAtA[i] = 0x28
 	}
-	dAtA[offset] = uint8(v)
-	return base
-}
-func (m *AddressGroup) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.Priority != nil {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(*m.Priority))))
+		i--
+		dAtA[i] = 0x21
 	}
-	var l int
-	_ = l
-	l = m.ObjectMeta.Size()
-	n += 1 + l + sovGenerated(uint64(l))
-	if len(m.Pods) > 0 {
-		for _, e := range m.Pods {
-			l = e.Size()
-			n += 1 + l + sovGenerated(uint64(l))
+	if len(m.AppliedToGroups) > 0 {
+		for iNdEx := len(m.AppliedToGroups) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.AppliedToGroups[iNdEx])
+			copy(dAtA[i:], m.AppliedToGroups[iNdEx])
+			i = encodeVarintGenerated(dAtA, i, uint64(len(m.AppliedToGroups[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
 		}
 	}
-	return n
-}
-
-func (m *AddressGroupList) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.Rules) > 0 {
+		for iNdEx := len(m.Rules) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Rules[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenerated(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
 	}
-	var l int
-	_ = l
-	l = m.ListMeta.Size()
-	n += 1 + l + sovGenerated(uint64(l))
-	if len(m.Items) > 0 {
-		for _, e := range m.Items {
-			l = e.Size()
-			n += 1 + l + sovGenerated(uint64(l))
+	{
+		size, err := m.ObjectMeta.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
 		}
+		i -= size
+		i = encodeVarintGenerated(dAtA, i, uint64(size))
 	}
-	return n
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *AddressGroupPatch) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *NetworkPolicyList) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *NetworkPolicyList) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NetworkPolicyList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = m.ObjectMeta.Size()
-	n += 1 + l + sovGenerated(uint64(l))
-	if len(m.AddedPods) > 0 {
-		for _, e := range m.AddedPods {
-			l = e.Size()
-			n += 1 + l + sovGenerated(uint64(l))
+	if len(m.Items) > 0 {
+		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenerated(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
 		}
 	}
-	if len(m.RemovedPods) > 0 {
-		for _, e := range m.RemovedPods {
-			l = e.Size()
-			n += 1 + l + sovGenerated(uint64(l))
+	{
+		size, err := m.ListMeta.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
 		}
+		i -= size
+		i = encodeVarintGenerated(dAtA, i, uint64(size))
 	}
-	return n
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *AppliedToGroup) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.ObjectMeta.Size()
-	n += 1 + l + sovGenerated(uint64(l))
-	if len(m.Pods) > 0 {
-		for _, e := range m.Pods {
-			l = e.Size()
-			n += 1 + l + sovGenerated(uint64(l))
-		}
+func (m *NetworkPolicyNodeStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *AppliedToGroupList) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.ListMeta.Size()
-	n += 1 + l + sovGenerated(uint64(l))
-	if len(m.Items) > 0 {
-		for _, e := range m.Items {
-			l = e.Size()
-			n += 1 + l + sovGenerated(uint64(l))
-		}
-	}
-	return n
+func (m *NetworkPolicyNodeStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *AppliedToGroupPatch) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *NetworkPolicyNodeStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = m.ObjectMeta.Size()
-	n += 1 + l + sovGenerated(uint64(l))
-	if len(m.AddedPods) > 0 {
-		for _, e := range m.AddedPods {
-			l = e.Size()
-			n += 1 + l + sovGenerated(uint64(l))
-		}
-	}
-	if len(m.RemovedPods) > 0 {
-		for _, e := range m.RemovedPods {
-			l = e.Size()
-			n += 1 + l + sovGenerated(uint64(l))
-		}
-	}
-	return n
+	i -= len(m.LastError)
+	copy(dAtA[i:], m.LastError)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.LastError)))
+	i--
+	dAtA[i] = 0x22
+	i = encodeVarintGenerated(dAtA, i, uint64(m.FailedRules))
+	i--
+	dAtA[i] = 0x18
+	i = encodeVarintGenerated(dAtA, i, uint64(m.Generation))
+	i--
+	dAtA[i] = 0x10
+	i -= len(m.NodeName)
+	copy(dAtA[i:], m.NodeName)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.NodeName)))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *GroupMemberPod) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *NetworkPolicyPeer) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *NetworkPolicyPeer) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NetworkPolicyPeer) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Pod != nil {
-		l = m.Pod.Size()
-		n += 1 + l + sovGenerated(uint64(l))
-	}
-	if m.IP != nil {
-		l = len(m.IP)
-		n += 1 + l + sovGenerated(uint64(l))
-	}
-	if len(m.Ports) > 0 {
-		for _, e := range m.Ports {
-			l = e.Size()
-			n += 1 + l + sovGenerated(uint64(l))
+	if len(m.FQDNs) > 0 {
+		for iNdEx := len(m.FQDNs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.FQDNs[iNdEx])
+			copy(dAtA[i:], m.FQDNs[iNdEx])
+			i = encodeVarintGenerated(dAtA, i, uint64(len(m.FQDNs[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
 		}
 	}
-	return n
-}
-
-func (m *IPBlock) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.IPBlocks) > 0 {
+		for iNdEx := len(m.IPBlocks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.IPBlocks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenerated(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
 	}
-	var l int
-	_ = l
-	l = m.CIDR.Size()
-	n += 1 + l + sovGenerated(uint64(l))
-	if len(m.Except) > 0 {
-		for _, e := range m.Except {
-			l = e.Size()
-			n += 1 + l + sovGenerated(uint64(l))
+	if len(m.AddressGroups) > 0 {
+		for iNdEx := len(m.AddressGroups) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.AddressGroups[iNdEx])
+			copy(dAtA[i:], m.AddressGroups[iNdEx])
+			i = encodeVarintGenerated(dAtA, i, uint64(len(m.AddressGroups[iNdEx])))
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *IPNet) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.IP != nil {
-		l = len(m.IP)
-		n += 1 + l + sovGenerated(uint64(l))
+func (m *NetworkPolicyRealizationStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	n += 1 + sovGenerated(uint64(m.PrefixLength))
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *NamedPort) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	n += 1 + sovGenerated(uint64(m.Port))
-	l = len(m.Name)
-	n += 1 + l + sovGenerated(uint64(l))
-	l = len(m.Protocol)
-	n += 1 + l + sovGenerated(uint64(l))
-	return n
+func (m *NetworkPolicyRealizationStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *NetworkPolicy) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *NetworkPolicyRealizationStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = m.ObjectMeta.Size()
-	n += 1 + l + sovGenerated(uint64(l))
-	if len(m.Rules) > 0 {
-		for _, e := range m.Rules {
-			l = e.Size()
-			n += 1 + l + sovGenerated(uint64(l))
-		}
-	}
-	if len(m.AppliedToGroups) > 0 {
-		for _, s := range m.AppliedToGroups {
-			l = len(s)
-			n += 1 + l + sovGenerated(uint64(l))
+	if len(m.FailedNodes) > 0 {
+		for iNdEx := len(m.FailedNodes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.FailedNodes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenerated(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
 		}
 	}
-	return n
+	i = encodeVarintGenerated(dAtA, i, uint64(m.DesiredNodesRealized))
+	i--
+	dAtA[i] = 0x10
+	i = encodeVarintGenerated(dAtA, i, uint64(m.CurrentNodesRealized))
+	i--
+	dAtA[i] = 0x8
+	return len(dAtA) - i, nil
 }
 
-func (m *NetworkPolicyList) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *NetworkPolicyReference) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *NetworkPolicyReference) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NetworkPolicyReference) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = m.ListMeta.Size()
-	n += 1 + l + sovGenerated(uint64(l))
-	if len(m.Items) > 0 {
-		for _, e := range m.Items {
-			l = e.Size()
-			n += 1 + l + sovGenerated(uint64(l))
-		}
-	}
-	return n
+	i -= len(m.UID)
+	copy(dAtA[i:], m.UID)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.UID)))
+	i--
+	dAtA[i] = 0x1a
+	i -= len(m.Name)
+	copy(dAtA[i:], m.Name)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Name)))
+	i--
+	dAtA[i] = 0x12
+	i -= len(m.Namespace)
+	copy(dAtA[i:], m.Namespace)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Namespace)))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *NetworkPolicyPeer) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *NetworkPolicyRule) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *NetworkPolicyRule) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NetworkPolicyRule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.AddressGroups) > 0 {
-		for _, s := range m.AddressGroups {
-			l = len(s)
-			n += 1 + l + sovGenerated(uint64(l))
+	i -= len(m.Name)
+	copy(dAtA[i:], m.Name)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Name)))
+	i--
+	dAtA[i] = 0x42
+	i--
+	if m.EnableLogging {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
+	}
+	i--
+	dAtA[i] = 0x38
+	if m.Action != nil {
+		i -= len(*m.Action)
+		copy(dAtA[i:], *m.Action)
+		i = encodeVarintGenerated(dAtA, i, uint64(len(*m.Action)))
+		i--
+		dAtA[i] = 0x32
+	}
+	i = encodeVarintGenerated(dAtA, i, uint64(m.Priority))
+	i--
+	dAtA[i] = 0x28
+	if len(m.Services) > 0 {
+		for iNdEx := len(m.Services) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Services[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenerated(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
 		}
 	}
-	if len(m.IPBlocks) > 0 {
-		for _, e := range m.IPBlocks {
-			l = e.Size()
-			n += 1 + l + sovGenerated(uint64(l))
+	{
+		size, err := m.To.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
 		}
+		i -= size
+		i = encodeVarintGenerated(dAtA, i, uint64(size))
 	}
-	return n
+	i--
+	dAtA[i] = 0x1a
+	{
+		size, err := m.From.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenerated(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	i -= len(m.Direction)
+	copy(dAtA[i:], m.Direction)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Direction)))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *NetworkPolicyRule) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *NetworkPolicyRuleReference) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *NetworkPolicyRuleReference) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NetworkPolicyRuleReference) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Direction)
-	n += 1 + l + sovGenerated(uint64(l))
-	l = m.From.Size()
-	n += 1 + l + sovGenerated(uint64(l))
-	l = m.To.Size()
-	n += 1 + l + sovGenerated(uint64(l))
-	if len(m.Services) > 0 {
-		for _, e := range m.Services {
-			l = e.Size()
-			n += 1 + l + sovGenerated(uint64(l))
+	if m.Action != nil {
+		i -= len(*m.Action)
+		copy(dAtA[i:], *m.Action)
+		i = encodeVarintGenerated(dAtA, i, uint64(len(*m.Action)))
+		i--
+		dAtA[i] = 0x22
+	}
+	i = encodeVarintGenerated(dAtA, i, uint64(m.Index))
+	i--
+	dAtA[i] = 0x18
+	i -= len(m.Direction)
+	copy(dAtA[i:], m.Direction)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Direction)))
+	i--
+	dAtA[i] = 0x12
+	{
+		size, err := m.Policy.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
 		}
+		i -= size
+		i = encodeVarintGenerated(dAtA, i, uint64(size))
 	}
-	return n
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *PodReference) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *NetworkPolicyStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.Name)
-	n += 1 + l + sovGenerated(uint64(l))
-	l = len(m.Namespace)
-	n += 1 + l + sovGenerated(uint64(l))
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *Service) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *NetworkPolicyStats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NetworkPolicyStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Protocol != nil {
-		l = len(*m.Protocol)
-		n += 1 + l + sovGenerated(uint64(l))
+	if len(m.RuleTrafficStats) > 0 {
+		for iNdEx := len(m.RuleTrafficStats) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.RuleTrafficStats[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenerated(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
 	}
-	if m.Port != nil {
-		l = m.Port.Size()
-		n += 1 + l + sovGenerated(uint64(l))
+	{
+		size, err := m.TrafficStats.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenerated(dAtA, i, uint64(size))
 	}
-	return n
+	i--
+	dAtA[i] = 0x12
+	{
+		size, err := m.ObjectMeta.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenerated(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func sovGenerated(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+func (m *NetworkPolicyStatsList) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
 }
-func sozGenerated(x uint64) (n int) {
-	return sovGenerated(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *NetworkPolicyStatsList) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *AddressGroup) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *NetworkPolicyStatsList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Items) > 0 {
+		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenerated(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
 	}
-	repeatedStringForPods := "[]GroupMemberPod{"
-	for _, f := range this.Pods {
-		repeatedStringForPods += strings.Replace(strings.Replace(f.String(), "GroupMemberPod", "GroupMemberPod", 1), `&`, ``, 1) + ","
+	{
+		size, err := m.ListMeta.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenerated(dAtA, i, uint64(size))
 	}
-	repeatedStringForPods += "}"
-	s := strings.Join([]string{`&AddressGroup{`,
-		`ObjectMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ObjectMeta), "ObjectMeta", "v1.ObjectMeta", 1), `&`, ``, 1) + `,`,
-		`Pods:` + repeatedStringForPods + `,`,
-		`}`,
-	}, "")
-	return s
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
-func (this *AddressGroupList) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForItems := "[]AddressGroup{"
-	for _, f := range this.Items {
-		repeatedStringForItems += strings.Replace(strings.Replace(f.String(), "AddressGroup", "AddressGroup", 1), `&`, ``, 1) + ","
+
+func (m *NetworkPolicyStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForItems += "}"
-	s := strings.Join([]string{`&AddressGroupList{`,
-		`ListMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ListMeta), "ListMeta", "v1.ListMeta", 1), `&`, ``, 1) + `,`,
-		`Items:` + repeatedStringForItems + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *AddressGroupPatch) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForAddedPods := "[]GroupMemberPod{"
-	for _, f := range this.AddedPods {
-		repeatedStringForAddedPods += strings.Replace(strings.Replace(f.String(), "GroupMemberPod", "GroupMemberPod", 1), `&`, ``, 1) + ","
-	}
-	repeatedStringForAddedPods += "}"
-	repeatedStringForRemovedPods := "[]GroupMemberPod{"
-	for _, f := range this.RemovedPods {
-		repeatedStringForRemovedPods += strings.Replace(strings.Replace(f.String(), "GroupMemberPod", "GroupMemberPod", 1), `&`, ``, 1) + ","
-	}
-	repeatedStringForRemovedPods += "}"
-	s := strings.Join([]string{`&AddressGroupPatch{`,
-		`ObjectMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ObjectMeta), "ObjectMeta", "v1.ObjectMeta", 1), `&`, ``, 1) + `,`,
-		`AddedPods:` + repeatedStringForAddedPods + `,`,
-		`RemovedPods:` + repeatedStringForRemovedPods + `,`,
-		`}`,
-	}, "")
-	return s
+
+func (m *NetworkPolicyStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *AppliedToGroup) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *NetworkPolicyStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Nodes) > 0 {
+		for iNdEx := len(m.Nodes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Nodes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenerated(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
 	}
-	repeatedStringForPods := "[]GroupMemberPod{"
-	for _, f := range this.Pods {
-		repeatedStringForPods += strings.Replace(strings.Replace(f.String(), "GroupMemberPod", "GroupMemberPod", 1), `&`, ``, 1) + ","
+	{
+		size, err := m.ObjectMeta.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenerated(dAtA, i, uint64(size))
 	}
-	repeatedStringForPods += "}"
-	s := strings.Join([]string{`&AppliedToGroup{`,
-		`ObjectMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ObjectMeta), "ObjectMeta", "v1.ObjectMeta", 1), `&`, ``, 1) + `,`,
-		`Pods:` + repeatedStringForPods + `,`,
-		`}`,
-	}, "")
-	return s
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
-func (this *AppliedToGroupList) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForItems := "[]AppliedToGroup{"
-	for _, f := range this.Items {
-		repeatedStringForItems += strings.Replace(strings.Replace(f.String(), "AppliedToGroup", "AppliedToGroup", 1), `&`, ``, 1) + ","
+
+func (m *NodeStatsSummary) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForItems += "}"
-	s := strings.Join([]string{`&AppliedToGroupList{`,
-		`ListMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ListMeta), "ListMeta", "v1.ListMeta", 1), `&`, ``, 1) + `,`,
-		`Items:` + repeatedStringForItems + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *AppliedToGroupPatch) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForAddedPods := "[]GroupMemberPod{"
-	for _, f := range this.AddedPods {
-		repeatedStringForAddedPods += strings.Replace(strings.Replace(f.String(), "GroupMemberPod", "GroupMemberPod", 1), `&`, ``, 1) + ","
-	}
-	repeatedStringForAddedPods += "}"
-	repeatedStringForRemovedPods := "[]GroupMemberPod{"
-	for _, f := range this.RemovedPods {
-		repeatedStringForRemovedPods += strings.Replace(strings.Replace(f.String(), "GroupMemberPod", "GroupMemberPod", 1), `&`, ``, 1) + ","
-	}
-	repeatedStringForRemovedPods += "}"
-	s := strings.Join([]string{`&AppliedToGroupPatch{`,
-		`ObjectMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ObjectMeta), "ObjectMeta", "v1.ObjectMeta", 1), `&`, ``, 1) + `,`,
-		`AddedPods:` + repeatedStringForAddedPods + `,`,
-		`RemovedPods:` + repeatedStringForRemovedPods + `,`,
-		`}`,
-	}, "")
-	return s
+
+func (m *NodeStatsSummary) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *GroupMemberPod) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *NodeStatsSummary) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.NetworkPolicies) > 0 {
+		for iNdEx := len(m.NetworkPolicies) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.NetworkPolicies[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenerated(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
 	}
-	repeatedStringForPorts := "[]NamedPort{"
-	for _, f := range this.Ports {
-		repeatedStringForPorts += strings.Replace(strings.Replace(f.String(), "NamedPort", "NamedPort", 1), `&`, ``, 1) + ","
+	{
+		size, err := m.ObjectMeta.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenerated(dAtA, i, uint64(size))
 	}
-	repeatedStringForPorts += "}"
-	s := strings.Join([]string{`&GroupMemberPod{`,
-		`Pod:` + strings.Replace(this.Pod.String(), "PodReference", "PodReference", 1) + `,`,
-		`IP:` + valueToStringGenerated(this.IP) + `,`,
-		`Ports:` + repeatedStringForPorts + `,`,
-		`}`,
-	}, "")
-	return s
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
-func (this *IPBlock) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForExcept := "[]IPNet{"
-	for _, f := range this.Except {
-		repeatedStringForExcept += strings.Replace(strings.Replace(f.String(), "IPNet", "IPNet", 1), `&`, ``, 1) + ","
+
+func (m *PodReference) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForExcept += "}"
-	s := strings.Join([]string{`&IPBlock{`,
-		`CIDR:` + strings.Replace(strings.Replace(this.CIDR.String(), "IPNet", "IPNet", 1), `&`, ``, 1) + `,`,
-		`Except:` + repeatedStringForExcept + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *IPNet) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&IPNet{`,
-		`IP:` + valueToStringGenerated(this.IP) + `,`,
-		`PrefixLength:` + fmt.Sprintf("%v", this.PrefixLength) + `,`,
-		`}`,
-	}, "")
-	return s
+
+func (m *PodReference) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *NamedPort) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&NamedPort{`,
-		`Port:` + fmt.Sprintf("%v", this.Port) + `,`,
-		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
-		`Protocol:` + fmt.Sprintf("%v", this.Protocol) + `,`,
-		`}`,
-	}, "")
-	return s
+
+func (m *PodReference) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	i -= len(m.Namespace)
+	copy(dAtA[i:], m.Namespace)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Namespace)))
+	i--
+	dAtA[i] = 0x12
+	i -= len(m.Name)
+	copy(dAtA[i:], m.Name)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Name)))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
-func (this *NetworkPolicy) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *RuleTrafficStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForRules := "[]NetworkPolicyRule{"
-	for _, f := range this.Rules {
-		repeatedStringForRules += strings.Replace(strings.Replace(f.String(), "NetworkPolicyRule", "NetworkPolicyRule", 1), `&`, ``, 1) + ","
+	return dAtA[:n], nil
+}
+
+func (m *RuleTrafficStats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RuleTrafficStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.TrafficStats.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenerated(dAtA, i, uint64(size))
 	}
-	repeatedStringForRules += "}"
-	s := strings.Join([]string{`&NetworkPolicy{`,
-		`ObjectMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ObjectMeta), "ObjectMeta", "v1.ObjectMeta", 1), `&`, ``, 1) + `,`,
-		`Rules:` + repeatedStringForRules + `,`,
-		`AppliedToGroups:` + fmt.Sprintf("%v", this.AppliedToGroups) + `,`,
-		`}`,
-	}, "")
-	return s
+	i--
+	dAtA[i] = 0x12
+	i -= len(m.Name)
+	copy(dAtA[i:], m.Name)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Name)))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
-func (this *NetworkPolicyList) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *Service) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForItems := "[]NetworkPolicy{"
-	for _, f := range this.Items {
-		repeatedStringForItems += strings.Replace(strings.Replace(f.String(), "NetworkPolicy", "NetworkPolicy", 1), `&`, ``, 1) + ","
+	return dAtA[:n], nil
+}
+
+func (m *Service) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Service) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ICMPCode != nil {
+		i = encodeVarintGenerated(dAtA, i, uint64(*m.ICMPCode))
+		i--
+		dAtA[i] = 0x28
 	}
-	repeatedStringForItems += "}"
-	s := strings.Join([]string{`&NetworkPolicyList{`,
-		`ListMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ListMeta), "ListMeta", "v1.ListMeta", 1), `&`, ``, 1) + `,`,
-		`Items:` + repeatedStringForItems + `,`,
-		`}`,
-	}, "")
-	return s
+	if m.ICMPType != nil {
+		i = encodeVarintGenerated(dAtA, i, uint64(*m.ICMPType))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.EndPort != nil {
+		i = encodeVarintGenerated(dAtA, i, uint64(*m.EndPort))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Port != nil {
+		{
+			size, err := m.Port.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGenerated(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Protocol != nil {
+		i -= len(*m.Protocol)
+		copy(dAtA[i:], *m.Protocol)
+		i = encodeVarintGenerated(dAtA, i, uint64(len(*m.Protocol)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
-func (this *NetworkPolicyPeer) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *TrafficStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForIPBlocks := "[]IPBlock{"
-	for _, f := range this.IPBlocks {
-		repeatedStringForIPBlocks += strings.Replace(strings.Replace(f.String(), "IPBlock", "IPBlock", 1), `&`, ``, 1) + ","
+	return dAtA[:n], nil
+}
+
+func (m *TrafficStats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TrafficStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	i = encodeVarintGenerated(dAtA, i, uint64(m.Sessions))
+	i--
+	dAtA[i] = 0x18
+	i = encodeVarintGenerated(dAtA, i, uint64(m.Bytes))
+	i--
+	dAtA[i] = 0x10
+	i = encodeVarintGenerated(dAtA, i, uint64(m.Packets))
+	i--
+	dAtA[i] = 0x8
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintGenerated(dAtA []byte, offset int, v uint64) int {
+	offset -= sovGenerated(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
 	}
-	repeatedStringForIPBlocks += "}"
-	s := strings.Join([]string{`&NetworkPolicyPeer{`,
-		`AddressGroups:` + fmt.Sprintf("%v", this.AddressGroups) + `,`,
-		`IPBlocks:` + repeatedStringForIPBlocks + `,`,
-		`}`,
-	}, "")
-	return s
+	dAtA[offset] = uint8(v)
+	return base
 }
-func (this *NetworkPolicyRule) String() string {
-	if this == nil {
-		return "nil"
+func (m *AddressGroup) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	repeatedStringForServices := "[]Service{"
-	for _, f := range this.Services {
-		repeatedStringForServices += strings.Replace(strings.Replace(f.String(), "Service", "Service", 1), `&`, ``, 1) + ","
+	var l int
+	_ = l
+	l = m.ObjectMeta.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.Pods) > 0 {
+		for _, e := range m.Pods {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
 	}
-	repeatedStringForServices += "}"
-	s := strings.Join([]string{`&NetworkPolicyRule{`,
-		`Direction:` + fmt.Sprintf("%v", this.Direction) + `,`,
-		`From:` + strings.Replace(strings.Replace(this.From.String(), "NetworkPolicyPeer", "NetworkPolicyPeer", 1), `&`, ``, 1) + `,`,
-		`To:` + strings.Replace(strings.Replace(this.To.String(), "NetworkPolicyPeer", "NetworkPolicyPeer", 1), `&`, ``, 1) + `,`,
-		`Services:` + repeatedStringForServices + `,`,
-		`}`,
-	}, "")
-	return s
+	return n
 }
-func (this *PodReference) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *AddressGroupList) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	s := strings.Join([]string{`&PodReference{`,
-		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
-		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
-		`}`,
-	}, "")
-	return s
+	var l int
+	_ = l
+	l = m.ListMeta.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.Items) > 0 {
+		for _, e := range m.Items {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	return n
 }
-func (this *Service) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *AddressGroupPatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.ObjectMeta.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.AddedPods) > 0 {
+		for _, e := range m.AddedPods {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	if len(m.RemovedPods) > 0 {
+		for _, e := range m.RemovedPods {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AppliedToGroup) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.ObjectMeta.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.Pods) > 0 {
+		for _, e := range m.Pods {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AppliedToGroupList) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.ListMeta.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.Items) > 0 {
+		for _, e := range m.Items {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AppliedToGroupPatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.ObjectMeta.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.AddedPods) > 0 {
+		for _, e := range m.AddedPods {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	if len(m.RemovedPods) > 0 {
+		for _, e := range m.RemovedPods {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ConnectivityQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.ObjectMeta.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	l = m.Spec.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	l = m.Status.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	return n
+}
+
+func (m *ConnectivityQueryPeer) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pod != nil {
+		l = m.Pod.Size()
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.IP)
+	n += 1 + l + sovGenerated(uint64(l))
+	return n
+}
+
+func (m *ConnectivityQuerySpec) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Source.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	l = m.Destination.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.Protocol)
+	n += 1 + l + sovGenerated(uint64(l))
+	n += 1 + sovGenerated(uint64(m.Port))
+	return n
+}
+
+func (m *ConnectivityQueryStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 2
+	if m.Egress != nil {
+		l = m.Egress.Size()
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	if m.Ingress != nil {
+		l = m.Ingress.Size()
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	return n
+}
+
+func (m *ConnectivityQueryVerdict) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 2
+	if m.Rule != nil {
+		l = m.Rule.Size()
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	n += 2
+	return n
+}
+
+func (m *EndpointQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.ObjectMeta.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.AppliedPolicies) > 0 {
+		for _, e := range m.AppliedPolicies {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	if len(m.PeerRules) > 0 {
+		for _, e := range m.PeerRules {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GroupMemberPod) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pod != nil {
+		l = m.Pod.Size()
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	if m.IP != nil {
+		l = len(m.IP)
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	if len(m.Ports) > 0 {
+		for _, e := range m.Ports {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	if len(m.IPs) > 0 {
+		for _, b := range m.IPs {
+			l = len(b)
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *IPBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.CIDR.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.Except) > 0 {
+		for _, e := range m.Except {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *IPNet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.IP != nil {
+		l = len(m.IP)
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	n += 1 + sovGenerated(uint64(m.PrefixLength))
+	return n
+}
+
+func (m *NamedPort) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovGenerated(uint64(m.Port))
+	l = len(m.Name)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.Protocol)
+	n += 1 + l + sovGenerated(uint64(l))
+	return n
+}
+
+func (m *NetworkPolicy) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.ObjectMeta.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.Rules) > 0 {
+		for _, e := range m.Rules {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	if len(m.AppliedToGroups) > 0 {
+		for _, s := range m.AppliedToGroups {
+			l = len(s)
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	if m.Priority != nil {
+		n += 9
+	}
+	if m.TierPriority != nil {
+		n += 1 + sovGenerated(uint64(*m.TierPriority))
+	}
+	if m.Status != nil {
+		l = m.Status.Size()
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	return n
+}
+
+func (m *NetworkPolicyList) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.ListMeta.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.Items) > 0 {
+		for _, e := range m.Items {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *NetworkPolicyNodeStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.NodeName)
+	n += 1 + l + sovGenerated(uint64(l))
+	n += 1 + sovGenerated(uint64(m.Generation))
+	n += 1 + sovGenerated(uint64(m.FailedRules))
+	l = len(m.LastError)
+	n += 1 + l + sovGenerated(uint64(l))
+	return n
+}
+
+func (m *NetworkPolicyPeer) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.AddressGroups) > 0 {
+		for _, s := range m.AddressGroups {
+			l = len(s)
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	if len(m.IPBlocks) > 0 {
+		for _, e := range m.IPBlocks {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	if len(m.FQDNs) > 0 {
+		for _, s := range m.FQDNs {
+			l = len(s)
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *NetworkPolicyRealizationStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovGenerated(uint64(m.CurrentNodesRealized))
+	n += 1 + sovGenerated(uint64(m.DesiredNodesRealized))
+	if len(m.FailedNodes) > 0 {
+		for _, e := range m.FailedNodes {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *NetworkPolicyReference) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.Name)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.UID)
+	n += 1 + l + sovGenerated(uint64(l))
+	return n
+}
+
+func (m *NetworkPolicyRule) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Direction)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = m.From.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	l = m.To.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.Services) > 0 {
+		for _, e := range m.Services {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	n += 1 + sovGenerated(uint64(m.Priority))
+	if m.Action != nil {
+		l = len(*m.Action)
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	n += 2
+	l = len(m.Name)
+	n += 1 + l + sovGenerated(uint64(l))
+	return n
+}
+
+func (m *NetworkPolicyRuleReference) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Policy.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.Direction)
+	n += 1 + l + sovGenerated(uint64(l))
+	n += 1 + sovGenerated(uint64(m.Index))
+	if m.Action != nil {
+		l = len(*m.Action)
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	return n
+}
+
+func (m *NetworkPolicyStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.ObjectMeta.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	l = m.TrafficStats.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.RuleTrafficStats) > 0 {
+		for _, e := range m.RuleTrafficStats {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *NetworkPolicyStatsList) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.ListMeta.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.Items) > 0 {
+		for _, e := range m.Items {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *NetworkPolicyStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.ObjectMeta.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.Nodes) > 0 {
+		for _, e := range m.Nodes {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *NodeStatsSummary) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.ObjectMeta.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.NetworkPolicies) > 0 {
+		for _, e := range m.NetworkPolicies {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PodReference) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.Namespace)
+	n += 1 + l + sovGenerated(uint64(l))
+	return n
+}
+
+func (m *RuleTrafficStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = m.TrafficStats.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	return n
+}
+
+func (m *Service) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Protocol != nil {
+		l = len(*m.Protocol)
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	if m.Port != nil {
+		l = m.Port.Size()
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	if m.EndPort != nil {
+		n += 1 + sovGenerated(uint64(*m.EndPort))
+	}
+	if m.ICMPType != nil {
+		n += 1 + sovGenerated(uint64(*m.ICMPType))
+	}
+	if m.ICMPCode != nil {
+		n += 1 + sovGenerated(uint64(*m.ICMPCode))
+	}
+	return n
+}
+
+func (m *TrafficStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovGenerated(uint64(m.Packets))
+	n += 1 + sovGenerated(uint64(m.Bytes))
+	n += 1 + sovGenerated(uint64(m.Sessions))
+	return n
+}
+
+func sovGenerated(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozGenerated(x uint64) (n int) {
+	return sovGenerated(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *AddressGroup) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForPods := "[]GroupMemberPod{"
+	for _, f := range this.Pods {
+		repeatedStringForPods += strings.Replace(strings.Replace(f.String(), "GroupMemberPod", "GroupMemberPod", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForPods += "}"
+	s := strings.Join([]string{`&AddressGroup{`,
+		`ObjectMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ObjectMeta), "ObjectMeta", "v1.ObjectMeta", 1), `&`, ``, 1) + `,`,
+		`Pods:` + repeatedStringForPods + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AddressGroupList) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForItems := "[]AddressGroup{"
+	for _, f := range this.Items {
+		repeatedStringForItems += strings.Replace(strings.Replace(f.String(), "AddressGroup", "AddressGroup", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForItems += "}"
+	s := strings.Join([]string{`&AddressGroupList{`,
+		`ListMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ListMeta), "ListMeta", "v1.ListMeta", 1), `&`, ``, 1) + `,`,
+		`Items:` + repeatedStringForItems + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AddressGroupPatch) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForAddedPods := "[]GroupMemberPod{"
+	for _, f := range this.AddedPods {
+		repeatedStringForAddedPods += strings.Replace(strings.Replace(f.String(), "GroupMemberPod", "GroupMemberPod", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForAddedPods += "}"
+	repeatedStringForRemovedPods := "[]GroupMemberPod{"
+	for _, f := range this.RemovedPods {
+		repeatedStringForRemovedPods += strings.Replace(strings.Replace(f.String(), "GroupMemberPod", "GroupMemberPod", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForRemovedPods += "}"
+	s := strings.Join([]string{`&AddressGroupPatch{`,
+		`ObjectMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ObjectMeta), "ObjectMeta", "v1.ObjectMeta", 1), `&`, ``, 1) + `,`,
+		`AddedPods:` + repeatedStringForAddedPods + `,`,
+		`RemovedPods:` + repeatedStringForRemovedPods + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AppliedToGroup) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForPods := "[]GroupMemberPod{"
+	for _, f := range this.Pods {
+		repeatedStringForPods += strings.Replace(strings.Replace(f.String(), "GroupMemberPod", "GroupMemberPod", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForPods += "}"
+	s := strings.Join([]string{`&AppliedToGroup{`,
+		`ObjectMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ObjectMeta), "ObjectMeta", "v1.ObjectMeta", 1), `&`, ``, 1) + `,`,
+		`Pods:` + repeatedStringForPods + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AppliedToGroupList) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForItems := "[]AppliedToGroup{"
+	for _, f := range this.Items {
+		repeatedStringForItems += strings.Replace(strings.Replace(f.String(), "AppliedToGroup", "AppliedToGroup", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForItems += "}"
+	s := strings.Join([]string{`&AppliedToGroupList{`,
+		`ListMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ListMeta), "ListMeta", "v1.ListMeta", 1), `&`, ``, 1) + `,`,
+		`Items:` + repeatedStringForItems + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AppliedToGroupPatch) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForAddedPods := "[]GroupMemberPod{"
+	for _, f := range this.AddedPods {
+		repeatedStringForAddedPods += strings.Replace(strings.Replace(f.String(), "GroupMemberPod", "GroupMemberPod", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForAddedPods += "}"
+	repeatedStringForRemovedPods := "[]GroupMemberPod{"
+	for _, f := range this.RemovedPods {
+		repeatedStringForRemovedPods += strings.Replace(strings.Replace(f.String(), "GroupMemberPod", "GroupMemberPod", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForRemovedPods += "}"
+	s := strings.Join([]string{`&AppliedToGroupPatch{`,
+		`ObjectMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ObjectMeta), "ObjectMeta", "v1.ObjectMeta", 1), `&`, ``, 1) + `,`,
+		`AddedPods:` + repeatedStringForAddedPods + `,`,
+		`RemovedPods:` + repeatedStringForRemovedPods + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ConnectivityQuery) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ConnectivityQuery{`,
+		`ObjectMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ObjectMeta), "ObjectMeta", "v1.ObjectMeta", 1), `&`, ``, 1) + `,`,
+		`Spec:` + strings.Replace(strings.Replace(this.Spec.String(), "ConnectivityQuerySpec", "ConnectivityQuerySpec", 1), `&`, ``, 1) + `,`,
+		`Status:` + strings.Replace(strings.Replace(this.Status.String(), "ConnectivityQueryStatus", "ConnectivityQueryStatus", 1), `&`, ``, 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ConnectivityQueryPeer) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ConnectivityQueryPeer{`,
+		`Pod:` + strings.Replace(this.Pod.String(), "PodReference", "PodReference", 1) + `,`,
+		`IP:` + fmt.Sprintf("%v", this.IP) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ConnectivityQuerySpec) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ConnectivityQuerySpec{`,
+		`Source:` + strings.Replace(strings.Replace(this.Source.String(), "ConnectivityQueryPeer", "ConnectivityQueryPeer", 1), `&`, ``, 1) + `,`,
+		`Destination:` + strings.Replace(strings.Replace(this.Destination.String(), "ConnectivityQueryPeer", "ConnectivityQueryPeer", 1), `&`, ``, 1) + `,`,
+		`Protocol:` + fmt.Sprintf("%v", this.Protocol) + `,`,
+		`Port:` + fmt.Sprintf("%v", this.Port) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ConnectivityQueryStatus) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ConnectivityQueryStatus{`,
+		`Allowed:` + fmt.Sprintf("%v", this.Allowed) + `,`,
+		`Egress:` + strings.Replace(this.Egress.String(), "ConnectivityQueryVerdict", "ConnectivityQueryVerdict", 1) + `,`,
+		`Ingress:` + strings.Replace(this.Ingress.String(), "ConnectivityQueryVerdict", "ConnectivityQueryVerdict", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ConnectivityQueryVerdict) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ConnectivityQueryVerdict{`,
+		`Allowed:` + fmt.Sprintf("%v", this.Allowed) + `,`,
+		`Rule:` + strings.Replace(this.Rule.String(), "NetworkPolicyRuleReference", "NetworkPolicyRuleReference", 1) + `,`,
+		`Isolated:` + fmt.Sprintf("%v", this.Isolated) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *EndpointQuery) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForAppliedPolicies := "[]NetworkPolicyReference{"
+	for _, f := range this.AppliedPolicies {
+		repeatedStringForAppliedPolicies += strings.Replace(strings.Replace(f.String(), "NetworkPolicyReference", "NetworkPolicyReference", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForAppliedPolicies += "}"
+	repeatedStringForPeerRules := "[]NetworkPolicyRuleReference{"
+	for _, f := range this.PeerRules {
+		repeatedStringForPeerRules += strings.Replace(strings.Replace(f.String(), "NetworkPolicyRuleReference", "NetworkPolicyRuleReference", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForPeerRules += "}"
+	s := strings.Join([]string{`&EndpointQuery{`,
+		`ObjectMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ObjectMeta), "ObjectMeta", "v1.ObjectMeta", 1), `&`, ``, 1) + `,`,
+		`AppliedPolicies:` + repeatedStringForAppliedPolicies + `,`,
+		`PeerRules:` + repeatedStringForPeerRules + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GroupMemberPod) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForPorts := "[]NamedPort{"
+	for _, f := range this.Ports {
+		repeatedStringForPorts += strings.Replace(strings.Replace(f.String(), "NamedPort", "NamedPort", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForPorts += "}"
+	s := strings.Join([]string{`&GroupMemberPod{`,
+		`Pod:` + strings.Replace(this.Pod.String(), "PodReference", "PodReference", 1) + `,`,
+		`IP:` + valueToStringGenerated(this.IP) + `,`,
+		`Ports:` + repeatedStringForPorts + `,`,
+		`IPs:` + fmt.Sprintf("%v", this.IPs) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *IPBlock) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForExcept := "[]IPNet{"
+	for _, f := range this.Except {
+		repeatedStringForExcept += strings.Replace(strings.Replace(f.String(), "IPNet", "IPNet", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForExcept += "}"
+	s := strings.Join([]string{`&IPBlock{`,
+		`CIDR:` + strings.Replace(strings.Replace(this.CIDR.String(), "IPNet", "IPNet", 1), `&`, ``, 1) + `,`,
+		`Except:` + repeatedStringForExcept + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *IPNet) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&IPNet{`,
+		`IP:` + valueToStringGenerated(this.IP) + `,`,
+		`PrefixLength:` + fmt.Sprintf("%v", this.PrefixLength) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *NamedPort) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&NamedPort{`,
+		`Port:` + fmt.Sprintf("%v", this.Port) + `,`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Protocol:` + fmt.Sprintf("%v", this.Protocol) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *NetworkPolicy) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForRules := "[]NetworkPolicyRule{"
+	for _, f := range this.Rules {
+		repeatedStringForRules += strings.Replace(strings.Replace(f.String(), "NetworkPolicyRule", "NetworkPolicyRule", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForRules += "}"
+	s := strings.Join([]string{`&NetworkPolicy{`,
+		`ObjectMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ObjectMeta), "ObjectMeta", "v1.ObjectMeta", 1), `&`, ``, 1) + `,`,
+		`Rules:` + repeatedStringForRules + `,`,
+		`AppliedToGroups:` + fmt.Sprintf("%v", this.AppliedToGroups) + `,`,
+		`Priority:` + valueToStringGenerated(this.Priority) + `,`,
+		`TierPriority:` + valueToStringGenerated(this.TierPriority) + `,`,
+		`Status:` + strings.Replace(this.Status.String(), "NetworkPolicyRealizationStatus", "NetworkPolicyRealizationStatus", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *NetworkPolicyList) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForItems := "[]NetworkPolicy{"
+	for _, f := range this.Items {
+		repeatedStringForItems += strings.Replace(strings.Replace(f.String(), "NetworkPolicy", "NetworkPolicy", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForItems += "}"
+	s := strings.Join([]string{`&NetworkPolicyList{`,
+		`ListMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ListMeta), "ListMeta", "v1.ListMeta", 1), `&`, ``, 1) + `,`,
+		`Items:` + repeatedStringForItems + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *NetworkPolicyNodeStatus) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&NetworkPolicyNodeStatus{`,
+		`NodeName:` + fmt.Sprintf("%v", this.NodeName) + `,`,
+		`Generation:` + fmt.Sprintf("%v", this.Generation) + `,`,
+		`FailedRules:` + fmt.Sprintf("%v", this.FailedRules) + `,`,
+		`LastError:` + fmt.Sprintf("%v", this.LastError) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *NetworkPolicyPeer) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForIPBlocks := "[]IPBlock{"
+	for _, f := range this.IPBlocks {
+		repeatedStringForIPBlocks += strings.Replace(strings.Replace(f.String(), "IPBlock", "IPBlock", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForIPBlocks += "}"
+	s := strings.Join([]string{`&NetworkPolicyPeer{`,
+		`AddressGroups:` + fmt.Sprintf("%v", this.AddressGroups) + `,`,
+		`IPBlocks:` + repeatedStringForIPBlocks + `,`,
+		`FQDNs:` + fmt.Sprintf("%v", this.FQDNs) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *NetworkPolicyRealizationStatus) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForFailedNodes := "[]NetworkPolicyNodeStatus{"
+	for _, f := range this.FailedNodes {
+		repeatedStringForFailedNodes += strings.Replace(strings.Replace(f.String(), "NetworkPolicyNodeStatus", "NetworkPolicyNodeStatus", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForFailedNodes += "}"
+	s := strings.Join([]string{`&NetworkPolicyRealizationStatus{`,
+		`CurrentNodesRealized:` + fmt.Sprintf("%v", this.CurrentNodesRealized) + `,`,
+		`DesiredNodesRealized:` + fmt.Sprintf("%v", this.DesiredNodesRealized) + `,`,
+		`FailedNodes:` + repeatedStringForFailedNodes + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *NetworkPolicyReference) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&NetworkPolicyReference{`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`UID:` + fmt.Sprintf("%v", this.UID) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *NetworkPolicyRule) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForServices := "[]Service{"
+	for _, f := range this.Services {
+		repeatedStringForServices += strings.Replace(strings.Replace(f.String(), "Service", "Service", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForServices += "}"
+	s := strings.Join([]string{`&NetworkPolicyRule{`,
+		`Direction:` + fmt.Sprintf("%v", this.Direction) + `,`,
+		`From:` + strings.Replace(strings.Replace(this.From.String(), "NetworkPolicyPeer", "NetworkPolicyPeer", 1), `&`, ``, 1) + `,`,
+		`To:` + strings.Replace(strings.Replace(this.To.String(), "NetworkPolicyPeer", "NetworkPolicyPeer", 1), `&`, ``, 1) + `,`,
+		`Services:` + repeatedStringForServices + `,`,
+		`Priority:` + fmt.Sprintf("%v", this.Priority) + `,`,
+		`Action:` + valueToStringGenerated(this.Action) + `,`,
+		`EnableLogging:` + fmt.Sprintf("%v", this.EnableLogging) + `,`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *NetworkPolicyRuleReference) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&NetworkPolicyRuleReference{`,
+		`Policy:` + strings.Replace(strings.Replace(this.Policy.String(), "NetworkPolicyReference", "NetworkPolicyReference", 1), `&`, ``, 1) + `,`,
+		`Direction:` + fmt.Sprintf("%v", this.Direction) + `,`,
+		`Index:` + fmt.Sprintf("%v", this.Index) + `,`,
+		`Action:` + valueToStringGenerated(this.Action) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *NetworkPolicyStats) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForRuleTrafficStats := "[]RuleTrafficStats{"
+	for _, f := range this.RuleTrafficStats {
+		repeatedStringForRuleTrafficStats += strings.Replace(strings.Replace(f.String(), "RuleTrafficStats", "RuleTrafficStats", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForRuleTrafficStats += "}"
+	s := strings.Join([]string{`&NetworkPolicyStats{`,
+		`ObjectMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ObjectMeta), "ObjectMeta", "v1.ObjectMeta", 1), `&`, ``, 1) + `,`,
+		`TrafficStats:` + strings.Replace(strings.Replace(this.TrafficStats.String(), "TrafficStats", "TrafficStats", 1), `&`, ``, 1) + `,`,
+		`RuleTrafficStats:` + repeatedStringForRuleTrafficStats + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *NetworkPolicyStatsList) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForItems := "[]NetworkPolicyStats{"
+	for _, f := range this.Items {
+		repeatedStringForItems += strings.Replace(strings.Replace(f.String(), "NetworkPolicyStats", "NetworkPolicyStats", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForItems += "}"
+	s := strings.Join([]string{`&NetworkPolicyStatsList{`,
+		`ListMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ListMeta), "ListMeta", "v1.ListMeta", 1), `&`, ``, 1) + `,`,
+		`Items:` + repeatedStringForItems + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *NetworkPolicyStatus) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForNodes := "[]NetworkPolicyNodeStatus{"
+	for _, f := range this.Nodes {
+		repeatedStringForNodes += strings.Replace(strings.Replace(f.String(), "NetworkPolicyNodeStatus", "NetworkPolicyNodeStatus", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForNodes += "}"
+	s := strings.Join([]string{`&NetworkPolicyStatus{`,
+		`ObjectMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ObjectMeta), "ObjectMeta", "v1.ObjectMeta", 1), `&`, ``, 1) + `,`,
+		`Nodes:` + repeatedStringForNodes + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *NodeStatsSummary) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForNetworkPolicies := "[]NetworkPolicyStats{"
+	for _, f := range this.NetworkPolicies {
+		repeatedStringForNetworkPolicies += strings.Replace(strings.Replace(f.String(), "NetworkPolicyStats", "NetworkPolicyStats", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForNetworkPolicies += "}"
+	s := strings.Join([]string{`&NodeStatsSummary{`,
+		`ObjectMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ObjectMeta), "ObjectMeta", "v1.ObjectMeta", 1), `&`, ``, 1) + `,`,
+		`NetworkPolicies:` + repeatedStringForNetworkPolicies + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PodReference) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PodReference{`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RuleTrafficStats) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RuleTrafficStats{`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`TrafficStats:` + strings.Replace(strings.Replace(this.TrafficStats.String(), "TrafficStats", "TrafficStats", 1), `&`, ``, 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Service) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Service{`,
+		`Protocol:` + valueToStringGenerated(this.Protocol) + `,`,
+		`Port:` + strings.Replace(fmt.Sprintf("%v", this.Port), "IntOrString", "intstr.IntOrString", 1) + `,`,
+		`EndPort:` + valueToStringGenerated(this.EndPort) + `,`,
+		`ICMPType:` + valueToStringGenerated(this.ICMPType) + `,`,
+		`ICMPCode:` + valueToStringGenerated(this.ICMPCode) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TrafficStats) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TrafficStats{`,
+		`Packets:` + fmt.Sprintf("%v", this.Packets) + `,`,
+		`Bytes:` + fmt.Sprintf("%v", this.Bytes) + `,`,
+		`Sessions:` + fmt.Sprintf("%v", this.Sessions) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringGenerated(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *AddressGroup) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddressGroup: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddressGroup: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pods", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pods = append(m.Pods, GroupMemberPod{})
+			if err := m.Pods[len(m.Pods)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddressGroupList) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddressGroupList: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddressGroupList: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ListMeta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ListMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Items = append(m.Items, AddressGroup{})
+			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddressGroupPatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddressGroupPatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddressGroupPatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AddedPods", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AddedPods = append(m.AddedPods, GroupMemberPod{})
+			if err := m.AddedPods[len(m.AddedPods)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemovedPods", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RemovedPods = append(m.RemovedPods, GroupMemberPod{})
+			if err := m.RemovedPods[len(m.RemovedPods)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AppliedToGroup) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AppliedToGroup: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AppliedToGroup: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pods", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pods = append(m.Pods, GroupMemberPod{})
+			if err := m.Pods[len(m.Pods)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AppliedToGroupList) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AppliedToGroupList: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AppliedToGroupList: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ListMeta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ListMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Items = append(m.Items, AppliedToGroup{})
+			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AppliedToGroupPatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AppliedToGroupPatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AppliedToGroupPatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AddedPods", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AddedPods = append(m.AddedPods, GroupMemberPod{})
+			if err := m.AddedPods[len(m.AddedPods)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemovedPods", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RemovedPods = append(m.RemovedPods, GroupMemberPod{})
+			if err := m.RemovedPods[len(m.RemovedPods)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConnectivityQuery) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConnectivityQuery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConnectivityQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Spec", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Spec.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Status.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConnectivityQueryPeer) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConnectivityQueryPeer: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConnectivityQueryPeer: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pod", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pod == nil {
+				m.Pod = &PodReference{}
+			}
+			if err := m.Pod.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IP", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IP = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConnectivityQuerySpec) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConnectivityQuerySpec: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConnectivityQuerySpec: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Source.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Destination", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Destination.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Protocol", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Protocol = Protocol(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+			}
+			m.Port = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Port |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConnectivityQueryStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConnectivityQueryStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConnectivityQueryStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Allowed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Allowed = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Egress", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Egress == nil {
+				m.Egress = &ConnectivityQueryVerdict{}
+			}
+			if err := m.Egress.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ingress", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Ingress == nil {
+				m.Ingress = &ConnectivityQueryVerdict{}
+			}
+			if err := m.Ingress.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConnectivityQueryVerdict) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConnectivityQueryVerdict: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConnectivityQueryVerdict: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Allowed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Allowed = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rule", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Rule == nil {
+				m.Rule = &NetworkPolicyRuleReference{}
+			}
+			if err := m.Rule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Isolated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Isolated = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EndpointQuery) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EndpointQuery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EndpointQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppliedPolicies", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppliedPolicies = append(m.AppliedPolicies, NetworkPolicyReference{})
+			if err := m.AppliedPolicies[len(m.AppliedPolicies)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerRules", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerRules = append(m.PeerRules, NetworkPolicyRuleReference{})
+			if err := m.PeerRules[len(m.PeerRules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GroupMemberPod) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GroupMemberPod: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GroupMemberPod: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pod", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pod == nil {
+				m.Pod = &PodReference{}
+			}
+			if err := m.Pod.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IP", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IP = append(m.IP[:0], dAtA[iNdEx:postIndex]...)
+			if m.IP == nil {
+				m.IP = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ports", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ports = append(m.Ports, NamedPort{})
+			if err := m.Ports[len(m.Ports)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IPs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IPs = append(m.IPs, make([]byte, postIndex-iNdEx))
+			copy(m.IPs[len(m.IPs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IPBlock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IPBlock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IPBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CIDR", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CIDR.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Except", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Except = append(m.Except, IPNet{})
+			if err := m.Except[len(m.Except)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IPNet) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IPNet: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IPNet: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IP", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IP = append(m.IP[:0], dAtA[iNdEx:postIndex]...)
+			if m.IP == nil {
+				m.IP = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrefixLength", wireType)
+			}
+			m.PrefixLength = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PrefixLength |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NamedPort) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NamedPort: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NamedPort: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+			}
+			m.Port = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Port |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Protocol", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Protocol = Protocol(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NetworkPolicy) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NetworkPolicy: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NetworkPolicy: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rules", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Rules = append(m.Rules, NetworkPolicyRule{})
+			if err := m.Rules[len(m.Rules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppliedToGroups", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppliedToGroups = append(m.AppliedToGroups, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			v2 := float64(math.Float64frombits(v))
+			m.Priority = &v2
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TierPriority", wireType)
+			}
+			var v TierPriority
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= TierPriority(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.TierPriority = &v
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Status == nil {
+				m.Status = &NetworkPolicyRealizationStatus{}
+			}
+			if err := m.Status.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NetworkPolicyList) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NetworkPolicyList: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NetworkPolicyList: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ListMeta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ListMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Items = append(m.Items, NetworkPolicy{})
+			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	s := strings.Join([]string{`&Service{`,
-		`Protocol:` + valueToStringGenerated(this.Protocol) + `,`,
-		`Port:` + strings.Replace(fmt.Sprintf("%v", this.Port), "IntOrString", "intstr.IntOrString", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func valueToStringGenerated(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	return nil
 }
-func (m *AddressGroup) Unmarshal(dAtA []byte) error {
+func (m *NetworkPolicyNodeStatus) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1948,17 +6396,17 @@ func (m *AddressGroup) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddressGroup: wiretype end group for non-group")
+			return fmt.Errorf("proto: NetworkPolicyNodeStatus: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddressGroup: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: NetworkPolicyNodeStatus: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeName", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -1968,30 +6416,67 @@ func (m *AddressGroup) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.NodeName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Generation", wireType)
+			}
+			m.Generation = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Generation |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FailedRules", wireType)
+			}
+			m.FailedRules = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FailedRules |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pods", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LastError", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -2001,25 +6486,23 @@ func (m *AddressGroup) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Pods = append(m.Pods, GroupMemberPod{})
-			if err := m.Pods[len(m.Pods)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.LastError = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2045,7 +6528,7 @@ func (m *AddressGroup) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddressGroupList) Unmarshal(dAtA []byte) error {
+func (m *NetworkPolicyPeer) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2068,15 +6551,47 @@ func (m *AddressGroupList) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddressGroupList: wiretype end group for non-group")
+			return fmt.Errorf("proto: NetworkPolicyPeer: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddressGroupList: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: NetworkPolicyPeer: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ListMeta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AddressGroups", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AddressGroups = append(m.AddressGroups, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IPBlocks", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2103,13 +6618,137 @@ func (m *AddressGroupList) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ListMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.IPBlocks = append(m.IPBlocks, IPBlock{})
+			if err := m.IPBlocks[len(m.IPBlocks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FQDNs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FQDNs = append(m.FQDNs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 2:
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NetworkPolicyRealizationStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NetworkPolicyRealizationStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NetworkPolicyRealizationStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CurrentNodesRealized", wireType)
+			}
+			m.CurrentNodesRealized = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CurrentNodesRealized |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DesiredNodesRealized", wireType)
+			}
+			m.DesiredNodesRealized = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DesiredNodesRealized |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field FailedNodes", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2136,8 +6775,8 @@ func (m *AddressGroupList) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Items = append(m.Items, AddressGroup{})
-			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.FailedNodes = append(m.FailedNodes, NetworkPolicyNodeStatus{})
+			if err := m.FailedNodes[len(m.FailedNodes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2165,7 +6804,7 @@ func (m *AddressGroupList) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddressGroupPatch) Unmarshal(dAtA []byte) error {
+func (m *NetworkPolicyReference) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2188,17 +6827,17 @@ func (m *AddressGroupPatch) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddressGroupPatch: wiretype end group for non-group")
+			return fmt.Errorf("proto: NetworkPolicyReference: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddressGroupPatch: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: NetworkPolicyReference: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -2208,30 +6847,29 @@ func (m *AddressGroupPatch) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AddedPods", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -2241,31 +6879,29 @@ func (m *AddressGroupPatch) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AddedPods = append(m.AddedPods, GroupMemberPod{})
-			if err := m.AddedPods[len(m.AddedPods)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RemovedPods", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -2275,25 +6911,23 @@ func (m *AddressGroupPatch) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RemovedPods = append(m.RemovedPods, GroupMemberPod{})
-			if err := m.RemovedPods[len(m.RemovedPods)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.UID = k8s_io_apimachinery_pkg_types.UID(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2319,7 +6953,7 @@ func (m *AddressGroupPatch) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AppliedToGroup) Unmarshal(dAtA []byte) error {
+func (m *NetworkPolicyRule) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2342,17 +6976,17 @@ func (m *AppliedToGroup) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AppliedToGroup: wiretype end group for non-group")
+			return fmt.Errorf("proto: NetworkPolicyRule: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AppliedToGroup: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: NetworkPolicyRule: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Direction", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -2362,28 +6996,27 @@ func (m *AppliedToGroup) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Direction = Direction(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pods", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2410,67 +7043,13 @@ func (m *AppliedToGroup) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Pods = append(m.Pods, GroupMemberPod{})
-			if err := m.Pods[len(m.Pods)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.From.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipGenerated(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *AppliedToGroupList) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowGenerated
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: AppliedToGroupList: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AppliedToGroupList: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ListMeta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field To", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2497,13 +7076,13 @@ func (m *AppliedToGroupList) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ListMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.To.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2530,69 +7109,35 @@ func (m *AppliedToGroupList) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Items = append(m.Items, AppliedToGroup{})
-			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Services = append(m.Services, Service{})
+			if err := m.Services[len(m.Services)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipGenerated(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *AppliedToGroupPatch) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowGenerated
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: AppliedToGroupPatch: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AppliedToGroupPatch: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -2602,30 +7147,30 @@ func (m *AppliedToGroupPatch) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			s := github_com_vmware_tanzu_antrea_pkg_apis_security_v1alpha1.RuleAction(dAtA[iNdEx:postIndex])
+			m.Action = &s
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AddedPods", wireType)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EnableLogging", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -2635,31 +7180,17 @@ func (m *AppliedToGroupPatch) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.AddedPods = append(m.AddedPods, GroupMemberPod{})
-			if err := m.AddedPods[len(m.AddedPods)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
+			m.EnableLogging = bool(v != 0)
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RemovedPods", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -2669,25 +7200,23 @@ func (m *AppliedToGroupPatch) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RemovedPods = append(m.RemovedPods, GroupMemberPod{})
-			if err := m.RemovedPods[len(m.RemovedPods)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2713,7 +7242,7 @@ func (m *AppliedToGroupPatch) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GroupMemberPod) Unmarshal(dAtA []byte) error {
+func (m *NetworkPolicyRuleReference) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2736,15 +7265,15 @@ func (m *GroupMemberPod) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GroupMemberPod: wiretype end group for non-group")
+			return fmt.Errorf("proto: NetworkPolicyRuleReference: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GroupMemberPod: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: NetworkPolicyRuleReference: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pod", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Policy", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2771,18 +7300,15 @@ func (m *GroupMemberPod) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Pod == nil {
-				m.Pod = &PodReference{}
-			}
-			if err := m.Pod.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Policy.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IP", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Direction", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -2792,31 +7318,48 @@ func (m *GroupMemberPod) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IP = append(m.IP[:0], dAtA[iNdEx:postIndex]...)
-			if m.IP == nil {
-				m.IP = []byte{}
-			}
+			m.Direction = Direction(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ports", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -2826,25 +7369,24 @@ func (m *GroupMemberPod) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Ports = append(m.Ports, NamedPort{})
-			if err := m.Ports[len(m.Ports)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			s := github_com_vmware_tanzu_antrea_pkg_apis_security_v1alpha1.RuleAction(dAtA[iNdEx:postIndex])
+			m.Action = &s
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2870,7 +7412,7 @@ func (m *GroupMemberPod) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *IPBlock) Unmarshal(dAtA []byte) error {
+func (m *NetworkPolicyStats) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2893,15 +7435,15 @@ func (m *IPBlock) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: IPBlock: wiretype end group for non-group")
+			return fmt.Errorf("proto: NetworkPolicyStats: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IPBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: NetworkPolicyStats: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CIDR", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2928,13 +7470,13 @@ func (m *IPBlock) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CIDR.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Except", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TrafficStats", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2961,69 +7503,15 @@ func (m *IPBlock) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Except = append(m.Except, IPNet{})
-			if err := m.Except[len(m.Except)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.TrafficStats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipGenerated(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *IPNet) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowGenerated
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: IPNet: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IPNet: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IP", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RuleTrafficStats", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -3033,45 +7521,26 @@ func (m *IPNet) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IP = append(m.IP[:0], dAtA[iNdEx:postIndex]...)
-			if m.IP == nil {
-				m.IP = []byte{}
+			m.RuleTrafficStats = append(m.RuleTrafficStats, RuleTrafficStats{})
+			if err := m.RuleTrafficStats[len(m.RuleTrafficStats)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PrefixLength", wireType)
-			}
-			m.PrefixLength = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowGenerated
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.PrefixLength |= int32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -3096,7 +7565,7 @@ func (m *IPNet) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *NamedPort) Unmarshal(dAtA []byte) error {
+func (m *NetworkPolicyStatsList) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3119,36 +7588,17 @@ func (m *NamedPort) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NamedPort: wiretype end group for non-group")
+			return fmt.Errorf("proto: NetworkPolicyStatsList: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NamedPort: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: NetworkPolicyStatsList: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
-			}
-			m.Port = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowGenerated
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Port |= int32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ListMeta", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -3158,29 +7608,30 @@ func (m *NamedPort) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			if err := m.ListMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Protocol", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -3190,23 +7641,25 @@ func (m *NamedPort) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Protocol = Protocol(dAtA[iNdEx:postIndex])
+			m.Items = append(m.Items, NetworkPolicyStats{})
+			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3232,7 +7685,7 @@ func (m *NamedPort) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *NetworkPolicy) Unmarshal(dAtA []byte) error {
+func (m *NetworkPolicyStatus) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3255,10 +7708,10 @@ func (m *NetworkPolicy) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NetworkPolicy: wiretype end group for non-group")
+			return fmt.Errorf("proto: NetworkPolicyStatus: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NetworkPolicy: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: NetworkPolicyStatus: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -3296,43 +7749,9 @@ func (m *NetworkPolicy) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Rules", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowGenerated
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Rules = append(m.Rules, NetworkPolicyRule{})
-			if err := m.Rules[len(m.Rules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppliedToGroups", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Nodes", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -3342,23 +7761,25 @@ func (m *NetworkPolicy) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AppliedToGroups = append(m.AppliedToGroups, string(dAtA[iNdEx:postIndex]))
+			m.Nodes = append(m.Nodes, NetworkPolicyNodeStatus{})
+			if err := m.Nodes[len(m.Nodes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3384,7 +7805,7 @@ func (m *NetworkPolicy) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *NetworkPolicyList) Unmarshal(dAtA []byte) error {
+func (m *NodeStatsSummary) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3407,15 +7828,15 @@ func (m *NetworkPolicyList) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NetworkPolicyList: wiretype end group for non-group")
+			return fmt.Errorf("proto: NodeStatsSummary: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NetworkPolicyList: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: NodeStatsSummary: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ListMeta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3442,13 +7863,13 @@ func (m *NetworkPolicyList) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ListMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NetworkPolicies", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3475,8 +7896,8 @@ func (m *NetworkPolicyList) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Items = append(m.Items, NetworkPolicy{})
-			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.NetworkPolicies = append(m.NetworkPolicies, NetworkPolicyStats{})
+			if err := m.NetworkPolicies[len(m.NetworkPolicies)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3504,7 +7925,7 @@ func (m *NetworkPolicyList) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *NetworkPolicyPeer) Unmarshal(dAtA []byte) error {
+func (m *PodReference) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3527,15 +7948,15 @@ func (m *NetworkPolicyPeer) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NetworkPolicyPeer: wiretype end group for non-group")
+			return fmt.Errorf("proto: PodReference: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NetworkPolicyPeer: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PodReference: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AddressGroups", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3563,13 +7984,13 @@ func (m *NetworkPolicyPeer) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AddressGroups = append(m.AddressGroups, string(dAtA[iNdEx:postIndex]))
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IPBlocks", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -3579,25 +8000,23 @@ func (m *NetworkPolicyPeer) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IPBlocks = append(m.IPBlocks, IPBlock{})
-			if err := m.IPBlocks[len(m.IPBlocks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3623,7 +8042,7 @@ func (m *NetworkPolicyPeer) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *NetworkPolicyRule) Unmarshal(dAtA []byte) error {
+func (m *RuleTrafficStats) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3646,15 +8065,15 @@ func (m *NetworkPolicyRule) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NetworkPolicyRule: wiretype end group for non-group")
+			return fmt.Errorf("proto: RuleTrafficStats: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NetworkPolicyRule: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RuleTrafficStats: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Direction", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3682,77 +8101,11 @@ func (m *NetworkPolicyRule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Direction = Direction(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowGenerated
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.From.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field To", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowGenerated
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.To.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TrafficStats", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3779,8 +8132,7 @@ func (m *NetworkPolicyRule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Services = append(m.Services, Service{})
-			if err := m.Services[len(m.Services)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.TrafficStats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3808,7 +8160,7 @@ func (m *NetworkPolicyRule) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PodReference) Unmarshal(dAtA []byte) error {
+func (m *Service) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3831,15 +8183,15 @@ func (m *PodReference) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PodReference: wiretype end group for non-group")
+			return fmt.Errorf("proto: Service: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PodReference: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Service: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Protocol", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3867,13 +8219,14 @@ func (m *PodReference) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			s := Protocol(dAtA[iNdEx:postIndex])
+			m.Protocol = &s
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -3883,24 +8236,88 @@ func (m *PodReference) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Namespace = string(dAtA[iNdEx:postIndex])
+			if m.Port == nil {
+				m.Port = &intstr.IntOrString{}
+			}
+			if err := m.Port.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndPort", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.EndPort = &v
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ICMPType", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ICMPType = &v
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ICMPCode", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ICMPCode = &v
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -3925,7 +8342,7 @@ func (m *PodReference) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Service) Unmarshal(dAtA []byte) error {
+func (m *TrafficStats) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3948,17 +8365,17 @@ func (m *Service) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Service: wiretype end group for non-group")
+			return fmt.Errorf("proto: TrafficStats: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Service: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TrafficStats: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Protocol", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Packets", wireType)
 			}
-			var stringLen uint64
+			m.Packets = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -3968,30 +8385,16 @@ func (m *Service) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Packets |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			s := Protocol(dAtA[iNdEx:postIndex])
-			m.Protocol = &s
-			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bytes", wireType)
 			}
-			var msglen int
+			m.Bytes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -4001,28 +8404,30 @@ func (m *Service) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Bytes |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Port == nil {
-				m.Port = &intstr.IntOrString{}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sessions", wireType)
 			}
-			if err := m.Port.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Sessions = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sessions |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -39,6 +39,18 @@ var (
 		Group:    SchemeGroupVersion.Group,
 		Version:  SchemeGroupVersion.Version,
 		Resource: "networkpolicies"}
+	NetworkPolicyStatsVersionResource = schema.GroupVersionResource{
+		Group:    SchemeGroupVersion.Group,
+		Version:  SchemeGroupVersion.Version,
+		Resource: "networkpolicystats"}
+	EndpointQueryVersionResource = schema.GroupVersionResource{
+		Group:    SchemeGroupVersion.Group,
+		Version:  SchemeGroupVersion.Version,
+		Resource: "endpointqueries"}
+	ConnectivityQueryVersionResource = schema.GroupVersionResource{
+		Group:    SchemeGroupVersion.Group,
+		Version:  SchemeGroupVersion.Version,
+		Resource: "connectivityqueries"}
 )
 
 // Resource takes an unqualified resource and returns a Group qualified GroupResource.
@@ -63,6 +75,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&AddressGroupList{},
 		&NetworkPolicy{},
 		&NetworkPolicyList{},
+		&NetworkPolicyStatus{},
+		&NodeStatsSummary{},
+		&NetworkPolicyStats{},
+		&NetworkPolicyStatsList{},
+		&EndpointQuery{},
+		&ConnectivityQuery{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
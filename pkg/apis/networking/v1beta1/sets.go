@@ -30,8 +30,12 @@ var (
 	}
 )
 
-// groupMemberPodHash is used to uniquely identify GroupMemberPod. Only Pod and
-// IP field are included as unique identifiers.
+// groupMemberPodHash is used to uniquely identify GroupMemberPod. Pod, IP, IPs
+// and Ports fields are included as unique identifiers. Ports must be included so
+// that a Pod recreated with the same name and IP but different named ports is
+// considered a different member, and the named ports are resolved again. IPs
+// must be included so that a Pod gaining or losing an address of the other IP
+// family is considered a different member.
 type groupMemberPodHash string
 
 // GroupMemberPodSet is a set of GroupMemberPods.
@@ -42,7 +46,7 @@ type GroupMemberPodSet map[groupMemberPodHash]*GroupMemberPod
 // a pointer changes.
 func hashGroupMemberPod(pod *GroupMemberPod) groupMemberPodHash {
 	hasher := md5.New()
-	hashObj := GroupMemberPod{Pod: pod.Pod, IP: pod.IP}
+	hashObj := GroupMemberPod{Pod: pod.Pod, IP: pod.IP, IPs: pod.IPs, Ports: pod.Ports}
 	printer.Fprintf(hasher, "%#v", hashObj)
 	return groupMemberPodHash(hex.EncodeToString(hasher.Sum(nil)[0:]))
 }
@@ -0,0 +1,40 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupMemberPodSetIPs(t *testing.T) {
+	pod := &PodReference{Name: "pod1", Namespace: "ns1"}
+	ipv4 := IPAddress(net.ParseIP("10.0.0.1").To4())
+	ipv6 := IPAddress(net.ParseIP("fd00::1"))
+	singleStack := &GroupMemberPod{Pod: pod, IP: ipv4, IPs: []IPAddress{ipv4}}
+	dualStack := &GroupMemberPod{Pod: pod, IP: ipv4, IPs: []IPAddress{ipv4, ipv6}}
+
+	s := NewGroupMemberPodSet(singleStack)
+	assert.True(t, s.Has(singleStack))
+	// A Pod which only gained an IPv6 address must be considered a different
+	// member so that the change is propagated.
+	assert.False(t, s.Has(dualStack))
+	s.Insert(dualStack)
+	assert.Len(t, s, 2)
+	s.Delete(singleStack)
+	assert.True(t, s.Equal(NewGroupMemberPodSet(dualStack)))
+}
@@ -16,7 +16,10 @@ package v1beta1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
 )
 
 // +genclient
@@ -57,6 +60,9 @@ type GroupMemberPod struct {
 	IP IPAddress `json:"ip,omitempty" protobuf:"bytes,2,opt,name=ip"`
 	// Ports maintain the named port mapping of this Pod.
 	Ports []NamedPort `json:"ports,omitempty" protobuf:"bytes,3,rep,name=ports"`
+	// IPs maintains the IPAddresses associated with the Pod, at most one for each
+	// IP family. IP is the first one of them and is kept for backward compatibility.
+	IPs []IPAddress `json:"ips,omitempty" protobuf:"bytes,4,rep,name=ips"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -124,8 +130,36 @@ type NetworkPolicy struct {
 	Rules []NetworkPolicyRule `json:"rules,omitempty" protobuf:"bytes,2,rep,name=rules"`
 	// AppliedToGroups is a list of names of AppliedToGroups to which this policy applies.
 	AppliedToGroups []string `json:"appliedToGroups,omitempty" protobuf:"bytes,3,rep,name=appliedToGroups"`
+	// Priority represents the relative priority of this NetworkPolicy as compared to
+	// other NetworkPolicies in the same tier. Priority will be unset (nil) for K8s
+	// NetworkPolicy.
+	Priority *float64 `json:"priority,omitempty" protobuf:"fixed64,4,opt,name=priority"`
+	// TierPriority represents the priority of the tier associated with this NetworkPolicy.
+	// TierPriority will be unset (nil) for K8s NetworkPolicy.
+	TierPriority *TierPriority `json:"tierPriority,omitempty" protobuf:"varint,5,opt,name=tierPriority"`
+	// Status is the realization status of this NetworkPolicy, aggregated from
+	// the statuses reported by the Nodes it spans. It is only set in the
+	// responses of get and list requests.
+	Status *NetworkPolicyRealizationStatus `json:"status,omitempty" protobuf:"bytes,6,opt,name=status"`
+}
+
+// NetworkPolicyRealizationStatus is the realization status of a NetworkPolicy
+// across the Nodes it spans.
+type NetworkPolicyRealizationStatus struct {
+	// CurrentNodesRealized is the number of Nodes that have realized the
+	// current generation of this NetworkPolicy.
+	CurrentNodesRealized int32 `json:"currentNodesRealized" protobuf:"varint,1,opt,name=currentNodesRealized"`
+	// DesiredNodesRealized is the number of Nodes this NetworkPolicy spans.
+	DesiredNodesRealized int32 `json:"desiredNodesRealized" protobuf:"varint,2,opt,name=desiredNodesRealized"`
+	// FailedNodes is a list of statuses of the Nodes that failed to realize
+	// some rules of the current generation of this NetworkPolicy.
+	FailedNodes []NetworkPolicyNodeStatus `json:"failedNodes,omitempty" protobuf:"bytes,3,rep,name=failedNodes"`
 }
 
+// TierPriority specifies the relative ordering among tiers. A lower value
+// means a higher precedence.
+type TierPriority uint32
+
 // Direction defines traffic direction of NetworkPolicyRule.
 type Direction string
 
@@ -146,6 +180,20 @@ type NetworkPolicyRule struct {
 	To NetworkPolicyPeer `json:"to,omitempty" protobuf:"bytes,3,opt,name=to"`
 	// Services is a list of services which should be matched.
 	Services []Service `json:"services,omitempty" protobuf:"bytes,4,rep,name=services"`
+	// Priority defines the priority of the rule as compared to other rules in the
+	// NetworkPolicy. A lower value means a higher precedence.
+	Priority int32 `json:"priority,omitempty" protobuf:"varint,5,opt,name=priority"`
+	// Action specifies the action to be applied on the rule, i.e. Allow, Drop or
+	// Reject. An empty action "nil" defaults to Allow, which would be the case for
+	// rules created for K8s NetworkPolicy.
+	Action *secv1alpha1.RuleAction `json:"action,omitempty" protobuf:"bytes,6,opt,name=action,casttype=github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1.RuleAction"`
+	// EnableLogging indicates whether the connections matching the rule, and
+	// the connections dropped because the rule isolates its Pods, must be logged.
+	EnableLogging bool `json:"enableLogging,omitempty" protobuf:"varint,7,opt,name=enableLogging"`
+	// Name is the name of the rule, unique within the NetworkPolicy. It's
+	// generated from the direction of the rule and its position in the spec,
+	// e.g. "ingress-0".
+	Name string `json:"name,omitempty" protobuf:"bytes,8,opt,name=name"`
 }
 
 // Protocol defines network protocols supported for things like container ports.
@@ -158,17 +206,31 @@ const (
 	ProtocolUDP Protocol = "UDP"
 	// ProtocolSCTP is the SCTP protocol.
 	ProtocolSCTP Protocol = "SCTP"
+	// ProtocolICMP is the ICMP protocol, or ICMPv6 for IPv6 traffic.
+	ProtocolICMP Protocol = "ICMP"
 )
 
 // Service describes a port to allow traffic on.
 type Service struct {
-	// The protocol (TCP, UDP, or SCTP) which traffic must match. If not specified, this
+	// The protocol (TCP, UDP, SCTP, or ICMP) which traffic must match. If not specified, this
 	// field defaults to TCP.
 	// +optional
 	Protocol *Protocol `json:"protocol,omitempty" protobuf:"bytes,1,opt,name=protocol"`
 	// The port name or number on the given protocol. If not specified, this matches all port numbers.
 	// +optional
 	Port *intstr.IntOrString `json:"port,omitempty" protobuf:"bytes,2,opt,name=port"`
+	// EndPort, if set, makes the Service match the range of port numbers from Port to
+	// EndPort, both inclusive. It can only be set when Port is a number.
+	// +optional
+	EndPort *int32 `json:"endPort,omitempty" protobuf:"varint,3,opt,name=endPort"`
+	// ICMPType is the ICMP type which traffic must match, only used with the ICMP
+	// protocol. If not specified, this matches all ICMP types.
+	// +optional
+	ICMPType *int32 `json:"icmpType,omitempty" protobuf:"varint,4,opt,name=icmpType"`
+	// ICMPCode is the ICMP code which traffic must match, only used with the ICMP
+	// protocol. If not specified, this matches all ICMP codes.
+	// +optional
+	ICMPCode *int32 `json:"icmpCode,omitempty" protobuf:"varint,5,opt,name=icmpCode"`
 }
 
 // NetworkPolicyPeer describes a peer of NetworkPolicyRules.
@@ -178,6 +240,10 @@ type NetworkPolicyPeer struct {
 	AddressGroups []string `json:"addressGroups,omitempty" protobuf:"bytes,1,rep,name=addressGroups"`
 	// A list of IPBlock.
 	IPBlocks []IPBlock `json:"ipBlocks,omitempty" protobuf:"bytes,2,rep,name=ipBlocks"`
+	// A list of FQDNs, which are resolved by the agent by snooping the DNS
+	// responses sent to the Pods the rule applies to. Only valid for egress
+	// rules. A leading "*." matches any subdomain.
+	FQDNs []string `json:"fqdns,omitempty" protobuf:"bytes,3,rep,name=fqdns"`
 }
 
 // IPBlock describes a particular CIDR (Ex. "192.168.1.1/24"). The except entry describes CIDRs that should
@@ -198,3 +264,185 @@ type NetworkPolicyList struct {
 	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
 	Items           []NetworkPolicy `json:"items" protobuf:"bytes,2,rep,name=items"`
 }
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:onlyVerbs=create
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// NetworkPolicyStatus is the status of a NetworkPolicy reported by antrea-agents.
+// Its name, namespace and UID are the ones of the NetworkPolicy.
+type NetworkPolicyStatus struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	// Nodes is a list of statuses of the NetworkPolicy on the reporting Nodes.
+	Nodes []NetworkPolicyNodeStatus `json:"nodes,omitempty" protobuf:"bytes,2,rep,name=nodes"`
+}
+
+// NetworkPolicyNodeStatus is the status of a NetworkPolicy on a Node.
+type NetworkPolicyNodeStatus struct {
+	// NodeName is the name of the Node that produced this status.
+	NodeName string `json:"nodeName" protobuf:"bytes,1,opt,name=nodeName"`
+	// Generation is the generation of the NetworkPolicy processed by the Node.
+	Generation int64 `json:"generation" protobuf:"varint,2,opt,name=generation"`
+	// FailedRules is the number of rules of the NetworkPolicy that the Node
+	// failed to realize.
+	FailedRules int32 `json:"failedRules,omitempty" protobuf:"varint,3,opt,name=failedRules"`
+	// LastError is the error encountered when realizing a failed rule.
+	LastError string `json:"lastError,omitempty" protobuf:"bytes,4,opt,name=lastError"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:onlyVerbs=create
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// NodeStatsSummary contains the traffic statistics collected by an antrea-agent
+// since its last report. Its name is the name of the Node.
+type NodeStatsSummary struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	// NetworkPolicies is a list of the traffic statistics of the
+	// NetworkPolicies collected on the Node.
+	NetworkPolicies []NetworkPolicyStats `json:"networkPolicies,omitempty" protobuf:"bytes,2,rep,name=networkPolicies"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// NetworkPolicyStats is the traffic statistics of a NetworkPolicy. Its name,
+// namespace and UID are the ones of the NetworkPolicy.
+type NetworkPolicyStats struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	// TrafficStats is the traffic statistics of the NetworkPolicy.
+	TrafficStats TrafficStats `json:"trafficStats" protobuf:"bytes,2,opt,name=trafficStats"`
+	// RuleTrafficStats is a list of the traffic statistics of the rules of
+	// the NetworkPolicy.
+	RuleTrafficStats []RuleTrafficStats `json:"ruleTrafficStats,omitempty" protobuf:"bytes,3,rep,name=ruleTrafficStats"`
+}
+
+// RuleTrafficStats contains the traffic statistics of a NetworkPolicy rule.
+type RuleTrafficStats struct {
+	// Name is the name of the rule.
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// TrafficStats is the traffic statistics of the rule.
+	TrafficStats TrafficStats `json:"trafficStats" protobuf:"bytes,2,opt,name=trafficStats"`
+}
+
+// TrafficStats contains the traffic statistics of an object.
+type TrafficStats struct {
+	// Packets is the number of packets.
+	Packets int64 `json:"packets" protobuf:"varint,1,opt,name=packets"`
+	// Bytes is the number of bytes.
+	Bytes int64 `json:"bytes" protobuf:"varint,2,opt,name=bytes"`
+	// Sessions is the number of sessions.
+	Sessions int64 `json:"sessions" protobuf:"varint,3,opt,name=sessions"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// NetworkPolicyStatsList is a list of NetworkPolicyStats.
+type NetworkPolicyStatsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	Items           []NetworkPolicyStats `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// NetworkPolicyReference is a reference to a NetworkPolicy.
+type NetworkPolicyReference struct {
+	// Namespace of the NetworkPolicy. It is empty for ClusterNetworkPolicies.
+	Namespace string `json:"namespace,omitempty" protobuf:"bytes,1,opt,name=namespace"`
+	// Name of the NetworkPolicy.
+	Name string `json:"name,omitempty" protobuf:"bytes,2,opt,name=name"`
+	// UID of the NetworkPolicy.
+	UID types.UID `json:"uid,omitempty" protobuf:"bytes,3,opt,name=uid,casttype=k8s.io/apimachinery/pkg/types.UID"`
+}
+
+// NetworkPolicyRuleReference is a reference to a rule of a NetworkPolicy.
+type NetworkPolicyRuleReference struct {
+	// Policy is the NetworkPolicy the rule belongs to.
+	Policy NetworkPolicyReference `json:"policy" protobuf:"bytes,1,opt,name=policy"`
+	// Direction is the direction of the rule.
+	Direction Direction `json:"direction" protobuf:"bytes,2,opt,name=direction"`
+	// Index is the index of the rule in the Rules of the NetworkPolicy.
+	Index int32 `json:"index" protobuf:"varint,3,opt,name=index"`
+	// Action is the action of the rule. It is nil for the rules of K8s
+	// NetworkPolicies, which are always Allow.
+	Action *secv1alpha1.RuleAction `json:"action,omitempty" protobuf:"bytes,4,opt,name=action,casttype=github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1.RuleAction"`
+}
+
+// +genclient
+// +genclient:onlyVerbs=get
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// EndpointQuery describes the NetworkPolicies affecting a Pod. Its name and
+// namespace are the ones of the Pod.
+type EndpointQuery struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	// AppliedPolicies is a list of the NetworkPolicies applied to the Pod.
+	AppliedPolicies []NetworkPolicyReference `json:"appliedPolicies,omitempty" protobuf:"bytes,2,rep,name=appliedPolicies"`
+	// PeerRules is a list of the rules of any NetworkPolicy which select the
+	// Pod as a peer, i.e. the ingress rules whose sources include the Pod and
+	// the egress rules whose destinations include the Pod.
+	PeerRules []NetworkPolicyRuleReference `json:"peerRules,omitempty" protobuf:"bytes,3,rep,name=peerRules"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:onlyVerbs=create
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// ConnectivityQuery evaluates whether the traffic from a source to a
+// destination is allowed by the NetworkPolicies.
+type ConnectivityQuery struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	// Spec describes the traffic to evaluate.
+	Spec ConnectivityQuerySpec `json:"spec" protobuf:"bytes,2,opt,name=spec"`
+	// Status is the result of the evaluation.
+	Status ConnectivityQueryStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// ConnectivityQuerySpec describes the traffic to evaluate.
+type ConnectivityQuerySpec struct {
+	// Source is the source of the traffic.
+	Source ConnectivityQueryPeer `json:"source" protobuf:"bytes,1,opt,name=source"`
+	// Destination is the destination of the traffic.
+	Destination ConnectivityQueryPeer `json:"destination" protobuf:"bytes,2,opt,name=destination"`
+	// Protocol is the protocol of the traffic. It defaults to TCP.
+	Protocol Protocol `json:"protocol,omitempty" protobuf:"bytes,3,opt,name=protocol"`
+	// Port is the destination port of the traffic. 0 means any port, in
+	// which case only the rules matching all ports are considered.
+	Port int32 `json:"port,omitempty" protobuf:"varint,4,opt,name=port"`
+}
+
+// ConnectivityQueryPeer is a peer of a ConnectivityQuery. Exactly one of Pod
+// and IP must be set.
+type ConnectivityQueryPeer struct {
+	// Pod is a reference to a Pod.
+	Pod *PodReference `json:"pod,omitempty" protobuf:"bytes,1,opt,name=pod"`
+	// IP is an IP address which doesn't belong to a Pod.
+	IP string `json:"ip,omitempty" protobuf:"bytes,2,opt,name=ip"`
+}
+
+// ConnectivityQueryStatus is the result of a ConnectivityQuery.
+type ConnectivityQueryStatus struct {
+	// Allowed indicates whether the traffic is allowed in both directions
+	// it is subject to.
+	Allowed bool `json:"allowed" protobuf:"varint,1,opt,name=allowed"`
+	// Egress is the verdict of the egress policies of the source. It is nil
+	// if the source isn't a Pod.
+	Egress *ConnectivityQueryVerdict `json:"egress,omitempty" protobuf:"bytes,2,opt,name=egress"`
+	// Ingress is the verdict of the ingress policies of the destination. It
+	// is nil if the destination isn't a Pod.
+	Ingress *ConnectivityQueryVerdict `json:"ingress,omitempty" protobuf:"bytes,3,opt,name=ingress"`
+}
+
+// ConnectivityQueryVerdict is the verdict of the NetworkPolicies applied to a
+// Pod for a direction.
+type ConnectivityQueryVerdict struct {
+	// Allowed indicates whether the traffic is allowed in this direction.
+	Allowed bool `json:"allowed" protobuf:"varint,1,opt,name=allowed"`
+	// Rule is the rule that decided the verdict. It is nil if no rule
+	// matched the traffic.
+	Rule *NetworkPolicyRuleReference `json:"rule,omitempty" protobuf:"bytes,2,opt,name=rule"`
+	// Isolated indicates whether the Pod is isolated in this direction by
+	// K8s NetworkPolicies, in which case the traffic matching no rule is
+	// dropped.
+	Isolated bool `json:"isolated" protobuf:"varint,3,opt,name=isolated"`
+}
@@ -22,8 +22,10 @@ import (
 	unsafe "unsafe"
 
 	networking "github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	v1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	types "k8s.io/apimachinery/pkg/types"
 	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -94,6 +96,66 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ConnectivityQuery)(nil), (*networking.ConnectivityQuery)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ConnectivityQuery_To_networking_ConnectivityQuery(a.(*ConnectivityQuery), b.(*networking.ConnectivityQuery), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.ConnectivityQuery)(nil), (*ConnectivityQuery)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_ConnectivityQuery_To_v1beta1_ConnectivityQuery(a.(*networking.ConnectivityQuery), b.(*ConnectivityQuery), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ConnectivityQueryPeer)(nil), (*networking.ConnectivityQueryPeer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ConnectivityQueryPeer_To_networking_ConnectivityQueryPeer(a.(*ConnectivityQueryPeer), b.(*networking.ConnectivityQueryPeer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.ConnectivityQueryPeer)(nil), (*ConnectivityQueryPeer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_ConnectivityQueryPeer_To_v1beta1_ConnectivityQueryPeer(a.(*networking.ConnectivityQueryPeer), b.(*ConnectivityQueryPeer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ConnectivityQuerySpec)(nil), (*networking.ConnectivityQuerySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ConnectivityQuerySpec_To_networking_ConnectivityQuerySpec(a.(*ConnectivityQuerySpec), b.(*networking.ConnectivityQuerySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.ConnectivityQuerySpec)(nil), (*ConnectivityQuerySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_ConnectivityQuerySpec_To_v1beta1_ConnectivityQuerySpec(a.(*networking.ConnectivityQuerySpec), b.(*ConnectivityQuerySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ConnectivityQueryStatus)(nil), (*networking.ConnectivityQueryStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ConnectivityQueryStatus_To_networking_ConnectivityQueryStatus(a.(*ConnectivityQueryStatus), b.(*networking.ConnectivityQueryStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.ConnectivityQueryStatus)(nil), (*ConnectivityQueryStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_ConnectivityQueryStatus_To_v1beta1_ConnectivityQueryStatus(a.(*networking.ConnectivityQueryStatus), b.(*ConnectivityQueryStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ConnectivityQueryVerdict)(nil), (*networking.ConnectivityQueryVerdict)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ConnectivityQueryVerdict_To_networking_ConnectivityQueryVerdict(a.(*ConnectivityQueryVerdict), b.(*networking.ConnectivityQueryVerdict), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.ConnectivityQueryVerdict)(nil), (*ConnectivityQueryVerdict)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_ConnectivityQueryVerdict_To_v1beta1_ConnectivityQueryVerdict(a.(*networking.ConnectivityQueryVerdict), b.(*ConnectivityQueryVerdict), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EndpointQuery)(nil), (*networking.EndpointQuery)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EndpointQuery_To_networking_EndpointQuery(a.(*EndpointQuery), b.(*networking.EndpointQuery), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.EndpointQuery)(nil), (*EndpointQuery)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_EndpointQuery_To_v1beta1_EndpointQuery(a.(*networking.EndpointQuery), b.(*EndpointQuery), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*GroupMemberPod)(nil), (*networking.GroupMemberPod)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_GroupMemberPod_To_networking_GroupMemberPod(a.(*GroupMemberPod), b.(*networking.GroupMemberPod), scope)
 	}); err != nil {
@@ -154,6 +216,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*NetworkPolicyNodeStatus)(nil), (*networking.NetworkPolicyNodeStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NetworkPolicyNodeStatus_To_networking_NetworkPolicyNodeStatus(a.(*NetworkPolicyNodeStatus), b.(*networking.NetworkPolicyNodeStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.NetworkPolicyNodeStatus)(nil), (*NetworkPolicyNodeStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_NetworkPolicyNodeStatus_To_v1beta1_NetworkPolicyNodeStatus(a.(*networking.NetworkPolicyNodeStatus), b.(*NetworkPolicyNodeStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NetworkPolicyPeer)(nil), (*networking.NetworkPolicyPeer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_NetworkPolicyPeer_To_networking_NetworkPolicyPeer(a.(*NetworkPolicyPeer), b.(*networking.NetworkPolicyPeer), scope)
 	}); err != nil {
@@ -164,6 +236,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*NetworkPolicyRealizationStatus)(nil), (*networking.NetworkPolicyRealizationStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NetworkPolicyRealizationStatus_To_networking_NetworkPolicyRealizationStatus(a.(*NetworkPolicyRealizationStatus), b.(*networking.NetworkPolicyRealizationStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.NetworkPolicyRealizationStatus)(nil), (*NetworkPolicyRealizationStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_NetworkPolicyRealizationStatus_To_v1beta1_NetworkPolicyRealizationStatus(a.(*networking.NetworkPolicyRealizationStatus), b.(*NetworkPolicyRealizationStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NetworkPolicyReference)(nil), (*networking.NetworkPolicyReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NetworkPolicyReference_To_networking_NetworkPolicyReference(a.(*NetworkPolicyReference), b.(*networking.NetworkPolicyReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.NetworkPolicyReference)(nil), (*NetworkPolicyReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_NetworkPolicyReference_To_v1beta1_NetworkPolicyReference(a.(*networking.NetworkPolicyReference), b.(*NetworkPolicyReference), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NetworkPolicyRule)(nil), (*networking.NetworkPolicyRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_NetworkPolicyRule_To_networking_NetworkPolicyRule(a.(*NetworkPolicyRule), b.(*networking.NetworkPolicyRule), scope)
 	}); err != nil {
@@ -174,6 +266,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*NetworkPolicyRuleReference)(nil), (*networking.NetworkPolicyRuleReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NetworkPolicyRuleReference_To_networking_NetworkPolicyRuleReference(a.(*NetworkPolicyRuleReference), b.(*networking.NetworkPolicyRuleReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.NetworkPolicyRuleReference)(nil), (*NetworkPolicyRuleReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_NetworkPolicyRuleReference_To_v1beta1_NetworkPolicyRuleReference(a.(*networking.NetworkPolicyRuleReference), b.(*NetworkPolicyRuleReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NetworkPolicyStats)(nil), (*networking.NetworkPolicyStats)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NetworkPolicyStats_To_networking_NetworkPolicyStats(a.(*NetworkPolicyStats), b.(*networking.NetworkPolicyStats), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.NetworkPolicyStats)(nil), (*NetworkPolicyStats)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_NetworkPolicyStats_To_v1beta1_NetworkPolicyStats(a.(*networking.NetworkPolicyStats), b.(*NetworkPolicyStats), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NetworkPolicyStatsList)(nil), (*networking.NetworkPolicyStatsList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NetworkPolicyStatsList_To_networking_NetworkPolicyStatsList(a.(*NetworkPolicyStatsList), b.(*networking.NetworkPolicyStatsList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.NetworkPolicyStatsList)(nil), (*NetworkPolicyStatsList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_NetworkPolicyStatsList_To_v1beta1_NetworkPolicyStatsList(a.(*networking.NetworkPolicyStatsList), b.(*NetworkPolicyStatsList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NetworkPolicyStatus)(nil), (*networking.NetworkPolicyStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NetworkPolicyStatus_To_networking_NetworkPolicyStatus(a.(*NetworkPolicyStatus), b.(*networking.NetworkPolicyStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.NetworkPolicyStatus)(nil), (*NetworkPolicyStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_NetworkPolicyStatus_To_v1beta1_NetworkPolicyStatus(a.(*networking.NetworkPolicyStatus), b.(*NetworkPolicyStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodeStatsSummary)(nil), (*networking.NodeStatsSummary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NodeStatsSummary_To_networking_NodeStatsSummary(a.(*NodeStatsSummary), b.(*networking.NodeStatsSummary), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.NodeStatsSummary)(nil), (*NodeStatsSummary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_NodeStatsSummary_To_v1beta1_NodeStatsSummary(a.(*networking.NodeStatsSummary), b.(*NodeStatsSummary), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*PodReference)(nil), (*networking.PodReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_PodReference_To_networking_PodReference(a.(*PodReference), b.(*networking.PodReference), scope)
 	}); err != nil {
@@ -184,6 +326,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RuleTrafficStats)(nil), (*networking.RuleTrafficStats)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RuleTrafficStats_To_networking_RuleTrafficStats(a.(*RuleTrafficStats), b.(*networking.RuleTrafficStats), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.RuleTrafficStats)(nil), (*RuleTrafficStats)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_RuleTrafficStats_To_v1beta1_RuleTrafficStats(a.(*networking.RuleTrafficStats), b.(*RuleTrafficStats), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Service)(nil), (*networking.Service)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_Service_To_networking_Service(a.(*Service), b.(*networking.Service), scope)
 	}); err != nil {
@@ -194,6 +346,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*TrafficStats)(nil), (*networking.TrafficStats)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_TrafficStats_To_networking_TrafficStats(a.(*TrafficStats), b.(*networking.TrafficStats), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.TrafficStats)(nil), (*TrafficStats)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_TrafficStats_To_v1beta1_TrafficStats(a.(*networking.TrafficStats), b.(*TrafficStats), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -333,10 +495,171 @@ func Convert_networking_AppliedToGroupPatch_To_v1beta1_AppliedToGroupPatch(in *n
 	return autoConvert_networking_AppliedToGroupPatch_To_v1beta1_AppliedToGroupPatch(in, out, s)
 }
 
+func autoConvert_v1beta1_ConnectivityQuery_To_networking_ConnectivityQuery(in *ConnectivityQuery, out *networking.ConnectivityQuery, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_ConnectivityQuerySpec_To_networking_ConnectivityQuerySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_ConnectivityQueryStatus_To_networking_ConnectivityQueryStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_ConnectivityQuery_To_networking_ConnectivityQuery is an autogenerated conversion function.
+func Convert_v1beta1_ConnectivityQuery_To_networking_ConnectivityQuery(in *ConnectivityQuery, out *networking.ConnectivityQuery, s conversion.Scope) error {
+	return autoConvert_v1beta1_ConnectivityQuery_To_networking_ConnectivityQuery(in, out, s)
+}
+
+func autoConvert_networking_ConnectivityQuery_To_v1beta1_ConnectivityQuery(in *networking.ConnectivityQuery, out *ConnectivityQuery, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_networking_ConnectivityQuerySpec_To_v1beta1_ConnectivityQuerySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_networking_ConnectivityQueryStatus_To_v1beta1_ConnectivityQueryStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_networking_ConnectivityQuery_To_v1beta1_ConnectivityQuery is an autogenerated conversion function.
+func Convert_networking_ConnectivityQuery_To_v1beta1_ConnectivityQuery(in *networking.ConnectivityQuery, out *ConnectivityQuery, s conversion.Scope) error {
+	return autoConvert_networking_ConnectivityQuery_To_v1beta1_ConnectivityQuery(in, out, s)
+}
+
+func autoConvert_v1beta1_ConnectivityQueryPeer_To_networking_ConnectivityQueryPeer(in *ConnectivityQueryPeer, out *networking.ConnectivityQueryPeer, s conversion.Scope) error {
+	out.Pod = (*networking.PodReference)(unsafe.Pointer(in.Pod))
+	out.IP = in.IP
+	return nil
+}
+
+// Convert_v1beta1_ConnectivityQueryPeer_To_networking_ConnectivityQueryPeer is an autogenerated conversion function.
+func Convert_v1beta1_ConnectivityQueryPeer_To_networking_ConnectivityQueryPeer(in *ConnectivityQueryPeer, out *networking.ConnectivityQueryPeer, s conversion.Scope) error {
+	return autoConvert_v1beta1_ConnectivityQueryPeer_To_networking_ConnectivityQueryPeer(in, out, s)
+}
+
+func autoConvert_networking_ConnectivityQueryPeer_To_v1beta1_ConnectivityQueryPeer(in *networking.ConnectivityQueryPeer, out *ConnectivityQueryPeer, s conversion.Scope) error {
+	out.Pod = (*PodReference)(unsafe.Pointer(in.Pod))
+	out.IP = in.IP
+	return nil
+}
+
+// Convert_networking_ConnectivityQueryPeer_To_v1beta1_ConnectivityQueryPeer is an autogenerated conversion function.
+func Convert_networking_ConnectivityQueryPeer_To_v1beta1_ConnectivityQueryPeer(in *networking.ConnectivityQueryPeer, out *ConnectivityQueryPeer, s conversion.Scope) error {
+	return autoConvert_networking_ConnectivityQueryPeer_To_v1beta1_ConnectivityQueryPeer(in, out, s)
+}
+
+func autoConvert_v1beta1_ConnectivityQuerySpec_To_networking_ConnectivityQuerySpec(in *ConnectivityQuerySpec, out *networking.ConnectivityQuerySpec, s conversion.Scope) error {
+	if err := Convert_v1beta1_ConnectivityQueryPeer_To_networking_ConnectivityQueryPeer(&in.Source, &out.Source, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_ConnectivityQueryPeer_To_networking_ConnectivityQueryPeer(&in.Destination, &out.Destination, s); err != nil {
+		return err
+	}
+	out.Protocol = networking.Protocol(in.Protocol)
+	out.Port = in.Port
+	return nil
+}
+
+// Convert_v1beta1_ConnectivityQuerySpec_To_networking_ConnectivityQuerySpec is an autogenerated conversion function.
+func Convert_v1beta1_ConnectivityQuerySpec_To_networking_ConnectivityQuerySpec(in *ConnectivityQuerySpec, out *networking.ConnectivityQuerySpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_ConnectivityQuerySpec_To_networking_ConnectivityQuerySpec(in, out, s)
+}
+
+func autoConvert_networking_ConnectivityQuerySpec_To_v1beta1_ConnectivityQuerySpec(in *networking.ConnectivityQuerySpec, out *ConnectivityQuerySpec, s conversion.Scope) error {
+	if err := Convert_networking_ConnectivityQueryPeer_To_v1beta1_ConnectivityQueryPeer(&in.Source, &out.Source, s); err != nil {
+		return err
+	}
+	if err := Convert_networking_ConnectivityQueryPeer_To_v1beta1_ConnectivityQueryPeer(&in.Destination, &out.Destination, s); err != nil {
+		return err
+	}
+	out.Protocol = Protocol(in.Protocol)
+	out.Port = in.Port
+	return nil
+}
+
+// Convert_networking_ConnectivityQuerySpec_To_v1beta1_ConnectivityQuerySpec is an autogenerated conversion function.
+func Convert_networking_ConnectivityQuerySpec_To_v1beta1_ConnectivityQuerySpec(in *networking.ConnectivityQuerySpec, out *ConnectivityQuerySpec, s conversion.Scope) error {
+	return autoConvert_networking_ConnectivityQuerySpec_To_v1beta1_ConnectivityQuerySpec(in, out, s)
+}
+
+func autoConvert_v1beta1_ConnectivityQueryStatus_To_networking_ConnectivityQueryStatus(in *ConnectivityQueryStatus, out *networking.ConnectivityQueryStatus, s conversion.Scope) error {
+	out.Allowed = in.Allowed
+	out.Egress = (*networking.ConnectivityQueryVerdict)(unsafe.Pointer(in.Egress))
+	out.Ingress = (*networking.ConnectivityQueryVerdict)(unsafe.Pointer(in.Ingress))
+	return nil
+}
+
+// Convert_v1beta1_ConnectivityQueryStatus_To_networking_ConnectivityQueryStatus is an autogenerated conversion function.
+func Convert_v1beta1_ConnectivityQueryStatus_To_networking_ConnectivityQueryStatus(in *ConnectivityQueryStatus, out *networking.ConnectivityQueryStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_ConnectivityQueryStatus_To_networking_ConnectivityQueryStatus(in, out, s)
+}
+
+func autoConvert_networking_ConnectivityQueryStatus_To_v1beta1_ConnectivityQueryStatus(in *networking.ConnectivityQueryStatus, out *ConnectivityQueryStatus, s conversion.Scope) error {
+	out.Allowed = in.Allowed
+	out.Egress = (*ConnectivityQueryVerdict)(unsafe.Pointer(in.Egress))
+	out.Ingress = (*ConnectivityQueryVerdict)(unsafe.Pointer(in.Ingress))
+	return nil
+}
+
+// Convert_networking_ConnectivityQueryStatus_To_v1beta1_ConnectivityQueryStatus is an autogenerated conversion function.
+func Convert_networking_ConnectivityQueryStatus_To_v1beta1_ConnectivityQueryStatus(in *networking.ConnectivityQueryStatus, out *ConnectivityQueryStatus, s conversion.Scope) error {
+	return autoConvert_networking_ConnectivityQueryStatus_To_v1beta1_ConnectivityQueryStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_ConnectivityQueryVerdict_To_networking_ConnectivityQueryVerdict(in *ConnectivityQueryVerdict, out *networking.ConnectivityQueryVerdict, s conversion.Scope) error {
+	out.Allowed = in.Allowed
+	out.Rule = (*networking.NetworkPolicyRuleReference)(unsafe.Pointer(in.Rule))
+	out.Isolated = in.Isolated
+	return nil
+}
+
+// Convert_v1beta1_ConnectivityQueryVerdict_To_networking_ConnectivityQueryVerdict is an autogenerated conversion function.
+func Convert_v1beta1_ConnectivityQueryVerdict_To_networking_ConnectivityQueryVerdict(in *ConnectivityQueryVerdict, out *networking.ConnectivityQueryVerdict, s conversion.Scope) error {
+	return autoConvert_v1beta1_ConnectivityQueryVerdict_To_networking_ConnectivityQueryVerdict(in, out, s)
+}
+
+func autoConvert_networking_ConnectivityQueryVerdict_To_v1beta1_ConnectivityQueryVerdict(in *networking.ConnectivityQueryVerdict, out *ConnectivityQueryVerdict, s conversion.Scope) error {
+	out.Allowed = in.Allowed
+	out.Rule = (*NetworkPolicyRuleReference)(unsafe.Pointer(in.Rule))
+	out.Isolated = in.Isolated
+	return nil
+}
+
+// Convert_networking_ConnectivityQueryVerdict_To_v1beta1_ConnectivityQueryVerdict is an autogenerated conversion function.
+func Convert_networking_ConnectivityQueryVerdict_To_v1beta1_ConnectivityQueryVerdict(in *networking.ConnectivityQueryVerdict, out *ConnectivityQueryVerdict, s conversion.Scope) error {
+	return autoConvert_networking_ConnectivityQueryVerdict_To_v1beta1_ConnectivityQueryVerdict(in, out, s)
+}
+
+func autoConvert_v1beta1_EndpointQuery_To_networking_EndpointQuery(in *EndpointQuery, out *networking.EndpointQuery, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.AppliedPolicies = *(*[]networking.NetworkPolicyReference)(unsafe.Pointer(&in.AppliedPolicies))
+	out.PeerRules = *(*[]networking.NetworkPolicyRuleReference)(unsafe.Pointer(&in.PeerRules))
+	return nil
+}
+
+// Convert_v1beta1_EndpointQuery_To_networking_EndpointQuery is an autogenerated conversion function.
+func Convert_v1beta1_EndpointQuery_To_networking_EndpointQuery(in *EndpointQuery, out *networking.EndpointQuery, s conversion.Scope) error {
+	return autoConvert_v1beta1_EndpointQuery_To_networking_EndpointQuery(in, out, s)
+}
+
+func autoConvert_networking_EndpointQuery_To_v1beta1_EndpointQuery(in *networking.EndpointQuery, out *EndpointQuery, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.AppliedPolicies = *(*[]NetworkPolicyReference)(unsafe.Pointer(&in.AppliedPolicies))
+	out.PeerRules = *(*[]NetworkPolicyRuleReference)(unsafe.Pointer(&in.PeerRules))
+	return nil
+}
+
+// Convert_networking_EndpointQuery_To_v1beta1_EndpointQuery is an autogenerated conversion function.
+func Convert_networking_EndpointQuery_To_v1beta1_EndpointQuery(in *networking.EndpointQuery, out *EndpointQuery, s conversion.Scope) error {
+	return autoConvert_networking_EndpointQuery_To_v1beta1_EndpointQuery(in, out, s)
+}
+
 func autoConvert_v1beta1_GroupMemberPod_To_networking_GroupMemberPod(in *GroupMemberPod, out *networking.GroupMemberPod, s conversion.Scope) error {
 	out.Pod = (*networking.PodReference)(unsafe.Pointer(in.Pod))
 	out.IP = *(*networking.IPAddress)(unsafe.Pointer(&in.IP))
 	out.Ports = *(*[]networking.NamedPort)(unsafe.Pointer(&in.Ports))
+	out.IPs = *(*[]networking.IPAddress)(unsafe.Pointer(&in.IPs))
 	return nil
 }
 
@@ -349,6 +672,7 @@ func autoConvert_networking_GroupMemberPod_To_v1beta1_GroupMemberPod(in *network
 	out.Pod = (*PodReference)(unsafe.Pointer(in.Pod))
 	out.IP = *(*IPAddress)(unsafe.Pointer(&in.IP))
 	out.Ports = *(*[]NamedPort)(unsafe.Pointer(&in.Ports))
+	out.IPs = *(*[]IPAddress)(unsafe.Pointer(&in.IPs))
 	return nil
 }
 
@@ -433,6 +757,9 @@ func autoConvert_v1beta1_NetworkPolicy_To_networking_NetworkPolicy(in *NetworkPo
 	out.ObjectMeta = in.ObjectMeta
 	out.Rules = *(*[]networking.NetworkPolicyRule)(unsafe.Pointer(&in.Rules))
 	out.AppliedToGroups = *(*[]string)(unsafe.Pointer(&in.AppliedToGroups))
+	out.Priority = (*float64)(unsafe.Pointer(in.Priority))
+	out.TierPriority = (*networking.TierPriority)(unsafe.Pointer(in.TierPriority))
+	out.Status = (*networking.NetworkPolicyRealizationStatus)(unsafe.Pointer(in.Status))
 	return nil
 }
 
@@ -445,6 +772,9 @@ func autoConvert_networking_NetworkPolicy_To_v1beta1_NetworkPolicy(in *networkin
 	out.ObjectMeta = in.ObjectMeta
 	out.Rules = *(*[]NetworkPolicyRule)(unsafe.Pointer(&in.Rules))
 	out.AppliedToGroups = *(*[]string)(unsafe.Pointer(&in.AppliedToGroups))
+	out.Priority = (*float64)(unsafe.Pointer(in.Priority))
+	out.TierPriority = (*TierPriority)(unsafe.Pointer(in.TierPriority))
+	out.Status = (*NetworkPolicyRealizationStatus)(unsafe.Pointer(in.Status))
 	return nil
 }
 
@@ -475,9 +805,36 @@ func Convert_networking_NetworkPolicyList_To_v1beta1_NetworkPolicyList(in *netwo
 	return autoConvert_networking_NetworkPolicyList_To_v1beta1_NetworkPolicyList(in, out, s)
 }
 
+func autoConvert_v1beta1_NetworkPolicyNodeStatus_To_networking_NetworkPolicyNodeStatus(in *NetworkPolicyNodeStatus, out *networking.NetworkPolicyNodeStatus, s conversion.Scope) error {
+	out.NodeName = in.NodeName
+	out.Generation = in.Generation
+	out.FailedRules = in.FailedRules
+	out.LastError = in.LastError
+	return nil
+}
+
+// Convert_v1beta1_NetworkPolicyNodeStatus_To_networking_NetworkPolicyNodeStatus is an autogenerated conversion function.
+func Convert_v1beta1_NetworkPolicyNodeStatus_To_networking_NetworkPolicyNodeStatus(in *NetworkPolicyNodeStatus, out *networking.NetworkPolicyNodeStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_NetworkPolicyNodeStatus_To_networking_NetworkPolicyNodeStatus(in, out, s)
+}
+
+func autoConvert_networking_NetworkPolicyNodeStatus_To_v1beta1_NetworkPolicyNodeStatus(in *networking.NetworkPolicyNodeStatus, out *NetworkPolicyNodeStatus, s conversion.Scope) error {
+	out.NodeName = in.NodeName
+	out.Generation = in.Generation
+	out.FailedRules = in.FailedRules
+	out.LastError = in.LastError
+	return nil
+}
+
+// Convert_networking_NetworkPolicyNodeStatus_To_v1beta1_NetworkPolicyNodeStatus is an autogenerated conversion function.
+func Convert_networking_NetworkPolicyNodeStatus_To_v1beta1_NetworkPolicyNodeStatus(in *networking.NetworkPolicyNodeStatus, out *NetworkPolicyNodeStatus, s conversion.Scope) error {
+	return autoConvert_networking_NetworkPolicyNodeStatus_To_v1beta1_NetworkPolicyNodeStatus(in, out, s)
+}
+
 func autoConvert_v1beta1_NetworkPolicyPeer_To_networking_NetworkPolicyPeer(in *NetworkPolicyPeer, out *networking.NetworkPolicyPeer, s conversion.Scope) error {
 	out.AddressGroups = *(*[]string)(unsafe.Pointer(&in.AddressGroups))
 	out.IPBlocks = *(*[]networking.IPBlock)(unsafe.Pointer(&in.IPBlocks))
+	out.FQDNs = *(*[]string)(unsafe.Pointer(&in.FQDNs))
 	return nil
 }
 
@@ -489,6 +846,7 @@ func Convert_v1beta1_NetworkPolicyPeer_To_networking_NetworkPolicyPeer(in *Netwo
 func autoConvert_networking_NetworkPolicyPeer_To_v1beta1_NetworkPolicyPeer(in *networking.NetworkPolicyPeer, out *NetworkPolicyPeer, s conversion.Scope) error {
 	out.AddressGroups = *(*[]string)(unsafe.Pointer(&in.AddressGroups))
 	out.IPBlocks = *(*[]IPBlock)(unsafe.Pointer(&in.IPBlocks))
+	out.FQDNs = *(*[]string)(unsafe.Pointer(&in.FQDNs))
 	return nil
 }
 
@@ -497,6 +855,54 @@ func Convert_networking_NetworkPolicyPeer_To_v1beta1_NetworkPolicyPeer(in *netwo
 	return autoConvert_networking_NetworkPolicyPeer_To_v1beta1_NetworkPolicyPeer(in, out, s)
 }
 
+func autoConvert_v1beta1_NetworkPolicyRealizationStatus_To_networking_NetworkPolicyRealizationStatus(in *NetworkPolicyRealizationStatus, out *networking.NetworkPolicyRealizationStatus, s conversion.Scope) error {
+	out.CurrentNodesRealized = in.CurrentNodesRealized
+	out.DesiredNodesRealized = in.DesiredNodesRealized
+	out.FailedNodes = *(*[]networking.NetworkPolicyNodeStatus)(unsafe.Pointer(&in.FailedNodes))
+	return nil
+}
+
+// Convert_v1beta1_NetworkPolicyRealizationStatus_To_networking_NetworkPolicyRealizationStatus is an autogenerated conversion function.
+func Convert_v1beta1_NetworkPolicyRealizationStatus_To_networking_NetworkPolicyRealizationStatus(in *NetworkPolicyRealizationStatus, out *networking.NetworkPolicyRealizationStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_NetworkPolicyRealizationStatus_To_networking_NetworkPolicyRealizationStatus(in, out, s)
+}
+
+func autoConvert_networking_NetworkPolicyRealizationStatus_To_v1beta1_NetworkPolicyRealizationStatus(in *networking.NetworkPolicyRealizationStatus, out *NetworkPolicyRealizationStatus, s conversion.Scope) error {
+	out.CurrentNodesRealized = in.CurrentNodesRealized
+	out.DesiredNodesRealized = in.DesiredNodesRealized
+	out.FailedNodes = *(*[]NetworkPolicyNodeStatus)(unsafe.Pointer(&in.FailedNodes))
+	return nil
+}
+
+// Convert_networking_NetworkPolicyRealizationStatus_To_v1beta1_NetworkPolicyRealizationStatus is an autogenerated conversion function.
+func Convert_networking_NetworkPolicyRealizationStatus_To_v1beta1_NetworkPolicyRealizationStatus(in *networking.NetworkPolicyRealizationStatus, out *NetworkPolicyRealizationStatus, s conversion.Scope) error {
+	return autoConvert_networking_NetworkPolicyRealizationStatus_To_v1beta1_NetworkPolicyRealizationStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_NetworkPolicyReference_To_networking_NetworkPolicyReference(in *NetworkPolicyReference, out *networking.NetworkPolicyReference, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.UID = types.UID(in.UID)
+	return nil
+}
+
+// Convert_v1beta1_NetworkPolicyReference_To_networking_NetworkPolicyReference is an autogenerated conversion function.
+func Convert_v1beta1_NetworkPolicyReference_To_networking_NetworkPolicyReference(in *NetworkPolicyReference, out *networking.NetworkPolicyReference, s conversion.Scope) error {
+	return autoConvert_v1beta1_NetworkPolicyReference_To_networking_NetworkPolicyReference(in, out, s)
+}
+
+func autoConvert_networking_NetworkPolicyReference_To_v1beta1_NetworkPolicyReference(in *networking.NetworkPolicyReference, out *NetworkPolicyReference, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.UID = types.UID(in.UID)
+	return nil
+}
+
+// Convert_networking_NetworkPolicyReference_To_v1beta1_NetworkPolicyReference is an autogenerated conversion function.
+func Convert_networking_NetworkPolicyReference_To_v1beta1_NetworkPolicyReference(in *networking.NetworkPolicyReference, out *NetworkPolicyReference, s conversion.Scope) error {
+	return autoConvert_networking_NetworkPolicyReference_To_v1beta1_NetworkPolicyReference(in, out, s)
+}
+
 func autoConvert_v1beta1_NetworkPolicyRule_To_networking_NetworkPolicyRule(in *NetworkPolicyRule, out *networking.NetworkPolicyRule, s conversion.Scope) error {
 	out.Direction = networking.Direction(in.Direction)
 	if err := Convert_v1beta1_NetworkPolicyPeer_To_networking_NetworkPolicyPeer(&in.From, &out.From, s); err != nil {
@@ -506,6 +912,10 @@ func autoConvert_v1beta1_NetworkPolicyRule_To_networking_NetworkPolicyRule(in *N
 		return err
 	}
 	out.Services = *(*[]networking.Service)(unsafe.Pointer(&in.Services))
+	out.Priority = in.Priority
+	out.Action = (*v1alpha1.RuleAction)(unsafe.Pointer(in.Action))
+	out.EnableLogging = in.EnableLogging
+	out.Name = in.Name
 	return nil
 }
 
@@ -523,6 +933,10 @@ func autoConvert_networking_NetworkPolicyRule_To_v1beta1_NetworkPolicyRule(in *n
 		return err
 	}
 	out.Services = *(*[]Service)(unsafe.Pointer(&in.Services))
+	out.Priority = in.Priority
+	out.Action = (*v1alpha1.RuleAction)(unsafe.Pointer(in.Action))
+	out.EnableLogging = in.EnableLogging
+	out.Name = in.Name
 	return nil
 }
 
@@ -531,6 +945,130 @@ func Convert_networking_NetworkPolicyRule_To_v1beta1_NetworkPolicyRule(in *netwo
 	return autoConvert_networking_NetworkPolicyRule_To_v1beta1_NetworkPolicyRule(in, out, s)
 }
 
+func autoConvert_v1beta1_NetworkPolicyRuleReference_To_networking_NetworkPolicyRuleReference(in *NetworkPolicyRuleReference, out *networking.NetworkPolicyRuleReference, s conversion.Scope) error {
+	if err := Convert_v1beta1_NetworkPolicyReference_To_networking_NetworkPolicyReference(&in.Policy, &out.Policy, s); err != nil {
+		return err
+	}
+	out.Direction = networking.Direction(in.Direction)
+	out.Index = in.Index
+	out.Action = (*v1alpha1.RuleAction)(unsafe.Pointer(in.Action))
+	return nil
+}
+
+// Convert_v1beta1_NetworkPolicyRuleReference_To_networking_NetworkPolicyRuleReference is an autogenerated conversion function.
+func Convert_v1beta1_NetworkPolicyRuleReference_To_networking_NetworkPolicyRuleReference(in *NetworkPolicyRuleReference, out *networking.NetworkPolicyRuleReference, s conversion.Scope) error {
+	return autoConvert_v1beta1_NetworkPolicyRuleReference_To_networking_NetworkPolicyRuleReference(in, out, s)
+}
+
+func autoConvert_networking_NetworkPolicyRuleReference_To_v1beta1_NetworkPolicyRuleReference(in *networking.NetworkPolicyRuleReference, out *NetworkPolicyRuleReference, s conversion.Scope) error {
+	if err := Convert_networking_NetworkPolicyReference_To_v1beta1_NetworkPolicyReference(&in.Policy, &out.Policy, s); err != nil {
+		return err
+	}
+	out.Direction = Direction(in.Direction)
+	out.Index = in.Index
+	out.Action = (*v1alpha1.RuleAction)(unsafe.Pointer(in.Action))
+	return nil
+}
+
+// Convert_networking_NetworkPolicyRuleReference_To_v1beta1_NetworkPolicyRuleReference is an autogenerated conversion function.
+func Convert_networking_NetworkPolicyRuleReference_To_v1beta1_NetworkPolicyRuleReference(in *networking.NetworkPolicyRuleReference, out *NetworkPolicyRuleReference, s conversion.Scope) error {
+	return autoConvert_networking_NetworkPolicyRuleReference_To_v1beta1_NetworkPolicyRuleReference(in, out, s)
+}
+
+func autoConvert_v1beta1_NetworkPolicyStats_To_networking_NetworkPolicyStats(in *NetworkPolicyStats, out *networking.NetworkPolicyStats, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_TrafficStats_To_networking_TrafficStats(&in.TrafficStats, &out.TrafficStats, s); err != nil {
+		return err
+	}
+	out.RuleTrafficStats = *(*[]networking.RuleTrafficStats)(unsafe.Pointer(&in.RuleTrafficStats))
+	return nil
+}
+
+// Convert_v1beta1_NetworkPolicyStats_To_networking_NetworkPolicyStats is an autogenerated conversion function.
+func Convert_v1beta1_NetworkPolicyStats_To_networking_NetworkPolicyStats(in *NetworkPolicyStats, out *networking.NetworkPolicyStats, s conversion.Scope) error {
+	return autoConvert_v1beta1_NetworkPolicyStats_To_networking_NetworkPolicyStats(in, out, s)
+}
+
+func autoConvert_networking_NetworkPolicyStats_To_v1beta1_NetworkPolicyStats(in *networking.NetworkPolicyStats, out *NetworkPolicyStats, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_networking_TrafficStats_To_v1beta1_TrafficStats(&in.TrafficStats, &out.TrafficStats, s); err != nil {
+		return err
+	}
+	out.RuleTrafficStats = *(*[]RuleTrafficStats)(unsafe.Pointer(&in.RuleTrafficStats))
+	return nil
+}
+
+// Convert_networking_NetworkPolicyStats_To_v1beta1_NetworkPolicyStats is an autogenerated conversion function.
+func Convert_networking_NetworkPolicyStats_To_v1beta1_NetworkPolicyStats(in *networking.NetworkPolicyStats, out *NetworkPolicyStats, s conversion.Scope) error {
+	return autoConvert_networking_NetworkPolicyStats_To_v1beta1_NetworkPolicyStats(in, out, s)
+}
+
+func autoConvert_v1beta1_NetworkPolicyStatsList_To_networking_NetworkPolicyStatsList(in *NetworkPolicyStatsList, out *networking.NetworkPolicyStatsList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]networking.NetworkPolicyStats)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1beta1_NetworkPolicyStatsList_To_networking_NetworkPolicyStatsList is an autogenerated conversion function.
+func Convert_v1beta1_NetworkPolicyStatsList_To_networking_NetworkPolicyStatsList(in *NetworkPolicyStatsList, out *networking.NetworkPolicyStatsList, s conversion.Scope) error {
+	return autoConvert_v1beta1_NetworkPolicyStatsList_To_networking_NetworkPolicyStatsList(in, out, s)
+}
+
+func autoConvert_networking_NetworkPolicyStatsList_To_v1beta1_NetworkPolicyStatsList(in *networking.NetworkPolicyStatsList, out *NetworkPolicyStatsList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]NetworkPolicyStats)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_networking_NetworkPolicyStatsList_To_v1beta1_NetworkPolicyStatsList is an autogenerated conversion function.
+func Convert_networking_NetworkPolicyStatsList_To_v1beta1_NetworkPolicyStatsList(in *networking.NetworkPolicyStatsList, out *NetworkPolicyStatsList, s conversion.Scope) error {
+	return autoConvert_networking_NetworkPolicyStatsList_To_v1beta1_NetworkPolicyStatsList(in, out, s)
+}
+
+func autoConvert_v1beta1_NetworkPolicyStatus_To_networking_NetworkPolicyStatus(in *NetworkPolicyStatus, out *networking.NetworkPolicyStatus, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.Nodes = *(*[]networking.NetworkPolicyNodeStatus)(unsafe.Pointer(&in.Nodes))
+	return nil
+}
+
+// Convert_v1beta1_NetworkPolicyStatus_To_networking_NetworkPolicyStatus is an autogenerated conversion function.
+func Convert_v1beta1_NetworkPolicyStatus_To_networking_NetworkPolicyStatus(in *NetworkPolicyStatus, out *networking.NetworkPolicyStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_NetworkPolicyStatus_To_networking_NetworkPolicyStatus(in, out, s)
+}
+
+func autoConvert_networking_NetworkPolicyStatus_To_v1beta1_NetworkPolicyStatus(in *networking.NetworkPolicyStatus, out *NetworkPolicyStatus, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.Nodes = *(*[]NetworkPolicyNodeStatus)(unsafe.Pointer(&in.Nodes))
+	return nil
+}
+
+// Convert_networking_NetworkPolicyStatus_To_v1beta1_NetworkPolicyStatus is an autogenerated conversion function.
+func Convert_networking_NetworkPolicyStatus_To_v1beta1_NetworkPolicyStatus(in *networking.NetworkPolicyStatus, out *NetworkPolicyStatus, s conversion.Scope) error {
+	return autoConvert_networking_NetworkPolicyStatus_To_v1beta1_NetworkPolicyStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_NodeStatsSummary_To_networking_NodeStatsSummary(in *NodeStatsSummary, out *networking.NodeStatsSummary, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.NetworkPolicies = *(*[]networking.NetworkPolicyStats)(unsafe.Pointer(&in.NetworkPolicies))
+	return nil
+}
+
+// Convert_v1beta1_NodeStatsSummary_To_networking_NodeStatsSummary is an autogenerated conversion function.
+func Convert_v1beta1_NodeStatsSummary_To_networking_NodeStatsSummary(in *NodeStatsSummary, out *networking.NodeStatsSummary, s conversion.Scope) error {
+	return autoConvert_v1beta1_NodeStatsSummary_To_networking_NodeStatsSummary(in, out, s)
+}
+
+func autoConvert_networking_NodeStatsSummary_To_v1beta1_NodeStatsSummary(in *networking.NodeStatsSummary, out *NodeStatsSummary, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.NetworkPolicies = *(*[]NetworkPolicyStats)(unsafe.Pointer(&in.NetworkPolicies))
+	return nil
+}
+
+// Convert_networking_NodeStatsSummary_To_v1beta1_NodeStatsSummary is an autogenerated conversion function.
+func Convert_networking_NodeStatsSummary_To_v1beta1_NodeStatsSummary(in *networking.NodeStatsSummary, out *NodeStatsSummary, s conversion.Scope) error {
+	return autoConvert_networking_NodeStatsSummary_To_v1beta1_NodeStatsSummary(in, out, s)
+}
+
 func autoConvert_v1beta1_PodReference_To_networking_PodReference(in *PodReference, out *networking.PodReference, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Namespace = in.Namespace
@@ -553,9 +1091,38 @@ func Convert_networking_PodReference_To_v1beta1_PodReference(in *networking.PodR
 	return autoConvert_networking_PodReference_To_v1beta1_PodReference(in, out, s)
 }
 
+func autoConvert_v1beta1_RuleTrafficStats_To_networking_RuleTrafficStats(in *RuleTrafficStats, out *networking.RuleTrafficStats, s conversion.Scope) error {
+	out.Name = in.Name
+	if err := Convert_v1beta1_TrafficStats_To_networking_TrafficStats(&in.TrafficStats, &out.TrafficStats, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_RuleTrafficStats_To_networking_RuleTrafficStats is an autogenerated conversion function.
+func Convert_v1beta1_RuleTrafficStats_To_networking_RuleTrafficStats(in *RuleTrafficStats, out *networking.RuleTrafficStats, s conversion.Scope) error {
+	return autoConvert_v1beta1_RuleTrafficStats_To_networking_RuleTrafficStats(in, out, s)
+}
+
+func autoConvert_networking_RuleTrafficStats_To_v1beta1_RuleTrafficStats(in *networking.RuleTrafficStats, out *RuleTrafficStats, s conversion.Scope) error {
+	out.Name = in.Name
+	if err := Convert_networking_TrafficStats_To_v1beta1_TrafficStats(&in.TrafficStats, &out.TrafficStats, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_networking_RuleTrafficStats_To_v1beta1_RuleTrafficStats is an autogenerated conversion function.
+func Convert_networking_RuleTrafficStats_To_v1beta1_RuleTrafficStats(in *networking.RuleTrafficStats, out *RuleTrafficStats, s conversion.Scope) error {
+	return autoConvert_networking_RuleTrafficStats_To_v1beta1_RuleTrafficStats(in, out, s)
+}
+
 func autoConvert_v1beta1_Service_To_networking_Service(in *Service, out *networking.Service, s conversion.Scope) error {
 	out.Protocol = (*networking.Protocol)(unsafe.Pointer(in.Protocol))
 	out.Port = (*intstr.IntOrString)(unsafe.Pointer(in.Port))
+	out.EndPort = (*int32)(unsafe.Pointer(in.EndPort))
+	out.ICMPType = (*int32)(unsafe.Pointer(in.ICMPType))
+	out.ICMPCode = (*int32)(unsafe.Pointer(in.ICMPCode))
 	return nil
 }
 
@@ -567,6 +1134,9 @@ func Convert_v1beta1_Service_To_networking_Service(in *Service, out *networking.
 func autoConvert_networking_Service_To_v1beta1_Service(in *networking.Service, out *Service, s conversion.Scope) error {
 	out.Protocol = (*Protocol)(unsafe.Pointer(in.Protocol))
 	out.Port = (*intstr.IntOrString)(unsafe.Pointer(in.Port))
+	out.EndPort = (*int32)(unsafe.Pointer(in.EndPort))
+	out.ICMPType = (*int32)(unsafe.Pointer(in.ICMPType))
+	out.ICMPCode = (*int32)(unsafe.Pointer(in.ICMPCode))
 	return nil
 }
 
@@ -574,3 +1144,27 @@ func autoConvert_networking_Service_To_v1beta1_Service(in *networking.Service, o
 func Convert_networking_Service_To_v1beta1_Service(in *networking.Service, out *Service, s conversion.Scope) error {
 	return autoConvert_networking_Service_To_v1beta1_Service(in, out, s)
 }
+
+func autoConvert_v1beta1_TrafficStats_To_networking_TrafficStats(in *TrafficStats, out *networking.TrafficStats, s conversion.Scope) error {
+	out.Packets = in.Packets
+	out.Bytes = in.Bytes
+	out.Sessions = in.Sessions
+	return nil
+}
+
+// Convert_v1beta1_TrafficStats_To_networking_TrafficStats is an autogenerated conversion function.
+func Convert_v1beta1_TrafficStats_To_networking_TrafficStats(in *TrafficStats, out *networking.TrafficStats, s conversion.Scope) error {
+	return autoConvert_v1beta1_TrafficStats_To_networking_TrafficStats(in, out, s)
+}
+
+func autoConvert_networking_TrafficStats_To_v1beta1_TrafficStats(in *networking.TrafficStats, out *TrafficStats, s conversion.Scope) error {
+	out.Packets = in.Packets
+	out.Bytes = in.Bytes
+	out.Sessions = in.Sessions
+	return nil
+}
+
+// Convert_networking_TrafficStats_To_v1beta1_TrafficStats is an autogenerated conversion function.
+func Convert_networking_TrafficStats_To_v1beta1_TrafficStats(in *networking.TrafficStats, out *TrafficStats, s conversion.Scope) error {
+	return autoConvert_networking_TrafficStats_To_v1beta1_TrafficStats(in, out, s)
+}
@@ -19,6 +19,7 @@
 package networking
 
 import (
+	v1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -235,6 +236,158 @@ func (in *AppliedToGroupPatch) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectivityQuery) DeepCopyInto(out *ConnectivityQuery) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivityQuery.
+func (in *ConnectivityQuery) DeepCopy() *ConnectivityQuery {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectivityQuery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConnectivityQuery) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectivityQueryPeer) DeepCopyInto(out *ConnectivityQueryPeer) {
+	*out = *in
+	if in.Pod != nil {
+		in, out := &in.Pod, &out.Pod
+		*out = new(PodReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivityQueryPeer.
+func (in *ConnectivityQueryPeer) DeepCopy() *ConnectivityQueryPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectivityQueryPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectivityQuerySpec) DeepCopyInto(out *ConnectivityQuerySpec) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	in.Destination.DeepCopyInto(&out.Destination)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivityQuerySpec.
+func (in *ConnectivityQuerySpec) DeepCopy() *ConnectivityQuerySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectivityQuerySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectivityQueryStatus) DeepCopyInto(out *ConnectivityQueryStatus) {
+	*out = *in
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = new(ConnectivityQueryVerdict)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(ConnectivityQueryVerdict)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivityQueryStatus.
+func (in *ConnectivityQueryStatus) DeepCopy() *ConnectivityQueryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectivityQueryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectivityQueryVerdict) DeepCopyInto(out *ConnectivityQueryVerdict) {
+	*out = *in
+	if in.Rule != nil {
+		in, out := &in.Rule, &out.Rule
+		*out = new(NetworkPolicyRuleReference)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivityQueryVerdict.
+func (in *ConnectivityQueryVerdict) DeepCopy() *ConnectivityQueryVerdict {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectivityQueryVerdict)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointQuery) DeepCopyInto(out *EndpointQuery) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.AppliedPolicies != nil {
+		in, out := &in.AppliedPolicies, &out.AppliedPolicies
+		*out = make([]NetworkPolicyReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.PeerRules != nil {
+		in, out := &in.PeerRules, &out.PeerRules
+		*out = make([]NetworkPolicyRuleReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointQuery.
+func (in *EndpointQuery) DeepCopy() *EndpointQuery {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointQuery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EndpointQuery) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GroupMemberPod) DeepCopyInto(out *GroupMemberPod) {
 	*out = *in
@@ -253,6 +406,17 @@ func (in *GroupMemberPod) DeepCopyInto(out *GroupMemberPod) {
 		*out = make([]NamedPort, len(*in))
 		copy(*out, *in)
 	}
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]IPAddress, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make(IPAddress, len(*in))
+				copy(*out, *in)
+			}
+		}
+	}
 	return
 }
 
@@ -394,6 +558,21 @@ func (in *NetworkPolicy) DeepCopyInto(out *NetworkPolicy) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(float64)
+		**out = **in
+	}
+	if in.TierPriority != nil {
+		in, out := &in.TierPriority, &out.TierPriority
+		*out = new(TierPriority)
+		**out = **in
+	}
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(NetworkPolicyRealizationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -448,6 +627,22 @@ func (in *NetworkPolicyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyNodeStatus) DeepCopyInto(out *NetworkPolicyNodeStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyNodeStatus.
+func (in *NetworkPolicyNodeStatus) DeepCopy() *NetworkPolicyNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkPolicyPeer) DeepCopyInto(out *NetworkPolicyPeer) {
 	*out = *in
@@ -463,6 +658,11 @@ func (in *NetworkPolicyPeer) DeepCopyInto(out *NetworkPolicyPeer) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FQDNs != nil {
+		in, out := &in.FQDNs, &out.FQDNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -476,6 +676,43 @@ func (in *NetworkPolicyPeer) DeepCopy() *NetworkPolicyPeer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyRealizationStatus) DeepCopyInto(out *NetworkPolicyRealizationStatus) {
+	*out = *in
+	if in.FailedNodes != nil {
+		in, out := &in.FailedNodes, &out.FailedNodes
+		*out = make([]NetworkPolicyNodeStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyRealizationStatus.
+func (in *NetworkPolicyRealizationStatus) DeepCopy() *NetworkPolicyRealizationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyRealizationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyReference) DeepCopyInto(out *NetworkPolicyReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyReference.
+func (in *NetworkPolicyReference) DeepCopy() *NetworkPolicyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkPolicyRule) DeepCopyInto(out *NetworkPolicyRule) {
 	*out = *in
@@ -488,6 +725,11 @@ func (in *NetworkPolicyRule) DeepCopyInto(out *NetworkPolicyRule) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Action != nil {
+		in, out := &in.Action, &out.Action
+		*out = new(v1alpha1.RuleAction)
+		**out = **in
+	}
 	return
 }
 
@@ -501,6 +743,157 @@ func (in *NetworkPolicyRule) DeepCopy() *NetworkPolicyRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyRuleReference) DeepCopyInto(out *NetworkPolicyRuleReference) {
+	*out = *in
+	out.Policy = in.Policy
+	if in.Action != nil {
+		in, out := &in.Action, &out.Action
+		*out = new(v1alpha1.RuleAction)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyRuleReference.
+func (in *NetworkPolicyRuleReference) DeepCopy() *NetworkPolicyRuleReference {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyRuleReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyStats) DeepCopyInto(out *NetworkPolicyStats) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.TrafficStats = in.TrafficStats
+	if in.RuleTrafficStats != nil {
+		in, out := &in.RuleTrafficStats, &out.RuleTrafficStats
+		*out = make([]RuleTrafficStats, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyStats.
+func (in *NetworkPolicyStats) DeepCopy() *NetworkPolicyStats {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkPolicyStats) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyStatsList) DeepCopyInto(out *NetworkPolicyStatsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NetworkPolicyStats, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyStatsList.
+func (in *NetworkPolicyStatsList) DeepCopy() *NetworkPolicyStatsList {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyStatsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkPolicyStatsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyStatus) DeepCopyInto(out *NetworkPolicyStatus) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NetworkPolicyNodeStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyStatus.
+func (in *NetworkPolicyStatus) DeepCopy() *NetworkPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkPolicyStatus) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeStatsSummary) DeepCopyInto(out *NodeStatsSummary) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.NetworkPolicies != nil {
+		in, out := &in.NetworkPolicies, &out.NetworkPolicies
+		*out = make([]NetworkPolicyStats, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStatsSummary.
+func (in *NodeStatsSummary) DeepCopy() *NodeStatsSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeStatsSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeStatsSummary) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodReference) DeepCopyInto(out *PodReference) {
 	*out = *in
@@ -517,6 +910,23 @@ func (in *PodReference) DeepCopy() *PodReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleTrafficStats) DeepCopyInto(out *RuleTrafficStats) {
+	*out = *in
+	out.TrafficStats = in.TrafficStats
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleTrafficStats.
+func (in *RuleTrafficStats) DeepCopy() *RuleTrafficStats {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleTrafficStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Service) DeepCopyInto(out *Service) {
 	*out = *in
@@ -530,6 +940,21 @@ func (in *Service) DeepCopyInto(out *Service) {
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.EndPort != nil {
+		in, out := &in.EndPort, &out.EndPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ICMPType != nil {
+		in, out := &in.ICMPType, &out.ICMPType
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ICMPCode != nil {
+		in, out := &in.ICMPCode, &out.ICMPCode
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -542,3 +967,19 @@ func (in *Service) DeepCopy() *Service {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficStats) DeepCopyInto(out *TrafficStats) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficStats.
+func (in *TrafficStats) DeepCopy() *TrafficStats {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficStats)
+	in.DeepCopyInto(out)
+	return out
+}
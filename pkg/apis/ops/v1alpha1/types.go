@@ -0,0 +1,190 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type TraceflowPhase string
+
+const (
+	// Running means the Traceflow has been assigned a dataplane tag and the
+	// probe packet is being traced.
+	Running TraceflowPhase = "Running"
+	// Succeeded means the probe packet has been either delivered or dropped.
+	Succeeded TraceflowPhase = "Succeeded"
+	// Failed means the Traceflow cannot be completed, e.g. because of a
+	// timeout or an invalid specification.
+	Failed TraceflowPhase = "Failed"
+)
+
+type TraceflowComponent string
+
+const (
+	SpoofGuard    TraceflowComponent = "SpoofGuard"
+	NetworkPolicy TraceflowComponent = "NetworkPolicy"
+	Forwarding    TraceflowComponent = "Forwarding"
+)
+
+type TraceflowAction string
+
+const (
+	Delivered TraceflowAction = "Delivered"
+	Received  TraceflowAction = "Received"
+	Forwarded TraceflowAction = "Forwarded"
+	Dropped   TraceflowAction = "Dropped"
+)
+
+type TraceflowNodeRole string
+
+const (
+	// RoleSender is the role of the Node where the source Pod runs.
+	RoleSender TraceflowNodeRole = "Sender"
+	// RoleReceiver is the role of any other Node the probe packet crosses.
+	RoleReceiver TraceflowNodeRole = "Receiver"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Traceflow traces a probe packet injected from a source Pod through the
+// datapath of every Node it crosses.
+type Traceflow struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TraceflowSpec   `json:"spec,omitempty"`
+	Status TraceflowStatus `json:"status,omitempty"`
+}
+
+// TraceflowSpec describes the probe packet to inject.
+type TraceflowSpec struct {
+	Source      Source      `json:"source,omitempty"`
+	Destination Destination `json:"destination,omitempty"`
+	Packet      Packet      `json:"packet,omitempty"`
+}
+
+// Source describes the Pod from which the probe packet is injected.
+type Source struct {
+	Namespace string `json:"namespace,omitempty"`
+	Pod       string `json:"pod,omitempty"`
+}
+
+// Destination describes the destination of the probe packet. Either a Pod or
+// an IP must be provided.
+type Destination struct {
+	Namespace string `json:"namespace,omitempty"`
+	Pod       string `json:"pod,omitempty"`
+	IP        string `json:"ip,omitempty"`
+}
+
+// IPHeader describes the IPv4 header fields of the probe packet.
+type IPHeader struct {
+	// Protocol is the IP protocol number, 1 (ICMP) by default.
+	Protocol int32 `json:"protocol,omitempty"`
+	// TTL is the time to live, 64 by default.
+	TTL   int32 `json:"ttl,omitempty"`
+	Flags int32 `json:"flags,omitempty"`
+}
+
+// TransportHeader describes the transport header of the probe packet. At
+// most one of its fields can be set, and it must match IPHeader.Protocol.
+type TransportHeader struct {
+	ICMP *ICMPEchoRequestHeader `json:"icmp,omitempty"`
+	UDP  *UDPHeader             `json:"udp,omitempty"`
+	TCP  *TCPHeader             `json:"tcp,omitempty"`
+}
+
+// ICMPEchoRequestHeader describes the ICMP echo request header.
+type ICMPEchoRequestHeader struct {
+	ID       int32 `json:"id,omitempty"`
+	Sequence int32 `json:"sequence,omitempty"`
+}
+
+// UDPHeader describes the UDP header.
+type UDPHeader struct {
+	SrcPort int32 `json:"srcPort,omitempty"`
+	DstPort int32 `json:"dstPort,omitempty"`
+}
+
+// TCPHeader describes the TCP header.
+type TCPHeader struct {
+	SrcPort int32 `json:"srcPort,omitempty"`
+	DstPort int32 `json:"dstPort,omitempty"`
+	Flags   int32 `json:"flags,omitempty"`
+}
+
+// Packet describes the header fields of the probe packet.
+type Packet struct {
+	IPHeader        IPHeader        `json:"ipHeader,omitempty"`
+	TransportHeader TransportHeader `json:"transportHeader,omitempty"`
+}
+
+// TraceflowStatus describes the current state of the Traceflow and the
+// observations collected from the Nodes.
+type TraceflowStatus struct {
+	Phase TraceflowPhase `json:"phase,omitempty"`
+	// Reason explains why the Traceflow failed.
+	Reason string `json:"reason,omitempty"`
+	// DataplaneTag is the tag carried by the probe packet to identify it in
+	// the datapath. It is assigned by antrea-controller.
+	DataplaneTag uint8 `json:"dataplaneTag,omitempty"`
+	// Results are the observations reported by each Node, the Sender Node
+	// first.
+	Results []NodeResult `json:"results,omitempty"`
+}
+
+// NodeResult is the list of observations reported by a Node.
+type NodeResult struct {
+	Node string            `json:"node,omitempty"`
+	Role TraceflowNodeRole `json:"role,omitempty"`
+	// Timestamp is the Unix time at which the observations were made.
+	Timestamp    int64         `json:"timestamp,omitempty"`
+	Observations []Observation `json:"observations"`
+}
+
+// Observation describes what happened to the probe packet in one component
+// of the datapath.
+type Observation struct {
+	Component TraceflowComponent `json:"component,omitempty"`
+	// ComponentInfo gives details about the component, e.g. the name of the
+	// Openflow table.
+	ComponentInfo string          `json:"componentInfo,omitempty"`
+	Action        TraceflowAction `json:"action,omitempty"`
+	// Pod is the name of the destination Pod if the packet is delivered
+	// locally.
+	Pod string `json:"pod,omitempty"`
+	// DstMAC is the destination MAC set by the forwarding component.
+	DstMAC string `json:"dstMAC,omitempty"`
+	// NetworkPolicy is the namespaced name of the NetworkPolicy which
+	// allowed or dropped the packet.
+	NetworkPolicy string `json:"networkPolicy,omitempty"`
+	// TTL is the time to live of the packet when leaving the component.
+	TTL int32 `json:"ttl,omitempty"`
+	// TunnelDstIP is the IP of the Node the packet is tunneled to.
+	TunnelDstIP string `json:"tunnelDstIP,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TraceflowList is a list of Traceflow objects.
+type TraceflowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Traceflow `json:"items"`
+}
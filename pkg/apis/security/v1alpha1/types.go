@@ -0,0 +1,234 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterNetworkPolicy is a cluster-scoped policy which lets cluster admins
+// define security guardrails that are enforced before any K8s NetworkPolicy.
+type ClusterNetworkPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard metadata of the object.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired behavior of ClusterNetworkPolicy.
+	Spec ClusterNetworkPolicySpec `json:"spec"`
+}
+
+// ClusterNetworkPolicySpec defines the desired state for ClusterNetworkPolicy.
+type ClusterNetworkPolicySpec struct {
+	// Tier specifies the tier to which this ClusterNetworkPolicy belongs.
+	// Policies in a tier with higher precedence are always enforced before
+	// policies in a lower tier, regardless of their Priority. If not set, the
+	// policy is created in the Application tier.
+	// +optional
+	Tier string `json:"tier,omitempty"`
+	// Priority specifies the order of the ClusterNetworkPolicy relative to
+	// other ClusterNetworkPolicies in the same tier. A lower value means a
+	// higher precedence.
+	Priority float64 `json:"priority"`
+	// Select workloads on which the rules will be applied to.
+	AppliedTo []NetworkPolicyPeer `json:"appliedTo"`
+	// Set of ingress rules evaluated based on the order in which they are set.
+	// Currently Ingress rule supports setting the `From` field but not the `To`
+	// field within a Rule.
+	// +optional
+	Ingress []Rule `json:"ingress"`
+	// Set of egress rules evaluated based on the order in which they are set.
+	// Currently Egress rule supports setting the `To` field but not the `From`
+	// field within a Rule.
+	// +optional
+	Egress []Rule `json:"egress"`
+}
+
+// Rule describes the traffic allowed to/from the workloads selected by
+// Spec.AppliedTo. Based on the action specified in the rule, traffic is either
+// allowed or denied which exactly match the specified ports and protocol.
+type Rule struct {
+	// Action specifies the action to be applied on the rule.
+	Action *RuleAction `json:"action"`
+	// EnableLogging is used to indicate if the connections matching the rule
+	// must be logged by the agents in the NetworkPolicy audit log.
+	// +optional
+	EnableLogging bool `json:"enableLogging,omitempty"`
+	// Set of port and protocol allowed/denied by the rule. If this field is unset
+	// or empty, this rule matches all ports.
+	// +optional
+	Ports []NetworkPolicyPort `json:"ports,omitempty"`
+	// Rule is matched if traffic originates from workloads selected by
+	// this field. If this field is empty, this rule matches all sources.
+	// +optional
+	From []NetworkPolicyPeer `json:"from,omitempty"`
+	// Rule is matched if traffic is intended for workloads selected by
+	// this field. If this field is empty or missing, this rule matches all
+	// destinations.
+	// +optional
+	To []NetworkPolicyPeer `json:"to,omitempty"`
+}
+
+// NetworkPolicyPeer describes the grouping selector of workloads.
+type NetworkPolicyPeer struct {
+	// IPBlock describes the IPAddresses/IPBlocks that is matched in to/from.
+	// IPBlock cannot be set as part of the AppliedTo field.
+	// Cannot be set with any other selector.
+	// +optional
+	IPBlock *IPBlock `json:"ipBlock,omitempty"`
+	// Select Pods from all Namespaces as workloads in AppliedTo/To/From
+	// fields. If set with NamespaceSelector, Pods are matched from
+	// Namespaces matched by the NamespaceSelector.
+	// Cannot be set with any other selector except NamespaceSelector.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+	// Select all Pods from Namespaces matched by this selector, as
+	// workloads in AppliedTo/To/From fields. If set with PodSelector,
+	// Pods are matched from Namespaces matched by the NamespaceSelector.
+	// Cannot be set with any other selector except PodSelector.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// FQDN selects the destinations whose addresses are resolved from this
+	// domain name, e.g. "www.example.com". A leading "*." matches any
+	// subdomain, e.g. "*.example.com". The addresses are learnt by the agent
+	// from the DNS responses sent to the Pods the rule applies to.
+	// FQDN can only be set in the To field of egress rules.
+	// Cannot be set with any other selector.
+	// +optional
+	FQDN string `json:"fqdn,omitempty"`
+}
+
+// IPBlock describes a particular CIDR (Ex. "192.168.1.1/24") that is allowed
+// or denied to/from the workloads matched by a Spec.AppliedTo.
+type IPBlock struct {
+	// CIDR is a string representing the IP Block
+	// Valid examples are "192.168.1.1/24".
+	CIDR string `json:"cidr"`
+}
+
+// NetworkPolicyPort describes the port and protocol to match in a rule.
+// A rule with a port violating the constraints of its fields is ignored.
+type NetworkPolicyPort struct {
+	// The protocol (TCP, UDP, SCTP, or ICMP) which traffic must match.
+	// If not specified, this field defaults to TCP.
+	// +optional
+	Protocol *v1.Protocol `json:"protocol,omitempty"`
+	// The port on the given protocol. This can be either a numerical
+	// or named port on a Pod. If this field is not provided, this
+	// matches all port names and numbers.
+	// +optional
+	Port *intstr.IntOrString `json:"port,omitempty"`
+	// EndPort defines the end of the port range, both ends inclusive.
+	// It can only be set when Port is a numerical port, and must be
+	// greater than or equal to Port.
+	// +optional
+	EndPort *int32 `json:"endPort,omitempty"`
+	// The ICMP type to match, only valid when Protocol is ICMP. If this
+	// field is not provided, this matches all ICMP types.
+	// +optional
+	ICMPType *int32 `json:"icmpType,omitempty"`
+	// The ICMP code to match, only valid when Protocol is ICMP and ICMPType
+	// is provided. If this field is not provided, this matches all ICMP
+	// codes.
+	// +optional
+	ICMPCode *int32 `json:"icmpCode,omitempty"`
+}
+
+// RuleAction describes the action to be applied on traffic matching a rule.
+type RuleAction string
+
+const (
+	// RuleActionAllow describes that the traffic matching the rule must be allowed.
+	RuleActionAllow RuleAction = "Allow"
+	// RuleActionDrop describes that the traffic matching the rule must be dropped.
+	RuleActionDrop RuleAction = "Drop"
+	// RuleActionReject describes that the traffic matching the rule must be
+	// rejected, i.e. dropped with a response sent back to the originator.
+	RuleActionReject RuleAction = "Reject"
+)
+
+// Tiers which ClusterNetworkPolicies can be created in, listed from the
+// highest precedence to the lowest.
+const (
+	TierEmergency   = "Emergency"
+	TierSecurityOps = "SecurityOps"
+	TierNetworkOps  = "NetworkOps"
+	TierPlatform    = "Platform"
+	TierApplication = "Application"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterNetworkPolicyList is a list of ClusterNetworkPolicy objects.
+type ClusterNetworkPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterNetworkPolicy `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Egress assigns a fixed egress IP, hosted on a single Node, to the traffic
+// sent by the selected Pods to destinations outside of the cluster.
+type Egress struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard metadata of the object.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired behavior of Egress.
+	Spec EgressSpec `json:"spec"`
+	// Most recently observed status of the Egress.
+	Status EgressStatus `json:"status,omitempty"`
+}
+
+// EgressSpec defines the desired state for Egress.
+type EgressSpec struct {
+	// AppliedTo selects the Pods whose egress traffic will be SNATed to
+	// EgressIP.
+	AppliedTo NetworkPolicyPeer `json:"appliedTo"`
+	// EgressIP is the IPv4 address used as the source address of the egress
+	// traffic of the selected Pods. It must be routable in the Node network,
+	// and must not be assigned to any other host.
+	EgressIP string `json:"egressIP"`
+	// NodeName is the name of the Node which should preferably host
+	// EgressIP. If it is not set, or if the Node is not ready, the Antrea
+	// Agents pick one of the ready Nodes to host EgressIP.
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+}
+
+// EgressStatus describes the current state of the Egress.
+type EgressStatus struct {
+	// NodeName is the name of the Node which currently hosts EgressIP.
+	NodeName string `json:"nodeName,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EgressList is a list of Egress objects.
+type EgressList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Egress `json:"items"`
+}
@@ -31,10 +31,16 @@ import (
 	system "github.com/vmware-tanzu/antrea/pkg/apis/system/v1beta1"
 	"github.com/vmware-tanzu/antrea/pkg/apiserver/registry/networkpolicy/addressgroup"
 	"github.com/vmware-tanzu/antrea/pkg/apiserver/registry/networkpolicy/appliedtogroup"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/registry/networkpolicy/connectivityquery"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/registry/networkpolicy/endpointquery"
 	"github.com/vmware-tanzu/antrea/pkg/apiserver/registry/networkpolicy/networkpolicy"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/registry/networkpolicy/networkpolicystats"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/registry/networkpolicy/networkpolicystatus"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/registry/networkpolicy/nodestatssummary"
 	"github.com/vmware-tanzu/antrea/pkg/apiserver/registry/system/controllerinfo"
 	"github.com/vmware-tanzu/antrea/pkg/apiserver/registry/system/supportbundle"
 	"github.com/vmware-tanzu/antrea/pkg/apiserver/storage"
+	controllernetworkpolicy "github.com/vmware-tanzu/antrea/pkg/controller/networkpolicy"
 	"github.com/vmware-tanzu/antrea/pkg/controller/querier"
 )
 
@@ -59,8 +65,12 @@ type ExtraConfig struct {
 	addressGroupStore   storage.Interface
 	appliedToGroupStore storage.Interface
 	networkPolicyStore  storage.Interface
-	controllerQuerier   querier.ControllerQuerier
-	caCertController    *certificate.CACertController
+	statusAggregator    *controllernetworkpolicy.StatusAggregator
+	// statsAggregator is nil if the NetworkPolicyStats feature is disabled.
+	statsAggregator   *controllernetworkpolicy.StatsAggregator
+	policyAnalyzer    *controllernetworkpolicy.PolicyAnalyzer
+	controllerQuerier querier.ControllerQuerier
+	caCertController  *certificate.CACertController
 }
 
 // Config defines the config for Antrea apiserver.
@@ -76,13 +86,17 @@ type APIServer struct {
 }
 
 func (s *APIServer) Run(stopCh <-chan struct{}) error {
-	// Make sure CACertController runs once to publish the CA cert before starting APIServer.
+	return s.GenericAPIServer.PrepareRun().Run(stopCh)
+}
+
+// RunCACertController publishes the CA cert of the APIServer and keeps it up to date until stopCh is closed.
+// Only the leader replica of antrea-controller, which serves the antrea Service, should publish its CA cert.
+func (s *APIServer) RunCACertController(stopCh <-chan struct{}) {
+	// Make sure CACertController runs once to publish the CA cert before the APIServer receives requests.
 	if err := s.caCertController.RunOnce(); err != nil {
 		klog.Warningf("caCertController RunOnce failed: %v", err)
 	}
 	go s.caCertController.Run(1, stopCh)
-
-	return s.GenericAPIServer.PrepareRun().Run(stopCh)
 }
 
 type completedConfig struct {
@@ -93,6 +107,9 @@ type completedConfig struct {
 func NewConfig(
 	genericConfig *genericapiserver.Config,
 	addressGroupStore, appliedToGroupStore, networkPolicyStore storage.Interface,
+	statusAggregator *controllernetworkpolicy.StatusAggregator,
+	statsAggregator *controllernetworkpolicy.StatsAggregator,
+	policyAnalyzer *controllernetworkpolicy.PolicyAnalyzer,
 	caCertController *certificate.CACertController,
 	controllerQuerier querier.ControllerQuerier) *Config {
 	return &Config{
@@ -101,6 +118,9 @@ func NewConfig(
 			addressGroupStore:   addressGroupStore,
 			appliedToGroupStore: appliedToGroupStore,
 			networkPolicyStore:  networkPolicyStore,
+			statusAggregator:    statusAggregator,
+			statsAggregator:     statsAggregator,
+			policyAnalyzer:      policyAnalyzer,
 			caCertController:    caCertController,
 			controllerQuerier:   controllerQuerier,
 		},
@@ -126,7 +146,14 @@ func (c completedConfig) New() (*APIServer, error) {
 	networkingStorage := map[string]rest.Storage{}
 	networkingStorage["addressgroups"] = addressgroup.NewREST(c.extraConfig.addressGroupStore)
 	networkingStorage["appliedtogroups"] = appliedtogroup.NewREST(c.extraConfig.appliedToGroupStore)
-	networkingStorage["networkpolicies"] = networkpolicy.NewREST(c.extraConfig.networkPolicyStore)
+	networkingStorage["networkpolicies"] = networkpolicy.NewREST(c.extraConfig.networkPolicyStore, c.extraConfig.statusAggregator)
+	networkingStorage["networkpolicystatuses"] = networkpolicystatus.NewREST(c.extraConfig.statusAggregator)
+	networkingStorage["endpointqueries"] = endpointquery.NewREST(c.extraConfig.policyAnalyzer)
+	networkingStorage["connectivityqueries"] = connectivityquery.NewREST(c.extraConfig.policyAnalyzer)
+	if c.extraConfig.statsAggregator != nil {
+		networkingStorage["networkpolicystats"] = networkpolicystats.NewREST(c.extraConfig.statsAggregator)
+		networkingStorage["nodestatssummaries"] = nodestatssummary.NewREST(c.extraConfig.statsAggregator)
+	}
 	networkingGroup.VersionedResourcesStorageMap["v1beta1"] = networkingStorage
 
 	systemGroup := genericapiserver.NewDefaultAPIGroupInfo(system.GroupName, Scheme, metav1.ParameterCodec, Codecs)
@@ -43,16 +43,32 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.AppliedToGroup":                      schema_pkg_apis_networking_v1beta1_AppliedToGroup(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.AppliedToGroupList":                  schema_pkg_apis_networking_v1beta1_AppliedToGroupList(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.AppliedToGroupPatch":                 schema_pkg_apis_networking_v1beta1_AppliedToGroupPatch(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.ConnectivityQuery":                   schema_pkg_apis_networking_v1beta1_ConnectivityQuery(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.ConnectivityQueryPeer":               schema_pkg_apis_networking_v1beta1_ConnectivityQueryPeer(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.ConnectivityQuerySpec":               schema_pkg_apis_networking_v1beta1_ConnectivityQuerySpec(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.ConnectivityQueryStatus":             schema_pkg_apis_networking_v1beta1_ConnectivityQueryStatus(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.ConnectivityQueryVerdict":            schema_pkg_apis_networking_v1beta1_ConnectivityQueryVerdict(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.EndpointQuery":                       schema_pkg_apis_networking_v1beta1_EndpointQuery(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.GroupMemberPod":                      schema_pkg_apis_networking_v1beta1_GroupMemberPod(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.IPBlock":                             schema_pkg_apis_networking_v1beta1_IPBlock(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.IPNet":                               schema_pkg_apis_networking_v1beta1_IPNet(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NamedPort":                           schema_pkg_apis_networking_v1beta1_NamedPort(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicy":                       schema_pkg_apis_networking_v1beta1_NetworkPolicy(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyList":                   schema_pkg_apis_networking_v1beta1_NetworkPolicyList(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyNodeStatus":             schema_pkg_apis_networking_v1beta1_NetworkPolicyNodeStatus(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyPeer":                   schema_pkg_apis_networking_v1beta1_NetworkPolicyPeer(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyRealizationStatus":      schema_pkg_apis_networking_v1beta1_NetworkPolicyRealizationStatus(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyReference":              schema_pkg_apis_networking_v1beta1_NetworkPolicyReference(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyRule":                   schema_pkg_apis_networking_v1beta1_NetworkPolicyRule(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyRuleReference":          schema_pkg_apis_networking_v1beta1_NetworkPolicyRuleReference(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyStats":                  schema_pkg_apis_networking_v1beta1_NetworkPolicyStats(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyStatsList":              schema_pkg_apis_networking_v1beta1_NetworkPolicyStatsList(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyStatus":                 schema_pkg_apis_networking_v1beta1_NetworkPolicyStatus(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NodeStatsSummary":                    schema_pkg_apis_networking_v1beta1_NodeStatsSummary(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.PodReference":                        schema_pkg_apis_networking_v1beta1_PodReference(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.RuleTrafficStats":                    schema_pkg_apis_networking_v1beta1_RuleTrafficStats(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.Service":                             schema_pkg_apis_networking_v1beta1_Service(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.TrafficStats":                        schema_pkg_apis_networking_v1beta1_TrafficStats(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/system/v1beta1.SupportBundle":                           schema_pkg_apis_system_v1beta1_SupportBundle(ref),
 		"k8s.io/api/core/v1.AWSElasticBlockStoreVolumeSource":                                            schema_k8sio_api_core_v1_AWSElasticBlockStoreVolumeSource(ref),
 		"k8s.io/api/core/v1.Affinity":                                    schema_k8sio_api_core_v1_Affinity(ref),
@@ -548,7 +564,7 @@ func schema_pkg_apis_clusterinformation_v1beta1_AntreaControllerInfo(ref common.
 					},
 					"nodeRef": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The Pod that Antrea Controller is running in",
+							Description: "The Pod of the leader Antrea Controller replica",
 							Ref:         ref("k8s.io/api/core/v1.ObjectReference"),
 						},
 					},
@@ -591,6 +607,13 @@ func schema_pkg_apis_clusterinformation_v1beta1_AntreaControllerInfo(ref common.
 							Format:      "int32",
 						},
 					},
+					"leader": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The port of antrea controller API Server",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -1064,6 +1087,254 @@ func schema_pkg_apis_networking_v1beta1_AppliedToGroupPatch(ref common.Reference
 	}
 }
 
+func schema_pkg_apis_networking_v1beta1_ConnectivityQuery(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ConnectivityQuery evaluates whether the traffic from a source to a destination is allowed by the NetworkPolicies.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Spec describes the traffic to evaluate.",
+							Ref:         ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.ConnectivityQuerySpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status is the result of the evaluation.",
+							Ref:         ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.ConnectivityQueryStatus"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.ConnectivityQuerySpec", "github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.ConnectivityQueryStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_networking_v1beta1_ConnectivityQueryPeer(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ConnectivityQueryPeer is a peer of a ConnectivityQuery. Exactly one of Pod and IP must be set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"pod": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Pod is a reference to a Pod.",
+							Ref:         ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.PodReference"),
+						},
+					},
+					"ip": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IP is an IP address which doesn't belong to a Pod.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.PodReference"},
+	}
+}
+
+func schema_pkg_apis_networking_v1beta1_ConnectivityQuerySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ConnectivityQuerySpec describes the traffic to evaluate.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"source": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Source is the source of the traffic.",
+							Ref:         ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.ConnectivityQueryPeer"),
+						},
+					},
+					"destination": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Destination is the destination of the traffic.",
+							Ref:         ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.ConnectivityQueryPeer"),
+						},
+					},
+					"protocol": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Protocol is the protocol of the traffic. It defaults to TCP.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"port": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Port is the destination port of the traffic. 0 means any port, in which case only the rules matching all ports are considered.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"source", "destination"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.ConnectivityQueryPeer"},
+	}
+}
+
+func schema_pkg_apis_networking_v1beta1_ConnectivityQueryStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ConnectivityQueryStatus is the result of a ConnectivityQuery.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"allowed": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Allowed indicates whether the traffic is allowed in both directions it is subject to.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"egress": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Egress is the verdict of the egress policies of the source. It is nil if the source isn't a Pod.",
+							Ref:         ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.ConnectivityQueryVerdict"),
+						},
+					},
+					"ingress": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Ingress is the verdict of the ingress policies of the destination. It is nil if the destination isn't a Pod.",
+							Ref:         ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.ConnectivityQueryVerdict"),
+						},
+					},
+				},
+				Required: []string{"allowed"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.ConnectivityQueryVerdict"},
+	}
+}
+
+func schema_pkg_apis_networking_v1beta1_ConnectivityQueryVerdict(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ConnectivityQueryVerdict is the verdict of the NetworkPolicies applied to a Pod for a direction.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"allowed": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Allowed indicates whether the traffic is allowed in this direction.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"rule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Rule is the rule that decided the verdict. It is nil if no rule matched the traffic.",
+							Ref:         ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyRuleReference"),
+						},
+					},
+					"isolated": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Isolated indicates whether the Pod is isolated in this direction by K8s NetworkPolicies, in which case the traffic matching no rule is dropped.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"allowed", "isolated"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyRuleReference"},
+	}
+}
+
+func schema_pkg_apis_networking_v1beta1_EndpointQuery(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "EndpointQuery describes the NetworkPolicies affecting a Pod. Its name and namespace are the ones of the Pod.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"appliedPolicies": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AppliedPolicies is a list of the NetworkPolicies applied to the Pod.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyReference"),
+									},
+								},
+							},
+						},
+					},
+					"peerRules": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PeerRules is a list of the rules of any NetworkPolicy which select the Pod as a peer, i.e. the ingress rules whose sources include the Pod and the egress rules whose destinations include the Pod.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyRuleReference"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyReference", "github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyRuleReference", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
 func schema_pkg_apis_networking_v1beta1_GroupMemberPod(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1097,6 +1368,20 @@ func schema_pkg_apis_networking_v1beta1_GroupMemberPod(ref common.ReferenceCallb
 							},
 						},
 					},
+					"ips": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IPs maintains the IPAddresses associated with the Pod, at most one for each IP family. IP is the first one of them and is kept for backward compatibility.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "byte",
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
@@ -1252,11 +1537,31 @@ func schema_pkg_apis_networking_v1beta1_NetworkPolicy(ref common.ReferenceCallba
 							},
 						},
 					},
+					"priority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Priority represents the relative priority of this NetworkPolicy as compared to other NetworkPolicies in the same tier. Priority will be unset (nil) for K8s NetworkPolicy.",
+							Type:        []string{"number"},
+							Format:      "double",
+						},
+					},
+					"tierPriority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TierPriority represents the priority of the tier associated with this NetworkPolicy. TierPriority will be unset (nil) for K8s NetworkPolicy.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status is the realization status of this NetworkPolicy, aggregated from the statuses reported by the Nodes it spans. It is only set in the responses of get and list requests.",
+							Ref:         ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyRealizationStatus"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyRule", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyRealizationStatus", "github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyRule", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
@@ -1307,6 +1612,48 @@ func schema_pkg_apis_networking_v1beta1_NetworkPolicyList(ref common.ReferenceCa
 	}
 }
 
+func schema_pkg_apis_networking_v1beta1_NetworkPolicyNodeStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NetworkPolicyNodeStatus is the status of a NetworkPolicy on a Node.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"nodeName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeName is the name of the Node that produced this status.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"generation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Generation is the generation of the NetworkPolicy processed by the Node.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"failedRules": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailedRules is the number of rules of the NetworkPolicy that the Node failed to realize.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"lastError": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastError is the error encountered when realizing a failed rule.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"nodeName", "generation"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_networking_v1beta1_NetworkPolicyPeer(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1341,6 +1688,20 @@ func schema_pkg_apis_networking_v1beta1_NetworkPolicyPeer(ref common.ReferenceCa
 							},
 						},
 					},
+					"fqdns": {
+						SchemaProps: spec.SchemaProps{
+							Description: "A list of FQDNs, which are resolved by the agent by snooping the DNS responses sent to the Pods the rule applies to. Only valid for egress rules. A leading \"*.\" matches any subdomain.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
@@ -1349,6 +1710,83 @@ func schema_pkg_apis_networking_v1beta1_NetworkPolicyPeer(ref common.ReferenceCa
 	}
 }
 
+func schema_pkg_apis_networking_v1beta1_NetworkPolicyRealizationStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NetworkPolicyRealizationStatus is the realization status of a NetworkPolicy across the Nodes it spans.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"currentNodesRealized": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CurrentNodesRealized is the number of Nodes that have realized the current generation of this NetworkPolicy.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"desiredNodesRealized": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DesiredNodesRealized is the number of Nodes this NetworkPolicy spans.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"failedNodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailedNodes is a list of statuses of the Nodes that failed to realize some rules of the current generation of this NetworkPolicy.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyNodeStatus"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"currentNodesRealized", "desiredNodesRealized"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyNodeStatus"},
+	}
+}
+
+func schema_pkg_apis_networking_v1beta1_NetworkPolicyReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NetworkPolicyReference is a reference to a NetworkPolicy.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace of the NetworkPolicy. It is empty for ClusterNetworkPolicies.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the NetworkPolicy.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"uid": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UID of the NetworkPolicy.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_networking_v1beta1_NetworkPolicyRule(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1388,6 +1826,34 @@ func schema_pkg_apis_networking_v1beta1_NetworkPolicyRule(ref common.ReferenceCa
 							},
 						},
 					},
+					"priority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Priority defines the priority of the rule as compared to other rules in the NetworkPolicy. A lower value means a higher precedence.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"action": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Action specifies the action to be applied on the rule, i.e. Allow, Drop or Reject. An empty action \"nil\" defaults to Allow, which would be the case for rules created for K8s NetworkPolicy.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"enableLogging": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnableLogging indicates whether the connections matching the rule, and the connections dropped because the rule isolates its Pods, must be logged.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the rule, unique within the NetworkPolicy. It's generated from the direction of the rule and its position in the spec, e.g. \"ingress-0\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -1396,6 +1862,244 @@ func schema_pkg_apis_networking_v1beta1_NetworkPolicyRule(ref common.ReferenceCa
 	}
 }
 
+func schema_pkg_apis_networking_v1beta1_NetworkPolicyRuleReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NetworkPolicyRuleReference is a reference to a rule of a NetworkPolicy.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"policy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Policy is the NetworkPolicy the rule belongs to.",
+							Ref:         ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyReference"),
+						},
+					},
+					"direction": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Direction is the direction of the rule.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"index": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Index is the index of the rule in the Rules of the NetworkPolicy.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"action": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Action is the action of the rule. It is nil for the rules of K8s NetworkPolicies, which are always Allow.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"policy", "direction", "index"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyReference"},
+	}
+}
+
+func schema_pkg_apis_networking_v1beta1_NetworkPolicyStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NetworkPolicyStats is the traffic statistics of a NetworkPolicy. Its name, namespace and UID are the ones of the NetworkPolicy.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"trafficStats": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TrafficStats is the traffic statistics of the NetworkPolicy.",
+							Ref:         ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.TrafficStats"),
+						},
+					},
+					"ruleTrafficStats": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RuleTrafficStats is a list of the traffic statistics of the rules of the NetworkPolicy.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.RuleTrafficStats"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"trafficStats"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.RuleTrafficStats", "github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.TrafficStats", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_networking_v1beta1_NetworkPolicyStatsList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NetworkPolicyStatsList is a list of NetworkPolicyStats.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyStats"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyStats", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_networking_v1beta1_NetworkPolicyStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NetworkPolicyStatus is the status of a NetworkPolicy reported by antrea-agents. Its name, namespace and UID are the ones of the NetworkPolicy.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"nodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Nodes is a list of statuses of the NetworkPolicy on the reporting Nodes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyNodeStatus"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyNodeStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_networking_v1beta1_NodeStatsSummary(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NodeStatsSummary contains the traffic statistics collected by an antrea-agent since its last report. Its name is the name of the Node.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"networkPolicies": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NetworkPolicies is a list of the traffic statistics of the NetworkPolicies collected on the Node.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyStats"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyStats", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
 func schema_pkg_apis_networking_v1beta1_PodReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1423,6 +2127,35 @@ func schema_pkg_apis_networking_v1beta1_PodReference(ref common.ReferenceCallbac
 	}
 }
 
+func schema_pkg_apis_networking_v1beta1_RuleTrafficStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RuleTrafficStats contains the traffic statistics of a NetworkPolicy rule.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the rule.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"trafficStats": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TrafficStats is the traffic statistics of the rule.",
+							Ref:         ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.TrafficStats"),
+						},
+					},
+				},
+				Required: []string{"name", "trafficStats"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.TrafficStats"},
+	}
+}
+
 func schema_pkg_apis_networking_v1beta1_Service(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1432,7 +2165,7 @@ func schema_pkg_apis_networking_v1beta1_Service(ref common.ReferenceCallback) co
 				Properties: map[string]spec.Schema{
 					"protocol": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The protocol (TCP, UDP, or SCTP) which traffic must match. If not specified, this field defaults to TCP.",
+							Description: "The protocol (TCP, UDP, SCTP, or ICMP) which traffic must match. If not specified, this field defaults to TCP.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -1443,6 +2176,27 @@ func schema_pkg_apis_networking_v1beta1_Service(ref common.ReferenceCallback) co
 							Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
 						},
 					},
+					"endPort": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EndPort, if set, makes the Service match the range of port numbers from Port to EndPort, both inclusive. It can only be set when Port is a number.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"icmpType": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ICMPType is the ICMP type which traffic must match, only used with the ICMP protocol. If not specified, this matches all ICMP types.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"icmpCode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ICMPCode is the ICMP code which traffic must match, only used with the ICMP protocol. If not specified, this matches all ICMP codes.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 				},
 			},
 		},
@@ -1451,6 +2205,41 @@ func schema_pkg_apis_networking_v1beta1_Service(ref common.ReferenceCallback) co
 	}
 }
 
+func schema_pkg_apis_networking_v1beta1_TrafficStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TrafficStats contains the traffic statistics of an object.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"packets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Packets is the number of packets.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"bytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Bytes is the number of bytes.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"sessions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Sessions is the number of sessions.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"packets", "bytes", "sessions"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_system_v1beta1_SupportBundle(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -86,5 +86,5 @@ func (r *REST) NamespaceScoped() bool {
 
 func (r *REST) Watch(ctx context.Context, options *internalversion.ListOptions) (watch.Interface, error) {
 	key, label, field := networkpolicy.GetSelectors(options)
-	return r.addressGroupStore.Watch(ctx, key, label, field)
+	return r.addressGroupStore.Watch(ctx, key, label, field, networkpolicy.GetWatchOptions(options))
 }
@@ -0,0 +1,67 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package connectivityquery
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+)
+
+// connectivityQuerier evaluates whether some traffic is allowed by the
+// NetworkPolicies.
+type connectivityQuerier interface {
+	QueryConnectivity(spec *networking.ConnectivityQuerySpec) (*networking.ConnectivityQueryStatus, error)
+}
+
+// REST implements rest.Storage for ConnectivityQueries.
+type REST struct {
+	querier connectivityQuerier
+}
+
+var (
+	_ rest.Storage = &REST{}
+	_ rest.Scoper  = &REST{}
+	_ rest.Creater = &REST{}
+)
+
+// NewREST returns a REST object that will work against API services.
+func NewREST(querier connectivityQuerier) *REST {
+	return &REST{querier}
+}
+
+func (r *REST) New() runtime.Object {
+	return &networking.ConnectivityQuery{}
+}
+
+func (r *REST) NamespaceScoped() bool {
+	return false
+}
+
+// Create evaluates the query and returns it with its status set. The query
+// isn't persisted.
+func (r *REST) Create(ctx context.Context, obj runtime.Object, _ rest.ValidateObjectFunc, _ *metav1.CreateOptions) (runtime.Object, error) {
+	query := obj.(*networking.ConnectivityQuery)
+	status, err := r.querier.QueryConnectivity(&query.Spec)
+	if err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+	query.Status = *status
+	return query, nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package endpointquery
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+)
+
+// endpointQuerier returns the NetworkPolicies affecting a Pod.
+type endpointQuerier interface {
+	QueryEndpoint(namespace, name string) (*networking.EndpointQuery, error)
+}
+
+// REST implements rest.Storage for EndpointQueries.
+type REST struct {
+	querier endpointQuerier
+}
+
+var (
+	_ rest.Storage = &REST{}
+	_ rest.Scoper  = &REST{}
+	_ rest.Getter  = &REST{}
+)
+
+// NewREST returns a REST object that will work against API services.
+func NewREST(querier endpointQuerier) *REST {
+	return &REST{querier}
+}
+
+func (r *REST) New() runtime.Object {
+	return &networking.EndpointQuery{}
+}
+
+// Get returns the NetworkPolicies affecting the Pod with the provided name.
+func (r *REST) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	ns, ok := request.NamespaceFrom(ctx)
+	if !ok || len(ns) == 0 {
+		return nil, errors.NewBadRequest("Namespace parameter required.")
+	}
+	return r.querier.QueryEndpoint(ns, name)
+}
+
+func (r *REST) NamespaceScoped() bool {
+	return true
+}
@@ -33,9 +33,15 @@ import (
 	"github.com/vmware-tanzu/antrea/pkg/k8s"
 )
 
+// statusProvider provides the realization statuses of NetworkPolicies.
+type statusProvider interface {
+	GetRealizationStatus(policy *types.NetworkPolicy) *networking.NetworkPolicyRealizationStatus
+}
+
 // REST implements rest.Storage for NetworkPolicies.
 type REST struct {
 	networkPolicyStore storage.Interface
+	statusProvider     statusProvider
 }
 
 var (
@@ -47,8 +53,8 @@ var (
 )
 
 // NewREST returns a REST object that will work against API services.
-func NewREST(networkPolicyStore storage.Interface) *REST {
-	return &REST{networkPolicyStore}
+func NewREST(networkPolicyStore storage.Interface, statusProvider statusProvider) *REST {
+	return &REST{networkPolicyStore, statusProvider}
 }
 
 func (r *REST) New() runtime.Object {
@@ -74,6 +80,7 @@ func (r *REST) Get(ctx context.Context, name string, options *v1.GetOptions) (ru
 	}
 	obj := new(networking.NetworkPolicy)
 	store.ToNetworkPolicyMsg(networkPolicy.(*types.NetworkPolicy), obj, true)
+	obj.Status = r.statusProvider.GetRealizationStatus(networkPolicy.(*types.NetworkPolicy))
 	return obj, nil
 }
 
@@ -85,6 +92,7 @@ func (r *REST) List(ctx context.Context, options *internalversion.ListOptions) (
 		if !namespaceScoped || len(ns) == 0 || networkPolicies[i].(*types.NetworkPolicy).Namespace == ns {
 			policy := networking.NetworkPolicy{}
 			store.ToNetworkPolicyMsg(networkPolicies[i].(*types.NetworkPolicy), &policy, true)
+			policy.Status = r.statusProvider.GetRealizationStatus(networkPolicies[i].(*types.NetworkPolicy))
 			list.Items = append(list.Items, policy)
 		}
 	}
@@ -104,5 +112,5 @@ func (r *REST) Watch(ctx context.Context, options *internalversion.ListOptions)
 		}
 		key = k8s.NamespacedName(ns, key)
 	}
-	return r.networkPolicyStore.Watch(ctx, key, label, field)
+	return r.networkPolicyStore.Watch(ctx, key, label, field, networkpolicy.GetWatchOptions(options))
 }
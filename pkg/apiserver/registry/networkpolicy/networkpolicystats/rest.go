@@ -0,0 +1,81 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicystats
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+)
+
+// statsProvider provides the traffic statistics of NetworkPolicies.
+type statsProvider interface {
+	GetNetworkPolicyStats(namespace, name string) (*networking.NetworkPolicyStats, bool)
+	ListNetworkPolicyStats(namespace string) []networking.NetworkPolicyStats
+}
+
+// REST implements rest.Storage for NetworkPolicyStats.
+type REST struct {
+	statsProvider statsProvider
+}
+
+var (
+	_ rest.Storage = &REST{}
+	_ rest.Scoper  = &REST{}
+	_ rest.Lister  = &REST{}
+	_ rest.Getter  = &REST{}
+)
+
+// NewREST returns a REST object that will work against API services.
+func NewREST(statsProvider statsProvider) *REST {
+	return &REST{statsProvider}
+}
+
+func (r *REST) New() runtime.Object {
+	return &networking.NetworkPolicyStats{}
+}
+
+func (r *REST) NewList() runtime.Object {
+	return &networking.NetworkPolicyStatsList{}
+}
+
+func (r *REST) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	ns, ok := request.NamespaceFrom(ctx)
+	if !ok || len(ns) == 0 {
+		return nil, errors.NewBadRequest("Namespace parameter required.")
+	}
+	stats, exists := r.statsProvider.GetNetworkPolicyStats(ns, name)
+	if !exists {
+		return nil, errors.NewNotFound(networking.Resource("networkpolicystats"), name)
+	}
+	return stats, nil
+}
+
+func (r *REST) List(ctx context.Context, options *internalversion.ListOptions) (runtime.Object, error) {
+	ns, _ := request.NamespaceFrom(ctx)
+	list := &networking.NetworkPolicyStatsList{Items: r.statsProvider.ListNetworkPolicyStats(ns)}
+	return list, nil
+}
+
+func (r *REST) NamespaceScoped() bool {
+	return true
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicystatus
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+)
+
+// statusUpdater records the statuses of NetworkPolicies reported by the Nodes.
+type statusUpdater interface {
+	UpdateStatus(status *networking.NetworkPolicyStatus) error
+}
+
+// REST implements rest.Storage for NetworkPolicyStatuses.
+type REST struct {
+	statusUpdater statusUpdater
+}
+
+var (
+	_ rest.Storage = &REST{}
+	_ rest.Scoper  = &REST{}
+	_ rest.Creater = &REST{}
+)
+
+// NewREST returns a REST object that will work against API services.
+func NewREST(statusUpdater statusUpdater) *REST {
+	return &REST{statusUpdater}
+}
+
+func (r *REST) New() runtime.Object {
+	return &networking.NetworkPolicyStatus{}
+}
+
+func (r *REST) NamespaceScoped() bool {
+	return false
+}
+
+// Create records the statuses of the NetworkPolicy reported by the Nodes.
+func (r *REST) Create(ctx context.Context, obj runtime.Object, _ rest.ValidateObjectFunc, _ *metav1.CreateOptions) (runtime.Object, error) {
+	status := obj.(*networking.NetworkPolicyStatus)
+	if err := r.statusUpdater.UpdateStatus(status); err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+	return status, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodestatssummary
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+)
+
+// statsCollector collects the traffic statistics reported by the Nodes.
+type statsCollector interface {
+	CollectNodeStatsSummary(summary *networking.NodeStatsSummary)
+}
+
+// REST implements rest.Storage for NodeStatsSummaries.
+type REST struct {
+	statsCollector statsCollector
+}
+
+var (
+	_ rest.Storage = &REST{}
+	_ rest.Scoper  = &REST{}
+	_ rest.Creater = &REST{}
+)
+
+// NewREST returns a REST object that will work against API services.
+func NewREST(statsCollector statsCollector) *REST {
+	return &REST{statsCollector}
+}
+
+func (r *REST) New() runtime.Object {
+	return &networking.NodeStatsSummary{}
+}
+
+func (r *REST) NamespaceScoped() bool {
+	return false
+}
+
+// Create collects the traffic statistics reported by a Node.
+func (r *REST) Create(ctx context.Context, obj runtime.Object, _ rest.ValidateObjectFunc, _ *metav1.CreateOptions) (runtime.Object, error) {
+	summary := obj.(*networking.NodeStatsSummary)
+	r.statsCollector.CollectNodeStatsSummary(summary)
+	return summary, nil
+}
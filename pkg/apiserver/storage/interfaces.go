@@ -32,6 +32,19 @@ type Selectors struct {
 	Field fields.Selector
 }
 
+// WatchOptions are the options of a watch besides its selectors.
+type WatchOptions struct {
+	// ResourceVersion is the resourceVersion of the last Bookmark event
+	// received by the client from a previous watch. If set, the watch resumes
+	// from it: no init events are sent and only the events that occurred
+	// after it are. If empty or "0", a watch with init events is started.
+	ResourceVersion string
+	// AllowBookmarks indicates that the client wants to receive Bookmark
+	// events with the resourceVersion up to which it has received events, so
+	// that it can resume the watch later.
+	AllowBookmarks bool
+}
+
 // InternalEvent is an internal event that can be converted to *watch.Event based on watcher's Selectors.
 // For example, an internal event may be converted to an ADDED event for one watcher, and to a MODIFIED event
 // for another.
@@ -76,8 +89,9 @@ type Interface interface {
 	// Delete removes an object that has specified key.
 	Delete(key string) error
 
-	// Watch starts watching with the specified key and selectors. Events will be sent to the returned watch.Interface.
-	Watch(ctx context.Context, key string, labelSelector labels.Selector, fieldSelector fields.Selector) (watch.Interface, error)
+	// Watch starts watching with the specified key, selectors and options. Events will be sent to the returned
+	// watch.Interface. It returns a ResourceExpired error if options.ResourceVersion is too old to resume from.
+	Watch(ctx context.Context, key string, labelSelector labels.Selector, fieldSelector fields.Selector, options WatchOptions) (watch.Interface, error)
 
 	// GetWatchersNum gets the number of watchers for the store.
 	GetWatchersNum() int
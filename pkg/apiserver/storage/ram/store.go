@@ -17,12 +17,15 @@ package ram
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/apiserver/pkg/storage"
 	"k8s.io/client-go/tools/cache"
@@ -37,6 +40,10 @@ const (
 	// watcherAddTimeout is the timeout of sending one event to all watchers.
 	// Watchers whose buffer can't be available in it will be terminated.
 	watcherAddTimeout = 50 * time.Millisecond
+	// historySize is the maximum number of events kept in the history of a
+	// store. Watchers can resume from a resourceVersion only if all the events
+	// that occurred after it are still in the history.
+	historySize = 1000
 )
 
 type watchersMap map[int]*storeWatcher
@@ -66,6 +73,12 @@ type store struct {
 
 	// resourceVersion up to which the store has generated.
 	resourceVersion uint64
+	// history keeps the latest events generated by the store, in the order of
+	// their resourceVersions. It's protected by eventMutex.
+	history []antreastorage.InternalEvent
+	// historyStartRV is the oldest resourceVersion that a watcher can resume
+	// from, i.e. all events newer than it are in history.
+	historyStartRV uint64
 	// watcherIdx is the index that will be allocated to next watcher and used as key in watchersMap
 	// so that a watcher can be deleted from the map according to its index later.
 	watcherIdx int
@@ -90,6 +103,12 @@ func NewStore(keyFunc cache.KeyFunc, indexers cache.Indexers, genEventFunc antre
 	if !timer.Stop() {
 		<-timer.C
 	}
+	// The store doesn't persist its objects and events, so a resourceVersion
+	// generated by another instance of it, e.g. a previous run or another
+	// replica of antrea-controller, must not be resumed from. Starting from a
+	// random offset in the upper 32 bits makes such resourceVersions fall
+	// outside the range of this instance, and be rejected, in all likelihood.
+	initialRV := uint64(rand.Int63nRange(1, 1<<31)) << 32
 	s := &store{
 		incoming:     make(chan antreastorage.InternalEvent, 100),
 		storage:      storage,
@@ -100,6 +119,9 @@ func NewStore(keyFunc cache.KeyFunc, indexers cache.Indexers, genEventFunc antre
 		selectFunc:   selectorFunc,
 		timer:        timer,
 		newFunc:      newFunc,
+
+		resourceVersion: initialRV,
+		historyStartRV:  initialRV,
 	}
 
 	go s.dispatchEvents()
@@ -113,7 +135,15 @@ func (s *store) nextResourceVersion() uint64 {
 	return s.resourceVersion
 }
 
+// processEvent records the event in history and sends it to the incoming channel.
+// It should be called while holding a lock on eventMutex.
 func (s *store) processEvent(event antreastorage.InternalEvent) {
+	if len(s.history) >= historySize {
+		s.historyStartRV = s.history[0].GetResourceVersion()
+		s.history[0] = nil
+		s.history = s.history[1:]
+	}
+	s.history = append(s.history, event)
 	if curLen := int64(len(s.incoming)); s.incomingHWM.Update(curLen) {
 		// Monitor if this gets backed up, and how much.
 		klog.V(1).Infof("%v objects queued in incoming channel", curLen)
@@ -221,8 +251,9 @@ func (s *store) Delete(key string) error {
 	return nil
 }
 
-// Watch creates a watcher based on the key, label selector and field selector.
-func (s *store) Watch(ctx context.Context, key string, labelSelector labels.Selector, fieldSelector fields.Selector) (watch.Interface, error) {
+// Watch creates a watcher based on the key, label selector, field selector and options.
+// If options.ResourceVersion is set, the watcher only receives the events that occurred after it.
+func (s *store) Watch(ctx context.Context, key string, labelSelector labels.Selector, fieldSelector fields.Selector, options antreastorage.WatchOptions) (watch.Interface, error) {
 	if s.genEventFunc == nil {
 		return nil, fmt.Errorf("genEventFunc must be set to support watching")
 	}
@@ -237,6 +268,10 @@ func (s *store) Watch(ctx context.Context, key string, labelSelector labels.Sele
 		Field: fieldSelector,
 	}
 
+	if options.ResourceVersion != "" && options.ResourceVersion != "0" {
+		return s.resumeWatch(ctx, selectors, options)
+	}
+
 	allObjects := s.storage.List()
 	initEvents := make([]antreastorage.InternalEvent, 0, len(allObjects))
 	for _, obj := range allObjects {
@@ -254,22 +289,48 @@ func (s *store) Watch(ctx context.Context, key string, labelSelector labels.Sele
 		initEvents = append(initEvents, event)
 	}
 
-	watcher := func() *storeWatcher {
-		s.watcherMutex.Lock()
-		defer s.watcherMutex.Unlock()
-
-		w := newStoreWatcher(watcherChanSize, selectors, forgetWatcher(s, s.watcherIdx), s.newFunc)
-		s.watchers[s.watcherIdx] = w
-		s.watcherIdx++
-		return w
-	}()
-
+	watcher := s.addWatcher(selectors, options.AllowBookmarks)
 	// Specify current resourceVersion so that old events that were currently buffered in incoming channel won't be
 	// delivered to the watcher twice when initEvents already have them.
 	go watcher.process(ctx, initEvents, s.resourceVersion)
 	return watcher, nil
 }
 
+// resumeWatch creates a watcher which receives the events that occurred after
+// options.ResourceVersion, followed by the events generated from now on.
+// It must be called while holding a read lock on eventMutex.
+func (s *store) resumeWatch(ctx context.Context, selectors *antreastorage.Selectors, options antreastorage.WatchOptions) (watch.Interface, error) {
+	resourceVersion, err := strconv.ParseUint(options.ResourceVersion, 10, 64)
+	if err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid resourceVersion %q: %v", options.ResourceVersion, err))
+	}
+	if resourceVersion < s.historyStartRV || resourceVersion > s.resourceVersion {
+		return nil, errors.NewResourceExpired(fmt.Sprintf("too old resource version: %d (%d)", resourceVersion, s.historyStartRV))
+	}
+
+	var missedEvents []antreastorage.InternalEvent
+	for _, event := range s.history {
+		if event.GetResourceVersion() > resourceVersion {
+			missedEvents = append(missedEvents, event)
+		}
+	}
+
+	watcher := s.addWatcher(selectors, options.AllowBookmarks)
+	go watcher.resume(ctx, missedEvents, s.resourceVersion)
+	return watcher, nil
+}
+
+func (s *store) addWatcher(selectors *antreastorage.Selectors, allowBookmarks bool) *storeWatcher {
+	s.watcherMutex.Lock()
+	defer s.watcherMutex.Unlock()
+
+	w := newStoreWatcher(watcherChanSize, selectors, forgetWatcher(s, s.watcherIdx), s.newFunc)
+	w.allowBookmarks = allowBookmarks
+	s.watchers[s.watcherIdx] = w
+	s.watcherIdx++
+	return w
+}
+
 // GetWatchersNum gets the number of watchers for the store.
 func (s *store) GetWatchersNum() int {
 	s.watcherMutex.RLock()
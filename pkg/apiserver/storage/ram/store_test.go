@@ -23,6 +23,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
@@ -312,7 +313,7 @@ func TestRamStoreWatchAll(t *testing.T) {
 	}
 	for i, testCase := range testCases {
 		store := NewStore(cache.MetaNamespaceKeyFunc, cache.Indexers{}, testGenEvent, testSelectFunc, func() runtime.Object { return new(v1.Pod) })
-		w, err := store.Watch(context.Background(), "", labels.Everything(), fields.Everything())
+		w, err := store.Watch(context.Background(), "", labels.Everything(), fields.Everything(), antreastorage.WatchOptions{})
 		if err != nil {
 			t.Errorf("%d: failed to watch object: %v", i, err)
 		}
@@ -379,7 +380,7 @@ func TestRamStoreWatchWithInitOperations(t *testing.T) {
 		store := NewStore(cache.MetaNamespaceKeyFunc, cache.Indexers{}, testGenEvent, testSelectFunc, func() runtime.Object { return new(v1.Pod) })
 		// Init the storage before watching
 		testCase.initOperations(store)
-		w, err := store.Watch(context.Background(), "", labels.Everything(), fields.Everything())
+		w, err := store.Watch(context.Background(), "", labels.Everything(), fields.Everything(), antreastorage.WatchOptions{})
 		if err != nil {
 			t.Errorf("%d: failed to watch object: %v", i, err)
 		}
@@ -448,7 +449,7 @@ func TestRamStoreWatchWithSelector(t *testing.T) {
 	}
 	for i, testCase := range testCases {
 		store := NewStore(cache.MetaNamespaceKeyFunc, cache.Indexers{}, testGenEvent, testSelectFunc, func() runtime.Object { return new(v1.Pod) })
-		w, err := store.Watch(context.Background(), "", testCase.labelSelector, fields.Everything())
+		w, err := store.Watch(context.Background(), "", testCase.labelSelector, fields.Everything(), antreastorage.WatchOptions{})
 		if err != nil {
 			t.Errorf("%d: failed to watch object: %v", i, err)
 		}
@@ -474,7 +475,7 @@ func TestRamStoreWatchTimeout(t *testing.T) {
 	maxBuffered := watcherChanSize*2 + 1
 
 	// w1 has consumer for its result chan.
-	w1, err := store.Watch(context.Background(), "", labels.SelectorFromSet(labels.Set{"app": "nginx"}), fields.Everything())
+	w1, err := store.Watch(context.Background(), "", labels.SelectorFromSet(labels.Set{"app": "nginx"}), fields.Everything(), antreastorage.WatchOptions{})
 	if err != nil {
 		t.Errorf("Failed to watch object: %v", err)
 	}
@@ -497,7 +498,7 @@ func TestRamStoreWatchTimeout(t *testing.T) {
 	}()
 
 	// w2 has no consumer for its result chan.
-	w2, err := store.Watch(context.Background(), "", labels.SelectorFromSet(labels.Set{"app": "nginx"}), fields.Everything())
+	w2, err := store.Watch(context.Background(), "", labels.SelectorFromSet(labels.Set{"app": "nginx"}), fields.Everything(), antreastorage.WatchOptions{})
 	if err != nil {
 		t.Errorf("Failed to watch object: %v", err)
 	}
@@ -527,3 +528,61 @@ func TestRamStoreWatchTimeout(t *testing.T) {
 	}
 	assert.Equal(t, 1, store.GetWatchersNum(), "Unexpected watchers number")
 }
+
+func TestRamStoreWatchResume(t *testing.T) {
+	store := NewStore(cache.MetaNamespaceKeyFunc, cache.Indexers{}, testGenEvent, testSelectFunc, func() runtime.Object { return new(v1.Pod) })
+	pod0 := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod0", Labels: map[string]string{"app": "nginx"}}}
+	store.Create(pod0)
+
+	options := antreastorage.WatchOptions{AllowBookmarks: true}
+	w1, err := store.Watch(context.Background(), "", labels.Everything(), fields.Everything(), options)
+	if err != nil {
+		t.Fatalf("Failed to watch object: %v", err)
+	}
+	assert.Equal(t, watch.Event{Type: watch.Added, Object: pod0}, <-w1.ResultChan())
+	bookmark := <-w1.ResultChan()
+	assert.Equal(t, watch.Bookmark, bookmark.Type)
+	resourceVersion := bookmark.Object.(*v1.Pod).ResourceVersion
+	assert.NotEmpty(t, resourceVersion)
+	w1.Stop()
+
+	pod1 := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Labels: map[string]string{"app": "nginx"}}}
+	updatedPod0 := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod0", Labels: map[string]string{"app": "web"}}}
+	store.Create(pod1)
+	store.Update(updatedPod0)
+	store.Delete("pod1")
+
+	options.ResourceVersion = resourceVersion
+	w2, err := store.Watch(context.Background(), "", labels.Everything(), fields.Everything(), options)
+	if err != nil {
+		t.Fatalf("Failed to resume watch: %v", err)
+	}
+	defer w2.Stop()
+	expectedEvents := []watch.Event{
+		{Type: watch.Added, Object: pod1},
+		{Type: watch.Modified, Object: updatedPod0},
+		{Type: watch.Deleted, Object: pod1},
+	}
+	for i, expectedEvent := range expectedEvents {
+		assert.Equal(t, expectedEvent, <-w2.ResultChan(), "Unexpected event %d", i)
+	}
+	bookmark = <-w2.ResultChan()
+	assert.Equal(t, watch.Bookmark, bookmark.Type)
+	assert.Equal(t, fmt.Sprint(store.resourceVersion), bookmark.Object.(*v1.Pod).ResourceVersion)
+
+	// Resuming from a resourceVersion whose following events have been evicted from history must fail.
+	for i := 0; i < historySize; i++ {
+		store.Update(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod0", Labels: map[string]string{"app": fmt.Sprintf("web%d", i)}}})
+	}
+	_, err = store.Watch(context.Background(), "", labels.Everything(), fields.Everything(), options)
+	assert.True(t, errors.IsResourceExpired(err), "Expected ResourceExpired error, got %v", err)
+
+	// Resuming from a resourceVersion generated by another store must fail.
+	options.ResourceVersion = "1"
+	_, err = store.Watch(context.Background(), "", labels.Everything(), fields.Everything(), options)
+	assert.True(t, errors.IsResourceExpired(err), "Expected ResourceExpired error, got %v", err)
+
+	options.ResourceVersion = "foo"
+	_, err = store.Watch(context.Background(), "", labels.Everything(), fields.Everything(), options)
+	assert.True(t, errors.IsBadRequest(err), "Expected BadRequest error, got %v", err)
+}
@@ -16,9 +16,11 @@ package ram
 
 import (
 	"context"
+	"strconv"
 	"sync"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/klog"
@@ -26,6 +28,10 @@ import (
 	"github.com/vmware-tanzu/antrea/pkg/apiserver/storage"
 )
 
+// bookmarkInterval is the interval at which a watcher that allows bookmarks is
+// sent a Bookmark event, if new events were generated since the last one.
+const bookmarkInterval = 5 * time.Second
+
 type bookmarkEvent struct {
 	resourceVersion uint64
 	object          runtime.Object
@@ -54,6 +60,10 @@ type storeWatcher struct {
 	stopOnce sync.Once
 	// newFunc is a function that creates new empty object of this type.
 	newFunc func() runtime.Object
+	// allowBookmarks indicates whether the Bookmark events sent to the client
+	// should carry the resourceVersion up to which the client has received
+	// events, in which case they are also sent periodically.
+	allowBookmarks bool
 }
 
 func newStoreWatcher(chanSize int, selectors *storage.Selectors, forget func(), newFunc func() runtime.Object) *storeWatcher {
@@ -106,15 +116,41 @@ func (w *storeWatcher) process(ctx context.Context, initEvents []storage.Interna
 	for _, event := range initEvents {
 		w.sendWatchEvent(event, true)
 	}
-	// Send a dummy bookmark event to indicate the end of initEvents. This is
-	// an unusual way to use the bookmark event, as it is meant to be used to
-	// refresh the last resource version of a client. In Antrea we do not use
-	// resource version when restarting a watch, but we need a way to
+	// Send a bookmark event to indicate the end of initEvents. This is an
+	// unusual way to use the bookmark event, as it is meant to be used to
+	// refresh the last resource version of a client, but we need a way to
 	// communicate to clients what the initial set of objects is, so that
 	// stale objects whose delete events were missed by the client (because
 	// the watch was down) can be deleted.
-	w.sendWatchEvent(&bookmarkEvent{resourceVersion, w.newFunc()}, true)
+	w.sendBookmark(resourceVersion)
+	w.processInput(ctx, resourceVersion)
+}
+
+// resume first sends the events that occurred after the resourceVersion the client
+// resumed from, then a bookmark event with the specified resourceVersion, and then
+// keeps sending events got from channel input if they are newer than it.
+func (w *storeWatcher) resume(ctx context.Context, missedEvents []storage.InternalEvent, resourceVersion uint64) {
+	for _, event := range missedEvents {
+		w.sendWatchEvent(event, false)
+	}
+	w.sendBookmark(resourceVersion)
+	w.processInput(ctx, resourceVersion)
+}
+
+// processInput keeps sending events got from channel input if they are newer than the
+// specified resourceVersion, until the channel is closed or the context is canceled.
+func (w *storeWatcher) processInput(ctx context.Context, resourceVersion uint64) {
 	defer close(w.result)
+	// bookmarkCh stays nil if the watcher doesn't allow bookmarks.
+	var bookmarkCh <-chan time.Time
+	if w.allowBookmarks {
+		ticker := time.NewTicker(bookmarkInterval)
+		defer ticker.Stop()
+		bookmarkCh = ticker.C
+	}
+	// lastRV is the resourceVersion of the last event processed, regardless of
+	// whether the watcher was interested in it or not.
+	lastRV, lastBookmarkRV := resourceVersion, resourceVersion
 	for {
 		select {
 		case event, ok := <-w.input:
@@ -124,6 +160,12 @@ func (w *storeWatcher) process(ctx context.Context, initEvents []storage.Interna
 			}
 			if event.GetResourceVersion() > resourceVersion {
 				w.sendWatchEvent(event, false)
+				lastRV = event.GetResourceVersion()
+			}
+		case <-bookmarkCh:
+			if lastRV > lastBookmarkRV {
+				w.sendBookmark(lastRV)
+				lastBookmarkRV = lastRV
 			}
 		case <-ctx.Done():
 			klog.Info("The context had been canceled, stopping process")
@@ -132,6 +174,18 @@ func (w *storeWatcher) process(ctx context.Context, initEvents []storage.Interna
 	}
 }
 
+// sendBookmark sends a bookmark event to result channel. The object of the event
+// carries the provided resourceVersion only if the watcher allows bookmarks.
+func (w *storeWatcher) sendBookmark(resourceVersion uint64) {
+	object := w.newFunc()
+	if w.allowBookmarks {
+		if accessor, err := meta.Accessor(object); err == nil {
+			accessor.SetResourceVersion(strconv.FormatUint(resourceVersion, 10))
+		}
+	}
+	w.sendWatchEvent(&bookmarkEvent{resourceVersion, object}, false)
+}
+
 // sendWatchEvent converts an InternalEvent to watch.Event based on the watcher's selectors.
 // It sends the converted event to result channel, if not nil.
 func (w *storeWatcher) sendWatchEvent(event storage.InternalEvent, isInitEvent bool) {
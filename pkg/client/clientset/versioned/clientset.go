@@ -20,7 +20,11 @@ import (
 	"fmt"
 
 	clusterinformationv1beta1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/clusterinformation/v1beta1"
+	ipamv1alpha1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/ipam/v1alpha1"
+	netattachv1alpha1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/netattach/v1alpha1"
 	networkingv1beta1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/networking/v1beta1"
+	opsv1alpha1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/ops/v1alpha1"
+	securityv1alpha1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/security/v1alpha1"
 	systemv1beta1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/system/v1beta1"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
@@ -30,7 +34,11 @@ import (
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	ClusterinformationV1beta1() clusterinformationv1beta1.ClusterinformationV1beta1Interface
+	IpamV1alpha1() ipamv1alpha1.IpamV1alpha1Interface
+	NetattachV1alpha1() netattachv1alpha1.NetattachV1alpha1Interface
 	NetworkingV1beta1() networkingv1beta1.NetworkingV1beta1Interface
+	OpsV1alpha1() opsv1alpha1.OpsV1alpha1Interface
+	SecurityV1alpha1() securityv1alpha1.SecurityV1alpha1Interface
 	SystemV1beta1() systemv1beta1.SystemV1beta1Interface
 }
 
@@ -39,7 +47,11 @@ type Interface interface {
 type Clientset struct {
 	*discovery.DiscoveryClient
 	clusterinformationV1beta1 *clusterinformationv1beta1.ClusterinformationV1beta1Client
+	ipamV1alpha1              *ipamv1alpha1.IpamV1alpha1Client
+	netattachV1alpha1         *netattachv1alpha1.NetattachV1alpha1Client
 	networkingV1beta1         *networkingv1beta1.NetworkingV1beta1Client
+	opsV1alpha1               *opsv1alpha1.OpsV1alpha1Client
+	securityV1alpha1          *securityv1alpha1.SecurityV1alpha1Client
 	systemV1beta1             *systemv1beta1.SystemV1beta1Client
 }
 
@@ -48,11 +60,31 @@ func (c *Clientset) ClusterinformationV1beta1() clusterinformationv1beta1.Cluste
 	return c.clusterinformationV1beta1
 }
 
+// IpamV1alpha1 retrieves the IpamV1alpha1Client
+func (c *Clientset) IpamV1alpha1() ipamv1alpha1.IpamV1alpha1Interface {
+	return c.ipamV1alpha1
+}
+
+// NetattachV1alpha1 retrieves the NetattachV1alpha1Client
+func (c *Clientset) NetattachV1alpha1() netattachv1alpha1.NetattachV1alpha1Interface {
+	return c.netattachV1alpha1
+}
+
 // NetworkingV1beta1 retrieves the NetworkingV1beta1Client
 func (c *Clientset) NetworkingV1beta1() networkingv1beta1.NetworkingV1beta1Interface {
 	return c.networkingV1beta1
 }
 
+// OpsV1alpha1 retrieves the OpsV1alpha1Client
+func (c *Clientset) OpsV1alpha1() opsv1alpha1.OpsV1alpha1Interface {
+	return c.opsV1alpha1
+}
+
+// SecurityV1alpha1 retrieves the SecurityV1alpha1Client
+func (c *Clientset) SecurityV1alpha1() securityv1alpha1.SecurityV1alpha1Interface {
+	return c.securityV1alpha1
+}
+
 // SystemV1beta1 retrieves the SystemV1beta1Client
 func (c *Clientset) SystemV1beta1() systemv1beta1.SystemV1beta1Interface {
 	return c.systemV1beta1
@@ -83,10 +115,26 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 	if err != nil {
 		return nil, err
 	}
+	cs.ipamV1alpha1, err = ipamv1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.netattachV1alpha1, err = netattachv1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
 	cs.networkingV1beta1, err = networkingv1beta1.NewForConfig(&configShallowCopy)
 	if err != nil {
 		return nil, err
 	}
+	cs.opsV1alpha1, err = opsv1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.securityV1alpha1, err = securityv1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
 	cs.systemV1beta1, err = systemv1beta1.NewForConfig(&configShallowCopy)
 	if err != nil {
 		return nil, err
@@ -104,7 +152,11 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 func NewForConfigOrDie(c *rest.Config) *Clientset {
 	var cs Clientset
 	cs.clusterinformationV1beta1 = clusterinformationv1beta1.NewForConfigOrDie(c)
+	cs.ipamV1alpha1 = ipamv1alpha1.NewForConfigOrDie(c)
+	cs.netattachV1alpha1 = netattachv1alpha1.NewForConfigOrDie(c)
 	cs.networkingV1beta1 = networkingv1beta1.NewForConfigOrDie(c)
+	cs.opsV1alpha1 = opsv1alpha1.NewForConfigOrDie(c)
+	cs.securityV1alpha1 = securityv1alpha1.NewForConfigOrDie(c)
 	cs.systemV1beta1 = systemv1beta1.NewForConfigOrDie(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClientForConfigOrDie(c)
@@ -115,7 +167,11 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.clusterinformationV1beta1 = clusterinformationv1beta1.New(c)
+	cs.ipamV1alpha1 = ipamv1alpha1.New(c)
+	cs.netattachV1alpha1 = netattachv1alpha1.New(c)
 	cs.networkingV1beta1 = networkingv1beta1.New(c)
+	cs.opsV1alpha1 = opsv1alpha1.New(c)
+	cs.securityV1alpha1 = securityv1alpha1.New(c)
 	cs.systemV1beta1 = systemv1beta1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
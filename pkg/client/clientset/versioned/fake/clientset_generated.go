@@ -20,8 +20,16 @@ import (
 	clientset "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned"
 	clusterinformationv1beta1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/clusterinformation/v1beta1"
 	fakeclusterinformationv1beta1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/clusterinformation/v1beta1/fake"
+	ipamv1alpha1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/ipam/v1alpha1"
+	fakeipamv1alpha1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/ipam/v1alpha1/fake"
+	netattachv1alpha1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/netattach/v1alpha1"
+	fakenetattachv1alpha1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/netattach/v1alpha1/fake"
 	networkingv1beta1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/networking/v1beta1"
 	fakenetworkingv1beta1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/networking/v1beta1/fake"
+	opsv1alpha1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/ops/v1alpha1"
+	fakeopsv1alpha1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/ops/v1alpha1/fake"
+	securityv1alpha1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/security/v1alpha1"
+	fakesecurityv1alpha1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/security/v1alpha1/fake"
 	systemv1beta1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/system/v1beta1"
 	fakesystemv1beta1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/system/v1beta1/fake"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -83,11 +91,31 @@ func (c *Clientset) ClusterinformationV1beta1() clusterinformationv1beta1.Cluste
 	return &fakeclusterinformationv1beta1.FakeClusterinformationV1beta1{Fake: &c.Fake}
 }
 
+// IpamV1alpha1 retrieves the IpamV1alpha1Client
+func (c *Clientset) IpamV1alpha1() ipamv1alpha1.IpamV1alpha1Interface {
+	return &fakeipamv1alpha1.FakeIpamV1alpha1{Fake: &c.Fake}
+}
+
+// NetattachV1alpha1 retrieves the NetattachV1alpha1Client
+func (c *Clientset) NetattachV1alpha1() netattachv1alpha1.NetattachV1alpha1Interface {
+	return &fakenetattachv1alpha1.FakeNetattachV1alpha1{Fake: &c.Fake}
+}
+
 // NetworkingV1beta1 retrieves the NetworkingV1beta1Client
 func (c *Clientset) NetworkingV1beta1() networkingv1beta1.NetworkingV1beta1Interface {
 	return &fakenetworkingv1beta1.FakeNetworkingV1beta1{Fake: &c.Fake}
 }
 
+// OpsV1alpha1 retrieves the OpsV1alpha1Client
+func (c *Clientset) OpsV1alpha1() opsv1alpha1.OpsV1alpha1Interface {
+	return &fakeopsv1alpha1.FakeOpsV1alpha1{Fake: &c.Fake}
+}
+
+// SecurityV1alpha1 retrieves the SecurityV1alpha1Client
+func (c *Clientset) SecurityV1alpha1() securityv1alpha1.SecurityV1alpha1Interface {
+	return &fakesecurityv1alpha1.FakeSecurityV1alpha1{Fake: &c.Fake}
+}
+
 // SystemV1beta1 retrieves the SystemV1beta1Client
 func (c *Clientset) SystemV1beta1() systemv1beta1.SystemV1beta1Interface {
 	return &fakesystemv1beta1.FakeSystemV1beta1{Fake: &c.Fake}
@@ -34,10 +34,26 @@ func (c *FakeNetworkingV1beta1) AppliedToGroups() v1beta1.AppliedToGroupInterfac
 	return &FakeAppliedToGroups{c}
 }
 
+func (c *FakeNetworkingV1beta1) ConnectivityQueries() v1beta1.ConnectivityQueryInterface {
+	return &FakeConnectivityQueries{c}
+}
+
+func (c *FakeNetworkingV1beta1) EndpointQueries(namespace string) v1beta1.EndpointQueryInterface {
+	return &FakeEndpointQueries{c, namespace}
+}
+
 func (c *FakeNetworkingV1beta1) NetworkPolicies(namespace string) v1beta1.NetworkPolicyInterface {
 	return &FakeNetworkPolicies{c, namespace}
 }
 
+func (c *FakeNetworkingV1beta1) NetworkPolicyStatuses() v1beta1.NetworkPolicyStatusInterface {
+	return &FakeNetworkPolicyStatuses{c}
+}
+
+func (c *FakeNetworkingV1beta1) NodeStatsSummaries() v1beta1.NodeStatsSummaryInterface {
+	return &FakeNodeStatsSummaries{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeNetworkingV1beta1) RESTClient() rest.Interface {
@@ -20,4 +20,12 @@ type AddressGroupExpansion interface{}
 
 type AppliedToGroupExpansion interface{}
 
+type ConnectivityQueryExpansion interface{}
+
+type EndpointQueryExpansion interface{}
+
 type NetworkPolicyExpansion interface{}
+
+type NetworkPolicyStatusExpansion interface{}
+
+type NodeStatsSummaryExpansion interface{}
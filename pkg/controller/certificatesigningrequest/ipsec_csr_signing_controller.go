@@ -0,0 +1,494 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certificatesigningrequest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	certificatesinformers "k8s.io/client-go/informers/certificates/v1beta1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	certificateslisters "k8s.io/client-go/listers/certificates/v1beta1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+)
+
+const (
+	// IPSecCSRSignerName is the signer name of the CertificateSigningRequests
+	// created by antrea-agent for the certificates of its IPSec tunnels. It
+	// prevents kube-controller-manager from signing these requests with the
+	// cluster CA. Note that signer names are only honored by K8s 1.18+.
+	IPSecCSRSignerName = "antrea.tanzu.vmware.com/antrea-agent-ipsec-tunnel"
+	// IPSecCSRLabelKey is the label set on the CertificateSigningRequests
+	// created by antrea-agent for the certificates of its IPSec tunnels.
+	IPSecCSRLabelKey = "antrea.tanzu.vmware.com/ipsec-tunnel"
+
+	// The namespace and name of the Secret that will hold the CA certificate
+	// and key signing the IPSec certificates, and of the ConfigMap that will
+	// publish the CA certificate to antrea-agent.
+	IPSecCANamespace     = "kube-system"
+	IPSecCASecretName    = "antrea-ipsec-ca"
+	IPSecCAConfigMapName = "antrea-ipsec-ca"
+	IPSecCAConfigMapKey  = "ca.crt"
+
+	controllerName = "IPSecCSRSigningController"
+	// How long to wait before retrying the processing of a CertificateSigningRequest.
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = 300 * time.Second
+	// Default number of workers processing a CertificateSigningRequest.
+	defaultWorkers = 2
+
+	// agentUsername is the only user allowed to request IPSec certificates,
+	// i.e. the ServiceAccount of antrea-agent.
+	agentUsername           = "system:serviceaccount:kube-system:antrea-agent"
+	agentNamespace          = "kube-system"
+	agentServiceAccountName = "antrea-agent"
+	// The extra info of the requester of a CertificateSigningRequest which
+	// identifies the Pod a bound ServiceAccount token was issued for.
+	podNameExtraKey = "authentication.kubernetes.io/pod-name"
+	podUIDExtraKey  = "authentication.kubernetes.io/pod-uid"
+	// The validity of the certificates issued to the Nodes. antrea-agent
+	// rotates its certificate before it expires.
+	certificateValidity = 365 * 24 * time.Hour
+	caCommonName        = "antrea-ipsec-ca"
+	// Clock skew tolerated between antrea-controller and antrea-agent.
+	backdate = 5 * time.Minute
+)
+
+// allowedUsages are the key usages which can be requested for an IPSec
+// certificate.
+var allowedUsages = map[certificatesv1beta1.KeyUsage]bool{
+	certificatesv1beta1.UsageDigitalSignature: true,
+	certificatesv1beta1.UsageKeyEncipherment:  true,
+	certificatesv1beta1.UsageServerAuth:       true,
+	certificatesv1beta1.UsageClientAuth:       true,
+	certificatesv1beta1.UsageIPsecTunnel:      true,
+}
+
+// IPSecCSRSigningController is responsible for approving and signing the
+// CertificateSigningRequests created by antrea-agent for the certificates used
+// to authenticate the IPSec tunnels between Nodes, with a CA it maintains in a
+// Secret.
+type IPSecCSRSigningController struct {
+	client           clientset.Interface
+	csrLister        certificateslisters.CertificateSigningRequestLister
+	csrListerSynced  cache.InformerSynced
+	nodeLister       corelisters.NodeLister
+	nodeListerSynced cache.InformerSynced
+	queue            workqueue.RateLimitingInterface
+
+	// The CA is loaded or created when the controller starts.
+	caCert *x509.Certificate
+	caKey  crypto.Signer
+}
+
+// NewIPSecCSRSigningController instantiates a new IPSecCSRSigningController
+// which will process the CertificateSigningRequest events.
+func NewIPSecCSRSigningController(
+	client clientset.Interface,
+	csrInformer certificatesinformers.CertificateSigningRequestInformer,
+	nodeInformer coreinformers.NodeInformer) *IPSecCSRSigningController {
+	c := &IPSecCSRSigningController{
+		client:           client,
+		csrLister:        csrInformer.Lister(),
+		csrListerSynced:  csrInformer.Informer().HasSynced,
+		nodeLister:       nodeInformer.Lister(),
+		nodeListerSynced: nodeInformer.Informer().HasSynced,
+		queue:            workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "ipsecCSR"),
+	}
+	csrInformer.Informer().AddEventHandler(
+		cache.FilteringResourceEventHandler{
+			FilterFunc: isIPSecCSR,
+			Handler: cache.ResourceEventHandlerFuncs{
+				AddFunc: c.enqueueCSR,
+				UpdateFunc: func(_, cur interface{}) {
+					c.enqueueCSR(cur)
+				},
+			},
+		},
+	)
+	return c
+}
+
+func isIPSecCSR(obj interface{}) bool {
+	csr, ok := obj.(*certificatesv1beta1.CertificateSigningRequest)
+	if !ok {
+		return false
+	}
+	return csr.Labels[IPSecCSRLabelKey] == "true"
+}
+
+func (c *IPSecCSRSigningController) enqueueCSR(obj interface{}) {
+	csr := obj.(*certificatesv1beta1.CertificateSigningRequest)
+	c.queue.Add(csr.Name)
+}
+
+// Run loads or creates the CA, publishes its certificate, then starts
+// defaultWorkers workers processing the CertificateSigningRequests. It blocks
+// until stopCh is closed.
+func (c *IPSecCSRSigningController) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+
+	klog.Infof("Starting %s", controllerName)
+	defer klog.Infof("Shutting down %s", controllerName)
+
+	if !cache.WaitForNamedCacheSync(controllerName, stopCh, c.csrListerSynced, c.nodeListerSynced) {
+		return
+	}
+
+	// The workers cannot sign anything until the CA is available.
+	if err := wait.PollImmediateUntil(minRetryDelay, func() (bool, error) {
+		if err := c.syncCA(); err != nil {
+			klog.Errorf("Failed to sync the IPSec CA: %v", err)
+			return false, nil
+		}
+		return true, nil
+	}, stopCh); err != nil {
+		return
+	}
+
+	for i := 0; i < defaultWorkers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+	<-stopCh
+}
+
+// syncCA loads the CA from the Secret, or generates a self-signed CA and saves
+// it to the Secret if the Secret has no valid CA, and then publishes the CA
+// certificate to the ConfigMap.
+func (c *IPSecCSRSigningController) syncCA() error {
+	secret, err := c.client.CoreV1().Secrets(IPSecCANamespace).Get(IPSecCASecretName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting Secret %s: %v", IPSecCASecretName, err)
+	}
+	caCert, caKey, err := parseCA(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		klog.Infof("Generating a new IPSec CA as Secret %s has no valid CA: %v", IPSecCASecretName, err)
+		certPEM, keyPEM, err := generateCA()
+		if err != nil {
+			return err
+		}
+		if caCert, caKey, err = parseCA(certPEM, keyPEM); err != nil {
+			return err
+		}
+		secret.Data = map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		}
+		if _, err := c.client.CoreV1().Secrets(IPSecCANamespace).Update(secret); err != nil {
+			return fmt.Errorf("error updating Secret %s: %v", IPSecCASecretName, err)
+		}
+	}
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: cert.CertificateBlockType, Bytes: caCert.Raw})
+	caConfigMap, err := c.client.CoreV1().ConfigMaps(IPSecCANamespace).Get(IPSecCAConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting ConfigMap %s: %v", IPSecCAConfigMapName, err)
+	}
+	if caConfigMap.Data[IPSecCAConfigMapKey] != string(caPEM) {
+		caConfigMap.Data = map[string]string{
+			IPSecCAConfigMapKey: string(caPEM),
+		}
+		if _, err := c.client.CoreV1().ConfigMaps(IPSecCANamespace).Update(caConfigMap); err != nil {
+			return fmt.Errorf("error updating ConfigMap %s: %v", IPSecCAConfigMapName, err)
+		}
+	}
+	c.caCert = caCert
+	c.caKey = caKey
+	return nil
+}
+
+// parseCA parses the PEM encoded CA certificate and key, and checks that the
+// certificate is an unexpired CA certificate.
+func parseCA(certPEM, keyPEM []byte) (*x509.Certificate, crypto.Signer, error) {
+	if len(certPEM) == 0 || len(keyPEM) == 0 {
+		return nil, nil, fmt.Errorf("CA certificate or key is missing")
+	}
+	certs, err := cert.ParseCertsPEM(certPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing CA certificate: %v", err)
+	}
+	key, err := keyutil.ParsePrivateKeyPEM(keyPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing CA key: %v", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("CA key is not a signer")
+	}
+	if !certs[0].IsCA {
+		return nil, nil, fmt.Errorf("certificate is not a CA certificate")
+	}
+	if time.Now().After(certs[0].NotAfter) {
+		return nil, nil, fmt.Errorf("CA certificate expired at %v", certs[0].NotAfter)
+	}
+	return certs[0], signer, nil
+}
+
+// generateCA generates a self-signed CA and returns its PEM encoded certificate
+// and key.
+func generateCA() ([]byte, []byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating CA key: %v", err)
+	}
+	caCert, err := cert.NewSelfSignedCACert(cert.Config{CommonName: caCommonName}, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating CA certificate: %v", err)
+	}
+	keyPEM, err := keyutil.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error encoding CA key: %v", err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: cert.CertificateBlockType, Bytes: caCert.Raw})
+	return certPEM, keyPEM, nil
+}
+
+func (c *IPSecCSRSigningController) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *IPSecCSRSigningController) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.syncCSR(key.(string)); err == nil {
+		c.queue.Forget(key)
+	} else {
+		klog.Errorf("Error syncing CertificateSigningRequest %s, requeuing it: %v", key, err)
+		c.queue.AddRateLimited(key)
+	}
+	return true
+}
+
+// syncCSR approves or denies the CertificateSigningRequest if no decision has
+// been made, and signs it once it is approved.
+func (c *IPSecCSRSigningController) syncCSR(name string) error {
+	csr, err := c.csrLister.Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if len(csr.Status.Certificate) != 0 {
+		// Already signed.
+		return nil
+	}
+	approved, denied := getCertApprovalCondition(&csr.Status)
+	if denied {
+		return nil
+	}
+	csr = csr.DeepCopy()
+
+	x509CSR, err := c.validateCSR(csr)
+	if _, ok := err.(*transientError); ok {
+		return err
+	}
+	if !approved {
+		if err != nil {
+			klog.Infof("Denying CertificateSigningRequest %s: %v", name, err)
+			csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1beta1.CertificateSigningRequestCondition{
+				Type:           certificatesv1beta1.CertificateDenied,
+				Reason:         "AntreaIPSecCSRInvalid",
+				Message:        err.Error(),
+				LastUpdateTime: metav1.Now(),
+			})
+			_, err = c.client.CertificatesV1beta1().CertificateSigningRequests().UpdateApproval(csr)
+			return err
+		}
+		csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1beta1.CertificateSigningRequestCondition{
+			Type:           certificatesv1beta1.CertificateApproved,
+			Reason:         "AntreaIPSecCSRApproved",
+			Message:        "Automatically approved by " + controllerName,
+			LastUpdateTime: metav1.Now(),
+		})
+		// The update event of the approval will trigger the signing.
+		_, err = c.client.CertificatesV1beta1().CertificateSigningRequests().UpdateApproval(csr)
+		return err
+	}
+	if err != nil {
+		// The request was approved by someone else but cannot be signed.
+		klog.Errorf("Not signing approved CertificateSigningRequest %s: %v", name, err)
+		return nil
+	}
+
+	certPEM, err := c.signCSR(x509CSR, csr.Spec.Usages)
+	if err != nil {
+		return err
+	}
+	csr.Status.Certificate = certPEM
+	if _, err := c.client.CertificatesV1beta1().CertificateSigningRequests().UpdateStatus(csr); err != nil {
+		return fmt.Errorf("error updating the status of CertificateSigningRequest %s: %v", name, err)
+	}
+	klog.Infof("Signed IPSec certificate for CertificateSigningRequest %s", name)
+	return nil
+}
+
+// transientError is returned by validateCSR when the request could not be
+// validated, and must be validated again later instead of being denied.
+type transientError struct {
+	err error
+}
+
+func (e *transientError) Error() string {
+	return e.err.Error()
+}
+
+// validateCSR checks that the CertificateSigningRequest was created by the
+// antrea-agent running on the Node named by its common name, and returns the
+// parsed request.
+func (c *IPSecCSRSigningController) validateCSR(csr *certificatesv1beta1.CertificateSigningRequest) (*x509.CertificateRequest, error) {
+	if csr.Spec.Username != agentUsername {
+		return nil, fmt.Errorf("requester %s is not allowed", csr.Spec.Username)
+	}
+	for _, usage := range csr.Spec.Usages {
+		if !allowedUsages[usage] {
+			return nil, fmt.Errorf("usage %s is not allowed", usage)
+		}
+	}
+	block, _ := pem.Decode(csr.Spec.Request)
+	if block == nil || block.Type != cert.CertificateRequestBlockType {
+		return nil, fmt.Errorf("request is not a PEM encoded certificate request")
+	}
+	x509CSR, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing certificate request: %v", err)
+	}
+	if err := x509CSR.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("invalid signature of certificate request: %v", err)
+	}
+	if len(x509CSR.DNSNames) != 0 || len(x509CSR.IPAddresses) != 0 || len(x509CSR.EmailAddresses) != 0 || len(x509CSR.URIs) != 0 {
+		return nil, fmt.Errorf("subject alternative names are not allowed")
+	}
+	nodeName := x509CSR.Subject.CommonName
+	if _, err := c.nodeLister.Get(nodeName); err != nil {
+		return nil, fmt.Errorf("common name %s is not a valid Node: %v", nodeName, err)
+	}
+	// All the antrea-agents share the same ServiceAccount, so the Node of the
+	// requester is the one of the Pod its bound token was issued for.
+	requesterNodeName, err := c.getRequesterNodeName(csr)
+	if err != nil {
+		return nil, err
+	}
+	if requesterNodeName != nodeName {
+		return nil, fmt.Errorf("common name %s does not match the Node %s of the requester", nodeName, requesterNodeName)
+	}
+	return x509CSR, nil
+}
+
+// getRequesterNodeName returns the Node of the antrea-agent Pod identified by
+// the extra info of the requester of the CertificateSigningRequest. The Pod is
+// read from the API instead of an informer, as a request denied because the
+// informer is lagging behind could not be approved later.
+func (c *IPSecCSRSigningController) getRequesterNodeName(csr *certificatesv1beta1.CertificateSigningRequest) (string, error) {
+	podNames, podUIDs := csr.Spec.Extra[podNameExtraKey], csr.Spec.Extra[podUIDExtraKey]
+	if len(podNames) != 1 || len(podUIDs) != 1 {
+		return "", fmt.Errorf("requester is not authenticated with a token bound to a Pod")
+	}
+	pod, err := c.client.CoreV1().Pods(agentNamespace).Get(podNames[0], metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return "", fmt.Errorf("requester Pod %s not found", podNames[0])
+		}
+		return "", &transientError{fmt.Errorf("error getting requester Pod %s: %v", podNames[0], err)}
+	}
+	if string(pod.UID) != podUIDs[0] {
+		return "", fmt.Errorf("requester Pod %s has UID %s instead of %s", pod.Name, pod.UID, podUIDs[0])
+	}
+	if pod.Spec.ServiceAccountName != agentServiceAccountName {
+		return "", fmt.Errorf("requester Pod %s does not run with ServiceAccount %s", pod.Name, agentServiceAccountName)
+	}
+	if pod.Spec.NodeName == "" {
+		return "", fmt.Errorf("requester Pod %s is not scheduled to a Node", pod.Name)
+	}
+	return pod.Spec.NodeName, nil
+}
+
+// signCSR issues a certificate for the request with the CA, and returns it PEM
+// encoded.
+func (c *IPSecCSRSigningController) signCSR(x509CSR *x509.CertificateRequest, usages []certificatesv1beta1.KeyUsage) ([]byte, error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
+	if err != nil {
+		return nil, fmt.Errorf("error generating serial number: %v", err)
+	}
+	now := time.Now()
+	notAfter := now.Add(certificateValidity)
+	if notAfter.After(c.caCert.NotAfter) {
+		notAfter = c.caCert.NotAfter
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: x509CSR.Subject.CommonName},
+		NotBefore:             now.Add(-backdate),
+		NotAfter:              notAfter,
+		BasicConstraintsValid: true,
+	}
+	for _, usage := range usages {
+		switch usage {
+		case certificatesv1beta1.UsageDigitalSignature:
+			template.KeyUsage |= x509.KeyUsageDigitalSignature
+		case certificatesv1beta1.UsageKeyEncipherment:
+			template.KeyUsage |= x509.KeyUsageKeyEncipherment
+		case certificatesv1beta1.UsageServerAuth:
+			template.ExtKeyUsage = append(template.ExtKeyUsage, x509.ExtKeyUsageServerAuth)
+		case certificatesv1beta1.UsageClientAuth:
+			template.ExtKeyUsage = append(template.ExtKeyUsage, x509.ExtKeyUsageClientAuth)
+		case certificatesv1beta1.UsageIPsecTunnel:
+			template.ExtKeyUsage = append(template.ExtKeyUsage, x509.ExtKeyUsageIPSECTunnel)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, c.caCert, x509CSR.PublicKey, c.caKey)
+	if err != nil {
+		return nil, fmt.Errorf("error signing certificate: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: cert.CertificateBlockType, Bytes: der}), nil
+}
+
+// getCertApprovalCondition returns whether the CertificateSigningRequest has
+// been approved or denied.
+func getCertApprovalCondition(status *certificatesv1beta1.CertificateSigningRequestStatus) (approved bool, denied bool) {
+	for _, c := range status.Conditions {
+		if c.Type == certificatesv1beta1.CertificateApproved {
+			approved = true
+		}
+		if c.Type == certificatesv1beta1.CertificateDenied {
+			denied = true
+		}
+	}
+	return
+}
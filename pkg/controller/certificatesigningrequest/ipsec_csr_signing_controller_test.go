@@ -0,0 +1,213 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certificatesigningrequest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/cert"
+)
+
+type testController struct {
+	*IPSecCSRSigningController
+	client     *fake.Clientset
+	csrIndexer cache.Indexer
+}
+
+func newTestController(t *testing.T) *testController {
+	client := fake.NewSimpleClientset(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: IPSecCANamespace, Name: IPSecCASecretName}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: IPSecCANamespace, Name: IPSecCAConfigMapName}},
+		newAgentPod("antrea-agent-1", "node1"),
+		newAgentPod("antrea-agent-2", "node2"),
+	)
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	csrInformer := informerFactory.Certificates().V1beta1().CertificateSigningRequests()
+	nodeInformer := informerFactory.Core().V1().Nodes()
+	nodeInformer.Informer().GetIndexer().Add(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}})
+	nodeInformer.Informer().GetIndexer().Add(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node2"}})
+	c := NewIPSecCSRSigningController(client, csrInformer, nodeInformer)
+	require.NoError(t, c.syncCA())
+	return &testController{c, client, csrInformer.Informer().GetIndexer()}
+}
+
+// addCSR creates the CertificateSigningRequest and adds it to the informer
+// store, as the informers are not started.
+func (c *testController) addCSR(t *testing.T, csr *certificatesv1beta1.CertificateSigningRequest) {
+	_, err := c.client.CertificatesV1beta1().CertificateSigningRequests().Create(csr)
+	require.NoError(t, err)
+	require.NoError(t, c.csrIndexer.Add(csr))
+}
+
+// refreshCSR copies the CertificateSigningRequest from the clientset to the
+// informer store, and returns it.
+func (c *testController) refreshCSR(t *testing.T, name string) *certificatesv1beta1.CertificateSigningRequest {
+	csr, err := c.client.CertificatesV1beta1().CertificateSigningRequests().Get(name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NoError(t, c.csrIndexer.Update(csr))
+	return csr
+}
+
+func newAgentPod(name, nodeName string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: agentNamespace, Name: name, UID: types.UID(name + "-uid")},
+		Spec:       corev1.PodSpec{NodeName: nodeName, ServiceAccountName: agentServiceAccountName},
+	}
+}
+
+// newCSR returns a CertificateSigningRequest created by the antrea-agent Pod
+// running on node1.
+func newCSR(t *testing.T, name, commonName, username string) *certificatesv1beta1.CertificateSigningRequest {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	request, err := cert.MakeCSR(key, &pkix.Name{CommonName: commonName}, nil, nil)
+	require.NoError(t, err)
+	return &certificatesv1beta1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{IPSecCSRLabelKey: "true"}},
+		Spec: certificatesv1beta1.CertificateSigningRequestSpec{
+			Request:  request,
+			Username: username,
+			Extra: map[string]certificatesv1beta1.ExtraValue{
+				podNameExtraKey: {"antrea-agent-1"},
+				podUIDExtraKey:  {"antrea-agent-1-uid"},
+			},
+			Usages: []certificatesv1beta1.KeyUsage{
+				certificatesv1beta1.UsageDigitalSignature,
+				certificatesv1beta1.UsageKeyEncipherment,
+				certificatesv1beta1.UsageIPsecTunnel,
+			},
+		},
+	}
+}
+
+func TestSyncCA(t *testing.T) {
+	c := newTestController(t)
+	secret, err := c.client.CoreV1().Secrets(IPSecCANamespace).Get(IPSecCASecretName, metav1.GetOptions{})
+	require.NoError(t, err)
+	configMap, err := c.client.CoreV1().ConfigMaps(IPSecCANamespace).Get(IPSecCAConfigMapName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, string(secret.Data[corev1.TLSCertKey]), configMap.Data[IPSecCAConfigMapKey])
+	assert.True(t, c.caCert.IsCA)
+
+	// The CA saved in the Secret is reused.
+	caCert := c.caCert
+	require.NoError(t, c.syncCA())
+	assert.Equal(t, caCert.Raw, c.caCert.Raw)
+}
+
+func TestSyncCSR(t *testing.T) {
+	c := newTestController(t)
+	c.addCSR(t, newCSR(t, "csr1", "node1", agentUsername))
+
+	// The first sync approves the request, the second one signs it.
+	require.NoError(t, c.syncCSR("csr1"))
+	csr := c.refreshCSR(t, "csr1")
+	approved, denied := getCertApprovalCondition(&csr.Status)
+	assert.True(t, approved)
+	assert.False(t, denied)
+	assert.Empty(t, csr.Status.Certificate)
+
+	require.NoError(t, c.syncCSR("csr1"))
+	csr = c.refreshCSR(t, "csr1")
+	certs, err := cert.ParseCertsPEM(csr.Status.Certificate)
+	require.NoError(t, err)
+	assert.Equal(t, "node1", certs[0].Subject.CommonName)
+	assert.ElementsMatch(t, []x509.ExtKeyUsage{x509.ExtKeyUsageIPSECTunnel}, certs[0].ExtKeyUsage)
+	roots := x509.NewCertPool()
+	roots.AddCert(c.caCert)
+	_, err = certs[0].Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}})
+	assert.NoError(t, err)
+}
+
+func TestSyncInvalidCSR(t *testing.T) {
+	tests := []struct {
+		name string
+		csr  *certificatesv1beta1.CertificateSigningRequest
+	}{
+		{
+			name: "unknown-node",
+			csr:  newCSR(t, "csr1", "node3", agentUsername),
+		},
+		{
+			name: "node-of-other-requester",
+			csr:  newCSR(t, "csr1", "node2", agentUsername),
+		},
+		{
+			name: "requester-without-bound-token",
+			csr: func() *certificatesv1beta1.CertificateSigningRequest {
+				csr := newCSR(t, "csr1", "node1", agentUsername)
+				csr.Spec.Extra = nil
+				return csr
+			}(),
+		},
+		{
+			name: "requester-pod-uid-mismatch",
+			csr: func() *certificatesv1beta1.CertificateSigningRequest {
+				csr := newCSR(t, "csr1", "node1", agentUsername)
+				csr.Spec.Extra[podUIDExtraKey] = certificatesv1beta1.ExtraValue{"antrea-agent-2-uid"}
+				return csr
+			}(),
+		},
+		{
+			name: "unauthorized-requester",
+			csr:  newCSR(t, "csr1", "node1", "system:serviceaccount:default:default"),
+		},
+		{
+			name: "disallowed-usage",
+			csr: func() *certificatesv1beta1.CertificateSigningRequest {
+				csr := newCSR(t, "csr1", "node1", agentUsername)
+				csr.Spec.Usages = append(csr.Spec.Usages, certificatesv1beta1.UsageCertSign)
+				return csr
+			}(),
+		},
+		{
+			name: "invalid-request",
+			csr: func() *certificatesv1beta1.CertificateSigningRequest {
+				csr := newCSR(t, "csr1", "node1", agentUsername)
+				csr.Spec.Request = []byte("invalid")
+				return csr
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestController(t)
+			c.addCSR(t, tt.csr)
+			require.NoError(t, c.syncCSR("csr1"))
+			csr := c.refreshCSR(t, "csr1")
+			approved, denied := getCertApprovalCondition(&csr.Status)
+			assert.False(t, approved)
+			assert.True(t, denied)
+
+			// A denied request is never signed.
+			require.NoError(t, c.syncCSR("csr1"))
+			csr = c.refreshCSR(t, "csr1")
+			assert.Empty(t, csr.Status.Certificate)
+		})
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	appsinformers "k8s.io/client-go/informers/apps/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+
+	ipamv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/ipam/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned"
+	ipaminformers "github.com/vmware-tanzu/antrea/pkg/client/informers/externalversions/ipam/v1alpha1"
+	ipamlisters "github.com/vmware-tanzu/antrea/pkg/client/listers/ipam/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/ipam/poolallocator"
+)
+
+const (
+	controllerName = "AntreaIPAMController"
+	// How long to wait before retrying the processing of a StatefulSet.
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = 300 * time.Second
+	// Default number of workers processing a StatefulSet.
+	defaultWorkers = 2
+	// Interval of releasing the IPs allocated to Pods which no longer exist.
+	garbageCollectionInterval = 10 * time.Minute
+)
+
+// AntreaIPAMController reserves IPs in the IPPool of their Namespace for the Pods of the
+// StatefulSets, so that a StatefulSet Pod keeps its IP when it is recreated, and releases the IPs
+// reserved for deleted StatefulSets. It also releases the IPs allocated to the Pods which no
+// longer exist, which antrea-agent could not release, e.g. because the Node was deleted.
+type AntreaIPAMController struct {
+	kubeClient              clientset.Interface
+	crdClient               versioned.Interface
+	namespaceLister         corelisters.NamespaceLister
+	namespaceListerSynced   cache.InformerSynced
+	statefulSetLister       appslisters.StatefulSetLister
+	statefulSetListerSynced cache.InformerSynced
+	podLister               corelisters.PodLister
+	podListerSynced         cache.InformerSynced
+	ipPoolLister            ipamlisters.IPPoolLister
+	ipPoolListerSynced      cache.InformerSynced
+	queue                   workqueue.RateLimitingInterface
+}
+
+// NewAntreaIPAMController instantiates a new AntreaIPAMController which will process the
+// StatefulSet and Namespace events.
+func NewAntreaIPAMController(
+	kubeClient clientset.Interface,
+	crdClient versioned.Interface,
+	namespaceInformer coreinformers.NamespaceInformer,
+	statefulSetInformer appsinformers.StatefulSetInformer,
+	podInformer coreinformers.PodInformer,
+	ipPoolInformer ipaminformers.IPPoolInformer) *AntreaIPAMController {
+	c := &AntreaIPAMController{
+		kubeClient:              kubeClient,
+		crdClient:               crdClient,
+		namespaceLister:         namespaceInformer.Lister(),
+		namespaceListerSynced:   namespaceInformer.Informer().HasSynced,
+		statefulSetLister:       statefulSetInformer.Lister(),
+		statefulSetListerSynced: statefulSetInformer.Informer().HasSynced,
+		podLister:               podInformer.Lister(),
+		podListerSynced:         podInformer.Informer().HasSynced,
+		ipPoolLister:            ipPoolInformer.Lister(),
+		ipPoolListerSynced:      ipPoolInformer.Informer().HasSynced,
+		queue:                   workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "antreaIPAM"),
+	}
+	statefulSetInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: c.enqueueStatefulSet,
+			UpdateFunc: func(_, cur interface{}) {
+				c.enqueueStatefulSet(cur)
+			},
+			DeleteFunc: c.enqueueStatefulSet,
+		},
+	)
+	// The IPs of the StatefulSets are moved to another IPPool when the annotation of their
+	// Namespace changes.
+	namespaceInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(old, cur interface{}) {
+				oldNamespace, curNamespace := old.(*corev1.Namespace), cur.(*corev1.Namespace)
+				if oldNamespace.Annotations[ipamv1alpha1.IPPoolAnnotationKey] != curNamespace.Annotations[ipamv1alpha1.IPPoolAnnotationKey] {
+					c.enqueueNamespaceStatefulSets(curNamespace.Name)
+				}
+			},
+		},
+	)
+	return c
+}
+
+func (c *AntreaIPAMController) enqueueStatefulSet(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		klog.Errorf("Failed to get key of StatefulSet %v: %v", obj, err)
+		return
+	}
+	c.queue.Add(key)
+}
+
+func (c *AntreaIPAMController) enqueueNamespaceStatefulSets(namespace string) {
+	statefulSets, err := c.statefulSetLister.StatefulSets(namespace).List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list StatefulSets of Namespace %s: %v", namespace, err)
+		return
+	}
+	for _, statefulSet := range statefulSets {
+		c.enqueueStatefulSet(statefulSet)
+	}
+}
+
+// Run starts defaultWorkers workers processing the StatefulSets, and the periodic garbage
+// collection of the IPs allocated to deleted Pods. It blocks until stopCh is closed.
+func (c *AntreaIPAMController) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+
+	klog.Infof("Starting %s", controllerName)
+	defer klog.Infof("Shutting down %s", controllerName)
+
+	if !cache.WaitForNamedCacheSync(controllerName, stopCh, c.namespaceListerSynced, c.statefulSetListerSynced, c.podListerSynced, c.ipPoolListerSynced) {
+		return
+	}
+
+	for i := 0; i < defaultWorkers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+	go wait.Until(c.garbageCollectPodIPs, garbageCollectionInterval, stopCh)
+	<-stopCh
+}
+
+func (c *AntreaIPAMController) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *AntreaIPAMController) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.syncStatefulSet(key.(string)); err == nil {
+		c.queue.Forget(key)
+	} else {
+		c.queue.AddRateLimited(key)
+		klog.Errorf("Error syncing StatefulSet %s, requeuing. Error: %v", key, err)
+	}
+	return true
+}
+
+// getNamespaceIPPool returns the name of the IPPool of the Namespace, or an empty string if the
+// Namespace has no IPPool.
+func (c *AntreaIPAMController) getNamespaceIPPool(namespace string) (string, error) {
+	ns, err := c.namespaceLister.Get(namespace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return ns.Annotations[ipamv1alpha1.IPPoolAnnotationKey], nil
+}
+
+// hasStatefulSetIPs returns whether the IPPool has IPs reserved for the StatefulSet.
+func hasStatefulSetIPs(pool *ipamv1alpha1.IPPool, namespace, name string) bool {
+	for _, state := range pool.Status.IPAddresses {
+		if owner := state.Owner.StatefulSet; owner != nil && owner.Namespace == namespace && owner.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// syncStatefulSet reserves an IP for each replica of the StatefulSet in the IPPool of its
+// Namespace, and releases the IPs reserved for it in any other IPPool, or in all IPPools if the
+// StatefulSet has been deleted.
+func (c *AntreaIPAMController) syncStatefulSet(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	var poolName string
+	statefulSet, err := c.statefulSetLister.StatefulSets(namespace).Get(name)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if statefulSet != nil {
+		if poolName, err = c.getNamespaceIPPool(namespace); err != nil {
+			return err
+		}
+	}
+
+	pools, err := c.ipPoolLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, pool := range pools {
+		if pool.Name == poolName || !hasStatefulSetIPs(pool, namespace, name) {
+			continue
+		}
+		klog.Infof("Releasing IPs reserved for StatefulSet %s in IPPool %s", key, pool.Name)
+		if err := poolallocator.NewIPPoolAllocator(pool.Name, c.crdClient).ReleaseStatefulSetIPs(namespace, name); err != nil {
+			return err
+		}
+	}
+	if poolName == "" {
+		return nil
+	}
+	return poolallocator.NewIPPoolAllocator(poolName, c.crdClient).ReserveStatefulSetIPs(namespace, name, getReplicas(statefulSet))
+}
+
+func getReplicas(statefulSet *appsv1.StatefulSet) int {
+	if statefulSet.Spec.Replicas == nil {
+		return 1
+	}
+	return int(*statefulSet.Spec.Replicas)
+}
+
+// podExists returns whether the Pod exists. The Pod is looked up with the API if it is not in the
+// informer cache, which can be stale for a Pod just created.
+func (c *AntreaIPAMController) podExists(namespace, name string) (bool, error) {
+	if _, err := c.podLister.Pods(namespace).Get(name); err == nil {
+		return true, nil
+	}
+	_, err := c.kubeClient.CoreV1().Pods(namespace).Get(name, metav1.GetOptions{})
+	if err == nil {
+		return true, nil
+	}
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// garbageCollectPodIPs releases the IPs allocated to the Pods which no longer exist. The IPs of
+// StatefulSet Pods stay reserved for the StatefulSets.
+func (c *AntreaIPAMController) garbageCollectPodIPs() {
+	pools, err := c.ipPoolLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list IPPools: %v", err)
+		return
+	}
+	for _, pool := range pools {
+		allocator := poolallocator.NewIPPoolAllocator(pool.Name, c.crdClient)
+		for _, state := range pool.Status.IPAddresses {
+			podOwner := state.Owner.Pod
+			if podOwner == nil {
+				continue
+			}
+			exists, err := c.podExists(podOwner.Namespace, podOwner.Name)
+			if err != nil {
+				klog.Errorf("Failed to check if Pod %s/%s exists: %v", podOwner.Namespace, podOwner.Name, err)
+				continue
+			}
+			if exists {
+				continue
+			}
+			klog.Infof("Releasing IP %s of deleted Pod %s/%s to IPPool %s", state.IPAddress, podOwner.Namespace, podOwner.Name, pool.Name)
+			if err := allocator.ReleaseContainerIP(podOwner.ContainerID, podOwner.IFName); err != nil {
+				klog.Errorf("Failed to release IP %s to IPPool %s: %v", state.IPAddress, pool.Name, err)
+			}
+		}
+	}
+}
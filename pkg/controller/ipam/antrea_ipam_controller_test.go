@@ -0,0 +1,169 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	ipamv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/ipam/v1alpha1"
+	fakeversioned "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/fake"
+	crdinformers "github.com/vmware-tanzu/antrea/pkg/client/informers/externalversions"
+)
+
+type ipamController struct {
+	*AntreaIPAMController
+	crdClient *fakeversioned.Clientset
+}
+
+func newController(t *testing.T, k8sObjects []runtime.Object, pools ...*ipamv1alpha1.IPPool) *ipamController {
+	kubeClient := fake.NewSimpleClientset(k8sObjects...)
+	var crdObjects []runtime.Object
+	for _, pool := range pools {
+		crdObjects = append(crdObjects, pool)
+	}
+	crdClient := fakeversioned.NewSimpleClientset(crdObjects...)
+	informerFactory := informers.NewSharedInformerFactory(kubeClient, 0)
+	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
+	c := NewAntreaIPAMController(
+		kubeClient,
+		crdClient,
+		informerFactory.Core().V1().Namespaces(),
+		informerFactory.Apps().V1().StatefulSets(),
+		informerFactory.Core().V1().Pods(),
+		crdInformerFactory.Ipam().V1alpha1().IPPools())
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	informerFactory.Start(stopCh)
+	crdInformerFactory.Start(stopCh)
+	informerFactory.WaitForCacheSync(stopCh)
+	crdInformerFactory.WaitForCacheSync(stopCh)
+	return &ipamController{c, crdClient}
+}
+
+func newIPPool(name string) *ipamv1alpha1.IPPool {
+	return &ipamv1alpha1.IPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: ipamv1alpha1.IPPoolSpec{IPRanges: []ipamv1alpha1.SubnetIPRange{{
+			IPRange:    ipamv1alpha1.IPRange{CIDR: "10.2.0.0/24"},
+			SubnetInfo: ipamv1alpha1.SubnetInfo{Gateway: "10.2.0.1", PrefixLength: 24},
+		}}},
+	}
+}
+
+func newNamespace(name, poolName string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        name,
+		Annotations: map[string]string{ipamv1alpha1.IPPoolAnnotationKey: poolName},
+	}}
+}
+
+func (c *ipamController) getIPAddresses(t *testing.T, poolName string) []ipamv1alpha1.IPAddressState {
+	pool, err := c.crdClient.IpamV1alpha1().IPPools().Get(poolName, metav1.GetOptions{})
+	require.NoError(t, err)
+	return pool.Status.IPAddresses
+}
+
+// waitForIPPoolCache waits until the IPPool informer cache has the provided number of IPs for the
+// IPPool.
+func (c *ipamController) waitForIPPoolCache(t *testing.T, poolName string, count int) {
+	assert.Eventually(t, func() bool {
+		pool, err := c.ipPoolLister.Get(poolName)
+		return err == nil && len(pool.Status.IPAddresses) == count
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSyncStatefulSet(t *testing.T) {
+	replicas := int32(2)
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "sts1", Namespace: "ns1"},
+		Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+	}
+	c := newController(t, []runtime.Object{newNamespace("ns1", "pool1"), statefulSet}, newIPPool("pool1"), newIPPool("pool2"))
+
+	require.NoError(t, c.syncStatefulSet("ns1/sts1"))
+	addresses := c.getIPAddresses(t, "pool1")
+	require.Len(t, addresses, 2)
+	for i, state := range addresses {
+		assert.Equal(t, ipamv1alpha1.IPAddressPhaseReserved, state.Phase)
+		assert.Equal(t, ipamv1alpha1.StatefulSetOwner{Name: "sts1", Namespace: "ns1", Index: i}, *state.Owner.StatefulSet)
+	}
+
+	// The IPs are moved to the new IPPool of the Namespace.
+	_, err := c.kubeClient.CoreV1().Namespaces().Update(newNamespace("ns1", "pool2"))
+	require.NoError(t, err)
+	c.waitForIPPoolCache(t, "pool1", 2)
+	assert.Eventually(t, func() bool {
+		poolName, _ := c.getNamespaceIPPool("ns1")
+		return poolName == "pool2"
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, c.syncStatefulSet("ns1/sts1"))
+	assert.Empty(t, c.getIPAddresses(t, "pool1"))
+	assert.Len(t, c.getIPAddresses(t, "pool2"), 2)
+
+	// The IPs are released when the StatefulSet is deleted.
+	require.NoError(t, c.kubeClient.AppsV1().StatefulSets("ns1").Delete("sts1", &metav1.DeleteOptions{}))
+	c.waitForIPPoolCache(t, "pool2", 2)
+	assert.Eventually(t, func() bool {
+		_, err := c.statefulSetLister.StatefulSets("ns1").Get("sts1")
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, c.syncStatefulSet("ns1/sts1"))
+	assert.Empty(t, c.getIPAddresses(t, "pool2"))
+}
+
+func TestGarbageCollectPodIPs(t *testing.T) {
+	pool := newIPPool("pool1")
+	pool.Status.IPAddresses = []ipamv1alpha1.IPAddressState{
+		{
+			IPAddress: "10.2.0.2",
+			Phase:     ipamv1alpha1.IPAddressPhaseAllocated,
+			Owner:     ipamv1alpha1.IPAddressOwner{Pod: &ipamv1alpha1.PodOwner{Name: "pod1", Namespace: "ns1", ContainerID: "c1"}},
+		},
+		{
+			IPAddress: "10.2.0.3",
+			Phase:     ipamv1alpha1.IPAddressPhaseAllocated,
+			Owner:     ipamv1alpha1.IPAddressOwner{Pod: &ipamv1alpha1.PodOwner{Name: "pod2", Namespace: "ns1", ContainerID: "c2"}},
+		},
+		{
+			IPAddress: "10.2.0.4",
+			Phase:     ipamv1alpha1.IPAddressPhaseAllocated,
+			Owner: ipamv1alpha1.IPAddressOwner{
+				Pod:         &ipamv1alpha1.PodOwner{Name: "sts1-0", Namespace: "ns1", ContainerID: "c3"},
+				StatefulSet: &ipamv1alpha1.StatefulSetOwner{Name: "sts1", Namespace: "ns1", Index: 0},
+			},
+		},
+	}
+	pod1 := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns1"}}
+	c := newController(t, []runtime.Object{newNamespace("ns1", "pool1"), pod1}, pool)
+
+	c.garbageCollectPodIPs()
+	addresses := c.getIPAddresses(t, "pool1")
+	require.Len(t, addresses, 2)
+	assert.Equal(t, "10.2.0.2", addresses[0].IPAddress)
+	// The IP of the StatefulSet Pod stays reserved.
+	assert.Equal(t, "10.2.0.4", addresses[1].IPAddress)
+	assert.Equal(t, ipamv1alpha1.IPAddressPhaseReserved, addresses[1].Phase)
+	assert.Nil(t, addresses[1].Owner.Pod)
+}
@@ -0,0 +1,205 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leaderelection
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/klog"
+)
+
+const (
+	// LeaderLabelKey is the label set to "true" on the Pod of the antrea-controller replica which is the leader.
+	// The antrea Service selects Pods with this label, so that antrea-agents and the K8s apiserver only connect to
+	// the leader.
+	LeaderLabelKey = "antrea.tanzu.vmware.com/controller-leader"
+	// lockName is the name of the Lease used for leader election.
+	lockName = "antrea-controller"
+	// defaultLockNamespace is the Namespace of the Lease if the Pod's Namespace is unknown.
+	defaultLockNamespace = "kube-system"
+	// labelRetryPeriod is the period of retrying to update the leader label of the Pod.
+	labelRetryPeriod = 2 * time.Second
+)
+
+// Config contains the parameters of leader election.
+type Config struct {
+	// Enable indicates whether leader election is enabled. If not, the replica always acts as the leader.
+	Enable bool
+	// LeaseDuration is the duration that non-leader replicas will wait before trying to acquire leadership.
+	LeaseDuration time.Duration
+	// RenewDeadline is the duration that the leader will retry refreshing leadership before giving up.
+	RenewDeadline time.Duration
+	// RetryPeriod is the duration the replicas should wait between tries of actions.
+	RetryPeriod time.Duration
+}
+
+// Elector elects the leader among antrea-controller replicas. The leader runs the components that must run in a
+// single replica and labels its Pod with LeaderLabelKey, so that it becomes the only endpoint of the antrea Service.
+// The other replicas stand by: they keep their NetworkPolicyController stores warm and take over when the leader
+// fails.
+type Elector struct {
+	client       kubernetes.Interface
+	config       Config
+	podNamespace string
+	podName      string
+	// identity is the unique identity of this replica in leader election.
+	identity string
+
+	// lock protects leader.
+	lock sync.RWMutex
+	// leader is the identity of the current leader.
+	leader string
+}
+
+// NewElector creates a new Elector for the replica running in the provided Pod. podName can be empty if
+// antrea-controller doesn't run in a Pod, in which case no Pod will be labeled.
+func NewElector(client kubernetes.Interface, config Config, podNamespace, podName string) *Elector {
+	identity := podName
+	if identity == "" {
+		identity, _ = os.Hostname()
+	}
+	if podNamespace == "" {
+		podNamespace = defaultLockNamespace
+	}
+	return &Elector{
+		client:       client,
+		config:       config,
+		podNamespace: podNamespace,
+		podName:      podName,
+		identity:     identity,
+	}
+}
+
+// GetLeader returns the identity of the current leader, which is the name of its Pod.
+func (e *Elector) GetLeader() string {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	return e.leader
+}
+
+// IsLeader returns whether this replica is the current leader.
+func (e *Elector) IsLeader() bool {
+	return e.GetLeader() == e.identity
+}
+
+func (e *Elector) setLeader(leader string) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.leader = leader
+}
+
+// Run runs leader election until stopCh is closed. When this replica becomes the leader, it calls onStartedLeading
+// with a channel which is closed when it stops leading, and then labels its Pod. onStartedLeading must not block.
+// If this replica loses leadership before stopCh is closed, the process exits so that it restarts as a standby
+// replica with a clean state.
+func (e *Elector) Run(stopCh <-chan struct{}, onStartedLeading func(stopCh <-chan struct{})) {
+	// The label may be left over by a previous run of the container in the same Pod.
+	if err := e.updateLeaderLabel(false); err != nil {
+		klog.Errorf("Failed to remove leader label from Pod %s/%s: %v", e.podNamespace, e.podName, err)
+	}
+
+	if !e.config.Enable {
+		klog.Info("Leader election is disabled, acting as the leader")
+		e.setLeader(e.identity)
+		e.startLeading(stopCh, onStartedLeading)
+		<-stopCh
+		return
+	}
+
+	lock, err := resourcelock.New(resourcelock.LeasesResourceLock,
+		e.podNamespace,
+		lockName,
+		e.client.CoreV1(),
+		e.client.CoordinationV1(),
+		resourcelock.ResourceLockConfig{Identity: e.identity})
+	if err != nil {
+		klog.Fatalf("Error creating resource lock for leader election: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stopCh
+		cancel()
+	}()
+
+	klog.Infof("Starting leader election with identity %s", e.identity)
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:          lock,
+		LeaseDuration: e.config.LeaseDuration,
+		RenewDeadline: e.config.RenewDeadline,
+		RetryPeriod:   e.config.RetryPeriod,
+		// Release the lease when stopping so that a standby replica can take over immediately.
+		ReleaseOnCancel: true,
+		Name:            lockName,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				klog.Infof("Became the leader of antrea-controller")
+				e.startLeading(ctx.Done(), onStartedLeading)
+			},
+			OnStoppedLeading: func() {
+				select {
+				case <-stopCh:
+					klog.Info("Stopped leading as antrea-controller is stopping")
+					if err := e.updateLeaderLabel(false); err != nil {
+						klog.Errorf("Failed to remove leader label from Pod %s/%s: %v", e.podNamespace, e.podName, err)
+					}
+				default:
+					klog.Fatalf("Lost the leadership of antrea-controller")
+				}
+			},
+			OnNewLeader: func(identity string) {
+				klog.Infof("The leader of antrea-controller is %s", identity)
+				e.setLeader(identity)
+			},
+		},
+	})
+}
+
+// startLeading calls onStartedLeading and then labels the Pod as the leader, retrying until it succeeds or stopCh
+// is closed. The label is set last so that traffic is only received once the leader components are started.
+func (e *Elector) startLeading(stopCh <-chan struct{}, onStartedLeading func(stopCh <-chan struct{})) {
+	onStartedLeading(stopCh)
+	wait.PollImmediateUntil(labelRetryPeriod, func() (bool, error) {
+		if err := e.updateLeaderLabel(true); err != nil {
+			klog.Errorf("Failed to add leader label to Pod %s/%s: %v", e.podNamespace, e.podName, err)
+			return false, nil
+		}
+		return true, nil
+	}, stopCh)
+}
+
+// updateLeaderLabel adds the leader label to the Pod if isLeader is true, otherwise removes it.
+func (e *Elector) updateLeaderLabel(isLeader bool) error {
+	if e.podName == "" {
+		return nil
+	}
+	value := "null"
+	if isLeader {
+		value = `"true"`
+	}
+	patch := fmt.Sprintf(`{"metadata":{"labels":{"%s":%s}}}`, LeaderLabelKey, value)
+	_, err := e.client.CoreV1().Pods(e.podNamespace).Patch(e.podName, types.MergePatchType, []byte(patch))
+	return err
+}
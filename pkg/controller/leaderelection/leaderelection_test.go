@@ -0,0 +1,111 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leaderelection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testNamespace = "kube-system"
+
+func newPod(name string, labels map[string]string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name, Labels: labels}}
+}
+
+func isLabeled(t *testing.T, client *fake.Clientset, podName string) bool {
+	pod, err := client.CoreV1().Pods(testNamespace).Get(podName, metav1.GetOptions{})
+	require.NoError(t, err)
+	return pod.Labels[LeaderLabelKey] == "true"
+}
+
+func TestElectorWithoutLeaderElection(t *testing.T) {
+	// The Pod has a stale label from a previous run, it should be overwritten.
+	client := fake.NewSimpleClientset(newPod("pod1", map[string]string{LeaderLabelKey: "false", "component": "antrea-controller"}))
+	e := NewElector(client, Config{}, testNamespace, "pod1")
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	startedCh := make(chan struct{})
+	go e.Run(stopCh, func(_ <-chan struct{}) {
+		close(startedCh)
+	})
+
+	select {
+	case <-startedCh:
+	case <-time.After(time.Second):
+		t.Fatal("Leader components were not started")
+	}
+	assert.NoError(t, wait.Poll(10*time.Millisecond, time.Second, func() (bool, error) {
+		return isLabeled(t, client, "pod1"), nil
+	}))
+	assert.True(t, e.IsLeader())
+	assert.Equal(t, "pod1", e.GetLeader())
+	pod, _ := client.CoreV1().Pods(testNamespace).Get("pod1", metav1.GetOptions{})
+	assert.Equal(t, "antrea-controller", pod.Labels["component"])
+}
+
+func TestElectorWithLeaderElection(t *testing.T) {
+	// pod2 has a stale leader label from a previous run, it should be removed.
+	client := fake.NewSimpleClientset(newPod("pod1", nil), newPod("pod2", map[string]string{LeaderLabelKey: "true"}))
+	config := Config{
+		Enable:        true,
+		LeaseDuration: 2 * time.Second,
+		RenewDeadline: time.Second,
+		RetryPeriod:   100 * time.Millisecond,
+	}
+	e1 := NewElector(client, config, testNamespace, "pod1")
+	e2 := NewElector(client, config, testNamespace, "pod2")
+
+	stopCh1 := make(chan struct{})
+	startedCh1 := make(chan struct{})
+	go e1.Run(stopCh1, func(_ <-chan struct{}) {
+		close(startedCh1)
+	})
+	select {
+	case <-startedCh1:
+	case <-time.After(time.Second):
+		t.Fatal("pod1 didn't become the leader")
+	}
+
+	stopCh2 := make(chan struct{})
+	defer close(stopCh2)
+	startedCh2 := make(chan struct{})
+	go e2.Run(stopCh2, func(_ <-chan struct{}) {
+		close(startedCh2)
+	})
+	assert.NoError(t, wait.Poll(10*time.Millisecond, time.Second, func() (bool, error) {
+		return isLabeled(t, client, "pod1") && !isLabeled(t, client, "pod2") && e2.GetLeader() == "pod1", nil
+	}))
+	assert.True(t, e1.IsLeader())
+	assert.False(t, e2.IsLeader())
+
+	// Stopping the leader releases the lease so that the standby replica takes over.
+	close(stopCh1)
+	select {
+	case <-startedCh2:
+	case <-time.After(5 * time.Second):
+		t.Fatal("pod2 didn't become the leader")
+	}
+	assert.NoError(t, wait.Poll(10*time.Millisecond, time.Second, func() (bool, error) {
+		return !isLabeled(t, client, "pod1") && isLabeled(t, client, "pod2") && e2.IsLeader(), nil
+	}))
+}
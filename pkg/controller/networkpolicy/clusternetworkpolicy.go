@@ -0,0 +1,307 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+	"github.com/vmware-tanzu/antrea/pkg/k8s"
+)
+
+var (
+	// tierPriorityMap maps the name of a tier to its TierPriority. A lower
+	// value means a higher precedence.
+	tierPriorityMap = map[string]networking.TierPriority{
+		secv1alpha1.TierEmergency:   networking.TierPriority(50),
+		secv1alpha1.TierSecurityOps: networking.TierPriority(100),
+		secv1alpha1.TierNetworkOps:  networking.TierPriority(150),
+		secv1alpha1.TierPlatform:    networking.TierPriority(200),
+		secv1alpha1.TierApplication: networking.TierPriority(250),
+	}
+	// defaultTierPriority is the TierPriority of ClusterNetworkPolicies
+	// created without a tier, i.e. the Application tier.
+	defaultTierPriority = tierPriorityMap[secv1alpha1.TierApplication]
+)
+
+// internalCNPKey returns the key of the internal NetworkPolicy corresponding
+// to the ClusterNetworkPolicy. As the policy is cluster scoped, the Namespace
+// part of the key is empty.
+func internalCNPKey(cnp *secv1alpha1.ClusterNetworkPolicy) string {
+	return k8s.NamespacedName("", cnp.Name)
+}
+
+// addCNP receives ClusterNetworkPolicy ADD events and creates resources
+// which can be consumed by agents to configure corresponding rules on the Nodes.
+func (n *NetworkPolicyController) addCNP(obj interface{}) {
+	defer n.heartbeat("addCNP")
+	cnp := obj.(*secv1alpha1.ClusterNetworkPolicy)
+	klog.V(2).Infof("Processing ClusterNetworkPolicy %s ADD event", cnp.Name)
+	// Create an internal NetworkPolicy object corresponding to this
+	// ClusterNetworkPolicy and enqueue task to internal NetworkPolicy Workqueue.
+	internalNP := n.processClusterNetworkPolicy(cnp)
+	klog.Infof("Creating new internal NetworkPolicy %s", internalNP.Name)
+	n.internalNetworkPolicyStore.Create(internalNP)
+	n.enqueueInternalNetworkPolicy(internalCNPKey(cnp))
+}
+
+// updateCNP receives ClusterNetworkPolicy UPDATE events and updates resources
+// which can be consumed by agents to configure corresponding rules on the Nodes.
+func (n *NetworkPolicyController) updateCNP(old, cur interface{}) {
+	defer n.heartbeat("updateCNP")
+	curCNP := cur.(*secv1alpha1.ClusterNetworkPolicy)
+	klog.V(2).Infof("Processing ClusterNetworkPolicy %s UPDATE event", curCNP.Name)
+	// Update an internal NetworkPolicy, corresponding to this ClusterNetworkPolicy
+	// and enqueue task to internal NetworkPolicy Workqueue.
+	curInternalNP := n.processClusterNetworkPolicy(curCNP)
+	klog.V(2).Infof("Updating existing internal NetworkPolicy %s", curInternalNP.Name)
+	// Old and current ClusterNetworkPolicy share the same key.
+	key := internalCNPKey(curCNP)
+	// Lock access to internal NetworkPolicy store such that concurrent access
+	// to an internal NetworkPolicy is not allowed. This will avoid the
+	// case in which an Update to an internal NetworkPolicy object may
+	// cause the SpanMeta member to be overridden with stale SpanMeta members
+	// from an older internal NetworkPolicy.
+	n.internalNetworkPolicyMutex.Lock()
+	oldInternalNPObj, _, _ := n.internalNetworkPolicyStore.Get(key)
+	oldInternalNP := oldInternalNPObj.(*antreatypes.NetworkPolicy)
+	// Must preserve old internal NetworkPolicy Span.
+	curInternalNP.SpanMeta = oldInternalNP.SpanMeta
+	n.internalNetworkPolicyStore.Update(curInternalNP)
+	// Unlock the internal NetworkPolicy store.
+	n.internalNetworkPolicyMutex.Unlock()
+	// Enqueue addressGroup keys to update their Node span.
+	for _, rule := range curInternalNP.Rules {
+		for _, addrGroupName := range rule.From.AddressGroups {
+			n.enqueueAddressGroup(addrGroupName)
+		}
+		for _, addrGroupName := range rule.To.AddressGroups {
+			n.enqueueAddressGroup(addrGroupName)
+		}
+	}
+	n.enqueueInternalNetworkPolicy(key)
+	// Delete the old AppliedToGroup objects which are no longer referenced by
+	// the current internal NetworkPolicy, if they are not referenced by any
+	// other internal NetworkPolicy.
+	curAppliedToGroupUIDs := sets.NewString(curInternalNP.AppliedToGroups...)
+	for _, oldAppliedToGroupUID := range oldInternalNP.AppliedToGroups {
+		if !curAppliedToGroupUIDs.Has(oldAppliedToGroupUID) {
+			n.deleteDereferencedAppliedToGroup(oldAppliedToGroupUID)
+		}
+	}
+	n.deleteDereferencedAddressGroups(oldInternalNP)
+}
+
+// deleteCNP receives ClusterNetworkPolicy DELETED events and deletes resources
+// which can be consumed by agents to delete corresponding rules on the Nodes.
+func (n *NetworkPolicyController) deleteCNP(old interface{}) {
+	cnp, ok := old.(*secv1alpha1.ClusterNetworkPolicy)
+	if !ok {
+		tombstone, ok := old.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("Error decoding object when deleting ClusterNetworkPolicy, invalid type: %v", old)
+			return
+		}
+		cnp, ok = tombstone.Obj.(*secv1alpha1.ClusterNetworkPolicy)
+		if !ok {
+			klog.Errorf("Error decoding object tombstone when deleting ClusterNetworkPolicy, invalid type: %v", tombstone.Obj)
+			return
+		}
+	}
+	defer n.heartbeat("deleteCNP")
+
+	klog.V(2).Infof("Processing ClusterNetworkPolicy %s DELETE event", cnp.Name)
+	key := internalCNPKey(cnp)
+	oldInternalNPObj, _, _ := n.internalNetworkPolicyStore.Get(key)
+	oldInternalNP := oldInternalNPObj.(*antreatypes.NetworkPolicy)
+	klog.Infof("Deleting internal NetworkPolicy %s", cnp.Name)
+	// Delete corresponding internal NetworkPolicy from store.
+	err := n.internalNetworkPolicyStore.Delete(key)
+	if err != nil {
+		klog.Errorf("Error deleting internal NetworkPolicy during ClusterNetworkPolicy %s delete: %v", cnp.Name, err)
+		return
+	}
+	for _, atg := range oldInternalNP.AppliedToGroups {
+		n.deleteDereferencedAppliedToGroup(atg)
+	}
+	n.deleteDereferencedAddressGroups(oldInternalNP)
+}
+
+// processClusterNetworkPolicy creates an internal NetworkPolicy instance
+// corresponding to the secv1alpha1.ClusterNetworkPolicy object. This method
+// does not commit the internal NetworkPolicy in store, instead returns an
+// instance to the caller wherein, it will be either stored as a new Object
+// in case of ADD event or modified and store the updated instance, in case
+// of an UPDATE event.
+func (n *NetworkPolicyController) processClusterNetworkPolicy(cnp *secv1alpha1.ClusterNetworkPolicy) *antreatypes.NetworkPolicy {
+	appliedToGroupNames := make([]string, 0, len(cnp.Spec.AppliedTo))
+	// Create AppliedToGroup for each AppliedTo present in the
+	// ClusterNetworkPolicy spec.
+	for _, at := range cnp.Spec.AppliedTo {
+		appliedToGroupNames = append(appliedToGroupNames, n.createAppliedToGroup("", at.PodSelector, at.NamespaceSelector))
+	}
+	rules := make([]networking.NetworkPolicyRule, 0, len(cnp.Spec.Ingress)+len(cnp.Spec.Egress))
+	// Compute NetworkPolicyRule for Ingress Rule. The priority of a rule is
+	// the order in which it is specified in the spec.
+	for idx, ingressRule := range cnp.Spec.Ingress {
+		if err := validateCNPPorts(ingressRule.Ports); err != nil {
+			klog.Errorf("Failure processing ClusterNetworkPolicy %s ingress rule %d: %v", cnp.Name, idx, err)
+			continue
+		}
+		rules = append(rules, networking.NetworkPolicyRule{
+			Name:          toRuleName(networking.DirectionIn, idx),
+			Direction:     networking.DirectionIn,
+			From:          *n.toAntreaPeerForCNP(ingressRule.From, cnp, networking.DirectionIn),
+			Services:      toAntreaServicesForCNP(ingressRule.Ports),
+			Action:        ingressRule.Action,
+			Priority:      int32(idx),
+			EnableLogging: ingressRule.EnableLogging,
+		})
+	}
+	// Compute NetworkPolicyRule for Egress Rule.
+	for idx, egressRule := range cnp.Spec.Egress {
+		if err := validateCNPPorts(egressRule.Ports); err != nil {
+			klog.Errorf("Failure processing ClusterNetworkPolicy %s egress rule %d: %v", cnp.Name, idx, err)
+			continue
+		}
+		rules = append(rules, networking.NetworkPolicyRule{
+			Name:          toRuleName(networking.DirectionOut, idx),
+			Direction:     networking.DirectionOut,
+			To:            *n.toAntreaPeerForCNP(egressRule.To, cnp, networking.DirectionOut),
+			Services:      toAntreaServicesForCNP(egressRule.Ports),
+			Action:        egressRule.Action,
+			Priority:      int32(idx),
+			EnableLogging: egressRule.EnableLogging,
+		})
+	}
+	tierPriority := getTierPriority(cnp.Spec.Tier)
+	priority := cnp.Spec.Priority
+	internalNetworkPolicy := &antreatypes.NetworkPolicy{
+		Name:            cnp.Name,
+		Namespace:       "",
+		UID:             cnp.UID,
+		Generation:      cnp.Generation,
+		AppliedToGroups: appliedToGroupNames,
+		Rules:           rules,
+		Priority:        &priority,
+		TierPriority:    &tierPriority,
+	}
+	return internalNetworkPolicy
+}
+
+// toAntreaPeerForCNP converts the secv1alpha1.NetworkPolicyPeers of a
+// ClusterNetworkPolicy rule to an Antrea NetworkPolicyPeer.
+func (n *NetworkPolicyController) toAntreaPeerForCNP(peers []secv1alpha1.NetworkPolicyPeer, cnp *secv1alpha1.ClusterNetworkPolicy, dir networking.Direction) *networking.NetworkPolicyPeer {
+	var addressGroups []string
+	// Empty NetworkPolicyPeer is supposed to match all addresses.
+	// It's treated as an IPBlock "0.0.0.0/0".
+	if len(peers) == 0 {
+		// For an ingress Peer, skip adding the AddressGroup matching all Pods
+		// because in case of ingress Rule, the named Port resolution happens on
+		// Pods in AppliedToGroup.
+		if dir == networking.DirectionIn {
+			return &matchAllPeer
+		}
+		// For an egress Peer, create an AddressGroup matching all Pods in all
+		// Namespaces such that it can be used to resolve named Ports. This
+		// AddressGroup is set in the NetworkPolicyPeer of matchAllPeer.
+		allPodsGroupUID := n.createAddressGroup("", matchAllPodsPeer.PodSelector, matchAllPodsPeer.NamespaceSelector)
+		podsPeer := matchAllPeer
+		addressGroups = append(addressGroups, allPodsGroupUID)
+		podsPeer.AddressGroups = addressGroups
+		return &podsPeer
+	}
+	var ipBlocks []networking.IPBlock
+	var fqdns []string
+	for _, peer := range peers {
+		// A secv1alpha1.NetworkPolicyPeer will either have an IPBlock, an FQDN
+		// or a podSelector and/or namespaceSelector set.
+		if peer.FQDN != "" {
+			// The addresses of FQDNs are learnt from the DNS responses to the
+			// Pods the rule applies to, which is only meaningful for egress.
+			if dir != networking.DirectionOut {
+				klog.Errorf("Failure processing ClusterNetworkPolicy %s FQDN %s: FQDN can only be set in egress rules", cnp.Name, peer.FQDN)
+				continue
+			}
+			fqdns = append(fqdns, peer.FQDN)
+		} else if peer.IPBlock != nil {
+			ipNet, err := cidrStrToIPNet(peer.IPBlock.CIDR)
+			if err != nil {
+				klog.Errorf("Failure processing ClusterNetworkPolicy %s IPBlock %v: %v", cnp.Name, peer.IPBlock, err)
+				continue
+			}
+			ipBlocks = append(ipBlocks, networking.IPBlock{CIDR: *ipNet})
+		} else {
+			normalizedUID := n.createAddressGroup("", peer.PodSelector, peer.NamespaceSelector)
+			addressGroups = append(addressGroups, normalizedUID)
+		}
+	}
+	return &networking.NetworkPolicyPeer{AddressGroups: addressGroups, IPBlocks: ipBlocks, FQDNs: fqdns}
+}
+
+// toAntreaServicesForCNP converts a secv1alpha1.NetworkPolicyPort object to an
+// Antrea Service object.
+func toAntreaServicesForCNP(npPorts []secv1alpha1.NetworkPolicyPort) []networking.Service {
+	var antreaServices []networking.Service
+	for _, npPort := range npPorts {
+		antreaService := networking.Service{
+			Protocol: toAntreaProtocol(npPort.Protocol),
+			Port:     npPort.Port,
+			EndPort:  npPort.EndPort,
+			ICMPType: npPort.ICMPType,
+			ICMPCode: npPort.ICMPCode,
+		}
+		antreaServices = append(antreaServices, antreaService)
+	}
+	return antreaServices
+}
+
+// validateCNPPorts checks the constraints of the NetworkPolicyPorts of a
+// ClusterNetworkPolicy rule which cannot be enforced by the CRD schema. The
+// whole rule is ignored when a port is invalid, as ignoring only the port
+// could make the rule match all the ports.
+func validateCNPPorts(npPorts []secv1alpha1.NetworkPolicyPort) error {
+	for _, npPort := range npPorts {
+		if npPort.EndPort != nil {
+			if npPort.Port == nil || npPort.Port.Type != intstr.Int {
+				return fmt.Errorf("endPort %d can only be set with a numerical port", *npPort.EndPort)
+			}
+			if *npPort.EndPort < npPort.Port.IntVal {
+				return fmt.Errorf("endPort %d must be greater than or equal to port %d", *npPort.EndPort, npPort.Port.IntVal)
+			}
+		}
+		if npPort.ICMPCode != nil && npPort.ICMPType == nil {
+			return fmt.Errorf("icmpCode %d can only be set with icmpType", *npPort.ICMPCode)
+		}
+	}
+	return nil
+}
+
+// getTierPriority retrieves the priority associated with the input Tier name.
+// If the Tier name is empty or unknown, by default the lowest priority Application
+// Tier's priority is returned.
+func getTierPriority(tier string) networking.TierPriority {
+	if tierPriority, ok := tierPriorityMap[tier]; ok {
+		return tierPriority
+	}
+	return defaultTierPriority
+}
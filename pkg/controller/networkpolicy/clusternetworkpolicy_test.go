@@ -0,0 +1,422 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+)
+
+func TestProcessClusterNetworkPolicy(t *testing.T) {
+	p10 := float64(10)
+	appTier := networking.TierPriority(250)
+	secOpsTier := networking.TierPriority(100)
+	allowAction := secv1alpha1.RuleActionAllow
+	dropAction := secv1alpha1.RuleActionDrop
+	protocolTCP := networking.ProtocolTCP
+	intstr80, intstr81 := intstr.FromInt(80), intstr.FromInt(81)
+	int80, int81 := intstr.FromInt(80), intstr.FromInt(81)
+	namedPort := intstr.FromString("http")
+	endPort, lowEndPort := int32(90), int32(70)
+	protocolICMP := networking.ProtocolICMP
+	k8sProtocolICMP := v1.Protocol("ICMP")
+	icmpType, icmpCode := int32(8), int32(0)
+	selectorA := metav1.LabelSelector{MatchLabels: map[string]string{"foo1": "bar1"}}
+	selectorB := metav1.LabelSelector{MatchLabels: map[string]string{"foo2": "bar2"}}
+	selectorC := metav1.LabelSelector{MatchLabels: map[string]string{"foo3": "bar3"}}
+	tests := []struct {
+		name                    string
+		inputPolicy             *secv1alpha1.ClusterNetworkPolicy
+		expectedPolicy          *antreatypes.NetworkPolicy
+		expectedAppliedToGroups int
+		expectedAddressGroups   int
+	}{
+		{
+			name: "rules-with-same-selectors",
+			inputPolicy: &secv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "cnpA", UID: "uidA"},
+				Spec: secv1alpha1.ClusterNetworkPolicySpec{
+					AppliedTo: []secv1alpha1.NetworkPolicyPeer{
+						{PodSelector: &selectorA},
+					},
+					Priority: p10,
+					Ingress: []secv1alpha1.Rule{
+						{
+							Ports: []secv1alpha1.NetworkPolicyPort{{Port: &int80}},
+							From: []secv1alpha1.NetworkPolicyPeer{
+								{PodSelector: &selectorB, NamespaceSelector: &selectorC},
+							},
+							Action: &allowAction,
+						},
+					},
+					Egress: []secv1alpha1.Rule{
+						{
+							Ports: []secv1alpha1.NetworkPolicyPort{{Port: &int81}},
+							To: []secv1alpha1.NetworkPolicyPeer{
+								{PodSelector: &selectorB, NamespaceSelector: &selectorC},
+							},
+							Action: &dropAction,
+						},
+					},
+				},
+			},
+			expectedPolicy: &antreatypes.NetworkPolicy{
+				UID:       "uidA",
+				Name:      "cnpA",
+				Namespace: "",
+				Rules: []networking.NetworkPolicyRule{
+					{
+						Name:      "ingress-0",
+						Direction: networking.DirectionIn,
+						From: networking.NetworkPolicyPeer{
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("", &selectorB, &selectorC).NormalizedName)},
+						},
+						Services: []networking.Service{{Protocol: &protocolTCP, Port: &intstr80}},
+						Priority: 0,
+						Action:   &allowAction,
+					},
+					{
+						Name:      "egress-0",
+						Direction: networking.DirectionOut,
+						To: networking.NetworkPolicyPeer{
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("", &selectorB, &selectorC).NormalizedName)},
+						},
+						Services: []networking.Service{{Protocol: &protocolTCP, Port: &intstr81}},
+						Priority: 0,
+						Action:   &dropAction,
+					},
+				},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("", &selectorA, nil).NormalizedName)},
+				Priority:        &p10,
+				TierPriority:    &appTier,
+			},
+			expectedAppliedToGroups: 1,
+			expectedAddressGroups:   1,
+		},
+		{
+			name: "rules-with-port-range-and-icmp",
+			inputPolicy: &secv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "cnpC", UID: "uidC"},
+				Spec: secv1alpha1.ClusterNetworkPolicySpec{
+					AppliedTo: []secv1alpha1.NetworkPolicyPeer{
+						{PodSelector: &selectorA},
+					},
+					Priority: p10,
+					Ingress: []secv1alpha1.Rule{
+						{
+							Ports: []secv1alpha1.NetworkPolicyPort{
+								{Port: &int80, EndPort: &endPort},
+								{Protocol: &k8sProtocolICMP, ICMPType: &icmpType, ICMPCode: &icmpCode},
+							},
+							From: []secv1alpha1.NetworkPolicyPeer{
+								{PodSelector: &selectorB},
+							},
+							Action: &allowAction,
+						},
+					},
+				},
+			},
+			expectedPolicy: &antreatypes.NetworkPolicy{
+				UID:       "uidC",
+				Name:      "cnpC",
+				Namespace: "",
+				Rules: []networking.NetworkPolicyRule{
+					{
+						Name:      "ingress-0",
+						Direction: networking.DirectionIn,
+						From: networking.NetworkPolicyPeer{
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("", &selectorB, nil).NormalizedName)},
+						},
+						Services: []networking.Service{
+							{Protocol: &protocolTCP, Port: &intstr80, EndPort: &endPort},
+							{Protocol: &protocolICMP, ICMPType: &icmpType, ICMPCode: &icmpCode},
+						},
+						Priority: 0,
+						Action:   &allowAction,
+					},
+				},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("", &selectorA, nil).NormalizedName)},
+				Priority:        &p10,
+				TierPriority:    &appTier,
+			},
+			expectedAppliedToGroups: 1,
+			expectedAddressGroups:   1,
+		},
+		{
+			name: "rules-with-fqdn",
+			inputPolicy: &secv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "cnpD", UID: "uidD"},
+				Spec: secv1alpha1.ClusterNetworkPolicySpec{
+					AppliedTo: []secv1alpha1.NetworkPolicyPeer{
+						{PodSelector: &selectorA},
+					},
+					Priority: p10,
+					Egress: []secv1alpha1.Rule{
+						{
+							Ports: []secv1alpha1.NetworkPolicyPort{
+								{Port: &int80},
+							},
+							To: []secv1alpha1.NetworkPolicyPeer{
+								{FQDN: "www.example.com"},
+								{FQDN: "*.example.org"},
+								{IPBlock: &secv1alpha1.IPBlock{CIDR: "10.0.0.0/24"}},
+							},
+							Action: &allowAction,
+						},
+					},
+				},
+			},
+			expectedPolicy: &antreatypes.NetworkPolicy{
+				UID:       "uidD",
+				Name:      "cnpD",
+				Namespace: "",
+				Rules: []networking.NetworkPolicyRule{
+					{
+						Name:      "egress-0",
+						Direction: networking.DirectionOut,
+						To: networking.NetworkPolicyPeer{
+							IPBlocks: []networking.IPBlock{
+								{
+									CIDR: networking.IPNet{
+										IP:           ipStrToIPAddress("10.0.0.0"),
+										PrefixLength: 24,
+									},
+								},
+							},
+							FQDNs: []string{"www.example.com", "*.example.org"},
+						},
+						Services: []networking.Service{
+							{Protocol: &protocolTCP, Port: &intstr80},
+						},
+						Priority: 0,
+						Action:   &allowAction,
+					},
+				},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("", &selectorA, nil).NormalizedName)},
+				Priority:        &p10,
+				TierPriority:    &appTier,
+			},
+			expectedAppliedToGroups: 1,
+			expectedAddressGroups:   0,
+		},
+		{
+			name: "multiple-appliedto-and-rules-in-tier",
+			inputPolicy: &secv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "cnpB", UID: "uidB"},
+				Spec: secv1alpha1.ClusterNetworkPolicySpec{
+					Tier: secv1alpha1.TierSecurityOps,
+					AppliedTo: []secv1alpha1.NetworkPolicyPeer{
+						{PodSelector: &selectorA},
+						{NamespaceSelector: &selectorC},
+					},
+					Priority: p10,
+					Ingress: []secv1alpha1.Rule{
+						{
+							From: []secv1alpha1.NetworkPolicyPeer{
+								{PodSelector: &selectorB},
+							},
+							Action: &allowAction,
+						},
+						{
+							From: []secv1alpha1.NetworkPolicyPeer{
+								{IPBlock: &secv1alpha1.IPBlock{CIDR: "10.0.0.0/24"}},
+							},
+							Action: &dropAction,
+						},
+					},
+				},
+			},
+			expectedPolicy: &antreatypes.NetworkPolicy{
+				UID:       "uidB",
+				Name:      "cnpB",
+				Namespace: "",
+				Rules: []networking.NetworkPolicyRule{
+					{
+						Name:      "ingress-0",
+						Direction: networking.DirectionIn,
+						From: networking.NetworkPolicyPeer{
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("", &selectorB, nil).NormalizedName)},
+						},
+						Priority: 0,
+						Action:   &allowAction,
+					},
+					{
+						Name:      "ingress-1",
+						Direction: networking.DirectionIn,
+						From: networking.NetworkPolicyPeer{
+							IPBlocks: []networking.IPBlock{
+								{
+									CIDR: networking.IPNet{
+										IP:           ipStrToIPAddress("10.0.0.0"),
+										PrefixLength: 24,
+									},
+								},
+							},
+						},
+						Priority: 1,
+						Action:   &dropAction,
+					},
+				},
+				AppliedToGroups: []string{
+					getNormalizedUID(toGroupSelector("", &selectorA, nil).NormalizedName),
+					getNormalizedUID(toGroupSelector("", nil, &selectorC).NormalizedName),
+				},
+				Priority:     &p10,
+				TierPriority: &secOpsTier,
+			},
+			expectedAppliedToGroups: 2,
+			expectedAddressGroups:   1,
+		},
+		{
+			name: "rules-with-invalid-ports",
+			inputPolicy: &secv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "cnpE", UID: "uidE"},
+				Spec: secv1alpha1.ClusterNetworkPolicySpec{
+					AppliedTo: []secv1alpha1.NetworkPolicyPeer{
+						{PodSelector: &selectorA},
+					},
+					Priority: p10,
+					Ingress: []secv1alpha1.Rule{
+						{
+							Ports: []secv1alpha1.NetworkPolicyPort{
+								{Port: &namedPort, EndPort: &endPort},
+							},
+							Action: &allowAction,
+						},
+						{
+							Ports: []secv1alpha1.NetworkPolicyPort{
+								{Port: &int81},
+								{Port: &int81, EndPort: &lowEndPort},
+							},
+							Action: &allowAction,
+						},
+						{
+							Ports: []secv1alpha1.NetworkPolicyPort{
+								{Port: &int80, EndPort: &endPort},
+							},
+							Action: &allowAction,
+						},
+					},
+					Egress: []secv1alpha1.Rule{
+						{
+							Ports: []secv1alpha1.NetworkPolicyPort{
+								{Protocol: &k8sProtocolICMP, ICMPCode: &icmpCode},
+							},
+							To: []secv1alpha1.NetworkPolicyPeer{
+								{PodSelector: &selectorB},
+							},
+							Action: &dropAction,
+						},
+					},
+				},
+			},
+			expectedPolicy: &antreatypes.NetworkPolicy{
+				UID:       "uidE",
+				Name:      "cnpE",
+				Namespace: "",
+				Rules: []networking.NetworkPolicyRule{
+					{
+						Name:      "ingress-2",
+						Direction: networking.DirectionIn,
+						From:      matchAllPeer,
+						Services: []networking.Service{
+							{Protocol: &protocolTCP, Port: &intstr80, EndPort: &endPort},
+						},
+						Priority: 2,
+						Action:   &allowAction,
+					},
+				},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("", &selectorA, nil).NormalizedName)},
+				Priority:        &p10,
+				TierPriority:    &appTier,
+			},
+			expectedAppliedToGroups: 1,
+			expectedAddressGroups:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, c := newController()
+			actualPolicy := c.processClusterNetworkPolicy(tt.inputPolicy)
+			assert.Equal(t, tt.expectedPolicy, actualPolicy)
+			assert.Equal(t, tt.expectedAppliedToGroups, len(c.appliedToGroupStore.List()))
+			assert.Equal(t, tt.expectedAddressGroups, len(c.addressGroupStore.List()))
+		})
+	}
+}
+
+func TestAddAndDeleteCNP(t *testing.T) {
+	p10 := float64(10)
+	allowAction := secv1alpha1.RuleActionAllow
+	selectorA := metav1.LabelSelector{MatchLabels: map[string]string{"foo1": "bar1"}}
+	selectorB := metav1.LabelSelector{MatchLabels: map[string]string{"foo2": "bar2"}}
+	cnp := &secv1alpha1.ClusterNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cnpA", UID: "uidA"},
+		Spec: secv1alpha1.ClusterNetworkPolicySpec{
+			AppliedTo: []secv1alpha1.NetworkPolicyPeer{{PodSelector: &selectorA}},
+			Priority:  p10,
+			Ingress: []secv1alpha1.Rule{
+				{
+					From:   []secv1alpha1.NetworkPolicyPeer{{PodSelector: &selectorB}},
+					Action: &allowAction,
+				},
+			},
+		},
+	}
+	_, c := newController()
+	c.addCNP(cnp)
+	key := internalCNPKey(cnp)
+	_, found, _ := c.internalNetworkPolicyStore.Get(key)
+	assert.True(t, found, "expected internal NetworkPolicy to be created")
+	assert.Len(t, c.appliedToGroupStore.List(), 1)
+	assert.Len(t, c.addressGroupStore.List(), 1)
+
+	c.deleteCNP(cnp)
+	_, found, _ = c.internalNetworkPolicyStore.Get(key)
+	assert.False(t, found, "expected internal NetworkPolicy to be deleted")
+	assert.Len(t, c.appliedToGroupStore.List(), 0, "expected empty AppliedToGroup list")
+	assert.Len(t, c.addressGroupStore.List(), 0, "expected empty AddressGroup list")
+}
+
+func TestSyncClusterScopedAppliedToGroup(t *testing.T) {
+	selectorA := metav1.LabelSelector{MatchLabels: map[string]string{"foo1": "bar1"}}
+	nsA := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "nsA", Labels: map[string]string{"foo1": "bar1"}}}
+	nsB := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "nsB"}}
+	podA := getPod("podA", "nsA", "nodeA", "1.1.1.1", false)
+	podB := getPod("podB", "nsB", "nodeB", "1.1.1.2", false)
+	_, c := newController()
+	c.namespaceStore.Add(nsA)
+	c.namespaceStore.Add(nsB)
+	c.podStore.Add(podA)
+	c.podStore.Add(podB)
+	key := c.createAppliedToGroup("", nil, &selectorA)
+	assert.NoError(t, c.syncAppliedToGroup(key))
+	obj, found, _ := c.appliedToGroupStore.Get(key)
+	assert.True(t, found)
+	group := obj.(*antreatypes.AppliedToGroup)
+	assert.Len(t, group.PodsByNode, 1)
+	assert.Contains(t, group.PodsByNode, "nodeA")
+	assert.Equal(t, []string{key}, c.filterAppliedToGroupsForNamespace(nsA).List())
+	assert.Empty(t, c.filterAppliedToGroupsForNamespace(nsB).List())
+	assert.Equal(t, []string{key}, c.filterAppliedToGroupsForPod(podA).List())
+}
@@ -46,9 +46,12 @@ import (
 
 	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
 	"github.com/vmware-tanzu/antrea/pkg/apiserver/storage"
+	secinformers "github.com/vmware-tanzu/antrea/pkg/client/informers/externalversions/security/v1alpha1"
+	seclisters "github.com/vmware-tanzu/antrea/pkg/client/listers/security/v1alpha1"
 	"github.com/vmware-tanzu/antrea/pkg/controller/metrics"
 	"github.com/vmware-tanzu/antrea/pkg/controller/networkpolicy/store"
 	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+	"github.com/vmware-tanzu/antrea/pkg/features"
 )
 
 const (
@@ -61,6 +64,10 @@ const (
 	maxRetryDelay = 300 * time.Second
 	// Default number of workers processing a NetworkPolicy change.
 	defaultWorkers = 4
+	// EnableLoggingAnnotation is the annotation of a K8s NetworkPolicy which
+	// enables the logging of the connections allowed by its rules, and of the
+	// connections dropped because it isolates Pods, if its value is "true".
+	EnableLoggingAnnotation = "networkpolicy.antrea.tanzu.vmware.com/enable-logging"
 )
 
 var (
@@ -73,9 +80,12 @@ var (
 	// uuid.NewV4() function.
 	uuidNamespace = uuid.FromStringOrNil("5a5e7dd9-e3fb-49bb-b263-9bab25c95841")
 
-	// matchAllPeer is a NetworkPolicyPeer matching all source/destination IP addresses.
+	// matchAllPeer is a NetworkPolicyPeer matching all source/destination IPv4 and IPv6 addresses.
 	matchAllPeer = networking.NetworkPolicyPeer{
-		IPBlocks: []networking.IPBlock{{CIDR: networking.IPNet{IP: networking.IPAddress(net.IPv4zero), PrefixLength: 0}}},
+		IPBlocks: []networking.IPBlock{
+			{CIDR: networking.IPNet{IP: networking.IPAddress(net.IPv4zero), PrefixLength: 0}},
+			{CIDR: networking.IPNet{IP: networking.IPAddress(net.IPv6zero), PrefixLength: 0}},
+		},
 	}
 	// matchAllPodsPeer is a networkingv1.NetworkPolicyPeer matching all Pods from all Namespaces.
 	matchAllPodsPeer = networkingv1.NetworkPolicyPeer{
@@ -118,6 +128,15 @@ type NetworkPolicyController struct {
 	// networkPolicyListerSynced is a function which returns true if the Network Policy shared informer has been synced at least once.
 	networkPolicyListerSynced cache.InformerSynced
 
+	cnpInformer secinformers.ClusterNetworkPolicyInformer
+
+	// cnpLister is able to list/get ClusterNetworkPolicies and is populated by the shared informer passed to
+	// NewNetworkPolicyController.
+	cnpLister seclisters.ClusterNetworkPolicyLister
+
+	// cnpListerSynced is a function which returns true if the ClusterNetworkPolicies shared informer has been synced at least once.
+	cnpListerSynced cache.InformerSynced
+
 	// addressGroupStore is the storage where the populated Address Groups are stored.
 	addressGroupStore storage.Interface
 
@@ -158,6 +177,7 @@ func NewNetworkPolicyController(kubeClient clientset.Interface,
 	podInformer coreinformers.PodInformer,
 	namespaceInformer coreinformers.NamespaceInformer,
 	networkPolicyInformer networkinginformers.NetworkPolicyInformer,
+	cnpInformer secinformers.ClusterNetworkPolicyInformer,
 	addressGroupStore storage.Interface,
 	appliedToGroupStore storage.Interface,
 	internalNetworkPolicyStore storage.Interface) *NetworkPolicyController {
@@ -172,6 +192,9 @@ func NewNetworkPolicyController(kubeClient clientset.Interface,
 		networkPolicyInformer:      networkPolicyInformer,
 		networkPolicyLister:        networkPolicyInformer.Lister(),
 		networkPolicyListerSynced:  networkPolicyInformer.Informer().HasSynced,
+		cnpInformer:                cnpInformer,
+		cnpLister:                  cnpInformer.Lister(),
+		cnpListerSynced:            cnpInformer.Informer().HasSynced,
 		addressGroupStore:          addressGroupStore,
 		appliedToGroupStore:        appliedToGroupStore,
 		internalNetworkPolicyStore: internalNetworkPolicyStore,
@@ -206,6 +229,17 @@ func NewNetworkPolicyController(kubeClient clientset.Interface,
 		},
 		resyncPeriod,
 	)
+	// Add handlers for ClusterNetworkPolicy events only if the feature is enabled.
+	if features.DefaultFeatureGate.Enabled(features.ClusterNetworkPolicy) {
+		cnpInformer.Informer().AddEventHandlerWithResyncPeriod(
+			cache.ResourceEventHandlerFuncs{
+				AddFunc:    n.addCNP,
+				UpdateFunc: n.updateCNP,
+				DeleteFunc: n.deleteCNP,
+			},
+			resyncPeriod,
+		)
+	}
 	return n
 }
 
@@ -246,7 +280,11 @@ func toGroupSelector(namespace string, podSelector, nsSelector *metav1.LabelSele
 		pSelector, _ := metav1.LabelSelectorAsSelector(podSelector)
 		groupSelector.PodSelector = pSelector
 	}
-	if nsSelector == nil {
+	if nsSelector == nil && namespace == "" {
+		// Neither namespaceSelector nor Namespace is set, which is the case of
+		// a cluster scoped policy. Pods must be selected from all Namespaces.
+		groupSelector.NamespaceSelector = labels.Everything()
+	} else if nsSelector == nil {
 		// No namespaceSelector indicates that the pods must be selected within
 		// the NetworkPolicy's Namespace.
 		groupSelector.Namespace = namespace
@@ -285,8 +323,8 @@ func generateNormalizedName(namespace string, podSelector, nsSelector labels.Sel
 }
 
 // createAppliedToGroup creates an AppliedToGroup object in store if it is not created already.
-func (n *NetworkPolicyController) createAppliedToGroup(np *networkingv1.NetworkPolicy) string {
-	groupSelector := toGroupSelector(np.ObjectMeta.Namespace, &np.Spec.PodSelector, nil)
+func (n *NetworkPolicyController) createAppliedToGroup(npNsName string, podSelector, nsSelector *metav1.LabelSelector) string {
+	groupSelector := toGroupSelector(npNsName, podSelector, nsSelector)
 	appliedToGroupUID := getNormalizedUID(groupSelector.NormalizedName)
 	// Get or create a AppliedToGroup for the generated UID.
 	_, found, _ := n.appliedToGroupStore.Get(appliedToGroupUID)
@@ -365,6 +403,22 @@ func (n *NetworkPolicyController) filterAddressGroupsForNamespace(namespace *v1.
 	return matchingKeys
 }
 
+// filterAppliedToGroupsForNamespace computes a list of AppliedToGroup keys
+// which match the Namespace's labels.
+func (n *NetworkPolicyController) filterAppliedToGroupsForNamespace(namespace *v1.Namespace) sets.String {
+	matchingKeys := sets.String{}
+	// Only cluster scoped groups can possibly select this Namespace.
+	appliedToGroups, _ := n.appliedToGroupStore.GetByIndex(cache.NamespaceIndex, "")
+	for _, group := range appliedToGroups {
+		appGroup := group.(*antreatypes.AppliedToGroup)
+		if appGroup.Selector.NamespaceSelector.Matches(labels.Set(namespace.Labels)) {
+			matchingKeys.Insert(appGroup.Name)
+			klog.V(2).Infof("Namespace %s matched AppliedToGroup %s", namespace.Name, appGroup.Name)
+		}
+	}
+	return matchingKeys
+}
+
 // filterAddressGroupsForPod computes a list of AddressGroup keys which
 // match the Pod's labels.
 func (n *NetworkPolicyController) filterAddressGroupsForPod(pod *v1.Pod) sets.String {
@@ -387,11 +441,11 @@ func (n *NetworkPolicyController) filterAddressGroupsForPod(pod *v1.Pod) sets.St
 // match the Pod's labels.
 func (n *NetworkPolicyController) filterAppliedToGroupsForPod(pod *v1.Pod) sets.String {
 	matchingKeySet := sets.String{}
-	// Only AppliedToGroups that are in this namespace can possibly select this Pod as there are
-	// no cluster scoped AppliedToGroups right now.
-	appliedToGroups, _ := n.appliedToGroupStore.GetByIndex(cache.NamespaceIndex, pod.Namespace)
+	// AppliedToGroups that are in this namespace or that are cluster scoped can possibly select this Pod.
+	localAppliedToGroups, _ := n.appliedToGroupStore.GetByIndex(cache.NamespaceIndex, pod.Namespace)
+	clusterScopedAppliedToGroups, _ := n.appliedToGroupStore.GetByIndex(cache.NamespaceIndex, "")
 	podNS, _ := n.namespaceLister.Get(pod.Namespace)
-	for _, group := range appliedToGroups {
+	for _, group := range append(localAppliedToGroups, clusterScopedAppliedToGroups...) {
 		appGroup := group.(*antreatypes.AppliedToGroup)
 		if n.labelsMatchGroupSelector(pod, podNS, &appGroup.Selector) {
 			matchingKeySet.Insert(appGroup.Name)
@@ -401,12 +455,12 @@ func (n *NetworkPolicyController) filterAppliedToGroupsForPod(pod *v1.Pod) sets.
 	return matchingKeySet
 }
 
-// createAddressGroup creates an AddressGroup object corresponding to a
-// NetworkPolicyPeer object in NetworkPolicyRule. This function simply
-// creates the object without actually populating the PodAddresses as the
-// affected Pods are calculated during sync process.
-func (n *NetworkPolicyController) createAddressGroup(peer networkingv1.NetworkPolicyPeer, np *networkingv1.NetworkPolicy) string {
-	groupSelector := toGroupSelector(np.ObjectMeta.Namespace, peer.PodSelector, peer.NamespaceSelector)
+// createAddressGroup creates an AddressGroup object corresponding to the
+// selectors of a NetworkPolicyPeer object in NetworkPolicyRule. This function
+// simply creates the object without actually populating the PodAddresses as
+// the affected Pods are calculated during sync process.
+func (n *NetworkPolicyController) createAddressGroup(npNsName string, podSelector, nsSelector *metav1.LabelSelector) string {
+	groupSelector := toGroupSelector(npNsName, podSelector, nsSelector)
 	normalizedUID := getNormalizedUID(groupSelector.NormalizedName)
 	// Get or create an AddressGroup for the generated UID.
 	_, found, _ := n.addressGroupStore.Get(normalizedUID)
@@ -434,6 +488,15 @@ func toAntreaProtocol(npProtocol *v1.Protocol) *networking.Protocol {
 	return &internalProtocol
 }
 
+// toRuleName generates the name of the idx-th rule of the given direction in
+// a NetworkPolicy spec, e.g. "ingress-0".
+func toRuleName(direction networking.Direction, idx int) string {
+	if direction == networking.DirectionIn {
+		return fmt.Sprintf("ingress-%d", idx)
+	}
+	return fmt.Sprintf("egress-%d", idx)
+}
+
 // toAntreaServices converts a networkingv1.NetworkPolicyPort object to an
 // Antrea Service object.
 func toAntreaServices(npPorts []networkingv1.NetworkPolicyPort) []networking.Service {
@@ -477,26 +540,32 @@ func toAntreaIPBlock(ipBlock *networkingv1.IPBlock) (*networking.IPBlock, error)
 // wherein, it will be either stored as a new Object in case of ADD event or
 // modified and store the updated instance, in case of an UPDATE event.
 func (n *NetworkPolicyController) processNetworkPolicy(np *networkingv1.NetworkPolicy) *antreatypes.NetworkPolicy {
-	appliedToGroupKey := n.createAppliedToGroup(np)
+	appliedToGroupKey := n.createAppliedToGroup(np.Namespace, &np.Spec.PodSelector, nil)
 	appliedToGroupNames := []string{appliedToGroupKey}
 	rules := make([]networking.NetworkPolicyRule, 0, len(np.Spec.Ingress)+len(np.Spec.Egress))
 	var ingressRuleExists, egressRuleExists bool
+	// Logging can only be enabled for all the rules of a K8s NetworkPolicy.
+	enableLogging := np.Annotations[EnableLoggingAnnotation] == "true"
 	// Compute NetworkPolicyRule for Ingress Rule.
-	for _, ingressRule := range np.Spec.Ingress {
+	for idx, ingressRule := range np.Spec.Ingress {
 		ingressRuleExists = true
 		rules = append(rules, networking.NetworkPolicyRule{
-			Direction: networking.DirectionIn,
-			From:      *n.toAntreaPeer(ingressRule.From, np, networking.DirectionIn),
-			Services:  toAntreaServices(ingressRule.Ports),
+			Name:          toRuleName(networking.DirectionIn, idx),
+			Direction:     networking.DirectionIn,
+			From:          *n.toAntreaPeer(ingressRule.From, np, networking.DirectionIn),
+			Services:      toAntreaServices(ingressRule.Ports),
+			EnableLogging: enableLogging,
 		})
 	}
 	// Compute NetworkPolicyRule for Egress Rule.
-	for _, egressRule := range np.Spec.Egress {
+	for idx, egressRule := range np.Spec.Egress {
 		egressRuleExists = true
 		rules = append(rules, networking.NetworkPolicyRule{
-			Direction: networking.DirectionOut,
-			To:        *n.toAntreaPeer(egressRule.To, np, networking.DirectionOut),
-			Services:  toAntreaServices(egressRule.Ports),
+			Name:          toRuleName(networking.DirectionOut, idx),
+			Direction:     networking.DirectionOut,
+			To:            *n.toAntreaPeer(egressRule.To, np, networking.DirectionOut),
+			Services:      toAntreaServices(egressRule.Ports),
+			EnableLogging: enableLogging,
 		})
 	}
 
@@ -513,18 +582,23 @@ func (n *NetworkPolicyController) processNetworkPolicy(np *networkingv1.NetworkP
 	// If ingress isolation is specified explicitly and there's no ingress rule, append a deny-all ingress rule.
 	// See https://kubernetes.io/docs/concepts/services-networking/network-policies/#default-deny-all-ingress-traffic
 	if ingressIsolated && !ingressRuleExists {
-		rules = append(rules, denyAllIngressRule)
+		rule := denyAllIngressRule
+		rule.EnableLogging = enableLogging
+		rules = append(rules, rule)
 	}
 	// If egress isolation is specified explicitly and there's no egress rule, append a deny-all egress rule.
 	// See https://kubernetes.io/docs/concepts/services-networking/network-policies/#default-deny-all-egress-traffic
 	if egressIsolated && !egressRuleExists {
-		rules = append(rules, denyAllEgressRule)
+		rule := denyAllEgressRule
+		rule.EnableLogging = enableLogging
+		rules = append(rules, rule)
 	}
 
 	internalNetworkPolicy := &antreatypes.NetworkPolicy{
 		Name:            np.ObjectMeta.Name,
 		Namespace:       np.ObjectMeta.Namespace,
 		UID:             np.ObjectMeta.UID,
+		Generation:      np.ObjectMeta.Generation,
 		AppliedToGroups: appliedToGroupNames,
 		Rules:           rules,
 	}
@@ -546,7 +620,7 @@ func (n *NetworkPolicyController) toAntreaPeer(peers []networkingv1.NetworkPolic
 		// For an egress Peer, create an AddressGroup matching all Pods in all
 		// Namespaces such that it can be used to resolve named Ports. This
 		// AddressGroup is set in the NetworkPolicyPeer of matchAllPeer.
-		allPodsGroupUID := n.createAddressGroup(matchAllPodsPeer, np)
+		allPodsGroupUID := n.createAddressGroup(np.Namespace, matchAllPodsPeer.PodSelector, matchAllPodsPeer.NamespaceSelector)
 		podsPeer := matchAllPeer
 		addressGroups = append(addressGroups, allPodsGroupUID)
 		podsPeer.AddressGroups = addressGroups
@@ -564,7 +638,7 @@ func (n *NetworkPolicyController) toAntreaPeer(peers []networkingv1.NetworkPolic
 			}
 			ipBlocks = append(ipBlocks, *ipBlock)
 		} else {
-			normalizedUID := n.createAddressGroup(peer, np)
+			normalizedUID := n.createAddressGroup(np.Namespace, peer.PodSelector, peer.NamespaceSelector)
 			addressGroups = append(addressGroups, normalizedUID)
 		}
 	}
@@ -781,6 +855,10 @@ func (n *NetworkPolicyController) addNamespace(obj interface{}) {
 	for group := range addressGroupKeys {
 		n.enqueueAddressGroup(group)
 	}
+	appliedToGroupKeys := n.filterAppliedToGroupsForNamespace(namespace)
+	for group := range appliedToGroupKeys {
+		n.enqueueAppliedToGroup(group)
+	}
 }
 
 // updateNamespace retrieves all AddressGroups which match the current and old
@@ -807,6 +885,13 @@ func (n *NetworkPolicyController) updateNamespace(oldObj, curObj interface{}) {
 	for group := range addressGroupKeys {
 		n.enqueueAddressGroup(group)
 	}
+	// Find AppliedToGroups matching the new and old Namespace's labels.
+	curAppliedToGroupKeySet := n.filterAppliedToGroupsForNamespace(curNamespace)
+	oldAppliedToGroupKeySet := n.filterAppliedToGroupsForNamespace(oldNamespace)
+	appliedToGroupKeys := oldAppliedToGroupKeySet.Difference(curAppliedToGroupKeySet).Union(curAppliedToGroupKeySet.Difference(oldAppliedToGroupKeySet))
+	for group := range appliedToGroupKeys {
+		n.enqueueAppliedToGroup(group)
+	}
 }
 
 // deleteNamespace retrieves all AddressGroups which match the Namespace's
@@ -834,6 +919,10 @@ func (n *NetworkPolicyController) deleteNamespace(old interface{}) {
 	for group := range addressGroupKeys {
 		n.enqueueAddressGroup(group)
 	}
+	appliedToGroupKeys := n.filterAppliedToGroupsForNamespace(namespace)
+	for group := range appliedToGroupKeys {
+		n.enqueueAppliedToGroup(group)
+	}
 }
 
 func (n *NetworkPolicyController) enqueueAppliedToGroup(key string) {
@@ -913,7 +1002,12 @@ func (n *NetworkPolicyController) Run(stopCh <-chan struct{}) {
 	defer klog.Info("Shutting down NetworkPolicy controller")
 
 	klog.Info("Waiting for caches to sync for NetworkPolicy controller")
-	if !cache.WaitForCacheSync(stopCh, n.podListerSynced, n.namespaceListerSynced, n.networkPolicyListerSynced) {
+	cacheSyncs := []cache.InformerSynced{n.podListerSynced, n.namespaceListerSynced, n.networkPolicyListerSynced}
+	// Only wait for cnpListerSynced when ClusterNetworkPolicy feature gate is enabled.
+	if features.DefaultFeatureGate.Enabled(features.ClusterNetworkPolicy) {
+		cacheSyncs = append(cacheSyncs, n.cnpListerSynced)
+	}
+	if !cache.WaitForCacheSync(stopCh, cacheSyncs...) {
 		klog.Error("Unable to sync caches for NetworkPolicy controller")
 		return
 	}
@@ -1069,25 +1163,7 @@ func (n *NetworkPolicyController) syncAddressGroup(key string) error {
 		addrGroupNodeNames = addrGroupNodeNames.Union(internalNP.SpanMeta.NodeNames)
 	}
 	// Find all Pods matching its selectors and update store.
-	groupSelector := addressGroup.Selector
-	if groupSelector.Namespace != "" {
-		// Namespace presence indicates Pods must be selected from the same Namespace.
-		pods, _ = n.podLister.Pods(groupSelector.Namespace).List(groupSelector.PodSelector)
-	} else if groupSelector.NamespaceSelector != nil && groupSelector.PodSelector != nil {
-		// Pods must be selected from Namespaces matching nsSelector.
-		namespaces, _ := n.namespaceLister.List(groupSelector.NamespaceSelector)
-		for _, ns := range namespaces {
-			nsPods, _ := n.podLister.Pods(ns.Name).List(groupSelector.PodSelector)
-			pods = append(pods, nsPods...)
-		}
-	} else if groupSelector.NamespaceSelector != nil {
-		// All the Pods from Namespaces matching the nsSelector must be selected.
-		namespaces, _ := n.namespaceLister.List(groupSelector.NamespaceSelector)
-		for _, ns := range namespaces {
-			nsPods, _ := n.podLister.Pods(ns.Name).List(labels.Everything())
-			pods = append(pods, nsPods...)
-		}
-	}
+	pods = n.processSelector(addressGroup.Selector)
 	podSet := networking.GroupMemberPodSet{}
 	for _, pod := range pods {
 		if pod.Status.PodIP == "" {
@@ -1108,6 +1184,30 @@ func (n *NetworkPolicyController) syncAddressGroup(key string) error {
 	return nil
 }
 
+// processSelector retrieves all Pods matching the given GroupSelector.
+func (n *NetworkPolicyController) processSelector(groupSelector antreatypes.GroupSelector) []*v1.Pod {
+	var pods []*v1.Pod
+	if groupSelector.Namespace != "" {
+		// Namespace presence indicates Pods must be selected from the same Namespace.
+		pods, _ = n.podLister.Pods(groupSelector.Namespace).List(groupSelector.PodSelector)
+	} else if groupSelector.NamespaceSelector != nil && groupSelector.PodSelector != nil {
+		// Pods must be selected from Namespaces matching nsSelector.
+		namespaces, _ := n.namespaceLister.List(groupSelector.NamespaceSelector)
+		for _, ns := range namespaces {
+			nsPods, _ := n.podLister.Pods(ns.Name).List(groupSelector.PodSelector)
+			pods = append(pods, nsPods...)
+		}
+	} else if groupSelector.NamespaceSelector != nil {
+		// All the Pods from Namespaces matching the nsSelector must be selected.
+		namespaces, _ := n.namespaceLister.List(groupSelector.NamespaceSelector)
+		for _, ns := range namespaces {
+			nsPods, _ := n.podLister.Pods(ns.Name).List(labels.Everything())
+			pods = append(pods, nsPods...)
+		}
+	}
+	return pods
+}
+
 // podToMemberPod is util function to convert a Pod to a GroupMemberPod type.
 // A networking.NamedPort item will be set in the GroupMemberPod, only if the
 // Pod contains a Port with the name field set. Depending on the input, the
@@ -1129,6 +1229,9 @@ func podToMemberPod(pod *v1.Pod, includeIP, includePodRef bool) *networking.Grou
 
 	if includeIP {
 		memberPod.IP = ipStrToIPAddress(pod.Status.PodIP)
+		for _, podIP := range pod.Status.PodIPs {
+			memberPod.IPs = append(memberPod.IPs, ipStrToIPAddress(podIP.IP))
+		}
 	}
 
 	if includePodRef {
@@ -1161,10 +1264,9 @@ func (n *NetworkPolicyController) syncAppliedToGroup(key string) error {
 		return nil
 	}
 	appliedToGroup := appliedToGroupObj.(*antreatypes.AppliedToGroup)
-	// AppliedToGroup will not have NamespaceSelector.
-	podSelector := appliedToGroup.Selector.PodSelector
-	// Retrieve all Pods matching the podSelector.
-	pods, err = n.podLister.Pods(appliedToGroup.Selector.Namespace).List(podSelector)
+	// Retrieve all Pods matching the GroupSelector. AppliedToGroups created
+	// for ClusterNetworkPolicies may have a NamespaceSelector.
+	pods = n.processSelector(appliedToGroup.Selector)
 	scheduledPodNum := 0
 	for _, pod := range pods {
 		if pod.Spec.NodeName == "" {
@@ -1249,8 +1351,11 @@ func (n *NetworkPolicyController) syncInternalNetworkPolicy(key string) error {
 		UID:             internalNP.UID,
 		Name:            internalNP.Name,
 		Namespace:       internalNP.Namespace,
+		Generation:      internalNP.Generation,
 		Rules:           internalNP.Rules,
 		AppliedToGroups: internalNP.AppliedToGroups,
+		Priority:        internalNP.Priority,
+		TierPriority:    internalNP.TierPriority,
 		SpanMeta:        antreatypes.SpanMeta{NodeNames: nodeNames},
 	}
 	klog.V(4).Infof("Updating internal NetworkPolicy %s with %d Nodes", key, nodeNames.Len())
@@ -37,6 +37,8 @@ import (
 
 	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
 	"github.com/vmware-tanzu/antrea/pkg/apiserver/storage"
+	crdfake "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/fake"
+	crdinformers "github.com/vmware-tanzu/antrea/pkg/client/informers/externalversions"
 	"github.com/vmware-tanzu/antrea/pkg/controller/networkpolicy/store"
 	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
 )
@@ -50,31 +52,38 @@ type networkPolicyController struct {
 	podStore                   cache.Store
 	namespaceStore             cache.Store
 	networkPolicyStore         cache.Store
+	cnpStore                   cache.Store
 	appliedToGroupStore        storage.Interface
 	addressGroupStore          storage.Interface
 	internalNetworkPolicyStore storage.Interface
 	informerFactory            informers.SharedInformerFactory
+	crdInformerFactory         crdinformers.SharedInformerFactory
 }
 
 func newController(objects ...runtime.Object) (*fake.Clientset, *networkPolicyController) {
 	client := newClientset(objects...)
+	crdClient := crdfake.NewSimpleClientset()
 	informerFactory := informers.NewSharedInformerFactory(client, informerDefaultResync)
+	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, informerDefaultResync)
 	appliedToGroupStore := store.NewAppliedToGroupStore()
 	addressGroupStore := store.NewAddressGroupStore()
 	internalNetworkPolicyStore := store.NewNetworkPolicyStore()
-	npController := NewNetworkPolicyController(client, informerFactory.Core().V1().Pods(), informerFactory.Core().V1().Namespaces(), informerFactory.Networking().V1().NetworkPolicies(), addressGroupStore, appliedToGroupStore, internalNetworkPolicyStore)
+	npController := NewNetworkPolicyController(client, informerFactory.Core().V1().Pods(), informerFactory.Core().V1().Namespaces(), informerFactory.Networking().V1().NetworkPolicies(), crdInformerFactory.Security().V1alpha1().ClusterNetworkPolicies(), addressGroupStore, appliedToGroupStore, internalNetworkPolicyStore)
 	npController.podListerSynced = alwaysReady
 	npController.namespaceListerSynced = alwaysReady
 	npController.networkPolicyListerSynced = alwaysReady
+	npController.cnpListerSynced = alwaysReady
 	return client, &networkPolicyController{
 		npController,
 		informerFactory.Core().V1().Pods().Informer().GetStore(),
 		informerFactory.Core().V1().Namespaces().Informer().GetStore(),
 		informerFactory.Networking().V1().NetworkPolicies().Informer().GetStore(),
+		crdInformerFactory.Security().V1alpha1().ClusterNetworkPolicies().Informer().GetStore(),
 		appliedToGroupStore,
 		addressGroupStore,
 		internalNetworkPolicyStore,
 		informerFactory,
+		crdInformerFactory,
 	}
 }
 
@@ -127,6 +136,7 @@ func TestAddNetworkPolicy(t *testing.T) {
 				Name:      "npA",
 				Namespace: "nsA",
 				Rules: []networking.NetworkPolicyRule{{
+					Name:      "ingress-0",
 					Direction: networking.DirectionIn,
 					From:      matchAllPeer,
 					Services:  nil,
@@ -151,6 +161,7 @@ func TestAddNetworkPolicy(t *testing.T) {
 				Name:      "npB",
 				Namespace: "nsA",
 				Rules: []networking.NetworkPolicyRule{{
+					Name:      "egress-0",
 					Direction: networking.DirectionOut,
 					To:        matchAllPeerEgress,
 					Services:  nil,
@@ -246,6 +257,7 @@ func TestAddNetworkPolicy(t *testing.T) {
 				Namespace: "nsA",
 				Rules: []networking.NetworkPolicyRule{
 					{
+						Name:      "ingress-0",
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
 							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC).NormalizedName)},
@@ -258,6 +270,7 @@ func TestAddNetworkPolicy(t *testing.T) {
 						},
 					},
 					{
+						Name:      "egress-0",
 						Direction: networking.DirectionOut,
 						To: networking.NetworkPolicyPeer{
 							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC).NormalizedName)},
@@ -315,6 +328,7 @@ func TestAddNetworkPolicy(t *testing.T) {
 				Namespace: "nsA",
 				Rules: []networking.NetworkPolicyRule{
 					{
+						Name:      "ingress-0",
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
 							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, nil).NormalizedName)},
@@ -327,6 +341,7 @@ func TestAddNetworkPolicy(t *testing.T) {
 						},
 					},
 					{
+						Name:      "ingress-1",
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
 							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", nil, &selectorC).NormalizedName)},
@@ -461,12 +476,14 @@ func TestUpdateNetworkPolicy(t *testing.T) {
 				Namespace: "nsA",
 				Rules: []networking.NetworkPolicyRule{
 					{
+						Name:      "ingress-0",
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
 							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC).NormalizedName)},
 						},
 					},
 					{
+						Name:      "egress-0",
 						Direction: networking.DirectionOut,
 						To: networking.NetworkPolicyPeer{
 							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, nil).NormalizedName)},
@@ -502,6 +519,7 @@ func TestUpdateNetworkPolicy(t *testing.T) {
 				Namespace: "nsA",
 				Rules: []networking.NetworkPolicyRule{
 					{
+						Name:      "egress-0",
 						Direction: networking.DirectionOut,
 						To: networking.NetworkPolicyPeer{
 							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC).NormalizedName)},
@@ -537,6 +555,7 @@ func TestUpdateNetworkPolicy(t *testing.T) {
 				Namespace: "nsA",
 				Rules: []networking.NetworkPolicyRule{
 					{
+						Name:      "ingress-0",
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
 							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC).NormalizedName)},
@@ -606,18 +625,21 @@ func TestUpdateNetworkPolicy(t *testing.T) {
 				Namespace: "nsA",
 				Rules: []networking.NetworkPolicyRule{
 					{
+						Name:      "ingress-0",
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
 							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC).NormalizedName)},
 						},
 					},
 					{
+						Name:      "ingress-1",
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
 							AddressGroups: []string{getNormalizedUID(toGroupSelector("", nil, &selectorA).NormalizedName)},
 						},
 					},
 					{
+						Name:      "egress-0",
 						Direction: networking.DirectionOut,
 						To: networking.NetworkPolicyPeer{
 							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, nil).NormalizedName)},
@@ -662,12 +684,14 @@ func TestUpdateNetworkPolicy(t *testing.T) {
 				Namespace: "nsA",
 				Rules: []networking.NetworkPolicyRule{
 					{
+						Name:      "ingress-0",
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
 							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC).NormalizedName)},
 						},
 					},
 					{
+						Name:      "egress-0",
 						Direction: networking.DirectionOut,
 						To: networking.NetworkPolicyPeer{
 							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorA, nil).NormalizedName)},
@@ -1413,6 +1437,18 @@ func TestToGroupSelector(t *testing.T) {
 				NormalizedName:    generateNormalizedName("", pLabelSelector, nLabelSelector),
 			},
 		},
+		{
+			"to-group-selector-cluster-scoped-pod-selector",
+			"",
+			&pSelector,
+			nil,
+			&antreatypes.GroupSelector{
+				Namespace:         "",
+				NamespaceSelector: labels.Everything(),
+				PodSelector:       pLabelSelector,
+				NormalizedName:    generateNormalizedName("", pLabelSelector, labels.Everything()),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1794,6 +1830,7 @@ func TestProcessNetworkPolicy(t *testing.T) {
 				Name:      "npA",
 				Namespace: "nsA",
 				Rules: []networking.NetworkPolicyRule{{
+					Name:      "ingress-0",
 					Direction: networking.DirectionIn,
 					From:      matchAllPeer,
 					Services:  nil,
@@ -1866,6 +1903,7 @@ func TestProcessNetworkPolicy(t *testing.T) {
 				Namespace: "nsA",
 				Rules: []networking.NetworkPolicyRule{
 					{
+						Name:      "ingress-0",
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
 							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC).NormalizedName)},
@@ -1878,6 +1916,7 @@ func TestProcessNetworkPolicy(t *testing.T) {
 						},
 					},
 					{
+						Name:      "egress-0",
 						Direction: networking.DirectionOut,
 						To: networking.NetworkPolicyPeer{
 							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC).NormalizedName)},
@@ -1935,6 +1974,7 @@ func TestProcessNetworkPolicy(t *testing.T) {
 				Namespace: "nsA",
 				Rules: []networking.NetworkPolicyRule{
 					{
+						Name:      "ingress-0",
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
 							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, nil).NormalizedName)},
@@ -1947,6 +1987,7 @@ func TestProcessNetworkPolicy(t *testing.T) {
 						},
 					},
 					{
+						Name:      "ingress-1",
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
 							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", nil, &selectorC).NormalizedName)},
@@ -1964,6 +2005,49 @@ func TestProcessNetworkPolicy(t *testing.T) {
 			expectedAppliedToGroups: 1,
 			expectedAddressGroups:   2,
 		},
+		{
+			name: "rules-with-logging-enabled",
+			inputPolicy: &networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "nsA", Name: "npA", UID: "uidA",
+					Annotations: map[string]string{EnableLoggingAnnotation: "true"}},
+				Spec: networkingv1.NetworkPolicySpec{
+					PodSelector: selectorA,
+					PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+					Ingress: []networkingv1.NetworkPolicyIngressRule{
+						{
+							From: []networkingv1.NetworkPolicyPeer{
+								{
+									PodSelector: &selectorB,
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedPolicy: &antreatypes.NetworkPolicy{
+				UID:       "uidA",
+				Name:      "npA",
+				Namespace: "nsA",
+				Rules: []networking.NetworkPolicyRule{
+					{
+						Name:      "ingress-0",
+						Direction: networking.DirectionIn,
+						From: networking.NetworkPolicyPeer{
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, nil).NormalizedName)},
+						},
+						EnableLogging: true,
+					},
+					{
+						Direction:     networking.DirectionOut,
+						To:            denyAllEgressRule.To,
+						EnableLogging: true,
+					},
+				},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorA, nil).NormalizedName)},
+			},
+			expectedAppliedToGroups: 1,
+			expectedAddressGroups:   1,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
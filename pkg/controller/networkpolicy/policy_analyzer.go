@@ -0,0 +1,419 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package networkpolicy
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/storage"
+	"github.com/vmware-tanzu/antrea/pkg/controller/networkpolicy/store"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+)
+
+// PolicyAnalyzer answers queries about the NetworkPolicies affecting Pods and
+// the traffic they allow, based on the internal NetworkPolicies,
+// AppliedToGroups and AddressGroups computed by the NetworkPolicyController.
+// Unlike the agents, which only know about the Pods running on their Nodes, it
+// can answer for any Pod of the cluster.
+type PolicyAnalyzer struct {
+	podLister           corelisters.PodLister
+	addressGroupStore   storage.Interface
+	appliedToGroupStore storage.Interface
+	networkPolicyStore  storage.Interface
+}
+
+// NewPolicyAnalyzer returns a new *PolicyAnalyzer.
+func NewPolicyAnalyzer(podLister corelisters.PodLister, addressGroupStore, appliedToGroupStore, networkPolicyStore storage.Interface) *PolicyAnalyzer {
+	return &PolicyAnalyzer{
+		podLister:           podLister,
+		addressGroupStore:   addressGroupStore,
+		appliedToGroupStore: appliedToGroupStore,
+		networkPolicyStore:  networkPolicyStore,
+	}
+}
+
+// endpoint is a source or a destination of the traffic evaluated by the
+// PolicyAnalyzer. pod is nil if the endpoint is an IP address which doesn't
+// belong to a Pod.
+type endpoint struct {
+	pod *corev1.Pod
+	ips []net.IP
+	// addressGroups is the set of names of the AddressGroups the endpoint
+	// is a member of.
+	addressGroups sets.String
+}
+
+// QueryEndpoint returns the NetworkPolicies applied to the provided Pod and
+// the rules which select it as a peer.
+func (a *PolicyAnalyzer) QueryEndpoint(namespace, name string) (*networking.EndpointQuery, error) {
+	pod, err := a.podLister.Pods(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+	ep := a.newPodEndpoint(pod)
+	result := &networking.EndpointQuery{}
+	result.Name = name
+	result.Namespace = namespace
+	for _, policy := range a.getAppliedPolicies(pod) {
+		result.AppliedPolicies = append(result.AppliedPolicies, newPolicyReference(policy))
+	}
+	policies := map[string]*antreatypes.NetworkPolicy{}
+	for groupName := range ep.addressGroups {
+		objs, _ := a.networkPolicyStore.GetByIndex(store.AddressGroupIndex, groupName)
+		for _, obj := range objs {
+			policy := obj.(*antreatypes.NetworkPolicy)
+			policies[string(policy.UID)] = policy
+		}
+	}
+	for _, policy := range sortPolicies(policies) {
+		for i, rule := range policy.Rules {
+			peer := rule.From
+			if rule.Direction == networking.DirectionOut {
+				peer = rule.To
+			}
+			if peerHasAddressGroup(peer, ep.addressGroups) {
+				result.PeerRules = append(result.PeerRules, newRuleReference(policy, i))
+			}
+		}
+	}
+	return result, nil
+}
+
+// QueryConnectivity evaluates whether the traffic described by the provided
+// spec is allowed by the egress policies of its source and the ingress
+// policies of its destination.
+func (a *PolicyAnalyzer) QueryConnectivity(spec *networking.ConnectivityQuerySpec) (*networking.ConnectivityQueryStatus, error) {
+	src, err := a.newEndpoint(&spec.Source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source: %v", err)
+	}
+	dst, err := a.newEndpoint(&spec.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination: %v", err)
+	}
+	protocol := spec.Protocol
+	if protocol == "" {
+		protocol = networking.ProtocolTCP
+	}
+	status := &networking.ConnectivityQueryStatus{Allowed: true}
+	if src.pod != nil {
+		status.Egress = a.evaluate(networking.DirectionOut, src, dst, dst, protocol, spec.Port)
+		status.Allowed = status.Allowed && status.Egress.Allowed
+	}
+	if dst.pod != nil {
+		status.Ingress = a.evaluate(networking.DirectionIn, dst, src, dst, protocol, spec.Port)
+		status.Allowed = status.Allowed && status.Ingress.Allowed
+	}
+	return status, nil
+}
+
+// evaluate returns the verdict of the policies applied to subject for the
+// traffic from or to peer in the provided direction. dst is the destination
+// of the traffic, whose container ports are used to resolve named ports.
+func (a *PolicyAnalyzer) evaluate(direction networking.Direction, subject, peer, dst *endpoint, protocol networking.Protocol, port int32) *networking.ConnectivityQueryVerdict {
+	type policyRule struct {
+		policy *antreatypes.NetworkPolicy
+		index  int
+	}
+	var cnpRules, k8sRules []policyRule
+	for _, policy := range a.getAppliedPolicies(subject.pod) {
+		for i := range policy.Rules {
+			if policy.Rules[i].Direction != direction {
+				continue
+			}
+			if policy.TierPriority != nil {
+				cnpRules = append(cnpRules, policyRule{policy, i})
+			} else {
+				k8sRules = append(k8sRules, policyRule{policy, i})
+			}
+		}
+	}
+	// The rules of ClusterNetworkPolicies are enforced first, in the order of
+	// their tier priority, policy priority and rule priority.
+	sort.SliceStable(cnpRules, func(i, j int) bool {
+		pi, pj := cnpRules[i].policy, cnpRules[j].policy
+		if *pi.TierPriority != *pj.TierPriority {
+			return *pi.TierPriority < *pj.TierPriority
+		}
+		if *pi.Priority != *pj.Priority {
+			return *pi.Priority < *pj.Priority
+		}
+		return pi.Rules[cnpRules[i].index].Priority < pj.Rules[cnpRules[j].index].Priority
+	})
+	matches := func(rule *networking.NetworkPolicyRule) bool {
+		p := rule.From
+		if direction == networking.DirectionOut {
+			p = rule.To
+		}
+		return peerMatches(p, peer) && servicesMatch(rule.Services, dst.pod, protocol, port)
+	}
+	for _, r := range cnpRules {
+		rule := &r.policy.Rules[r.index]
+		if !matches(rule) {
+			continue
+		}
+		ref := newRuleReference(r.policy, r.index)
+		return &networking.ConnectivityQueryVerdict{
+			Allowed: rule.Action == nil || *rule.Action == secv1alpha1.RuleActionAllow,
+			Rule:    &ref,
+		}
+	}
+	// A Pod is isolated by the K8s NetworkPolicies which have a rule in the
+	// direction, including the deny-all rules which match no peer.
+	verdict := &networking.ConnectivityQueryVerdict{Allowed: len(k8sRules) == 0, Isolated: len(k8sRules) > 0}
+	for _, r := range k8sRules {
+		if matches(&r.policy.Rules[r.index]) {
+			ref := newRuleReference(r.policy, r.index)
+			verdict.Allowed = true
+			verdict.Rule = &ref
+			break
+		}
+	}
+	return verdict
+}
+
+// getAppliedPolicies returns the NetworkPolicies applied to the provided Pod,
+// sorted by namespace and name.
+func (a *PolicyAnalyzer) getAppliedPolicies(pod *corev1.Pod) []*antreatypes.NetworkPolicy {
+	policies := map[string]*antreatypes.NetworkPolicy{}
+	for _, obj := range a.appliedToGroupStore.List() {
+		group := obj.(*antreatypes.AppliedToGroup)
+		if !podSetHasPod(group.PodsByNode[pod.Spec.NodeName], pod) {
+			continue
+		}
+		objs, _ := a.networkPolicyStore.GetByIndex(store.AppliedToGroupIndex, group.Name)
+		for _, obj := range objs {
+			policy := obj.(*antreatypes.NetworkPolicy)
+			policies[string(policy.UID)] = policy
+		}
+	}
+	return sortPolicies(policies)
+}
+
+// newEndpoint validates the provided peer and returns the corresponding
+// endpoint.
+func (a *PolicyAnalyzer) newEndpoint(peer *networking.ConnectivityQueryPeer) (*endpoint, error) {
+	if (peer.Pod == nil) == (peer.IP == "") {
+		return nil, fmt.Errorf("exactly one of Pod and IP must be set")
+	}
+	if peer.Pod != nil {
+		pod, err := a.podLister.Pods(peer.Pod.Namespace).Get(peer.Pod.Name)
+		if err != nil {
+			return nil, err
+		}
+		return a.newPodEndpoint(pod), nil
+	}
+	ip := net.ParseIP(peer.IP)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address %s", peer.IP)
+	}
+	ep := &endpoint{ips: []net.IP{ip}, addressGroups: sets.NewString()}
+	for _, obj := range a.addressGroupStore.List() {
+		group := obj.(*antreatypes.AddressGroup)
+		for _, member := range group.Pods {
+			if memberHasIP(member, ip) {
+				ep.addressGroups.Insert(group.Name)
+				break
+			}
+		}
+	}
+	return ep, nil
+}
+
+func (a *PolicyAnalyzer) newPodEndpoint(pod *corev1.Pod) *endpoint {
+	ep := &endpoint{pod: pod, addressGroups: sets.NewString()}
+	for _, podIP := range pod.Status.PodIPs {
+		if ip := net.ParseIP(podIP.IP); ip != nil {
+			ep.ips = append(ep.ips, ip)
+		}
+	}
+	if len(ep.ips) == 0 {
+		if ip := net.ParseIP(pod.Status.PodIP); ip != nil {
+			ep.ips = append(ep.ips, ip)
+		}
+	}
+	for _, obj := range a.addressGroupStore.List() {
+		group := obj.(*antreatypes.AddressGroup)
+		if podSetHasPod(group.Pods, pod) {
+			ep.addressGroups.Insert(group.Name)
+		}
+	}
+	return ep
+}
+
+func podSetHasPod(podSet networking.GroupMemberPodSet, pod *corev1.Pod) bool {
+	for _, member := range podSet {
+		if member.Pod != nil && member.Pod.Namespace == pod.Namespace && member.Pod.Name == pod.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func memberHasIP(member *networking.GroupMemberPod, ip net.IP) bool {
+	if net.IP(member.IP).Equal(ip) {
+		return true
+	}
+	for _, memberIP := range member.IPs {
+		if net.IP(memberIP).Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func peerHasAddressGroup(peer networking.NetworkPolicyPeer, addressGroups sets.String) bool {
+	for _, group := range peer.AddressGroups {
+		if addressGroups.Has(group) {
+			return true
+		}
+	}
+	return false
+}
+
+// peerMatches returns whether the provided NetworkPolicyPeer selects the
+// endpoint, either through an AddressGroup or an IPBlock.
+func peerMatches(peer networking.NetworkPolicyPeer, ep *endpoint) bool {
+	if peerHasAddressGroup(peer, ep.addressGroups) {
+		return true
+	}
+	for _, ipBlock := range peer.IPBlocks {
+		for _, ip := range ep.ips {
+			if ipBlockContains(ipBlock, ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func ipBlockContains(ipBlock networking.IPBlock, ip net.IP) bool {
+	if !ipNetContains(ipBlock.CIDR, ip) {
+		return false
+	}
+	for _, except := range ipBlock.Except {
+		if ipNetContains(except, ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func ipNetContains(ipNet networking.IPNet, ip net.IP) bool {
+	bits := 8 * net.IPv4len
+	if net.IP(ipNet.IP).To4() == nil {
+		bits = 8 * net.IPv6len
+	}
+	// An IPv4 address never matches an IPv6 CIDR and vice versa.
+	if (ip.To4() == nil) != (bits == 8*net.IPv6len) {
+		return false
+	}
+	n := net.IPNet{IP: net.IP(ipNet.IP), Mask: net.CIDRMask(int(ipNet.PrefixLength), bits)}
+	return n.Contains(ip)
+}
+
+// servicesMatch returns whether the provided Services match the protocol and
+// port. A nil slice of Services matches all traffic. Named ports are resolved
+// on the container ports of the destination Pod, if any. As the query carries
+// no ICMP type or code, an ICMP Service matches all ICMP traffic.
+func servicesMatch(services []networking.Service, dstPod *corev1.Pod, protocol networking.Protocol, port int32) bool {
+	if len(services) == 0 {
+		return true
+	}
+	for _, service := range services {
+		serviceProtocol := networking.ProtocolTCP
+		if service.Protocol != nil {
+			serviceProtocol = *service.Protocol
+		}
+		if serviceProtocol != protocol {
+			continue
+		}
+		if service.Port == nil || serviceProtocol == networking.ProtocolICMP {
+			return true
+		}
+		if port == 0 {
+			continue
+		}
+		if service.Port.Type == intstr.Int {
+			endPort := service.Port.IntVal
+			if service.EndPort != nil && *service.EndPort > endPort {
+				endPort = *service.EndPort
+			}
+			if port >= service.Port.IntVal && port <= endPort {
+				return true
+			}
+			continue
+		}
+		if dstPod != nil && podHasNamedPort(dstPod, service.Port.StrVal, protocol, port) {
+			return true
+		}
+	}
+	return false
+}
+
+func podHasNamedPort(pod *corev1.Pod, name string, protocol networking.Protocol, port int32) bool {
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			containerProtocol := containerPort.Protocol
+			if containerProtocol == "" {
+				containerProtocol = corev1.ProtocolTCP
+			}
+			if containerPort.Name == name && containerPort.ContainerPort == port && string(containerProtocol) == string(protocol) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sortPolicies(policies map[string]*antreatypes.NetworkPolicy) []*antreatypes.NetworkPolicy {
+	sorted := make([]*antreatypes.NetworkPolicy, 0, len(policies))
+	for _, policy := range policies {
+		sorted = append(sorted, policy)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Namespace != sorted[j].Namespace {
+			return sorted[i].Namespace < sorted[j].Namespace
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+func newPolicyReference(policy *antreatypes.NetworkPolicy) networking.NetworkPolicyReference {
+	return networking.NetworkPolicyReference{
+		Namespace: policy.Namespace,
+		Name:      policy.Name,
+		UID:       policy.UID,
+	}
+}
+
+func newRuleReference(policy *antreatypes.NetworkPolicy, index int) networking.NetworkPolicyRuleReference {
+	rule := &policy.Rules[index]
+	return networking.NetworkPolicyRuleReference{
+		Policy:    newPolicyReference(policy),
+		Direction: rule.Direction,
+		Index:     int32(index),
+		Action:    rule.Action,
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package networkpolicy
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/controller/networkpolicy/store"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+)
+
+func newAnalyzerPod(namespace, name, nodeName, ip string, ports ...corev1.ContainerPort) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: corev1.PodSpec{
+			NodeName:   nodeName,
+			Containers: []corev1.Container{{Name: "c", Ports: ports}},
+		},
+		Status: corev1.PodStatus{PodIP: ip},
+	}
+}
+
+func newMemberPod(pod *corev1.Pod) *networking.GroupMemberPod {
+	return &networking.GroupMemberPod{
+		Pod: &networking.PodReference{Name: pod.Name, Namespace: pod.Namespace},
+		IP:  networking.IPAddress(net.ParseIP(pod.Status.PodIP)),
+	}
+}
+
+func TestPolicyAnalyzer(t *testing.T) {
+	pod1 := newAnalyzerPod("ns1", "pod1", "node1", "10.0.1.1", corev1.ContainerPort{Name: "http", ContainerPort: 80})
+	pod2 := newAnalyzerPod("ns1", "pod2", "node2", "10.0.2.1")
+	pod3 := newAnalyzerPod("ns2", "pod3", "node1", "10.0.1.2")
+	podIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, pod := range []*corev1.Pod{pod1, pod2, pod3} {
+		podIndexer.Add(pod)
+	}
+
+	appliedToGroupStore := store.NewAppliedToGroupStore()
+	appliedToGroupStore.Create(&antreatypes.AppliedToGroup{Name: "atg1", UID: "atg1",
+		PodsByNode: map[string]networking.GroupMemberPodSet{"node1": networking.NewGroupMemberPodSet(newMemberPod(pod1))}})
+	appliedToGroupStore.Create(&antreatypes.AppliedToGroup{Name: "atg2", UID: "atg2",
+		PodsByNode: map[string]networking.GroupMemberPodSet{"node2": networking.NewGroupMemberPodSet(newMemberPod(pod2))}})
+	addressGroupStore := store.NewAddressGroupStore()
+	addressGroupStore.Create(&antreatypes.AddressGroup{Name: "ag1", UID: "ag1", Pods: networking.NewGroupMemberPodSet(newMemberPod(pod2))})
+	addressGroupStore.Create(&antreatypes.AddressGroup{Name: "ag2", UID: "ag2", Pods: networking.NewGroupMemberPodSet(newMemberPod(pod3))})
+
+	protocolTCP := networking.ProtocolTCP
+	portHTTP := intstr.FromString("http")
+	port80 := intstr.FromInt(80)
+	dropAction := secv1alpha1.RuleActionDrop
+	tierPriority := networking.TierPriority(1)
+	priority := float64(1)
+	// np1 allows ingress traffic from pod2 to the "http" port of pod1.
+	np1 := &antreatypes.NetworkPolicy{
+		UID:       "uid1",
+		Name:      "np1",
+		Namespace: "ns1",
+		Rules: []networking.NetworkPolicyRule{{
+			Direction: networking.DirectionIn,
+			From:      networking.NetworkPolicyPeer{AddressGroups: []string{"ag1"}},
+			Services:  []networking.Service{{Protocol: &protocolTCP, Port: &portHTTP}},
+		}},
+		AppliedToGroups: []string{"atg1"},
+	}
+	// np2 only allows egress traffic from pod2 to port 80 of 10.0.1.0/24,
+	// except 10.0.1.2.
+	np2 := &antreatypes.NetworkPolicy{
+		UID:       "uid2",
+		Name:      "np2",
+		Namespace: "ns1",
+		Rules: []networking.NetworkPolicyRule{{
+			Direction: networking.DirectionOut,
+			To: networking.NetworkPolicyPeer{IPBlocks: []networking.IPBlock{{
+				CIDR:   networking.IPNet{IP: networking.IPAddress(net.ParseIP("10.0.1.0")), PrefixLength: 24},
+				Except: []networking.IPNet{{IP: networking.IPAddress(net.ParseIP("10.0.1.2")), PrefixLength: 32}},
+			}}},
+			Services: []networking.Service{{Port: &port80}},
+		}},
+		AppliedToGroups: []string{"atg2"},
+	}
+	// cnp1 drops ingress traffic from pod3 to pod1.
+	cnp1 := &antreatypes.NetworkPolicy{
+		UID:  "uid3",
+		Name: "cnp1",
+		Rules: []networking.NetworkPolicyRule{{
+			Direction: networking.DirectionIn,
+			From:      networking.NetworkPolicyPeer{AddressGroups: []string{"ag2"}},
+			Action:    &dropAction,
+		}},
+		AppliedToGroups: []string{"atg1"},
+		Priority:        &priority,
+		TierPriority:    &tierPriority,
+	}
+	networkPolicyStore := store.NewNetworkPolicyStore()
+	for _, policy := range []*antreatypes.NetworkPolicy{np1, np2, cnp1} {
+		networkPolicyStore.Create(policy)
+	}
+	analyzer := NewPolicyAnalyzer(corelisters.NewPodLister(podIndexer), addressGroupStore, appliedToGroupStore, networkPolicyStore)
+
+	np1Ref := networking.NetworkPolicyReference{Namespace: "ns1", Name: "np1", UID: types.UID("uid1")}
+	np2Ref := networking.NetworkPolicyReference{Namespace: "ns1", Name: "np2", UID: types.UID("uid2")}
+	cnp1Ref := networking.NetworkPolicyReference{Name: "cnp1", UID: types.UID("uid3")}
+	np1Rule := &networking.NetworkPolicyRuleReference{Policy: np1Ref, Direction: networking.DirectionIn}
+	np2Rule := &networking.NetworkPolicyRuleReference{Policy: np2Ref, Direction: networking.DirectionOut}
+	cnp1Rule := &networking.NetworkPolicyRuleReference{Policy: cnp1Ref, Direction: networking.DirectionIn, Action: &dropAction}
+
+	t.Run("endpoint queries", func(t *testing.T) {
+		result, err := analyzer.QueryEndpoint("ns1", "pod1")
+		require.NoError(t, err)
+		assert.Equal(t, []networking.NetworkPolicyReference{cnp1Ref, np1Ref}, result.AppliedPolicies)
+		assert.Empty(t, result.PeerRules)
+
+		result, err = analyzer.QueryEndpoint("ns1", "pod2")
+		require.NoError(t, err)
+		assert.Equal(t, []networking.NetworkPolicyReference{np2Ref}, result.AppliedPolicies)
+		assert.Equal(t, []networking.NetworkPolicyRuleReference{*np1Rule}, result.PeerRules)
+
+		result, err = analyzer.QueryEndpoint("ns2", "pod3")
+		require.NoError(t, err)
+		assert.Empty(t, result.AppliedPolicies)
+		assert.Equal(t, []networking.NetworkPolicyRuleReference{*cnp1Rule}, result.PeerRules)
+
+		_, err = analyzer.QueryEndpoint("ns2", "pod4")
+		assert.Error(t, err)
+	})
+
+	tests := []struct {
+		name           string
+		spec           networking.ConnectivityQuerySpec
+		expectedStatus *networking.ConnectivityQueryStatus
+		expectedErr    bool
+	}{
+		{
+			name: "allowed by K8s NetworkPolicies",
+			spec: networking.ConnectivityQuerySpec{
+				Source:      networking.ConnectivityQueryPeer{Pod: &networking.PodReference{Namespace: "ns1", Name: "pod2"}},
+				Destination: networking.ConnectivityQueryPeer{Pod: &networking.PodReference{Namespace: "ns1", Name: "pod1"}},
+				Port:        80,
+			},
+			expectedStatus: &networking.ConnectivityQueryStatus{
+				Allowed: true,
+				Egress:  &networking.ConnectivityQueryVerdict{Allowed: true, Rule: np2Rule, Isolated: true},
+				Ingress: &networking.ConnectivityQueryVerdict{Allowed: true, Rule: np1Rule, Isolated: true},
+			},
+		},
+		{
+			name: "dropped by isolation",
+			spec: networking.ConnectivityQuerySpec{
+				Source:      networking.ConnectivityQueryPeer{Pod: &networking.PodReference{Namespace: "ns1", Name: "pod2"}},
+				Destination: networking.ConnectivityQueryPeer{Pod: &networking.PodReference{Namespace: "ns1", Name: "pod1"}},
+				Protocol:    networking.ProtocolUDP,
+				Port:        80,
+			},
+			expectedStatus: &networking.ConnectivityQueryStatus{
+				Egress:  &networking.ConnectivityQueryVerdict{Isolated: true},
+				Ingress: &networking.ConnectivityQueryVerdict{Isolated: true},
+			},
+		},
+		{
+			name: "dropped by ClusterNetworkPolicy",
+			spec: networking.ConnectivityQuerySpec{
+				Source:      networking.ConnectivityQueryPeer{Pod: &networking.PodReference{Namespace: "ns2", Name: "pod3"}},
+				Destination: networking.ConnectivityQueryPeer{Pod: &networking.PodReference{Namespace: "ns1", Name: "pod1"}},
+				Port:        80,
+			},
+			expectedStatus: &networking.ConnectivityQueryStatus{
+				Egress:  &networking.ConnectivityQueryVerdict{Allowed: true},
+				Ingress: &networking.ConnectivityQueryVerdict{Rule: cnp1Rule},
+			},
+		},
+		{
+			name: "egress to excepted IP",
+			spec: networking.ConnectivityQuerySpec{
+				Source:      networking.ConnectivityQueryPeer{Pod: &networking.PodReference{Namespace: "ns1", Name: "pod2"}},
+				Destination: networking.ConnectivityQueryPeer{IP: "10.0.1.2"},
+				Port:        80,
+			},
+			expectedStatus: &networking.ConnectivityQueryStatus{
+				Egress: &networking.ConnectivityQueryVerdict{Isolated: true},
+			},
+		},
+		{
+			name: "ingress from external IP",
+			spec: networking.ConnectivityQuerySpec{
+				Source:      networking.ConnectivityQueryPeer{IP: "192.168.1.1"},
+				Destination: networking.ConnectivityQueryPeer{Pod: &networking.PodReference{Namespace: "ns2", Name: "pod3"}},
+				Port:        443,
+			},
+			expectedStatus: &networking.ConnectivityQueryStatus{
+				Allowed: true,
+				Ingress: &networking.ConnectivityQueryVerdict{Allowed: true},
+			},
+		},
+		{
+			name: "invalid peer",
+			spec: networking.ConnectivityQuerySpec{
+				Source:      networking.ConnectivityQueryPeer{IP: "192.168.1.1", Pod: &networking.PodReference{Namespace: "ns2", Name: "pod3"}},
+				Destination: networking.ConnectivityQueryPeer{IP: "192.168.1.2"},
+			},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := analyzer.QueryConnectivity(&tt.spec)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, status)
+		})
+	}
+}
+
+func TestServicesMatch(t *testing.T) {
+	protocolUDP := networking.ProtocolUDP
+	protocolICMP := networking.ProtocolICMP
+	port8000 := intstr.FromInt(8000)
+	endPort9000 := int32(9000)
+	icmpType := int32(8)
+	services := []networking.Service{
+		{Port: &port8000, EndPort: &endPort9000},
+		{Protocol: &protocolICMP, ICMPType: &icmpType},
+	}
+	tests := []struct {
+		name     string
+		protocol networking.Protocol
+		port     int32
+		expected bool
+	}{
+		{"start of range", networking.ProtocolTCP, 8000, true},
+		{"end of range", networking.ProtocolTCP, 9000, true},
+		{"inside range", networking.ProtocolTCP, 8443, true},
+		{"outside range", networking.ProtocolTCP, 9001, false},
+		{"protocol mismatch", protocolUDP, 8443, false},
+		{"icmp", protocolICMP, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, servicesMatch(services, nil, tt.protocol, tt.port))
+		})
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/storage"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+	"github.com/vmware-tanzu/antrea/pkg/k8s"
+)
+
+const (
+	// How often the statistics of the deleted NetworkPolicies are garbage
+	// collected.
+	statsGCInterval = 1 * time.Minute
+)
+
+// policyStats contains the traffic statistics accumulated for a NetworkPolicy.
+type policyStats struct {
+	// uid is the UID of the NetworkPolicy the statistics were reported for.
+	uid          types.UID
+	trafficStats networking.TrafficStats
+	// ruleStats is a map from rule name to the statistics accumulated for
+	// the rule.
+	ruleStats map[string]*networking.TrafficStats
+}
+
+// StatsAggregator collects the traffic statistics of the internal
+// NetworkPolicies reported by the antrea-agents and accumulates them, so that
+// the cluster-wide statistics of each NetworkPolicy can be queried.
+type StatsAggregator struct {
+	networkPolicyStore storage.Interface
+
+	statsLock sync.RWMutex
+	// stats is a map from NetworkPolicy key to the statistics accumulated for
+	// it.
+	stats map[string]*policyStats
+}
+
+// NewStatsAggregator returns a new *StatsAggregator.
+func NewStatsAggregator(networkPolicyStore storage.Interface) *StatsAggregator {
+	return &StatsAggregator{
+		networkPolicyStore: networkPolicyStore,
+		stats:              map[string]*policyStats{},
+	}
+}
+
+// CollectNodeStatsSummary adds the statistics reported by a Node since its
+// last report to the accumulated statistics. The statistics of the
+// NetworkPolicies which don't exist anymore or were recreated are ignored.
+func (a *StatsAggregator) CollectNodeStatsSummary(summary *networking.NodeStatsSummary) {
+	a.statsLock.Lock()
+	defer a.statsLock.Unlock()
+	for _, reported := range summary.NetworkPolicies {
+		key := k8s.NamespacedName(reported.Namespace, reported.Name)
+		obj, exists, _ := a.networkPolicyStore.Get(key)
+		if !exists || obj.(*antreatypes.NetworkPolicy).UID != reported.UID {
+			klog.V(2).Infof("Ignoring statistics of NetworkPolicy %s (UID %s) reported by Node %s which doesn't exist", key, reported.UID, summary.Name)
+			continue
+		}
+		ps, exists := a.stats[key]
+		if !exists || ps.uid != reported.UID {
+			ps = &policyStats{uid: reported.UID, ruleStats: map[string]*networking.TrafficStats{}}
+			a.stats[key] = ps
+		}
+		addTrafficStats(&ps.trafficStats, &reported.TrafficStats)
+		for _, reportedRule := range reported.RuleTrafficStats {
+			rs, exists := ps.ruleStats[reportedRule.Name]
+			if !exists {
+				rs = &networking.TrafficStats{}
+				ps.ruleStats[reportedRule.Name] = rs
+			}
+			addTrafficStats(rs, &reportedRule.TrafficStats)
+		}
+	}
+}
+
+func addTrafficStats(stats, delta *networking.TrafficStats) {
+	stats.Packets += delta.Packets
+	stats.Bytes += delta.Bytes
+	stats.Sessions += delta.Sessions
+}
+
+// toNetworkPolicyStats returns the NetworkPolicyStats of the provided
+// NetworkPolicy. The statistics of every named rule of the NetworkPolicy are
+// included, even if no traffic matched it. The caller must hold statsLock.
+func (a *StatsAggregator) toNetworkPolicyStats(policy *antreatypes.NetworkPolicy) networking.NetworkPolicyStats {
+	stats := networking.NetworkPolicyStats{}
+	stats.Name = policy.Name
+	stats.Namespace = policy.Namespace
+	stats.UID = policy.UID
+	ps, exists := a.stats[k8s.NamespacedName(policy.Namespace, policy.Name)]
+	if exists && ps.uid == policy.UID {
+		stats.TrafficStats = ps.trafficStats
+	} else {
+		ps = nil
+	}
+	for _, rule := range policy.Rules {
+		if rule.Name == "" {
+			continue
+		}
+		ruleStats := networking.RuleTrafficStats{Name: rule.Name}
+		if ps != nil {
+			if rs, exists := ps.ruleStats[rule.Name]; exists {
+				ruleStats.TrafficStats = *rs
+			}
+		}
+		stats.RuleTrafficStats = append(stats.RuleTrafficStats, ruleStats)
+	}
+	return stats
+}
+
+// GetNetworkPolicyStats returns the statistics of the NetworkPolicy with the
+// provided Namespace and name. The second return value is false if the
+// NetworkPolicy doesn't exist.
+func (a *StatsAggregator) GetNetworkPolicyStats(namespace, name string) (*networking.NetworkPolicyStats, bool) {
+	obj, exists, _ := a.networkPolicyStore.Get(k8s.NamespacedName(namespace, name))
+	if !exists {
+		return nil, false
+	}
+	a.statsLock.RLock()
+	defer a.statsLock.RUnlock()
+	stats := a.toNetworkPolicyStats(obj.(*antreatypes.NetworkPolicy))
+	return &stats, true
+}
+
+// ListNetworkPolicyStats returns the statistics of the NetworkPolicies in the
+// provided Namespace, or of all the NetworkPolicies if namespace is empty.
+func (a *StatsAggregator) ListNetworkPolicyStats(namespace string) []networking.NetworkPolicyStats {
+	policies := a.networkPolicyStore.List()
+	a.statsLock.RLock()
+	defer a.statsLock.RUnlock()
+	statsList := make([]networking.NetworkPolicyStats, 0, len(policies))
+	for _, obj := range policies {
+		policy := obj.(*antreatypes.NetworkPolicy)
+		if namespace != "" && policy.Namespace != namespace {
+			continue
+		}
+		statsList = append(statsList, a.toNetworkPolicyStats(policy))
+	}
+	return statsList
+}
+
+// Run periodically garbage collects the statistics of the deleted
+// NetworkPolicies until stopCh is closed.
+func (a *StatsAggregator) Run(stopCh <-chan struct{}) {
+	wait.Until(a.garbageCollect, statsGCInterval, stopCh)
+}
+
+// garbageCollect deletes the statistics of the deleted NetworkPolicies.
+func (a *StatsAggregator) garbageCollect() {
+	a.statsLock.Lock()
+	defer a.statsLock.Unlock()
+	for key, ps := range a.stats {
+		obj, exists, _ := a.networkPolicyStore.Get(key)
+		if !exists || obj.(*antreatypes.NetworkPolicy).UID != ps.uid {
+			delete(a.stats, key)
+		}
+	}
+}
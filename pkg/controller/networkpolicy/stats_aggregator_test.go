@@ -0,0 +1,94 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	"github.com/vmware-tanzu/antrea/pkg/controller/networkpolicy/store"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+)
+
+func newPolicyStats(name, namespace string, uid types.UID, packets, bytes, sessions int64, ruleStats ...networking.RuleTrafficStats) networking.NetworkPolicyStats {
+	return networking.NetworkPolicyStats{
+		ObjectMeta:       metav1.ObjectMeta{Name: name, Namespace: namespace, UID: uid},
+		TrafficStats:     networking.TrafficStats{Packets: packets, Bytes: bytes, Sessions: sessions},
+		RuleTrafficStats: ruleStats,
+	}
+}
+
+func newRuleStats(name string, packets, bytes, sessions int64) networking.RuleTrafficStats {
+	return networking.RuleTrafficStats{
+		Name:         name,
+		TrafficStats: networking.TrafficStats{Packets: packets, Bytes: bytes, Sessions: sessions},
+	}
+}
+
+func TestStatsAggregator(t *testing.T) {
+	policyStore := store.NewNetworkPolicyStore()
+	policyStore.Create(&antreatypes.NetworkPolicy{UID: "uid1", Name: "np1", Namespace: "ns1", Rules: []networking.NetworkPolicyRule{
+		{Name: "ingress-0", Direction: networking.DirectionIn},
+		{Name: "ingress-1", Direction: networking.DirectionIn},
+		{Name: "egress-0", Direction: networking.DirectionOut},
+	}})
+	policyStore.Create(&antreatypes.NetworkPolicy{UID: "uid2", Name: "np2", Namespace: "ns2"})
+	aggregator := NewStatsAggregator(policyStore)
+
+	aggregator.CollectNodeStatsSummary(&networking.NodeStatsSummary{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		NetworkPolicies: []networking.NetworkPolicyStats{
+			newPolicyStats("np1", "ns1", "uid1", 10, 1000, 1, newRuleStats("ingress-0", 10, 1000, 1)),
+			newPolicyStats("np2", "ns2", "uid2", 5, 500, 2),
+		},
+	})
+	aggregator.CollectNodeStatsSummary(&networking.NodeStatsSummary{
+		ObjectMeta: metav1.ObjectMeta{Name: "node2"},
+		NetworkPolicies: []networking.NetworkPolicyStats{
+			newPolicyStats("np1", "ns1", "uid1", 20, 2000, 2, newRuleStats("ingress-0", 15, 1500, 1), newRuleStats("egress-0", 5, 500, 1)),
+			// The statistics of NetworkPolicies which don't exist or were recreated are ignored.
+			newPolicyStats("np2", "ns2", "uid3", 5, 500, 2),
+			newPolicyStats("np3", "ns1", "uid4", 5, 500, 2),
+		},
+	})
+
+	// The statistics of every rule are reported, in the order of the rules,
+	// even if no traffic matched the rule.
+	np1Stats := newPolicyStats("np1", "ns1", "uid1", 30, 3000, 3,
+		newRuleStats("ingress-0", 25, 2500, 2), newRuleStats("ingress-1", 0, 0, 0), newRuleStats("egress-0", 5, 500, 1))
+	stats, exists := aggregator.GetNetworkPolicyStats("ns1", "np1")
+	require.True(t, exists)
+	assert.Equal(t, np1Stats, *stats)
+	_, exists = aggregator.GetNetworkPolicyStats("ns1", "np3")
+	assert.False(t, exists)
+	assert.ElementsMatch(t, []networking.NetworkPolicyStats{
+		np1Stats,
+		newPolicyStats("np2", "ns2", "uid2", 5, 500, 2),
+	}, aggregator.ListNetworkPolicyStats(""))
+	assert.Equal(t, []networking.NetworkPolicyStats{newPolicyStats("np2", "ns2", "uid2", 5, 500, 2)}, aggregator.ListNetworkPolicyStats("ns2"))
+
+	// The statistics of a recreated NetworkPolicy start from zero.
+	policyStore.Update(&antreatypes.NetworkPolicy{UID: "uid5", Name: "np2", Namespace: "ns2"})
+	assert.Equal(t, []networking.NetworkPolicyStats{newPolicyStats("np2", "ns2", "uid5", 0, 0, 0)}, aggregator.ListNetworkPolicyStats("ns2"))
+
+	policyStore.Delete("ns1/np1")
+	aggregator.garbageCollect()
+	assert.Len(t, aggregator.stats, 0)
+}
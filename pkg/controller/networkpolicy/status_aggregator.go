@@ -0,0 +1,150 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/storage"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+	"github.com/vmware-tanzu/antrea/pkg/k8s"
+)
+
+const (
+	// How often the statuses of the deleted NetworkPolicies and of the Nodes
+	// no longer spanned by their NetworkPolicies are garbage collected.
+	statusGCInterval = 1 * time.Minute
+)
+
+// policyStatus contains the statuses reported by the Nodes for a NetworkPolicy.
+type policyStatus struct {
+	// uid is the UID of the NetworkPolicy the statuses were reported for.
+	uid types.UID
+	// nodeStatuses is a map from Node name to the status reported by the Node.
+	nodeStatuses map[string]networking.NetworkPolicyNodeStatus
+}
+
+// StatusAggregator collects the realization statuses of the internal
+// NetworkPolicies reported by the antrea-agents and aggregates them.
+type StatusAggregator struct {
+	networkPolicyStore storage.Interface
+
+	statusesLock sync.RWMutex
+	// statuses is a map from NetworkPolicy key to the statuses reported for it.
+	statuses map[string]*policyStatus
+}
+
+// NewStatusAggregator returns a new *StatusAggregator.
+func NewStatusAggregator(networkPolicyStore storage.Interface) *StatusAggregator {
+	return &StatusAggregator{
+		networkPolicyStore: networkPolicyStore,
+		statuses:           map[string]*policyStatus{},
+	}
+}
+
+// UpdateStatus records the statuses reported for a NetworkPolicy. It returns
+// an error if the NetworkPolicy doesn't exist. The statuses of the Nodes that
+// the NetworkPolicy doesn't span are ignored.
+func (a *StatusAggregator) UpdateStatus(status *networking.NetworkPolicyStatus) error {
+	key := k8s.NamespacedName(status.Namespace, status.Name)
+	obj, exists, _ := a.networkPolicyStore.Get(key)
+	if !exists {
+		return fmt.Errorf("NetworkPolicy %s not found", key)
+	}
+	policy := obj.(*antreatypes.NetworkPolicy)
+	if status.UID != policy.UID {
+		return fmt.Errorf("NetworkPolicy %s has UID %s, not %s", key, policy.UID, status.UID)
+	}
+
+	a.statusesLock.Lock()
+	defer a.statusesLock.Unlock()
+	ps, exists := a.statuses[key]
+	if !exists || ps.uid != policy.UID {
+		ps = &policyStatus{uid: policy.UID, nodeStatuses: map[string]networking.NetworkPolicyNodeStatus{}}
+		a.statuses[key] = ps
+	}
+	for _, nodeStatus := range status.Nodes {
+		if !policy.SpanMeta.Has(nodeStatus.NodeName) {
+			klog.V(2).Infof("Ignoring status of NetworkPolicy %s reported by Node %s which it doesn't span", key, nodeStatus.NodeName)
+			continue
+		}
+		ps.nodeStatuses[nodeStatus.NodeName] = nodeStatus
+	}
+	return nil
+}
+
+// GetRealizationStatus returns the realization status of the provided
+// NetworkPolicy, aggregated from the statuses reported by the Nodes it spans.
+func (a *StatusAggregator) GetRealizationStatus(policy *antreatypes.NetworkPolicy) *networking.NetworkPolicyRealizationStatus {
+	status := &networking.NetworkPolicyRealizationStatus{
+		DesiredNodesRealized: int32(len(policy.SpanMeta.NodeNames)),
+	}
+
+	a.statusesLock.RLock()
+	defer a.statusesLock.RUnlock()
+	ps, exists := a.statuses[k8s.NamespacedName(policy.Namespace, policy.Name)]
+	if !exists || ps.uid != policy.UID {
+		return status
+	}
+	for nodeName := range policy.SpanMeta.NodeNames {
+		nodeStatus, exists := ps.nodeStatuses[nodeName]
+		if !exists || nodeStatus.Generation != policy.Generation {
+			continue
+		}
+		if nodeStatus.FailedRules == 0 {
+			status.CurrentNodesRealized++
+		} else {
+			status.FailedNodes = append(status.FailedNodes, nodeStatus)
+		}
+	}
+	sort.Slice(status.FailedNodes, func(i, j int) bool {
+		return status.FailedNodes[i].NodeName < status.FailedNodes[j].NodeName
+	})
+	return status
+}
+
+// Run periodically garbage collects the statuses which are no longer relevant
+// until stopCh is closed.
+func (a *StatusAggregator) Run(stopCh <-chan struct{}) {
+	wait.Until(a.garbageCollect, statusGCInterval, stopCh)
+}
+
+// garbageCollect deletes the statuses of the deleted NetworkPolicies and the
+// statuses of the Nodes no longer spanned by their NetworkPolicies.
+func (a *StatusAggregator) garbageCollect() {
+	a.statusesLock.Lock()
+	defer a.statusesLock.Unlock()
+	for key, ps := range a.statuses {
+		obj, exists, _ := a.networkPolicyStore.Get(key)
+		if !exists || obj.(*antreatypes.NetworkPolicy).UID != ps.uid {
+			delete(a.statuses, key)
+			continue
+		}
+		policy := obj.(*antreatypes.NetworkPolicy)
+		for nodeName := range ps.nodeStatuses {
+			if !policy.SpanMeta.Has(nodeName) {
+				delete(ps.nodeStatuses, nodeName)
+			}
+		}
+	}
+}
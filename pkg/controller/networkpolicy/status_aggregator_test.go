@@ -0,0 +1,93 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	"github.com/vmware-tanzu/antrea/pkg/controller/networkpolicy/store"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+)
+
+func newPolicyStatus(policy *antreatypes.NetworkPolicy, nodeStatuses ...networking.NetworkPolicyNodeStatus) *networking.NetworkPolicyStatus {
+	return &networking.NetworkPolicyStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: policy.Name, Namespace: policy.Namespace, UID: policy.UID},
+		Nodes:      nodeStatuses,
+	}
+}
+
+func TestStatusAggregator(t *testing.T) {
+	policyStore := store.NewNetworkPolicyStore()
+	policy := &antreatypes.NetworkPolicy{
+		SpanMeta:   antreatypes.SpanMeta{NodeNames: sets.NewString("node1", "node2", "node3")},
+		UID:        types.UID("uid1"),
+		Name:       "np1",
+		Namespace:  "ns1",
+		Generation: 2,
+	}
+	policyStore.Create(policy)
+	aggregator := NewStatusAggregator(policyStore)
+
+	assert.Equal(t, &networking.NetworkPolicyRealizationStatus{DesiredNodesRealized: 3}, aggregator.GetRealizationStatus(policy))
+
+	failedStatus := networking.NetworkPolicyNodeStatus{NodeName: "node2", Generation: 2, FailedRules: 1, LastError: "failed to install flows"}
+	require.NoError(t, aggregator.UpdateStatus(newPolicyStatus(policy,
+		networking.NetworkPolicyNodeStatus{NodeName: "node1", Generation: 2})))
+	require.NoError(t, aggregator.UpdateStatus(newPolicyStatus(policy, failedStatus)))
+	// node3 has not realized the current generation yet.
+	require.NoError(t, aggregator.UpdateStatus(newPolicyStatus(policy,
+		networking.NetworkPolicyNodeStatus{NodeName: "node3", Generation: 1})))
+	// node4 is not spanned by the NetworkPolicy.
+	require.NoError(t, aggregator.UpdateStatus(newPolicyStatus(policy,
+		networking.NetworkPolicyNodeStatus{NodeName: "node4", Generation: 2})))
+	assert.Equal(t, &networking.NetworkPolicyRealizationStatus{
+		CurrentNodesRealized: 1,
+		DesiredNodesRealized: 3,
+		FailedNodes:          []networking.NetworkPolicyNodeStatus{failedStatus},
+	}, aggregator.GetRealizationStatus(policy))
+
+	require.NoError(t, aggregator.UpdateStatus(newPolicyStatus(policy,
+		networking.NetworkPolicyNodeStatus{NodeName: "node2", Generation: 2},
+		networking.NetworkPolicyNodeStatus{NodeName: "node3", Generation: 2})))
+	assert.Equal(t, &networking.NetworkPolicyRealizationStatus{
+		CurrentNodesRealized: 3,
+		DesiredNodesRealized: 3,
+	}, aggregator.GetRealizationStatus(policy))
+
+	// A new generation is realized by none of the Nodes.
+	updatedPolicy := *policy
+	updatedPolicy.Generation = 3
+	updatedPolicy.SpanMeta = antreatypes.SpanMeta{NodeNames: sets.NewString("node1", "node2")}
+	policyStore.Update(&updatedPolicy)
+	assert.Equal(t, &networking.NetworkPolicyRealizationStatus{DesiredNodesRealized: 2}, aggregator.GetRealizationStatus(&updatedPolicy))
+
+	aggregator.garbageCollect()
+	assert.Len(t, aggregator.statuses["ns1/np1"].nodeStatuses, 2)
+
+	// The statuses of a NetworkPolicy which doesn't exist or was recreated are rejected.
+	assert.Error(t, aggregator.UpdateStatus(&networking.NetworkPolicyStatus{ObjectMeta: metav1.ObjectMeta{Name: "np2", Namespace: "ns1"}}))
+	assert.Error(t, aggregator.UpdateStatus(&networking.NetworkPolicyStatus{ObjectMeta: metav1.ObjectMeta{Name: "np1", Namespace: "ns1", UID: "uid2"}}))
+
+	policyStore.Delete("ns1/np1")
+	aggregator.garbageCollect()
+	assert.Empty(t, aggregator.statuses)
+}
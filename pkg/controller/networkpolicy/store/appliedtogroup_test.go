@@ -39,6 +39,11 @@ func TestWatchAppliedToGroupEvent(t *testing.T) {
 	pod2 := newAppliedToGroupMember("pod2", "default")
 	pod3 := newAppliedToGroupMember("pod3", "default")
 	pod4 := newAppliedToGroupMember("pod4", "default")
+	// pod5 and recreatedPod5 refer to the same Pod with different named ports.
+	pod5 := newAppliedToGroupMember("pod5", "default")
+	pod5.Ports = []networking.NamedPort{{Port: 80, Name: "http", Protocol: networking.ProtocolTCP}}
+	recreatedPod5 := newAppliedToGroupMember("pod5", "default")
+	recreatedPod5.Ports = []networking.NamedPort{{Port: 8080, Name: "http", Protocol: networking.ProtocolTCP}}
 
 	testCases := map[string]struct {
 		fieldSelector fields.Selector
@@ -75,6 +80,34 @@ func TestWatchAppliedToGroupEvent(t *testing.T) {
 				}},
 			},
 		},
+		"named-port-change": {
+			// The Pod should be replaced so that its named ports are resolved again.
+			fieldSelector: fields.Everything(),
+			operations: func(store storage.Interface) {
+				store.Create(&types.AppliedToGroup{
+					Name:       "foo",
+					SpanMeta:   types.SpanMeta{sets.NewString("node1")},
+					PodsByNode: map[string]networking.GroupMemberPodSet{"node1": networking.NewGroupMemberPodSet(pod5)},
+				})
+				store.Update(&types.AppliedToGroup{
+					Name:       "foo",
+					SpanMeta:   types.SpanMeta{sets.NewString("node1")},
+					PodsByNode: map[string]networking.GroupMemberPodSet{"node1": networking.NewGroupMemberPodSet(recreatedPod5)},
+				})
+			},
+			expected: []watch.Event{
+				{watch.Bookmark, nil},
+				{watch.Added, &networking.AppliedToGroup{
+					ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+					Pods:       []networking.GroupMemberPod{*pod5},
+				}},
+				{watch.Modified, &networking.AppliedToGroupPatch{
+					ObjectMeta:  metav1.ObjectMeta{Name: "foo"},
+					AddedPods:   []networking.GroupMemberPod{*recreatedPod5},
+					RemovedPods: []networking.GroupMemberPod{*pod5},
+				}},
+			},
+		},
 		"node-scoped-watcher": {
 			// Only events that span node3 should be watched.
 			fieldSelector: fields.SelectorFromSet(fields.Set{"nodeName": "node3"}),
@@ -130,7 +163,7 @@ func TestWatchAppliedToGroupEvent(t *testing.T) {
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
 			store := NewAppliedToGroupStore()
-			w, err := store.Watch(context.Background(), "", labels.Everything(), testCase.fieldSelector)
+			w, err := store.Watch(context.Background(), "", labels.Everything(), testCase.fieldSelector, storage.WatchOptions{})
 			if err != nil {
 				t.Fatalf("Failed to watch object: %v", err)
 			}
@@ -106,12 +106,15 @@ func ToNetworkPolicyMsg(in *types.NetworkPolicy, out *networking.NetworkPolicy,
 	out.Namespace = in.Namespace
 	out.Name = in.Name
 	out.UID = in.UID
+	out.Generation = in.Generation
 	if !includeBody {
 		return
 	}
 	// Since stored objects are immutable, we just reference the fields here.
 	out.Rules = in.Rules
 	out.AppliedToGroups = in.AppliedToGroups
+	out.Priority = in.Priority
+	out.TierPriority = in.TierPriority
 }
 
 // NetworkPolicyKeyFunc knows how to get the key of a NetworkPolicy.
@@ -131,7 +131,7 @@ func TestWatchNetworkPolicyEvent(t *testing.T) {
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
 			store := NewNetworkPolicyStore()
-			w, err := store.Watch(context.Background(), "", labels.Everything(), testCase.fieldSelector)
+			w, err := store.Watch(context.Background(), "", labels.Everything(), testCase.fieldSelector, storage.WatchOptions{})
 			if err != nil {
 				t.Fatalf("Failed to watch object: %v", err)
 			}
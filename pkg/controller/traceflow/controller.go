@@ -0,0 +1,354 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package traceflow
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+
+	opsv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/ops/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned"
+	opsinformers "github.com/vmware-tanzu/antrea/pkg/client/informers/externalversions/ops/v1alpha1"
+	opslisters "github.com/vmware-tanzu/antrea/pkg/client/listers/ops/v1alpha1"
+)
+
+const (
+	controllerName = "TraceflowController"
+	// How long to wait before retrying the processing of a Traceflow.
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = 300 * time.Second
+	// Default number of workers processing a Traceflow.
+	defaultWorkers = 4
+	// Interval at which a Running Traceflow is checked for timeout.
+	checkInterval = 10 * time.Second
+	// A Running Traceflow which doesn't complete within timeout is marked as Failed.
+	timeout = 2 * time.Minute
+
+	// The dataplane tags are carried in the 6-bit DSCP field of the probe packets.
+	// Tag 0 is reserved for regular traffic.
+	minTagNum uint8 = 1
+	maxTagNum uint8 = 15
+)
+
+// Controller is responsible for allocating the dataplane tags of Traceflows and for
+// determining when a Traceflow has completed, based on the observations reported by the
+// antrea-agents.
+type Controller struct {
+	client                 versioned.Interface
+	traceflowInformer      opsinformers.TraceflowInformer
+	traceflowLister        opslisters.TraceflowLister
+	traceflowListerSynced  cache.InformerSynced
+	queue                  workqueue.RateLimitingInterface
+	runningTraceflowsMutex sync.Mutex
+	// runningTraceflows is a map from a dataplane tag to the name of the Traceflow using it.
+	runningTraceflows map[uint8]string
+}
+
+// NewTraceflowController instantiates a new Controller object which will process Traceflow
+// events.
+func NewTraceflowController(client versioned.Interface, traceflowInformer opsinformers.TraceflowInformer) *Controller {
+	c := &Controller{
+		client:                client,
+		traceflowInformer:     traceflowInformer,
+		traceflowLister:       traceflowInformer.Lister(),
+		traceflowListerSynced: traceflowInformer.Informer().HasSynced,
+		queue:                 workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "traceflow"),
+		runningTraceflows:     make(map[uint8]string),
+	}
+	traceflowInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.addTraceflow,
+			UpdateFunc: c.updateTraceflow,
+			DeleteFunc: c.deleteTraceflow,
+		},
+	)
+	return c
+}
+
+// Run will create defaultWorkers workers (go routines) which will process the Traceflow events
+// from the workqueue.
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+
+	klog.Infof("Starting %s", controllerName)
+	defer klog.Infof("Shutting down %s", controllerName)
+
+	if !cache.WaitForNamedCacheSync(controllerName, stopCh, c.traceflowListerSynced) {
+		return
+	}
+
+	// Rebuild the dataplane tag allocations from the Traceflows which were Running before a
+	// restart, so that the tags are not assigned twice.
+	tfs, err := c.traceflowLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list all Traceflows: %v", err)
+	}
+	for _, tf := range tfs {
+		if tf.Status.Phase == opsv1alpha1.Running && tf.Status.DataplaneTag != 0 {
+			c.occupyTag(tf.Name, tf.Status.DataplaneTag)
+		}
+	}
+
+	go wait.Until(c.checkTraceflows, checkInterval, stopCh)
+
+	for i := 0; i < defaultWorkers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+	<-stopCh
+}
+
+func (c *Controller) addTraceflow(obj interface{}) {
+	tf := obj.(*opsv1alpha1.Traceflow)
+	klog.V(2).Infof("Processing Traceflow %s ADD event", tf.Name)
+	c.queue.Add(tf.Name)
+}
+
+func (c *Controller) updateTraceflow(_, curObj interface{}) {
+	tf := curObj.(*opsv1alpha1.Traceflow)
+	klog.V(2).Infof("Processing Traceflow %s UPDATE event", tf.Name)
+	c.queue.Add(tf.Name)
+}
+
+func (c *Controller) deleteTraceflow(old interface{}) {
+	tf, ok := old.(*opsv1alpha1.Traceflow)
+	if !ok {
+		tombstone, ok := old.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("Error decoding object when deleting Traceflow, invalid type: %v", old)
+			return
+		}
+		tf, ok = tombstone.Obj.(*opsv1alpha1.Traceflow)
+		if !ok {
+			klog.Errorf("Error decoding object tombstone when deleting Traceflow, invalid type: %v", tombstone.Obj)
+			return
+		}
+	}
+	klog.V(2).Infof("Processing Traceflow %s DELETE event", tf.Name)
+	c.releaseTag(tf.Name, tf.Status.DataplaneTag)
+}
+
+// checkTraceflows enqueues all Running Traceflows so that the timed out ones are marked as
+// Failed.
+func (c *Controller) checkTraceflows() {
+	tfs, err := c.traceflowLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list all Traceflows: %v", err)
+		return
+	}
+	for _, tf := range tfs {
+		if tf.Status.Phase == opsv1alpha1.Running {
+			c.queue.Add(tf.Name)
+		}
+	}
+}
+
+func (c *Controller) worker() {
+	for c.processTraceflowItem() {
+	}
+}
+
+func (c *Controller) processTraceflowItem() bool {
+	obj, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	// We call Done here so the workqueue knows we have finished processing this item. We also
+	// must remember to call Forget if we do not want this work item being re-queued.
+	defer c.queue.Done(obj)
+
+	if key, ok := obj.(string); !ok {
+		c.queue.Forget(obj)
+		klog.Errorf("Expected string in work queue but got %#v", obj)
+		return true
+	} else if err := c.syncTraceflow(key); err == nil {
+		c.queue.Forget(key)
+	} else {
+		c.queue.AddRateLimited(key)
+		klog.Errorf("Error syncing Traceflow %s, requeuing. Error: %v", key, err)
+	}
+	return true
+}
+
+func (c *Controller) syncTraceflow(name string) error {
+	startTime := time.Now()
+	defer func() {
+		klog.V(4).Infof("Finished syncing Traceflow for %s. (%v)", name, time.Since(startTime))
+	}()
+
+	tf, err := c.traceflowLister.Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	switch tf.Status.Phase {
+	case "":
+		return c.startTraceflow(tf)
+	case opsv1alpha1.Running:
+		return c.checkTraceflowStatus(tf)
+	default:
+		// The Traceflow has completed, its dataplane tag can be reused.
+		c.releaseTag(tf.Name, tf.Status.DataplaneTag)
+	}
+	return nil
+}
+
+// startTraceflow validates a new Traceflow, assigns a dataplane tag to it and marks it as
+// Running.
+func (c *Controller) startTraceflow(tf *opsv1alpha1.Traceflow) error {
+	if err := validateTraceflow(tf); err != nil {
+		return c.updateTraceflowStatus(tf, opsv1alpha1.Failed, err.Error(), 0)
+	}
+	tag, err := c.allocateTag(tf.Name)
+	if err != nil {
+		// Retry later as the tags are released when the running Traceflows complete.
+		return err
+	}
+	if err := c.updateTraceflowStatus(tf, opsv1alpha1.Running, "", tag); err != nil {
+		c.releaseTag(tf.Name, tag)
+		return err
+	}
+	return nil
+}
+
+// checkTraceflowStatus marks a Running Traceflow as Succeeded if the probe packet has reached
+// its final observation, or as Failed if it has timed out.
+func (c *Controller) checkTraceflowStatus(tf *opsv1alpha1.Traceflow) error {
+	c.occupyTag(tf.Name, tf.Status.DataplaneTag)
+	if isTraceflowCompleted(tf) {
+		if err := c.updateTraceflowStatus(tf, opsv1alpha1.Succeeded, "", tf.Status.DataplaneTag); err != nil {
+			return err
+		}
+		c.releaseTag(tf.Name, tf.Status.DataplaneTag)
+		return nil
+	}
+	if time.Since(tf.CreationTimestamp.Time) > timeout {
+		if err := c.updateTraceflowStatus(tf, opsv1alpha1.Failed, "traceflow timeout", tf.Status.DataplaneTag); err != nil {
+			return err
+		}
+		c.releaseTag(tf.Name, tf.Status.DataplaneTag)
+	}
+	return nil
+}
+
+// isTraceflowCompleted returns whether any Node has reported that the probe packet was
+// delivered, dropped or forwarded out of the cluster network.
+func isTraceflowCompleted(tf *opsv1alpha1.Traceflow) bool {
+	for _, result := range tf.Status.Results {
+		for _, ob := range result.Observations {
+			switch ob.Action {
+			case opsv1alpha1.Delivered, opsv1alpha1.Dropped:
+				return true
+			case opsv1alpha1.Forwarded:
+				if ob.TunnelDstIP == "" && ob.Component == opsv1alpha1.Forwarding && tf.Spec.Destination.Pod == "" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func validateTraceflow(tf *opsv1alpha1.Traceflow) error {
+	if tf.Spec.Source.Namespace == "" || tf.Spec.Source.Pod == "" {
+		return fmt.Errorf("source Pod must be specified")
+	}
+	if tf.Spec.Destination.Pod == "" && tf.Spec.Destination.IP == "" {
+		return fmt.Errorf("destination Pod or IP must be specified")
+	}
+	if tf.Spec.Destination.Pod != "" && tf.Spec.Destination.Namespace == "" {
+		return fmt.Errorf("destination Namespace must be specified with destination Pod")
+	}
+	header := tf.Spec.Packet.TransportHeader
+	switch tf.Spec.Packet.IPHeader.Protocol {
+	case 0, 1:
+		if header.UDP != nil || header.TCP != nil {
+			return fmt.Errorf("transport header doesn't match IP protocol ICMP")
+		}
+	case 6:
+		if header.ICMP != nil || header.UDP != nil {
+			return fmt.Errorf("transport header doesn't match IP protocol TCP")
+		}
+	case 17:
+		if header.ICMP != nil || header.TCP != nil {
+			return fmt.Errorf("transport header doesn't match IP protocol UDP")
+		}
+	default:
+		return fmt.Errorf("unsupported IP protocol %d", tf.Spec.Packet.IPHeader.Protocol)
+	}
+	return nil
+}
+
+func (c *Controller) updateTraceflowStatus(tf *opsv1alpha1.Traceflow, phase opsv1alpha1.TraceflowPhase, reason string, tag uint8) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := c.client.OpsV1alpha1().Traceflows().Get(tf.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		toUpdate := latest.DeepCopy()
+		toUpdate.Status.Phase = phase
+		toUpdate.Status.Reason = reason
+		toUpdate.Status.DataplaneTag = tag
+		_, err = c.client.OpsV1alpha1().Traceflows().UpdateStatus(toUpdate)
+		return err
+	})
+}
+
+// allocateTag returns a free dataplane tag and records that it's used by the provided Traceflow.
+func (c *Controller) allocateTag(name string) (uint8, error) {
+	c.runningTraceflowsMutex.Lock()
+	defer c.runningTraceflowsMutex.Unlock()
+	for tag, n := range c.runningTraceflows {
+		if n == name {
+			return tag, nil
+		}
+	}
+	for tag := minTagNum; tag <= maxTagNum; tag++ {
+		if _, ok := c.runningTraceflows[tag]; !ok {
+			c.runningTraceflows[tag] = name
+			return tag, nil
+		}
+	}
+	return 0, fmt.Errorf("number of running Traceflows reaches the upper limit %d", maxTagNum-minTagNum+1)
+}
+
+// occupyTag records that the provided tag is used by the provided Traceflow.
+func (c *Controller) occupyTag(name string, tag uint8) {
+	if tag < minTagNum || tag > maxTagNum {
+		return
+	}
+	c.runningTraceflowsMutex.Lock()
+	defer c.runningTraceflowsMutex.Unlock()
+	c.runningTraceflows[tag] = name
+}
+
+// releaseTag frees the provided tag if it's used by the provided Traceflow.
+func (c *Controller) releaseTag(name string, tag uint8) {
+	c.runningTraceflowsMutex.Lock()
+	defer c.runningTraceflowsMutex.Unlock()
+	if c.runningTraceflows[tag] == name {
+		delete(c.runningTraceflows, tag)
+	}
+}
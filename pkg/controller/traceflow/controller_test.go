@@ -0,0 +1,117 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package traceflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opsv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/ops/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/fake"
+	crdinformers "github.com/vmware-tanzu/antrea/pkg/client/informers/externalversions"
+)
+
+func newTraceflow(name string) *opsv1alpha1.Traceflow {
+	return &opsv1alpha1.Traceflow{
+		ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.Now()},
+		Spec: opsv1alpha1.TraceflowSpec{
+			Source:      opsv1alpha1.Source{Namespace: "ns1", Pod: "pod1"},
+			Destination: opsv1alpha1.Destination{Namespace: "ns2", Pod: "pod2"},
+		},
+	}
+}
+
+func newTestController(objects ...*opsv1alpha1.Traceflow) (*Controller, *fake.Clientset) {
+	client := fake.NewSimpleClientset()
+	informerFactory := crdinformers.NewSharedInformerFactory(client, 0)
+	c := NewTraceflowController(client, informerFactory.Ops().V1alpha1().Traceflows())
+	for _, tf := range objects {
+		client.OpsV1alpha1().Traceflows().Create(tf)
+		informerFactory.Ops().V1alpha1().Traceflows().Informer().GetIndexer().Add(tf)
+	}
+	return c, client
+}
+
+func TestAllocateTag(t *testing.T) {
+	c, _ := newTestController()
+	for i := minTagNum; i <= maxTagNum; i++ {
+		tag, err := c.allocateTag(string([]byte{'a' + i}))
+		require.NoError(t, err)
+		assert.Equal(t, i, tag)
+	}
+	// The same Traceflow gets its existing tag.
+	tag, err := c.allocateTag("b")
+	require.NoError(t, err)
+	assert.Equal(t, uint8(1), tag)
+	// All tags are used.
+	_, err = c.allocateTag("new")
+	assert.Error(t, err)
+	// Releasing with a wrong name doesn't free the tag.
+	c.releaseTag("wrong", 3)
+	_, err = c.allocateTag("new")
+	assert.Error(t, err)
+	c.releaseTag(string([]byte{'a' + 3}), 3)
+	tag, err = c.allocateTag("new")
+	require.NoError(t, err)
+	assert.Equal(t, uint8(3), tag)
+}
+
+func TestSyncTraceflow(t *testing.T) {
+	invalid := newTraceflow("invalid")
+	invalid.Spec.Destination = opsv1alpha1.Destination{}
+	timedOut := newTraceflow("timed-out")
+	timedOut.CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * timeout))
+	timedOut.Status = opsv1alpha1.TraceflowStatus{Phase: opsv1alpha1.Running, DataplaneTag: 2}
+	delivered := newTraceflow("delivered")
+	delivered.Status = opsv1alpha1.TraceflowStatus{
+		Phase:        opsv1alpha1.Running,
+		DataplaneTag: 3,
+		Results: []opsv1alpha1.NodeResult{{
+			Node:         "node1",
+			Role:         opsv1alpha1.RoleSender,
+			Observations: []opsv1alpha1.Observation{{Component: opsv1alpha1.Forwarding, Action: opsv1alpha1.Delivered}},
+		}},
+	}
+	running := newTraceflow("running")
+	running.Status = opsv1alpha1.TraceflowStatus{Phase: opsv1alpha1.Running, DataplaneTag: 4}
+
+	tests := []struct {
+		tf            *opsv1alpha1.Traceflow
+		expectedPhase opsv1alpha1.TraceflowPhase
+		expectedTag   uint8
+		tagOccupied   bool
+	}{
+		{newTraceflow("new"), opsv1alpha1.Running, 1, true},
+		{invalid, opsv1alpha1.Failed, 0, false},
+		{timedOut, opsv1alpha1.Failed, 2, false},
+		{delivered, opsv1alpha1.Succeeded, 3, false},
+		{running, opsv1alpha1.Running, 4, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tf.Name, func(t *testing.T) {
+			c, client := newTestController(tt.tf)
+			require.NoError(t, c.syncTraceflow(tt.tf.Name))
+			tf, err := client.OpsV1alpha1().Traceflows().Get(tt.tf.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPhase, tf.Status.Phase)
+			assert.Equal(t, tt.expectedTag, tf.Status.DataplaneTag)
+			_, occupied := c.runningTraceflows[tt.expectedTag]
+			assert.Equal(t, tt.tagOccupied, occupied)
+		})
+	}
+}
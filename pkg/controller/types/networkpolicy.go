@@ -91,8 +91,18 @@ type NetworkPolicy struct {
 	Name string
 	// Namespace of the original K8s Network Policy.
 	Namespace string
+	// Generation of the original K8s Network Policy. It is used to tell
+	// whether the Nodes have realized the latest version of the policy.
+	Generation int64
 	// Rules is a list of rules to be applied to the selected Pods.
 	Rules []networking.NetworkPolicyRule
 	// AppliedToGroups is a list of names of AppliedToGroups to which this policy applies.
 	AppliedToGroups []string
+	// Priority represents the relative priority of this Network Policy as compared to
+	// other Network Policies in the same tier. Priority will be unset (nil) for
+	// K8s NetworkPolicy.
+	Priority *float64
+	// TierPriority represents the priority of the tier associated with this Network
+	// Policy. TierPriority will be unset (nil) for K8s NetworkPolicy.
+	TierPriority *networking.TierPriority
 }
@@ -0,0 +1,108 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package features
+
+import (
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/component-base/featuregate"
+)
+
+const (
+	// Every feature gate should add a key here following this template:
+	//
+	// // alpha: v0.X
+	// MyFeature featuregate.Feature = "MyFeature"
+
+	// alpha: v0.8
+	// Enables support for ClusterNetworkPolicy CRDs, which allow cluster admins
+	// to specify security policies with tiers, priorities and rule actions.
+	ClusterNetworkPolicy featuregate.Feature = "ClusterNetworkPolicy"
+
+	// alpha: v0.8
+	// Enables Traceflow, which allows users to trace a probe packet injected
+	// from a Pod across the OVS pipelines of the Nodes it traverses.
+	Traceflow featuregate.Feature = "Traceflow"
+
+	// alpha: v0.8
+	// Enables AntreaProxy, which load-balances the traffic from Pods to the
+	// ClusterIP of Services in OVS instead of relying on kube-proxy.
+	AntreaProxy featuregate.Feature = "AntreaProxy"
+
+	// alpha: v0.8
+	// Enables the flow exporter, which exports the connections of the Pods
+	// as IPFIX flow records to a collector.
+	FlowExporter featuregate.Feature = "FlowExporter"
+
+	// alpha: v0.8
+	// Enables the Egress API, which SNATs the traffic from the selected Pods
+	// to external destinations to a fixed egress IP hosted on a single Node.
+	Egress featuregate.Feature = "Egress"
+
+	// alpha: v0.8
+	// Enables the collection of the traffic statistics of NetworkPolicies
+	// by the agents, and the NetworkPolicyStats API in the controller,
+	// which exposes the statistics aggregated from all Nodes.
+	NetworkPolicyStats featuregate.Feature = "NetworkPolicyStats"
+
+	// alpha: v0.8
+	// Enables NodePortLocal, which exposes the container ports of the
+	// annotated Pods on ports of their Node, so that external load balancers
+	// can target the Pods directly.
+	NodePortLocal featuregate.Feature = "NodePortLocal"
+
+	// alpha: v0.8
+	// Enables certificate based authentication for the IPSec tunnels, with
+	// X.509 certificates issued to the Nodes by the Antrea Controller.
+	IPSecCertAuth featuregate.Feature = "IPSecCertAuth"
+
+	// alpha: v0.8
+	// Enables Antrea IPAM, which allocates the IPs of the Pods of annotated
+	// Namespaces from IPPool CRDs instead of the PodCIDR of their Node.
+	AntreaIPAM featuregate.Feature = "AntreaIPAM"
+
+	// alpha: v0.8
+	// Enables the secondary interfaces of the Pods annotated with
+	// SecondaryNetworks, connected to VLANs or dedicated OVS bridges.
+	SecondaryNetwork featuregate.Feature = "SecondaryNetwork"
+)
+
+var (
+	// DefaultMutableFeatureGate is a mutable version of DefaultFeatureGate.
+	DefaultMutableFeatureGate featuregate.MutableFeatureGate = featuregate.NewFeatureGate()
+
+	// DefaultFeatureGate is a shared global FeatureGate.
+	// Top-level commands/options setup that needs to modify this feature gate
+	// should use DefaultMutableFeatureGate.
+	DefaultFeatureGate featuregate.FeatureGate = DefaultMutableFeatureGate
+
+	// defaultAntreaFeatureGates consists of all known Antrea-specific feature
+	// keys. To add a new feature, define a key for it above and add it here.
+	defaultAntreaFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
+		ClusterNetworkPolicy: {Default: false, PreRelease: featuregate.Alpha},
+		Traceflow:            {Default: false, PreRelease: featuregate.Alpha},
+		AntreaProxy:          {Default: false, PreRelease: featuregate.Alpha},
+		FlowExporter:         {Default: false, PreRelease: featuregate.Alpha},
+		Egress:               {Default: false, PreRelease: featuregate.Alpha},
+		NetworkPolicyStats:   {Default: false, PreRelease: featuregate.Alpha},
+		NodePortLocal:        {Default: false, PreRelease: featuregate.Alpha},
+		IPSecCertAuth:        {Default: false, PreRelease: featuregate.Alpha},
+		AntreaIPAM:           {Default: false, PreRelease: featuregate.Alpha},
+		SecondaryNetwork:     {Default: false, PreRelease: featuregate.Alpha},
+	}
+)
+
+func init() {
+	runtime.Must(DefaultMutableFeatureGate.Add(defaultAntreaFeatureGates))
+}
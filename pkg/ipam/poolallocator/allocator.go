@@ -0,0 +1,354 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poolallocator
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog"
+
+	ipamv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/ipam/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned"
+)
+
+// IPPoolAllocator allocates IPs from an IPPool. The allocations are persisted
+// in the status of the IPPool, which is updated with optimistic concurrency so
+// that allocators running on different Nodes never hand out the same IP.
+type IPPoolAllocator struct {
+	// ipPoolName is the name of the IPPool to allocate IPs from.
+	ipPoolName string
+	crdClient  versioned.Interface
+}
+
+// NewIPPoolAllocator creates an IPPoolAllocator for the provided IPPool.
+func NewIPPoolAllocator(ipPoolName string, crdClient versioned.Interface) *IPPoolAllocator {
+	return &IPPoolAllocator{ipPoolName: ipPoolName, crdClient: crdClient}
+}
+
+// GetContainerIPPoolAllocator returns an IPPoolAllocator for the IPPool which
+// allocated an IP to the interface ifName of the container, ifName being empty
+// for the primary interface, or nil if no IPPool did. The IPPool is found from
+// the statuses of all the IPPools, so that the IP of a container can be
+// released or checked regardless of how the IPPool was selected for it.
+func GetContainerIPPoolAllocator(crdClient versioned.Interface, containerID, ifName string) (*IPPoolAllocator, error) {
+	pools, err := crdClient.IpamV1alpha1().IPPools().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, pool := range pools.Items {
+		for _, state := range pool.Status.IPAddresses {
+			if isContainerOwner(state.Owner.Pod, containerID, ifName) {
+				return NewIPPoolAllocator(pool.Name, crdClient), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// ipRange is a parsed SubnetIPRange. Only IPv4 ranges are supported.
+type ipRange struct {
+	start, end uint32
+	gateway    uint32
+	subnetInfo ipamv1alpha1.SubnetInfo
+}
+
+func ipToUint32(ip net.IP) (uint32, bool) {
+	ip = ip.To4()
+	if ip == nil {
+		return 0, false
+	}
+	return binary.BigEndian.Uint32(ip), true
+}
+
+func uint32ToIP(n uint32) net.IP {
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, n)
+	return ip
+}
+
+// parseIPRanges validates the IP ranges of an IPPool and converts them to
+// ipRanges. The network and broadcast addresses of CIDR ranges are excluded.
+func parseIPRanges(pool *ipamv1alpha1.IPPool) ([]ipRange, error) {
+	var ranges []ipRange
+	for _, r := range pool.Spec.IPRanges {
+		gateway, ok := ipToUint32(net.ParseIP(r.Gateway))
+		if !ok {
+			return nil, fmt.Errorf("invalid gateway %q in IPPool %s", r.Gateway, pool.Name)
+		}
+		if r.PrefixLength <= 0 || r.PrefixLength > 32 {
+			return nil, fmt.Errorf("invalid prefix length %d in IPPool %s", r.PrefixLength, pool.Name)
+		}
+		parsed := ipRange{gateway: gateway, subnetInfo: r.SubnetInfo}
+		if r.CIDR != "" {
+			_, ipNet, err := net.ParseCIDR(r.CIDR)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR %q in IPPool %s: %v", r.CIDR, pool.Name, err)
+			}
+			network, ok := ipToUint32(ipNet.IP)
+			if !ok {
+				return nil, fmt.Errorf("IPv6 CIDR %q in IPPool %s is not supported", r.CIDR, pool.Name)
+			}
+			ones, _ := ipNet.Mask.Size()
+			broadcast := network | ^binary.BigEndian.Uint32(ipNet.Mask)
+			parsed.start, parsed.end = network, broadcast
+			if ones < 31 {
+				parsed.start, parsed.end = network+1, broadcast-1
+			}
+		} else {
+			start, ok1 := ipToUint32(net.ParseIP(r.Start))
+			end, ok2 := ipToUint32(net.ParseIP(r.End))
+			if !ok1 || !ok2 || start > end {
+				return nil, fmt.Errorf("invalid IP range %q-%q in IPPool %s", r.Start, r.End, pool.Name)
+			}
+			parsed.start, parsed.end = start, end
+		}
+		ranges = append(ranges, parsed)
+	}
+	return ranges, nil
+}
+
+// subnetInfoForIP returns the SubnetInfo of the range the IP belongs to.
+func subnetInfoForIP(ranges []ipRange, ip net.IP) (*ipamv1alpha1.SubnetInfo, error) {
+	n, ok := ipToUint32(ip)
+	if ok {
+		for i := range ranges {
+			if n >= ranges[i].start && n <= ranges[i].end {
+				return &ranges[i].subnetInfo, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("IP %s does not belong to any range of the IPPool", ip)
+}
+
+// nextAvailableIP returns the first IP of the ranges which is neither a
+// gateway nor already present in the status of the IPPool.
+func nextAvailableIP(ranges []ipRange, status *ipamv1alpha1.IPPoolStatus) (net.IP, error) {
+	used := make(map[uint32]bool, len(status.IPAddresses))
+	for _, state := range status.IPAddresses {
+		if n, ok := ipToUint32(net.ParseIP(state.IPAddress)); ok {
+			used[n] = true
+		}
+	}
+	for _, r := range ranges {
+		for n := r.start; ; n++ {
+			if n != r.gateway && !used[n] {
+				return uint32ToIP(n), nil
+			}
+			if n == r.end {
+				break
+			}
+		}
+	}
+	return nil, fmt.Errorf("no IP available")
+}
+
+// isContainerOwner returns whether the Pod owner is the interface ifName of
+// the container, ifName being empty for the primary interface.
+func isContainerOwner(owner *ipamv1alpha1.PodOwner, containerID, ifName string) bool {
+	return owner != nil && owner.ContainerID == containerID && owner.IFName == ifName
+}
+
+func isStatefulSetOwner(owner *ipamv1alpha1.StatefulSetOwner, namespace, name string) bool {
+	return owner != nil && owner.Namespace == namespace && owner.Name == name
+}
+
+// updateIPPool gets the IPPool, lets updateFunc modify a copy of it, and
+// updates the status of the IPPool if updateFunc returns true. It retries on
+// conflicts, when the IPPool has been updated concurrently.
+func (a *IPPoolAllocator) updateIPPool(updateFunc func(pool *ipamv1alpha1.IPPool, ranges []ipRange) (bool, error)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		pool, err := a.crdClient.IpamV1alpha1().IPPools().Get(a.ipPoolName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		ranges, err := parseIPRanges(pool)
+		if err != nil {
+			return err
+		}
+		toUpdate := pool.DeepCopy()
+		changed, err := updateFunc(toUpdate, ranges)
+		if err != nil || !changed {
+			return err
+		}
+		_, err = a.crdClient.IpamV1alpha1().IPPools().UpdateStatus(toUpdate)
+		return err
+	})
+}
+
+// AllocateIP allocates an IP to the Pod owner, and returns the IP with the
+// information of its subnet. It is idempotent: the IP already allocated to the
+// interface of the container of the Pod is returned if there is one. If owner also includes a
+// StatefulSet owner, the IP reserved for the StatefulSet Pod is allocated if
+// there is one, otherwise a new IP is allocated and reserved for it.
+func (a *IPPoolAllocator) AllocateIP(owner ipamv1alpha1.IPAddressOwner) (net.IP, *ipamv1alpha1.SubnetInfo, error) {
+	if owner.Pod == nil {
+		return nil, nil, fmt.Errorf("a Pod owner is required to allocate an IP")
+	}
+	var ip net.IP
+	var subnetInfo *ipamv1alpha1.SubnetInfo
+	err := a.updateIPPool(func(pool *ipamv1alpha1.IPPool, ranges []ipRange) (bool, error) {
+		var err error
+		addresses := pool.Status.IPAddresses
+		for i := range addresses {
+			if isContainerOwner(addresses[i].Owner.Pod, owner.Pod.ContainerID, owner.Pod.IFName) {
+				ip = net.ParseIP(addresses[i].IPAddress)
+				subnetInfo, err = subnetInfoForIP(ranges, ip)
+				return false, err
+			}
+		}
+		if sts := owner.StatefulSet; sts != nil {
+			for i := range addresses {
+				reserved := addresses[i].Owner.StatefulSet
+				if isStatefulSetOwner(reserved, sts.Namespace, sts.Name) && reserved.Index == sts.Index {
+					ip = net.ParseIP(addresses[i].IPAddress)
+					if subnetInfo, err = subnetInfoForIP(ranges, ip); err != nil {
+						return false, err
+					}
+					addresses[i].Phase = ipamv1alpha1.IPAddressPhaseAllocated
+					addresses[i].Owner.Pod = owner.Pod.DeepCopy()
+					return true, nil
+				}
+			}
+		}
+		if ip, err = nextAvailableIP(ranges, &pool.Status); err != nil {
+			return false, fmt.Errorf("failed to allocate IP from IPPool %s: %v", pool.Name, err)
+		}
+		subnetInfo, _ = subnetInfoForIP(ranges, ip)
+		pool.Status.IPAddresses = append(addresses, ipamv1alpha1.IPAddressState{
+			IPAddress: ip.String(),
+			Phase:     ipamv1alpha1.IPAddressPhaseAllocated,
+			Owner:     *owner.DeepCopy(),
+		})
+		return true, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	klog.V(2).Infof("Allocated IP %s from IPPool %s to container %s", ip, a.ipPoolName, owner.Pod.ContainerID)
+	return ip, subnetInfo, nil
+}
+
+// GetContainerIP returns the IP allocated to the interface ifName of the
+// container, or nil if there is none. ifName is empty for the primary
+// interface of the container.
+func (a *IPPoolAllocator) GetContainerIP(containerID, ifName string) (net.IP, *ipamv1alpha1.SubnetInfo, error) {
+	pool, err := a.crdClient.IpamV1alpha1().IPPools().Get(a.ipPoolName, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	ranges, err := parseIPRanges(pool)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, state := range pool.Status.IPAddresses {
+		if isContainerOwner(state.Owner.Pod, containerID, ifName) {
+			ip := net.ParseIP(state.IPAddress)
+			subnetInfo, err := subnetInfoForIP(ranges, ip)
+			return ip, subnetInfo, err
+		}
+	}
+	return nil, nil, nil
+}
+
+// ReleaseContainerIP releases the IP allocated to the interface ifName of the
+// container, ifName being empty for the primary interface. An IP reserved for
+// a StatefulSet Pod stays reserved for the next incarnation of the Pod.
+// Releasing a container without IP is not an error.
+func (a *IPPoolAllocator) ReleaseContainerIP(containerID, ifName string) error {
+	return a.updateIPPool(func(pool *ipamv1alpha1.IPPool, _ []ipRange) (bool, error) {
+		addresses := pool.Status.IPAddresses
+		for i := range addresses {
+			if !isContainerOwner(addresses[i].Owner.Pod, containerID, ifName) {
+				continue
+			}
+			klog.V(2).Infof("Releasing IP %s of container %s to IPPool %s", addresses[i].IPAddress, containerID, pool.Name)
+			if addresses[i].Owner.StatefulSet != nil {
+				addresses[i].Phase = ipamv1alpha1.IPAddressPhaseReserved
+				addresses[i].Owner.Pod = nil
+			} else {
+				pool.Status.IPAddresses = append(addresses[:i], addresses[i+1:]...)
+			}
+			return true, nil
+		}
+		return false, nil
+	})
+}
+
+// ReserveStatefulSetIPs reserves an IP for each of the replicas of a
+// StatefulSet, and releases the reserved IPs of the Pods whose ordinal index
+// is not lower than replicas. IPs of the StatefulSet which are still
+// allocated to Pods are kept until the Pods are deleted.
+func (a *IPPoolAllocator) ReserveStatefulSetIPs(namespace, name string, replicas int) error {
+	return a.updateIPPool(func(pool *ipamv1alpha1.IPPool, ranges []ipRange) (bool, error) {
+		changed := false
+		reserved := make(map[int]bool)
+		var addresses []ipamv1alpha1.IPAddressState
+		for _, state := range pool.Status.IPAddresses {
+			sts := state.Owner.StatefulSet
+			if isStatefulSetOwner(sts, namespace, name) {
+				if sts.Index >= replicas && state.Phase == ipamv1alpha1.IPAddressPhaseReserved {
+					changed = true
+					continue
+				}
+				reserved[sts.Index] = true
+			}
+			addresses = append(addresses, state)
+		}
+		pool.Status.IPAddresses = addresses
+		for index := 0; index < replicas; index++ {
+			if reserved[index] {
+				continue
+			}
+			ip, err := nextAvailableIP(ranges, &pool.Status)
+			if err != nil {
+				return false, fmt.Errorf("failed to reserve IP from IPPool %s for StatefulSet %s/%s: %v", pool.Name, namespace, name, err)
+			}
+			pool.Status.IPAddresses = append(pool.Status.IPAddresses, ipamv1alpha1.IPAddressState{
+				IPAddress: ip.String(),
+				Phase:     ipamv1alpha1.IPAddressPhaseReserved,
+				Owner: ipamv1alpha1.IPAddressOwner{
+					StatefulSet: &ipamv1alpha1.StatefulSetOwner{Name: name, Namespace: namespace, Index: index},
+				},
+			})
+			changed = true
+		}
+		return changed, nil
+	})
+}
+
+// ReleaseStatefulSetIPs releases the IPs reserved for a StatefulSet. IPs still
+// allocated to Pods of the StatefulSet are released when the Pods are deleted.
+func (a *IPPoolAllocator) ReleaseStatefulSetIPs(namespace, name string) error {
+	return a.updateIPPool(func(pool *ipamv1alpha1.IPPool, _ []ipRange) (bool, error) {
+		changed := false
+		var addresses []ipamv1alpha1.IPAddressState
+		for _, state := range pool.Status.IPAddresses {
+			if isStatefulSetOwner(state.Owner.StatefulSet, namespace, name) {
+				changed = true
+				if state.Phase == ipamv1alpha1.IPAddressPhaseReserved {
+					continue
+				}
+				state.Owner.StatefulSet = nil
+			}
+			addresses = append(addresses, state)
+		}
+		pool.Status.IPAddresses = addresses
+		return changed, nil
+	})
+}
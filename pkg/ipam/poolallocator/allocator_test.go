@@ -0,0 +1,230 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poolallocator
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ipamv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/ipam/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/fake"
+)
+
+const testPoolName = "pool1"
+
+func newTestAllocator(ranges ...ipamv1alpha1.SubnetIPRange) (*IPPoolAllocator, *fake.Clientset) {
+	pool := &ipamv1alpha1.IPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: testPoolName},
+		Spec:       ipamv1alpha1.IPPoolSpec{IPRanges: ranges},
+	}
+	client := fake.NewSimpleClientset(pool)
+	return NewIPPoolAllocator(testPoolName, client), client
+}
+
+func getIPAddresses(t *testing.T, client *fake.Clientset) []ipamv1alpha1.IPAddressState {
+	pool, err := client.IpamV1alpha1().IPPools().Get(testPoolName, metav1.GetOptions{})
+	require.NoError(t, err)
+	return pool.Status.IPAddresses
+}
+
+func podOwner(name, containerID string) ipamv1alpha1.IPAddressOwner {
+	return ipamv1alpha1.IPAddressOwner{
+		Pod: &ipamv1alpha1.PodOwner{Name: name, Namespace: "ns1", ContainerID: containerID, NodeName: "node1"},
+	}
+}
+
+var (
+	cidrRange = ipamv1alpha1.SubnetIPRange{
+		IPRange:    ipamv1alpha1.IPRange{CIDR: "10.2.0.0/30"},
+		SubnetInfo: ipamv1alpha1.SubnetInfo{Gateway: "10.2.0.1", PrefixLength: 24},
+	}
+	startEndRange = ipamv1alpha1.SubnetIPRange{
+		IPRange:    ipamv1alpha1.IPRange{Start: "10.2.0.10", End: "10.2.0.11"},
+		SubnetInfo: ipamv1alpha1.SubnetInfo{Gateway: "10.2.0.1", PrefixLength: 24},
+	}
+)
+
+func TestAllocateIP(t *testing.T) {
+	allocator, client := newTestAllocator(cidrRange, startEndRange)
+
+	// The network, broadcast and gateway addresses of the CIDR are skipped.
+	ip, subnetInfo, err := allocator.AllocateIP(podOwner("pod1", "c1"))
+	require.NoError(t, err)
+	assert.Equal(t, "10.2.0.2", ip.String())
+	assert.Equal(t, cidrRange.SubnetInfo, *subnetInfo)
+
+	// Allocating again for the same container returns the same IP.
+	ip, _, err = allocator.AllocateIP(podOwner("pod1", "c1"))
+	require.NoError(t, err)
+	assert.Equal(t, "10.2.0.2", ip.String())
+
+	for _, expected := range []string{"10.2.0.10", "10.2.0.11"} {
+		ip, _, err = allocator.AllocateIP(podOwner("pod", expected))
+		require.NoError(t, err)
+		assert.Equal(t, expected, ip.String())
+	}
+	_, _, err = allocator.AllocateIP(podOwner("pod4", "c4"))
+	assert.Error(t, err, "The IPPool should be exhausted")
+	assert.Len(t, getIPAddresses(t, client), 3)
+
+	ip, _, err = allocator.GetContainerIP("c1", "")
+	require.NoError(t, err)
+	assert.Equal(t, "10.2.0.2", ip.String())
+
+	// The released IP can be allocated again.
+	require.NoError(t, allocator.ReleaseContainerIP("c1", ""))
+	require.NoError(t, allocator.ReleaseContainerIP("c1", ""))
+	ip, _, err = allocator.GetContainerIP("c1", "")
+	require.NoError(t, err)
+	assert.Nil(t, ip)
+	ip, _, err = allocator.AllocateIP(podOwner("pod4", "c4"))
+	require.NoError(t, err)
+	assert.Equal(t, "10.2.0.2", ip.String())
+}
+
+func TestAllocateSecondaryInterfaceIP(t *testing.T) {
+	allocator, client := newTestAllocator(cidrRange, startEndRange)
+
+	ip, _, err := allocator.AllocateIP(podOwner("pod1", "c1"))
+	require.NoError(t, err)
+	assert.Equal(t, "10.2.0.2", ip.String())
+
+	// The secondary interfaces of a container are allocated their own IPs.
+	secondaryOwner := podOwner("pod1", "c1")
+	secondaryOwner.Pod.IFName = "net1"
+	ip, _, err = allocator.AllocateIP(secondaryOwner)
+	require.NoError(t, err)
+	assert.Equal(t, "10.2.0.10", ip.String())
+	ip, _, err = allocator.GetContainerIP("c1", "net1")
+	require.NoError(t, err)
+	assert.Equal(t, "10.2.0.10", ip.String())
+
+	// Releasing the IP of an interface keeps the IPs of the other interfaces.
+	require.NoError(t, allocator.ReleaseContainerIP("c1", "net1"))
+	addresses := getIPAddresses(t, client)
+	require.Len(t, addresses, 1)
+	assert.Equal(t, "10.2.0.2", addresses[0].IPAddress)
+}
+
+func TestGetContainerIPPoolAllocator(t *testing.T) {
+	allocator, client := newTestAllocator(cidrRange)
+	_, err := client.IpamV1alpha1().IPPools().Create(&ipamv1alpha1.IPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool2"},
+		Spec:       ipamv1alpha1.IPPoolSpec{IPRanges: []ipamv1alpha1.SubnetIPRange{startEndRange}},
+	})
+	require.NoError(t, err)
+	_, _, err = NewIPPoolAllocator("pool2", client).AllocateIP(podOwner("pod1", "c1"))
+	require.NoError(t, err)
+	_, _, err = allocator.AllocateIP(podOwner("pod2", "c2"))
+	require.NoError(t, err)
+
+	found, err := GetContainerIPPoolAllocator(client, "c1", "")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "pool2", found.ipPoolName)
+	found, err = GetContainerIPPoolAllocator(client, "c2", "")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, testPoolName, found.ipPoolName)
+	// No IPPool allocated an IP to the container or to its secondary interface.
+	found, err = GetContainerIPPoolAllocator(client, "c3", "")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+	found, err = GetContainerIPPoolAllocator(client, "c1", "eth1")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
+
+func TestAllocateIPInvalidPool(t *testing.T) {
+	allocator, _ := newTestAllocator(ipamv1alpha1.SubnetIPRange{
+		IPRange:    ipamv1alpha1.IPRange{CIDR: "10.2.0.0/33"},
+		SubnetInfo: ipamv1alpha1.SubnetInfo{Gateway: "10.2.0.1", PrefixLength: 24},
+	})
+	_, _, err := allocator.AllocateIP(podOwner("pod1", "c1"))
+	assert.Error(t, err)
+}
+
+func TestStatefulSetIPs(t *testing.T) {
+	allocator, client := newTestAllocator(ipamv1alpha1.SubnetIPRange{
+		IPRange:    ipamv1alpha1.IPRange{CIDR: "10.2.0.0/24"},
+		SubnetInfo: ipamv1alpha1.SubnetInfo{Gateway: "10.2.0.1", PrefixLength: 24},
+	})
+
+	require.NoError(t, allocator.ReserveStatefulSetIPs("ns1", "sts1", 2))
+	addresses := getIPAddresses(t, client)
+	require.Len(t, addresses, 2)
+	for i, state := range addresses {
+		assert.Equal(t, ipamv1alpha1.IPAddressPhaseReserved, state.Phase)
+		assert.Equal(t, i, state.Owner.StatefulSet.Index)
+	}
+	reservedIP := addresses[1].IPAddress
+
+	// The Pod of the StatefulSet gets the IP reserved for its index.
+	owner := podOwner("sts1-1", "c1")
+	owner.StatefulSet = &ipamv1alpha1.StatefulSetOwner{Name: "sts1", Namespace: "ns1", Index: 1}
+	ip, _, err := allocator.AllocateIP(owner)
+	require.NoError(t, err)
+	assert.Equal(t, reservedIP, ip.String())
+	assert.Equal(t, ipamv1alpha1.IPAddressPhaseAllocated, getIPAddresses(t, client)[1].Phase)
+
+	// The IP stays reserved when the Pod is deleted, and is allocated to the
+	// next Pod with the same index.
+	require.NoError(t, allocator.ReleaseContainerIP("c1", ""))
+	state := getIPAddresses(t, client)[1]
+	assert.Equal(t, ipamv1alpha1.IPAddressPhaseReserved, state.Phase)
+	assert.Nil(t, state.Owner.Pod)
+	owner.Pod.ContainerID = "c2"
+	ip, _, err = allocator.AllocateIP(owner)
+	require.NoError(t, err)
+	assert.Equal(t, reservedIP, ip.String())
+
+	// Scaling down releases the IPs which are only reserved.
+	require.NoError(t, allocator.ReserveStatefulSetIPs("ns1", "sts1", 1))
+	assert.Len(t, getIPAddresses(t, client), 2)
+	require.NoError(t, allocator.ReserveStatefulSetIPs("ns1", "sts1", 0))
+	addresses = getIPAddresses(t, client)
+	require.Len(t, addresses, 1)
+	assert.Equal(t, reservedIP, addresses[0].IPAddress)
+
+	// The IPs still allocated to Pods are kept when the StatefulSet is
+	// deleted, and released with the Pods.
+	require.NoError(t, allocator.ReleaseStatefulSetIPs("ns1", "sts1"))
+	addresses = getIPAddresses(t, client)
+	require.Len(t, addresses, 1)
+	assert.Nil(t, addresses[0].Owner.StatefulSet)
+	require.NoError(t, allocator.ReleaseContainerIP("c2", ""))
+	assert.Empty(t, getIPAddresses(t, client))
+}
+
+func TestNextAvailableIP(t *testing.T) {
+	pool := &ipamv1alpha1.IPPool{Spec: ipamv1alpha1.IPPoolSpec{IPRanges: []ipamv1alpha1.SubnetIPRange{{
+		IPRange:    ipamv1alpha1.IPRange{CIDR: "10.2.0.4/31"},
+		SubnetInfo: ipamv1alpha1.SubnetInfo{Gateway: "10.2.0.1", PrefixLength: 24},
+	}}}}
+	ranges, err := parseIPRanges(pool)
+	require.NoError(t, err)
+	// No address is excluded from a /31.
+	ip, err := nextAvailableIP(ranges, &pool.Status)
+	require.NoError(t, err)
+	assert.Equal(t, net.ParseIP("10.2.0.4").To4(), ip)
+	pool.Status.IPAddresses = []ipamv1alpha1.IPAddressState{{IPAddress: "10.2.0.4"}}
+	ip, err = nextAvailableIP(ranges, &pool.Status)
+	require.NoError(t, err)
+	assert.Equal(t, net.ParseIP("10.2.0.5").To4(), ip)
+}
@@ -0,0 +1,280 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipfix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	ipfixVersion = 10
+	// templateSetID is the Set ID of the Sets carrying Template Records.
+	templateSetID = 2
+	// minTemplateID is the lowest ID which can be assigned to a template.
+	minTemplateID = 256
+
+	msgHeaderLen = 16
+	setHeaderLen = 4
+	// The maximum length of an IPFIX message sent over UDP is limited so that it fits in the
+	// path MTU, as IPFIX messages must not be fragmented.
+	maxUDPMsgLen = 1400
+	maxTCPMsgLen = 65535
+
+	dialTimeout = 5 * time.Second
+)
+
+// DataRecord is a Data Record to export with the Template identified by TemplateID. Values must
+// be provided in the order of the template's Information Elements.
+type DataRecord struct {
+	TemplateID uint16
+	Values     []interface{}
+}
+
+// ExportingProcess sends IPFIX messages to a Collecting Process over TCP or UDP, as defined in
+// RFC 7011.
+type ExportingProcess struct {
+	conn          net.Conn
+	isUDP         bool
+	obsDomainID   uint32
+	seqNumber     uint32
+	nextTemplate  uint16
+	templates     map[uint16][]*InfoElement
+	templateOrder []uint16
+}
+
+// NewExportingProcess connects to the Collecting Process listening on the provided address.
+// network must be either "tcp" or "udp".
+func NewExportingProcess(network, address string, obsDomainID uint32) (*ExportingProcess, error) {
+	if network != "tcp" && network != "udp" {
+		return nil, fmt.Errorf("unsupported transport protocol %s", network)
+	}
+	conn, err := net.DialTimeout(network, address, dialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("error when connecting to collector %s: %v", address, err)
+	}
+	return &ExportingProcess{
+		conn:         conn,
+		isUDP:        network == "udp",
+		obsDomainID:  obsDomainID,
+		nextTemplate: minTemplateID,
+		templates:    make(map[uint16][]*InfoElement),
+	}, nil
+}
+
+// AddTemplate registers a Template with the provided Information Elements and returns its ID.
+// The Template is sent to the Collecting Process by SendTemplates.
+func (ep *ExportingProcess) AddTemplate(elements []*InfoElement) uint16 {
+	id := ep.nextTemplate
+	ep.nextTemplate++
+	ep.templates[id] = elements
+	ep.templateOrder = append(ep.templateOrder, id)
+	return id
+}
+
+// SendTemplates sends all the registered Templates in a single message. Templates must be sent
+// before the first Data Records, and they must be resent periodically over UDP, as the
+// Collecting Process may have missed them.
+func (ep *ExportingProcess) SendTemplates() error {
+	set := new(bytes.Buffer)
+	for _, id := range ep.templateOrder {
+		elements := ep.templates[id]
+		binary.Write(set, binary.BigEndian, id)
+		binary.Write(set, binary.BigEndian, uint16(len(elements)))
+		for _, ie := range elements {
+			if ie.EnterpriseID != IANAEnterpriseID {
+				binary.Write(set, binary.BigEndian, ie.ElementID|0x8000)
+				binary.Write(set, binary.BigEndian, ie.Len)
+				binary.Write(set, binary.BigEndian, ie.EnterpriseID)
+			} else {
+				binary.Write(set, binary.BigEndian, ie.ElementID)
+				binary.Write(set, binary.BigEndian, ie.Len)
+			}
+		}
+	}
+	return ep.sendMessage([]*bytes.Buffer{newSet(templateSetID, set.Bytes())}, 0)
+}
+
+// SendDataRecords encodes the provided Data Records and sends them in as few messages as
+// possible. Consecutive records using the same Template are sent in the same Set.
+func (ep *ExportingProcess) SendDataRecords(records []DataRecord) error {
+	maxLen := maxTCPMsgLen
+	if ep.isUDP {
+		maxLen = maxUDPMsgLen
+	}
+	var sets []*bytes.Buffer
+	var msgLen, recordCount int
+	var currentTemplate uint16
+	var current *bytes.Buffer
+	flushSet := func() {
+		if current != nil {
+			sets = append(sets, newSet(currentTemplate, current.Bytes()))
+			current = nil
+		}
+	}
+	for _, record := range records {
+		encoded, err := ep.encodeRecord(record)
+		if err != nil {
+			return err
+		}
+		recordLen := len(encoded)
+		if current == nil || record.TemplateID != currentTemplate {
+			recordLen += setHeaderLen
+		}
+		if msgHeaderLen+msgLen+recordLen > maxLen && recordCount > 0 {
+			flushSet()
+			if err := ep.sendMessage(sets, uint32(recordCount)); err != nil {
+				return err
+			}
+			sets, msgLen, recordCount = nil, 0, 0
+			recordLen = len(encoded) + setHeaderLen
+		}
+		if current == nil || record.TemplateID != currentTemplate {
+			flushSet()
+			current = new(bytes.Buffer)
+			currentTemplate = record.TemplateID
+		}
+		current.Write(encoded)
+		msgLen += recordLen
+		recordCount++
+	}
+	flushSet()
+	if recordCount == 0 {
+		return nil
+	}
+	return ep.sendMessage(sets, uint32(recordCount))
+}
+
+// Close closes the connection to the Collecting Process.
+func (ep *ExportingProcess) Close() error {
+	return ep.conn.Close()
+}
+
+func (ep *ExportingProcess) encodeRecord(record DataRecord) ([]byte, error) {
+	elements, ok := ep.templates[record.TemplateID]
+	if !ok {
+		return nil, fmt.Errorf("unknown template %d", record.TemplateID)
+	}
+	if len(elements) != len(record.Values) {
+		return nil, fmt.Errorf("template %d has %d elements but %d values are provided", record.TemplateID, len(elements), len(record.Values))
+	}
+	buf := new(bytes.Buffer)
+	for i, ie := range elements {
+		if err := encodeValue(buf, ie, record.Values[i]); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeValue(buf *bytes.Buffer, ie *InfoElement, value interface{}) error {
+	invalid := fmt.Errorf("invalid value %v for Information Element %s", value, ie.Name)
+	switch ie.DataType {
+	case Unsigned8:
+		v, ok := value.(uint8)
+		if !ok {
+			return invalid
+		}
+		buf.WriteByte(v)
+	case Unsigned16:
+		v, ok := value.(uint16)
+		if !ok {
+			return invalid
+		}
+		binary.Write(buf, binary.BigEndian, v)
+	case Unsigned32:
+		v, ok := value.(uint32)
+		if !ok {
+			return invalid
+		}
+		binary.Write(buf, binary.BigEndian, v)
+	case Unsigned64:
+		v, ok := value.(uint64)
+		if !ok {
+			return invalid
+		}
+		binary.Write(buf, binary.BigEndian, v)
+	case DateTimeSeconds:
+		v, ok := value.(time.Time)
+		if !ok {
+			return invalid
+		}
+		binary.Write(buf, binary.BigEndian, uint32(v.Unix()))
+	case IPv4Address:
+		v, ok := value.(net.IP)
+		if !ok || v.To4() == nil {
+			return invalid
+		}
+		buf.Write(v.To4())
+	case IPv6Address:
+		v, ok := value.(net.IP)
+		if !ok || v.To16() == nil {
+			return invalid
+		}
+		buf.Write(v.To16())
+	case String:
+		v, ok := value.(string)
+		if !ok {
+			return invalid
+		}
+		// Variable-length values shorter than 255 bytes are prefixed with a 1-byte length,
+		// others with 255 followed by a 2-byte length.
+		if len(v) < 255 {
+			buf.WriteByte(uint8(len(v)))
+		} else {
+			buf.WriteByte(255)
+			binary.Write(buf, binary.BigEndian, uint16(len(v)))
+		}
+		buf.WriteString(v)
+	default:
+		return fmt.Errorf("unsupported data type %d for Information Element %s", ie.DataType, ie.Name)
+	}
+	return nil
+}
+
+func newSet(setID uint16, content []byte) *bytes.Buffer {
+	set := new(bytes.Buffer)
+	binary.Write(set, binary.BigEndian, setID)
+	binary.Write(set, binary.BigEndian, uint16(setHeaderLen+len(content)))
+	set.Write(content)
+	return set
+}
+
+// sendMessage sends a message made of the provided Sets. recordCount is the number of Data
+// Records in the message, which is used to compute the Sequence Number of the next message.
+func (ep *ExportingProcess) sendMessage(sets []*bytes.Buffer, recordCount uint32) error {
+	length := msgHeaderLen
+	for _, set := range sets {
+		length += set.Len()
+	}
+	msg := bytes.NewBuffer(make([]byte, 0, length))
+	binary.Write(msg, binary.BigEndian, uint16(ipfixVersion))
+	binary.Write(msg, binary.BigEndian, uint16(length))
+	binary.Write(msg, binary.BigEndian, uint32(time.Now().Unix()))
+	binary.Write(msg, binary.BigEndian, ep.seqNumber)
+	binary.Write(msg, binary.BigEndian, ep.obsDomainID)
+	for _, set := range sets {
+		msg.Write(set.Bytes())
+	}
+	if _, err := ep.conn.Write(msg.Bytes()); err != nil {
+		return fmt.Errorf("error when sending IPFIX message: %v", err)
+	}
+	// The Sequence Number is the number of Data Records sent before the message.
+	ep.seqNumber += recordCount
+	return nil
+}
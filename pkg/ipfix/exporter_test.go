@@ -0,0 +1,133 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipfix
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSet struct {
+	id      uint16
+	content []byte
+}
+
+// receiveMessage reads an IPFIX message from the provided UDP connection and returns its
+// Sequence Number and Sets.
+func receiveMessage(t *testing.T, conn net.PacketConn) (uint32, []testSet) {
+	buf := make([]byte, 65535)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	msg := buf[:n]
+	require.True(t, len(msg) >= msgHeaderLen)
+	assert.Equal(t, uint16(ipfixVersion), binary.BigEndian.Uint16(msg[0:2]))
+	assert.Equal(t, uint16(n), binary.BigEndian.Uint16(msg[2:4]))
+	assert.Equal(t, uint32(1), binary.BigEndian.Uint32(msg[12:16]))
+	seqNumber := binary.BigEndian.Uint32(msg[8:12])
+	var sets []testSet
+	for offset := msgHeaderLen; offset < n; {
+		setLen := int(binary.BigEndian.Uint16(msg[offset+2 : offset+4]))
+		sets = append(sets, testSet{id: binary.BigEndian.Uint16(msg[offset : offset+2]), content: msg[offset+setHeaderLen : offset+setLen]})
+		offset += setLen
+	}
+	return seqNumber, sets
+}
+
+func TestExportingProcess(t *testing.T) {
+	collector, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer collector.Close()
+
+	ep, err := NewExportingProcess("udp", collector.LocalAddr().String(), 1)
+	require.NoError(t, err)
+	defer ep.Close()
+	templateID := ep.AddTemplate([]*InfoElement{SourceIPv4Address, SourceTransportPort, PacketTotalCount, SourcePodName})
+	assert.Equal(t, uint16(minTemplateID), templateID)
+
+	require.NoError(t, ep.SendTemplates())
+	seqNumber, sets := receiveMessage(t, collector)
+	assert.Equal(t, uint32(0), seqNumber)
+	require.Equal(t, 1, len(sets))
+	assert.Equal(t, uint16(templateSetID), sets[0].id)
+	expectedTemplate := []byte{
+		0x01, 0x00, 0x00, 0x04, // Template ID 256, 4 fields.
+		0x00, 0x08, 0x00, 0x04, // sourceIPv4Address.
+		0x00, 0x07, 0x00, 0x02, // sourceTransportPort.
+		0x00, 0x56, 0x00, 0x08, // packetTotalCount.
+		0x80, 0x65, 0xff, 0xff, 0x00, 0x00, 0xdc, 0xba, // sourcePodName, variable length, enterprise 56506.
+	}
+	assert.Equal(t, expectedTemplate, sets[0].content)
+
+	records := []DataRecord{
+		{TemplateID: templateID, Values: []interface{}{net.ParseIP("10.10.0.1"), uint16(80), uint64(10), "pod1"}},
+		{TemplateID: templateID, Values: []interface{}{net.ParseIP("10.10.0.2"), uint16(443), uint64(20), ""}},
+	}
+	require.NoError(t, ep.SendDataRecords(records))
+	seqNumber, sets = receiveMessage(t, collector)
+	assert.Equal(t, uint32(0), seqNumber)
+	require.Equal(t, 1, len(sets))
+	assert.Equal(t, templateID, sets[0].id)
+	expectedRecords := []byte{
+		10, 10, 0, 1, 0, 80, 0, 0, 0, 0, 0, 0, 0, 10, 4, 'p', 'o', 'd', '1',
+		10, 10, 0, 2, 0x01, 0xbb, 0, 0, 0, 0, 0, 0, 0, 20, 0,
+	}
+	assert.Equal(t, expectedRecords, sets[0].content)
+
+	// The Sequence Number is incremented by the number of Data Records previously sent.
+	require.NoError(t, ep.SendDataRecords(records[:1]))
+	seqNumber, _ = receiveMessage(t, collector)
+	assert.Equal(t, uint32(2), seqNumber)
+}
+
+func TestSendDataRecordsSplitMessages(t *testing.T) {
+	collector, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer collector.Close()
+
+	ep, err := NewExportingProcess("udp", collector.LocalAddr().String(), 1)
+	require.NoError(t, err)
+	defer ep.Close()
+	templateID := ep.AddTemplate([]*InfoElement{PacketTotalCount})
+
+	// Each record is 8 bytes, so that 200 records don't fit in a single UDP message.
+	var records []DataRecord
+	for i := 0; i < 200; i++ {
+		records = append(records, DataRecord{TemplateID: templateID, Values: []interface{}{uint64(i)}})
+	}
+	require.NoError(t, ep.SendDataRecords(records))
+	seqNumber, sets := receiveMessage(t, collector)
+	assert.Equal(t, uint32(0), seqNumber)
+	firstCount := len(sets[0].content) / 8
+	assert.True(t, firstCount < 200)
+	seqNumber, sets = receiveMessage(t, collector)
+	assert.Equal(t, uint32(firstCount), seqNumber)
+	assert.Equal(t, 200-firstCount, len(sets[0].content)/8)
+}
+
+func TestEncodeInvalidValue(t *testing.T) {
+	ep := &ExportingProcess{templates: map[uint16][]*InfoElement{minTemplateID: {SourceIPv4Address}}}
+	_, err := ep.encodeRecord(DataRecord{TemplateID: minTemplateID, Values: []interface{}{net.ParseIP("fd00::1")}})
+	assert.Error(t, err)
+	_, err = ep.encodeRecord(DataRecord{TemplateID: minTemplateID, Values: []interface{}{"10.10.0.1"}})
+	assert.Error(t, err)
+	_, err = ep.encodeRecord(DataRecord{TemplateID: minTemplateID + 1, Values: []interface{}{net.ParseIP("10.10.0.1")}})
+	assert.Error(t, err)
+}
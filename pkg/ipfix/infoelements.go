@@ -0,0 +1,99 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipfix
+
+// DataType is the abstract data type of an Information Element, as defined in RFC 7012. Only the
+// types used by Antrea are supported.
+type DataType uint8
+
+const (
+	Unsigned8 DataType = iota
+	Unsigned16
+	Unsigned32
+	Unsigned64
+	DateTimeSeconds
+	IPv4Address
+	IPv6Address
+	String
+)
+
+const (
+	// VariableLength is the field length of the Information Elements encoded with a variable
+	// length, as defined in RFC 7011.
+	VariableLength uint16 = 65535
+
+	// IANAEnterpriseID is the enterprise number of the Information Elements registered by IANA.
+	IANAEnterpriseID uint32 = 0
+	// IANAReversedEnterpriseID is the enterprise number of the reverse Information Elements
+	// defined in RFC 5103 for bidirectional flows.
+	IANAReversedEnterpriseID uint32 = 29305
+	// AntreaEnterpriseID is the enterprise number of the Antrea-specific Information Elements.
+	AntreaEnterpriseID uint32 = 56506
+)
+
+// InfoElement describes an IPFIX Information Element.
+type InfoElement struct {
+	Name         string
+	ElementID    uint16
+	DataType     DataType
+	EnterpriseID uint32
+	// Len is the length of the encoded values in bytes, or VariableLength.
+	Len uint16
+}
+
+func newInfoElement(name string, elementID uint16, dataType DataType, enterpriseID uint32, length uint16) *InfoElement {
+	return &InfoElement{Name: name, ElementID: elementID, DataType: dataType, EnterpriseID: enterpriseID, Len: length}
+}
+
+// reverse returns the reverse Information Element of the provided IANA Information Element, as
+// defined in RFC 5103.
+func reverse(name string, ie *InfoElement) *InfoElement {
+	return newInfoElement(name, ie.ElementID, ie.DataType, IANAReversedEnterpriseID, ie.Len)
+}
+
+// IANA Information Elements.
+var (
+	OctetDeltaCount          = newInfoElement("octetDeltaCount", 1, Unsigned64, IANAEnterpriseID, 8)
+	PacketDeltaCount         = newInfoElement("packetDeltaCount", 2, Unsigned64, IANAEnterpriseID, 8)
+	ProtocolIdentifier       = newInfoElement("protocolIdentifier", 4, Unsigned8, IANAEnterpriseID, 1)
+	SourceTransportPort      = newInfoElement("sourceTransportPort", 7, Unsigned16, IANAEnterpriseID, 2)
+	SourceIPv4Address        = newInfoElement("sourceIPv4Address", 8, IPv4Address, IANAEnterpriseID, 4)
+	DestinationTransportPort = newInfoElement("destinationTransportPort", 11, Unsigned16, IANAEnterpriseID, 2)
+	DestinationIPv4Address   = newInfoElement("destinationIPv4Address", 12, IPv4Address, IANAEnterpriseID, 4)
+	SourceIPv6Address        = newInfoElement("sourceIPv6Address", 27, IPv6Address, IANAEnterpriseID, 16)
+	DestinationIPv6Address   = newInfoElement("destinationIPv6Address", 28, IPv6Address, IANAEnterpriseID, 16)
+	OctetTotalCount          = newInfoElement("octetTotalCount", 85, Unsigned64, IANAEnterpriseID, 8)
+	PacketTotalCount         = newInfoElement("packetTotalCount", 86, Unsigned64, IANAEnterpriseID, 8)
+	FlowStartSeconds         = newInfoElement("flowStartSeconds", 150, DateTimeSeconds, IANAEnterpriseID, 4)
+	FlowEndSeconds           = newInfoElement("flowEndSeconds", 151, DateTimeSeconds, IANAEnterpriseID, 4)
+)
+
+// Reverse Information Elements.
+var (
+	ReverseOctetDeltaCount  = reverse("reverseOctetDeltaCount", OctetDeltaCount)
+	ReversePacketDeltaCount = reverse("reversePacketDeltaCount", PacketDeltaCount)
+	ReverseOctetTotalCount  = reverse("reverseOctetTotalCount", OctetTotalCount)
+	ReversePacketTotalCount = reverse("reversePacketTotalCount", PacketTotalCount)
+)
+
+// Antrea-specific Information Elements, which add the Kubernetes context to the flow records.
+var (
+	SourcePodNamespace      = newInfoElement("sourcePodNamespace", 100, String, AntreaEnterpriseID, VariableLength)
+	SourcePodName           = newInfoElement("sourcePodName", 101, String, AntreaEnterpriseID, VariableLength)
+	SourceNodeName          = newInfoElement("sourceNodeName", 102, String, AntreaEnterpriseID, VariableLength)
+	DestinationPodNamespace = newInfoElement("destinationPodNamespace", 103, String, AntreaEnterpriseID, VariableLength)
+	DestinationPodName      = newInfoElement("destinationPodName", 104, String, AntreaEnterpriseID, VariableLength)
+	DestinationNodeName     = newInfoElement("destinationNodeName", 105, String, AntreaEnterpriseID, VariableLength)
+)
@@ -32,19 +32,25 @@ import (
 
 const crdName = "antrea-controller"
 
+// leaderGetter returns the identity of the leader antrea-controller replica.
+type leaderGetter interface {
+	GetLeader() string
+}
+
 type controllerMonitor struct {
 	client       clientset.Interface
 	nodeInformer coreinformers.NodeInformer
 	// nodeListerSynced is a function which returns true if the node shared informer has been synced at least once.
 	nodeListerSynced cache.InformerSynced
 	querier          controllerquerier.ControllerQuerier
+	elector          leaderGetter
 	// controllerCRD is the desired state of controller monitoring CRD which controllerMonitor expects.
 	controllerCRD *v1beta1.AntreaControllerInfo
 }
 
 // NewControllerMonitor creates a new controller monitor.
-func NewControllerMonitor(client clientset.Interface, nodeInformer coreinformers.NodeInformer, querier controllerquerier.ControllerQuerier) *controllerMonitor {
-	m := &controllerMonitor{client: client, nodeInformer: nodeInformer, nodeListerSynced: nodeInformer.Informer().HasSynced, querier: querier, controllerCRD: nil}
+func NewControllerMonitor(client clientset.Interface, nodeInformer coreinformers.NodeInformer, querier controllerquerier.ControllerQuerier, elector leaderGetter) *controllerMonitor {
+	m := &controllerMonitor{client: client, nodeInformer: nodeInformer, nodeListerSynced: nodeInformer.Informer().HasSynced, querier: querier, elector: elector, controllerCRD: nil}
 	nodeInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    nil,
 		UpdateFunc: nil,
@@ -114,6 +120,7 @@ func (monitor *controllerMonitor) createControllerCRD(crdName string) (*v1beta1.
 	controllerCRD := new(v1beta1.AntreaControllerInfo)
 	controllerCRD.Name = crdName
 	monitor.querier.GetControllerInfo(controllerCRD, false)
+	controllerCRD.Leader = monitor.elector.GetLeader()
 	klog.V(2).Infof("Creating controller monitoring CRD %+v", controllerCRD)
 	return monitor.client.ClusterinformationV1beta1().AntreaControllerInfos().Create(controllerCRD)
 }
@@ -121,6 +128,7 @@ func (monitor *controllerMonitor) createControllerCRD(crdName string) (*v1beta1.
 // updateControllerCRD updates the monitoring CRD.
 func (monitor *controllerMonitor) updateControllerCRD(partial bool) (*v1beta1.AntreaControllerInfo, error) {
 	monitor.querier.GetControllerInfo(monitor.controllerCRD, partial)
+	monitor.controllerCRD.Leader = monitor.elector.GetLeader()
 	klog.V(2).Infof("Updating controller monitoring CRD %+v, partial: %t", monitor.controllerCRD, partial)
 	return monitor.client.ClusterinformationV1beta1().AntreaControllerInfos().Update(monitor.controllerCRD)
 }
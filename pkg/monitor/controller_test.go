@@ -0,0 +1,69 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/fake"
+	queriertest "github.com/vmware-tanzu/antrea/pkg/controller/querier/testing"
+)
+
+type fakeElector struct {
+	leader string
+}
+
+func (e *fakeElector) GetLeader() string {
+	return e.leader
+}
+
+func TestSyncControllerCRDLeader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := fake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(k8sfake.NewSimpleClientset(), 0)
+	querier := queriertest.NewMockControllerQuerier(ctrl)
+	querier.EXPECT().GetControllerInfo(gomock.Any(), gomock.Any()).AnyTimes()
+	elector := &fakeElector{leader: "antrea-controller-1"}
+	monitor := NewControllerMonitor(client, informerFactory.Core().V1().Nodes(), querier, elector)
+
+	getLeader := func() string {
+		controllerInfo, err := client.ClusterinformationV1beta1().AntreaControllerInfos().Get(crdName, metav1.GetOptions{})
+		require.NoError(t, err)
+		return controllerInfo.Leader
+	}
+
+	// The first sync creates the CRD.
+	monitor.syncControllerCRD()
+	assert.Equal(t, "antrea-controller-1", getLeader())
+
+	// The following syncs update the leader.
+	elector.leader = "antrea-controller-2"
+	monitor.syncControllerCRD()
+	assert.Equal(t, "antrea-controller-2", getLeader())
+
+	// The leader is also set when the CRD created by a previous leader is updated entirely.
+	monitor.controllerCRD = nil
+	elector.leader = "antrea-controller-3"
+	monitor.syncControllerCRD()
+	assert.Equal(t, "antrea-controller-3", getLeader())
+}
@@ -18,6 +18,7 @@ import (
 	"net"
 	"time"
 
+	"github.com/contiv/libOpenflow/protocol"
 	"github.com/contiv/ofnet/ofctrl"
 )
 
@@ -41,6 +42,12 @@ const (
 	ProtocolUDP  Protocol = "udp"
 	ProtocolSCTP Protocol = "sctp"
 	ProtocolICMP Protocol = "icmp"
+
+	ProtocolIPv6   Protocol = "ipv6"
+	ProtocolTCPv6  Protocol = "tcp6"
+	ProtocolUDPv6  Protocol = "udp6"
+	ProtocolSCTPv6 Protocol = "sctp6"
+	ProtocolICMPv6 Protocol = "icmp6"
 )
 
 const (
@@ -53,6 +60,8 @@ const (
 const (
 	NxmFieldSrcMAC      = "NXM_OF_ETH_SRC"
 	NxmFieldDstMAC      = "NXM_OF_ETH_DST"
+	NxmFieldSrcIPv4     = "NXM_OF_IP_SRC"
+	NxmFieldDstIPv4     = "NXM_OF_IP_DST"
 	NxmFieldARPSha      = "NXM_NX_ARP_SHA"
 	NxmFieldARPTha      = "NXM_NX_ARP_THA"
 	NxmFieldARPSpa      = "NXM_OF_ARP_SPA"
@@ -78,7 +87,7 @@ type Bridge interface {
 	DeleteGroup(id GroupIDType) bool
 	DumpTableStatus() []TableStatus
 	// DumpFlows queries the Openflow entries from OFSwitch. The filter of the query is Openflow cookieID; the result is
-	// a map from flow cookieID to FlowStates.
+	// a map from flow cookieID to FlowStates. The counts of the entries sharing a cookie are summed.
 	DumpFlows(cookieID, cookieMask uint64) (map[uint64]*FlowStates, error)
 	// DeleteFlowsByCookie removes Openflow entries from OFSwitch. The removed Openflow entries use the specific CookieID.
 	DeleteFlowsByCookie(cookieID, cookieMask uint64) error
@@ -106,6 +115,8 @@ type Bridge interface {
 	AddTLVMap(optClass uint16, optType uint8, optLength uint8, tunMetadataIndex uint16) error
 	// SendPacketOut sends a packetOut message to the OVS Bridge.
 	SendPacketOut(packetOut *ofctrl.PacketOut) error
+	// SendPacketOutFrame outputs the provided Ethernet frame to outPort unchanged.
+	SendPacketOutFrame(frame *protocol.Ethernet, outPort uint32) error
 	// BuildPacketOut returns a new PacketOutBuilder.
 	BuildPacketOut() PacketOutBuilder
 }
@@ -212,9 +223,15 @@ type FlowBuilder interface {
 	MatchCTMark(value uint32) FlowBuilder
 	MatchConjID(value uint32) FlowBuilder
 	MatchTCPDstPort(port uint16) FlowBuilder
+	MatchTCPSrcPort(port uint16) FlowBuilder
 	MatchUDPDstPort(port uint16) FlowBuilder
+	MatchUDPSrcPort(port uint16) FlowBuilder
 	MatchSCTPDstPort(port uint16) FlowBuilder
+	MatchDstPortMask(port uint16, mask uint16) FlowBuilder
+	MatchICMPType(icmpType uint8) FlowBuilder
+	MatchICMPCode(icmpCode uint8) FlowBuilder
 	MatchTunMetadata(index int, data uint32) FlowBuilder
+	MatchIPDscp(dscp uint8) FlowBuilder
 	Cookie(cookieID uint64) FlowBuilder
 	SetHardTimeout(timout uint16) FlowBuilder
 	SetIdleTimeout(timeout uint16) FlowBuilder
@@ -238,6 +255,9 @@ type LearnAction interface {
 
 type Group interface {
 	OFEntry
+	// ResetBuckets removes all the buckets of the Group. It does not modify the Group on the OFSwitch, Modify should
+	// be called after the new buckets are added.
+	ResetBuckets() Group
 	Bucket() BucketBuilder
 }
 
@@ -273,17 +293,21 @@ type PacketOutBuilder interface {
 	SetSrcIP(ip net.IP) PacketOutBuilder
 	SetDstIP(ip net.IP) PacketOutBuilder
 	SetIPProtocol(protocol Protocol) PacketOutBuilder
+	SetIPDscp(dscp uint8) PacketOutBuilder
 	SetTTL(ttl uint8) PacketOutBuilder
 	SetIPFlags(flags uint16) PacketOutBuilder
 	SetTCPSrcPort(port uint16) PacketOutBuilder
 	SetTCPDstPort(port uint16) PacketOutBuilder
 	SetTCPFlags(flags uint8) PacketOutBuilder
+	SetTCPSeqNum(seqNum uint32) PacketOutBuilder
+	SetTCPAckNum(ackNum uint32) PacketOutBuilder
 	SetUDPSrcPort(port uint16) PacketOutBuilder
 	SetUDPDstPort(port uint16) PacketOutBuilder
 	SetICMPType(icmpType uint8) PacketOutBuilder
 	SetICMPCode(icmpCode uint8) PacketOutBuilder
 	SetICMPID(id uint16) PacketOutBuilder
 	SetICMPSequence(seq uint16) PacketOutBuilder
+	SetICMPData(data []byte) PacketOutBuilder
 	SetInport(inPort uint32) PacketOutBuilder
 	SetOutport(outport uint32) PacketOutBuilder
 	AddLoadAction(name string, data uint64, rng Range) PacketOutBuilder
@@ -306,3 +330,27 @@ type PortRange struct {
 	StartPort uint16
 	EndPort   uint16
 }
+
+// Packet describes the header fields of a packet to be sent with a PacketOut message.
+type Packet struct {
+	SourceMAC       net.HardwareAddr
+	DestinationMAC  net.HardwareAddr
+	SourceIP        net.IP
+	DestinationIP   net.IP
+	IPProto         uint8
+	IPFlags         uint16
+	TTL             uint8
+	SourcePort      uint16
+	DestinationPort uint16
+	TCPFlags        uint8
+	// TCPSeqNum and TCPAckNum are random if they are not set.
+	TCPSeqNum   *uint32
+	TCPAckNum   *uint32
+	ICMPType    uint8
+	ICMPCode    uint8
+	ICMPEchoID  uint16
+	ICMPEchoSeq uint16
+	// ICMPData is the data following the checksum in the ICMP header of the ICMP messages which
+	// are not echo requests.
+	ICMPData []byte
+}
@@ -332,7 +332,11 @@ func (a *ofLearnAction) MatchTransportDst(protocol Protocol) LearnAction {
 	}
 	a.MatchEthernetProtocolIP()
 	ipTypeVal := make([]byte, 2)
-	ipTypeVal[1] = byte(ofctrl.IP_PROTO_TCP)
+	if protocol == ProtocolTCP {
+		ipTypeVal[1] = byte(ofctrl.IP_PROTO_TCP)
+	} else {
+		ipTypeVal[1] = byte(ofctrl.IP_PROTO_UDP)
+	}
 	a.nxLearn.AddMatch(&ofctrl.LearnField{Name: "NXM_OF_IP_PROTO"}, 1*8, nil, ipTypeVal)
 	fieldName := fmt.Sprintf("NXM_OF_%s_DST", strings.ToUpper(string(protocol)))
 	a.nxLearn.AddMatch(&ofctrl.LearnField{Name: fieldName}, 2*8, &ofctrl.LearnField{Name: fieldName}, nil)
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/contiv/libOpenflow/openflow13"
+	"github.com/contiv/libOpenflow/protocol"
 	"github.com/contiv/ofnet/ofctrl"
 	"k8s.io/klog"
 )
@@ -80,7 +81,8 @@ func (t *ofTable) BuildFlow(priority uint16) FlowBuilder {
 	return fb
 }
 
-// DumpFlows dumps all existing Openflow entries from OFSwitch using cookie ID and table ID as filters.
+// DumpFlows dumps all existing Openflow entries from OFSwitch using cookie ID and table ID as filters. The counts of the
+// entries sharing a cookie are summed.
 func (t *ofTable) DumpFlows(cookieID, cookieMask uint64) (map[uint64]*FlowStates, error) {
 	ofStats, err := t.Table.Switch.DumpFlowStats(cookieID, cookieMask, nil, &t.TableId)
 	if err != nil {
@@ -89,17 +91,27 @@ func (t *ofTable) DumpFlows(cookieID, cookieMask uint64) (map[uint64]*FlowStates
 	if ofStats == nil {
 		return nil, nil
 	}
+	return toFlowStates(ofStats), nil
+}
+
+// toFlowStates converts the flow statistics to a map from flow cookieID to FlowStates. The counts of the entries
+// sharing a cookie are summed, and the TableID and DurationNSecond of the first entry are kept.
+func toFlowStates(ofStats []*openflow13.FlowStats) map[uint64]*FlowStates {
 	flowStats := make(map[uint64]*FlowStates)
 	for _, stat := range ofStats {
-		cookie := stat.Cookie
-		s := &FlowStates{
+		if s, exists := flowStats[stat.Cookie]; exists {
+			s.PacketCount += stat.PacketCount
+			s.ByteCount += stat.ByteCount
+			continue
+		}
+		flowStats[stat.Cookie] = &FlowStates{
 			TableID:         stat.TableId,
 			PacketCount:     stat.PacketCount,
+			ByteCount:       stat.ByteCount,
 			DurationNSecond: stat.DurationNSec,
 		}
-		flowStats[cookie] = s
 	}
-	return flowStats, nil
+	return flowStats
 }
 
 func newOFTable(id, next TableIDType, missAction MissActionType) *ofTable {
@@ -146,9 +158,14 @@ func (b *OFBridge) CreateGroup(id GroupIDType) Group {
 	return g
 }
 
+// DeleteGroup deletes the group from the OFSwitch if it has been installed, and removes it from the group cache.
 func (b *OFBridge) DeleteGroup(id GroupIDType) bool {
-	err := b.ofSwitch.DeleteGroup(uint32(id))
-	if err != nil {
+	g := b.ofSwitch.GetGroup(uint32(id))
+	if g == nil {
+		return true
+	}
+	if err := g.Delete(); err != nil {
+		klog.Errorf("Failed to delete group %d: %v", id, err)
 		return false
 	}
 	return true
@@ -280,7 +297,7 @@ func (b *OFBridge) Disconnect() error {
 }
 
 // DumpFlows queries the Openflow entries from OFSwitch, the filter of the query is Openflow cookieID. The result is
-// a map from flow cookieID to FlowStates.
+// a map from flow cookieID to FlowStates. The counts of the entries sharing a cookie are summed.
 func (b *OFBridge) DumpFlows(cookieID, cookieMask uint64) (map[uint64]*FlowStates, error) {
 	ofStats, err := b.ofSwitch.DumpFlowStats(cookieID, cookieMask, nil, nil)
 	if err != nil {
@@ -289,17 +306,7 @@ func (b *OFBridge) DumpFlows(cookieID, cookieMask uint64) (map[uint64]*FlowState
 	if ofStats == nil {
 		return nil, nil
 	}
-	flowStats := make(map[uint64]*FlowStates)
-	for _, stat := range ofStats {
-		cookie := stat.Cookie
-		s := &FlowStates{
-			TableID:         stat.TableId,
-			PacketCount:     stat.PacketCount,
-			DurationNSecond: stat.DurationNSec,
-		}
-		flowStats[cookie] = s
-	}
-	return flowStats, nil
+	return toFlowStates(ofStats), nil
 }
 
 // DeleteFlowsByCookie removes Openflow entries from OFSwitch. The removed Openflow entries use the specific CookieID.
@@ -339,7 +346,7 @@ func (b *OFBridge) AddFlowsInBundle(addflows []Flow, modFlows []Flow, delFlows [
 			// the BundleAdd message. An absence of error does not mean that all Openflow entries are added into the
 			// bundle by the switch. The number of entries successfully added to the bundle by the switch will be
 			// returned by function "Complete".
-			flowMod, err := ofFlow.Flow.GenerateFlowModMessage(operation)
+			flowMod, err := ofFlow.generateFlowModMessage(operation)
 			if err != nil {
 				return err
 			}
@@ -533,7 +540,15 @@ func (b *OFBridge) AddTLVMap(optClass uint16, optType uint8, optLength uint8, tu
 }
 
 func (b *OFBridge) SendPacketOut(packetOut *ofctrl.PacketOut) error {
-	return b.ofSwitch.Send(packetOut.GetMessage())
+	return b.ofSwitch.Send(packetOutMessage(packetOut))
+}
+
+func (b *OFBridge) SendPacketOutFrame(frame *protocol.Ethernet, outPort uint32) error {
+	message := openflow13.NewPacketOut()
+	message.InPort = openflow13.P_CONTROLLER
+	message.AddAction(openflow13.NewActionOutput(outPort))
+	message.Data = frame
+	return b.ofSwitch.Send(message)
 }
 
 func (b *OFBridge) BuildPacketOut() PacketOutBuilder {
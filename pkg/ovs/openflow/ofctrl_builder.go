@@ -7,6 +7,7 @@ import (
 
 	"github.com/contiv/libOpenflow/openflow13"
 	"github.com/contiv/ofnet/ofctrl"
+	"k8s.io/klog"
 )
 
 type ofFlowBuilder struct {
@@ -174,6 +175,14 @@ func (b *ofFlowBuilder) MatchCTMark(value uint32) FlowBuilder {
 	return b
 }
 
+// MatchIPDscp adds match condition for matching the DSCP field in the IP header. Note that OVS only matches the
+// DSCP field if it's not 0.
+func (b *ofFlowBuilder) MatchIPDscp(dscp uint8) FlowBuilder {
+	b.matchers = append(b.matchers, fmt.Sprintf("nw_tos=%d", dscp<<2))
+	b.Match.IpDscp = dscp
+	return b
+}
+
 // MatchCTMarkMask sets the mask of ct_mark. The mask is used only if ct_mark is set.
 func (b *ofFlowBuilder) MatchCTMarkMask(mask uint32) FlowBuilder {
 	if b.Flow.Match.CtMark > 0 {
@@ -195,15 +204,28 @@ func (b *ofFlowBuilder) MatchInPort(inPort uint32) FlowBuilder {
 	return b
 }
 
-// MatchDstIP adds match condition for matching destination IP address.
+// MatchDstIP adds match condition for matching destination IP address. An IPv6 address is matched with
+// "ipv6_dst" instead of "nw_dst".
 func (b *ofFlowBuilder) MatchDstIP(ip net.IP) FlowBuilder {
+	if ip.To4() == nil {
+		b.matchers = append(b.matchers, fmt.Sprintf("ipv6_dst=%s", ip.String()))
+		b.Match.Ipv6Da = &ip
+		return b
+	}
 	b.matchers = append(b.matchers, fmt.Sprintf("nw_dst=%s", ip.String()))
 	b.Match.IpDa = &ip
 	return b
 }
 
-// MatchDstIPNet adds match condition for matching destination IP CIDR.
+// MatchDstIPNet adds match condition for matching destination IP CIDR. An IPv6 CIDR is matched with "ipv6_dst"
+// instead of "nw_dst".
 func (b *ofFlowBuilder) MatchDstIPNet(ipnet net.IPNet) FlowBuilder {
+	if ipnet.IP.To4() == nil {
+		b.matchers = append(b.matchers, fmt.Sprintf("ipv6_dst=%s", ipnet.String()))
+		b.Match.Ipv6Da = &ipnet.IP
+		b.Match.Ipv6DaMask = maskToIPv6(ipnet.Mask)
+		return b
+	}
 	b.matchers = append(b.matchers, fmt.Sprintf("nw_dst=%s", ipnet.String()))
 	b.Match.IpDa = &ipnet.IP
 	b.Match.IpDaMask = maskToIPv4(ipnet.Mask)
@@ -215,15 +237,33 @@ func maskToIPv4(mask net.IPMask) *net.IP {
 	return &ip
 }
 
-// MatchSrcIP adds match condition for matching source IP address.
+func maskToIPv6(mask net.IPMask) *net.IP {
+	ip := net.IP(mask)
+	return &ip
+}
+
+// MatchSrcIP adds match condition for matching source IP address. An IPv6 address is matched with "ipv6_src"
+// instead of "nw_src".
 func (b *ofFlowBuilder) MatchSrcIP(ip net.IP) FlowBuilder {
+	if ip.To4() == nil {
+		b.matchers = append(b.matchers, fmt.Sprintf("ipv6_src=%s", ip.String()))
+		b.Match.Ipv6Sa = &ip
+		return b
+	}
 	b.matchers = append(b.matchers, fmt.Sprintf("nw_src=%s", ip.String()))
 	b.Match.IpSa = &ip
 	return b
 }
 
-// MatchSrcIPNet adds match condition for matching source IP CIDR.
+// MatchSrcIPNet adds match condition for matching source IP CIDR. An IPv6 CIDR is matched with "ipv6_src"
+// instead of "nw_src".
 func (b *ofFlowBuilder) MatchSrcIPNet(ipnet net.IPNet) FlowBuilder {
+	if ipnet.IP.To4() == nil {
+		b.matchers = append(b.matchers, fmt.Sprintf("ipv6_src=%s", ipnet.String()))
+		b.Match.Ipv6Sa = &ipnet.IP
+		b.Match.Ipv6SaMask = maskToIPv6(ipnet.Mask)
+		return b
+	}
 	b.matchers = append(b.matchers, fmt.Sprintf("nw_src=%s", ipnet.String()))
 	b.Match.IpSa = &ipnet.IP
 	b.Match.IpSaMask = maskToIPv4(ipnet.Mask)
@@ -305,6 +345,20 @@ func (b *ofFlowBuilder) MatchProtocol(protocol Protocol) FlowBuilder {
 	case ProtocolICMP:
 		b.Match.Ethertype = 0x0800
 		b.Match.IpProto = 1
+	case ProtocolIPv6:
+		b.Match.Ethertype = 0x86dd
+	case ProtocolTCPv6:
+		b.Match.Ethertype = 0x86dd
+		b.Match.IpProto = 6
+	case ProtocolUDPv6:
+		b.Match.Ethertype = 0x86dd
+		b.Match.IpProto = 17
+	case ProtocolSCTPv6:
+		b.Match.Ethertype = 0x86dd
+		b.Match.IpProto = 132
+	case ProtocolICMPv6:
+		b.Match.Ethertype = 0x86dd
+		b.Match.IpProto = 58
 	}
 	b.protocol = protocol
 	return b
@@ -312,7 +366,9 @@ func (b *ofFlowBuilder) MatchProtocol(protocol Protocol) FlowBuilder {
 
 // MatchTCPDstPort adds match condition for matching TCP destination port.
 func (b *ofFlowBuilder) MatchTCPDstPort(port uint16) FlowBuilder {
-	b.MatchProtocol(ProtocolTCP)
+	if b.protocol != ProtocolTCPv6 {
+		b.MatchProtocol(ProtocolTCP)
+	}
 	b.Match.TcpDstPort = port
 	// According to ovs-ofctl(8) man page, "tp_dst" is deprecated and "tcp_dst",
 	// "udp_dst", "sctp_dst" should be used for the destination port of TCP, UDP,
@@ -324,22 +380,129 @@ func (b *ofFlowBuilder) MatchTCPDstPort(port uint16) FlowBuilder {
 	return b
 }
 
+// MatchTCPSrcPort adds match condition for matching TCP source port.
+func (b *ofFlowBuilder) MatchTCPSrcPort(port uint16) FlowBuilder {
+	if b.protocol != ProtocolTCPv6 {
+		b.MatchProtocol(ProtocolTCP)
+	}
+	b.Match.TcpSrcPort = port
+	b.matchers = append(b.matchers, fmt.Sprintf("tp_src=%d", port))
+	return b
+}
+
 // MatchUDPDstPort adds match condition for matching UDP destination port.
 func (b *ofFlowBuilder) MatchUDPDstPort(port uint16) FlowBuilder {
-	b.MatchProtocol(ProtocolUDP)
+	if b.protocol != ProtocolUDPv6 {
+		b.MatchProtocol(ProtocolUDP)
+	}
 	b.Match.UdpDstPort = port
 	b.matchers = append(b.matchers, fmt.Sprintf("tp_dst=%d", port))
 	return b
 }
 
+// MatchUDPSrcPort adds match condition for matching UDP source port.
+func (b *ofFlowBuilder) MatchUDPSrcPort(port uint16) FlowBuilder {
+	if b.protocol != ProtocolUDPv6 {
+		b.MatchProtocol(ProtocolUDP)
+	}
+	b.Match.UdpSrcPort = port
+	b.matchers = append(b.matchers, fmt.Sprintf("tp_src=%d", port))
+	return b
+}
+
 // MatchSCTPDstPort adds match condition for matching SCTP destination port.
 func (b *ofFlowBuilder) MatchSCTPDstPort(port uint16) FlowBuilder {
-	b.MatchProtocol(ProtocolSCTP)
+	if b.protocol != ProtocolSCTPv6 {
+		b.MatchProtocol(ProtocolSCTP)
+	}
 	b.Match.SctpDstPort = port
 	b.matchers = append(b.matchers, fmt.Sprintf("tp_dst=%d", port))
 	return b
 }
 
+// MatchDstPortMask adds match condition for matching the transport destination port with a bitmask. The protocol
+// (TCP, UDP or SCTP) must be set with MatchProtocol before calling it. The ofctrl.FlowMatch has no field for masked
+// ports, so the match field is built here and added to the FlowMod message when the Flow is installed.
+func (b *ofFlowBuilder) MatchDstPortMask(port uint16, mask uint16) FlowBuilder {
+	var field uint8
+	switch b.protocol {
+	case ProtocolTCP, ProtocolTCPv6:
+		field = openflow13.OXM_FIELD_TCP_DST
+	case ProtocolUDP, ProtocolUDPv6:
+		field = openflow13.OXM_FIELD_UDP_DST
+	case ProtocolSCTP, ProtocolSCTPv6:
+		field = openflow13.OXM_FIELD_SCTP_DST
+	default:
+		klog.Errorf("Transport destination port cannot be matched for protocol %s", b.protocol)
+		return b
+	}
+	b.extraMatchFields = append(b.extraMatchFields, &openflow13.MatchField{
+		Class:   openflow13.OXM_CLASS_OPENFLOW_BASIC,
+		Field:   field,
+		HasMask: true,
+		Length:  4,
+		Value:   &openflow13.Uint16Message{Data: port},
+		Mask:    &openflow13.Uint16Message{Data: mask},
+	})
+	b.matchers = append(b.matchers, fmt.Sprintf("tp_dst=0x%x/0x%x", port, mask))
+	return b
+}
+
+// MatchICMPType adds match condition for matching the ICMP type. The protocol (ICMP or ICMPv6) must be set with
+// MatchProtocol before calling it.
+func (b *ofFlowBuilder) MatchICMPType(icmpType uint8) FlowBuilder {
+	return b.matchICMPField(icmpType, openflow13.OXM_FIELD_ICMPV4_TYPE, openflow13.OXM_FIELD_ICMPV6_TYPE, "type")
+}
+
+// MatchICMPCode adds match condition for matching the ICMP code. The protocol (ICMP or ICMPv6) must be set with
+// MatchProtocol before calling it.
+func (b *ofFlowBuilder) MatchICMPCode(icmpCode uint8) FlowBuilder {
+	return b.matchICMPField(icmpCode, openflow13.OXM_FIELD_ICMPV4_CODE, openflow13.OXM_FIELD_ICMPV6_CODE, "code")
+}
+
+func (b *ofFlowBuilder) matchICMPField(value uint8, icmpv4Field, icmpv6Field uint8, name string) FlowBuilder {
+	var field uint8
+	var prefix string
+	switch b.protocol {
+	case ProtocolICMP:
+		field, prefix = icmpv4Field, "icmp"
+	case ProtocolICMPv6:
+		field, prefix = icmpv6Field, "icmpv6"
+	default:
+		klog.Errorf("ICMP %s cannot be matched for protocol %s", name, b.protocol)
+		return b
+	}
+	b.extraMatchFields = append(b.extraMatchFields, &openflow13.MatchField{
+		Class:  openflow13.OXM_CLASS_OPENFLOW_BASIC,
+		Field:  field,
+		Length: 1,
+		Value:  &uint8Message{data: value},
+	})
+	b.matchers = append(b.matchers, fmt.Sprintf("%s_%s=%d", prefix, name, value))
+	return b
+}
+
+// uint8Message is a one-byte value of an OpenFlow match field.
+type uint8Message struct {
+	data uint8
+}
+
+func (m *uint8Message) Len() uint16 {
+	return 1
+}
+
+func (m *uint8Message) MarshalBinary() ([]byte, error) {
+	return []byte{m.data}, nil
+}
+
+func (m *uint8Message) UnmarshalBinary(data []byte) error {
+	if len(data) < 1 {
+		return fmt.Errorf("the []byte is too short to unmarshal a uint8Message")
+	}
+	m.data = data[0]
+	return nil
+}
+
 // Cookie sets cookie ID for the flow entry.
 func (b *ofFlowBuilder) Cookie(cookieID uint64) FlowBuilder {
 	b.Flow.CookieID = cookieID
@@ -1,6 +1,7 @@
 package openflow
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -11,6 +12,7 @@ import (
 type FlowStates struct {
 	TableID         uint8
 	PacketCount     uint64
+	ByteCount       uint64
 	DurationNSecond uint32
 }
 
@@ -33,6 +35,26 @@ type ofFlow struct {
 	ctStates *openflow13.CTStates
 	// lastAction is used to set ofctrl.Flow nextElem field. It is the last action of the Flow.
 	lastAction ofctrl.FgraphElem
+	// extraMatchFields are the match fields which cannot be expressed with ofctrl.FlowMatch, e.g. masked transport
+	// ports and ICMP type and code. They are added to the FlowMod message when the Flow is installed with a bundle.
+	extraMatchFields []*openflow13.MatchField
+}
+
+// errExtraMatchFields is returned when a Flow with extra match fields is installed without using AddFlowsInBundle,
+// in which case the FlowMod message is generated by the ofnet library and the extra match fields would be lost.
+var errExtraMatchFields = errors.New("the Flow has match fields which can only be installed with AddFlowsInBundle")
+
+// generateFlowModMessage generates the FlowMod message of the Flow for the provided command, including the extra
+// match fields.
+func (f *ofFlow) generateFlowModMessage(command int) (*openflow13.FlowMod, error) {
+	flowMod, err := f.Flow.GenerateFlowModMessage(command)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range f.extraMatchFields {
+		flowMod.Match.AddField(*field)
+	}
+	return flowMod, nil
 }
 
 // Reset updates the ofFlow.Flow.Table field with ofFlow.table.Table.
@@ -44,6 +66,9 @@ func (f *ofFlow) Reset() {
 }
 
 func (f *ofFlow) Add() error {
+	if len(f.extraMatchFields) > 0 {
+		return errExtraMatchFields
+	}
 	f.Flow.UpdateInstallStatus(false)
 	err := f.Flow.Next(f.lastAction)
 	if err != nil {
@@ -54,6 +79,9 @@ func (f *ofFlow) Add() error {
 }
 
 func (f *ofFlow) Modify() error {
+	if len(f.extraMatchFields) > 0 {
+		return errExtraMatchFields
+	}
 	f.Flow.UpdateInstallStatus(true)
 	err := f.Flow.Next(f.lastAction)
 	if err != nil {
@@ -64,6 +92,9 @@ func (f *ofFlow) Modify() error {
 }
 
 func (f *ofFlow) Delete() error {
+	if len(f.extraMatchFields) > 0 {
+		return errExtraMatchFields
+	}
 	f.Flow.UpdateInstallStatus(true)
 	err := f.Flow.Delete()
 	if err != nil {
@@ -94,6 +125,9 @@ func (f *ofFlow) MatchString() string {
 }
 
 func (f *ofFlow) GetBundleMessage(entryOper OFOperation) (ofctrl.OpenFlowModMessage, error) {
+	if len(f.extraMatchFields) > 0 {
+		return nil, errExtraMatchFields
+	}
 	var operation int
 	switch entryOper {
 	case AddMessage:
@@ -123,8 +157,9 @@ func (f *ofFlow) CopyToBuilder(priority uint16) FlowBuilder {
 			CookieMask: f.Flow.CookieMask,
 			Match:      f.Flow.Match,
 		},
-		matchers: f.matchers,
-		protocol: f.protocol,
+		matchers:         append([]string{}, f.matchers...),
+		protocol:         f.protocol,
+		extraMatchFields: append([]*openflow13.MatchField{}, f.extraMatchFields...),
 	}
 	if priority > 0 {
 		newFlow.Flow.Match.Priority = priority
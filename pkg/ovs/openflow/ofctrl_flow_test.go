@@ -3,7 +3,10 @@ package openflow
 import (
 	"testing"
 
+	"github.com/contiv/libOpenflow/openflow13"
+	"github.com/contiv/ofnet/ofctrl"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCopyToBuilder(t *testing.T) {
@@ -29,3 +32,31 @@ func TestCopyToBuilder(t *testing.T) {
 	newFlow2 := oriFlow.CopyToBuilder(newPriority)
 	assert.Equal(t, newPriority, newFlow2.Done().(*ofFlow).Match.Priority)
 }
+
+func TestExtraMatchFields(t *testing.T) {
+	table := &ofTable{
+		id:    0,
+		next:  1,
+		Table: &ofctrl.Table{TableId: 0},
+	}
+	flow := table.BuildFlow(uint16(100)).MatchProtocol(ProtocolTCP).
+		MatchDstPortMask(0x400, 0xfc00).
+		Done()
+	assert.Equal(t, "table=0,tcp,tp_dst=0x400/0xfc00", flow.MatchString())
+	flowMod, err := flow.(*ofFlow).generateFlowModMessage(openflow13.FC_DELETE_STRICT)
+	require.NoError(t, err)
+	field := flowMod.Match.Fields[len(flowMod.Match.Fields)-1]
+	assert.Equal(t, uint8(openflow13.OXM_FIELD_TCP_DST), field.Field)
+	assert.True(t, field.HasMask)
+	data, err := field.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x04, 0x00, 0xfc, 0x00}, data[4:])
+
+	icmpFlow := table.BuildFlow(uint16(100)).MatchProtocol(ProtocolICMPv6).
+		MatchICMPType(128).MatchICMPCode(0).
+		Done()
+	assert.Equal(t, "table=0,icmp6,icmpv6_type=128,icmpv6_code=0", icmpFlow.MatchString())
+	assert.Equal(t, errExtraMatchFields, icmpFlow.Add())
+	copied := icmpFlow.CopyToBuilder(0).Done()
+	assert.Equal(t, icmpFlow.(*ofFlow).extraMatchFields, copied.(*ofFlow).extraMatchFields)
+}
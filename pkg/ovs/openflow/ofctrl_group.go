@@ -50,6 +50,11 @@ func (g *ofGroup) KeyString() string {
 	return fmt.Sprintf("group_id:%d", g.ofctrl.ID)
 }
 
+func (g *ofGroup) ResetBuckets() Group {
+	g.ofctrl.Buckets = nil
+	return g
+}
+
 func (g *ofGroup) Bucket() BucketBuilder {
 	return &bucketBuilder{
 		group:  g,
@@ -101,7 +106,8 @@ func (b *bucketBuilder) Weight(val uint16) BucketBuilder {
 	return b
 }
 
+// Done appends the bucket to the Group. The Group is not modified on the OFSwitch until Add or Modify is called.
 func (b *bucketBuilder) Done() Group {
-	b.group.ofctrl.AddBuckets(b.bucket)
+	b.group.ofctrl.Buckets = append(b.group.ofctrl.Buckets, b.bucket)
 	return b.group
 }
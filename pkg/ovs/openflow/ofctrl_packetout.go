@@ -19,14 +19,19 @@ import (
 	"math/rand"
 	"net"
 
+	"github.com/contiv/libOpenflow/openflow13"
 	"github.com/contiv/libOpenflow/protocol"
+	"github.com/contiv/libOpenflow/util"
 	"github.com/contiv/ofnet/ofctrl"
 )
 
 type ofPacketOutBuilder struct {
-	pktOut  *ofctrl.PacketOut
-	icmpID  *uint16
-	icmpSeq *uint16
+	pktOut    *ofctrl.PacketOut
+	icmpID    *uint16
+	icmpSeq   *uint16
+	icmpData  []byte
+	tcpSeqNum *uint32
+	tcpAckNum *uint32
 }
 
 // SetSrcMAC sets the packet's source MAC with the provided value.
@@ -73,12 +78,23 @@ func (b *ofPacketOutBuilder) SetIPProtocol(proto Protocol) PacketOutBuilder {
 		b.pktOut.IPHeader.Protocol = 0x84
 	case ProtocolICMP:
 		b.pktOut.IPHeader.Protocol = protocol.Type_ICMP
+	case ProtocolICMPv6:
+		b.pktOut.IPHeader.Protocol = protocol.Type_IPv6ICMP
 	default:
 		b.pktOut.IPHeader.Protocol = 0xff
 	}
 	return b
 }
 
+// SetIPDscp sets the DSCP field in the packet's IP header.
+func (b *ofPacketOutBuilder) SetIPDscp(dscp uint8) PacketOutBuilder {
+	if b.pktOut.IPHeader == nil {
+		b.pktOut.IPHeader = new(protocol.IPv4)
+	}
+	b.pktOut.IPHeader.DSCP = dscp
+	return b
+}
+
 // SetTTL sets TTL in the packet's IP header.
 func (b *ofPacketOutBuilder) SetTTL(ttl uint8) PacketOutBuilder {
 	if b.pktOut.IPHeader == nil {
@@ -124,6 +140,25 @@ func (b *ofPacketOutBuilder) SetTCPFlags(flags uint8) PacketOutBuilder {
 	return b
 }
 
+// SetTCPSeqNum sets the sequence number in the packet's TCP header. A random sequence number is used if it is not set.
+func (b *ofPacketOutBuilder) SetTCPSeqNum(seqNum uint32) PacketOutBuilder {
+	if b.pktOut.TCPHeader == nil {
+		b.pktOut.TCPHeader = new(protocol.TCP)
+	}
+	b.tcpSeqNum = &seqNum
+	return b
+}
+
+// SetTCPAckNum sets the acknowledgment number in the packet's TCP header. A random acknowledgment number is used if it
+// is not set.
+func (b *ofPacketOutBuilder) SetTCPAckNum(ackNum uint32) PacketOutBuilder {
+	if b.pktOut.TCPHeader == nil {
+		b.pktOut.TCPHeader = new(protocol.TCP)
+	}
+	b.tcpAckNum = &ackNum
+	return b
+}
+
 // SetUDPSrcPort sets the source port in the packet's UDP header.
 func (b *ofPacketOutBuilder) SetUDPSrcPort(port uint16) PacketOutBuilder {
 	if b.pktOut.UDPHeader == nil {
@@ -160,7 +195,7 @@ func (b *ofPacketOutBuilder) SetICMPCode(icmpCode uint8) PacketOutBuilder {
 	return b
 }
 
-// SetICMs sets the identifier in the packet's ICMP header.
+// SetICMPID sets the identifier in the packet's ICMP header.
 func (b *ofPacketOutBuilder) SetICMPID(id uint16) PacketOutBuilder {
 	if b.pktOut.ICMPHeader == nil {
 		b.pktOut.ICMPHeader = new(protocol.ICMP)
@@ -178,6 +213,17 @@ func (b *ofPacketOutBuilder) SetICMPSequence(seq uint16) PacketOutBuilder {
 	return b
 }
 
+// SetICMPData sets the data following the type, code and checksum in the packet's ICMP header, e.g. the unused field
+// and the leading bytes of the original datagram in an ICMP Destination Unreachable message. It takes precedence over
+// the identifier and the sequence number.
+func (b *ofPacketOutBuilder) SetICMPData(data []byte) PacketOutBuilder {
+	if b.pktOut.ICMPHeader == nil {
+		b.pktOut.ICMPHeader = new(protocol.ICMP)
+	}
+	b.icmpData = data
+	return b
+}
+
 // SetInport sets the in_port field of the packetOut message.
 func (b *ofPacketOutBuilder) SetInport(inPort uint32) PacketOutBuilder {
 	b.pktOut.InPort = inPort
@@ -198,29 +244,48 @@ func (b *ofPacketOutBuilder) AddLoadAction(name string, data uint64, rng Range)
 	return b
 }
 
+// Done returns the packetOut message. The lengths and the checksums of the IPv4 and transport headers are calculated
+// from the provided fields. If the source IP is an IPv6 address, the IPv4 header only holds the fields of the IPv6
+// header, and the packet is serialized as an IPv6 packet by SendPacketOut.
 func (b *ofPacketOutBuilder) Done() *ofctrl.PacketOut {
+	var transport util.Message
 	if b.pktOut.ICMPHeader != nil {
 		b.setICMPData()
 		b.pktOut.IPHeader.Length = 20 + b.pktOut.ICMPHeader.Len()
+		transport = b.pktOut.ICMPHeader
 	} else if b.pktOut.TCPHeader != nil {
 		b.pktOut.TCPHeader.HdrLen = 5
 		b.pktOut.TCPHeader.SeqNum = rand.Uint32()
+		if b.tcpSeqNum != nil {
+			b.pktOut.TCPHeader.SeqNum = *b.tcpSeqNum
+		}
 		b.pktOut.TCPHeader.AckNum = rand.Uint32()
+		if b.tcpAckNum != nil {
+			b.pktOut.TCPHeader.AckNum = *b.tcpAckNum
+		}
 		b.pktOut.IPHeader.Length = 20 + b.pktOut.TCPHeader.Len()
+		transport = b.pktOut.TCPHeader
 	} else if b.pktOut.UDPHeader != nil {
+		b.pktOut.UDPHeader.Length = b.pktOut.UDPHeader.Len()
 		b.pktOut.IPHeader.Length = 20 + b.pktOut.UDPHeader.Len()
+		transport = b.pktOut.UDPHeader
 	}
 	b.pktOut.IPHeader.Id = uint16(rand.Uint32())
 	// Set IP version in the IP Header.
-	if b.pktOut.IPHeader.NWSrc.To16() != nil {
+	if b.pktOut.IPHeader.NWSrc.To4() == nil {
 		b.pktOut.IPHeader.Version = 0x6
 	} else {
 		b.pktOut.IPHeader.Version = 0x4
 	}
+	b.setChecksums(transport)
 	return b.pktOut
 }
 
 func (b *ofPacketOutBuilder) setICMPData() {
+	if b.icmpData != nil {
+		b.pktOut.ICMPHeader.Data = b.icmpData
+		return
+	}
 	data := make([]byte, 4)
 	if b.icmpID != nil {
 		binary.BigEndian.PutUint16(data, *b.icmpID)
@@ -230,3 +295,141 @@ func (b *ofPacketOutBuilder) setICMPData() {
 	}
 	b.pktOut.ICMPHeader.Data = data
 }
+
+// setChecksums calculates the checksums of the IPv4 header and of the transport header, so that the packet is not
+// discarded by the receivers. The TCP and UDP checksums cover the IP pseudo header, and so does the ICMPv6 checksum.
+// IPv6 headers don't have a checksum.
+func (b *ofPacketOutBuilder) setChecksums(transport util.Message) {
+	ipHeader := b.pktOut.IPHeader
+	isIPv6 := ipHeader.Version == 0x6
+	if transport != nil {
+		var pseudoHeader []byte
+		switch t := transport.(type) {
+		case *protocol.TCP:
+			t.Checksum = 0
+			pseudoHeader = ipPseudoHeader(ipHeader, protocol.Type_TCP, t.Len())
+		case *protocol.UDP:
+			t.Checksum = 0
+			pseudoHeader = ipPseudoHeader(ipHeader, protocol.Type_UDP, t.Len())
+		case *protocol.ICMP:
+			t.Checksum = 0
+			if isIPv6 {
+				pseudoHeader = ipv6PseudoHeader(ipHeader, protocol.Type_IPv6ICMP, t.Len())
+			}
+		}
+		data, _ := transport.MarshalBinary()
+		checksum := calculateChecksum(append(pseudoHeader, data...))
+		switch t := transport.(type) {
+		case *protocol.TCP:
+			t.Checksum = checksum
+		case *protocol.UDP:
+			// A zero UDP checksum means that no checksum is transmitted, so it's sent as all ones instead.
+			if checksum == 0 {
+				checksum = 0xffff
+			}
+			t.Checksum = checksum
+		case *protocol.ICMP:
+			t.Checksum = checksum
+		}
+	}
+	if isIPv6 {
+		return
+	}
+	ipHeader.Checksum = 0
+	header := *ipHeader
+	header.Data = nil
+	data, _ := header.MarshalBinary()
+	ipHeader.Checksum = calculateChecksum(data)
+}
+
+// ipPseudoHeader returns the IPv4 or IPv6 pseudo header, depending on the version of the provided IP header, used to
+// calculate the checksum of a TCP or UDP header.
+func ipPseudoHeader(ipHeader *protocol.IPv4, proto uint8, length uint16) []byte {
+	if ipHeader.Version == 0x6 {
+		return ipv6PseudoHeader(ipHeader, proto, length)
+	}
+	return ipv4PseudoHeader(ipHeader, proto, length)
+}
+
+// ipv6PseudoHeader returns the IPv6 pseudo header used to calculate the checksum of a TCP, UDP or ICMPv6 header.
+func ipv6PseudoHeader(ipHeader *protocol.IPv4, proto uint8, length uint16) []byte {
+	pseudoHeader := make([]byte, 40)
+	copy(pseudoHeader[0:16], ipHeader.NWSrc.To16())
+	copy(pseudoHeader[16:32], ipHeader.NWDst.To16())
+	binary.BigEndian.PutUint32(pseudoHeader[32:36], uint32(length))
+	pseudoHeader[39] = proto
+	return pseudoHeader
+}
+
+// ipv4PseudoHeader returns the IPv4 pseudo header used to calculate the checksum of a TCP or UDP header.
+func ipv4PseudoHeader(ipHeader *protocol.IPv4, proto uint8, length uint16) []byte {
+	pseudoHeader := make([]byte, 12)
+	copy(pseudoHeader[0:4], ipHeader.NWSrc.To4())
+	copy(pseudoHeader[4:8], ipHeader.NWDst.To4())
+	pseudoHeader[9] = proto
+	binary.BigEndian.PutUint16(pseudoHeader[10:12], length)
+	return pseudoHeader
+}
+
+// calculateChecksum returns the Internet checksum (RFC 1071) of the provided data.
+func calculateChecksum(data []byte) uint16 {
+	var sum uint32
+	for i := 0; i+1 < len(data); i += 2 {
+		sum += uint32(binary.BigEndian.Uint16(data[i:]))
+	}
+	if len(data)%2 == 1 {
+		sum += uint32(data[len(data)-1]) << 8
+	}
+	for sum > 0xffff {
+		sum = (sum >> 16) + (sum & 0xffff)
+	}
+	return ^uint16(sum)
+}
+
+// packetOutMessage returns the Openflow message of the provided packetOut. The Openflow library only serializes IPv4
+// packets, so the IPv6 packets, whose IPv6 header fields are held by the IPv4 header, are serialized here.
+func packetOutMessage(packetOut *ofctrl.PacketOut) util.Message {
+	if packetOut.IPHeader == nil || packetOut.IPHeader.Version != 0x6 {
+		return packetOut.GetMessage()
+	}
+	ipv6Packet := &IPv6Packet{
+		TrafficClass: packetOut.IPHeader.DSCP<<2 | packetOut.IPHeader.ECN,
+		HopLimit:     packetOut.IPHeader.TTL,
+		NWSrc:        packetOut.IPHeader.NWSrc,
+		NWDst:        packetOut.IPHeader.NWDst,
+	}
+	var transport util.Message
+	switch {
+	case packetOut.TCPHeader != nil:
+		ipv6Packet.NextHeader = protocol.Type_TCP
+		transport = packetOut.TCPHeader
+	case packetOut.UDPHeader != nil:
+		ipv6Packet.NextHeader = protocol.Type_UDP
+		transport = packetOut.UDPHeader
+	case packetOut.ICMPHeader != nil:
+		ipv6Packet.NextHeader = protocol.Type_IPv6ICMP
+		transport = packetOut.ICMPHeader
+	default:
+		ipv6Packet.NextHeader = packetOut.IPHeader.Protocol
+	}
+	if transport != nil {
+		ipv6Packet.Payload, _ = transport.MarshalBinary()
+	}
+	message := openflow13.NewPacketOut()
+	message.InPort = packetOut.InPort
+	for _, act := range packetOut.Actions {
+		message.AddAction(act.GetActionMessage())
+	}
+	message.Data = &protocol.Ethernet{
+		HWDst:     packetOut.DstMAC,
+		HWSrc:     packetOut.SrcMAC,
+		Ethertype: protocol.IPv6_MSG,
+		Data:      ipv6Packet,
+	}
+	outPort := packetOut.OutPort
+	if outPort == 0 {
+		outPort = openflow13.P_TABLE
+	}
+	message.AddAction(openflow13.NewActionOutput(outPort))
+	return message
+}
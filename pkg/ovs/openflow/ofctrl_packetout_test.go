@@ -0,0 +1,172 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openflow
+
+import (
+	"encoding/hex"
+	"net"
+	"testing"
+
+	"github.com/contiv/libOpenflow/openflow13"
+	"github.com/contiv/libOpenflow/protocol"
+	"github.com/contiv/libOpenflow/util"
+	"github.com/contiv/ofnet/ofctrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateChecksum(t *testing.T) {
+	// The IPv4 header example of https://en.wikipedia.org/wiki/IPv4_header_checksum.
+	header, _ := hex.DecodeString("450000730000400040110000c0a80001c0a800c7")
+	assert.Equal(t, uint16(0xb861), calculateChecksum(header))
+	// Odd lengths are padded with a zero byte.
+	assert.Equal(t, calculateChecksum([]byte{0x12, 0x34, 0x56, 0x00}), calculateChecksum([]byte{0x12, 0x34, 0x56}))
+}
+
+func TestPacketOutChecksums(t *testing.T) {
+	srcIP, dstIP := net.ParseIP("10.10.0.2"), net.ParseIP("10.10.0.1")
+	newBuilder := func() PacketOutBuilder {
+		return (&ofPacketOutBuilder{pktOut: new(ofctrl.PacketOut)}).
+			SetSrcIP(srcIP).
+			SetDstIP(dstIP).
+			SetTTL(64)
+	}
+	verify := func(t *testing.T, pktOut *ofctrl.PacketOut, proto uint8, transportData []byte) {
+		ipHeader := *pktOut.IPHeader
+		ipHeader.Data = nil
+		data, err := ipHeader.MarshalBinary()
+		require.NoError(t, err)
+		assert.Equal(t, uint16(0), calculateChecksum(data))
+		if proto != 0 {
+			transportData = append(ipv4PseudoHeader(pktOut.IPHeader, proto, uint16(len(transportData))), transportData...)
+		}
+		assert.Equal(t, uint16(0), calculateChecksum(transportData))
+	}
+
+	t.Run("TCP", func(t *testing.T) {
+		pktOut := newBuilder().SetIPProtocol(ProtocolTCP).
+			SetTCPSrcPort(80).
+			SetTCPDstPort(34567).
+			SetTCPFlags(0x14).
+			SetTCPSeqNum(0).
+			SetTCPAckNum(1001).
+			Done()
+		assert.Equal(t, uint32(0), pktOut.TCPHeader.SeqNum)
+		assert.Equal(t, uint32(1001), pktOut.TCPHeader.AckNum)
+		assert.Equal(t, uint16(40), pktOut.IPHeader.Length)
+		data, err := pktOut.TCPHeader.MarshalBinary()
+		require.NoError(t, err)
+		verify(t, pktOut, 6, data)
+	})
+
+	t.Run("UDP", func(t *testing.T) {
+		pktOut := newBuilder().SetIPProtocol(ProtocolUDP).
+			SetUDPSrcPort(53).
+			SetUDPDstPort(34567).
+			Done()
+		assert.Equal(t, uint16(8), pktOut.UDPHeader.Length)
+		data, err := pktOut.UDPHeader.MarshalBinary()
+		require.NoError(t, err)
+		verify(t, pktOut, 17, data)
+	})
+
+	t.Run("ICMP", func(t *testing.T) {
+		icmpData := []byte{0, 0, 0, 0, 0x45, 0, 0, 0x1c, 0x12, 0x34, 0x40, 0, 0x40, 0x11, 0xab, 0xcd}
+		pktOut := newBuilder().SetIPProtocol(ProtocolICMP).
+			SetICMPType(3).
+			SetICMPCode(3).
+			SetICMPData(icmpData).
+			Done()
+		assert.Equal(t, icmpData, pktOut.ICMPHeader.Data)
+		assert.Equal(t, uint16(20+4+len(icmpData)), pktOut.IPHeader.Length)
+		data, err := pktOut.ICMPHeader.MarshalBinary()
+		require.NoError(t, err)
+		verify(t, pktOut, 0, data)
+	})
+}
+
+func TestIPv6PacketOut(t *testing.T) {
+	srcIP, dstIP := net.ParseIP("fd00:10:10::2"), net.ParseIP("fd00:10:10::1")
+	newBuilder := func() PacketOutBuilder {
+		return (&ofPacketOutBuilder{pktOut: new(ofctrl.PacketOut)}).
+			SetSrcIP(srcIP).
+			SetDstIP(dstIP).
+			SetTTL(64)
+	}
+	// verify checks the serialized IPv6 packet of the message, and the checksum of its payload.
+	verify := func(t *testing.T, pktOut *ofctrl.PacketOut, nextHeader uint8, transport util.Message) {
+		message, ok := packetOutMessage(pktOut).(*openflow13.PacketOut)
+		require.True(t, ok)
+		frame, ok := message.Data.(*protocol.Ethernet)
+		require.True(t, ok)
+		assert.Equal(t, uint16(protocol.IPv6_MSG), frame.Ethertype)
+		data, err := frame.Data.MarshalBinary()
+		require.NoError(t, err)
+		ipv6Packet, err := ParseIPv6Packet(data)
+		require.NoError(t, err)
+		assert.Equal(t, nextHeader, ipv6Packet.NextHeader)
+		assert.Equal(t, uint8(64), ipv6Packet.HopLimit)
+		assert.True(t, srcIP.Equal(ipv6Packet.NWSrc))
+		assert.True(t, dstIP.Equal(ipv6Packet.NWDst))
+		transportData, err := transport.MarshalBinary()
+		require.NoError(t, err)
+		assert.Equal(t, transportData, ipv6Packet.Payload)
+		pseudoHeader := ipv6PseudoHeader(pktOut.IPHeader, nextHeader, uint16(len(transportData)))
+		assert.Equal(t, uint16(0), calculateChecksum(append(pseudoHeader, transportData...)))
+	}
+
+	t.Run("TCP", func(t *testing.T) {
+		pktOut := newBuilder().SetIPProtocol(ProtocolTCP).
+			SetTCPSrcPort(80).
+			SetTCPDstPort(34567).
+			SetTCPFlags(0x14).
+			SetTCPSeqNum(0).
+			SetTCPAckNum(1001).
+			Done()
+		verify(t, pktOut, protocol.Type_TCP, pktOut.TCPHeader)
+	})
+
+	t.Run("ICMPv6", func(t *testing.T) {
+		pktOut := newBuilder().SetIPProtocol(ProtocolICMPv6).
+			SetICMPType(1).
+			SetICMPCode(4).
+			SetICMPData([]byte{0, 0, 0, 0, 0x60, 0, 0, 0}).
+			Done()
+		verify(t, pktOut, protocol.Type_IPv6ICMP, pktOut.ICMPHeader)
+	})
+}
+
+func TestParseIPv6Packet(t *testing.T) {
+	packet := &IPv6Packet{
+		TrafficClass: 0x12,
+		FlowLabel:    0x34567,
+		NextHeader:   protocol.Type_UDP,
+		HopLimit:     64,
+		NWSrc:        net.ParseIP("fd00:10:10::2"),
+		NWDst:        net.ParseIP("fd00:10:10::1"),
+		Payload:      []byte{1, 2, 3, 4},
+	}
+	data, err := packet.MarshalBinary()
+	require.NoError(t, err)
+	// The padding of the Ethernet frame is not part of the payload.
+	parsed, err := ParseIPv6Packet(append(data, 0, 0))
+	require.NoError(t, err)
+	assert.Equal(t, packet, parsed)
+
+	_, err = ParseIPv6Packet(data[:39])
+	assert.Error(t, err)
+	_, err = ParseIPv6Packet(data[:43])
+	assert.Error(t, err)
+}
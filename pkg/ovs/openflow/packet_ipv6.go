@@ -0,0 +1,88 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openflow
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+)
+
+const ipv6HeaderLen = 40
+
+// IPv6Packet is an IPv6 packet. The Openflow library only parses and serializes IPv4 packets: the IPv6 packets of the
+// PacketIn messages are left unparsed, and must be parsed with ParseIPv6Packet. The extension headers are not parsed,
+// they are part of the payload.
+type IPv6Packet struct {
+	TrafficClass uint8
+	FlowLabel    uint32
+	NextHeader   uint8
+	HopLimit     uint8
+	NWSrc        net.IP
+	NWDst        net.IP
+	Payload      []byte
+}
+
+// ParseIPv6Packet parses the provided IPv6 packet. The payload is truncated to the payload length of the IPv6 header,
+// so that the padding of the Ethernet frame is not considered as part of it.
+func ParseIPv6Packet(data []byte) (*IPv6Packet, error) {
+	if len(data) < ipv6HeaderLen {
+		return nil, fmt.Errorf("IPv6 packet too short: %d bytes", len(data))
+	}
+	if version := data[0] >> 4; version != 6 {
+		return nil, fmt.Errorf("invalid IP version %d", version)
+	}
+	payloadLen := int(binary.BigEndian.Uint16(data[4:6]))
+	if len(data) < ipv6HeaderLen+payloadLen {
+		return nil, fmt.Errorf("IPv6 payload too short: %d bytes, expected %d", len(data)-ipv6HeaderLen, payloadLen)
+	}
+	return &IPv6Packet{
+		TrafficClass: data[0]<<4 | data[1]>>4,
+		FlowLabel:    binary.BigEndian.Uint32(data[0:4]) & 0xfffff,
+		NextHeader:   data[6],
+		HopLimit:     data[7],
+		NWSrc:        net.IP(append([]byte(nil), data[8:24]...)),
+		NWDst:        net.IP(append([]byte(nil), data[24:40]...)),
+		Payload:      data[ipv6HeaderLen : ipv6HeaderLen+payloadLen],
+	}, nil
+}
+
+// Len returns the length of the serialized packet.
+func (p *IPv6Packet) Len() uint16 {
+	return uint16(ipv6HeaderLen + len(p.Payload))
+}
+
+// MarshalBinary serializes the packet. The payload length is calculated from the payload.
+func (p *IPv6Packet) MarshalBinary() ([]byte, error) {
+	data := make([]byte, p.Len())
+	binary.BigEndian.PutUint32(data[0:4], 6<<28|uint32(p.TrafficClass)<<20|p.FlowLabel&0xfffff)
+	binary.BigEndian.PutUint16(data[4:6], uint16(len(p.Payload)))
+	data[6] = p.NextHeader
+	data[7] = p.HopLimit
+	copy(data[8:24], p.NWSrc.To16())
+	copy(data[24:40], p.NWDst.To16())
+	copy(data[ipv6HeaderLen:], p.Payload)
+	return data, nil
+}
+
+// UnmarshalBinary parses the provided IPv6 packet into p.
+func (p *IPv6Packet) UnmarshalBinary(data []byte) error {
+	packet, err := ParseIPv6Packet(data)
+	if err != nil {
+		return err
+	}
+	*p = *packet
+	return nil
+}
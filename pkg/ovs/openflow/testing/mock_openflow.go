@@ -20,6 +20,7 @@
 package testing
 
 import (
+	protocol "github.com/contiv/libOpenflow/protocol"
 	ofctrl "github.com/contiv/ofnet/ofctrl"
 	gomock "github.com/golang/mock/gomock"
 	openflow "github.com/vmware-tanzu/antrea/pkg/ovs/openflow"
@@ -261,6 +262,20 @@ func (mr *MockBridgeMockRecorder) SendPacketOut(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPacketOut", reflect.TypeOf((*MockBridge)(nil).SendPacketOut), arg0)
 }
 
+// SendPacketOutFrame mocks base method
+func (m *MockBridge) SendPacketOutFrame(arg0 *protocol.Ethernet, arg1 uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPacketOutFrame", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPacketOutFrame indicates an expected call of SendPacketOutFrame
+func (mr *MockBridgeMockRecorder) SendPacketOutFrame(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPacketOutFrame", reflect.TypeOf((*MockBridge)(nil).SendPacketOutFrame), arg0, arg1)
+}
+
 // SubscribePacketIn mocks base method
 func (m *MockBridge) SubscribePacketIn(arg0 byte, arg1 chan *ofctrl.PacketIn) error {
 	m.ctrl.T.Helper()
@@ -1234,6 +1249,62 @@ func (mr *MockFlowBuilderMockRecorder) MatchDstMAC(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchDstMAC", reflect.TypeOf((*MockFlowBuilder)(nil).MatchDstMAC), arg0)
 }
 
+// MatchDstPortMask mocks base method
+func (m *MockFlowBuilder) MatchDstPortMask(arg0, arg1 uint16) openflow.FlowBuilder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchDstPortMask", arg0, arg1)
+	ret0, _ := ret[0].(openflow.FlowBuilder)
+	return ret0
+}
+
+// MatchDstPortMask indicates an expected call of MatchDstPortMask
+func (mr *MockFlowBuilderMockRecorder) MatchDstPortMask(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchDstPortMask", reflect.TypeOf((*MockFlowBuilder)(nil).MatchDstPortMask), arg0, arg1)
+}
+
+// MatchICMPCode mocks base method
+func (m *MockFlowBuilder) MatchICMPCode(arg0 byte) openflow.FlowBuilder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchICMPCode", arg0)
+	ret0, _ := ret[0].(openflow.FlowBuilder)
+	return ret0
+}
+
+// MatchICMPCode indicates an expected call of MatchICMPCode
+func (mr *MockFlowBuilderMockRecorder) MatchICMPCode(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchICMPCode", reflect.TypeOf((*MockFlowBuilder)(nil).MatchICMPCode), arg0)
+}
+
+// MatchICMPType mocks base method
+func (m *MockFlowBuilder) MatchICMPType(arg0 byte) openflow.FlowBuilder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchICMPType", arg0)
+	ret0, _ := ret[0].(openflow.FlowBuilder)
+	return ret0
+}
+
+// MatchICMPType indicates an expected call of MatchICMPType
+func (mr *MockFlowBuilderMockRecorder) MatchICMPType(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchICMPType", reflect.TypeOf((*MockFlowBuilder)(nil).MatchICMPType), arg0)
+}
+
+// MatchIPDscp mocks base method
+func (m *MockFlowBuilder) MatchIPDscp(arg0 byte) openflow.FlowBuilder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchIPDscp", arg0)
+	ret0, _ := ret[0].(openflow.FlowBuilder)
+	return ret0
+}
+
+// MatchIPDscp indicates an expected call of MatchIPDscp
+func (mr *MockFlowBuilderMockRecorder) MatchIPDscp(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchIPDscp", reflect.TypeOf((*MockFlowBuilder)(nil).MatchIPDscp), arg0)
+}
+
 // MatchInPort mocks base method
 func (m *MockFlowBuilder) MatchInPort(arg0 uint32) openflow.FlowBuilder {
 	m.ctrl.T.Helper()
@@ -1360,6 +1431,20 @@ func (mr *MockFlowBuilderMockRecorder) MatchTCPDstPort(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchTCPDstPort", reflect.TypeOf((*MockFlowBuilder)(nil).MatchTCPDstPort), arg0)
 }
 
+// MatchTCPSrcPort mocks base method
+func (m *MockFlowBuilder) MatchTCPSrcPort(arg0 uint16) openflow.FlowBuilder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchTCPSrcPort", arg0)
+	ret0, _ := ret[0].(openflow.FlowBuilder)
+	return ret0
+}
+
+// MatchTCPSrcPort indicates an expected call of MatchTCPSrcPort
+func (mr *MockFlowBuilderMockRecorder) MatchTCPSrcPort(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchTCPSrcPort", reflect.TypeOf((*MockFlowBuilder)(nil).MatchTCPSrcPort), arg0)
+}
+
 // MatchTunMetadata mocks base method
 func (m *MockFlowBuilder) MatchTunMetadata(arg0 int, arg1 uint32) openflow.FlowBuilder {
 	m.ctrl.T.Helper()
@@ -1388,6 +1473,20 @@ func (mr *MockFlowBuilderMockRecorder) MatchUDPDstPort(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchUDPDstPort", reflect.TypeOf((*MockFlowBuilder)(nil).MatchUDPDstPort), arg0)
 }
 
+// MatchUDPSrcPort mocks base method
+func (m *MockFlowBuilder) MatchUDPSrcPort(arg0 uint16) openflow.FlowBuilder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchUDPSrcPort", arg0)
+	ret0, _ := ret[0].(openflow.FlowBuilder)
+	return ret0
+}
+
+// MatchUDPSrcPort indicates an expected call of MatchUDPSrcPort
+func (mr *MockFlowBuilderMockRecorder) MatchUDPSrcPort(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchUDPSrcPort", reflect.TypeOf((*MockFlowBuilder)(nil).MatchUDPSrcPort), arg0)
+}
+
 // SetHardTimeout mocks base method
 func (m *MockFlowBuilder) SetHardTimeout(arg0 uint16) openflow.FlowBuilder {
 	m.ctrl.T.Helper()
@@ -33,9 +33,11 @@ type OVSBridgeClient interface {
 	SetExternalIDs(externalIDs map[string]interface{}) Error
 	SetDatapathID(datapathID string) Error
 	CreatePort(name, ifDev string, externalIDs map[string]interface{}) (string, Error)
+	CreateAccessPort(name, ifDev string, vlanID uint16, externalIDs map[string]interface{}) (string, Error)
 	CreateInternalPort(name string, ofPortRequest int32, externalIDs map[string]interface{}) (string, Error)
 	CreateTunnelPort(name string, tunnelType TunnelType, ofPortRequest int32) (string, Error)
-	CreateTunnelPortExt(name string, tunnelType TunnelType, ofPortRequest int32, localIP string, remoteIP string, psk string, externalIDs map[string]interface{}) (string, Error)
+	CreateTunnelPortExt(name string, tunnelType TunnelType, ofPortRequest int32, localIP string, remoteIP string, psk string, remoteName string, externalIDs map[string]interface{}) (string, Error)
+	SetTunnelInterfaceOptions(name, localIP, remoteIP, psk, remoteName string) Error
 	CreateUplinkPort(name string, ofPortRequest int32, externalIDs map[string]interface{}) (string, Error)
 	DeletePort(portUUID string) Error
 	DeletePorts(portUUIDList []string) Error
@@ -43,9 +45,14 @@ type OVSBridgeClient interface {
 	GetPortData(portUUID, ifName string) (*OVSPortData, Error)
 	GetPortList() ([]OVSPortData, Error)
 	SetInterfaceMTU(name string, MTU int) error
+	SetInterfaceIngressPolicing(name string, rate, burst int64) Error
+	GetInterfaceIngressPolicing(name string) (int64, int64, Error)
+	SetPortQoS(portUUID string, maxRate, burst int64) Error
+	GetPortQoS(portUUID string) (int64, int64, Error)
 	GetOVSVersion() (string, Error)
 	AddOVSOtherConfig(configs map[string]interface{}) Error
 	GetOVSOtherConfig() (map[string]string, Error)
+	UpdateOVSOtherConfig(configs map[string]interface{}) Error
 	DeleteOVSOtherConfig(configs map[string]interface{}) Error
 	GetBridgeName() string
 }
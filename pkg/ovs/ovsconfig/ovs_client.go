@@ -17,6 +17,7 @@ package ovsconfig
 import (
 	"fmt"
 	"net"
+	"strconv"
 	"time"
 
 	"github.com/TomCodeLV/OVSDB-golang-lib/pkg/dbtransaction"
@@ -53,6 +54,12 @@ const (
 	openflowProtoVersion13 = "OpenFlow13"
 	// Maximum allowed value of ofPortRequest.
 	ofPortRequestMax = 65279
+	// The key of the external_ids of the QoS and Queue rows created for a port, the value being
+	// the UUID of the port. It is used to delete the rows, which are not garbage collected by OVSDB,
+	// with the port.
+	qosExternalIDPortUUID = "antrea-port-uuid"
+	// The QoS type used to shape the traffic sent to a port.
+	qosTypeLinuxHTB = "linux-htb"
 )
 
 // NewOVSDBConnectionUDS connects to the OVSDB server on the UNIX domain socket
@@ -312,6 +319,9 @@ func (br *OVSBridge) GetPortUUIDList() ([]string, Error) {
 // DeletePorts deletes ports in portUUIDList on the bridge
 func (br *OVSBridge) DeletePorts(portUUIDList []string) Error {
 	tx := br.ovsdb.Transaction(openvSwitchSchema)
+	for _, portUUID := range portUUIDList {
+		deletePortQoS(tx, portUUID)
+	}
 	mutateSet := helpers.MakeOVSDBSet(map[string]interface{}{
 		"uuid": portUUIDList,
 	})
@@ -328,10 +338,12 @@ func (br *OVSBridge) DeletePorts(portUUIDList []string) Error {
 	return nil
 }
 
-// DeletePort deletes the port with the provided portUUID.
+// DeletePort deletes the port with the provided portUUID, and the QoS configured
+// for it with SetPortQoS.
 // If the port does not exist no change will be done.
 func (br *OVSBridge) DeletePort(portUUID string) Error {
 	tx := br.ovsdb.Transaction(openvSwitchSchema)
+	deletePortQoS(tx, portUUID)
 	mutateSet := helpers.MakeOVSDBSet(map[string]interface{}{
 		"uuid": []string{portUUID},
 	})
@@ -357,14 +369,14 @@ func (br *OVSBridge) CreateInternalPort(name string, ofPortRequest int32, extern
 	if ofPortRequest < 0 || ofPortRequest > ofPortRequestMax {
 		return "", newInvalidArgumentsError(fmt.Sprint("invalid ofPortRequest value: ", ofPortRequest))
 	}
-	return br.createPort(name, name, "internal", ofPortRequest, externalIDs, nil)
+	return br.createPort(name, name, "internal", ofPortRequest, 0, externalIDs, nil)
 }
 
 // CreateTunnelPort creates a tunnel port with the specified name and type on
 // the bridge.
 // If ofPortRequest is not zero, it will be passed to the OVS port creation.
 func (br *OVSBridge) CreateTunnelPort(name string, tunnelType TunnelType, ofPortRequest int32) (string, Error) {
-	return br.createTunnelPort(name, tunnelType, ofPortRequest, "", "", "", nil)
+	return br.createTunnelPort(name, tunnelType, ofPortRequest, "", "", "", "", nil)
 }
 
 // CreateTunnelPortExt creates a tunnel port with the specified name and type
@@ -372,9 +384,11 @@ func (br *OVSBridge) CreateTunnelPort(name string, tunnelType TunnelType, ofPort
 // If ofPortRequest is not zero, it will be passed to the OVS port creation.
 // If remoteIP is not empty, it will be set to the tunnel port interface
 // options; otherwise flow based tunneling will be configured.
-// psk is for the pre-shared key of IPSec ESP tunnel. If it is not empty, it
-// will be set to the tunnel port interface options. Flow based IPSec tunnel is
-// not supported, so remoteIP must be provided too when psk is not empty.
+// psk is for the pre-shared key of IPSec ESP tunnel, and remoteName is for the
+// name expected in the certificate of the remote IPSec endpoint when certificate
+// based authentication is used. If they are not empty, they will be set to the
+// tunnel port interface options. Flow based IPSec tunnel is not supported, so
+// remoteIP must be provided too when psk or remoteName is not empty.
 // If externalIDs is not nill, the IDs in it will be added to the port's
 // external_ids.
 func (br *OVSBridge) CreateTunnelPortExt(
@@ -384,11 +398,12 @@ func (br *OVSBridge) CreateTunnelPortExt(
 	localIP string,
 	remoteIP string,
 	psk string,
+	remoteName string,
 	externalIDs map[string]interface{}) (string, Error) {
-	if psk != "" && remoteIP == "" {
+	if (psk != "" || remoteName != "") && remoteIP == "" {
 		return "", newInvalidArgumentsError("IPSec tunnel can not be flow based. remoteIP must be set")
 	}
-	return br.createTunnelPort(name, tunnelType, ofPortRequest, localIP, remoteIP, psk, externalIDs)
+	return br.createTunnelPort(name, tunnelType, ofPortRequest, localIP, remoteIP, psk, remoteName, externalIDs)
 }
 
 func (br *OVSBridge) createTunnelPort(
@@ -398,6 +413,7 @@ func (br *OVSBridge) createTunnelPort(
 	localIP string,
 	remoteIP string,
 	psk string,
+	remoteName string,
 	externalIDs map[string]interface{}) (string, Error) {
 
 	if tunnelType != VXLANTunnel && tunnelType != GeneveTunnel && tunnelType != GRETunnel && tunnelType != STTTunnel {
@@ -407,6 +423,11 @@ func (br *OVSBridge) createTunnelPort(
 		return "", newInvalidArgumentsError(fmt.Sprint("invalid ofPortRequest value: ", ofPortRequest))
 	}
 
+	options := buildTunnelInterfaceOptions(localIP, remoteIP, psk, remoteName)
+	return br.createPort(name, name, string(tunnelType), ofPortRequest, 0, externalIDs, options)
+}
+
+func buildTunnelInterfaceOptions(localIP, remoteIP, psk, remoteName string) map[string]interface{} {
 	options := make(map[string]interface{}, 2)
 	if remoteIP != "" {
 		options["remote_ip"] = remoteIP
@@ -422,19 +443,46 @@ func (br *OVSBridge) createTunnelPort(
 	if psk != "" {
 		options["psk"] = psk
 	}
+	if remoteName != "" {
+		options["remote_name"] = remoteName
+	}
+	return options
+}
+
+// SetTunnelInterfaceOptions replaces the options of the tunnel interface with
+// the provided name, with the same semantics as CreateTunnelPortExt. The port
+// and its ofport are kept, so that the flows using it remain valid.
+func (br *OVSBridge) SetTunnelInterfaceOptions(name, localIP, remoteIP, psk, remoteName string) Error {
+	if (psk != "" || remoteName != "") && remoteIP == "" {
+		return newInvalidArgumentsError("IPSec tunnel can not be flow based. remoteIP must be set")
+	}
+	tx := br.ovsdb.Transaction(openvSwitchSchema)
+
+	tx.Update(dbtransaction.Update{
+		Table: "Interface",
+		Where: [][]interface{}{{"name", "==", name}},
+		Row: map[string]interface{}{
+			"options": helpers.MakeOVSDBMap(buildTunnelInterfaceOptions(localIP, remoteIP, psk, remoteName)),
+		},
+	})
 
-	return br.createPort(name, name, string(tunnelType), ofPortRequest, externalIDs, options)
+	_, err, temporary := tx.Commit()
+	if err != nil {
+		klog.Error("Transaction failed: ", err)
+		return NewTransactionError(err, temporary)
+	}
+	return nil
 }
 
-// ParseTunnelInterfaceOptions reads remote IP and IPSec PSK from the tunnel
-// interface options and returns them.
-func ParseTunnelInterfaceOptions(portData *OVSPortData) (net.IP, net.IP, string) {
+// ParseTunnelInterfaceOptions reads remote IP, local IP, IPSec PSK and IPSec
+// remote name from the tunnel interface options and returns them.
+func ParseTunnelInterfaceOptions(portData *OVSPortData) (net.IP, net.IP, string, string) {
 	if portData.Options == nil {
-		return nil, nil, ""
+		return nil, nil, "", ""
 	}
 
 	var ok bool
-	var remoteIPStr, localIPStr, psk string
+	var remoteIPStr, localIPStr, psk, remoteName string
 	var remoteIP, localIP net.IP
 
 	if remoteIPStr, ok = portData.Options["remote_ip"]; ok {
@@ -447,12 +495,13 @@ func ParseTunnelInterfaceOptions(portData *OVSPortData) (net.IP, net.IP, string)
 	}
 
 	psk = portData.Options["psk"]
-	return remoteIP, localIP, psk
+	remoteName = portData.Options["remote_name"]
+	return remoteIP, localIP, psk, remoteName
 }
 
 // CreateUplinkPort creates uplink port.
 func (br *OVSBridge) CreateUplinkPort(name string, ofPortRequest int32, externalIDs map[string]interface{}) (string, Error) {
-	return br.createPort(name, name, "", ofPortRequest, externalIDs, nil)
+	return br.createPort(name, name, "", ofPortRequest, 0, externalIDs, nil)
 }
 
 // CreatePort creates a port with the specified name on the bridge, and connects
@@ -460,10 +509,20 @@ func (br *OVSBridge) CreateUplinkPort(name string, ofPortRequest int32, external
 // If externalIDs is not empty, the map key/value pairs will be set to the
 // port's external_ids.
 func (br *OVSBridge) CreatePort(name, ifDev string, externalIDs map[string]interface{}) (string, Error) {
-	return br.createPort(name, ifDev, "", 0, externalIDs, nil)
+	return br.createPort(name, ifDev, "", 0, 0, externalIDs, nil)
+}
+
+// CreateAccessPort creates a port with the specified name on the bridge as an
+// access port of the VLAN specified by vlanID, and connects the interface
+// specified by ifDev to the port. The traffic of the interface is tagged with
+// the VLAN ID when it leaves the bridge through a trunk port.
+// If externalIDs is not empty, the map key/value pairs will be set to the
+// port's external_ids.
+func (br *OVSBridge) CreateAccessPort(name, ifDev string, vlanID uint16, externalIDs map[string]interface{}) (string, Error) {
+	return br.createPort(name, ifDev, "", 0, vlanID, externalIDs, nil)
 }
 
-func (br *OVSBridge) createPort(name, ifName, ifType string, ofPortRequest int32, externalIDs, options map[string]interface{}) (string, Error) {
+func (br *OVSBridge) createPort(name, ifName, ifType string, ofPortRequest int32, vlanID uint16, externalIDs, options map[string]interface{}) (string, Error) {
 	var externalIDMap []interface{}
 	var optionMap []interface{}
 
@@ -493,6 +552,7 @@ func (br *OVSBridge) createPort(name, ifName, ifType string, ofPortRequest int32
 			"named-uuid": []string{ifNamedUUID},
 		}),
 		ExternalIDs: externalIDMap,
+		Tag:         vlanID,
 	}
 	portNamedUUID := tx.Insert(dbtransaction.Insert{
 		Table: "Port",
@@ -708,6 +768,154 @@ func (br *OVSBridge) SetInterfaceMTU(name string, MTU int) error {
 	return nil
 }
 
+// SetInterfaceIngressPolicing sets the maximum rate in kbps and the burst size
+// in kb of the traffic received by OVS from the interface with the provided
+// name. The excess traffic is dropped. A rate of 0 disables the policing, and
+// a burst size of 0 lets OVS use its default burst size.
+func (br *OVSBridge) SetInterfaceIngressPolicing(name string, rate, burst int64) Error {
+	tx := br.ovsdb.Transaction(openvSwitchSchema)
+
+	tx.Update(dbtransaction.Update{
+		Table: "Interface",
+		Where: [][]interface{}{{"name", "==", name}},
+		Row: map[string]interface{}{
+			"ingress_policing_rate":  rate,
+			"ingress_policing_burst": burst,
+		},
+	})
+
+	_, err, temporary := tx.Commit()
+	if err != nil {
+		klog.Error("Transaction failed: ", err)
+		return NewTransactionError(err, temporary)
+	}
+	return nil
+}
+
+// GetInterfaceIngressPolicing returns the maximum rate in kbps and the burst
+// size in kb of the traffic received by OVS from the interface with the
+// provided name.
+func (br *OVSBridge) GetInterfaceIngressPolicing(name string) (int64, int64, Error) {
+	tx := br.ovsdb.Transaction(openvSwitchSchema)
+
+	tx.Select(dbtransaction.Select{
+		Table:   "Interface",
+		Columns: []string{"ingress_policing_rate", "ingress_policing_burst"},
+		Where:   [][]interface{}{{"name", "==", name}},
+	})
+
+	res, err, temporary := tx.Commit()
+	if err != nil {
+		klog.Error("Transaction failed: ", err)
+		return 0, 0, NewTransactionError(err, temporary)
+	}
+	if len(res[0].Rows) == 0 {
+		return 0, 0, NewTransactionError(fmt.Errorf("interface %s not found", name), false)
+	}
+	row := res[0].Rows[0].(map[string]interface{})
+	return int64(row["ingress_policing_rate"].(float64)), int64(row["ingress_policing_burst"].(float64)), nil
+}
+
+// deletePortQoS adds the operations deleting the QoS configured for the port
+// with the provided UUID to the transaction. The QoS must be removed from the
+// port first, as OVSDB does not allow deleting rows which are still
+// referenced.
+func deletePortQoS(tx *dbtransaction.Transaction, portUUID string) {
+	tx.Update(dbtransaction.Update{
+		Table: "Port",
+		Where: [][]interface{}{{"_uuid", "==", []string{"uuid", portUUID}}},
+		Row: map[string]interface{}{
+			"qos": makeOVSDBSetFromList([]string{}),
+		},
+	})
+	where := [][]interface{}{{"external_ids", "includes", helpers.MakeOVSDBMap(map[string]interface{}{qosExternalIDPortUUID: portUUID})}}
+	tx.Delete(dbtransaction.Delete{Table: "QoS", Where: where})
+	tx.Delete(dbtransaction.Delete{Table: "Queue", Where: where})
+}
+
+// SetPortQoS sets the maximum rate in bps and the burst size in bits of the
+// traffic sent by OVS to the port with the provided UUID, by replacing the QoS
+// of the port with a linux-htb QoS with a single queue. A rate of 0 removes the
+// QoS of the port.
+func (br *OVSBridge) SetPortQoS(portUUID string, maxRate, burst int64) Error {
+	tx := br.ovsdb.Transaction(openvSwitchSchema)
+	deletePortQoS(tx, portUUID)
+
+	if maxRate > 0 {
+		externalIDs := helpers.MakeOVSDBMap(map[string]interface{}{qosExternalIDPortUUID: portUUID})
+		queueConfig := map[string]interface{}{"max-rate": strconv.FormatInt(maxRate, 10)}
+		if burst > 0 {
+			queueConfig["burst"] = strconv.FormatInt(burst, 10)
+		}
+		queueNamedUUID := tx.Insert(dbtransaction.Insert{
+			Table: "Queue",
+			Row: Queue{
+				OtherConfig: helpers.MakeOVSDBMap(queueConfig),
+				ExternalIDs: externalIDs,
+			},
+		})
+		qosNamedUUID := tx.Insert(dbtransaction.Insert{
+			Table: "QoS",
+			Row: QoS{
+				Type:        qosTypeLinuxHTB,
+				OtherConfig: helpers.MakeOVSDBMap(map[string]interface{}{"max-rate": strconv.FormatInt(maxRate, 10)}),
+				// The queue map has integer keys, which MakeOVSDBMap does not support.
+				Queues:      []interface{}{"map", []interface{}{[]interface{}{0, []string{"named-uuid", queueNamedUUID}}}},
+				ExternalIDs: externalIDs,
+			},
+		})
+		tx.Update(dbtransaction.Update{
+			Table: "Port",
+			Where: [][]interface{}{{"_uuid", "==", []string{"uuid", portUUID}}},
+			Row: map[string]interface{}{
+				"qos": []string{"named-uuid", qosNamedUUID},
+			},
+		})
+	}
+
+	_, err, temporary := tx.Commit()
+	if err != nil {
+		klog.Error("Transaction failed: ", err)
+		return NewTransactionError(err, temporary)
+	}
+	return nil
+}
+
+// GetPortQoS returns the maximum rate in bps and the burst size in bits of the
+// traffic sent by OVS to the port with the provided UUID, as set by SetPortQoS.
+// 0 is returned for both if the port has no QoS.
+func (br *OVSBridge) GetPortQoS(portUUID string) (int64, int64, Error) {
+	tx := br.ovsdb.Transaction(openvSwitchSchema)
+
+	tx.Select(dbtransaction.Select{
+		Table:   "Port",
+		Columns: []string{"qos"},
+		Where:   [][]interface{}{{"_uuid", "==", []string{"uuid", portUUID}}},
+	})
+	tx.Select(dbtransaction.Select{
+		Table:   "Queue",
+		Columns: []string{"other_config"},
+		Where:   [][]interface{}{{"external_ids", "includes", helpers.MakeOVSDBMap(map[string]interface{}{qosExternalIDPortUUID: portUUID})}},
+	})
+
+	res, err, temporary := tx.Commit()
+	if err != nil {
+		klog.Error("Transaction failed: ", err)
+		return 0, 0, NewTransactionError(err, temporary)
+	}
+	if len(res[0].Rows) == 0 {
+		return 0, 0, NewTransactionError(fmt.Errorf("port %s not found", portUUID), false)
+	}
+	// An empty set is encoded as ["set", []], while a single UUID is encoded as ["uuid", <uuid>].
+	if qos := res[0].Rows[0].(map[string]interface{})["qos"].([]interface{}); qos[0] != "uuid" || len(res[1].Rows) == 0 {
+		return 0, 0, nil
+	}
+	queueConfig := buildMapFromOVSDBMap(res[1].Rows[0].(map[string]interface{})["other_config"].([]interface{}))
+	maxRate, _ := strconv.ParseInt(queueConfig["max-rate"], 10, 64)
+	burst, _ := strconv.ParseInt(queueConfig["burst"], 10, 64)
+	return maxRate, burst, nil
+}
+
 func (br *OVSBridge) GetOVSVersion() (string, Error) {
 	tx := br.ovsdb.Transaction(openvSwitchSchema)
 
@@ -772,6 +980,34 @@ func (br *OVSBridge) GetOVSOtherConfig() (map[string]string, Error) {
 	return buildMapFromOVSDBMap(otherConfigs), nil
 }
 
+// UpdateOVSOtherConfig sets the given configs in the "other_config" column of
+// the single record of the "Open_vSwitch" table, replacing the existing values
+// of their keys.
+func (br *OVSBridge) UpdateOVSOtherConfig(configs map[string]interface{}) Error {
+	tx := br.ovsdb.Transaction(openvSwitchSchema)
+
+	keys := make([]string, 0, len(configs))
+	for key := range configs {
+		keys = append(keys, key)
+	}
+	// Deleting a set of keys from a map removes the pairs with these keys whatever their values.
+	tx.Mutate(dbtransaction.Mutate{
+		Table:     "Open_vSwitch",
+		Mutations: [][]interface{}{{"other_config", "delete", makeOVSDBSetFromList(keys)}},
+	})
+	tx.Mutate(dbtransaction.Mutate{
+		Table:     "Open_vSwitch",
+		Mutations: [][]interface{}{{"other_config", "insert", helpers.MakeOVSDBMap(configs)}},
+	})
+
+	_, err, temporary := tx.Commit()
+	if err != nil {
+		klog.Error("Transaction failed: ", err)
+		return NewTransactionError(err, temporary)
+	}
+	return nil
+}
+
 // DeleteOVSOtherConfig deletes the given configs from the "other_config" column of
 // the single record of the "Open_vSwitch" table.
 // For each config, it will only be deleted if its key exists and its value matches the stored one.
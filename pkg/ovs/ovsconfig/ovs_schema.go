@@ -24,6 +24,7 @@ type Port struct {
 	Name        string        `json:"name"`
 	Interfaces  []interface{} `json:"interfaces"`
 	ExternalIDs []interface{} `json:"external_ids,omitempty"`
+	Tag         uint16        `json:"tag,omitempty"`
 }
 
 type Interface struct {
@@ -32,3 +33,15 @@ type Interface struct {
 	OFPortRequest int32         `json:"ofport_request,omitempty"`
 	Options       []interface{} `json:"options,omitempty"`
 }
+
+type QoS struct {
+	Type        string        `json:"type"`
+	OtherConfig []interface{} `json:"other_config,omitempty"`
+	Queues      []interface{} `json:"queues,omitempty"`
+	ExternalIDs []interface{} `json:"external_ids,omitempty"`
+}
+
+type Queue struct {
+	OtherConfig []interface{} `json:"other_config,omitempty"`
+	ExternalIDs []interface{} `json:"external_ids,omitempty"`
+}
@@ -76,6 +76,21 @@ func (mr *MockOVSBridgeClientMockRecorder) Create() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOVSBridgeClient)(nil).Create))
 }
 
+// CreateAccessPort mocks base method
+func (m *MockOVSBridgeClient) CreateAccessPort(arg0, arg1 string, arg2 uint16, arg3 map[string]interface{}) (string, ovsconfig.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccessPort", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(ovsconfig.Error)
+	return ret0, ret1
+}
+
+// CreateAccessPort indicates an expected call of CreateAccessPort
+func (mr *MockOVSBridgeClientMockRecorder) CreateAccessPort(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccessPort", reflect.TypeOf((*MockOVSBridgeClient)(nil).CreateAccessPort), arg0, arg1, arg2, arg3)
+}
+
 // CreateInternalPort mocks base method
 func (m *MockOVSBridgeClient) CreateInternalPort(arg0 string, arg1 int32, arg2 map[string]interface{}) (string, ovsconfig.Error) {
 	m.ctrl.T.Helper()
@@ -122,18 +137,18 @@ func (mr *MockOVSBridgeClientMockRecorder) CreateTunnelPort(arg0, arg1, arg2 int
 }
 
 // CreateTunnelPortExt mocks base method
-func (m *MockOVSBridgeClient) CreateTunnelPortExt(arg0 string, arg1 ovsconfig.TunnelType, arg2 int32, arg3, arg4, arg5 string, arg6 map[string]interface{}) (string, ovsconfig.Error) {
+func (m *MockOVSBridgeClient) CreateTunnelPortExt(arg0 string, arg1 ovsconfig.TunnelType, arg2 int32, arg3, arg4, arg5, arg6 string, arg7 map[string]interface{}) (string, ovsconfig.Error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTunnelPortExt", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret := m.ctrl.Call(m, "CreateTunnelPortExt", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(ovsconfig.Error)
 	return ret0, ret1
 }
 
 // CreateTunnelPortExt indicates an expected call of CreateTunnelPortExt
-func (mr *MockOVSBridgeClientMockRecorder) CreateTunnelPortExt(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+func (mr *MockOVSBridgeClientMockRecorder) CreateTunnelPortExt(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTunnelPortExt", reflect.TypeOf((*MockOVSBridgeClient)(nil).CreateTunnelPortExt), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTunnelPortExt", reflect.TypeOf((*MockOVSBridgeClient)(nil).CreateTunnelPortExt), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 }
 
 // CreateUplinkPort mocks base method
@@ -236,6 +251,22 @@ func (mr *MockOVSBridgeClientMockRecorder) GetExternalIDs() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExternalIDs", reflect.TypeOf((*MockOVSBridgeClient)(nil).GetExternalIDs))
 }
 
+// GetInterfaceIngressPolicing mocks base method
+func (m *MockOVSBridgeClient) GetInterfaceIngressPolicing(arg0 string) (int64, int64, ovsconfig.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterfaceIngressPolicing", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(ovsconfig.Error)
+	return ret0, ret1, ret2
+}
+
+// GetInterfaceIngressPolicing indicates an expected call of GetInterfaceIngressPolicing
+func (mr *MockOVSBridgeClientMockRecorder) GetInterfaceIngressPolicing(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterfaceIngressPolicing", reflect.TypeOf((*MockOVSBridgeClient)(nil).GetInterfaceIngressPolicing), arg0)
+}
+
 // GetOFPort mocks base method
 func (m *MockOVSBridgeClient) GetOFPort(arg0 string) (int32, ovsconfig.Error) {
 	m.ctrl.T.Helper()
@@ -311,6 +342,22 @@ func (mr *MockOVSBridgeClientMockRecorder) GetPortList() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPortList", reflect.TypeOf((*MockOVSBridgeClient)(nil).GetPortList))
 }
 
+// GetPortQoS mocks base method
+func (m *MockOVSBridgeClient) GetPortQoS(arg0 string) (int64, int64, ovsconfig.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPortQoS", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(ovsconfig.Error)
+	return ret0, ret1, ret2
+}
+
+// GetPortQoS indicates an expected call of GetPortQoS
+func (mr *MockOVSBridgeClientMockRecorder) GetPortQoS(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPortQoS", reflect.TypeOf((*MockOVSBridgeClient)(nil).GetPortQoS), arg0)
+}
+
 // SetDatapathID mocks base method
 func (m *MockOVSBridgeClient) SetDatapathID(arg0 string) ovsconfig.Error {
 	m.ctrl.T.Helper()
@@ -339,6 +386,20 @@ func (mr *MockOVSBridgeClientMockRecorder) SetExternalIDs(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExternalIDs", reflect.TypeOf((*MockOVSBridgeClient)(nil).SetExternalIDs), arg0)
 }
 
+// SetInterfaceIngressPolicing mocks base method
+func (m *MockOVSBridgeClient) SetInterfaceIngressPolicing(arg0 string, arg1, arg2 int64) ovsconfig.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetInterfaceIngressPolicing", arg0, arg1, arg2)
+	ret0, _ := ret[0].(ovsconfig.Error)
+	return ret0
+}
+
+// SetInterfaceIngressPolicing indicates an expected call of SetInterfaceIngressPolicing
+func (mr *MockOVSBridgeClientMockRecorder) SetInterfaceIngressPolicing(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInterfaceIngressPolicing", reflect.TypeOf((*MockOVSBridgeClient)(nil).SetInterfaceIngressPolicing), arg0, arg1, arg2)
+}
+
 // SetInterfaceMTU mocks base method
 func (m *MockOVSBridgeClient) SetInterfaceMTU(arg0 string, arg1 int) error {
 	m.ctrl.T.Helper()
@@ -352,3 +413,45 @@ func (mr *MockOVSBridgeClientMockRecorder) SetInterfaceMTU(arg0, arg1 interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInterfaceMTU", reflect.TypeOf((*MockOVSBridgeClient)(nil).SetInterfaceMTU), arg0, arg1)
 }
+
+// SetPortQoS mocks base method
+func (m *MockOVSBridgeClient) SetPortQoS(arg0 string, arg1, arg2 int64) ovsconfig.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPortQoS", arg0, arg1, arg2)
+	ret0, _ := ret[0].(ovsconfig.Error)
+	return ret0
+}
+
+// SetPortQoS indicates an expected call of SetPortQoS
+func (mr *MockOVSBridgeClientMockRecorder) SetPortQoS(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPortQoS", reflect.TypeOf((*MockOVSBridgeClient)(nil).SetPortQoS), arg0, arg1, arg2)
+}
+
+// SetTunnelInterfaceOptions mocks base method
+func (m *MockOVSBridgeClient) SetTunnelInterfaceOptions(arg0, arg1, arg2, arg3, arg4 string) ovsconfig.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTunnelInterfaceOptions", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(ovsconfig.Error)
+	return ret0
+}
+
+// SetTunnelInterfaceOptions indicates an expected call of SetTunnelInterfaceOptions
+func (mr *MockOVSBridgeClientMockRecorder) SetTunnelInterfaceOptions(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTunnelInterfaceOptions", reflect.TypeOf((*MockOVSBridgeClient)(nil).SetTunnelInterfaceOptions), arg0, arg1, arg2, arg3, arg4)
+}
+
+// UpdateOVSOtherConfig mocks base method
+func (m *MockOVSBridgeClient) UpdateOVSOtherConfig(arg0 map[string]interface{}) ovsconfig.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOVSOtherConfig", arg0)
+	ret0, _ := ret[0].(ovsconfig.Error)
+	return ret0
+}
+
+// UpdateOVSOtherConfig indicates an expected call of UpdateOVSOtherConfig
+func (mr *MockOVSBridgeClientMockRecorder) UpdateOVSOtherConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOVSOtherConfig", reflect.TypeOf((*MockOVSBridgeClient)(nil).UpdateOVSOtherConfig), arg0)
+}
@@ -15,6 +15,7 @@
 package ovsctl
 
 import (
+	"bufio"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -124,18 +125,39 @@ func (c *ovsCtlClient) Trace(req *TracingRequest) (string, error) {
 }
 
 func (c *ovsCtlClient) runTracing(flow string) (string, error) {
-	out, execErr := c.runAppctlCmd("ofproto/trace", flow)
+	out, execErr := c.runAppctlCmd("ofproto/trace", true, flow)
 	if execErr != nil {
 		return "", execErr
 	}
 	return string(out), nil
 }
 
-func (c *ovsCtlClient) runAppctlCmd(cmd string, args ...string) ([]byte, *ExecError) {
+func (c *ovsCtlClient) DumpConntrack(zone uint16) ([]string, error) {
+	// The dpctl commands apply to the datapath instead of the bridge, and the datapath can be
+	// omitted as there is a single one per datapath type. "-m" and "-s" add the zone and the
+	// packet and byte counters of the connections to the output.
+	out, execErr := c.runAppctlCmd("dpctl/dump-conntrack", false, "-m", "-s", fmt.Sprintf("zone=%d", zone))
+	if execErr != nil {
+		return nil, execErr
+	}
+	var connections []string
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			connections = append(connections, line)
+		}
+	}
+	return connections, nil
+}
+
+func (c *ovsCtlClient) runAppctlCmd(cmd string, needsBridge bool, args ...string) ([]byte, *ExecError) {
 	// Use the control UNIX domain socket to connect to ovs-vswitchd, as Agent can
 	// run in a different PID namespace from ovs-vswitchd, and so might not be able
 	// to reach ovs-vswitchd using the PID.
-	cmdStr := fmt.Sprintf("ovs-appctl -t %s %s %s", ovsVSwitchdUDS, cmd, c.bridge)
+	cmdStr := fmt.Sprintf("ovs-appctl -t %s %s", ovsVSwitchdUDS, cmd)
+	if needsBridge {
+		cmdStr = cmdStr + " " + c.bridge
+	}
 	cmdStr = cmdStr + " " + strings.Join(args, " ")
 	out, err := exec.Command("/bin/sh", "-c", cmdStr).CombinedOutput()
 	if err != nil {
@@ -37,6 +37,9 @@ type OVSCtlClient interface {
 	DumpFlows(args ...string) ([]string, error)
 	// DumpMatchedFlows returns the flow which exactly matches the matchStr.
 	DumpMatchedFlow(matchStr string) (string, error)
+	// DumpFlowMatchSet returns the match conditions of all the flows of the bridge, so that
+	// many flows can be looked up with a single dump.
+	DumpFlowMatchSet() (FlowMatchSet, error)
 	// DumpTableFlows returns all flows in the table.
 	DumpTableFlows(table uint8) ([]string, error)
 	// DumpGroups returns OpenFlow groups of the bridge.
@@ -45,6 +48,9 @@ type OVSCtlClient interface {
 	RunOfctlCmd(cmd string, args ...string) ([]byte, error)
 	// Trace executes "ovs-appctl ofproto/trace" to perform OVS packet tracing.
 	Trace(req *TracingRequest) (string, error)
+	// DumpConntrack executes "ovs-appctl dpctl/dump-conntrack" to return the connections of
+	// the provided conntrack zone, with their statistics.
+	DumpConntrack(zone uint16) ([]string, error)
 }
 
 type BadRequestError string
@@ -18,9 +18,30 @@ import (
 	"bufio"
 	"fmt"
 	"os/exec"
+	"sort"
 	"strings"
 )
 
+// FlowMatchSet is a set of the match conditions of flows dumped from the bridge, including their
+// tables but not their priorities.
+type FlowMatchSet map[string]struct{}
+
+// NewFlowMatchSet returns a FlowMatchSet including the flows matching the provided match strings.
+func NewFlowMatchSet(matchStrs ...string) FlowMatchSet {
+	s := FlowMatchSet{}
+	for _, matchStr := range matchStrs {
+		s[sortedMatchKey(strings.Split(matchStr, ","))] = struct{}{}
+	}
+	return s
+}
+
+// Has returns whether the set includes a flow which exactly matches matchStr, e.g.
+// "table=10,arp,in_port=5,arp_spa=10.10.0.2". The order of the match conditions doesn't matter.
+func (s FlowMatchSet) Has(matchStr string) bool {
+	_, ok := s[sortedMatchKey(strings.Split(matchStr, ","))]
+	return ok
+}
+
 func (c *ovsCtlClient) DumpFlows(args ...string) ([]string, error) {
 	// Print table and port names.
 	flowDump, err := c.RunOfctlCmd("dump-flows", append(args, "--names")...)
@@ -59,6 +80,25 @@ func (c *ovsCtlClient) DumpMatchedFlow(matchStr string) (string, error) {
 	return "", nil
 }
 
+func (c *ovsCtlClient) DumpFlowMatchSet() (FlowMatchSet, error) {
+	// Print port numbers, as the match strings of the flows use them.
+	flowDump, err := c.RunOfctlCmd("dump-flows", "--no-names")
+	if err != nil {
+		return nil, err
+	}
+	flows := FlowMatchSet{}
+	scanner := bufio.NewScanner(strings.NewReader(string(flowDump)))
+	scanner.Split(bufio.ScanLines)
+	for scanner.Scan() {
+		// Skip the header of the reply.
+		if !strings.Contains(scanner.Text(), " table=") {
+			continue
+		}
+		flows[flowMatchKey(scanner.Text())] = struct{}{}
+	}
+	return flows, nil
+}
+
 func (c *ovsCtlClient) DumpTableFlows(table uint8) ([]string, error) {
 	return c.DumpFlows(fmt.Sprintf("table=%d", table))
 }
@@ -123,3 +163,31 @@ func flowExactMatch(matchStr, flowStr string) bool {
 	}
 	return true
 }
+
+// flowMatchKey returns the table and the match conditions of a flow printed by ovs-ofctl, without
+// its priority, as they are keyed in FlowMatchSet.
+func flowMatchKey(flowStr string) string {
+	if i := strings.Index(flowStr, " actions="); i >= 0 {
+		flowStr = flowStr[:i]
+	}
+	var matches []string
+	for _, field := range strings.Split(strings.TrimSpace(flowStr), ", ") {
+		if strings.HasPrefix(field, "table=") {
+			matches = append(matches, field)
+		}
+	}
+	// The match conditions are printed after the statistics, without any space.
+	for _, m := range strings.Split(flowStr[strings.LastIndexByte(flowStr, ' ')+1:], ",") {
+		if !strings.HasPrefix(m, "priority=") {
+			matches = append(matches, m)
+		}
+	}
+	return sortedMatchKey(matches)
+}
+
+// sortedMatchKey joins the provided match conditions in a canonical order.
+func sortedMatchKey(matches []string) string {
+	sorted := append([]string(nil), matches...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
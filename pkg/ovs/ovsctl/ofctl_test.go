@@ -0,0 +1,46 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovsctl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlowMatchSet(t *testing.T) {
+	flowDump := []string{
+		" cookie=0x1020000000000, duration=100.2s, table=0, n_packets=10, n_bytes=820, idle_age=5, priority=190,in_port=3 actions=load:0x2->NXM_NX_REG0[0..15],resubmit(,10)",
+		" cookie=0x1020000000000, duration=100.2s, table=10, n_packets=0, n_bytes=0, idle_age=100, priority=200,ip,in_port=3,dl_src=aa:bb:cc:dd:ee:ff,nw_src=10.1.2.100 actions=resubmit(,29)",
+		" cookie=0x1000000000000, duration=120.5s, table=10, n_packets=0, n_bytes=0, idle_age=120, priority=0 actions=drop",
+	}
+	flows := FlowMatchSet{}
+	for _, flowStr := range flowDump {
+		flows[flowMatchKey(flowStr)] = struct{}{}
+	}
+	assert.True(t, flows.Has("table=0,in_port=3"))
+	assert.True(t, flows.Has("table=10,ip,in_port=3,dl_src=aa:bb:cc:dd:ee:ff,nw_src=10.1.2.100"))
+	assert.True(t, flows.Has("table=10,in_port=3,ip,nw_src=10.1.2.100,dl_src=aa:bb:cc:dd:ee:ff"))
+	assert.True(t, flows.Has("table=10"))
+	assert.False(t, flows.Has("table=0,in_port=4"))
+	assert.False(t, flows.Has("table=10,in_port=3"))
+	assert.False(t, flows.Has("table=10,ip,in_port=3,dl_src=aa:bb:cc:dd:ee:ff"))
+
+	assert.Equal(t, flows, NewFlowMatchSet(
+		"table=0,in_port=3",
+		"table=10,ip,in_port=3,dl_src=aa:bb:cc:dd:ee:ff,nw_src=10.1.2.100",
+		"table=10",
+	))
+}
@@ -48,6 +48,36 @@ func (m *MockOVSCtlClient) EXPECT() *MockOVSCtlClientMockRecorder {
 	return m.recorder
 }
 
+// DumpConntrack mocks base method
+func (m *MockOVSCtlClient) DumpConntrack(arg0 uint16) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DumpConntrack", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DumpConntrack indicates an expected call of DumpConntrack
+func (mr *MockOVSCtlClientMockRecorder) DumpConntrack(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DumpConntrack", reflect.TypeOf((*MockOVSCtlClient)(nil).DumpConntrack), arg0)
+}
+
+// DumpFlowMatchSet mocks base method
+func (m *MockOVSCtlClient) DumpFlowMatchSet() (ovsctl.FlowMatchSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DumpFlowMatchSet")
+	ret0, _ := ret[0].(ovsctl.FlowMatchSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DumpFlowMatchSet indicates an expected call of DumpFlowMatchSet
+func (mr *MockOVSCtlClientMockRecorder) DumpFlowMatchSet() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DumpFlowMatchSet", reflect.TypeOf((*MockOVSCtlClient)(nil).DumpFlowMatchSet))
+}
+
 // DumpFlows mocks base method
 func (m *MockOVSCtlClient) DumpFlows(arg0 ...string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -23,8 +23,9 @@ import (
 
 // nodeNameEnvKey is environment variable.
 const (
-	nodeNameEnvKey = "NODE_NAME"
-	podNameEnvKey  = "POD_NAME"
+	nodeNameEnvKey     = "NODE_NAME"
+	podNameEnvKey      = "POD_NAME"
+	podNamespaceEnvKey = "POD_NAMESPACE"
 
 	antreaCloudEKSEnvKey = "ANTREA_CLOUD_EKS"
 )
@@ -56,6 +57,15 @@ func GetPodName() string {
 	return podName
 }
 
+// GetPodNamespace returns the namespace of the pod where the code executes
+func GetPodNamespace() string {
+	podNamespace := os.Getenv(podNamespaceEnvKey)
+	if podNamespace == "" {
+		klog.Warningf("Environment variable %s not found", podNamespaceEnvKey)
+	}
+	return podNamespace
+}
+
 func getBoolEnvVar(name string, defaultValue bool) bool {
 	if strValue := os.Getenv(name); strValue != "" {
 		parsedValue, err := strconv.ParseBool(strValue)
@@ -74,3 +74,11 @@ func comparePodName(k, v string, t *testing.T) {
 		t.Errorf("Failed to retrieve pod name, want: %s, get: %s", v, podName)
 	}
 }
+
+func TestGetPodNamespace(t *testing.T) {
+	_ = os.Setenv(podNamespaceEnvKey, "kube-system")
+	defer os.Unsetenv(podNamespaceEnvKey)
+	if podNamespace := GetPodNamespace(); podNamespace != "kube-system" {
+		t.Errorf("Failed to retrieve pod namespace, want: kube-system, get: %s", podNamespace)
+	}
+}
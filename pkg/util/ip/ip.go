@@ -29,18 +29,16 @@ const (
 )
 
 // This function takes in one allow CIDR and multiple except CIDRs and gives diff CIDRs
-// in allowCIDR eliminating except CIDRs. It currently supports only IPv4. except CIDR input
-// can be changed.
+// in allowCIDR eliminating except CIDRs. It supports both IPv4 and IPv6, but all the CIDRs
+// must be of the same IP family. except CIDR input can be changed.
 func DiffFromCIDRs(allowCIDR *net.IPNet, exceptCIDRs []*net.IPNet) ([]*net.IPNet, error) {
-	if allowCIDR.IP.To4() == nil {
-		return nil, fmt.Errorf("allowCIDR IP type is not v4")
-	}
+	isIPv4 := allowCIDR.IP.To4() != nil
 	// Remove the redundant CIDRs
 	exceptCIDRs = mergeCIDRs(exceptCIDRs)
 	newCIDRs := []*net.IPNet{allowCIDR}
 	for _, exceptCIDR := range exceptCIDRs {
-		if exceptCIDR.IP.To4() == nil {
-			return nil, fmt.Errorf("exceptCIDR IP type is not v4")
+		if (exceptCIDR.IP.To4() != nil) != isIPv4 {
+			return nil, fmt.Errorf("exceptCIDR %s is not of the same IP family as allowCIDR %s", exceptCIDR, allowCIDR)
 		}
 	beginLoop:
 		for i, indCIDR := range newCIDRs {
@@ -70,7 +68,7 @@ func DiffFromCIDRs(allowCIDR *net.IPNet, exceptCIDRs []*net.IPNet) ([]*net.IPNet
 // This function gives diff CIDRs between a superset CIDR (allow CIDR) and subset CIDR
 // (except CIDR)
 func diffFromCIDR(allowCIDR, exceptCIDR *net.IPNet) []*net.IPNet {
-	allowPrefix, _ := allowCIDR.Mask.Size()
+	allowPrefix, bitLen := allowCIDR.Mask.Size()
 	exceptPrefix, _ := exceptCIDR.Mask.Size()
 
 	// Mask the IP to get the start IP of range
@@ -83,8 +81,8 @@ func diffFromCIDR(allowCIDR, exceptCIDR *net.IPNet) []*net.IPNet {
 	remainingCIDRs := make([]*net.IPNet, 0, exceptPrefix-allowPrefix)
 	for i := allowPrefix + 1; i <= exceptPrefix; i++ {
 		// Flip the (ipBitLen - i)th bit from LSB in exceptCIDR to get the IP which is not in exceptCIDR
-		ipOfNewCIDR := flipSingleBit(&exceptStartIP, uint8(v4BitLen-i))
-		newCIDRMask := net.CIDRMask(i, v4BitLen)
+		ipOfNewCIDR := flipSingleBit(&exceptStartIP, uint8(bitLen-i))
+		newCIDRMask := net.CIDRMask(i, bitLen)
 		for j := range allowStartIP {
 			ipOfNewCIDR[j] = allowStartIP[j] | ipOfNewCIDR[j]
 		}
@@ -146,3 +144,23 @@ func NetIPNetToIPNet(ipNet *net.IPNet) *v1beta1.IPNet {
 	prefix, _ := ipNet.Mask.Size()
 	return &v1beta1.IPNet{IP: v1beta1.IPAddress(ipNet.IP), PrefixLength: int32(prefix)}
 }
+
+// GetIPv4Addr returns the first IPv4 address in the provided list, or nil if there is none.
+func GetIPv4Addr(ips []net.IP) net.IP {
+	for _, ip := range ips {
+		if ip.To4() != nil {
+			return ip
+		}
+	}
+	return nil
+}
+
+// GetIPv6Addr returns the first IPv6 address in the provided list, or nil if there is none.
+func GetIPv6Addr(ips []net.IP) net.IP {
+	for _, ip := range ips {
+		if ip.To4() == nil && ip.To16() != nil {
+			return ip
+		}
+	}
+	return nil
+}
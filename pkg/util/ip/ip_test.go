@@ -68,6 +68,18 @@ func TestDiffCIDRs(t *testing.T) {
 		assert.ElementsMatch(t, correctList2, diffCIDRs)
 	}
 
+	exceptList3 := []*net.IPNet{newCIDR("fd00:10:20::/50")}
+	correctList3 := []*net.IPNet{newCIDR("fd00:10:20:8000::/49"),
+		newCIDR("fd00:10:20:4000::/50")}
+	diffCIDRs, err = DiffFromCIDRs(newCIDR("fd00:10:20::/48"), exceptList3)
+	if err != nil {
+		t.Fatalf("diffFromCIDRs() error = %v", err)
+	} else {
+		assert.ElementsMatch(t, correctList3, diffCIDRs)
+	}
+
+	_, err = DiffFromCIDRs(testList[0], exceptList3)
+	assert.Error(t, err)
 }
 
 func TestMergeCIDRs(t *testing.T) {
@@ -108,3 +120,14 @@ func TestMergeCIDRs(t *testing.T) {
 	ipNetList4 = mergeCIDRs(ipNetList4)
 	assert.ElementsMatch(t, correctList4, ipNetList4)
 }
+
+func TestGetIPAddrByFamily(t *testing.T) {
+	v4 := net.ParseIP("10.10.0.1")
+	v6 := net.ParseIP("fd00:10:10::1")
+
+	assert.Equal(t, v4, GetIPv4Addr([]net.IP{v6, v4}))
+	assert.Equal(t, v6, GetIPv6Addr([]net.IP{v4, v6}))
+	assert.Nil(t, GetIPv4Addr([]net.IP{v6}))
+	assert.Nil(t, GetIPv6Addr([]net.IP{v4}))
+	assert.Nil(t, GetIPv4Addr(nil))
+}
@@ -531,7 +531,7 @@ func newTester() *cmdAddDelTester {
 		make(chan v1beta1.PodReference, 100),
 		false,
 		nil)
-	tester.server.Initialize(ovsServiceMock, ofServiceMock, ifaceStore, "")
+	tester.server.Initialize(ovsServiceMock, ofServiceMock, nil, ifaceStore, "")
 	ctx, _ := context.WithCancel(context.Background())
 	tester.ctx = ctx
 	return tester
@@ -585,6 +585,8 @@ func cmdAddDelCheckTest(testNS ns.NetNS, tc testCase, dataDir string) {
 	testRequire.Nil(err)
 
 	// Test CHECK
+	ovsServiceMock.EXPECT().GetInterfaceIngressPolicing(ovsPortname).Return(int64(0), int64(0), nil).AnyTimes()
+	ovsServiceMock.EXPECT().GetPortQoS(ovsPortUUID).Return(int64(0), int64(0), nil).AnyTimes()
 	tester.cmdCheckTest(tc, newConf, dataDir)
 
 	// Test delete
@@ -727,7 +729,7 @@ func TestCNIServerChaining(t *testing.T) {
 			ovsServiceMock = ovsconfigtest.NewMockOVSBridgeClient(controller)
 			ofServiceMock = openflowtest.NewMockClient(controller)
 			ifaceStore := interfacestore.NewInterfaceStore()
-			err = server.Initialize(ovsServiceMock, ofServiceMock, ifaceStore, "")
+			err = server.Initialize(ovsServiceMock, ofServiceMock, nil, ifaceStore, "")
 			testRequire.Nil(err)
 		}
 
@@ -756,7 +758,7 @@ func TestCNIServerChaining(t *testing.T) {
 			routeMock.EXPECT().MigrateRoutesToGw(hostVeth.Name),
 			ovsServiceMock.EXPECT().CreatePort(ovsPortname, ovsPortname, mock.Any()).Return(ovsPortUUID, nil),
 			ovsServiceMock.EXPECT().GetOFPort(ovsPortname).Return(testContainerOFPort, nil),
-			ofServiceMock.EXPECT().InstallPodFlows(ovsPortname, podIP, containerIntf.HardwareAddr, gwMAC, mock.Any()),
+			ofServiceMock.EXPECT().InstallPodFlows(ovsPortname, []net.IP{podIP}, containerIntf.HardwareAddr, gwMAC, mock.Any()),
 		)
 		mock.InOrder(orderedCalls...)
 		cniResp, err := server.CmdAdd(ctx, cniReq)
@@ -787,8 +789,8 @@ func init() {
 	nodeName := "node1"
 	gwIP := net.ParseIP("192.168.1.1")
 	gwMAC, _ = net.ParseMAC("11:11:11:11:11:11")
-	nodeGateway := &config.GatewayConfig{IP: gwIP, MAC: gwMAC, Name: ""}
+	nodeGateway := &config.GatewayConfig{IPv4: gwIP, MAC: gwMAC, Name: ""}
 	_, nodePodCIDR, _ := net.ParseCIDR("192.168.1.0/24")
 
-	testNodeConfig = &config.NodeConfig{Name: nodeName, PodCIDR: nodePodCIDR, GatewayConfig: nodeGateway}
+	testNodeConfig = &config.NodeConfig{Name: nodeName, PodIPv4CIDR: nodePodCIDR, GatewayConfig: nodeGateway}
 }
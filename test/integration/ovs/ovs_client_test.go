@@ -203,6 +203,52 @@ func TestOVSOtherConfig(t *testing.T) {
 	gotOtherConfigs, err = data.br.GetOVSOtherConfig()
 	require.Nil(t, err, "Error when getting OVS other_config")
 	require.Equal(t, map[string]string{"foo1": "bar1", "foo2": "bar2"}, gotOtherConfigs, "other_config mismatched")
+
+	// Expect the value of existing configs to be replaced.
+	err = data.br.UpdateOVSOtherConfig(map[string]interface{}{"foo1": "bar3", "foo3": "bar3"})
+	require.Nil(t, err, "Error when updating OVS other_config")
+
+	gotOtherConfigs, err = data.br.GetOVSOtherConfig()
+	require.Nil(t, err, "Error when getting OVS other_config")
+	require.Equal(t, map[string]string{"foo1": "bar3", "foo2": "bar2", "foo3": "bar3"}, gotOtherConfigs, "other_config mismatched")
+}
+
+// TestTunnelInterfaceOptions verifies that the options of a tunnel interface
+// can be updated without changing its ofport.
+func TestTunnelInterfaceOptions(t *testing.T) {
+	data := &testData{}
+	data.setup(t)
+	defer data.teardown(t)
+
+	deleteAllPorts(t, data.br)
+
+	name := "ipsec1"
+	uuid, err := data.br.CreateTunnelPortExt(name, ovsconfig.GRETunnel, 0, "", "1.1.1.1", "changeme", "", nil)
+	require.Nil(t, err, "Error when creating tunnel port")
+	ofPort, err := data.br.GetOFPort(name)
+	require.Nil(t, err, "Error when getting ofport")
+
+	portData, err := data.br.GetPortData(uuid, name)
+	require.Nil(t, err, "Error when getting port data")
+	remoteIP, _, psk, remoteName := ovsconfig.ParseTunnelInterfaceOptions(portData)
+	assert.Equal(t, "1.1.1.1", remoteIP.String())
+	assert.Equal(t, "changeme", psk)
+	assert.Empty(t, remoteName)
+
+	err = data.br.SetTunnelInterfaceOptions(name, "", "1.1.1.2", "", "node2")
+	require.Nil(t, err, "Error when updating tunnel options")
+
+	portData, err = data.br.GetPortData(uuid, name)
+	require.Nil(t, err, "Error when getting port data")
+	remoteIP, _, psk, remoteName = ovsconfig.ParseTunnelInterfaceOptions(portData)
+	assert.Equal(t, "1.1.1.2", remoteIP.String())
+	assert.Empty(t, psk)
+	assert.Equal(t, "node2", remoteName)
+	newOFPort, err := data.br.GetOFPort(name)
+	require.Nil(t, err, "Error when getting ofport")
+	assert.Equal(t, ofPort, newOFPort)
+
+	testDeletePort(t, data.br, uuid)
 }
 
 func deleteAllPorts(t *testing.T, br *ovsconfig.OVSBridge) {